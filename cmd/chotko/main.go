@@ -2,16 +2,25 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"time"
+	_ "time/tzdata" // Embed timezone database for display.timezone on systems without one
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	zone "github.com/lrstanley/bubblezone"
+	"github.com/muesli/termenv"
 	flag "github.com/spf13/pflag"
 
 	"github.com/harpchad/chotko/internal/app"
 	"github.com/harpchad/chotko/internal/config"
+	"github.com/harpchad/chotko/internal/demo"
+	"github.com/harpchad/chotko/internal/recording"
+	"github.com/harpchad/chotko/internal/session"
 	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
 )
 
 var (
@@ -21,6 +30,24 @@ var (
 )
 
 func main() {
+	// Subcommands for theme authors
+	if len(os.Args) > 1 && os.Args[1] == "theme" {
+		if err := runThemeCommand(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// One-line summary for status bars such as tmux's
+	if len(os.Args) > 1 && os.Args[1] == "status" {
+		if err := runStatusCommand(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Command line flags
 	var (
 		configPath  string
@@ -31,6 +58,13 @@ func main() {
 		themeName   string
 		refresh     int
 		minSeverity int
+		debug       bool
+		noColor     bool
+		reader      bool
+		demoMode    bool
+		kiosk       bool
+		recordPath  string
+		replayPath  string
 		showVersion bool
 		showHelp    bool
 	)
@@ -43,6 +77,13 @@ func main() {
 	flag.StringVar(&themeName, "theme", "", "Theme name")
 	flag.IntVarP(&refresh, "refresh", "r", 0, "Refresh interval in seconds")
 	flag.IntVar(&minSeverity, "min-severity", -1, "Minimum severity (0-5)")
+	flag.BoolVar(&debug, "debug", false, "Log API calls to debug.log in the config directory")
+	flag.BoolVar(&noColor, "no-color", false, "Show severity and status without color")
+	flag.BoolVar(&reader, "screen-reader", false, "Plain linear output for screen readers")
+	flag.BoolVar(&demoMode, "demo", false, "Run against a simulated Zabbix server")
+	flag.BoolVar(&kiosk, "kiosk", false, "Unattended wallboard: rotate tabs, ignore input except quit")
+	flag.StringVar(&recordPath, "record", "", "Save all API responses to a file")
+	flag.StringVar(&replayPath, "replay", "", "Serve API responses from a recorded file")
 	flag.BoolVarP(&showVersion, "version", "v", false, "Show version")
 	flag.BoolVarP(&showHelp, "help", "h", false, "Show help")
 
@@ -69,8 +110,37 @@ func main() {
 		os.Exit(0)
 	}
 
+	if replayPath != "" && (demoMode || recordPath != "") {
+		fmt.Fprintln(os.Stderr, "Error: --replay cannot be combined with --demo or --record")
+		os.Exit(1)
+	}
+
+	// Demo and replay modes don't need a server, so the wizard is not run
+	var clientOptions []zabbix.ClientOption
+	offline := demoMode || replayPath != ""
+	switch {
+	case demoMode:
+		clientOptions = append(clientOptions, zabbix.WithTransport(demo.New(uint64(time.Now().UnixNano()))))
+	case replayPath != "":
+		player, err := recording.Load(replayPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		clientOptions = append(clientOptions, zabbix.WithTransport(player))
+	}
+
 	// Load or create configuration
-	cfg, err := loadConfig(configPath)
+	var (
+		cfg      *config.Config
+		firstRun bool
+		err      error
+	)
+	if offline {
+		cfg = loadOfflineConfig(configPath)
+	} else {
+		cfg, firstRun, err = loadConfig(configPath)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -101,6 +171,37 @@ func main() {
 	if minSeverity >= 0 {
 		cfg.Display.MinSeverity = minSeverity
 	}
+	if noColor {
+		cfg.Display.NoColor = true
+	}
+	if reader {
+		cfg.Display.ScreenReader = true
+	}
+	if offline {
+		cfg.Server.URL = demo.URL
+		if replayPath != "" {
+			cfg.Server.URL = recording.URL
+		}
+		cfg.Auth.Token = "offline"
+		cfg.Auth.Username = ""
+		cfg.Auth.Password = ""
+	}
+
+	if cfg.Server.CompressRequests && !offline {
+		clientOptions = append(clientOptions, zabbix.WithRequestCompression())
+	}
+
+	// Record API traffic for bug reports
+	var recordFile *os.File
+	var recorder *recording.Recorder
+	if recordPath != "" {
+		if recordFile, err = os.Create(recordPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to create recording: %v\n", err)
+			os.Exit(1)
+		}
+		recorder = recording.NewRecorder(recordFile)
+		clientOptions = append(clientOptions, zabbix.WrapTransport(recorder.Wrap))
+	}
 
 	// Validate configuration
 	if err = cfg.Validate(); err != nil {
@@ -108,55 +209,117 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Load theme
-	t, err := theme.Load(cfg.Display.Theme, config.Dir())
+	// Load theme, picking the dark or light variant for "auto" themes.
+	// The background must be queried before the program takes over the terminal.
+	themeName = cfg.Display.Theme
+	dark := true
+	if theme.IsAuto(themeName) {
+		dark = lipgloss.HasDarkBackground()
+		themeName = theme.Resolve(themeName, dark)
+	}
+	t, err := theme.Load(themeName, config.Dir())
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: Could not load theme '%s', using default: %v\n", cfg.Display.Theme, err)
+		fmt.Fprintf(os.Stderr, "Warning: Could not load theme '%s', using default: %v\n", themeName, err)
 		t = theme.DefaultTheme()
 	}
 
+	if cfg.GetNoColor() {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+
 	// Initialize mouse zone manager for click detection
 	zone.NewGlobal()
 
 	// Create and run the application
 	model := app.New(cfg, t)
-	p := tea.NewProgram(
-		model,
-		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
-	)
+	model.SetDarkBackground(dark)
+	if configPath == "" {
+		configPath = config.Path()
+	}
+	model.SetClientOptions(clientOptions...)
+	model.SetKiosk(kiosk)
+	if !offline {
+		if err := model.WatchConfig(configPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: config changes will not be reloaded: %v\n", err)
+		}
+	}
+	if !offline && !kiosk {
+		// Pick up where the last run left off; a kiosk always starts afresh
+		// and leaves the interactive session alone
+		state, err := session.Load(config.Dir())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: previous session not restored: %v\n", err)
+		}
+		if minSeverity >= 0 {
+			state.MinSeverity = nil // The flag wins
+		}
+		model.RestoreSession(state)
+	}
+	if firstRun && !kiosk {
+		model.StartTutorial()
+	}
+	if debug {
+		if err := model.SetDebug(true); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+	options := []tea.ProgramOption{tea.WithAltScreen()}
+	if !kiosk {
+		options = append(options, tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(model, options...)
 
-	if _, err := p.Run(); err != nil {
+	_, err = p.Run()
+	if recordFile != nil {
+		if rerr := errors.Join(recorder.Err(), recordFile.Close()); rerr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: recording is incomplete: %v\n", rerr)
+		}
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
 // loadConfig loads configuration from file or runs the setup wizard.
-func loadConfig(path string) (*config.Config, error) {
+// firstRun reports whether the wizard created the configuration.
+func loadConfig(path string) (cfg *config.Config, firstRun bool, err error) {
 	// Use specified path or default
 	if path == "" {
 		path = config.Path()
 	}
 
 	// Try to load existing config
-	cfg, err := config.LoadFromFile(path)
+	cfg, err = config.LoadFromFile(path)
 	if err == nil {
-		return cfg, nil
+		return cfg, false, nil
 	}
 
 	// Config doesn't exist - prompt for wizard
 	if !config.PromptForConfig() {
-		return nil, fmt.Errorf("configuration required. Run with --help for options")
+		return nil, false, fmt.Errorf("configuration required. Run with --help for options")
 	}
 
 	// Run setup wizard
 	cfg, err = config.RunWizard()
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	return cfg, nil
+	return cfg, true, nil
+}
+
+// loadOfflineConfig loads the display settings for demo and replay modes
+// from the config file if there is one, so that themes can be tried out, and
+// uses the defaults otherwise. The wizard is never run.
+func loadOfflineConfig(path string) *config.Config {
+	if path == "" {
+		path = config.Path()
+	}
+	if cfg, err := config.LoadFromFile(path); err == nil {
+		return cfg
+	}
+	return config.DefaultConfig()
 }
 
 func printUsage() {
@@ -164,6 +327,8 @@ func printUsage() {
 
 Usage:
   chotko [flags]
+  chotko theme list|preview NAME|export NAME [FILE]
+  chotko status [--tmux] [--min-severity N] [--max-age DURATION]
 
 Flags:
   -c, --config string     Path to config file (default ~/.config/chotko/config.yaml)
@@ -174,6 +339,13 @@ Flags:
       --theme string      Theme name (default "nord")
   -r, --refresh int       Refresh interval in seconds (default 30)
       --min-severity int  Minimum severity to display (0-5)
+      --debug             Log API calls to ~/.config/chotko/debug.log (view with :log)
+      --no-color          Show severity and status as text labels instead of color
+      --screen-reader     Plain, linear output without box drawing for screen readers
+      --demo              Run against a simulated Zabbix server with random data
+      --kiosk             Wallboard mode: full-screen list, rotating tabs, only q quits
+      --record string     Save all API responses to a file (passwords are redacted)
+      --replay string     Serve API responses from a file saved with --record
   -h, --help              Show this help
   -v, --version           Show version
 
@@ -181,6 +353,7 @@ Environment Variables:
   CHOTKO_SERVER    Zabbix server URL
   CHOTKO_TOKEN     API token (recommended over CLI flag)
   CHOTKO_PASSWORD  Password (recommended over CLI flag)
+  NO_COLOR         Same as --no-color when set
 
 Examples:
   # Run with config file (or setup wizard if none exists)
@@ -202,13 +375,34 @@ Examples:
   # Show only high severity alerts
   chotko --min-severity 4
 
+  # Try chotko (or a theme) without a Zabbix server
+  chotko --demo --theme dracula
+
+  # Start a custom theme from a built-in one
+  chotko theme preview nord
+  chotko theme export nord ~/.config/chotko/themes/mynord.yaml
+
+  # Show the problems in tmux's status bar (in ~/.tmux.conf)
+  set -g status-right '#(chotko status --tmux)'
+
+  # Unattended NOC screen rotating through the tabs set under kiosk: in the config
+  chotko --kiosk
+
+  # Record a session for a bug report, then replay it without the server
+  chotko --record session.jsonl
+  chotko --replay session.jsonl
+
 Available Themes:
   default, nord, dracula, gruvbox, catppuccin, tokyonight, solarized
+  Light variants: add "-light" (e.g. nord-light)
+  auto, or e.g. nord-auto: dark or light variant to match the terminal
 
 Key Bindings (press ? in app for full list):
   j/k, ↑/↓    Navigate alerts
   Tab         Switch panes
   a           Acknowledge alert
+  x           Acknowledge and close alert (if the trigger allows)
+  H           Show the selected host's events
   r           Refresh
   /           Filter
   :           Command mode
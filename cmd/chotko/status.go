@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	flag "github.com/spf13/pflag"
+
+	"github.com/harpchad/chotko/internal/config"
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// statusUsage describes the status subcommand.
+const statusUsage = `Usage:
+  chotko status [flags]    Print a one-line summary of the active problems
+
+Flags:
+  -c, --config string       Path to config file (default ~/.config/chotko/config.yaml)
+      --min-severity int    Minimum severity to count (default from config)
+      --max-age duration    Reuse the last summary for this long (default 1m)
+      --tmux                Color the summary with tmux #[fg=...] styles
+
+Example, in ~/.tmux.conf:
+  set -g status-right '#(chotko status --tmux)'`
+
+// statusCache is the last summary, saved to spare the API when a status bar
+// runs "chotko status" every few seconds.
+type statusCache struct {
+	Server      string               `json:"server"`
+	MinSeverity int                  `json:"min_severity"`
+	Time        time.Time            `json:"time"`
+	Counts      zabbix.ProblemCounts `json:"counts"`
+	Err         string               `json:"error,omitempty"` // Failures are cached too
+}
+
+// runStatusCommand runs "chotko status" with the arguments after "status":
+// it prints the counts of the active problems by severity, worst first, or
+// "OK" if there are none.
+func runStatusCommand(args []string, out io.Writer) error {
+	var (
+		configPath  string
+		minSeverity int
+		maxAge      time.Duration
+		tmux        bool
+		showHelp    bool
+	)
+	flags := flag.NewFlagSet("status", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVarP(&configPath, "config", "c", "", "Path to config file")
+	flags.IntVar(&minSeverity, "min-severity", -1, "Minimum severity (0-5)")
+	flags.DurationVar(&maxAge, "max-age", time.Minute, "Reuse the last summary for this long")
+	flags.BoolVar(&tmux, "tmux", false, "Color the summary for tmux")
+	flags.BoolVarP(&showHelp, "help", "h", false, "Show help")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w\n\n%s", err, statusUsage)
+	}
+	if showHelp {
+		_, err := fmt.Fprintln(out, statusUsage)
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q\n\n%s", flags.Arg(0), statusUsage)
+	}
+
+	if configPath == "" {
+		configPath = config.Path()
+	}
+	cfg, err := config.LoadFromFile(configPath)
+	if err != nil {
+		return fmt.Errorf("%w (run chotko once to create it)", err)
+	}
+	if url := os.Getenv("CHOTKO_SERVER"); url != "" {
+		cfg.Server.URL = url
+	}
+	if token := os.Getenv("CHOTKO_TOKEN"); token != "" {
+		cfg.Auth.Token = token
+		cfg.Auth.Username = ""
+		cfg.Auth.Password = ""
+	}
+	if password := os.Getenv("CHOTKO_PASSWORD"); password != "" {
+		cfg.Auth.Password = password
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if minSeverity < 0 {
+		minSeverity = cfg.Display.MinSeverity
+	}
+
+	cachePath := filepath.Join(config.Dir(), "status.json")
+	cache, ok := loadStatusCache(cachePath, cfg.Server.URL, minSeverity, maxAge)
+	if !ok {
+		cache = statusCache{Server: cfg.Server.URL, MinSeverity: minSeverity, Time: time.Now()}
+		counts, err := countProblems(cfg, minSeverity)
+		if err != nil {
+			cache.Err = err.Error()
+		}
+		cache.Counts = counts
+		// A status bar retries on its next refresh anyway
+		_ = saveStatusCache(cachePath, cache)
+	}
+
+	if cache.Err != "" {
+		fmt.Fprintln(out, "Zabbix error")
+		return errors.New(cache.Err)
+	}
+	// Auto themes can't query the background from a status bar; most are dark
+	t, err := theme.Load(theme.Resolve(cfg.Display.Theme, true), config.Dir())
+	if err != nil {
+		t = theme.DefaultTheme()
+	}
+	_, err = fmt.Fprintln(out, formatStatus(cache.Counts, t.Colors, tmux))
+	return err
+}
+
+// countProblems connects to the server as the application does and counts
+// its active problems.
+func countProblems(cfg *config.Config, minSeverity int) (zabbix.ProblemCounts, error) {
+	var options []zabbix.ClientOption
+	if cfg.Server.CompressRequests {
+		options = append(options, zabbix.WithRequestCompression())
+	}
+	if cfg.Auth.Mode == config.AuthModeBasic {
+		options = append(options, zabbix.WithBasicAuth(cfg.Auth.BasicUsername, cfg.Auth.BasicPassword))
+	}
+	client := zabbix.NewClient(cfg.Server.URL, append([]zabbix.ClientOption{
+		zabbix.WithTimeout(cfg.GetTimeout()),
+		zabbix.WithMethodTimeouts(cfg.GetMethodTimeouts()),
+	}, options...)...)
+
+	ctx := context.Background()
+	caps, err := client.Negotiate(ctx)
+	if err != nil {
+		return zabbix.ProblemCounts{}, err
+	}
+	switch {
+	case cfg.UseSession():
+		client.SetToken(zabbix.SessionID(cfg.Auth.SessionID))
+	case cfg.UseToken():
+		if !caps.APITokens {
+			return zabbix.ProblemCounts{}, fmt.Errorf("API tokens require Zabbix 5.4 or later; server version is %s", caps.Version)
+		}
+		client.SetToken(cfg.Auth.Token)
+	default:
+		if err := client.Login(ctx, cfg.Auth.Username, cfg.Auth.Password); err != nil {
+			return zabbix.ProblemCounts{}, err
+		}
+		// Don't leave a session behind on every refresh
+		defer func() { _ = client.Logout(ctx) }()
+	}
+	return client.CountProblems(ctx, minSeverity)
+}
+
+// loadStatusCache returns the cached summary of a server if it is at most
+// maxAge old.
+func loadStatusCache(path, server string, minSeverity int, maxAge time.Duration) (statusCache, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return statusCache{}, false
+	}
+	var cache statusCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return statusCache{}, false
+	}
+	if cache.Server != server || cache.MinSeverity != minSeverity || time.Since(cache.Time) > maxAge {
+		return statusCache{}, false
+	}
+	return cache, true
+}
+
+// saveStatusCache saves the summary for the next runs.
+func saveStatusCache(path string, cache statusCache) error {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	// Written whole then renamed, as status bars may run several at once
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// formatStatus returns the summary line, e.g. "HIGH 1 WARN 3 · 2 unacked",
+// with each severity in its theme color for tmux.
+func formatStatus(counts zabbix.ProblemCounts, colors theme.ColorPalette, tmux bool) string {
+	style := func(color lipgloss.TerminalColor, s string) string {
+		if c, ok := color.(lipgloss.Color); ok && tmux {
+			return "#[fg=" + string(c) + "]" + s + "#[default]"
+		}
+		return s
+	}
+
+	if counts.Total() == 0 {
+		return style(colors.OK, "OK")
+	}
+	var parts []string
+	for s := len(counts.Severities) - 1; s >= 0; s-- {
+		if n := counts.Severities[s]; n > 0 {
+			label := strings.Trim(theme.SeverityLabel(s), "[]")
+			parts = append(parts, style(colors.SeverityColor(s), fmt.Sprintf("%s %d", label, n)))
+		}
+	}
+	line := strings.Join(parts, " ")
+	if counts.Unacknowledged > 0 {
+		line += fmt.Sprintf(" · %d unacked", counts.Unacknowledged)
+	}
+	return line
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/harpchad/chotko/internal/config"
+	"github.com/harpchad/chotko/internal/theme"
+)
+
+// themeUsage describes the theme subcommands.
+const themeUsage = `Usage:
+  chotko theme list                    List built-in and custom themes
+  chotko theme preview NAME            Show a theme's colors and sample alerts
+  chotko theme export NAME [FILE]      Write a theme as YAML to start a custom theme`
+
+// runThemeCommand runs "chotko theme" with the arguments after "theme".
+func runThemeCommand(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("missing theme command\n\n" + themeUsage)
+	}
+	switch cmd, args := args[0], args[1:]; {
+	case cmd == "list" && len(args) == 0:
+		return listThemes(out)
+	case cmd == "preview" && len(args) == 1:
+		t, err := loadTheme(args[0])
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(out, theme.Preview(t))
+		return err
+	case cmd == "export" && (len(args) == 1 || len(args) == 2):
+		t, err := loadTheme(args[0])
+		if err != nil {
+			return err
+		}
+		data, err := theme.Export(t)
+		if err != nil {
+			return err
+		}
+		if len(args) == 2 {
+			if err := os.WriteFile(args[1], data, 0o600); err != nil {
+				return fmt.Errorf("failed to write theme: %w", err)
+			}
+			return nil
+		}
+		_, err = out.Write(data)
+		return err
+	case cmd == "help" || cmd == "-h" || cmd == "--help":
+		_, err := fmt.Fprintln(out, themeUsage)
+		return err
+	default:
+		return fmt.Errorf("invalid theme command %q\n\n%s", cmd, themeUsage)
+	}
+}
+
+// listThemes prints the built-in themes with their descriptions, then the
+// custom themes in the config directory.
+func listThemes(out io.Writer) error {
+	builtin := theme.BuiltinThemes()
+	fmt.Fprintln(out, "Built-in themes:")
+	for _, name := range theme.BuiltinThemeNames() {
+		fmt.Fprintf(out, "  %-18s %s\n", name, builtin[name].Description)
+	}
+	fmt.Fprintf(out, "  %-18s %s\n", theme.ThemeAuto, `Dark or light variant to match the terminal (also e.g. "nord-auto")`)
+
+	custom, err := theme.CustomThemeNames(config.Dir())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "\nCustom themes:")
+	if len(custom) == 0 {
+		fmt.Fprintln(out, "  none (create one with: chotko theme export NAME)")
+	}
+	for _, name := range custom {
+		fmt.Fprintf(out, "  %s\n", name)
+	}
+	return nil
+}
+
+// loadTheme loads a built-in or custom theme, resolving auto themes by the
+// terminal background as the application does.
+func loadTheme(name string) (*theme.Theme, error) {
+	if theme.IsAuto(name) {
+		name = theme.Resolve(name, lipgloss.HasDarkBackground())
+	}
+	t, err := theme.Load(name, config.Dir())
+	if err != nil {
+		return nil, fmt.Errorf("theme %q: %w", name, err)
+	}
+	return t, nil
+}
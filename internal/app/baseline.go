@@ -0,0 +1,82 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/detail"
+	"github.com/harpchad/chotko/internal/format"
+)
+
+// baselineOffsets are how long ago the history a chart is compared with
+// was collected: the same time yesterday and a week ago.
+var baselineOffsets = []time.Duration{24 * time.Hour, 7 * 24 * time.Hour}
+
+// toggleBaseline turns comparing the Graphs tab's charts with the past on
+// or off.
+func (m *Model) toggleBaseline() tea.Cmd {
+	m.baseline = !m.baseline
+	if !m.baseline {
+		m.baselineItem = ""
+		m.detailPane.SetBaselines("", nil)
+		m.statusBar.SetStatus("Charts show the present only")
+		return nil
+	}
+	labels := make([]string, len(baselineOffsets))
+	for i, offset := range baselineOffsets {
+		labels[i] = format.Span(offset)
+	}
+	m.statusBar.SetStatus("Comparing charts with " + strings.Join(labels, " and ") + " ago")
+	return m.maybeLoadBaselines()
+}
+
+// maybeLoadBaselines loads the past history of the item selected on the
+// Graphs tab while comparing with the past, unless it is loaded already.
+func (m *Model) maybeLoadBaselines() tea.Cmd {
+	if !m.baseline || m.tabBar.Active() != TabGraphs {
+		return nil
+	}
+	item := m.graphList.SelectedItem()
+	if item == nil || !item.IsNumeric() || item.ItemID == m.baselineItem {
+		return nil
+	}
+	m.baselineItem = item.ItemID
+
+	client := m.client
+	ctx := m.ctx
+	window := time.Duration(m.config.GetHistoryHours()) * time.Hour
+	now := time.Now()
+	selected := *item
+
+	return func() tea.Msg {
+		msg := BaselinesLoadedMsg{ItemID: selected.ItemID}
+		if client == nil {
+			return msg
+		}
+		for _, offset := range baselineOffsets {
+			till := now.Add(-offset)
+			history, err := client.GetHistoryBetween(ctx, selected, till.Add(-window), till)
+			if err != nil {
+				msg.Err = err
+				return msg
+			}
+			msg.Baselines = append(msg.Baselines, detail.Baseline{Offset: offset, History: history})
+		}
+		return msg
+	}
+}
+
+// handleBaselinesLoadedMsg draws the past history of an item over its
+// chart, unless another item was selected since.
+func (m Model) handleBaselinesLoadedMsg(msg BaselinesLoadedMsg) (tea.Model, tea.Cmd) {
+	if !m.baseline || msg.ItemID != m.baselineItem {
+		return m, nil
+	}
+	if msg.Err != nil {
+		return m, m.notifyError("Could not load the past history", msg.Err)
+	}
+	m.detailPane.SetBaselines(msg.ItemID, msg.Baselines)
+	return m, nil
+}
@@ -0,0 +1,268 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/ical"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// defaultCalendarWindow is how far ahead ":calendar" looks for maintenance
+// windows, and maxCalendarWindow how far it may be asked to.
+const (
+	defaultCalendarWindow = 28 * 24 * time.Hour
+	maxCalendarWindow     = 366 * 24 * time.Hour
+)
+
+// defaultMaintenancesFile is the file ":export-maintenances" writes to by
+// default, in the current directory.
+const defaultMaintenancesFile = "maintenances.ics"
+
+// maintenanceWindow is a window of a maintenance of the calendar.
+type maintenanceWindow struct {
+	zabbix.Window
+	Maintenance *zabbix.Maintenance
+}
+
+// handleCalendarCommand loads the maintenances of the selected host to show
+// when they are in effect over the next weeks, e.g. ":calendar 90d".
+func (m *Model) handleCalendarCommand(cmd string) tea.Cmd {
+	args := strings.Fields(strings.TrimPrefix(cmd, "calendar"))
+	window := defaultCalendarWindow
+	if len(args) > 1 {
+		m.statusBar.SetStatus("Usage: :calendar [WINDOW], e.g. :calendar 90d")
+		return nil
+	}
+	if len(args) == 1 {
+		d, err := format.ParseSpan(args[0])
+		if err != nil || d <= 0 || d > maxCalendarWindow {
+			m.statusBar.SetStatus("Usage: :calendar [WINDOW], a window of up to 366d")
+			return nil
+		}
+		window = d
+	}
+	hostID := m.getSelectedHostID()
+	if hostID == "" {
+		m.statusBar.SetStatus("Select a host to show its maintenance calendar")
+		return nil
+	}
+
+	client := m.client
+	ctx := m.ctx
+	// Days start at midnight in the display timezone, as other times are shown
+	now := time.Now().In(m.config.GetLocation())
+	m.statusBar.SetStatus("Loading maintenances...")
+
+	return func() tea.Msg {
+		msg := CalendarLoadedMsg{From: now, Till: now.Add(window)}
+		if client == nil {
+			return msg
+		}
+		hosts, err := client.GetHosts(ctx, zabbix.HostGetParams{
+			Output:           []string{"hostid", "host", "name"},
+			SelectHostGroups: []string{"groupid", "name"},
+			HostIDs:          []string{hostID},
+		})
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		if len(hosts) == 0 {
+			msg.Err = fmt.Errorf("host %s: %w", hostID, zabbix.ErrNotFound)
+			return msg
+		}
+		groupIDs := make([]string, 0, len(hosts[0].Groups))
+		for _, g := range hosts[0].Groups {
+			groupIDs = append(groupIDs, g.GroupID)
+		}
+		msg.Host = hosts[0]
+		msg.Maintenances, msg.Err = client.GetHostMaintenances(ctx, hostID, groupIDs)
+		return msg
+	}
+}
+
+// handleCalendarLoadedMsg shows a calendar of the days the host is in
+// maintenance, above the maintenance windows in order and when the
+// maintenances recur.
+func (m Model) handleCalendarLoadedMsg(msg CalendarLoadedMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not load the maintenances", msg.Err)
+	}
+	host := msg.Host.DisplayName()
+	span := format.Span(msg.Till.Sub(msg.From))
+	windows := maintenanceWindows(msg.Maintenances, msg.From, msg.Till)
+	if len(windows) == 0 {
+		m.statusBar.SetStatus(fmt.Sprintf("No maintenance of %s in the next %s", host, span))
+		return m, nil
+	}
+
+	lines := []string{fmt.Sprintf("Maintenance of %s in the next %s", host, span)}
+	if next := windows[0]; next.Start.After(msg.From) {
+		lines = append(lines, fmt.Sprintf("Next: %s %s", next.Maintenance.Name, format.Relative(next.Start, msg.From)))
+	} else {
+		lines = append(lines, fmt.Sprintf("Now: %s until %s", next.Maintenance.Name, m.timeFormat.Full(next.End)))
+	}
+	lines = append(lines, "")
+	lines = append(lines, m.calendarLines(windows, msg.From, msg.Till)...)
+
+	var schedules []string
+	for i := range msg.Maintenances {
+		mt := &msg.Maintenances[i]
+		if !slices.ContainsFunc(windows, func(w maintenanceWindow) bool { return w.Maintenance == mt }) {
+			continue
+		}
+		var recurs []string
+		for _, p := range mt.TimePeriods {
+			if p.TimePeriodType != zabbix.TimePeriodOnce {
+				recurs = append(recurs, p.Schedule())
+			}
+		}
+		if len(recurs) > 0 {
+			schedules = append(schedules, fmt.Sprintf("  %s: %s", mt.Name, strings.Join(recurs, "; ")))
+		}
+	}
+	if len(schedules) > 0 {
+		schedules = append([]string{""}, schedules...)
+	}
+
+	// ShowText keeps the last lines that fit, so leave out the latest
+	// windows
+	lines = append(lines, "")
+	rows := max(1, m.height-11-len(lines)-len(schedules))
+	width := max(40, m.width-12)
+	for i, w := range windows {
+		if i == rows-1 && len(windows) > rows {
+			lines = append(lines, fmt.Sprintf("  ... and %d more", len(windows)-i))
+			break
+		}
+		lines = append(lines, ansi.Truncate(m.windowLine(w), width, "…"))
+	}
+	for _, s := range schedules {
+		lines = append(lines, ansi.Truncate(s, width, "…"))
+	}
+
+	m.showError = true
+	m.errorModal.ShowText("Maintenance calendar", lines)
+	return m, nil
+}
+
+// handleExportMaintenancesCommand writes the maintenances of the server to
+// an iCalendar file to import into team calendars, by default
+// defaultMaintenancesFile, e.g. ":export-maintenances patching.ics".
+func (m *Model) handleExportMaintenancesCommand(cmd string) tea.Cmd {
+	path := strings.TrimSpace(strings.TrimPrefix(cmd, "export-maintenances"))
+	if path == "" {
+		path = defaultMaintenancesFile
+	}
+	if !strings.EqualFold(filepath.Ext(path), ".ics") {
+		m.statusBar.SetStatus("Usage: :export-maintenances [FILE.ics]")
+		return nil
+	}
+
+	client := m.client
+	ctx := m.ctx
+	loc := m.config.GetLocation()
+	domain := "chotko"
+	if u, err := url.Parse(m.config.Server.URL); err == nil && u.Hostname() != "" {
+		domain = u.Hostname()
+	}
+	m.statusBar.SetStatus("Exporting maintenances...")
+
+	return func() tea.Msg {
+		if client == nil {
+			return ConfigurationExportedMsg{Name: "maintenances"}
+		}
+		maintenances, err := client.GetAllMaintenances(ctx)
+		if err != nil {
+			return ConfigurationExportedMsg{Name: "maintenances", Err: err}
+		}
+		// Periods are expanded in the display timezone, as by :calendar
+		var b bytes.Buffer
+		n, err := ical.Write(&b, maintenances, loc, domain, time.Now())
+		if err == nil {
+			err = os.WriteFile(path, b.Bytes(), 0o600)
+		}
+		return ConfigurationExportedMsg{Name: fmt.Sprintf("%d maintenances", n), Path: path, Err: err}
+	}
+}
+
+// maintenanceWindows returns the windows of the maintenances between from
+// and till, in order.
+func maintenanceWindows(maintenances []zabbix.Maintenance, from, till time.Time) []maintenanceWindow {
+	var windows []maintenanceWindow
+	for i := range maintenances {
+		for _, w := range maintenances[i].Windows(from, till) {
+			windows = append(windows, maintenanceWindow{Window: w, Maintenance: &maintenances[i]})
+		}
+	}
+	slices.SortStableFunc(windows, func(a, b maintenanceWindow) int {
+		return a.Start.Compare(b.Start)
+	})
+	return windows
+}
+
+// calendarLines renders the weeks from from until till, a row a week with
+// the month of its Monday on the left where it changes, highlighting the
+// days with a maintenance window.
+func (m Model) calendarLines(windows []maintenanceWindow, from, till time.Time) []string {
+	lines := []string{m.styles.Subtle.Render("      Mo Tu We Th Fr Sa Su")}
+	y, mo, d := from.Date()
+	today := time.Date(y, mo, d, 0, 0, 0, 0, from.Location())
+	week := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	for ; week.Before(till); week = week.AddDate(0, 0, 7) {
+		label := ""
+		if len(lines) == 1 || week.Day() <= 7 {
+			label = week.Format("Jan")
+		}
+		var days []string
+		for i := range 7 {
+			day := week.AddDate(0, 0, i)
+			text := fmt.Sprintf("%2d", day.Day())
+			switch {
+			case day.Before(today) || !day.Before(till):
+				text = m.styles.Subtle.Render(text)
+			case inMaintenance(windows, day, day.AddDate(0, 0, 1)):
+				text = m.styles.StatusMaint.Render(text)
+			case day.Equal(today):
+				text = m.styles.Title.Render(text)
+			}
+			days = append(days, text)
+		}
+		lines = append(lines, fmt.Sprintf("  %-3s %s", label, strings.Join(days, " ")))
+	}
+	return lines
+}
+
+// inMaintenance returns true if a window overlaps from until till.
+func inMaintenance(windows []maintenanceWindow, from, till time.Time) bool {
+	return slices.ContainsFunc(windows, func(w maintenanceWindow) bool {
+		return w.Start.Before(till) && w.End.After(from)
+	})
+}
+
+// windowLine renders a maintenance window: its day and times, how long it
+// lasts and the maintenance, and whether it stops data collection.
+func (m Model) windowLine(w maintenanceWindow) string {
+	end := m.timeFormat.ClockShort(w.End)
+	if y, mo, d := w.End.Date(); y != w.Start.Year() || mo != w.Start.Month() || d != w.Start.Day() {
+		end = w.End.Format("Mon 02 Jan ") + end
+	}
+	line := fmt.Sprintf("  %s %s - %s  %-7s %s", w.Start.Format("Mon 02 Jan"), m.timeFormat.ClockShort(w.Start),
+		end, format.Duration(w.End.Sub(w.Start)), w.Maintenance.Name)
+	if !w.Maintenance.CollectsData() {
+		line += m.styles.Subtle.Render("  no data")
+	}
+	return line
+}
@@ -0,0 +1,137 @@
+package app
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/plot"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// chartFileName returns the name, without extension, of the export of an
+// item's chart, e.g. "web01_system.cpu.util_20260301-150405".
+func chartFileName(item *zabbix.Item, now time.Time) string {
+	name := unsafeFileChars.ReplaceAllString(item.HostName()+"_"+item.Key, "_")
+	return strings.Trim(name, "_") + "_" + now.Format("20060102-150405")
+}
+
+// chartCSV returns the values of an item's history as CSV, with their time
+// in RFC 3339 and as a Unix timestamp.
+func chartCSV(history []zabbix.History) []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"time", "clock", "value"})
+	for i := range history {
+		h := &history[i]
+		_ = w.Write([]string{h.Time().Format(time.RFC3339), h.Clock, h.Value})
+	}
+	w.Flush()
+	return buf.Bytes()
+}
+
+// chartImage returns the chart of an item's history as a PNG image.
+func chartImage(item *zabbix.Item, history []zabbix.History, tf format.TimeFormat) ([]byte, error) {
+	chart := plot.Chart{
+		Title:  item.HostName() + ": " + item.Name,
+		YLabel: func(v float64) string { return format.YAxisValue(v, item.Units) },
+		XLabel: tf.ClockShort,
+	}
+	for i := range history {
+		if t := history[i].Time(); !t.IsZero() {
+			chart.Points = append(chart.Points, plot.Point{Time: t, Value: history[i].ValueFloat()})
+		}
+	}
+	if n := len(chart.Points); n > 1 && chart.Points[n-1].Time.Sub(chart.Points[0].Time) > 24*time.Hour {
+		chart.XLabel = func(t time.Time) string { return t.Format("01-02 ") + tf.ClockShort(t) }
+	}
+	var buf bytes.Buffer
+	if err := chart.PNG(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// exportChart exports the chart of the item selected on the Graphs tab:
+// "csv" saves its data points, "png" renders it as an image, "clipboard"
+// copies the CSV to the clipboard, and an empty what saves the CSV and,
+// with graphs.export_png, the image.
+func (m *Model) exportChart(what string) tea.Cmd {
+	item := m.graphList.SelectedItem()
+	if m.tabBar.Active() != TabGraphs || item == nil {
+		m.statusBar.SetStatus("Select an item on the Graphs tab to export its chart")
+		return nil
+	}
+	history := m.graphList.GetHistory(item.ItemID)
+	if len(history) == 0 {
+		m.statusBar.SetStatus("No history to export for " + item.Name)
+		return nil
+	}
+
+	csvFile, pngFile := true, m.config.Graphs.ExportPNG
+	switch what {
+	case "":
+	case "csv":
+		pngFile = false
+	case "png":
+		csvFile, pngFile = false, true
+	case "clipboard":
+		m.copyText(string(chartCSV(history)))
+		return m.notify(notify.Success, fmt.Sprintf("Copied %d values of %s to the clipboard", len(history), item.Name))
+	default:
+		m.statusBar.SetStatus("Usage: :export-chart [csv|png|clipboard]")
+		return nil
+	}
+	if pngFile && !item.IsNumeric() {
+		if !csvFile {
+			m.statusBar.SetStatus(item.Name + " is not numeric; use :export-chart csv")
+			return nil
+		}
+		pngFile = false
+	}
+
+	dir := m.config.GetExportDir()
+	base := filepath.Join(dir, chartFileName(item, time.Now()))
+	tf := m.timeFormat
+	name := item.Name
+
+	return func() tea.Msg {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return ChartExportedMsg{Name: name, Err: err}
+		}
+		var paths []string
+		if csvFile {
+			if err := os.WriteFile(base+".csv", chartCSV(history), 0o600); err != nil {
+				return ChartExportedMsg{Name: name, Err: err}
+			}
+			paths = append(paths, base+".csv")
+		}
+		if pngFile {
+			image, err := chartImage(item, history, tf)
+			if err == nil {
+				err = os.WriteFile(base+".png", image, 0o600)
+			}
+			if err != nil {
+				return ChartExportedMsg{Name: name, Paths: paths, Err: err}
+			}
+			paths = append(paths, base+".png")
+		}
+		return ChartExportedMsg{Name: name, Paths: paths}
+	}
+}
+
+// handleChartExportedMsg reports an exported chart.
+func (m Model) handleChartExportedMsg(msg ChartExportedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.notifyError("Could not export the chart of "+msg.Name, msg.Err)
+	}
+	return m, m.notify(notify.Success, fmt.Sprintf("Exported the chart of %s to %s", msg.Name, strings.Join(msg.Paths, " and ")))
+}
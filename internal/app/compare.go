@@ -0,0 +1,161 @@
+package app
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/detail"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// maxComparedHosts is how many hosts an item is compared across at most, to
+// keep the charts readable and the history request small.
+const maxComparedHosts = 24
+
+// toggleComparison compares the item selected on the Graphs tab across the
+// hosts that have its key, then overlays them on one chart, then shows the
+// item's own detail again.
+func (m *Model) toggleComparison() tea.Cmd {
+	item := m.graphList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	if m.detailPane.ComparedItem() == item.ItemID {
+		if !m.detailPane.Overlaid() {
+			m.detailPane.SetOverlaid(true)
+			return nil
+		}
+		m.detailPane.CloseComparison()
+		return nil
+	}
+	return m.compareItem(m.comparePattern)
+}
+
+// handleCompareCommand compares the item selected on the Graphs tab across
+// the hosts whose name matches a pattern, e.g. ":compare web*", or all
+// hosts that have its key.
+func (m *Model) handleCompareCommand(cmd string) tea.Cmd {
+	pattern := strings.TrimSpace(strings.TrimPrefix(cmd, "compare"))
+	if _, err := path.Match(pattern, ""); err != nil {
+		m.statusBar.SetStatus(fmt.Sprintf("Invalid host pattern %q", pattern))
+		return nil
+	}
+	m.comparePattern = pattern
+	return m.compareItem(pattern)
+}
+
+// compareItem loads the history of the selected item's key on the hosts
+// matching pattern.
+func (m *Model) compareItem(pattern string) tea.Cmd {
+	item := m.graphList.SelectedItem()
+	if m.tabBar.Active() != TabGraphs || item == nil {
+		m.statusBar.SetStatus("Select an item on the Graphs tab to compare it across hosts")
+		return nil
+	}
+	if !item.IsNumeric() {
+		m.statusBar.SetStatus("Only numeric items can be compared across hosts")
+		return nil
+	}
+	items := comparedItems(m.items, item, pattern)
+	if len(items) < 2 {
+		m.statusBar.SetStatus("No other host has " + item.Key)
+		return nil
+	}
+	hosts := len(items)
+	items = items[:min(hosts, maxComparedHosts)]
+	m.statusBar.SetStatus(fmt.Sprintf("Comparing %s across %d hosts...", item.Key, len(items)))
+
+	client := m.client
+	ctx := m.ctx
+	hours := m.config.GetHistoryHours()
+	itemID := item.ItemID
+
+	return func() tea.Msg {
+		msg := CompareLoadedMsg{ItemID: itemID, Hosts: hosts}
+		if client == nil {
+			return msg
+		}
+		history, err := client.GetItemsHistory(ctx, items, hours)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		for _, i := range items {
+			msg.Series = append(msg.Series, detail.Series{Item: i, History: history[i.ItemID]})
+		}
+		return msg
+	}
+}
+
+// comparedItems returns the items with the same key as item on the hosts
+// matching pattern, item's own first and the others by host name.
+func comparedItems(items []zabbix.Item, item *zabbix.Item, pattern string) []zabbix.Item {
+	pattern = strings.ToLower(pattern)
+	var compared []zabbix.Item
+	for _, i := range items {
+		if i.Key != item.Key || !i.IsNumeric() {
+			continue
+		}
+		if i.ItemID != item.ItemID && pattern != "" && !hostMatches(&i, pattern) {
+			continue
+		}
+		compared = append(compared, i)
+	}
+	slices.SortStableFunc(compared, func(a, b zabbix.Item) int {
+		switch {
+		case a.ItemID == item.ItemID:
+			return -1
+		case b.ItemID == item.ItemID:
+			return 1
+		}
+		return strings.Compare(strings.ToLower(a.HostName()), strings.ToLower(b.HostName()))
+	})
+	return compared
+}
+
+// hostMatches reports whether the visible or technical name of an item's
+// host matches a lower case pattern.
+func hostMatches(item *zabbix.Item, pattern string) bool {
+	return nameMatches(item.HostName(), pattern) || nameMatches(technicalHostName(item), pattern)
+}
+
+// nameMatches reports whether a name matches a lower case pattern, ignoring
+// case.
+func nameMatches(name, pattern string) bool {
+	ok, _ := path.Match(pattern, strings.ToLower(name))
+	return ok
+}
+
+// reloadComparison loads the comparison shown in the detail pane again, e.g.
+// after the items were refreshed.
+func (m *Model) reloadComparison() tea.Cmd {
+	item := m.graphList.SelectedItem()
+	if item == nil || m.detailPane.ComparedItem() != item.ItemID {
+		return nil
+	}
+	return m.compareItem(m.comparePattern)
+}
+
+// handleCompareLoadedMsg shows the history of an item's key across hosts,
+// unless another item was selected since.
+func (m Model) handleCompareLoadedMsg(msg CompareLoadedMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not load the history to compare", msg.Err)
+	}
+	selected := m.graphList.SelectedItem()
+	if len(msg.Series) == 0 || m.tabBar.Active() != TabGraphs || selected == nil || selected.ItemID != msg.ItemID {
+		return m, nil
+	}
+	status := fmt.Sprintf("%s on %d hosts", selected.Key, len(msg.Series))
+	if msg.Hosts > len(msg.Series) {
+		status = fmt.Sprintf("%s on the first %d of %d hosts; narrow them with :compare PATTERN", selected.Key, len(msg.Series), msg.Hosts)
+	}
+	m.statusBar.SetStatus(status)
+	m.detailPane.SetComparison(msg.ItemID, msg.Series)
+	return m, nil
+}
@@ -0,0 +1,219 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// importRequest is a configuration file awaiting confirmation of its
+// import.
+type importRequest struct {
+	path    string
+	preview *zabbix.ImportPreview
+}
+
+// unsafeFileChars matches the characters of a host or template name left
+// out of the name of its export file.
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// exportFileName returns the default file of the export of a host or
+// template.
+func exportFileName(name string) string {
+	return strings.Trim(unsafeFileChars.ReplaceAllString(name, "_"), "_") + ".yaml"
+}
+
+// handleExportCommand exports the selected host to a file, by default named
+// after the host in the current directory, e.g. ":export web01.json".
+func (m *Model) handleExportCommand(cmd string) tea.Cmd {
+	hostID := m.getSelectedHostID()
+	host := m.findHostByID(hostID)
+	if host == nil {
+		m.statusBar.SetStatus("Select a host to export, or use :export-template NAME")
+		return nil
+	}
+	path := strings.TrimSpace(strings.TrimPrefix(cmd, "export"))
+	if path == "" {
+		path = exportFileName(host.Host)
+	}
+	format, err := zabbix.ConfigFormatOf(path)
+	if err != nil {
+		m.statusBar.SetStatus(err.Error())
+		return nil
+	}
+
+	client := m.client
+	ctx := m.ctx
+	name := host.DisplayName()
+	m.statusBar.SetStatus("Exporting " + name + "...")
+
+	return func() tea.Msg {
+		if client == nil {
+			return ConfigurationExportedMsg{Name: name, Path: path}
+		}
+		source, err := client.ExportConfiguration(ctx, format, []string{hostID}, nil)
+		if err == nil {
+			err = os.WriteFile(path, []byte(source), 0o600)
+		}
+		return ConfigurationExportedMsg{Name: name, Path: path, Err: err}
+	}
+}
+
+// handleExportTemplateCommand exports a template, by technical or visible
+// name, to a YAML file named after it in the current directory, e.g.
+// ":export-template Linux by Zabbix agent".
+func (m *Model) handleExportTemplateCommand(cmd string) tea.Cmd {
+	name := strings.TrimSpace(strings.TrimPrefix(cmd, "export-template"))
+	if name == "" {
+		m.statusBar.SetStatus("Usage: :export-template NAME")
+		return nil
+	}
+
+	client := m.client
+	ctx := m.ctx
+	m.statusBar.SetStatus("Exporting " + name + "...")
+
+	return func() tea.Msg {
+		if client == nil {
+			return ConfigurationExportedMsg{Name: name}
+		}
+		template, err := client.FindTemplate(ctx, name)
+		if err != nil {
+			return ConfigurationExportedMsg{Name: name, Err: err}
+		}
+		path := exportFileName(template.Host)
+		source, err := client.ExportConfiguration(ctx, zabbix.ConfigFormatYAML, nil, []string{template.TemplateID})
+		if err == nil {
+			err = os.WriteFile(path, []byte(source), 0o600)
+		}
+		return ConfigurationExportedMsg{Name: name, Path: path, Err: err}
+	}
+}
+
+// handleConfigurationExportedMsg reports an exported host, template or
+// maintenances.
+func (m Model) handleConfigurationExportedMsg(msg ConfigurationExportedMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not export "+msg.Name, msg.Err)
+	}
+	if msg.Path == "" {
+		return m, nil
+	}
+	return m, m.notify(notify.Success, fmt.Sprintf("Exported %s to %s", msg.Name, msg.Path))
+}
+
+// handleImportCommand compares a configuration file with the server, e.g.
+// ":import web01.yaml", to confirm importing it.
+func (m *Model) handleImportCommand(cmd string) tea.Cmd {
+	path := strings.TrimSpace(strings.TrimPrefix(cmd, "import"))
+	if path == "" {
+		m.statusBar.SetStatus("Usage: :import FILE (.yaml, .json or .xml)")
+		return nil
+	}
+	format, err := zabbix.ConfigFormatOf(path)
+	if err != nil {
+		m.statusBar.SetStatus(err.Error())
+		return nil
+	}
+
+	client := m.client
+	ctx := m.ctx
+	m.statusBar.SetStatus("Comparing " + path + " with the server...")
+
+	return func() tea.Msg {
+		if client == nil {
+			return ImportPreviewMsg{Path: path}
+		}
+		source, err := os.ReadFile(path)
+		if err != nil {
+			return ImportPreviewMsg{Path: path, Err: err}
+		}
+		preview, err := client.PreviewImport(ctx, format, string(source))
+		return ImportPreviewMsg{Path: path, Preview: preview, Err: err}
+	}
+}
+
+// handleImportPreviewMsg shows what importing a configuration file would
+// change and asks to confirm it.
+func (m Model) handleImportPreviewMsg(msg ImportPreviewMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not read "+msg.Path, msg.Err)
+	}
+	preview := msg.Preview
+	if preview == nil {
+		return m, nil
+	}
+	if len(preview.Diff) == 0 {
+		m.statusBar.SetStatus(msg.Path + " matches the server; nothing to import")
+		return m, nil
+	}
+
+	var lines []string
+	if created := preview.NewObjects(); len(created) > 0 {
+		lines = append(lines, "Creates: "+strings.Join(created, ", "))
+	}
+	if len(preview.Existing) > 0 {
+		lines = append(lines, "Updates: "+strings.Join(preview.Existing, ", "))
+	}
+	lines = append(lines, "Objects missing from the file are kept", "")
+	lines = append(lines, preview.Diff...)
+
+	m.pendingImport = &importRequest{path: msg.Path, preview: preview}
+	m.showError = true
+	m.errorModal.ShowConfirm("Import "+filepath.Base(msg.Path)+"?", lines)
+	return m, nil
+}
+
+// handleImportConfirmKey handles the keys of the import confirmation: y
+// imports the file, n or Esc cancels.
+func (m Model) handleImportConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		req := *m.pendingImport
+		m.pendingImport = nil
+		m.showError = false
+		m.errorModal.Hide()
+		return m, m.importConfiguration(req)
+	case "n", "N", "esc", "q":
+		m.pendingImport = nil
+		m.showError = false
+		m.errorModal.Hide()
+		m.statusBar.SetStatus("Import canceled")
+	}
+	return m, nil
+}
+
+// importConfiguration imports a configuration file compared with the
+// server.
+func (m *Model) importConfiguration(req importRequest) tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+	m.statusBar.SetStatus("Importing " + req.path + "...")
+
+	return func() tea.Msg {
+		if client == nil {
+			return ImportResultMsg{Path: req.path}
+		}
+		err := client.ImportConfiguration(ctx, req.preview.Format, req.preview.Source)
+		return ImportResultMsg{Path: req.path, Err: err}
+	}
+}
+
+// handleImportResultMsg reports an imported configuration and reloads the
+// hosts to show it.
+func (m Model) handleImportResultMsg(msg ImportResultMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not import "+msg.Path, msg.Err)
+	}
+	return m, tea.Batch(m.notify(notify.Success, "Imported "+msg.Path), m.loadHosts())
+}
@@ -0,0 +1,160 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/dashboard"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// handleDashboardCommand lists the dashboards of the server to choose one,
+// or opens the one named with ":dashboard NAME".
+func (m *Model) handleDashboardCommand(cmd string) tea.Cmd {
+	name := strings.TrimSpace(strings.TrimPrefix(cmd, "dashboard"))
+	client := m.client
+	ctx := m.ctx
+
+	return func() tea.Msg {
+		if client == nil {
+			return DashboardsLoadedMsg{Name: name}
+		}
+		dashboards, err := client.GetDashboards(ctx)
+		return DashboardsLoadedMsg{Name: name, Dashboards: dashboards, Err: err}
+	}
+}
+
+// handleDashboardsLoadedMsg shows the list of dashboards, or loads the one
+// asked for by name.
+func (m Model) handleDashboardsLoadedMsg(msg DashboardsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.statusBar.SetStatus(fmt.Sprintf("Could not load dashboards: %v", msg.Err))
+		return m, nil
+	}
+	if msg.Name == "" {
+		if len(msg.Dashboards) == 0 {
+			m.statusBar.SetStatus("No dashboards on the server")
+			return m, nil
+		}
+		m.dashboardView.ShowList(msg.Dashboards)
+		m.showDashboard = true
+		return m, nil
+	}
+
+	d := findDashboard(msg.Dashboards, msg.Name)
+	if d == nil {
+		m.statusBar.SetStatus(fmt.Sprintf("No dashboard named %q", msg.Name))
+		return m, nil
+	}
+	return m, m.loadDashboard(d.DashboardID)
+}
+
+// findDashboard returns the dashboard with the name, ignoring case, or else
+// the first one whose name contains it.
+func findDashboard(dashboards []zabbix.Dashboard, name string) *zabbix.Dashboard {
+	for i := range dashboards {
+		if strings.EqualFold(dashboards[i].Name, name) {
+			return &dashboards[i]
+		}
+	}
+	name = strings.ToLower(name)
+	for i := range dashboards {
+		if strings.Contains(strings.ToLower(dashboards[i].Name), name) {
+			return &dashboards[i]
+		}
+	}
+	return nil
+}
+
+// loadDashboard loads the pages and widgets of a dashboard.
+func (m *Model) loadDashboard(dashboardID string) tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+
+	return func() tea.Msg {
+		if client == nil {
+			return DashboardLoadedMsg{}
+		}
+		d, err := client.GetDashboard(ctx, dashboardID)
+		return DashboardLoadedMsg{Dashboard: d, Err: err}
+	}
+}
+
+// handleDashboardLoadedMsg shows a dashboard and loads the data of its
+// first page.
+func (m Model) handleDashboardLoadedMsg(msg DashboardLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.statusBar.SetStatus(fmt.Sprintf("Could not load dashboard: %v", msg.Err))
+		return m, nil
+	}
+	if msg.Dashboard == nil {
+		return m, nil
+	}
+	m.dashboardView.ShowDashboard(msg.Dashboard)
+	m.showDashboard = true
+	return m, m.loadWidgetData()
+}
+
+// loadWidgetData loads what the widgets of the shown dashboard page display,
+// over the history window of the Graphs tab.
+func (m *Model) loadWidgetData() tea.Cmd {
+	d := m.dashboardView.Dashboard()
+	if d == nil {
+		return nil
+	}
+	client := m.client
+	ctx := m.ctx
+	dashboardID, page := d.DashboardID, m.dashboardView.Page()
+	widgets := m.dashboardView.Widgets()
+	hours := m.config.GetHistoryHours()
+
+	return func() tea.Msg {
+		if client == nil {
+			return WidgetDataLoadedMsg{DashboardID: dashboardID, Page: page}
+		}
+		data, err := client.GetWidgetData(ctx, widgets, hours)
+		return WidgetDataLoadedMsg{DashboardID: dashboardID, Page: page, Data: data, Err: err}
+	}
+}
+
+// handleWidgetDataLoadedMsg shows the data of the widgets. On errors the
+// widgets keep what they showed before.
+func (m Model) handleWidgetDataLoadedMsg(msg WidgetDataLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.statusBar.SetStatus(fmt.Sprintf("Could not load dashboard widgets: %v", msg.Err))
+		return m, nil
+	}
+	if msg.Data != nil {
+		m.dashboardView.SetData(msg.DashboardID, msg.Page, msg.Data)
+	}
+	return m, nil
+}
+
+// handleDashboardInput forwards keys and clicks to the shown dashboard or
+// list of dashboards, and returns to the tabs when it is closed.
+func (m Model) handleDashboardInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.dashboardView, cmd = m.dashboardView.Update(msg)
+	m.showDashboard = m.dashboardView.Visible()
+	return m, cmd
+}
+
+// handleDashboardGroupMsg closes the dashboard and shows the problems of the
+// host group clicked on it in the Alerts tab, until the filters are cleared.
+func (m Model) handleDashboardGroupMsg(msg dashboard.GroupMsg) (tea.Model, tea.Cmd) {
+	m.dashboardView.Hide()
+	m.showDashboard = false
+	group := msg.Group
+	m.alertGroup = &group
+
+	model, cmd := m.switchTab(TabAlerts)
+	m = model.(Model)
+	m.statusBar.SetStatus(fmt.Sprintf("Alerts of host group %s; Ctrl+L shows all", group.Name))
+	if !m.connected {
+		return m, cmd
+	}
+	m.statusBar.SetLoading(true)
+	return m, tea.Batch(cmd, m.loadProblems())
+}
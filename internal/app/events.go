@@ -0,0 +1,176 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// eventType selects which events the Events tab shows.
+type eventType int
+
+// Event types, in the order the event type key cycles through them.
+const (
+	eventsAll eventType = iota
+	eventsProblems
+	eventsRecoveries
+	eventTypeCount
+)
+
+// String returns the name of the event type.
+func (t eventType) String() string {
+	switch t {
+	case eventsProblems:
+		return "problems"
+	case eventsRecoveries:
+		return "recoveries"
+	}
+	return "all"
+}
+
+// parseEventType returns the event type with the given name.
+func parseEventType(name string) (eventType, bool) {
+	for t := range eventTypeCount {
+		if t.String() == name {
+			return t, true
+		}
+	}
+	return eventsAll, false
+}
+
+// defaultEventWindow is how far back the Events tab looks by default.
+const defaultEventWindow = 24 * time.Hour
+
+// eventWindows are the lookback windows the window key cycles through.
+var eventWindows = []time.Duration{time.Hour, 6 * time.Hour, 24 * time.Hour, 7 * 24 * time.Hour}
+
+// eventScope selects the events loaded on the Events tab.
+type eventScope struct {
+	Window      time.Duration // How far back to look
+	Type        eventType
+	MinSeverity int    // Minimum problem severity; 0 for all
+	HostID      string // Only this host's events; empty for all hosts
+	HostName    string
+}
+
+// params returns the event.get parameters for the scope.
+func (s eventScope) params(now time.Time) zabbix.EventHistoryParams {
+	params := zabbix.DefaultEventHistoryParams()
+	params.TimeFrom = now.Add(-s.Window).Unix()
+	switch s.Type {
+	case eventsProblems:
+		params.Values = []int{1}
+	case eventsRecoveries:
+		params.Values = []int{0}
+	}
+	if s.MinSeverity > 0 {
+		params.Severities = zabbix.SeveritiesFrom(s.MinSeverity)
+	}
+	if s.HostID != "" {
+		params.HostIDs = []string{s.HostID}
+	}
+	return params
+}
+
+// String describes the scope for the Events tab header, e.g.
+// "web-01, 6h, problems, High+".
+func (s eventScope) String() string {
+	var parts []string
+	if s.HostID != "" {
+		parts = append(parts, s.HostName)
+	}
+	parts = append(parts, format.Span(s.Window))
+	if s.Type != eventsAll {
+		parts = append(parts, s.Type.String())
+	}
+	if s.MinSeverity > 0 {
+		parts = append(parts, theme.SeverityName(s.MinSeverity)+"+")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// nextEventWindow returns the preset window after w, wrapping around.
+func nextEventWindow(w time.Duration) time.Duration {
+	for _, preset := range eventWindows {
+		if preset > w {
+			return preset
+		}
+	}
+	return eventWindows[0]
+}
+
+// setEventScope changes the events loaded on the Events tab and reloads them.
+func (m *Model) setEventScope(scope eventScope) tea.Cmd {
+	m.applyEventScope(scope)
+	if !m.connected {
+		return nil
+	}
+	m.statusBar.SetLoading(true)
+	return m.loadEvents()
+}
+
+// applyEventScope changes the events to load on the Events tab, without
+// loading them.
+func (m *Model) applyEventScope(scope eventScope) {
+	m.eventScope = scope
+	m.eventList.SetScope(scope.String())
+	m.statusBar.SetStatus("Events: " + scope.String())
+
+	// The loaded pages belong to the old scope
+	m.pages[TabEvents] = 0
+	m.pageNext[TabEvents] = ""
+}
+
+// showHostHistory switches to the Events tab showing only the events of
+// the host selected on the Alerts or Hosts tab.
+func (m Model) showHostHistory() (tea.Model, tea.Cmd) {
+	scope := m.eventScope
+	switch m.tabBar.Active() {
+	case TabAlerts:
+		p := m.alertList.Selected()
+		if p == nil || len(p.Hosts) == 0 {
+			return m, nil
+		}
+		scope.HostID, scope.HostName = p.Hosts[0].HostID, p.HostName()
+	case TabHosts:
+		h := m.hostList.Selected()
+		if h == nil {
+			return m, nil
+		}
+		scope.HostID, scope.HostName = h.HostID, h.DisplayName()
+	default:
+		return m, nil
+	}
+
+	// Drop the other hosts' events so that switching tabs loads the host's
+	m.applyEventScope(scope)
+	m.events = nil
+	m.eventList.SetEvents(nil)
+	m.eventList.GoToTop()
+	m.statusBar.SetStatus(fmt.Sprintf("Events of %s (Ctrl+L shows all hosts)", scope.HostName))
+	return m.switchTab(TabEvents)
+}
+
+// handleWindowCommand sets the Events tab lookback window, e.g. ":window 3d".
+func (m *Model) handleWindowCommand(cmd string) tea.Cmd {
+	parts := strings.Fields(cmd)
+	if len(parts) != 2 {
+		m.statusBar.SetStatus(fmt.Sprintf("Usage: :window <duration>, e.g. 12h or 3d (currently %s)",
+			format.Span(m.eventScope.Window)))
+		return nil
+	}
+	window, err := format.ParseSpan(parts[1])
+	if err != nil {
+		m.statusBar.SetStatus(fmt.Sprintf("Window: %v", err))
+		return nil
+	}
+	scope := m.eventScope
+	scope.Window = window
+	return m.setEventScope(scope)
+}
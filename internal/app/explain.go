@@ -0,0 +1,87 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// explainTrigger evaluates the trigger of the selected problem or event
+// with the current values of its items, to tell why it is firing.
+func (m *Model) explainTrigger() tea.Cmd {
+	if tab := m.tabBar.Active(); tab != TabAlerts && tab != TabEvents {
+		return nil
+	}
+	_, triggerID := m.getSelectedHostAndTriggerID()
+	if triggerID == "" {
+		m.statusBar.SetStatus("Cannot evaluate: no trigger associated")
+		return nil
+	}
+
+	client := m.client
+	ctx := m.ctx
+	m.statusBar.SetStatus("Evaluating trigger...")
+
+	return func() tea.Msg {
+		if client == nil {
+			return TriggerEvaluatedMsg{}
+		}
+		eval, err := client.EvaluateTrigger(ctx, triggerID)
+		return TriggerEvaluatedMsg{Evaluation: eval, Err: err}
+	}
+}
+
+// triggerStateLabels are how the state of a condition is shown.
+var triggerStateLabels = map[zabbix.Truth]string{
+	zabbix.TruthTrue:    "[true] ",
+	zabbix.TruthFalse:   "[false]",
+	zabbix.TruthUnknown: "[?]    ",
+}
+
+// handleTriggerEvaluatedMsg shows which conditions of a trigger expression
+// are true with the current values of its items.
+func (m Model) handleTriggerEvaluatedMsg(msg TriggerEvaluatedMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not evaluate the trigger", msg.Err)
+	}
+	eval := msg.Evaluation
+	if eval == nil {
+		return m, nil
+	}
+
+	zabbixState := "OK"
+	if eval.Trigger.Value == "1" {
+		zabbixState = "PROBLEM"
+	}
+	summary := fmt.Sprintf("The expression is %s with the latest values", eval.State)
+	if eval.State == zabbix.TruthUnknown {
+		summary = "The expression can't be fully evaluated"
+	}
+	lines := []string{
+		eval.Trigger.Description,
+		summary + "; Zabbix has the trigger in " + zabbixState,
+		"",
+		"Expression:",
+		"  " + eval.Expression,
+		"",
+		"Conditions:",
+	}
+	for _, c := range eval.Conditions {
+		lines = append(lines,
+			"  "+triggerStateLabels[c.State]+" "+c.Text,
+			"          "+c.Values)
+	}
+	if len(eval.Notes) > 0 {
+		lines = append(lines, "", "Unknown values:")
+		for _, note := range eval.Notes {
+			lines = append(lines, "  "+note)
+		}
+	}
+
+	m.showError = true
+	m.errorModal.ShowText("Why is it firing?", lines)
+	return m, nil
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/report"
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// defaultFlapThreshold is how many problems a trigger must exceed in the
+// window to be listed by ":flapping".
+const defaultFlapThreshold = 5
+
+// parseFlappingArgs parses the arguments of ":flapping [WINDOW] [N]", in any
+// order: a duration, defaulting to window, and the number of problems a
+// trigger must exceed, defaulting to defaultFlapThreshold.
+func parseFlappingArgs(args []string, window time.Duration) (time.Duration, int, bool) {
+	threshold := defaultFlapThreshold
+	for _, arg := range args {
+		if n, err := strconv.Atoi(arg); err == nil && n >= 0 {
+			threshold = n
+			continue
+		}
+		d, err := format.ParseSpan(arg)
+		if err != nil {
+			return 0, 0, false
+		}
+		window = d
+	}
+	return window, threshold, true
+}
+
+// handleFlappingCommand finds the triggers that went into problem more than
+// N times in a window, by default the Events tab's, e.g. ":flapping 1d 10".
+func (m *Model) handleFlappingCommand(cmd string) tea.Cmd {
+	window, threshold, ok := parseFlappingArgs(strings.Fields(strings.TrimPrefix(cmd, "flapping")), m.eventScope.Window)
+	if !ok {
+		m.statusBar.SetStatus("Usage: :flapping [WINDOW] [N], e.g. :flapping 1d 10")
+		return nil
+	}
+
+	client := m.client
+	ctx := m.ctx
+	now := time.Now()
+	m.statusBar.SetStatus(fmt.Sprintf("Looking for flapping triggers in the last %s...", format.Span(window)))
+
+	return func() tea.Msg {
+		msg := FlappingLoadedMsg{Window: window, Threshold: threshold}
+		if client == nil {
+			return msg
+		}
+		params := zabbix.EventHistoryParams{Limit: reportPageSize, TimeFrom: now.Add(-window).Unix(), TimeTill: now.Unix()}
+		events, truncated, err := fetchReportEvents(ctx, client, params)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		msg.Flaps = report.Flapping(events, threshold)
+		msg.Truncated = truncated
+		return msg
+	}
+}
+
+// handleFlappingLoadedMsg shows the flapping triggers, the most problems
+// first, with how long their problems lasted: thresholds that need tuning
+// or hysteresis come first.
+func (m Model) handleFlappingLoadedMsg(msg FlappingLoadedMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not load the events", msg.Err)
+	}
+	if len(msg.Flaps) == 0 {
+		m.statusBar.SetStatus(fmt.Sprintf("No trigger had more than %d problems in the last %s",
+			msg.Threshold, format.Span(msg.Window)))
+		return m, nil
+	}
+
+	lines := []string{fmt.Sprintf("Triggers with more than %d problems in the last %s", msg.Threshold, format.Span(msg.Window))}
+	if msg.Truncated {
+		lines = append(lines, "Only the latest events of the window were counted")
+	}
+	lines = append(lines, "", fmt.Sprintf("  %6s  %-14s %-10s %-19s %s", "COUNT", "SEVERITY", "MEAN", "LAST", "HOST: TRIGGER"))
+
+	// ShowText keeps the last lines that fit, so leave out the fewest flaps
+	rows := max(1, m.height-11-len(lines))
+	width := max(40, m.width-12)
+	for i, f := range msg.Flaps {
+		if i == rows-1 && len(msg.Flaps) > rows {
+			lines = append(lines, fmt.Sprintf("  ... and %d more", len(msg.Flaps)-i))
+			break
+		}
+		mean := "-"
+		if f.MeanDuration > 0 {
+			mean = format.Duration(f.MeanDuration)
+		}
+		line := fmt.Sprintf("  %6d  %-14s %-10s %-19s %s: %s", f.Count, theme.SeverityName(f.Severity),
+			mean, m.timeFormat.Full(f.Last), f.Host, f.Name)
+		lines = append(lines, ansi.Truncate(line, width, "…"))
+	}
+
+	m.showError = true
+	m.errorModal.ShowText("Flapping", lines)
+	return m, nil
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/config"
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/report"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// defaultHandoverWindow is how far back ":handover" looks for events by
+// default: a shift.
+const defaultHandoverWindow = 8 * time.Hour
+
+// handoverRequest is what ":handover" summarizes: the problems of at least
+// minSeverity, and their events of the last window, written to path or
+// copied to the clipboard when empty.
+type handoverRequest struct {
+	minSeverity int
+	window      time.Duration
+	path        string
+}
+
+// parseHandoverArgs parses the arguments of ":handover [SEVERITY] [WINDOW]
+// [FILE]", in any order: a severity by number or name (see
+// config.SeverityKeys), a duration and a file. The severity defaults to
+// minSeverity and the window to defaultHandoverWindow.
+func parseHandoverArgs(args []string, minSeverity int) (handoverRequest, error) {
+	req := handoverRequest{minSeverity: minSeverity, window: defaultHandoverWindow}
+	for _, arg := range args {
+		if severity, ok := parseSeverityArg(arg); ok {
+			req.minSeverity = severity
+			continue
+		}
+		if d, err := format.ParseSpan(arg); err == nil {
+			req.window = d
+			continue
+		}
+		if req.path != "" {
+			return req, errors.New("usage: :handover [SEVERITY] [WINDOW] [FILE], e.g. :handover high 12h handover.md")
+		}
+		req.path = arg
+	}
+	return req, nil
+}
+
+// parseSeverityArg parses a severity given by number, 0 to 5, or by name,
+// e.g. "high".
+func parseSeverityArg(arg string) (int, bool) {
+	if n, err := strconv.Atoi(arg); err == nil {
+		return n, n >= 0 && n <= config.MaxSeverity
+	}
+	i := slices.Index(config.SeverityKeys, strings.ToLower(arg))
+	return i, i >= 0
+}
+
+// handleHandoverCommand summarizes the open problems, who acknowledged them
+// and the events of the last hours for the next shift, copied to the
+// clipboard or written to a file, e.g. ":handover high 12h handover.md".
+func (m *Model) handleHandoverCommand(cmd string) tea.Cmd {
+	req, err := parseHandoverArgs(strings.Fields(strings.TrimPrefix(cmd, "handover")), m.minSeverity)
+	if err != nil {
+		m.statusBar.SetStatus(capitalize(err.Error()))
+		return nil
+	}
+
+	client := m.client
+	ctx := m.ctx
+	tf := m.timeFormat
+	now := time.Now()
+	from := now.Add(-req.window)
+	m.statusBar.SetStatus(fmt.Sprintf("Summarizing the last %s for the handover...", format.Span(req.window)))
+
+	return func() tea.Msg {
+		if client == nil {
+			return HandoverWrittenMsg{}
+		}
+		problems, err := client.GetProblemsWithMinSeverity(ctx, req.minSeverity)
+		if err != nil {
+			return HandoverWrittenMsg{Path: req.path, Err: err}
+		}
+		params := zabbix.EventHistoryParams{Limit: reportPageSize, TimeFrom: from.Unix(), TimeTill: now.Unix()}
+		events, truncated, err := fetchReportEvents(ctx, client, params)
+		if err != nil {
+			return HandoverWrittenMsg{Path: req.path, Err: err}
+		}
+
+		h := report.NewHandover(problems, events, req.minSeverity, from, now)
+		h.Truncated = truncated
+		text := h.Markdown(tf)
+		msg := HandoverWrittenMsg{Path: req.path, Text: text, Open: len(h.Open)}
+		if req.path != "" {
+			msg.Err = os.WriteFile(req.path, []byte(text), 0o600)
+		}
+		return msg
+	}
+}
+
+// handleHandoverWrittenMsg copies a handover summary to the clipboard, or
+// reports it written to a file.
+func (m Model) handleHandoverWrittenMsg(msg HandoverWrittenMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	switch {
+	case msg.Err != nil:
+		return m, m.notifyError("Could not write the handover", msg.Err)
+	case msg.Text == "":
+		return m, nil
+	case msg.Path != "":
+		return m, m.notify(notify.Success, fmt.Sprintf("Wrote the handover to %s (%d open problems)", msg.Path, msg.Open))
+	}
+	m.copyText(msg.Text)
+	return m, m.notify(notify.Success, fmt.Sprintf("Copied the handover to the clipboard (%d open problems)", msg.Open))
+}
@@ -0,0 +1,288 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/detail"
+	"github.com/harpchad/chotko/internal/components/heatmap"
+	"github.com/harpchad/chotko/internal/config"
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/report"
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// maxHeatmapHosts is how many hosts ":heatmap" loads the history of at most;
+// the screen usually shows fewer.
+const maxHeatmapHosts = 100
+
+// heatmapRequest is what ":heatmap" maps: when the problems of hosts were
+// open, or else the values of the item selected on the Graphs tab, over
+// window on the hosts matching pattern, all if empty.
+type heatmapRequest struct {
+	problems bool
+	window   time.Duration
+	pattern  string
+}
+
+// parseHeatmapArgs parses the arguments of ":heatmap [problems] [WINDOW]
+// [HOSTS]", in any order; the window defaults to window.
+func parseHeatmapArgs(args []string, window time.Duration) (heatmapRequest, error) {
+	req := heatmapRequest{window: window}
+	for _, arg := range args {
+		if arg == "problems" {
+			req.problems = true
+			continue
+		}
+		if d, err := format.ParseSpan(arg); err == nil {
+			req.window = d
+			continue
+		}
+		if _, err := path.Match(arg, ""); err != nil || req.pattern != "" {
+			return req, errors.New("usage: :heatmap [problems] [WINDOW] [HOSTS], e.g. :heatmap 12h web*")
+		}
+		req.pattern = strings.ToLower(arg)
+	}
+	return req, nil
+}
+
+// handleHeatmapCommand maps hosts over time, e.g. ":heatmap 12h web*" for
+// the values of the item selected on the Graphs tab on the web servers, and
+// ":heatmap problems 1d" for when hosts had problems.
+func (m *Model) handleHeatmapCommand(cmd string) tea.Cmd {
+	args := strings.Fields(strings.TrimPrefix(cmd, "heatmap"))
+	window := time.Duration(m.config.GetHistoryHours()) * time.Hour
+	if slices.Contains(args, "problems") {
+		window = m.eventScope.Window
+	}
+	req, err := parseHeatmapArgs(args, window)
+	if err != nil {
+		m.statusBar.SetStatus(capitalize(err.Error()))
+		return nil
+	}
+	if req.problems {
+		return m.loadProblemHeatmap(req)
+	}
+	return m.loadItemHeatmap(req)
+}
+
+// loadItemHeatmap loads the history of the selected item's key on the hosts
+// matching the request's pattern.
+func (m *Model) loadItemHeatmap(req heatmapRequest) tea.Cmd {
+	item := m.graphList.SelectedItem()
+	if m.tabBar.Active() != TabGraphs || item == nil || !item.IsNumeric() {
+		m.statusBar.SetStatus("Select a numeric item on the Graphs tab, or use :heatmap problems")
+		return nil
+	}
+	items := comparedItems(m.items, item, req.pattern)
+	truncated := len(items) > maxHeatmapHosts
+	items = items[:min(len(items), maxHeatmapHosts)]
+	slices.SortStableFunc(items, func(a, b zabbix.Item) int {
+		return strings.Compare(strings.ToLower(a.HostName()), strings.ToLower(b.HostName()))
+	})
+
+	client := m.client
+	ctx := m.ctx
+	now := time.Now()
+	hours := int(math.Ceil(req.window.Hours()))
+	selected := *item
+	m.statusBar.SetStatus(fmt.Sprintf("Mapping %s on %d hosts...", item.Key, len(items)))
+
+	return func() tea.Msg {
+		msg := HeatmapLoadedMsg{From: now.Add(-req.window), Till: now, Item: &selected, Truncated: truncated}
+		if client == nil {
+			return msg
+		}
+		history, err := client.GetItemsHistory(ctx, items, hours)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		for _, i := range items {
+			msg.Series = append(msg.Series, detail.Series{Item: i, History: history[i.ItemID]})
+		}
+		return msg
+	}
+}
+
+// loadProblemHeatmap loads when the problems of the hosts matching the
+// request's pattern were open.
+func (m *Model) loadProblemHeatmap(req heatmapRequest) tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+	now := time.Now()
+	from := now.Add(-req.window)
+	m.statusBar.SetStatus(fmt.Sprintf("Mapping the problems of the last %s...", format.Span(req.window)))
+
+	return func() tea.Msg {
+		msg := HeatmapLoadedMsg{From: from, Till: now}
+		if client == nil {
+			return msg
+		}
+		problems, err := client.GetProblemsWithMinSeverity(ctx, 0)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		params := zabbix.EventHistoryParams{Limit: reportPageSize, TimeFrom: from.Unix(), TimeTill: now.Unix()}
+		events, truncated, err := fetchReportEvents(ctx, client, params)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		for _, s := range report.ProblemSpans(events, problems, from, now) {
+			if req.pattern == "" || nameMatches(s.Host, req.pattern) {
+				msg.Spans = append(msg.Spans, s)
+			}
+		}
+		msg.Truncated = truncated
+		return msg
+	}
+}
+
+// handleHeatmapLoadedMsg shows a heatmap of hosts, a row each, over time.
+func (m Model) handleHeatmapLoadedMsg(msg HeatmapLoadedMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not load the heatmap", msg.Err)
+	}
+
+	width := max(40, m.width-12)
+	var lines []string
+	var h heatmap.Heatmap
+	if msg.Item != nil {
+		if len(msg.Series) == 0 {
+			return m, nil
+		}
+		lines, h = m.itemHeatmap(msg, width)
+	} else {
+		if len(msg.Spans) == 0 {
+			m.statusBar.SetStatus(fmt.Sprintf("No problems in the last %s", format.Span(msg.Till.Sub(msg.From))))
+			return m, nil
+		}
+		lines, h = m.problemHeatmap(msg, width)
+	}
+	lines = append(lines, "")
+
+	// ShowText keeps the last lines that fit, so leave out the last hosts
+	rows := max(2, m.height-13-len(lines))
+	more := 0
+	if len(h.Rows) > rows {
+		more = len(h.Rows) - rows + 1
+		h.Rows = h.Rows[:rows-1]
+	}
+	body := h.Lines(m.timeFormat)
+	lines = append(lines, body[:len(body)-1]...)
+	if more > 0 {
+		lines = append(lines, m.styles.Subtle.Render(fmt.Sprintf("... and %d more hosts", more)))
+	}
+	lines = append(lines, body[len(body)-1])
+
+	m.showError = true
+	m.errorModal.ShowText("Heatmap", lines)
+	return m, nil
+}
+
+// itemHeatmap returns the heading and heatmap of the values of an item's
+// key on hosts, shaded from the lowest value to the highest.
+func (m Model) itemHeatmap(msg HeatmapLoadedMsg, width int) ([]string, heatmap.Heatmap) {
+	labels := make([]string, len(msg.Series))
+	for i := range msg.Series {
+		labels[i] = msg.Series[i].Item.HostName()
+	}
+	columns := heatmap.Columns(labels, width)
+	h := heatmap.Heatmap{From: msg.From, Till: msg.Till, Empty: m.styles.Subtle.Render("·")}
+	first := true
+	for i, s := range msg.Series {
+		cells := heatmap.Average(s.History, msg.From, msg.Till, columns)
+		for _, v := range cells {
+			if math.IsNaN(v) {
+				continue
+			}
+			if first {
+				h.Min, h.Max, first = v, v, false
+			}
+			h.Min, h.Max = min(h.Min, v), max(h.Max, v)
+		}
+		h.Rows = append(h.Rows, heatmap.Row{Label: labels[i], Cells: cells})
+	}
+	for _, shade := range []string{"░", "▒", "▓", "█"} {
+		h.Levels = append(h.Levels, m.styles.ChartLine.Render(shade))
+	}
+
+	units := msg.Item.Units
+	title := fmt.Sprintf("%s (%s) on %d hosts, the last %s", msg.Item.Name, msg.Item.Key, len(msg.Series), format.Span(msg.Till.Sub(msg.From)))
+	legend := fmt.Sprintf("%s %s  %s  %s %s  %s no data", h.Levels[0], format.Value(h.Min, units),
+		strings.Join(h.Levels[1:3], " "), h.Levels[3], format.Value(h.Max, units), h.Empty)
+	lines := []string{title, legend}
+	if msg.Truncated {
+		lines = append(lines, fmt.Sprintf("Only the first %d hosts were loaded; narrow them with :heatmap HOSTS", maxHeatmapHosts))
+	}
+	return lines, h
+}
+
+// problemHeatmap returns the heading and heatmap of when the problems of
+// hosts were open, colored by the worst open at the time.
+func (m Model) problemHeatmap(msg HeatmapLoadedMsg, width int) ([]string, heatmap.Heatmap) {
+	byHost := make(map[string][]report.Span)
+	for _, s := range msg.Spans {
+		byHost[s.Host] = append(byHost[s.Host], s)
+	}
+	labels := make([]string, 0, len(byHost))
+	for host := range byHost {
+		labels = append(labels, host)
+	}
+	slices.SortFunc(labels, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+
+	columns := heatmap.Columns(labels, width)
+	h := heatmap.Heatmap{From: msg.From, Till: msg.Till, Min: 0, Max: config.MaxSeverity, Empty: m.styles.Subtle.Render("·")}
+	for _, host := range labels {
+		h.Rows = append(h.Rows, heatmap.Row{Label: host, Cells: worstSeverities(byHost[host], msg.From, msg.Till, columns)})
+	}
+	legend := make([]string, 0, config.MaxSeverity+1)
+	for severity := range config.MaxSeverity + 1 {
+		h.Levels = append(h.Levels, m.styles.AlertSeverity[severity].Render("█"))
+		legend = append(legend, h.Levels[severity]+" "+theme.SeverityName(severity))
+	}
+
+	lines := []string{
+		fmt.Sprintf("Problems of %d hosts in the last %s", len(labels), format.Span(msg.Till.Sub(msg.From))),
+		strings.Join(legend, "  ") + "  " + h.Empty + " none",
+	}
+	if msg.Truncated {
+		lines = append(lines, "Only the latest events of the window were mapped")
+	}
+	return lines, h
+}
+
+// worstSeverities returns the highest severity of the spans open in each of
+// n buckets of equal length between from and till, NaN where none is.
+func worstSeverities(spans []report.Span, from, till time.Time, n int) []float64 {
+	cells := make([]float64, n)
+	for i := range cells {
+		cells[i] = math.NaN()
+	}
+	for _, s := range spans {
+		first, ok := heatmap.Bucket(s.Start, from, till, n)
+		last, ok2 := heatmap.Bucket(s.End, from, till, n)
+		if !ok || !ok2 {
+			continue
+		}
+		for i := first; i <= last; i++ {
+			if math.IsNaN(cells[i]) || float64(s.Severity) > cells[i] {
+				cells[i] = float64(s.Severity)
+			}
+		}
+	}
+	return cells
+}
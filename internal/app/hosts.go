@@ -0,0 +1,385 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/command"
+	"github.com/harpchad/chotko/internal/components/hosts"
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// cycleHostState switches the hosts shown between all, unavailable, of
+// unknown availability, in maintenance and disabled.
+func (m *Model) cycleHostState() {
+	f := m.hostList.StateFilter().Next()
+	m.hostList.SetStateFilter(f)
+	if f == hosts.StateAll {
+		m.statusBar.SetStatus("Hosts: all")
+	} else {
+		m.statusBar.SetStatus("Hosts: " + f.Label() + " only")
+	}
+	m.showSelectedHost()
+}
+
+// cycleHostSort switches the order of the hosts between name, availability,
+// group and problem count.
+func (m *Model) cycleHostSort() {
+	o := m.hostList.SortOrder().Next()
+	m.hostList.SetSortOrder(o)
+	m.statusBar.SetStatus("Hosts sorted by " + o.String())
+	m.showSelectedHost()
+}
+
+// showSelectedHost shows the selected host in the detail pane, or clears it
+// when no host is shown.
+func (m *Model) showSelectedHost() {
+	if selected := m.hostList.Selected(); selected != nil {
+		m.detailPane.SetHost(selected)
+	} else {
+		m.detailPane.Clear()
+	}
+}
+
+// handleHostGroupBy shows hosts under their host groups with
+// ":group-by hostgroup", or as a list with ":group-by none".
+func (m *Model) handleHostGroupBy(parts []string) {
+	if len(parts) != 2 || (parts[1] != "hostgroup" && parts[1] != "none") {
+		current := "none"
+		if m.hostList.TreeView() {
+			current = "hostgroup"
+		}
+		m.statusBar.SetStatus("Usage: :group-by hostgroup|none on the Hosts tab (currently " + current + ")")
+		return
+	}
+	tree := parts[1] == "hostgroup"
+	m.hostList.SetTreeView(tree)
+	if selected := m.hostList.Selected(); selected != nil {
+		m.detailPane.SetHost(selected)
+	}
+	if tree {
+		m.statusBar.SetStatus("Hosts grouped by host group")
+	} else {
+		m.statusBar.SetStatus("Hosts not grouped")
+	}
+}
+
+// maintenancePresets are the lengths of maintenance offered by the
+// maintenance key, chosen with 1, 2 and 3.
+var maintenancePresets = []time.Duration{30 * time.Minute, time.Hour, 4 * time.Hour}
+
+// maintenanceRequest is a maintenance for a host awaiting its length.
+type maintenanceRequest struct {
+	hostID   string
+	hostName string
+	noData   bool // Pause data collection during the maintenance
+}
+
+// startMaintenancePrompt asks for the length of a maintenance for the
+// selected host.
+func (m *Model) startMaintenancePrompt() {
+	if m.tabBar.Active() != TabHosts {
+		return
+	}
+	host := m.hostList.Selected()
+	if host == nil {
+		return
+	}
+	m.pendingMaintenance = &maintenanceRequest{hostID: host.HostID, hostName: host.DisplayName()}
+	m.statusBar.SetStatus(m.maintenancePrompt())
+}
+
+// maintenancePrompt returns the status bar prompt for the pending
+// maintenance.
+func (m *Model) maintenancePrompt() string {
+	req := m.pendingMaintenance
+	presets := make([]string, len(maintenancePresets))
+	for i, d := range maintenancePresets {
+		presets[i] = fmt.Sprintf("%d) %s", i+1, format.Span(d))
+	}
+	collection := "on"
+	if req.noData {
+		collection = "off"
+	}
+	return fmt.Sprintf("Maintenance for %s: %s; d) data collection %s; Esc cancel",
+		truncate(req.hostName, 30), strings.Join(presets, " "), collection)
+}
+
+// handleMaintenanceKey handles the keys of the maintenance prompt: a
+// preset number creates the maintenance, d toggles data collection.
+func (m Model) handleMaintenanceKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch s := msg.String(); s {
+	case "d", "D":
+		req := *m.pendingMaintenance
+		req.noData = !req.noData
+		m.pendingMaintenance = &req
+		m.statusBar.SetStatus(m.maintenancePrompt())
+	case "esc", "n", "N":
+		m.pendingMaintenance = nil
+		m.statusBar.SetStatus("Canceled")
+	default:
+		i, err := strconv.Atoi(s)
+		if err != nil || i < 1 || i > len(maintenancePresets) {
+			// Ignore other keys while awaiting the length
+			return m, nil
+		}
+		req := *m.pendingMaintenance
+		m.pendingMaintenance = nil
+		m.statusBar.SetStatus(fmt.Sprintf("Putting %s in maintenance...", req.hostName))
+		return m, m.createMaintenance(req, maintenancePresets[i-1])
+	}
+	return m, nil
+}
+
+// createMaintenance creates a one-time maintenance for a host, named after
+// the host and its length so that it can be found in the frontend.
+func (m *Model) createMaintenance(req maintenanceRequest, duration time.Duration) tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+	name := fmt.Sprintf("%s for %s from %s (chotko)",
+		req.hostName, format.Span(duration), time.Now().Format(format.DefaultTimeLayout))
+
+	return func() tea.Msg {
+		if client == nil {
+			return MaintenanceResultMsg{HostName: req.hostName, Duration: duration}
+		}
+		_, err := client.CreateHostMaintenance(ctx, req.hostID, name, duration, !req.noData)
+		return MaintenanceResultMsg{HostName: req.hostName, Duration: duration, Err: err}
+	}
+}
+
+// handleMaintenanceResultMsg reports a created maintenance and reloads the
+// hosts to show it.
+func (m Model) handleMaintenanceResultMsg(msg MaintenanceResultMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not put "+msg.HostName+" in maintenance", msg.Err)
+	}
+	notice := m.notify(notify.Success, fmt.Sprintf("%s in maintenance for %s", msg.HostName, format.Span(msg.Duration)))
+	return m, tea.Batch(notice, m.loadHosts())
+}
+
+// cloneRequest is a host to clone awaiting the name of the clone.
+type cloneRequest struct {
+	hostID   string
+	hostName string
+}
+
+// startClonePrompt asks for the name and address of a clone of the
+// selected host.
+func (m *Model) startClonePrompt() {
+	host := m.hostList.Selected()
+	if host == nil {
+		return
+	}
+	m.pendingClone = &cloneRequest{hostID: host.HostID, hostName: host.DisplayName()}
+	m.mode = ModeCloneHost
+	m.commandInput.SetMode(command.ModeCloneHost)
+	m.statusBar.SetStatus("Cloning " + host.DisplayName())
+}
+
+// parseCloneTarget parses the name of a clone and its optional address,
+// separated by spaces.
+func parseCloneTarget(s string) (name, address string, err error) {
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 1:
+		return fields[0], "", nil
+	case 2:
+		return fields[0], fields[1], nil
+	}
+	return "", "", fmt.Errorf("enter a host name, then optionally an IP or DNS name")
+}
+
+// cloneHost creates a host named name as a copy of the host of req, on
+// address if not empty.
+func (m *Model) cloneHost(req cloneRequest, name, address string) tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+	m.statusBar.SetStatus(fmt.Sprintf("Cloning %s as %s...", req.hostName, name))
+
+	return func() tea.Msg {
+		if client == nil {
+			return HostClonedMsg{Source: req.hostName, Name: name}
+		}
+		clone, err := client.CloneHost(ctx, req.hostID, name, address)
+		return HostClonedMsg{Source: req.hostName, Name: name, Clone: clone, Err: err}
+	}
+}
+
+// handleHostClonedMsg reports a cloned host and reloads the hosts to show
+// it.
+func (m Model) handleHostClonedMsg(msg HostClonedMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not clone "+msg.Source, msg.Err)
+	}
+	text := fmt.Sprintf("Cloned %s as %s", msg.Source, msg.Name)
+	if msg.Clone != nil && len(msg.Clone.SkippedMacros) > 0 {
+		text += "; set its secret macros: " + strings.Join(msg.Clone.SkippedMacros, ", ")
+	}
+	return m, tea.Batch(m.notify(notify.Success, text), m.loadHosts())
+}
+
+// hostMaintenanceIDs returns the IDs of the maintenances hosts are in.
+func hostMaintenanceIDs(hosts []zabbix.Host) []string {
+	var ids []string
+	for _, h := range hosts {
+		if h.InMaintenance() && h.MaintenanceID != "" && !slices.Contains(ids, h.MaintenanceID) {
+			ids = append(ids, h.MaintenanceID)
+		}
+	}
+	return ids
+}
+
+// toggleDiagnostics loads the availability details of the selected host
+// into the detail pane, or shows its detail again if they are shown.
+func (m *Model) toggleDiagnostics() tea.Cmd {
+	if m.tabBar.Active() != TabHosts {
+		return nil
+	}
+	host := m.hostList.Selected()
+	if host == nil {
+		return nil
+	}
+	if m.detailPane.DiagnosedHost() == host.HostID {
+		m.detailPane.CloseDiagnostics()
+		return nil
+	}
+	m.statusBar.SetStatus("Diagnosing " + host.DisplayName() + "...")
+	return m.loadHostDiagnostics(host.HostID)
+}
+
+// loadHostDiagnostics fetches the errors of a host's interfaces and when
+// its agents last sent data.
+func (m *Model) loadHostDiagnostics(hostID string) tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+
+	return func() tea.Msg {
+		if client == nil {
+			return HostDiagnosticsLoadedMsg{HostID: hostID}
+		}
+		d, err := client.GetHostDiagnostics(ctx, hostID)
+		return HostDiagnosticsLoadedMsg{HostID: hostID, Diagnostics: d, Err: err}
+	}
+}
+
+// handleHostDiagnosticsLoadedMsg shows the diagnostics of a host if it is
+// still selected.
+func (m Model) handleHostDiagnosticsLoadedMsg(msg HostDiagnosticsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.statusBar.SetStatus(fmt.Sprintf("Could not diagnose the host: %v", msg.Err))
+		return m, nil
+	}
+	selected := m.hostList.Selected()
+	if msg.Diagnostics == nil || m.tabBar.Active() != TabHosts || selected == nil || selected.HostID != msg.HostID {
+		return m, nil
+	}
+	if m.detailPane.DiagnosedHost() != msg.HostID {
+		m.statusBar.SetStatus("Diagnostics of " + selected.DisplayName() + "; D shows its detail again")
+	}
+	m.detailPane.SetDiagnostics(msg.Diagnostics)
+	return m, nil
+}
+
+// forceAvailabilityCheck asks Zabbix to poll the selected host's
+// unavailable interfaces now rather than at their next retry.
+func (m *Model) forceAvailabilityCheck() tea.Cmd {
+	if m.tabBar.Active() != TabHosts {
+		return nil
+	}
+	host := m.hostList.Selected()
+	if host == nil {
+		return nil
+	}
+	client := m.client
+	ctx := m.ctx
+	hostID, hostName := host.HostID, host.DisplayName()
+	m.statusBar.SetStatus("Checking " + hostName + "...")
+
+	return func() tea.Msg {
+		if client == nil {
+			return AvailabilityCheckMsg{HostName: hostName}
+		}
+		d, err := client.GetHostDiagnostics(ctx, hostID)
+		if err != nil {
+			return AvailabilityCheckMsg{HostName: hostName, Err: err}
+		}
+		items := d.AvailabilityCheckItems()
+		if len(items) > 0 {
+			err = client.CheckItemsNow(ctx, items)
+		}
+		return AvailabilityCheckMsg{HostName: hostName, Items: len(items), Err: err}
+	}
+}
+
+// handleAvailabilityCheckMsg reports the requested availability check.
+func (m Model) handleAvailabilityCheckMsg(msg AvailabilityCheckMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	switch {
+	case msg.Err != nil:
+		return m, m.notifyError("Could not check "+msg.HostName+" now", msg.Err)
+	case msg.Items == 0:
+		return m, m.notify(notify.Info, "No polled items to check on the interfaces of "+msg.HostName)
+	case msg.Items == 1:
+		return m, m.notify(notify.Success, "Checking 1 interface of "+msg.HostName+" now; refresh for the result")
+	}
+	return m, m.notify(notify.Success, fmt.Sprintf("Checking %d interfaces of %s now; refresh for the result", msg.Items, msg.HostName))
+}
+
+// resolveTimeout is how long resolving a host's DNS name may take.
+const resolveTimeout = 5 * time.Second
+
+// copyHostAddress copies the address of the selected host's default
+// interface to the clipboard, resolving it first when Zabbix connects to
+// its DNS name.
+func (m *Model) copyHostAddress() tea.Cmd {
+	host := m.hostList.Selected()
+	if host == nil {
+		m.statusBar.SetStatus("Select a host to copy its address")
+		return nil
+	}
+	iface := host.MainInterface()
+	if iface == nil || iface.Address() == "" {
+		m.statusBar.SetStatus(host.DisplayName() + " has no interface address")
+		return nil
+	}
+	if !iface.UsesDNS() {
+		m.copyText(iface.Address())
+		m.statusBar.SetStatus("Copied " + iface.Address())
+		return nil
+	}
+
+	name := iface.DNS
+	lookup := m.lookupHost
+	ctx := m.ctx
+	m.statusBar.SetStatus("Resolving " + name + "...")
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+		defer cancel()
+		addrs, err := lookup(ctx, name)
+		return AddressResolvedMsg{Name: name, Addresses: addrs, Err: err}
+	}
+}
+
+// handleAddressResolvedMsg copies the first address a host's DNS name
+// resolves to, or the name itself if it does not resolve.
+func (m Model) handleAddressResolvedMsg(msg AddressResolvedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil || len(msg.Addresses) == 0 {
+		m.copyText(msg.Name)
+		m.statusBar.SetStatus("Could not resolve " + msg.Name + "; copied the name")
+		return m, nil
+	}
+	m.copyText(msg.Addresses[0])
+	m.statusBar.SetStatus("Copied " + msg.Addresses[0] + " (" + msg.Name + ")")
+	return m, nil
+}
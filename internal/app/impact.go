@@ -0,0 +1,147 @@
+package app
+
+import (
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/detail"
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// impactWindow is how soon after a problem others on related hosts must
+// start to be shown as possibly caused by it.
+const impactWindow = 5 * time.Minute
+
+// problemTrigger returns the ID of the trigger that raised a problem.
+func problemTrigger(p *zabbix.Problem) string {
+	if p.RelatedObject.TriggerID != "" {
+		return p.RelatedObject.TriggerID
+	}
+	return p.ObjectID
+}
+
+// loadTriggerRelations fetches the dependencies and host groups of the
+// triggers of the loaded problems, and of the triggers they depend on,
+// unless they are already known.
+func (m *Model) loadTriggerRelations() tea.Cmd {
+	if m.triggerRelations == nil {
+		m.triggerRelations = make(map[string]zabbix.Trigger)
+	}
+
+	var ids []string
+	want := func(id string) {
+		if _, ok := m.triggerRelations[id]; !ok && id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	for i := range m.problems {
+		want(problemTrigger(&m.problems[i]))
+	}
+	for _, t := range m.triggerRelations {
+		for _, d := range t.Dependencies {
+			want(d.TriggerID)
+		}
+	}
+	if len(ids) == 0 || m.client == nil {
+		return nil
+	}
+
+	// Mark them as known, so they are requested once even if the call fails
+	for _, id := range ids {
+		m.triggerRelations[id] = zabbix.Trigger{TriggerID: id}
+	}
+	client := m.client
+	ctx := m.ctx
+	return func() tea.Msg {
+		triggers, err := client.GetTriggerRelations(ctx, ids)
+		return TriggerRelationsLoadedMsg{Triggers: triggers, Err: err}
+	}
+}
+
+// handleTriggerRelationsLoadedMsg stores loaded trigger relations and
+// fetches those of the triggers they depend on.
+func (m Model) handleTriggerRelationsLoadedMsg(msg TriggerRelationsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		// The hints are optional; the problems show without them
+		return m, nil
+	}
+	for _, t := range msg.Triggers {
+		m.triggerRelations[t.TriggerID] = t
+	}
+	if m.tabBar.Active() == TabAlerts {
+		m.setDetailProblem(m.alertList.Selected())
+	}
+	return m, m.loadTriggerRelations()
+}
+
+// setDetailProblem shows a problem in the detail pane, with the other
+// active problems related to it.
+func (m *Model) setDetailProblem(p *zabbix.Problem) {
+	m.detailPane.SetProblem(p)
+	if p != nil {
+		m.detailPane.SetImpact(m.impactOf(p))
+	}
+}
+
+// dependsOn returns the triggers a trigger depends on, directly or through
+// others.
+func (m *Model) dependsOn(triggerID string) map[string]bool {
+	found := make(map[string]bool)
+	queue := []string{triggerID}
+	for len(queue) > 0 {
+		t := m.triggerRelations[queue[0]]
+		queue = queue[1:]
+		for _, d := range t.Dependencies {
+			if !found[d.TriggerID] {
+				found[d.TriggerID] = true
+				queue = append(queue, d.TriggerID)
+			}
+		}
+	}
+	return found
+}
+
+// impactOf relates a problem to the other active problems: those of
+// triggers it depends on, those of triggers depending on it, and those on
+// the same host or hosts sharing a group that started shortly after it.
+func (m *Model) impactOf(p *zabbix.Problem) detail.Impact {
+	impact := detail.Impact{Window: format.Span(impactWindow)}
+	trigger := problemTrigger(p)
+	ancestors := m.dependsOn(trigger)
+	groups := m.triggerRelations[trigger].Groups
+
+	for _, q := range m.problems {
+		if q.EventID == p.EventID {
+			continue
+		}
+		other := problemTrigger(&q)
+		switch {
+		case ancestors[other]:
+			impact.DependsOn = append(impact.DependsOn, q)
+		case m.dependsOn(other)[trigger]:
+			impact.Dependents = append(impact.Dependents, q)
+		case m.relatedHosts(p, &q, groups):
+			if after := q.StartTime().Sub(p.StartTime()); after >= 0 && after <= impactWindow {
+				impact.Related = append(impact.Related, q)
+			}
+		}
+	}
+	return impact
+}
+
+// relatedHosts reports whether q is on the same host as p, or on a host in
+// one of p's host groups.
+func (m *Model) relatedHosts(p, q *zabbix.Problem, groups []zabbix.HostGroup) bool {
+	if len(p.Hosts) > 0 && len(q.Hosts) > 0 && p.Hosts[0].HostID == q.Hosts[0].HostID {
+		return true
+	}
+	for _, g := range m.triggerRelations[problemTrigger(q)].Groups {
+		if slices.ContainsFunc(groups, func(h zabbix.HostGroup) bool { return h.GroupID == g.GroupID }) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// handleEditInterfaces opens the interface editor of the selected host.
+func (m Model) handleEditInterfaces() (tea.Model, tea.Cmd, bool) {
+	if m.tabBar.Active() != TabHosts {
+		return m, nil, true
+	}
+	if host := m.hostList.Selected(); host != nil {
+		return m, m.loadHostInterfaces(host.HostID), true
+	}
+	return m, nil, true
+}
+
+// loadHostInterfaces fetches the interfaces of a host with their SNMP
+// details.
+func (m *Model) loadHostInterfaces(hostID string) tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+
+	return func() tea.Msg {
+		if client == nil {
+			return HostInterfacesLoadedMsg{HostID: hostID}
+		}
+		ifaces, err := client.GetHostInterfaces(ctx, hostID)
+		return HostInterfacesLoadedMsg{HostID: hostID, Interfaces: ifaces, Err: err}
+	}
+}
+
+// handleHostInterfacesLoadedMsg shows the loaded interfaces in the editor.
+func (m Model) handleHostInterfacesLoadedMsg(msg HostInterfacesLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.notifyError("Could not load interfaces", msg.Err)
+	}
+	if host := m.findHostByID(msg.HostID); host != nil {
+		m.editorPane.ShowHostInterfaces(host, msg.Interfaces)
+		m.showEditor = true
+	}
+	return m, nil
+}
+
+// updateInterface saves an interface edited in the editor.
+func (m *Model) updateInterface(hostID string, iface zabbix.Interface) tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+
+	return func() tea.Msg {
+		if client == nil {
+			return InterfaceUpdatedMsg{HostID: hostID}
+		}
+		return InterfaceUpdatedMsg{HostID: hostID, Err: client.UpdateInterface(ctx, iface)}
+	}
+}
+
+// handleInterfaceUpdatedMsg reports a saved interface and reloads the
+// hosts to show it.
+func (m Model) handleInterfaceUpdatedMsg(msg InterfaceUpdatedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.notifyError("Could not update the interface", msg.Err)
+	}
+	return m, tea.Batch(m.notify(notify.Success, "Interface updated"), m.loadHosts())
+}
@@ -0,0 +1,281 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/detail"
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// toggleItem enables the selected item of the Graphs tab if it is disabled,
+// or disables it.
+func (m *Model) toggleItem() tea.Cmd {
+	item := m.graphList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	client := m.client
+	ctx := m.ctx
+	itemID, itemName := item.ItemID, item.Name
+	enable := item.IsDisabled()
+
+	return func() tea.Msg {
+		status := zabbix.ItemStatusDisabled
+		if enable {
+			status = zabbix.ItemStatusEnabled
+		}
+		if client == nil {
+			return ItemUpdateResultMsg{ItemID: itemID, ItemName: itemName, Status: status}
+		}
+
+		var err error
+		if enable {
+			err = client.EnableItem(ctx, itemID)
+		} else {
+			err = client.DisableItem(ctx, itemID)
+		}
+		return ItemUpdateResultMsg{ItemID: itemID, ItemName: itemName, Status: status, Err: err}
+	}
+}
+
+// handleItemUpdateResultMsg shows the new status of an item. Disabled items
+// stay listed until the next refresh, so they can be enabled again.
+func (m Model) handleItemUpdateResultMsg(msg ItemUpdateResultMsg) (tea.Model, tea.Cmd) {
+	action := "disable"
+	if msg.Status == zabbix.ItemStatusEnabled {
+		action = "enable"
+	}
+	if msg.Err != nil {
+		return m, m.notifyError("Could not "+action+" "+msg.ItemName, msg.Err)
+	}
+
+	m.graphList.SetItemStatus(msg.ItemID, msg.Status)
+	if m.tabBar.Active() == TabGraphs {
+		if selected := m.graphList.SelectedItem(); selected != nil && selected.ItemID == msg.ItemID {
+			m.detailPane.SetItem(selected, m.graphList.GetHistory(selected.ItemID))
+		}
+	}
+	return m, m.notify(notify.Success, msg.ItemName+" "+actionDone[action])
+}
+
+// checkItemNow asks Zabbix to collect the selected item of the Graphs tab
+// now rather than at its next scheduled check.
+func (m *Model) checkItemNow() tea.Cmd {
+	item := m.graphList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	client := m.client
+	ctx := m.ctx
+	itemID, itemName := item.ItemID, item.Name
+	m.statusBar.SetStatus("Checking " + itemName + "...")
+
+	return func() tea.Msg {
+		if client == nil {
+			return ItemCheckResultMsg{ItemName: itemName}
+		}
+		err := client.CheckItemsNow(ctx, []string{itemID})
+		return ItemCheckResultMsg{ItemName: itemName, Err: err}
+	}
+}
+
+// handleItemCheckResultMsg reports the requested item check.
+func (m Model) handleItemCheckResultMsg(msg ItemCheckResultMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not check "+msg.ItemName+" now", msg.Err)
+	}
+	return m, m.notify(notify.Success, "Checking "+msg.ItemName+" now; refresh for the new value")
+}
+
+// handleReferenceSelectedMsg selects the first item on the Graphs tab that
+// the formula of a calculated or aggregate item references, and moves the
+// focus to it, loading its host's history if needed.
+func (m Model) handleReferenceSelectedMsg(msg detail.ReferenceSelectedMsg) (tea.Model, tea.Cmd) {
+	ownHost := technicalHostName(msg.Item)
+	item := m.graphList.SelectItem(func(i *zabbix.Item) bool {
+		return msg.Reference.Matches(ownHost, technicalHostName(i), i.Key)
+	})
+	if item == nil {
+		m.statusBar.SetStatus(msg.Reference.String() + " is not listed on the Graphs tab")
+		return m, nil
+	}
+	return m, m.showGraphItem(item)
+}
+
+// showGraphItem shows the item selected on the Graphs tab in the detail
+// pane and moves the focus to the list, loading its host's history if
+// needed.
+func (m *Model) showGraphItem(item *zabbix.Item) tea.Cmd {
+	m.detailPane.SetItem(item, m.graphList.GetHistory(item.ItemID))
+	m.setFocus(PaneList)
+	baselines := m.maybeLoadBaselines()
+	hostID := item.GetHostID()
+	if m.graphList.HasHostHistory(hostID) || m.graphList.IsHostLoading(hostID) {
+		return baselines
+	}
+	m.graphList.SetHostLoading(hostID, true)
+	return tea.Batch(baselines, m.loadHostHistory(hostID))
+}
+
+// technicalHostName returns the technical name of an item's host, which
+// formulas reference it by.
+func technicalHostName(item *zabbix.Item) string {
+	if len(item.Hosts) == 0 {
+		return ""
+	}
+	return item.Hosts[0].Host
+}
+
+// followInterval is how often a followed log item is polled.
+const followInterval = 2 * time.Second
+
+// toggleUnsupportedItems shows only the unsupported items on the Graphs
+// tab, listed under their hosts, or all items again.
+func (m *Model) toggleUnsupportedItems() tea.Cmd {
+	only := !m.graphList.UnsupportedOnly()
+	m.graphList.SetUnsupportedOnly(only)
+	if selected := m.graphList.SelectedItem(); selected != nil {
+		m.detailPane.SetItem(selected, m.graphList.GetHistory(selected.ItemID))
+	}
+	if !only {
+		m.statusBar.SetStatus("Items: all")
+		return nil
+	}
+
+	total, _ := m.graphList.Count()
+	if total == 0 {
+		m.statusBar.SetStatus("No unsupported items")
+		return nil
+	}
+	m.statusBar.SetStatus(fmt.Sprintf("Items: %d unsupported only", total))
+	var cmds []tea.Cmd
+	for _, hostID := range m.graphList.UnloadedHosts() {
+		cmds = append(cmds, m.loadHostHistory(hostID))
+	}
+	return tea.Batch(cmds...)
+}
+
+// maxFollowLines is how many values of a followed log item are kept.
+const maxFollowLines = 1000
+
+// logFollow is a log item whose new values are polled, like tail -f.
+type logFollow struct {
+	item zabbix.Item
+	seq  int
+}
+
+// toggleFollow starts following the selected text item of the Graphs tab,
+// or stops following.
+func (m *Model) toggleFollow() tea.Cmd {
+	if m.follow != nil {
+		m.stopFollow()
+		return nil
+	}
+	item := m.graphList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	if item.IsNumeric() {
+		m.statusBar.SetStatus("Only log and text items can be followed")
+		return nil
+	}
+
+	m.followSeq++
+	m.follow = &logFollow{item: *item, seq: m.followSeq}
+	m.detailPane.SetFollowing(true)
+	m.detailPane.GoToBottom()
+	m.statusBar.SetStatus("Following " + item.Name + "; f stops")
+	return m.pollFollow()
+}
+
+// stopFollow stops polling the followed log item.
+func (m *Model) stopFollow() {
+	m.statusBar.SetStatus("Stopped following " + m.follow.item.Name)
+	m.follow = nil
+	m.detailPane.SetFollowing(false)
+}
+
+// pollFollow fetches the values of the followed log item newer than those
+// shown.
+func (m *Model) pollFollow() tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+	f := *m.follow
+	last := zabbix.History{Clock: strconv.FormatInt(time.Now().Unix(), 10)}
+	if history := m.graphList.GetHistory(f.item.ItemID); len(history) > 0 {
+		last = history[len(history)-1]
+	}
+
+	return func() tea.Msg {
+		if client == nil {
+			return LogLinesMsg{ItemID: f.item.ItemID, Seq: f.seq}
+		}
+		history, err := client.GetHistoryAfter(ctx, f.item, last)
+		return LogLinesMsg{ItemID: f.item.ItemID, Seq: f.seq, History: history, Err: err}
+	}
+}
+
+// handleLogLinesMsg appends new values of the followed log item and polls
+// again after followInterval.
+func (m Model) handleLogLinesMsg(msg LogLinesMsg) (tea.Model, tea.Cmd) {
+	if m.follow == nil || msg.Seq != m.follow.seq {
+		return m, nil
+	}
+	if msg.Err != nil {
+		name := m.follow.item.Name
+		m.stopFollow()
+		m.statusBar.SetStatus("Stopped following " + name + ": " + msg.Err.Error())
+		return m, nil
+	}
+
+	m.graphList.AppendHistory(msg.ItemID, msg.History, maxFollowLines)
+	if selected := m.graphList.SelectedItem(); selected != nil && selected.ItemID == msg.ItemID && m.tabBar.Active() == TabGraphs {
+		m.detailPane.SetItem(selected, m.graphList.GetHistory(msg.ItemID))
+	}
+
+	seq := msg.Seq
+	return m, tea.Tick(followInterval, func(time.Time) tea.Msg {
+		return FollowTickMsg{Seq: seq}
+	})
+}
+
+// handleFollowTickMsg polls the followed log item, unless it is no longer
+// shown.
+func (m Model) handleFollowTickMsg(msg FollowTickMsg) (tea.Model, tea.Cmd) {
+	if m.follow == nil || msg.Seq != m.follow.seq {
+		return m, nil
+	}
+	selected := m.graphList.SelectedItem()
+	if m.tabBar.Active() != TabGraphs || selected == nil || selected.ItemID != m.follow.item.ItemID {
+		m.stopFollow()
+		return m, nil
+	}
+	return m, m.pollFollow()
+}
+
+// handleHighlightCommand highlights the matches of a regular expression in
+// the values of text items, e.g. ":highlight error|fail", or removes the
+// highlight.
+func (m *Model) handleHighlightCommand(cmd string) {
+	pattern := strings.TrimSpace(strings.TrimPrefix(cmd, "highlight"))
+	if pattern == "" {
+		m.detailPane.SetHighlight(nil)
+		m.statusBar.SetStatus("Highlight cleared")
+		return
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		m.statusBar.SetStatus(fmt.Sprintf("Invalid pattern: %v", err))
+		return
+	}
+	m.detailPane.SetHighlight(re)
+	m.statusBar.SetStatus("Highlighting " + pattern)
+}
@@ -1,7 +1,13 @@
 // Package app contains the main application logic and UI model.
 package app
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/harpchad/chotko/internal/components/modal"
+)
 
 // KeyMap defines all key bindings for the application.
 type KeyMap struct {
@@ -22,6 +28,7 @@ type KeyMap struct {
 	Tab2    key.Binding
 	Tab3    key.Binding
 	Tab4    key.Binding
+	Tab5    key.Binding
 
 	// Pane navigation
 	NextPane key.Binding
@@ -31,12 +38,30 @@ type KeyMap struct {
 	Select      key.Binding
 	Acknowledge key.Binding
 	AckMessage  key.Binding
+	AckClose    key.Binding
+	Suppress    key.Binding
 	Refresh     key.Binding
+	OpenBrowser key.Binding
+	Explain     key.Binding
 
 	// Host editing
-	EditTriggers  key.Binding
-	EditMacros    key.Binding
-	ToggleMonitor key.Binding
+	EditTriggers   key.Binding
+	EditMacros     key.Binding
+	EditInterfaces key.Binding
+	ToggleMonitor  key.Binding
+	Maintenance    key.Binding
+	CloneHost      key.Binding
+
+	// Availability troubleshooting
+	Diagnose   key.Binding
+	ForceCheck key.Binding
+
+	// Graphs tab
+	Follow           key.Binding
+	UnsupportedItems key.Binding
+	ExportChart      key.Binding
+	Baseline         key.Binding
+	Compare          key.Binding
 
 	// Alert ignoring
 	Ignore      key.Binding
@@ -46,9 +71,25 @@ type KeyMap struct {
 	Filter         key.Binding
 	ClearFilter    key.Binding
 	SeverityFilter key.Binding
+	AckFilter      key.Binding
+
+	// Hosts tab
+	HostState   key.Binding
+	HostSort    key.Binding
+	CopyAddress key.Binding
+
+	// Events tab
+	EventWindow key.Binding
+	EventType   key.Binding
+	HostHistory key.Binding
+
+	// Display
+	ToggleTime  key.Binding
+	ToggleSound key.Binding
 
 	// Modes
 	Command key.Binding
+	Palette key.Binding
 	Help    key.Binding
 	Escape  key.Binding
 	Quit    key.Binding
@@ -97,8 +138,8 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("]/L", "next tab"),
 		),
 		PrevTab: key.NewBinding(
-			key.WithKeys("[", "H"),
-			key.WithHelp("[/H", "prev tab"),
+			key.WithKeys("["),
+			key.WithHelp("[", "prev tab"),
 		),
 		Tab1: key.NewBinding(
 			key.WithKeys("F1"),
@@ -116,6 +157,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("F4"),
 			key.WithHelp("F4", "Graphs tab"),
 		),
+		Tab5: key.NewBinding(
+			key.WithKeys("F5"),
+			key.WithHelp("F5", "Search tab"),
+		),
 
 		// Pane navigation
 		NextPane: key.NewBinding(
@@ -140,10 +185,26 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("A"),
 			key.WithHelp("A", "ack with message"),
 		),
+		AckClose: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "ack & close"),
+		),
+		Suppress: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "suppress until..."),
+		),
 		Refresh: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
 		),
+		OpenBrowser: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open in browser"),
+		),
+		Explain: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "why is it firing?"),
+		),
 
 		// Host editing
 		EditTriggers: key.NewBinding(
@@ -151,12 +212,56 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("t", "edit triggers"),
 		),
 		EditMacros: key.NewBinding(
-			key.WithKeys("m"),
-			key.WithHelp("m", "edit macros"),
+			key.WithKeys("$"),
+			key.WithHelp("$", "edit macros"),
+		),
+		EditInterfaces: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "edit interfaces"),
 		),
 		ToggleMonitor: key.NewBinding(
 			key.WithKeys("e"),
-			key.WithHelp("e", "enable/disable host"),
+			key.WithHelp("e", "enable/disable host or item"),
+		),
+		Maintenance: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "put host in maintenance"),
+		),
+		CloneHost: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "clone host"),
+		),
+
+		// Availability troubleshooting
+		Diagnose: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "diagnose host availability"),
+		),
+		ForceCheck: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "check host or item now"),
+		),
+
+		// Graphs tab
+		Follow: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "follow log item"),
+		),
+		UnsupportedItems: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "unsupported items only"),
+		),
+		ExportChart: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "export chart to CSV/PNG"),
+		),
+		Baseline: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "compare with 1d and 7d ago"),
+		),
+		Compare: key.NewBinding(
+			key.WithKeys("="),
+			key.WithHelp("=", "compare item across hosts"),
 		),
 
 		// Alert ignoring
@@ -182,12 +287,58 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("0", "1", "2", "3", "4", "5"),
 			key.WithHelp("0-5", "severity filter"),
 		),
+		AckFilter: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "all/unacked/my acks/assigned"),
+		),
+
+		// Hosts tab
+		HostState: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "host state filter"),
+		),
+		HostSort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort hosts"),
+		),
+		CopyAddress: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy host address"),
+		),
+
+		// Events tab
+		EventWindow: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "lookback window"),
+		),
+		EventType: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "problems/recoveries"),
+		),
+		HostHistory: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "host's event history"),
+		),
+
+		// Display
+		ToggleTime: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "relative/absolute time"),
+		),
+		ToggleSound: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "mute/unmute sound hooks"),
+		),
 
 		// Modes
 		Command: key.NewBinding(
 			key.WithKeys(":"),
 			key.WithHelp(":", "command mode"),
 		),
+		Palette: key.NewBinding(
+			key.WithKeys("ctrl+k"),
+			key.WithHelp("Ctrl+K", "command palette"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
@@ -216,16 +367,168 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		// Navigation
 		{k.Up, k.Down, k.PageUp, k.PageDown, k.Home, k.End},
 		// Tabs
-		{k.NextTab, k.PrevTab, k.Tab1, k.Tab2, k.Tab3, k.Tab4},
+		{k.NextTab, k.PrevTab, k.Tab1, k.Tab2, k.Tab3, k.Tab4, k.Tab5},
 		// Panes
 		{k.NextPane, k.PrevPane, k.Select},
 		// Actions
-		{k.Acknowledge, k.AckMessage, k.Refresh},
+		{k.Acknowledge, k.AckMessage, k.AckClose, k.HostHistory, k.Explain, k.OpenBrowser, k.Refresh},
 		// Host editing
-		{k.EditTriggers, k.EditMacros, k.ToggleMonitor},
+		{k.EditTriggers, k.EditMacros, k.EditInterfaces, k.ToggleMonitor, k.Maintenance, k.CloneHost},
+		// Availability troubleshooting
+		{k.Diagnose, k.ForceCheck},
+		// Graphs tab
+		{k.Follow, k.UnsupportedItems, k.ExportChart, k.Baseline, k.Compare},
 		// Alert ignoring
 		{k.Ignore, k.ListIgnores},
+		// Hosts tab
+		{k.HostState, k.HostSort, k.CopyAddress},
+		// Events tab
+		{k.EventWindow, k.EventType},
+		// Display
+		{k.ToggleTime, k.ToggleSound},
 		// Filtering & Modes
-		{k.Filter, k.ClearFilter, k.Command, k.Help, k.Quit},
+		{k.Filter, k.ClearFilter, k.AckFilter, k.Command, k.Palette, k.Help, k.Quit},
+	}
+}
+
+// commandHelp lists the command-mode commands shown on the help screen.
+var commandHelp = []modal.HelpEntry{
+	{Keys: ":help", Desc: "show this help"},
+	{Keys: ":refresh", Desc: "refresh data"},
+	{Keys: ":tutorial", Desc: "show the guided tour"},
+	{Keys: ":window D", Desc: "events lookback, e.g. 12h or 3d"},
+	{Keys: ":group-by G", Desc: "group alerts by trigger, host, tag:NAME or none; hosts by hostgroup"},
+	{Keys: ":assign [USER]", Desc: "assign the selected alerts, by default to you"},
+	{Keys: ":unassign", Desc: "unassign the selected alerts"},
+	{Keys: ":ignores", Desc: "list ignored alerts"},
+	{Keys: ":unignore N", Desc: "remove ignore rule"},
+	{Keys: ":muted", Desc: "edit the muted triggers"},
+	{Keys: ":muted show|hide", Desc: "show or hide muted alerts"},
+	{Keys: ":highlight RE", Desc: "highlight matches in item history; alone to clear"},
+	{Keys: ":dashboard [NAME]", Desc: "show a dashboard of the server"},
+	{Keys: ":log", Desc: "show recent API calls"},
+	{Keys: ":notifications", Desc: "show the last 50 action results"},
+	{Keys: ":stats", Desc: "show latencies and resource use"},
+	{Keys: ":queue", Desc: "show items late to be collected, by delay"},
+	{Keys: ":flapping [WINDOW] [N]", Desc: "show triggers with more than N problems in the window"},
+	{Keys: ":server-info", Desc: "show the server version, counts and housekeeping"},
+	{Keys: ":settings", Desc: "show the server's severities, working time and other settings"},
+	{Keys: ":role-filter [on|off]", Desc: "show the role filter, or turn its host groups on or off"},
+	{Keys: ":search TEXT", Desc: "search hosts, triggers, items and templates"},
+	{Keys: ":why", Desc: "evaluate the selected alert's trigger"},
+	{Keys: ":export [FILE]", Desc: "export the selected host to YAML, JSON or XML"},
+	{Keys: ":export-template NAME", Desc: "export a template to NAME.yaml"},
+	{Keys: ":export-maintenances [FILE]", Desc: "export the maintenances to an iCalendar file"},
+	{Keys: ":import FILE", Desc: "import a configuration file after showing the changes"},
+	{Keys: ":export-chart [csv|png|clipboard]", Desc: "export the selected item's chart"},
+	{Keys: ":compare [HOSTS]", Desc: "compare the selected item across hosts matching a pattern"},
+	{Keys: ":heatmap [problems] [WINDOW] [HOSTS]", Desc: "map the selected item's values, or problems, of hosts over time"},
+	{Keys: ":calendar [WINDOW]", Desc: "show when the selected host is in maintenance"},
+	{Keys: ":report [WINDOW] [FILE] [GROUP]", Desc: "write an incident timeline to a .md or .html file"},
+	{Keys: ":handover [SEVERITY] [WINDOW] [FILE]", Desc: "copy a shift handover summary, or write it to FILE"},
+	{Keys: ":tokens", Desc: "list your API tokens and when they expire"},
+	{Keys: ":tokens rotate", Desc: "replace the API token chotko uses"},
+	{Keys: ":debug on|off", Desc: "toggle API call logging"},
+	{Keys: ":quit", Desc: "quit"},
+}
+
+// bindingGroup is a titled group of bindings on the help screen.
+type bindingGroup struct {
+	title    string
+	bindings []key.Binding
+}
+
+// bindingGroups returns the bindings grouped as on the help screen.
+func (k KeyMap) bindingGroups() []bindingGroup {
+	return []bindingGroup{
+		{"Navigation", []key.Binding{k.Up, k.Down, k.Left, k.Right, k.PageUp, k.PageDown, k.Home, k.End}},
+		{"Tabs & Panes", []key.Binding{k.NextTab, k.PrevTab, k.Tab1, k.Tab2, k.Tab3, k.Tab4, k.Tab5, k.NextPane, k.PrevPane}},
+		{"Actions", []key.Binding{k.Select, k.Acknowledge, k.AckMessage, k.AckClose, k.Suppress, k.HostHistory, k.Explain, k.OpenBrowser, k.Refresh}},
+		{"Host Editing (Hosts tab)", []key.Binding{k.EditTriggers, k.EditMacros, k.EditInterfaces, k.ToggleMonitor, k.Maintenance, k.CloneHost}},
+		{"Availability (Hosts tab)", []key.Binding{k.Diagnose, k.ForceCheck}},
+		{"Items (Graphs tab)", []key.Binding{k.ToggleMonitor, k.ForceCheck, k.Follow, k.UnsupportedItems, k.ExportChart, k.Baseline, k.Compare}},
+		{"Alert Ignoring (Alerts tab)", []key.Binding{k.Ignore, k.ListIgnores}},
+		{"Hosts Tab", []key.Binding{k.HostState, k.HostSort, k.CopyAddress}},
+		{"Events Tab", []key.Binding{k.EventWindow, k.EventType, k.SeverityFilter}},
+		{"Filtering", []key.Binding{k.Filter, k.SeverityFilter, k.AckFilter, k.ClearFilter}},
+		{"Display", []key.Binding{k.ToggleTime, k.ToggleSound}},
+		{"General", []key.Binding{k.Command, k.Palette, k.Help, k.Escape, k.Quit}},
+	}
+}
+
+// HelpSections returns the help screen content, built from the bindings so
+// that it always shows the keys currently in effect.
+func (k KeyMap) HelpSections() []modal.HelpSection {
+	groups := k.bindingGroups()
+	sections := make([]modal.HelpSection, 0, len(groups)+1)
+	for _, g := range groups {
+		section := modal.HelpSection{Title: g.title}
+		for _, b := range g.bindings {
+			if !b.Enabled() {
+				continue
+			}
+			section.Entries = append(section.Entries, modal.HelpEntry{
+				Keys: keyLabel(b.Keys()),
+				Desc: b.Help().Desc,
+			})
+		}
+		sections = append(sections, section)
+	}
+	return append(sections, modal.HelpSection{Title: "Commands", Entries: commandHelp})
+}
+
+// keyNames maps key names to how they are shown on the help screen.
+var keyNames = map[string]string{
+	"up":        "↑",
+	"down":      "↓",
+	"left":      "←",
+	"right":     "→",
+	"pgup":      "PgUp",
+	"pgdown":    "PgDn",
+	"home":      "Home",
+	"end":       "End",
+	"enter":     "Enter",
+	"esc":       "Esc",
+	"tab":       "Tab",
+	"shift+tab": "Shift+Tab",
+	"space":     "Space",
+	"backspace": "Backspace",
+}
+
+// keyLabel formats the keys of a binding for display, e.g. "↑/k" or
+// "PgUp/Ctrl+U". A run of consecutive digits is shown as a range ("0-5").
+func keyLabel(keys []string) string {
+	if isDigitRun(keys) {
+		return keys[0] + "-" + keys[len(keys)-1]
+	}
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		switch {
+		case keyNames[k] != "":
+			labels[i] = keyNames[k]
+		case strings.HasPrefix(k, "ctrl+"):
+			labels[i] = "Ctrl+" + strings.ToUpper(strings.TrimPrefix(k, "ctrl+"))
+		case strings.HasPrefix(k, "alt+"):
+			labels[i] = "Alt+" + strings.TrimPrefix(k, "alt+")
+		default:
+			labels[i] = k
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+// isDigitRun reports whether keys are three or more consecutive digits.
+func isDigitRun(keys []string) bool {
+	if len(keys) < 3 {
+		return false
+	}
+	for i, k := range keys {
+		if len(k) != 1 || k[0] < '0' || k[0] > '9' {
+			return false
+		}
+		if i > 0 && k[0] != keys[i-1][0]+1 {
+			return false
+		}
 	}
+	return true
 }
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+func TestKeyLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		keys []string
+		want string
+	}{
+		{[]string{"up", "k"}, "↑/k"},
+		{[]string{"pgup", "ctrl+u"}, "PgUp/Ctrl+U"},
+		{[]string{"shift+tab"}, "Shift+Tab"},
+		{[]string{"0", "1", "2", "3", "4", "5"}, "0-5"},
+		{[]string{"1", "3"}, "1/3"},
+		{[]string{"F1"}, "F1"},
+	}
+
+	for _, tt := range tests {
+		if got := keyLabel(tt.keys); got != tt.want {
+			t.Errorf("keyLabel(%v) = %q, want %q", tt.keys, got, tt.want)
+		}
+	}
+}
+
+func TestHelpSections(t *testing.T) {
+	t.Parallel()
+
+	keys := DefaultKeyMap()
+	keys.Refresh = key.NewBinding(key.WithKeys("f5"), key.WithHelp("F5", "refresh"))
+	keys.Ignore.SetEnabled(false)
+
+	var found, ignored bool
+	for _, section := range keys.HelpSections() {
+		for _, e := range section.Entries {
+			if e.Desc == "refresh" && e.Keys == "f5" {
+				found = true
+			}
+			if e.Desc == "ignore alert" {
+				ignored = true
+			}
+		}
+	}
+	if !found {
+		t.Error("help should show the remapped refresh key")
+	}
+	if ignored {
+		t.Error("help should omit disabled bindings")
+	}
+}
@@ -0,0 +1,172 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// SetKiosk turns kiosk mode on for unattended wallboards: the list fills the
+// screen without the detail pane and command bar, the tabs configured under
+// kiosk rotate on a timer, as do its host groups once connected, and every
+// key but quit is ignored. Call it before the program starts.
+func (m *Model) SetKiosk(enabled bool) {
+	m.kiosk = enabled
+	m.kioskTabs = nil
+	if !enabled {
+		return
+	}
+	for _, name := range m.config.GetKioskTabs() {
+		for tab := range TabCount {
+			if sessionTabName(tab) == name {
+				m.kioskTabs = append(m.kioskTabs, tab)
+			}
+		}
+	}
+	if len(m.kioskTabs) == 0 {
+		m.kioskTabs = []int{TabAlerts}
+	}
+	m.tabBar.SetActive(m.kioskTabs[0])
+	m.setFocus(PaneList)
+	m.SetSize(m.width, m.height)
+}
+
+// tickKiosk schedules the next tab rotation, or returns nil outside kiosk
+// mode.
+func (m *Model) tickKiosk() tea.Cmd {
+	if !m.kiosk {
+		return nil
+	}
+	return tea.Tick(m.config.GetKioskInterval(), func(t time.Time) tea.Msg {
+		return KioskTickMsg{Time: t}
+	})
+}
+
+// handleKioskTickMsg shows the next kiosk tab.
+func (m Model) handleKioskTickMsg() (tea.Model, tea.Cmd) {
+	next := m.kioskTabs[0]
+	if i := slices.Index(m.kioskTabs, m.tabBar.Active()); i >= 0 {
+		next = m.kioskTabs[(i+1)%len(m.kioskTabs)]
+	}
+	// The tab is reloaded below, as its data may be of another host group
+	model, _ := m.switchTab(next)
+	updated, ok := model.(Model)
+	if !ok {
+		return model, nil
+	}
+	return updated, tea.Batch(updated.reloadCurrentTab(), updated.tickKiosk())
+}
+
+// reloadCurrentTab loads the data of the shown tab, as when the host groups
+// the lists are limited to change.
+func (m *Model) reloadCurrentTab() tea.Cmd {
+	if !m.connected {
+		return nil
+	}
+	m.loading = true
+	m.statusBar.SetLoading(true)
+	return tea.Batch(m.loadDataForCurrentTab()...)
+}
+
+// resolveKioskGroups looks up the host groups kiosk mode rotates through, or
+// returns nil if there are none or they are known.
+func (m *Model) resolveKioskGroups() tea.Cmd {
+	names := m.config.Kiosk.Groups
+	if !m.kiosk || len(names) == 0 || m.kioskGroups != nil {
+		return nil
+	}
+	client := m.client
+	ctx := m.ctx
+
+	return func() tea.Msg {
+		if client == nil {
+			return KioskGroupsMsg{}
+		}
+		groups, err := client.GetHostGroupsByName(ctx, names)
+		return KioskGroupsMsg{Groups: groups, Err: err}
+	}
+}
+
+// handleKioskGroupsMsg starts rotating through the host groups found, in
+// the order configured.
+func (m Model) handleKioskGroupsMsg(msg KioskGroupsMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.notifyError("Kiosk host groups not loaded", msg.Err)
+	}
+	var missing []string
+	for _, name := range m.config.Kiosk.Groups {
+		i := slices.IndexFunc(msg.Groups, func(g zabbix.HostGroup) bool { return g.Name == name })
+		if i < 0 {
+			missing = append(missing, name)
+			continue
+		}
+		m.kioskGroups = append(m.kioskGroups, msg.Groups[i])
+	}
+	var cmds []tea.Cmd
+	if len(missing) > 0 {
+		cmds = append(cmds, m.notify(notify.Error, "Host groups not found: "+strings.Join(missing, ", ")))
+	}
+	if len(m.kioskGroups) == 0 {
+		return m, tea.Batch(cmds...)
+	}
+	m.kioskGroup = 0
+	m.tabBar.SetLabel(m.kioskGroups[0].Name)
+	cmds = append(cmds, m.reloadCurrentTab(), m.tickKioskGroup())
+	return m, tea.Batch(cmds...)
+}
+
+// tickKioskGroup schedules showing the next host group, if there are more
+// than one.
+func (m *Model) tickKioskGroup() tea.Cmd {
+	if len(m.kioskGroups) < 2 {
+		return nil
+	}
+	return tea.Tick(m.config.GetKioskGroupInterval(), func(t time.Time) tea.Msg {
+		return KioskGroupTickMsg{Time: t}
+	})
+}
+
+// handleKioskGroupTickMsg shows the next host group.
+func (m Model) handleKioskGroupTickMsg() (tea.Model, tea.Cmd) {
+	if len(m.kioskGroups) == 0 {
+		return m, nil
+	}
+	m.kioskGroup = (m.kioskGroup + 1) % len(m.kioskGroups)
+	m.tabBar.SetLabel(m.kioskGroups[m.kioskGroup].Name)
+	return m, tea.Batch(m.reloadCurrentTab(), m.tickKioskGroup())
+}
+
+// kioskGroupIDs returns the host group the lists are limited to in kiosk
+// mode, or nil for all hosts.
+func (m *Model) kioskGroupIDs() []string {
+	if len(m.kioskGroups) == 0 {
+		return nil
+	}
+	return []string{m.kioskGroups[m.kioskGroup].GroupID}
+}
+
+// inGroups reports whether a host is in one of groupIDs, or groupIDs is
+// empty.
+func inGroups(host zabbix.Host, groupIDs []string) bool {
+	if len(groupIDs) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(host.Groups, func(g zabbix.HostGroup) bool {
+		return slices.Contains(groupIDs, g.GroupID)
+	})
+}
+
+// handleKioskInput ignores keys and clicks in kiosk mode, except quit.
+func (m Model) handleKioskInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && key.Matches(keyMsg, m.keys.Quit) {
+		m.Shutdown()
+		return m, tea.Quit
+	}
+	return m, nil
+}
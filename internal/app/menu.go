@@ -0,0 +1,151 @@
+package app
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/menu"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// handleRightClick selects the row under the mouse and opens the context
+// menu of its actions just below the click position.
+func (m Model) handleRightClick(mouseX, mouseY int) (tea.Model, tea.Cmd) {
+	row, ok := m.rowAt(mouseX, mouseY)
+	if !ok {
+		return m, nil
+	}
+	if m.focused != PaneList {
+		m.setFocus(PaneList)
+	}
+	switch m.tabBar.Active() {
+	case TabAlerts:
+		m.alertList.SetCursor(row)
+		if selected := m.alertList.Selected(); selected != nil {
+			m.setDetailProblem(selected)
+		}
+	case TabHosts:
+		m.hostList.SetCursor(row)
+		if selected := m.hostList.Selected(); selected != nil {
+			m.detailPane.SetHost(selected)
+		}
+	case TabEvents:
+		m.eventList.SetCursor(row)
+		if selected := m.eventList.Selected(); selected != nil {
+			m.detailPane.SetEvent(selected)
+		}
+	}
+	m.contextMenu.Show(mouseX, mouseY+1, m.contextMenuItems())
+	return m, nil
+}
+
+// contextMenuItems returns the actions offered for the selected row, as
+// the bindings running them.
+func (m *Model) contextMenuItems() []menu.Item {
+	var bindings []key.Binding
+	switch m.tabBar.Active() {
+	case TabAlerts:
+		bindings = append(bindings, m.keys.Acknowledge, m.keys.Suppress)
+		if m.alertList.SelectedGroup() != nil || m.alertList.Marked() != nil {
+			// Groups and marked alerts are only acknowledged together
+			break
+		}
+		if selected := m.alertList.Selected(); selected != nil && selected.AllowsManualClose() {
+			bindings = append(bindings, m.keys.AckClose)
+		}
+		bindings = append(bindings, m.keys.Explain, m.keys.EditTriggers, m.keys.EditMacros, m.keys.OpenBrowser)
+	case TabHosts:
+		if m.hostList.Selected() == nil {
+			// Host group rows have no actions
+			break
+		}
+		bindings = append(bindings, m.keys.EditTriggers, m.keys.EditMacros, m.keys.EditInterfaces, m.keys.ToggleMonitor, m.keys.Maintenance, m.keys.CloneHost, m.keys.Diagnose, m.keys.ForceCheck, m.keys.CopyAddress, m.keys.OpenBrowser)
+	case TabEvents:
+		bindings = append(bindings, m.keys.Explain, m.keys.EditTriggers, m.keys.EditMacros, m.keys.OpenBrowser)
+	}
+
+	items := make([]menu.Item, 0, len(bindings))
+	for _, b := range bindings {
+		if !b.Enabled() {
+			continue
+		}
+		desc := b.Help().Desc
+		items = append(items, menu.Item{Label: strings.ToUpper(desc[:1]) + desc[1:], Key: b.Keys()[0]})
+	}
+	return items
+}
+
+// handleMenuSelectedMsg runs the action chosen from the context menu, as
+// if its key had been pressed.
+func (m Model) handleMenuSelectedMsg(msg menu.SelectedMsg) (tea.Model, tea.Cmd) {
+	model, cmd, _ := m.handleActionKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(msg.Item.Key)})
+	return model, cmd
+}
+
+// openInBrowser opens the frontend page of the selected row.
+func (m *Model) openInBrowser() {
+	page := m.selectedPageURL()
+	if page == "" {
+		m.statusBar.SetStatus("Nothing selected to open")
+		return
+	}
+	if err := m.openURL(page); err != nil {
+		m.statusBar.SetStatus(fmt.Sprintf("Could not open the browser: %v", err))
+		return
+	}
+	m.statusBar.SetStatus("Opened " + page)
+}
+
+// selectedPageURL returns the frontend URL of the selected problem, event
+// or host, or empty if nothing is selected.
+func (m *Model) selectedPageURL() string {
+	base := strings.TrimSuffix(m.config.Server.URL, "/")
+	var event *zabbix.Problem
+	switch m.tabBar.Active() {
+	case TabAlerts:
+		event = m.alertList.Selected()
+	case TabEvents:
+		event = m.eventList.Selected()
+	case TabHosts:
+		host := m.hostList.Selected()
+		if host == nil {
+			return ""
+		}
+		if !zabbix.VersionAtLeast(m.version, 6, 0) {
+			return base + "/hosts.php?form=update&hostid=" + url.QueryEscape(host.HostID)
+		}
+		return base + "/zabbix.php?action=host.edit&hostid=" + url.QueryEscape(host.HostID)
+	}
+	if event == nil {
+		return ""
+	}
+	_, triggerID := m.getSelectedHostAndTriggerID()
+	if triggerID == "" {
+		return base + "/zabbix.php?action=problem.view"
+	}
+	return base + "/tr_events.php?triggerid=" + url.QueryEscape(triggerID) + "&eventid=" + url.QueryEscape(event.EventID)
+}
+
+// startBrowser opens a URL in the default browser without waiting for it.
+func startBrowser(page string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", page)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", page)
+	default:
+		cmd = exec.Command("xdg-open", page)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
@@ -1,6 +1,10 @@
 package app
 
 import (
+	"time"
+
+	"github.com/harpchad/chotko/internal/components/detail"
+	"github.com/harpchad/chotko/internal/report"
 	"github.com/harpchad/chotko/internal/zabbix"
 )
 
@@ -9,6 +13,11 @@ import (
 // ProblemsLoadedMsg is sent when problems are loaded from Zabbix.
 type ProblemsLoadedMsg struct {
 	Problems []zabbix.Problem
+	Next     string        // EventIDTill for the next page; empty when there are no more
+	Append   bool          // Problems are a further page to append to the list
+	Complete bool          // Problems are all the open ones: unfiltered and not cut short
+	Duration time.Duration // Time taken by the load
+	Seq      int           // Load sequence number, to drop superseded results
 	Err      error
 }
 
@@ -20,26 +29,105 @@ type HostCountsLoadedMsg struct {
 
 // HostsLoadedMsg is sent when hosts are loaded from Zabbix.
 type HostsLoadedMsg struct {
-	Hosts []zabbix.Host
-	Err   error
+	Hosts    []zabbix.Host
+	Problems map[string]zabbix.HostProblems // Active problems by host ID
+	// Maintenances the hosts are in, by ID; nil if they could not be read
+	Maintenances map[string]zabbix.Maintenance
+	// Status counts of the monitored hosts, derived from Hosts when the
+	// whole host list was loaded; nil after a server search
+	Counts   *zabbix.HostCounts
+	Duration time.Duration // Time taken by the load
+	Seq      int           // Load sequence number, to drop superseded results
+	Err      error
 }
 
 // EventsLoadedMsg is sent when events are loaded from Zabbix.
 type EventsLoadedMsg struct {
-	Events []zabbix.Event
-	Err    error
+	Events   []zabbix.Event
+	Next     string        // EventIDTill for the next page; empty when there are no more
+	Append   bool          // Events are a further page to append to the list
+	Duration time.Duration // Time taken by the load
+	Seq      int           // Load sequence number, to drop superseded results
+	Err      error
+}
+
+// TriggerRelationsLoadedMsg is sent when the dependencies and host groups of
+// the problems' triggers are loaded.
+type TriggerRelationsLoadedMsg struct {
+	Triggers []zabbix.Trigger
+	Err      error
 }
 
 // AcknowledgeResultMsg is sent after acknowledging a problem.
 type AcknowledgeResultMsg struct {
 	EventID string
+	Closed  bool // The problem was also closed, or closing was attempted
+	Count   int  // Problems of a group acknowledged together; 0 for one problem
 	Success bool
 	Err     error
 }
 
+// AssignResultMsg is sent after assigning or unassigning problems.
+type AssignResultMsg struct {
+	Username string // The new assignee; empty when unassigned
+	Count    int
+	Err      error
+}
+
 // RefreshTickMsg is sent periodically to trigger data refresh.
 type RefreshTickMsg struct{}
 
+// KioskTickMsg is sent when kiosk mode shows the next tab.
+type KioskTickMsg struct {
+	Time time.Time
+}
+
+// KioskGroupTickMsg is sent when kiosk mode shows the next host group.
+type KioskGroupTickMsg struct {
+	Time time.Time
+}
+
+// KioskGroupsMsg is sent when the host groups of kiosk mode are looked up.
+type KioskGroupsMsg struct {
+	Groups []zabbix.HostGroup
+	Err    error
+}
+
+// RoleGroupsMsg is sent when the host groups of the role filter are looked
+// up.
+type RoleGroupsMsg struct {
+	Groups []zabbix.HostGroup
+	Err    error
+}
+
+// HooksRunMsg is sent when the sound and command hooks of new or
+// unacknowledged problems finish.
+type HooksRunMsg struct {
+	Err error
+}
+
+// ClockTickMsg is sent every second to update the status bar clock and countdown.
+type ClockTickMsg struct {
+	Time time.Time
+}
+
+// PingTickMsg is sent when the server's latency is due to be measured.
+type PingTickMsg struct{}
+
+// PingResultMsg is sent with the round-trip time of a health check.
+type PingResultMsg struct {
+	Latency time.Duration
+	Err     error
+}
+
+// ConfigChangedMsg is sent when the config file or a custom theme file changes on disk.
+type ConfigChangedMsg struct{}
+
+// FilterDebounceMsg is sent after typing in the filter input pauses.
+type FilterDebounceMsg struct {
+	Seq int // Matches Model.filterSeq unless superseded by further typing
+}
+
 // ErrorMsg represents an error to be displayed to the user.
 type ErrorMsg struct {
 	Title   string
@@ -54,6 +142,28 @@ type ClearErrorMsg struct{}
 type ConnectedMsg struct {
 	Version string
 	Client  *zabbix.Client
+	UserID  string // Empty if the current user couldn't be looked up
+	// Username of the current user; empty if it couldn't be looked up
+	Username string
+	// What the user may change; nil if it couldn't be looked up
+	Permissions *zabbix.Permissions
+	// Global settings; nil before Zabbix 5.2 or if they couldn't be read
+	Settings *zabbix.Settings
+	// Role and user groups of the user; nil without role filters or if
+	// they couldn't be looked up
+	Profile *zabbix.UserProfile
+}
+
+// ConnectFailedMsg is sent when connecting or authenticating to Zabbix fails.
+type ConnectFailedMsg struct {
+	Title   string
+	Message string
+	Err     error
+}
+
+// ReconnectTickMsg is sent every second while waiting to reconnect.
+type ReconnectTickMsg struct {
+	Time time.Time
 }
 
 // DisconnectedMsg is sent when disconnected from Zabbix.
@@ -63,8 +173,10 @@ type DisconnectedMsg struct {
 
 // ItemsLoadedMsg is sent when items are loaded from Zabbix.
 type ItemsLoadedMsg struct {
-	Items []zabbix.Item
-	Err   error
+	Items    []zabbix.Item
+	Duration time.Duration // Time taken by the load
+	Seq      int           // Load sequence number, to drop superseded results
+	Err      error
 }
 
 // HostHistoryLoadedMsg is sent when history for a specific host is loaded.
@@ -82,10 +194,25 @@ type HostTriggersLoadedMsg struct {
 	Err             error
 }
 
-// HostMacrosLoadedMsg is sent when macros for a host are loaded.
+// HostMacrosLoadedMsg is sent when macros for a host are loaded, with those
+// it inherits from templates and global macros.
 type HostMacrosLoadedMsg struct {
 	HostID string
-	Macros []zabbix.HostMacro
+	Macros []zabbix.EffectiveMacro
+	Err    error
+}
+
+// HostInterfacesLoadedMsg is sent when the interfaces of a host are loaded
+// for the editor.
+type HostInterfacesLoadedMsg struct {
+	HostID     string
+	Interfaces []zabbix.Interface
+	Err        error
+}
+
+// InterfaceUpdatedMsg is sent after saving an interface.
+type InterfaceUpdatedMsg struct {
+	HostID string
 	Err    error
 }
 
@@ -97,10 +224,193 @@ type HostUpdateResultMsg struct {
 	Err     error
 }
 
+// MaintenanceResultMsg is sent after putting a host in maintenance.
+type MaintenanceResultMsg struct {
+	HostName string
+	Duration time.Duration
+	Err      error
+}
+
+// HostClonedMsg is sent after cloning a host.
+type HostClonedMsg struct {
+	Source string // Name of the cloned host
+	Name   string
+	Clone  *zabbix.HostClone
+	Err    error
+}
+
+// ConfigurationExportedMsg is sent after exporting a host, a template or
+// the maintenances to a file.
+type ConfigurationExportedMsg struct {
+	Name string
+	Path string
+	Err  error
+}
+
+// ChartExportedMsg is sent after exporting the chart of an item to files.
+type ChartExportedMsg struct {
+	Name  string
+	Paths []string
+	Err   error
+}
+
+// ReportWrittenMsg is sent after writing a timeline report to a file.
+type ReportWrittenMsg struct {
+	Path     string
+	Problems int
+	Err      error
+}
+
+// HandoverWrittenMsg is sent with a shift handover summary, after writing
+// it to Path if set.
+type HandoverWrittenMsg struct {
+	Path string
+	Text string
+	Open int // Open problems listed
+	Err  error
+}
+
+// FlappingLoadedMsg is sent with the triggers that had more than Threshold
+// problems in the last Window.
+type FlappingLoadedMsg struct {
+	Window    time.Duration
+	Threshold int
+	Flaps     []report.Flap
+	Truncated bool // Only the latest events of the window were counted
+	Err       error
+}
+
+// CalendarLoadedMsg is sent with the maintenances of a host, given directly
+// or through its host groups, to show their windows from From until Till.
+type CalendarLoadedMsg struct {
+	Host         zabbix.Host
+	Maintenances []zabbix.Maintenance
+	From         time.Time
+	Till         time.Time
+	Err          error
+}
+
+// BaselinesLoadedMsg is sent with the past history of an item, to compare
+// its chart with.
+type BaselinesLoadedMsg struct {
+	ItemID    string
+	Baselines []detail.Baseline
+	Err       error
+}
+
+// CompareLoadedMsg is sent with the history of an item's key on the hosts
+// it is compared across, the first of Hosts that have it.
+type CompareLoadedMsg struct {
+	ItemID string
+	Series []detail.Series
+	Hosts  int
+	Err    error
+}
+
+// HeatmapLoadedMsg is sent with what ":heatmap" maps between From and Till:
+// the history of Item's key on hosts, or when the problems of hosts were
+// open if Item is nil. Truncated is set if some hosts or events were left
+// out.
+type HeatmapLoadedMsg struct {
+	From, Till time.Time
+	Item       *zabbix.Item
+	Series     []detail.Series
+	Spans      []report.Span
+	Truncated  bool
+	Err        error
+}
+
+// AddressResolvedMsg is sent with the addresses a host's DNS name resolves
+// to, for copying the first.
+type AddressResolvedMsg struct {
+	Name      string
+	Addresses []string
+	Err       error
+}
+
+// TokensLoadedMsg is sent with the API tokens of the current user.
+type TokensLoadedMsg struct {
+	Tokens    []zabbix.Token
+	CurrentID string // Token chotko authenticates with; empty with a password
+	Err       error
+}
+
+// TokenRotatedMsg is sent after replacing the API token chotko uses.
+type TokenRotatedMsg struct {
+	Secret string // New token; empty if it was not replaced
+	Path   string // Config file the new token was saved to; empty if not saved
+	Err    error
+}
+
+// ImportPreviewMsg is sent with what importing a configuration file would
+// change.
+type ImportPreviewMsg struct {
+	Path    string
+	Preview *zabbix.ImportPreview
+	Err     error
+}
+
+// ImportResultMsg is sent after importing a configuration file.
+type ImportResultMsg struct {
+	Path string
+	Err  error
+}
+
+// SuppressResultMsg is sent after suppressing problems.
+type SuppressResultMsg struct {
+	Name  string // The problem, or the number of problems
+	Until time.Time
+	Err   error
+}
+
+// HostDiagnosticsLoadedMsg is sent when the availability details of a host
+// are loaded.
+type HostDiagnosticsLoadedMsg struct {
+	HostID      string
+	Diagnostics *zabbix.HostDiagnostics
+	Err         error
+}
+
+// AvailabilityCheckMsg is sent after asking for a host's interfaces to be
+// checked now.
+type AvailabilityCheckMsg struct {
+	HostName string
+	Items    int // Items checked, one per interface
+	Err      error
+}
+
+// ItemUpdateResultMsg is sent after enabling or disabling an item.
+type ItemUpdateResultMsg struct {
+	ItemID   string
+	ItemName string
+	Status   string // The status set, see zabbix.ItemStatusEnabled
+	Err      error
+}
+
+// ItemCheckResultMsg is sent after asking for an item to be checked now.
+type ItemCheckResultMsg struct {
+	ItemName string
+	Err      error
+}
+
+// FollowTickMsg is sent when a followed log item is due to be polled.
+type FollowTickMsg struct {
+	Seq int
+}
+
+// LogLinesMsg is sent with the new values of a followed log item.
+type LogLinesMsg struct {
+	ItemID  string
+	Seq     int
+	History []zabbix.History
+	Err     error
+}
+
 // TriggerUpdateResultMsg is sent after a trigger update operation.
 type TriggerUpdateResultMsg struct {
 	TriggerID string
 	Action    string // "enable", "disable", "update"
+	Count     int    // Triggers changed together; 0 for one trigger
 	Success   bool
 	Err       error
 }
@@ -119,3 +429,63 @@ type IgnoreResultMsg struct {
 	Message string // status message to display
 	Err     error
 }
+
+// QueuesLoadedMsg is sent with the queues of the Zabbix servers and proxies.
+type QueuesLoadedMsg struct {
+	Queues []zabbix.Queue
+	Err    error
+}
+
+// SettingsLoadedMsg is sent with the global settings of the server, for
+// ":settings".
+type SettingsLoadedMsg struct {
+	Settings *zabbix.Settings
+	Err      error
+}
+
+// ServerInfoLoadedMsg is sent with what the API tells about the Zabbix
+// installation.
+type ServerInfoLoadedMsg struct {
+	Info *zabbix.ServerInfo
+	Err  error
+}
+
+// TriggerEvaluatedMsg is sent with the trigger of the selected problem or
+// event evaluated client-side.
+type TriggerEvaluatedMsg struct {
+	Evaluation *zabbix.TriggerEvaluation
+	Err        error
+}
+
+// DashboardsLoadedMsg is sent with the dashboards of the server. Name is the
+// dashboard asked for with ":dashboard NAME", empty to choose from the list.
+type DashboardsLoadedMsg struct {
+	Name       string
+	Dashboards []zabbix.Dashboard
+	Err        error
+}
+
+// DashboardLoadedMsg is sent with the pages and widgets of a dashboard.
+type DashboardLoadedMsg struct {
+	Dashboard *zabbix.Dashboard
+	Err       error
+}
+
+// WidgetDataLoadedMsg is sent with the data shown by the widgets of a
+// dashboard page.
+type WidgetDataLoadedMsg struct {
+	DashboardID string
+	Page        int
+	Data        *zabbix.WidgetData
+	Err         error
+}
+
+// SearchLoadedMsg is sent with the hosts, triggers, items and templates
+// found by a search.
+type SearchLoadedMsg struct {
+	Query    string
+	Results  *zabbix.SearchResults
+	Duration time.Duration // Time taken by the load
+	Seq      int           // Load sequence number, to drop superseded results
+	Err      error
+}
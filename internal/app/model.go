@@ -3,23 +3,40 @@ package app
 import (
 	"context"
 	"fmt"
+	"net"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
 
 	"github.com/harpchad/chotko/internal/components/alerts"
 	"github.com/harpchad/chotko/internal/components/command"
+	"github.com/harpchad/chotko/internal/components/dashboard"
 	"github.com/harpchad/chotko/internal/components/detail"
 	"github.com/harpchad/chotko/internal/components/editor"
 	"github.com/harpchad/chotko/internal/components/events"
 	"github.com/harpchad/chotko/internal/components/graphs"
 	"github.com/harpchad/chotko/internal/components/hosts"
+	"github.com/harpchad/chotko/internal/components/menu"
 	"github.com/harpchad/chotko/internal/components/modal"
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/components/palette"
+	"github.com/harpchad/chotko/internal/components/search"
 	"github.com/harpchad/chotko/internal/components/statusbar"
 	"github.com/harpchad/chotko/internal/components/tabs"
+	"github.com/harpchad/chotko/internal/components/tutorial"
 	"github.com/harpchad/chotko/internal/config"
+	"github.com/harpchad/chotko/internal/debuglog"
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/hooks"
 	"github.com/harpchad/chotko/internal/ignores"
+	"github.com/harpchad/chotko/internal/metrics"
+	"github.com/harpchad/chotko/internal/mute"
+	"github.com/harpchad/chotko/internal/session"
 	"github.com/harpchad/chotko/internal/theme"
 	"github.com/harpchad/chotko/internal/zabbix"
 )
@@ -39,16 +56,41 @@ const (
 	TabHosts  = 1
 	TabEvents = 2
 	TabGraphs = 3
-	TabCount  = 4
+	TabSearch = 4
+	TabCount  = 5
 )
 
+// loadState tracks the in-flight data load for a tab so a newer load can
+// cancel and supersede it.
+type loadState struct {
+	seq    int                // Incremented for every load started
+	cancel context.CancelFunc // Cancels the in-flight load; nil when idle
+}
+
+// tabNames are the tab labels, indexed by tab constant.
+var tabNames = [TabCount]string{"Alerts", "Hosts", "Events", "Graphs", "Search"}
+
 // Layout constants for UI rendering.
 const (
 	ListWidthPercent   = 45 // Percentage of width for list pane
 	DetailWidthPercent = 55 // Percentage of width for detail pane
+	ListHeightPercent  = 50 // Percentage of height for list pane in stacked layout
+	CellAspectRatio    = 2  // Approximate height/width ratio of a terminal cell
+	CompactWidth       = 90 // Below this width only one pane is shown at a time
 	LogoutTimeout      = 5  // Seconds to wait for logout on shutdown
 )
 
+// FilterDebounce is how long typing must pause before the text filter is applied.
+const FilterDebounce = 300 * time.Millisecond
+
+// Connection recovery constants.
+const (
+	MaxRefreshFailures = 3               // Consecutive failed loads before reconnecting
+	ReconnectBaseDelay = 2 * time.Second // Delay before the first reconnect attempt
+	ReconnectMaxDelay  = 5 * time.Minute // Upper bound for the reconnect delay
+	StaleRefreshFactor = 2               // Data older than this many refresh intervals is stale
+)
+
 // Zabbix object type constants.
 const (
 	ObjectTypeTrigger = "0" // Trigger-based problem
@@ -63,6 +105,9 @@ const (
 	ModeFilter
 	ModeCommand
 	ModeAckMessage
+	ModeSuppressUntil
+	ModeCloneHost
+	ModeSearch
 )
 
 // Model is the main application model.
@@ -76,6 +121,8 @@ type Model struct {
 	// Theme and styles
 	theme  *theme.Theme
 	styles *theme.Styles
+	// Severity colors configured on the server, for server_severity_colors
+	serverColors [6]string
 
 	// Key bindings
 	keys KeyMap
@@ -89,6 +136,7 @@ type Model struct {
 	mode            Mode
 	minSeverity     int
 	textFilter      string
+	eventScope      eventScope // Events loaded on the Events tab
 	refreshInterval time.Duration
 
 	// Data
@@ -98,6 +146,12 @@ type Model struct {
 	items      []zabbix.Item
 	hostCounts *zabbix.HostCounts
 
+	// Alert counts of recent refreshes, oldest first, for the status bar
+	problemTrend []int
+
+	// Dependencies and host groups of triggers by ID, for relating problems
+	triggerRelations map[string]zabbix.Trigger
+
 	// Components
 	statusBar    statusbar.Model
 	tabBar       tabs.Model
@@ -105,6 +159,7 @@ type Model struct {
 	hostList     hosts.Model
 	eventList    events.Model
 	graphList    graphs.Model
+	searchList   search.Model
 	detailPane   detail.Model
 	commandInput command.Model
 
@@ -115,33 +170,174 @@ type Model struct {
 	errorModal modal.Model
 	editorPane editor.Model
 
+	// Dashboard of the server shown instead of the tabs, with :dashboard
+	showDashboard bool
+	dashboardView dashboard.Model
+
+	// Host group clicked on a dashboard, the Alerts tab is limited to until
+	// the filters are cleared
+	alertGroup *zabbix.HostGroup
+
+	// Guided tour, shown after the setup wizard and with :tutorial
+	tutorial tutorial.Model
+
+	// Context menu opened by right-clicking a row
+	contextMenu menu.Model
+
+	// Command palette and the IDs of its recently used actions, most recent
+	// first
+	palette       palette.Model
+	recentActions []string
+
+	// Drawer of action results, with their history shown by :notifications
+	notifications notify.Model
+
 	// Loading states
 	loading     bool
 	lastRefresh time.Time
-	connected   bool
-	version     string
+	nextRefresh time.Time // When the next scheduled refresh fires
+
+	// Last successful load per tab, for stale-data indication
+	tabLoaded [TabCount]time.Time
+
+	// Duration of the last successful load per tab, for :stats
+	loadDurations [TabCount]time.Duration
+
+	// In-flight load per tab, for cancellation of superseded requests
+	loads [TabCount]loadState
+
+	// Pagination of problems and events: pages loaded so far, the EventIDTill
+	// of the next page (empty when everything is loaded) and whether a page
+	// fetch is running
+	pages       [TabCount]int
+	pageNext    [TabCount]string
+	loadingMore [TabCount]bool
+
+	// Text filter state: filterSeq invalidates pending debounce ticks,
+	// filterBefore is restored when filter input is canceled, and
+	// serverSearch is the API search words each tab's data was loaded with
+	filterSeq    int
+	filterBefore string
+	serverSearch [TabCount][]string
+
+	// Connection recovery
+	failures       int       // Consecutive failed data loads
+	reconnecting   bool      // Reconnect loop is active
+	reconnectAt    time.Time // When the next reconnect attempt fires
+	reconnectTries int       // Failed reconnect attempts since the connection was lost
+	connected      bool
+	version        string
+	userID         string                // Current user; empty if it couldn't be looked up
+	username       string                // Current user; empty if it couldn't be looked up
+	clientOptions  []zabbix.ClientOption // Extra options for new clients, e.g. the demo transport
+
+	// API call log for troubleshooting (:debug, :log)
+	debugLog *debuglog.Log
+
+	// Live reload: the config file as last read from disk, so that settings
+	// unchanged in the file keep command-line overrides and interactive changes
+	watcher        *config.Watcher
+	configPath     string
+	fileConfig     *config.Config
+	darkBackground bool // Terminal background, for resolving "auto" themes
+
+	// API call latencies and process start time, for :stats
+	latencies *metrics.Latencies
+	startedAt time.Time
 
 	// Context for cancellation
 	ctx    context.Context
 	cancel context.CancelFunc
 
+	// Timestamp display preferences shared by components
+	timeFormat format.TimeFormat
+
+	// Layout - stacked places the list above the detail pane, compact shows
+	// only the focused pane with the detail as a full-width overlay
+	stacked bool
+	compact bool
+
+	// Kiosk mode shows only the list and rotates through kioskTabs, and
+	// through kioskGroups, limiting the lists to kioskGroups[kioskGroup]
+	kiosk       bool
+	kioskTabs   []int
+	kioskGroups []zabbix.HostGroup
+	kioskGroup  int
+
+	// The role filter (role_filters) matching the user's role or user
+	// groups; the lists are limited to roleGroups unless roleGroupsOff
+	roleFilter    string
+	roleGroups    []zabbix.HostGroup
+	roleGroupsOff bool
+
 	// Mouse tracking - pane bounds for scroll detection
-	listPaneX     int // X position where list pane starts (0)
-	listPaneWidth int // Width of list pane including borders
-	detailPaneX   int // X position where detail pane starts
-	contentY      int // Y position where content panes start (after status bar and tab bar)
-	contentHeight int // Height of content area
+	listPaneX      int // X position where list pane starts (0)
+	listPaneWidth  int // Width of list pane including borders
+	listPaneHeight int // Height of list pane including borders
+	detailPaneX    int // X position where detail pane starts
+	contentY       int // Y position where content panes start (after status bar and tab bar)
+	contentHeight  int // Height of content area
+	lastClick      clickState
+	drag           dragState
+
+	// openURL opens a page of the frontend in the browser
+	openURL func(page string) error
+	// copyText copies text to the clipboard
+	copyText func(text string)
+	// lookupHost resolves a DNS name to its addresses
+	lookupHost func(ctx context.Context, name string) ([]string, error)
 
 	// Ignore list for locally hiding alerts
 	ignoreList            *ignores.List
 	pendingIgnore         *ignores.Rule // rule awaiting y/n confirmation
 	awaitingIgnoreConfirm bool          // waiting for y/n input
+
+	// Host maintenance awaiting its length; nil when not prompting
+	pendingMaintenance *maintenanceRequest
+	// The acknowledgment message being typed closes the problem too
+	ackClose bool
+	// Problem suppression awaiting its end; nil when not prompting
+	pendingSuppress *suppressRequest
+	// Host to clone, awaiting the clone's name; nil when not prompting
+	pendingClone *cloneRequest
+	// Configuration file awaiting confirmation of its import; nil when
+	// not asking
+	pendingImport *importRequest
+	// Awaiting confirmation of rotating the API token
+	pendingRotate bool
+
+	// Graphs tab charts are compared with the past (see baselineOffsets),
+	// loaded for the item with ID baselineItem
+	baseline     bool
+	baselineItem string
+	// Pattern of the hosts the Graphs tab's item is compared across, empty
+	// for all
+	comparePattern string
+
+	// Log item whose new values are polled; nil when not following
+	follow    *logFollow
+	followSeq int // Identifies the polls of the current follow
+
+	// Mute rules from the config, hiding alerts of known-noisy triggers
+	muteList *mute.List
+
+	// Sounds and commands run for new problems; nil without hooks
+	hooks      *hooks.Runner
+	soundMuted bool
+
+	// Session state saved on exit; nil when not persisted
+	session         *session.State
+	restoreSelected [TabCount]string // Row IDs to select once a restored tab loads
+
+	// searchItem is the ID of the item a search result goes to, to select
+	// once the Graphs tab loads
+	searchItem string
 }
 
 // New creates a new application model.
 func New(cfg *config.Config, t *theme.Theme) *Model {
 	ctx, cancel := context.WithCancel(context.Background())
-	styles := theme.NewStyles(t)
+	styles := newStyles(cfg, t)
 
 	m := &Model{
 		config:          cfg,
@@ -151,9 +347,23 @@ func New(cfg *config.Config, t *theme.Theme) *Model {
 		focused:         PaneList,
 		mode:            ModeNormal,
 		minSeverity:     cfg.Display.MinSeverity,
+		eventScope:      eventScope{Window: defaultEventWindow},
 		refreshInterval: time.Duration(cfg.Display.RefreshInterval) * time.Second,
-		ctx:             ctx,
-		cancel:          cancel,
+		timeFormat: format.TimeFormat{
+			Relative: cfg.GetRelativeTime(),
+			Hour12:   cfg.GetHour12(),
+			Layout:   cfg.GetTimeFormat(),
+			Location: cfg.GetLocation(),
+		},
+		darkBackground: true,
+		debugLog:       debuglog.New(debuglog.DefaultCapacity),
+		latencies:      metrics.NewLatencies(),
+		startedAt:      time.Now(),
+		openURL:        startBrowser,
+		copyText:       termenv.Copy,
+		lookupHost:     net.DefaultResolver.LookupHost,
+		ctx:            ctx,
+		cancel:         cancel,
 	}
 
 	// Load ignore list (errors are logged but don't block startup)
@@ -166,20 +376,41 @@ func New(cfg *config.Config, t *theme.Theme) *Model {
 
 	// Initialize components
 	m.statusBar = statusbar.New(styles)
-	m.tabBar = tabs.New(styles, []string{"Alerts", "Hosts", "Events", "Graphs"}, 0)
+	m.tabBar = tabs.New(styles, tabNames[:], 0)
 	m.alertList = alerts.New(styles)
 	m.hostList = hosts.New(styles)
 	m.eventList = events.New(styles)
 	m.graphList = graphs.New(styles)
+	m.searchList = search.New(styles)
 	m.detailPane = detail.New(styles)
 	m.commandInput = command.New(styles)
 	m.errorModal = modal.New(styles)
 	m.editorPane = editor.New(styles)
+	m.dashboardView = dashboard.New(styles)
+	m.tutorial = tutorial.New(styles)
+	m.contextMenu = menu.New(styles)
+	m.palette = palette.New(styles)
+	m.notifications = notify.New(styles)
+
+	m.applyTimeFormat()
+	m.eventList.SetScope(m.eventScope.String())
+	m.statusBar.SetOnCall(cfg.Display.OnCall)
+	m.nextRefresh = time.Now().Add(m.refreshInterval)
+	m.updateClock(time.Now())
 
 	// Set ignore checker on alerts component
 	if m.ignoreList != nil {
 		m.alertList.SetIgnoreChecker(m.ignoreList.IsIgnored)
 	}
+	if err := m.applyMuteRules(); err != nil {
+		m.statusBar.SetStatus(fmt.Sprintf("Mute rules: %v", err))
+	}
+	if f, ok := alerts.ParseAckFilter(m.config.Display.AckFilter); ok {
+		m.alertList.SetAckFilter(f)
+	}
+	m.alertList.SetSLA(m.config.GetSLA())
+	m.hooks = hooks.New(cfg, m.startedAt)
+	m.statusBar.SetSound(m.hooks != nil, false)
 
 	// Set initial focus to alerts list
 	m.alertList.SetFocused(true)
@@ -187,11 +418,37 @@ func New(cfg *config.Config, t *theme.Theme) *Model {
 	return m
 }
 
+// newStyles builds the styles for a theme, applying the glyph and no-color
+// settings.
+func newStyles(cfg *config.Config, t *theme.Theme) *theme.Styles {
+	styles := theme.NewStyles(t)
+	styles.SetGlyphs(theme.Glyphs(cfg.Display.Glyphs))
+	if cfg.GetNoColor() {
+		styles.Monochrome()
+	}
+	styles.SetSeverityIcons(cfg.Display.SeverityGlyphs)
+	return styles
+}
+
+// applyTimeFormat pushes the timestamp display preferences to components.
+func (m *Model) applyTimeFormat() {
+	m.eventList.SetTimeFormat(m.timeFormat)
+	m.detailPane.SetTimeFormat(m.timeFormat)
+	m.dashboardView.SetTimeFormat(m.timeFormat)
+	if !m.lastRefresh.IsZero() {
+		m.statusBar.SetLastUpdate(m.timeFormat.Clock(m.lastRefresh))
+	}
+}
+
 // Init initializes the application.
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.connect(),
 		m.tickRefresh(),
+		m.tickClock(),
+		m.tickPing(),
+		m.tickKiosk(),
+		m.waitForConfigChange(),
 	)
 }
 
@@ -200,21 +457,86 @@ func (m *Model) connect() tea.Cmd {
 	// Capture config values for the goroutine
 	serverURL := m.config.Server.URL
 	useToken := m.config.UseToken()
+	useSession := m.config.UseSession()
 	token := m.config.Auth.Token
 	username := m.config.Auth.Username
 	password := m.config.Auth.Password
+	sessionID := zabbix.SessionID(m.config.Auth.SessionID)
 	ctx := m.ctx
+	debugLog := m.debugLog
+	latencies := m.latencies
+	options := m.clientOptions
+	useBasic := m.config.Auth.Mode == config.AuthModeBasic
+	if useBasic {
+		options = append(slices.Clip(options), zabbix.WithBasicAuth(m.config.Auth.BasicUsername, m.config.Auth.BasicPassword))
+	}
+	timeout := m.config.GetTimeout()
+	methodTimeouts := m.config.GetMethodTimeouts()
+	roleFilters := m.config.RoleFilters
 
 	return func() tea.Msg {
 		// Create client
-		client := zabbix.NewClient(serverURL)
+		observer := zabbix.WithCallObserver(func(info zabbix.CallInfo) {
+			entry := debuglog.Entry{
+				Time:          time.Now(),
+				Method:        info.Method,
+				Duration:      info.Duration,
+				RequestBytes:  info.RequestBytes,
+				ResponseBytes: info.ResponseBytes,
+			}
+			if info.Err != nil {
+				entry.Err = info.Err.Error()
+			}
+			debugLog.Record(entry)
+			latencies.Record(info.Method, info.Duration, info.Err != nil)
+		})
+		client := zabbix.NewClient(serverURL, append([]zabbix.ClientOption{
+			observer,
+			zabbix.WithTimeout(timeout),
+			zabbix.WithMethodTimeouts(methodTimeouts),
+		}, options...)...)
+
+		// Get the version first so that the API calls, including login,
+		// are adapted to the server
+		caps, err := client.Negotiate(ctx)
+		if err != nil {
+			return ConnectFailedMsg{
+				Title:   "Connection Failed",
+				Message: "Failed to connect to Zabbix server",
+				Err:     err,
+			}
+		}
+		if useBasic && caps.AuthHeaderOnly {
+			return ConnectFailedMsg{
+				Title:   "Authentication Failed",
+				Message: "HTTP basic auth can't be used with Zabbix 7.2 or later, which takes the session or token in the same Authorization header",
+				Err:     zabbix.ErrBasicAuthToken,
+			}
+		}
 
 		// Authenticate
-		if useToken {
+		switch {
+		case useSession:
+			client.SetToken(sessionID)
+			if err := client.CheckAuth(ctx); err != nil {
+				return ConnectFailedMsg{
+					Title:   "Authentication Failed",
+					Message: "The session ID was not accepted; sign in to the frontend again for a new one",
+					Err:     err,
+				}
+			}
+		case useToken:
+			if !caps.APITokens {
+				return ConnectFailedMsg{
+					Title:   "Authentication Failed",
+					Message: "API tokens require Zabbix 5.4 or later; use a username and password",
+					Err:     fmt.Errorf("server version is %s", caps.Version),
+				}
+			}
 			client.SetToken(token)
-		} else {
+		default:
 			if err := client.Login(ctx, username, password); err != nil {
-				return ErrorMsg{
+				return ConnectFailedMsg{
 					Title:   "Authentication Failed",
 					Message: "Failed to connect to Zabbix server",
 					Err:     err,
@@ -222,17 +544,48 @@ func (m *Model) connect() tea.Cmd {
 			}
 		}
 
-		// Get version to verify connection
-		version, err := client.Version(ctx)
-		if err != nil {
-			return ErrorMsg{
-				Title:   "Connection Failed",
-				Message: "Failed to connect to Zabbix server",
-				Err:     err,
+		// Only needed to show the user's own acknowledgements and
+		// assignments, so failing to look them up isn't an error
+		var userID string
+		switch {
+		case useSession:
+			userID, username, _ = client.SessionUser(ctx)
+		case !useToken:
+			userID, _ = client.UserID(ctx, username)
+		case caps.BearerAuth:
+			userID, _ = client.TokenUserID(ctx)
+			if userID != "" {
+				username, _ = client.Username(ctx, userID)
 			}
 		}
 
-		return ConnectedMsg{Version: version, Client: client}
+		// Without them every action is offered, and refused ones fail
+		var permissions *zabbix.Permissions
+		if userID != "" {
+			permissions, _ = client.GetPermissions(ctx, userID)
+		}
+
+		// Only needed to match role filters
+		var profile *zabbix.UserProfile
+		if userID != "" && len(roleFilters) > 0 {
+			profile, _ = client.GetUserProfile(ctx, userID)
+		}
+
+		// Without them severities keep their default names
+		var settings *zabbix.Settings
+		if caps.Settings {
+			settings, _ = client.GetSettings(ctx)
+		}
+
+		return ConnectedMsg{
+			Version:     caps.Version,
+			Client:      client,
+			UserID:      userID,
+			Username:    username,
+			Permissions: permissions,
+			Settings:    settings,
+			Profile:     profile,
+		}
 	}
 }
 
@@ -243,46 +596,277 @@ func (m *Model) tickRefresh() tea.Cmd {
 	})
 }
 
-// loadProblems fetches problems from Zabbix.
-func (m *Model) loadProblems() tea.Cmd {
+// tickPing returns a command that schedules the next health check, or nil
+// when it is off.
+func (m *Model) tickPing() tea.Cmd {
+	interval := m.config.GetPingInterval()
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(_ time.Time) tea.Msg {
+		return PingTickMsg{}
+	})
+}
+
+// ping measures the round-trip time of the server.
+func (m *Model) ping() tea.Cmd {
 	// Capture values for the goroutine
 	client := m.client
 	ctx := m.ctx
-	minSeverity := m.minSeverity
 
 	return func() tea.Msg {
 		if client == nil {
-			return ProblemsLoadedMsg{Err: nil}
+			return PingResultMsg{}
 		}
+		latency, err := client.Ping(ctx)
+		return PingResultMsg{Latency: latency, Err: err}
+	}
+}
 
-		var problems []zabbix.Problem
-		var err error
+// tickReconnect returns a command that drives the reconnect countdown.
+func (m *Model) tickReconnect() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return ReconnectTickMsg{Time: t}
+	})
+}
+
+// reconnectDelay returns the exponential backoff delay for the given attempt,
+// doubling from ReconnectBaseDelay up to ReconnectMaxDelay.
+func reconnectDelay(attempt int) time.Duration {
+	delay := ReconnectBaseDelay
+	for range attempt {
+		delay *= 2
+		if delay >= ReconnectMaxDelay {
+			return ReconnectMaxDelay
+		}
+	}
+	return delay
+}
+
+// updateBanner refreshes the disconnected banner countdown.
+func (m *Model) updateBanner(now time.Time) {
+	if !m.reconnecting {
+		m.statusBar.SetBanner("")
+		return
+	}
+
+	banner := "DISCONNECTED — reconnecting..."
+	if remaining := m.reconnectAt.Sub(now).Round(time.Second); remaining > 0 {
+		banner = "DISCONNECTED — retrying in " + format.Duration(remaining)
+	}
+	if !m.lastRefresh.IsZero() {
+		banner += " — showing data from " + m.timeFormat.Clock(m.lastRefresh)
+	}
+	m.statusBar.SetBanner(banner)
+}
+
+// beginLoad cancels any in-flight load for a tab and starts a new one.
+// It returns the context for the request and the sequence number that the
+// result message must carry to be accepted.
+func (m *Model) beginLoad(tab int) (context.Context, int) {
+	m.loadingMore[tab] = false
+	l := &m.loads[tab]
+	if l.cancel != nil {
+		l.cancel()
+	}
+	l.seq++
+	ctx, cancel := context.WithCancel(m.ctx)
+	l.cancel = cancel
+	return ctx, l.seq
+}
+
+// finishLoad reports whether a load result is current and releases its context.
+// Results of superseded loads return false and must be dropped so that they
+// cannot overwrite newer data.
+func (m *Model) finishLoad(tab, seq int) bool {
+	l := &m.loads[tab]
+	if seq != l.seq {
+		return false
+	}
+	m.loadingMore[tab] = false
+	if l.cancel != nil {
+		l.cancel()
+		l.cancel = nil
+	}
+	return true
+}
+
+// pageLimit returns how many rows a refresh of tab fetches: one page for
+// every page loaded so far, so scrolled-in rows stay on refresh.
+func (m *Model) pageLimit(tab int) int {
+	return m.config.GetPageSize() * max(1, m.pages[tab])
+}
+
+// syncMore shows the "load more" row state on the tab's list.
+func (m *Model) syncMore(tab int) {
+	more := m.pageNext[tab] != ""
+	switch tab {
+	case TabAlerts:
+		m.alertList.SetMore(more, m.loadingMore[tab])
+	case TabEvents:
+		m.eventList.SetMore(more, m.loadingMore[tab])
+	}
+}
 
+// maybeLoadMore fetches the next page of the current tab once the list is
+// scrolled near its end.
+func (m *Model) maybeLoadMore() tea.Cmd {
+	tab := m.tabBar.Active()
+	if !m.connected || m.pageNext[tab] == "" || m.loadingMore[tab] || m.loads[tab].cancel != nil {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	switch {
+	case tab == TabAlerts && m.alertList.NearEnd():
+		cmd = m.loadMoreProblems()
+	case tab == TabEvents && m.eventList.NearEnd():
+		cmd = m.loadMoreEvents()
+	default:
+		return nil
+	}
+	m.syncMore(tab)
+	return cmd
+}
+
+// cancelLoadsExcept cancels in-flight loads for every tab except keep.
+func (m *Model) cancelLoadsExcept(keep int) {
+	for tab := range m.loads {
+		if tab != keep && m.loads[tab].cancel != nil {
+			m.loads[tab].cancel()
+		}
+	}
+}
+
+// loadsInFlight reports whether any tab load is still running.
+func (m *Model) loadsInFlight() bool {
+	for _, l := range m.loads {
+		if l.cancel != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isStale reports whether the data for a tab is older than the stale threshold.
+// Tabs that have never loaded are not considered stale.
+func (m *Model) isStale(tab int, now time.Time) bool {
+	loaded := m.tabLoaded[tab]
+	return !loaded.IsZero() && now.Sub(loaded) > m.refreshInterval*StaleRefreshFactor
+}
+
+// updateStale marks each tab's list as stale or fresh based on its last load.
+func (m *Model) updateStale(now time.Time) {
+	age := func(tab int) string {
+		if !m.isStale(tab, now) {
+			return ""
+		}
+		return format.Duration(now.Sub(m.tabLoaded[tab]))
+	}
+	m.alertList.SetStale(age(TabAlerts))
+	m.hostList.SetStale(age(TabHosts))
+	m.eventList.SetStale(age(TabEvents))
+	m.graphList.SetStale(age(TabGraphs))
+}
+
+// tickClock returns a command that updates the status bar clock every second.
+// Returns nil when neither the clock nor the refresh countdown is shown.
+func (m *Model) tickClock() tea.Cmd {
+	if !m.config.Display.ShowClock && !m.config.Display.ShowCountdown {
+		return nil
+	}
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return ClockTickMsg{Time: t}
+	})
+}
+
+// updateClock refreshes the status bar clock and refresh countdown.
+func (m *Model) updateClock(now time.Time) {
+	if m.config.Display.ShowClock {
+		m.statusBar.SetClock(m.timeFormat.Clock(now))
+	}
+	if m.config.Display.ShowCountdown {
+		remaining := max(0, m.nextRefresh.Sub(now).Round(time.Second))
+		m.statusBar.SetNextRefresh(format.Duration(remaining))
+	}
+}
+
+// loadProblems fetches problems from Zabbix, enough to refill every page loaded so far.
+func (m *Model) loadProblems() tea.Cmd {
+	return m.fetchProblems("", m.pageLimit(TabAlerts))
+}
+
+// loadMoreProblems fetches the next page of problems and appends it to the list.
+func (m *Model) loadMoreProblems() tea.Cmd {
+	cmd := m.fetchProblems(m.pageNext[TabAlerts], m.config.GetPageSize())
+	m.loadingMore[TabAlerts] = true
+	return cmd
+}
+
+// fetchProblems fetches up to limit problems with an event ID up to till, or
+// the newest problems when till is empty. A non-empty till fetches a further
+// page whose results are appended.
+func (m *Model) fetchProblems(till string, limit int) tea.Cmd {
+	// Capture values for the goroutine
+	client := m.client
+	ctx, seq := m.beginLoad(TabAlerts)
+	minSeverity := m.minSeverity
+	search := m.serverSearch[TabAlerts]
+	ackFilter := m.alertList.AckFilter()
+	groupIDs := m.listGroupIDs()
+	if m.alertGroup != nil {
+		groupIDs = []string{m.alertGroup.GroupID}
+	}
+
+	return func() tea.Msg {
+		if client == nil {
+			return ProblemsLoadedMsg{Seq: seq}
+		}
+		start := time.Now()
+
+		params := zabbix.DefaultProblemGetParams()
+		params.Search = search
+		params.Limit = limit
+		params.EventIDTill = till
+		params.GroupIDs = groupIDs
 		if minSeverity > 0 {
-			problems, err = client.GetProblemsWithMinSeverity(ctx, minSeverity)
-		} else {
-			problems, err = client.GetActiveProblems(ctx)
+			params.Severities = zabbix.SeveritiesFrom(minSeverity)
+		}
+		// The user's own acknowledgements and assignments are picked out
+		// of the rest locally
+		params.Acknowledged = ackFilter.Acknowledged()
+		page, err := client.GetProblemPage(ctx, params)
+		if err != nil {
+			return ProblemsLoadedMsg{Seq: seq, Append: till != "", Err: err}
 		}
 
+		// Filters on the server leave out problems that are still open
+		scoped := len(groupIDs) > 0 || minSeverity > 0 || len(search) > 0 || params.Acknowledged != nil
 		return ProblemsLoadedMsg{
-			Problems: problems,
-			Err:      err,
+			Problems: page.Problems,
+			Next:     page.Next,
+			Append:   till != "",
+			Complete: !scoped && till == "" && page.Next == "",
+			Duration: time.Since(start),
+			Seq:      seq,
 		}
 	}
 }
 
-// loadHostCounts fetches host status counts from Zabbix.
+// loadHostCounts fetches host status counts from Zabbix, of the host groups
+// the lists are limited to so that they match those of the Hosts tab.
 func (m *Model) loadHostCounts() tea.Cmd {
 	// Capture values for the goroutine
 	client := m.client
 	ctx := m.ctx
+	groupIDs := m.listGroupIDs()
 
 	return func() tea.Msg {
 		if client == nil {
 			return HostCountsLoadedMsg{Err: nil}
 		}
 
-		counts, err := client.GetHostCounts(ctx)
+		counts, err := client.GetHostCounts(ctx, groupIDs)
 		return HostCountsLoadedMsg{
 			Counts: counts,
 			Err:    err,
@@ -290,61 +874,148 @@ func (m *Model) loadHostCounts() tea.Cmd {
 	}
 }
 
-// loadHosts fetches all hosts from Zabbix.
+// loadHosts fetches all hosts from Zabbix, with a summary of each host's
+// active problems.
 func (m *Model) loadHosts() tea.Cmd {
 	// Capture values for the goroutine
 	client := m.client
-	ctx := m.ctx
+	ctx, seq := m.beginLoad(TabHosts)
+	search := m.serverSearch[TabHosts]
+	groupIDs := m.listGroupIDs()
 
 	return func() tea.Msg {
 		if client == nil {
-			return HostsLoadedMsg{Err: nil}
+			return HostsLoadedMsg{Seq: seq}
 		}
 
-		fetchedHosts, err := client.GetAllHosts(ctx)
+		start := time.Now()
+		var fetchedHosts []zabbix.Host
+		var problems map[string]zabbix.HostProblems
+		var counts *zabbix.HostCounts
+		var err error
+		if len(search) > 0 {
+			// Hosts with the longest word are a superset of those with
+			// every word, which the list filter then picks out
+			var hostIDs []string
+			fetchedHosts, err = client.FindHosts(ctx, slices.MaxFunc(search, func(a, b string) int { return len(a) - len(b) }))
+			for _, h := range fetchedHosts {
+				hostIDs = append(hostIDs, h.HostID)
+			}
+			if err == nil && len(fetchedHosts) > 0 {
+				problems, err = client.GetHostProblems(ctx, hostIDs)
+			}
+		} else {
+			// The problems of every host don't depend on the host list, so
+			// fetch both at once to save a round trip
+			type problemsResult struct {
+				problems map[string]zabbix.HostProblems
+				err      error
+			}
+			done := make(chan problemsResult, 1)
+			go func() {
+				p, err := client.GetHostProblems(ctx, nil)
+				done <- problemsResult{p, err}
+			}()
+			fetchedHosts, err = client.GetAllHosts(ctx)
+			result := <-done
+			if err == nil {
+				problems, err = result.problems, result.err
+			}
+			if err == nil {
+				counts = zabbix.CountHosts(fetchedHosts)
+			}
+		}
+		if len(groupIDs) > 0 {
+			fetchedHosts = slices.DeleteFunc(fetchedHosts, func(h zabbix.Host) bool { return !inGroups(h, groupIDs) })
+			if counts != nil {
+				counts = zabbix.CountHosts(fetchedHosts)
+			}
+		}
+		var maintenances map[string]zabbix.Maintenance
+		if maintenanceIDs := hostMaintenanceIDs(fetchedHosts); err == nil && len(maintenanceIDs) > 0 {
+			// Only admins can read maintenances: others see hosts without them
+			maintenances, _ = client.GetMaintenances(ctx, maintenanceIDs)
+		}
 		return HostsLoadedMsg{
-			Hosts: fetchedHosts,
-			Err:   err,
+			Hosts:        fetchedHosts,
+			Problems:     problems,
+			Maintenances: maintenances,
+			Counts:       counts,
+			Duration:     time.Since(start),
+			Seq:          seq,
+			Err:          err,
 		}
 	}
 }
 
-// loadEvents fetches recent events from Zabbix.
+// loadEvents fetches recent events from Zabbix, enough to refill every page loaded so far.
 func (m *Model) loadEvents() tea.Cmd {
+	return m.fetchEvents("", m.pageLimit(TabEvents))
+}
+
+// loadMoreEvents fetches the next page of events and appends it to the list.
+func (m *Model) loadMoreEvents() tea.Cmd {
+	cmd := m.fetchEvents(m.pageNext[TabEvents], m.config.GetPageSize())
+	m.loadingMore[TabEvents] = true
+	return cmd
+}
+
+// fetchEvents fetches up to limit events in the Events tab's scope with an
+// event ID up to till, or the newest events when till is empty. A non-empty
+// till fetches a further page whose results are appended.
+func (m *Model) fetchEvents(till string, limit int) tea.Cmd {
 	// Capture values for the goroutine
 	client := m.client
-	ctx := m.ctx
+	ctx, seq := m.beginLoad(TabEvents)
+	search := m.serverSearch[TabEvents]
+	scope := m.eventScope
+	groupIDs := m.listGroupIDs()
 
 	return func() tea.Msg {
 		if client == nil {
-			return EventsLoadedMsg{Err: nil}
+			return EventsLoadedMsg{Seq: seq}
+		}
+
+		start := time.Now()
+		params := scope.params(start)
+		params.Limit = limit
+		params.EventIDTill = till
+		params.Search = search
+		params.GroupIDs = groupIDs
+		page, err := client.GetEventPage(ctx, params)
+		if err != nil {
+			return EventsLoadedMsg{Seq: seq, Append: till != "", Err: err}
 		}
 
-		// Get events from the last 24 hours, limit to 500
-		fetchedEvents, err := client.GetRecentEvents(ctx, 24, 500)
 		return EventsLoadedMsg{
-			Events: fetchedEvents,
-			Err:    err,
+			Events:   page.Events,
+			Next:     page.Next,
+			Append:   till != "",
+			Duration: time.Since(start),
+			Seq:      seq,
 		}
 	}
 }
 
-// loadItems fetches numeric items from Zabbix for the graphs tab.
+// loadItems fetches the items of the graphs tab from Zabbix.
 func (m *Model) loadItems() tea.Cmd {
 	// Capture values for the goroutine
 	client := m.client
-	ctx := m.ctx
+	ctx, seq := m.beginLoad(TabGraphs)
 	categories := m.config.GetGraphCategories()
 
 	return func() tea.Msg {
 		if client == nil {
-			return ItemsLoadedMsg{Err: nil}
+			return ItemsLoadedMsg{Seq: seq}
 		}
 
-		items, err := client.GetAllNumericItems(ctx, categories)
+		start := time.Now()
+		items, err := client.GetGraphItems(ctx, categories)
 		return ItemsLoadedMsg{
-			Items: items,
-			Err:   err,
+			Items:    items,
+			Duration: time.Since(start),
+			Seq:      seq,
+			Err:      err,
 		}
 	}
 }
@@ -373,28 +1044,96 @@ func (m *Model) loadHostHistory(hostID string) tea.Cmd {
 	}
 }
 
-// acknowledgeProblem sends an acknowledgment for the selected problem.
-func (m *Model) acknowledgeProblem(message string) tea.Cmd {
+// promptAckMessage asks for the message acknowledging the selected
+// problem, closing it as well if closeProblem is set.
+func (m *Model) promptAckMessage(closeProblem bool) {
+	m.mode = ModeAckMessage
+	m.ackClose = closeProblem
+	m.commandInput.SetMode(command.ModeAckMessage)
+	if n := m.config.Ack.MinMessageLength; n > 0 {
+		m.commandInput.SetHint(fmt.Sprintf("A message of at least %d characters is required", n))
+	}
+}
+
+// checkAckMessage returns an error if a message is too short for the
+// configured minimum length.
+func (m *Model) checkAckMessage(message string) error {
+	n := m.config.Ack.MinMessageLength
+	if got := utf8.RuneCountInString(strings.TrimSpace(message)); got < n {
+		return fmt.Errorf("message needs at least %d characters, got %d", n, got)
+	}
+	return nil
+}
+
+// acknowledgeProblem sends an acknowledgment for the selected problem, or
+// all problems of the selected group, closing it as well if closeProblem is
+// set.
+func (m *Model) acknowledgeProblem(message string, closeProblem bool) tea.Cmd {
 	// Capture values for the goroutine
 	client := m.client
 	ctx := m.ctx
 	selected := m.alertList.Selected()
+	group := m.alertList.SelectedGroup()
+	if marked := m.alertList.Marked(); marked != nil {
+		// Alerts marked by dragging are acknowledged together, like a group
+		group = marked
+	}
 
 	return func() tea.Msg {
 		if client == nil || selected == nil {
 			return AcknowledgeResultMsg{Success: false}
 		}
 
-		err := client.AcknowledgeProblem(ctx, selected.EventID, message)
+		var err error
+		if group != nil {
+			ids := make([]string, len(group))
+			for i, p := range group {
+				ids[i] = p.EventID
+			}
+			err = client.AcknowledgeProblems(ctx, ids, message)
+			return AcknowledgeResultMsg{Count: len(ids), Success: err == nil, Err: err}
+		}
+		if closeProblem {
+			err = client.AcknowledgeAndCloseProblem(ctx, selected.EventID, message)
+		} else {
+			err = client.AcknowledgeProblem(ctx, selected.EventID, message)
+		}
 
 		return AcknowledgeResultMsg{
 			EventID: selected.EventID,
+			Closed:  closeProblem,
 			Success: err == nil,
 			Err:     err,
 		}
 	}
 }
 
+// assignProblems assigns the selected problem, or all problems of the
+// selected group or the marked ones, to username, or unassigns them when
+// username is empty.
+func (m *Model) assignProblems(username string) tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+	problems := m.selectedProblems()
+
+	return func() tea.Msg {
+		if client == nil || len(problems) == 0 {
+			return AssignResultMsg{Username: username}
+		}
+
+		ids := make([]string, len(problems))
+		for i, p := range problems {
+			ids[i] = p.EventID
+		}
+		message := zabbix.UnassignMessage("")
+		if username != "" {
+			message = zabbix.AssignMessage(username, "")
+		}
+		err := client.AddProblemsMessage(ctx, ids, message)
+		return AssignResultMsg{Username: username, Count: len(ids), Err: err}
+	}
+}
+
 // loadHostTriggers fetches triggers for a specific host.
 // If selectTriggerID is non-empty, that trigger will be pre-selected in the editor.
 func (m *Model) loadHostTriggers(hostID, selectTriggerID string) tea.Cmd {
@@ -426,7 +1165,7 @@ func (m *Model) loadHostMacros(hostID string) tea.Cmd {
 			return HostMacrosLoadedMsg{HostID: hostID, Err: nil}
 		}
 
-		macros, err := client.GetHostMacros(ctx, hostID)
+		macros, err := client.GetEffectiveMacros(ctx, hostID)
 		return HostMacrosLoadedMsg{
 			HostID: hostID,
 			Macros: macros,
@@ -439,20 +1178,21 @@ func (m *Model) loadHostMacros(hostID string) tea.Cmd {
 func (m *Model) toggleTrigger(triggerID string, enable bool, _ string) tea.Cmd {
 	client := m.client
 	ctx := m.ctx
+	action := "disable"
+	if enable {
+		action = "enable"
+	}
 
 	return func() tea.Msg {
 		if client == nil {
-			return TriggerUpdateResultMsg{TriggerID: triggerID, Err: nil}
+			return TriggerUpdateResultMsg{TriggerID: triggerID, Action: action}
 		}
 
 		var err error
-		var action string
 		if enable {
 			err = client.EnableTrigger(ctx, triggerID)
-			action = "enable"
 		} else {
 			err = client.DisableTrigger(ctx, triggerID)
-			action = "disable"
 		}
 
 		return TriggerUpdateResultMsg{
@@ -464,6 +1204,36 @@ func (m *Model) toggleTrigger(triggerID string, enable bool, _ string) tea.Cmd {
 	}
 }
 
+// toggleTriggers enables or disables several triggers at once.
+func (m *Model) toggleTriggers(triggerIDs []string, enable bool) tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+	action := "disable"
+	if enable {
+		action = "enable"
+	}
+
+	return func() tea.Msg {
+		if client == nil {
+			return TriggerUpdateResultMsg{TriggerID: triggerIDs[0], Action: action, Count: len(triggerIDs)}
+		}
+
+		var err error
+		if enable {
+			err = client.EnableTriggers(ctx, triggerIDs)
+		} else {
+			err = client.DisableTriggers(ctx, triggerIDs)
+		}
+		return TriggerUpdateResultMsg{
+			TriggerID: triggerIDs[0],
+			Action:    action,
+			Count:     len(triggerIDs),
+			Success:   err == nil,
+			Err:       err,
+		}
+	}
+}
+
 // updateHostMacro updates a macro value.
 func (m *Model) updateHostMacro(macroID, value, _ string) tea.Cmd {
 	client := m.client
@@ -471,7 +1241,7 @@ func (m *Model) updateHostMacro(macroID, value, _ string) tea.Cmd {
 
 	return func() tea.Msg {
 		if client == nil {
-			return MacroUpdateResultMsg{MacroID: macroID, Err: nil}
+			return MacroUpdateResultMsg{MacroID: macroID, Action: "update"}
 		}
 
 		err := client.UpdateHostMacro(ctx, macroID, value)
@@ -484,6 +1254,25 @@ func (m *Model) updateHostMacro(macroID, value, _ string) tea.Cmd {
 	}
 }
 
+// createHostMacro defines a macro on a host, overriding its inherited value.
+func (m *Model) createHostMacro(hostID, macro, value string) tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+
+	return func() tea.Msg {
+		if client == nil {
+			return MacroUpdateResultMsg{Action: "create"}
+		}
+
+		err := client.CreateHostMacro(ctx, hostID, macro, value)
+		return MacroUpdateResultMsg{
+			Action:  "create",
+			Success: err == nil,
+			Err:     err,
+		}
+	}
+}
+
 // deleteHostMacro deletes a macro.
 func (m *Model) deleteHostMacro(macroID, _ string) tea.Cmd {
 	client := m.client
@@ -491,7 +1280,7 @@ func (m *Model) deleteHostMacro(macroID, _ string) tea.Cmd {
 
 	return func() tea.Msg {
 		if client == nil {
-			return MacroUpdateResultMsg{MacroID: macroID, Err: nil}
+			return MacroUpdateResultMsg{MacroID: macroID, Action: "delete"}
 		}
 
 		err := client.DeleteHostMacro(ctx, macroID)
@@ -511,7 +1300,7 @@ func (m *Model) enableHost(hostID string) tea.Cmd {
 
 	return func() tea.Msg {
 		if client == nil {
-			return HostUpdateResultMsg{HostID: hostID, Err: nil}
+			return HostUpdateResultMsg{HostID: hostID, Action: "enable"}
 		}
 
 		err := client.EnableHost(ctx, hostID)
@@ -531,7 +1320,7 @@ func (m *Model) disableHost(hostID string) tea.Cmd {
 
 	return func() tea.Msg {
 		if client == nil {
-			return HostUpdateResultMsg{HostID: hostID, Err: nil}
+			return HostUpdateResultMsg{HostID: hostID, Action: "disable"}
 		}
 
 		err := client.DisableHost(ctx, hostID)
@@ -553,42 +1342,273 @@ func (m *Model) SetSize(width, height int) {
 	statusBarHeight := 1
 	tabBarHeight := 1
 	commandHeight := 1
+	if m.kiosk {
+		commandHeight = 0
+	}
 	contentHeight := height - statusBarHeight - tabBarHeight - commandHeight - 4 // borders
 
-	// Split width based on defined percentages
-	// Account for borders: each pane has 2 chars (left+right border)
-	availableWidth := width - 4 // 4 = 2 borders per pane * 2 panes
-	listWidth := availableWidth * ListWidthPercent / 100
-	detailWidth := availableWidth - listWidth
-
-	// Store pane bounds for mouse tracking
-	m.listPaneX = 0
-	m.listPaneWidth = listWidth + 2 // Include left+right border
-	m.detailPaneX = m.listPaneWidth
 	m.contentY = statusBarHeight + tabBarHeight // Y position after status bar and tab bar
 	m.contentHeight = contentHeight + 2         // Include top+bottom border
+	m.stacked = !m.kiosk && m.useStackedLayout(width, height)
+	m.compact = m.kiosk || (!m.stacked && width < CompactWidth)
+
+	var listWidth, listHeight, detailWidth, detailHeight int
+	switch {
+	case m.compact:
+		// Single pane: list and detail each use the whole content area
+		listWidth = width - 2
+		detailWidth = width - 2
+		listHeight = contentHeight
+		detailHeight = contentHeight
+
+		m.listPaneWidth = width
+		m.listPaneHeight = m.contentHeight
+		m.detailPaneX = 0
+	case m.stacked:
+		// Stack panes vertically: each pane spans the full width
+		// Account for the second pane's top+bottom border
+		availableHeight := contentHeight - 2
+		listWidth = width - 2
+		detailWidth = width - 2
+		listHeight = availableHeight * ListHeightPercent / 100
+		detailHeight = availableHeight - listHeight
+
+		m.listPaneWidth = width
+		m.listPaneHeight = listHeight + 2 // Include top+bottom border
+		m.detailPaneX = 0
+	default:
+		// Split width based on defined percentages
+		// Account for borders: each pane has 2 chars (left+right border)
+		availableWidth := width - 4 // 4 = 2 borders per pane * 2 panes
+		listWidth = availableWidth * ListWidthPercent / 100
+		detailWidth = availableWidth - listWidth
+		listHeight = contentHeight
+		detailHeight = contentHeight
+
+		m.listPaneWidth = listWidth + 2 // Include left+right border
+		m.listPaneHeight = m.contentHeight
+		m.detailPaneX = m.listPaneWidth
+	}
+	m.listPaneX = 0
 
-	m.alertList.SetSize(listWidth, contentHeight)
-	m.hostList.SetSize(listWidth, contentHeight)
-	m.eventList.SetSize(listWidth, contentHeight)
-	m.graphList.SetSize(listWidth, contentHeight)
-	m.detailPane.SetSize(detailWidth, contentHeight)
+	m.alertList.SetSize(listWidth, listHeight)
+	m.hostList.SetSize(listWidth, listHeight)
+	m.eventList.SetSize(listWidth, listHeight)
+	m.graphList.SetSize(listWidth, listHeight)
+	m.searchList.SetSize(listWidth, listHeight)
+	m.detailPane.SetSize(detailWidth, detailHeight)
 	m.statusBar.SetWidth(width)
 	m.tabBar.SetWidth(width)
 	m.commandInput.SetWidth(width)
 	m.editorPane.SetScreenSize(width, height)
+	m.dashboardView.SetScreenSize(width, height)
+	m.tutorial.SetSize(width, height)
+	m.contextMenu.SetSize(width, height)
+	m.palette.SetSize(width, height)
+	m.notifications.SetSize(width, height)
+}
+
+// useStackedLayout reports whether the list should be stacked above the detail pane.
+// In auto mode the panes are stacked when the terminal is taller than wide,
+// accounting for terminal cells being roughly twice as tall as they are wide.
+func (m *Model) useStackedLayout(width, height int) bool {
+	switch m.config.GetLayout() {
+	case config.LayoutStacked:
+		return true
+	case config.LayoutSideBySide:
+		return false
+	default:
+		return height*CellAspectRatio > width
+	}
+}
+
+// paneAt returns the content pane at the given screen position.
+// Returns false if the position is outside the content area.
+func (m *Model) paneAt(x, y int) (Pane, bool) {
+	if y < m.contentY || y >= m.contentY+m.contentHeight {
+		return PaneList, false
+	}
+	if m.compact {
+		// Only the focused pane is on screen
+		return m.focused, true
+	}
+	if m.stacked {
+		if y < m.contentY+m.listPaneHeight {
+			return PaneList, true
+		}
+		return PaneDetail, true
+	}
+	if x < m.listPaneWidth {
+		return PaneList, true
+	}
+	return PaneDetail, true
 }
 
 // Shutdown performs cleanup.
 func (m *Model) Shutdown() {
 	// Logout before canceling context so the request can complete
-	if m.client != nil {
+	// A session of the frontend is the user's to end
+	if m.client != nil && !m.config.UseSession() {
 		// Use a short timeout context for logout, not the canceled one
 		ctx, cancel := context.WithTimeout(context.Background(), LogoutTimeout*time.Second)
 		defer cancel()
 		_ = m.client.Logout(ctx)
 	}
+	if m.session != nil {
+		_ = m.saveSession()
+	}
 	m.cancel()
+	_ = m.debugLog.Close()
+	if m.watcher != nil {
+		_ = m.watcher.Close()
+	}
+}
+
+// SetDebug enables or disables API call logging.
+// When enabled, calls are also appended to debug.log in the config directory.
+func (m *Model) SetDebug(enabled bool) error {
+	if !enabled {
+		return m.debugLog.Disable()
+	}
+	return m.debugLog.Enable(filepath.Join(config.Dir(), "debug.log"))
+}
+
+// SetClientOptions sets extra options for the API clients created when
+// connecting, such as a transport that serves demo data.
+func (m *Model) SetClientOptions(opts ...zabbix.ClientOption) {
+	m.clientOptions = opts
+}
+
+// SetDarkBackground records whether the terminal background is dark, for
+// resolving "auto" themes on reload. Defaults to true.
+func (m *Model) SetDarkBackground(dark bool) {
+	m.darkBackground = dark
+}
+
+// WatchConfig reloads settings and the theme whenever the config file at
+// path or a custom theme file changes on disk.
+func (m *Model) WatchConfig(path string) error {
+	fileConfig, err := config.LoadFromFile(path)
+	if err != nil {
+		return err
+	}
+	w, err := config.Watch(path, filepath.Join(config.Dir(), "themes"))
+	if err != nil {
+		return err
+	}
+	m.configPath = path
+	m.fileConfig = fileConfig
+	m.watcher = w
+	return nil
+}
+
+// waitForConfigChange returns a command that waits for the next change to
+// the config or theme files. Returns nil when not watching.
+func (m *Model) waitForConfigChange() tea.Cmd {
+	if m.watcher == nil {
+		return nil
+	}
+	changes := m.watcher.Changes()
+	return func() tea.Msg {
+		if _, ok := <-changes; !ok {
+			return nil
+		}
+		return ConfigChangedMsg{}
+	}
+}
+
+// reloadConfig applies the display and graph settings of the config file and
+// re-reads the theme. Settings that are unchanged in the file keep their
+// current values, so command-line overrides and changes made with keys
+// survive a reload. Connection settings require a restart.
+func (m *Model) reloadConfig() (tea.Cmd, error) {
+	next, err := config.LoadFromFile(m.configPath)
+	if err != nil {
+		return nil, err
+	}
+	prev := m.fileConfig
+
+	display := next.Display
+	if display.RefreshInterval == prev.Display.RefreshInterval {
+		display.RefreshInterval = m.config.Display.RefreshInterval
+	}
+	if display.MinSeverity == prev.Display.MinSeverity {
+		display.MinSeverity = m.config.Display.MinSeverity
+	}
+	if display.Theme == prev.Display.Theme {
+		display.Theme = m.config.Display.Theme
+	}
+	// The color profile is chosen at startup, so no-color mode needs a restart
+	display.NoColor = m.config.Display.NoColor
+
+	merged := *m.config
+	merged.Display = display
+	merged.Graphs = next.Graphs
+	merged.Mute = next.Mute
+	merged.SLA = next.SLA
+	if err := merged.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Custom theme files are re-read even when the name is unchanged
+	t, err := theme.Load(theme.Resolve(display.Theme, m.darkBackground), config.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	clockRunning := m.config.Display.ShowClock || m.config.Display.ShowCountdown
+	*m.config = merged
+	m.fileConfig = next
+
+	m.theme = t
+	m.restyle()
+	m.alertList.SetSLA(m.config.GetSLA())
+	if err := m.applyMuteRules(); err != nil {
+		return nil, err
+	}
+
+	m.refreshInterval = time.Duration(display.RefreshInterval) * time.Second
+	if display.MinSeverity != prev.Display.MinSeverity {
+		m.minSeverity = display.MinSeverity
+		m.alertList.SetMinSeverity(m.minSeverity)
+		m.statusBar.SetFilter(m.minSeverity, m.textFilter)
+	}
+	if display.AckFilter != prev.Display.AckFilter {
+		// Applied to the loaded alerts now, and to the API query on refresh
+		f, _ := alerts.ParseAckFilter(display.AckFilter)
+		m.alertList.SetAckFilter(f)
+	}
+
+	relative := m.timeFormat.Relative
+	if display.TimeDisplay != prev.Display.TimeDisplay {
+		relative = m.config.GetRelativeTime()
+	}
+	m.timeFormat = format.TimeFormat{
+		Relative: relative,
+		Hour12:   m.config.GetHour12(),
+		Layout:   m.config.GetTimeFormat(),
+		Location: m.config.GetLocation(),
+	}
+	m.applyTimeFormat()
+
+	m.statusBar.SetOnCall(display.OnCall)
+	m.recordProblemTrend(-1)
+	if !display.ShowClock {
+		m.statusBar.SetClock("")
+	}
+	if !display.ShowCountdown {
+		m.statusBar.SetNextRefresh("")
+	}
+	m.updateClock(time.Now())
+	if m.width > 0 {
+		m.SetSize(m.width, m.height)
+	}
+
+	cmds := []tea.Cmd{m.updateWindowTitle()}
+	if !clockRunning {
+		cmds = append(cmds, m.tickClock())
+	}
+	return tea.Batch(cmds...), nil
 }
 
 // getSelectedHostID returns the host ID for the currently selected item.
@@ -630,6 +1650,8 @@ func (m *Model) getSelectedHostAndTriggerID() (hostID, triggerID string) {
 				triggerID = selected.RelatedObject.TriggerID
 			}
 		}
+	case TabSearch:
+		hostID, triggerID = searchResultIDs(m.searchList.Selected())
 	}
 	return
 }
@@ -683,33 +1705,53 @@ var (
 	}
 )
 
+// recordProblemTrend adds an alert count to the status bar sparkline,
+// keeping the configured number of refreshes. A negative count only trims
+// it, e.g. after the setting changed.
+func (m *Model) recordProblemTrend(count int) {
+	if count >= 0 {
+		m.problemTrend = append(m.problemTrend, count)
+	}
+	keep := m.config.GetProblemTrend()
+	if len(m.problemTrend) > keep {
+		m.problemTrend = slices.Clone(m.problemTrend[len(m.problemTrend)-keep:])
+	}
+	m.statusBar.SetTrend(m.problemTrend)
+}
+
 // getAlertCountsBySeverity returns a map of severity level to alert count.
-// Only counts problems that are not ignored.
+// Only counts problems that are not ignored or muted.
 func (m *Model) getAlertCountsBySeverity() map[int]int {
 	counts := make(map[int]int)
-	for _, p := range m.problems {
-		// Skip ignored alerts
-		if m.ignoreList != nil {
-			hostID := ""
-			triggerID := ""
-			if len(p.Hosts) > 0 {
-				hostID = p.Hosts[0].HostID
-			}
-			if p.Object == ObjectTypeTrigger {
-				triggerID = p.ObjectID
-			}
-			if triggerID == "" && p.RelatedObject.TriggerID != "" {
-				triggerID = p.RelatedObject.TriggerID
-			}
-			if hostID != "" && triggerID != "" && m.ignoreList.IsIgnored(hostID, triggerID) {
-				continue
-			}
+	for i := range m.problems {
+		if !m.isHidden(&m.problems[i]) {
+			counts[m.problems[i].SeverityInt()]++
 		}
-		counts[p.SeverityInt()]++
 	}
 	return counts
 }
 
+// isHidden reports whether a problem is ignored or muted.
+func (m *Model) isHidden(p *zabbix.Problem) bool {
+	if m.ignoreList != nil {
+		hostID := ""
+		triggerID := ""
+		if len(p.Hosts) > 0 {
+			hostID = p.Hosts[0].HostID
+		}
+		if p.Object == ObjectTypeTrigger {
+			triggerID = p.ObjectID
+		}
+		if triggerID == "" && p.RelatedObject.TriggerID != "" {
+			triggerID = p.RelatedObject.TriggerID
+		}
+		if hostID != "" && triggerID != "" && m.ignoreList.IsIgnored(hostID, triggerID) {
+			return true
+		}
+	}
+	return m.muteList.Muted(p)
+}
+
 // windowTitle generates the window/tab title string based on current alert state.
 func (m *Model) windowTitle() string {
 	if !m.connected {
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/config"
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// TestSetSize_Layout verifies the pane layout chosen for each layout setting
+// and terminal shape.
+func TestSetSize_Layout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		layout      string
+		width       int
+		height      int
+		wantStacked bool
+	}{
+		{"auto wide terminal", "", 160, 40, false},
+		{"auto tall terminal", config.LayoutAuto, 60, 50, true},
+		{"forced side-by-side", config.LayoutSideBySide, 100, 60, false},
+		{"forced stacked", config.LayoutStacked, 160, 40, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testConfig()
+			cfg.Display.Layout = tt.layout
+			m := New(cfg, theme.DefaultTheme())
+			m.SetSize(tt.width, tt.height)
+
+			if m.stacked != tt.wantStacked {
+				t.Errorf("stacked = %v, want %v", m.stacked, tt.wantStacked)
+			}
+
+			// The detail pane must be reachable with the mouse in either layout
+			var x, y int
+			if tt.wantStacked {
+				x, y = 1, m.contentY+m.listPaneHeight+1
+			} else {
+				x, y = m.listPaneWidth+1, m.contentY+1
+			}
+			if pane, ok := m.paneAt(x, y); !ok || pane != PaneDetail {
+				t.Errorf("paneAt(%d, %d) = %v, %v; want PaneDetail", x, y, pane, ok)
+			}
+			if pane, ok := m.paneAt(1, m.contentY+1); !ok || pane != PaneList {
+				t.Errorf("paneAt(1, %d) = %v, %v; want PaneList", m.contentY+1, pane, ok)
+			}
+		})
+	}
+}
+
+// TestCompactMode verifies that narrow terminals show one pane at a time and
+// that Enter/Esc open and close the detail overlay.
+func TestCompactMode(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Display.Layout = config.LayoutSideBySide
+	m := New(cfg, theme.DefaultTheme())
+	m.SetSize(80, 30)
+
+	if !m.compact {
+		t.Fatal("expected compact mode below CompactWidth")
+	}
+
+	// Enter without a selection keeps the list visible
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated, ok := newModel.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", newModel)
+	}
+	if updated.focused != PaneList {
+		t.Error("expected list to stay focused without a selection")
+	}
+
+	updated.problems = []zabbix.Problem{{EventID: "1", Name: "CPU high", Severity: "4"}}
+	updated.alertList.SetProblems(updated.problems)
+
+	newModel, _ = updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated, ok = newModel.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", newModel)
+	}
+	if updated.focused != PaneDetail {
+		t.Error("expected Enter to open the detail overlay")
+	}
+
+	newModel, _ = updated.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	updated, ok = newModel.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", newModel)
+	}
+	if updated.focused != PaneList {
+		t.Error("expected Esc to close the detail overlay")
+	}
+}
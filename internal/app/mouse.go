@@ -0,0 +1,53 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// doubleClickInterval is the longest time between two clicks on the same
+// row that counts as a double-click.
+const doubleClickInterval = 400 * time.Millisecond
+
+// clickState is the last click on a list row, for detecting double-clicks.
+type clickState struct {
+	tab, row int
+	at       time.Time
+}
+
+// dragState is a drag of the mouse over the Alerts list, which marks the
+// rows from the one it started on.
+type dragState struct {
+	from   int  // Row the drag started on
+	active bool // The left button was pressed on a row
+	moved  bool // The mouse has moved to another row
+}
+
+// isDoubleClick records a click on a row of the active tab and reports
+// whether it completes a double-click. A third click starts over.
+func (m *Model) isDoubleClick(row int, now time.Time) bool {
+	tab := m.tabBar.Active()
+	double := m.lastClick.tab == tab && m.lastClick.row == row && now.Sub(m.lastClick.at) < doubleClickInterval
+	if double {
+		m.lastClick = clickState{}
+	} else {
+		m.lastClick = clickState{tab: tab, row: row, at: now}
+	}
+	return double
+}
+
+// openRow opens the selected row on double-click: the detail pane for
+// alerts and events, the trigger editor for hosts, and a search result on
+// its tab.
+func (m Model) openRow() (tea.Model, tea.Cmd) {
+	switch m.tabBar.Active() {
+	case TabHosts:
+		model, cmd, _ := m.handleEditTriggers()
+		return model, cmd
+	case TabSearch:
+		return m, m.searchList.Open()
+	}
+	m.setFocus(PaneDetail)
+	return m, nil
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/editor"
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/config"
+	"github.com/harpchad/chotko/internal/mute"
+)
+
+// applyMuteRules hides the alerts matching the configured mute rules.
+func (m *Model) applyMuteRules() error {
+	list, err := mute.New(m.config.Mute)
+	if err != nil {
+		return err
+	}
+	m.muteList = list
+	m.alertList.SetMuteChecker(list.Muted)
+	m.updateAlertCounts()
+	return nil
+}
+
+// updateAlertCounts shows the numbers of alerts by severity, of muted
+// alerts and of alerts past their SLA in the status bar.
+func (m *Model) updateAlertCounts() {
+	m.statusBar.SetSeverityCounts(m.getAlertCountsBySeverity())
+	m.statusBar.SetMuted(m.alertList.MutedCount(), m.alertList.ShowMuted())
+	m.statusBar.SetSLABreaches(m.alertList.SLABreaches())
+}
+
+// muteCounts returns the number of loaded problems matching each rule.
+func (m *Model) muteCounts(rules []config.MuteRule) []int {
+	counts := make([]int, len(rules))
+	for i, rule := range rules {
+		list, err := mute.New([]config.MuteRule{rule})
+		if err != nil {
+			continue
+		}
+		for j := range m.problems {
+			if list.Muted(&m.problems[j]) {
+				counts[i]++
+			}
+		}
+	}
+	return counts
+}
+
+// handleMutedCommand shows or hides muted alerts with ":muted show|hide",
+// or opens the mute rule editor with ":muted".
+func (m *Model) handleMutedCommand(cmd string) {
+	parts := strings.Fields(cmd)
+	switch {
+	case len(parts) == 1:
+		m.showMuteEditor()
+	case len(parts) == 2 && (parts[1] == "show" || parts[1] == "hide"):
+		m.alertList.SetShowMuted(parts[1] == "show")
+		m.updateAlertCounts()
+		if selected := m.alertList.Selected(); selected != nil {
+			m.setDetailProblem(selected)
+		}
+		if parts[1] == "show" {
+			m.statusBar.SetStatus(fmt.Sprintf("Showing %d muted alerts", m.alertList.MutedCount()))
+		} else {
+			m.statusBar.SetStatus("Hiding muted alerts")
+		}
+	default:
+		m.statusBar.SetStatus("Usage: :muted to edit the mute rules, :muted show|hide")
+	}
+}
+
+// showMuteEditor opens the mute rule editor. A new rule starts with the
+// name of the selected alert.
+func (m *Model) showMuteEditor() {
+	suggest := ""
+	if selected := m.alertList.Selected(); selected != nil {
+		suggest = "^" + regexp.QuoteMeta(selected.Name) + "$"
+	}
+	m.editorPane.ShowMuteRules(m.config.Mute, m.muteCounts(m.config.Mute), suggest)
+	m.showEditor = true
+}
+
+// handleMuteRulesChangedMsg applies edited mute rules and saves them to
+// the config file.
+func (m Model) handleMuteRulesChangedMsg(msg editor.MuteRulesChangedMsg) (tea.Model, tea.Cmd) {
+	m.config.Mute = msg.Rules
+	if err := m.applyMuteRules(); err != nil {
+		m.statusBar.SetStatus(fmt.Sprintf("Mute rules: %v", err))
+		return m, nil
+	}
+	m.editorPane.SetMuteRules(msg.Rules, m.muteCounts(msg.Rules))
+	if selected := m.alertList.Selected(); selected != nil {
+		m.setDetailProblem(selected)
+	}
+
+	if m.configPath == "" {
+		m.statusBar.SetStatus("Mute rules changed for this session only")
+		return m, m.updateWindowTitle()
+	}
+	if err := m.saveMuteRules(msg.Rules); err != nil {
+		return m, tea.Batch(m.notifyError("Mute rules not saved", err), m.updateWindowTitle())
+	}
+	return m, tea.Batch(m.notify(notify.Success, "Mute rules saved to "+m.configPath), m.updateWindowTitle())
+}
+
+// saveMuteRules writes mute rules to the config file, leaving its other
+// settings as they are on disk.
+func (m *Model) saveMuteRules(rules []config.MuteRule) error {
+	cfg, err := config.LoadFromFile(m.configPath)
+	if err != nil {
+		return err
+	}
+	cfg.Mute = rules
+	if err := config.SaveToFile(cfg, m.configPath); err != nil {
+		return err
+	}
+	// The reload this triggers finds the rules unchanged
+	m.fileConfig.Mute = rules
+	return nil
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/notify"
+)
+
+// actionDone describes the actions of result messages once done.
+var actionDone = map[string]string{
+	"create":  "created",
+	"update":  "updated",
+	"delete":  "deleted",
+	"enable":  "enabled",
+	"disable": "disabled",
+}
+
+// notify shows the result of an action in the notification drawer and
+// keeps it in the history.
+func (m *Model) notify(level notify.Level, text string) tea.Cmd {
+	return m.notifications.Push(level, text, time.Now())
+}
+
+// notifyError shows a failed action in the notification drawer.
+func (m *Model) notifyError(text string, err error) tea.Cmd {
+	return m.notify(notify.Error, text+": "+err.Error())
+}
+
+// showNotifications displays the recent notifications in a modal, the most
+// recent last.
+func (m *Model) showNotifications() {
+	history := m.notifications.History()
+	lines := make([]string, 0, len(history))
+	if len(history) == 0 {
+		lines = append(lines, "No notifications yet.")
+	}
+	for _, n := range history {
+		label := "      "
+		switch n.Level {
+		case notify.Success:
+			label = "done  "
+		case notify.Error:
+			label = "error "
+		}
+		lines = append(lines, m.timeFormat.Clock(n.Time)+"  "+label+n.Text)
+	}
+
+	m.showError = true
+	m.errorModal.ShowText("Notifications", lines)
+}
@@ -0,0 +1,160 @@
+package app
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/command"
+	"github.com/harpchad/chotko/internal/components/palette"
+	"github.com/harpchad/chotko/internal/theme"
+)
+
+// maxRecentActions is how many recently used actions the command palette
+// remembers.
+const maxRecentActions = 10
+
+// paletteAction is an action of the command palette: a key to press, or a
+// command to run or to complete in command mode.
+type paletteAction struct {
+	entry   palette.Entry
+	key     string
+	command string
+	partial bool // The command needs arguments typed after it
+}
+
+// paletteActions returns every action of the command palette: those of the
+// key bindings in effect, as grouped on the help screen, then the commands.
+// Moving around lists is left to the keys.
+func (m *Model) paletteActions() []paletteAction {
+	var actions []paletteAction
+	seen := make(map[string]bool)
+	for _, g := range m.keys.bindingGroups() {
+		if g.title == "Navigation" {
+			continue
+		}
+		for _, b := range g.bindings {
+			desc := b.Help().Desc
+			id := "key:" + desc
+			if !b.Enabled() || seen[id] || desc == m.keys.Select.Help().Desc ||
+				desc == m.keys.Escape.Help().Desc || desc == m.keys.Palette.Help().Desc {
+				continue
+			}
+			seen[id] = true
+			if desc == m.keys.SeverityFilter.Help().Desc {
+				actions = append(actions, severityActions(b)...)
+				continue
+			}
+			actions = append(actions, paletteAction{
+				entry: palette.Entry{ID: id, Label: capitalize(desc), Group: g.title, Key: keyLabel(b.Keys())},
+				key:   b.Keys()[0],
+			})
+		}
+	}
+
+	for _, c := range commandHelp {
+		name, partial := commandToRun(c.Keys)
+		actions = append(actions, paletteAction{
+			entry:   palette.Entry{ID: "cmd:" + c.Keys, Label: capitalize(c.Desc), Group: "Command", Key: c.Keys},
+			command: name,
+			partial: partial,
+		})
+	}
+	return actions
+}
+
+// severityActions returns an action per key of the severity filter, which
+// sets a different minimum severity each.
+func severityActions(b key.Binding) []paletteAction {
+	var actions []paletteAction
+	for _, k := range b.Keys() {
+		severity, err := strconv.Atoi(k)
+		if err != nil {
+			continue
+		}
+		label := "Show " + theme.SeverityName(severity) + " and above"
+		if severity == 0 {
+			label = "Show all severities"
+		}
+		actions = append(actions, paletteAction{
+			entry: palette.Entry{ID: "severity:" + k, Label: label, Group: "Filtering", Key: k},
+			key:   k,
+		})
+	}
+	return actions
+}
+
+// commandToRun returns the command to run for a command as listed on the
+// help screen, e.g. "tokens rotate" for ":tokens rotate" or "export" for
+// ":export [FILE]", and whether arguments such as NAME or on|off must be
+// typed after it.
+func commandToRun(usage string) (string, bool) {
+	fields := strings.Fields(strings.TrimPrefix(usage, ":"))
+	words := fields[:1]
+	for _, f := range fields[1:] {
+		switch {
+		case strings.HasPrefix(f, "["):
+			return strings.Join(words, " "), false
+		case strings.ContainsRune(f, '|') || strings.ContainsFunc(f, unicode.IsUpper):
+			return strings.Join(words, " "), true
+		}
+		words = append(words, f)
+	}
+	return strings.Join(words, " "), false
+}
+
+// paletteEntries returns the entries of the command palette.
+func (m *Model) paletteEntries() []palette.Entry {
+	actions := m.paletteActions()
+	entries := make([]palette.Entry, len(actions))
+	for i, a := range actions {
+		entries[i] = a.entry
+	}
+	return entries
+}
+
+// handlePaletteSelectedMsg runs the action chosen from the command palette
+// and remembers it as the most recently used.
+func (m Model) handlePaletteSelectedMsg(msg palette.SelectedMsg) (tea.Model, tea.Cmd) {
+	actions := m.paletteActions()
+	i := slices.IndexFunc(actions, func(a paletteAction) bool { return a.entry.ID == msg.Entry.ID })
+	if i < 0 {
+		return m, nil
+	}
+	action := actions[i]
+	m.rememberAction(action.entry.ID)
+
+	switch {
+	case action.key != "":
+		return m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(action.key)})
+	case action.partial:
+		m.mode = ModeCommand
+		m.commandInput.SetMode(command.ModeCommand)
+		m.commandInput.SetValue(action.command + " ")
+		return m, nil
+	}
+	return m.executeCommand(action.command)
+}
+
+// rememberAction moves an action to the front of the recently used ones.
+func (m *Model) rememberAction(id string) {
+	recent := slices.DeleteFunc(slices.Clone(m.recentActions), func(r string) bool { return r == id })
+	m.recentActions = append([]string{id}, recent...)
+	if len(m.recentActions) > maxRecentActions {
+		m.recentActions = m.recentActions[:maxRecentActions]
+	}
+}
+
+// capitalize upper-cases the first letter of s.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// permissionBinding is the key binding of an action that needs a
+// permission, with whether it is granted.
+type permissionBinding struct {
+	binding *key.Binding
+	allowed bool
+}
+
+// permissionBindings returns the bindings of the actions that need a
+// permission, with whether p grants it.
+func (m *Model) permissionBindings(p *zabbix.Permissions) []permissionBinding {
+	return []permissionBinding{
+		{&m.keys.Acknowledge, p.Acknowledge},
+		{&m.keys.AckMessage, p.Acknowledge},
+		{&m.keys.AckClose, p.Close},
+		{&m.keys.Suppress, p.Suppress},
+		{&m.keys.EditTriggers, p.Configure},
+		{&m.keys.EditMacros, p.Configure},
+		{&m.keys.EditInterfaces, p.Configure},
+		{&m.keys.ToggleMonitor, p.Configure},
+		{&m.keys.Maintenance, p.Maintenance},
+		{&m.keys.CloneHost, p.Configure},
+		{&m.keys.ForceCheck, p.CheckNow},
+	}
+}
+
+// applyPermissions disables the key bindings of the actions the user may
+// not take, hiding them from the help and the context menu. Unknown
+// permissions allow everything.
+func (m *Model) applyPermissions(p *zabbix.Permissions) {
+	if p == nil {
+		p = zabbix.AllPermissions()
+	}
+	for _, b := range m.permissionBindings(p) {
+		b.binding.SetEnabled(b.allowed)
+	}
+}
+
+// deniedAction returns the description of the action msg would take if the
+// user were allowed to, or empty when its key isn't of a disabled action.
+func (m *Model) deniedAction(msg tea.KeyMsg) string {
+	for _, b := range m.permissionBindings(zabbix.AllPermissions()) {
+		if !b.binding.Enabled() && slices.Contains(b.binding.Keys(), msg.String()) {
+			return b.binding.Help().Desc
+		}
+	}
+	return ""
+}
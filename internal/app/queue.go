@@ -0,0 +1,59 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// loadQueues loads the queues of the Zabbix servers and proxies for
+// ":queue".
+func (m *Model) loadQueues() tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+	m.statusBar.SetStatus("Loading queue...")
+
+	return func() tea.Msg {
+		if client == nil {
+			return QueuesLoadedMsg{}
+		}
+		queues, err := client.GetQueues(ctx)
+		return QueuesLoadedMsg{Queues: queues, Err: err}
+	}
+}
+
+// handleQueuesLoadedMsg shows how many items each server and proxy is late
+// collecting, by how late they are: a growing queue is an early sign of an
+// overloaded server or proxy.
+func (m Model) handleQueuesLoadedMsg(msg QueuesLoadedMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not load the queue", msg.Err)
+	}
+	if len(msg.Queues) == 0 {
+		m.statusBar.SetStatus("No zabbix[queue] items; link the Zabbix server or proxy health templates")
+		return m, nil
+	}
+
+	lines := []string{"Items late to be collected, by delay", ""}
+	width := 0
+	for _, q := range msg.Queues {
+		width = max(width, len(q.Host.DisplayName()))
+	}
+	for _, q := range msg.Queues {
+		buckets := make([]string, 0, len(q.Buckets))
+		for _, b := range q.Buckets {
+			buckets = append(buckets, fmt.Sprintf("%8s %-6d", b.Label(), b.Count))
+		}
+		line := fmt.Sprintf("  %-*s %s", width, q.Host.DisplayName(), strings.Join(buckets, " "))
+		if len(q.Buckets) > 0 && !q.Buckets[0].Clock.IsZero() {
+			line += "  at " + m.timeFormat.Clock(q.Buckets[0].Clock)
+		}
+		lines = append(lines, line)
+	}
+
+	m.showError = true
+	m.errorModal.ShowText("Queue", lines)
+	return m, nil
+}
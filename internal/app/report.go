@@ -0,0 +1,140 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/report"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// Events of a report are fetched reportPageSize at a time, up to
+// reportMaxEvents.
+const (
+	reportPageSize  = 1000
+	reportMaxEvents = 10000
+)
+
+// reportRequest is what ":report" writes: the events of the last window,
+// of the hosts in group, to path.
+type reportRequest struct {
+	window time.Duration
+	group  string
+	path   string
+}
+
+// parseReportArgs parses the arguments of ":report [WINDOW] [FILE] [GROUP]",
+// in any order: a duration, a file ending in .md or .html, and the words of
+// a host group name. The window defaults to the Events tab's and the file to
+// one named after the time.
+func parseReportArgs(args []string, window time.Duration, now time.Time) reportRequest {
+	req := reportRequest{window: window}
+	var group []string
+	windowSet := false
+	for _, arg := range args {
+		if req.path == "" && report.IsReportFile(arg) {
+			req.path = arg
+			continue
+		}
+		if d, err := format.ParseSpan(arg); err == nil && d > 0 && !windowSet && len(group) == 0 {
+			req.window, windowSet = d, true
+			continue
+		}
+		group = append(group, arg)
+	}
+	req.group = strings.Join(group, " ")
+	if req.path == "" {
+		req.path = "incident-" + now.Format("20060102-1504") + ".md"
+	}
+	return req
+}
+
+// handleReportCommand writes the timeline of the events of a time window to
+// a Markdown or HTML file, e.g. ":report 12h outage.html Linux servers".
+func (m *Model) handleReportCommand(cmd string) tea.Cmd {
+	now := time.Now()
+	req := parseReportArgs(strings.Fields(strings.TrimPrefix(cmd, "report")), m.eventScope.Window, now)
+	f, err := report.FormatOf(req.path)
+	if err != nil {
+		m.statusBar.SetStatus(err.Error())
+		return nil
+	}
+
+	client := m.client
+	ctx := m.ctx
+	tf := m.timeFormat
+	from := now.Add(-req.window)
+	m.statusBar.SetStatus(fmt.Sprintf("Writing the report of the last %s...", format.Span(req.window)))
+
+	return func() tea.Msg {
+		if client == nil {
+			return ReportWrittenMsg{}
+		}
+		params := zabbix.EventHistoryParams{Limit: reportPageSize, TimeFrom: from.Unix(), TimeTill: now.Unix()}
+		if req.group != "" {
+			groups, err := client.GetHostGroupsByName(ctx, []string{req.group})
+			if err != nil {
+				return ReportWrittenMsg{Path: req.path, Err: err}
+			}
+			if len(groups) == 0 {
+				return ReportWrittenMsg{Path: req.path, Err: fmt.Errorf("host group %q not found", req.group)}
+			}
+			params.GroupIDs = []string{groups[0].GroupID}
+		}
+
+		events, truncated, err := fetchReportEvents(ctx, client, params)
+		if err != nil {
+			return ReportWrittenMsg{Path: req.path, Err: err}
+		}
+
+		r := report.New(events, from, now)
+		r.Group = req.group
+		r.Truncated = truncated
+		var buf bytes.Buffer
+		if err := r.Write(&buf, f, tf); err != nil {
+			return ReportWrittenMsg{Path: req.path, Err: err}
+		}
+		err = os.WriteFile(req.path, buf.Bytes(), 0o600)
+		return ReportWrittenMsg{Path: req.path, Problems: len(r.Problems), Err: err}
+	}
+}
+
+// fetchReportEvents fetches the events of params page by page, up to
+// reportMaxEvents, and tells whether there were more.
+func fetchReportEvents(ctx context.Context, client *zabbix.Client, params zabbix.EventHistoryParams) ([]zabbix.Event, bool, error) {
+	var events []zabbix.Event
+	for {
+		page, err := client.GetEventPage(ctx, params)
+		if err != nil {
+			return nil, false, err
+		}
+		events = append(events, page.Events...)
+		if page.Next == "" {
+			return events, false, nil
+		}
+		if len(events) >= reportMaxEvents {
+			return events, true, nil
+		}
+		params.EventIDTill = page.Next
+	}
+}
+
+// handleReportWrittenMsg reports a written timeline report.
+func (m Model) handleReportWrittenMsg(msg ReportWrittenMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not write the report", msg.Err)
+	}
+	if msg.Path == "" {
+		return m, nil
+	}
+	return m, m.notify(notify.Success, fmt.Sprintf("Wrote the incident timeline to %s (%d problems)", msg.Path, msg.Problems))
+}
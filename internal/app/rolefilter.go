@@ -0,0 +1,156 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/alerts"
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// applyRoleFilter applies the role filter configured for the user's role or
+// one of their user groups, if any. A minimum severity restored from the
+// last session is kept. The host groups are looked up by resolveRoleGroups.
+func (m *Model) applyRoleFilter(profile *zabbix.UserProfile) {
+	if profile == nil {
+		return
+	}
+	name, f, ok := m.config.RoleFilters.Match(profile.Role, profile.Groups)
+	if !ok {
+		return
+	}
+	m.roleFilter = name
+	if f.MinSeverity > 0 && m.minSeverity == m.config.Display.MinSeverity {
+		m.minSeverity = f.MinSeverity
+		m.alertList.SetMinSeverity(m.minSeverity)
+		m.statusBar.SetFilter(m.minSeverity, m.textFilter)
+	}
+	if af, ok := alerts.ParseAckFilter(f.AckFilter); ok {
+		m.alertList.SetAckFilter(af)
+	}
+	m.statusBar.SetStatus("Filtered for " + name)
+}
+
+// resolveRoleGroups looks up the host groups of the role filter, or returns
+// nil if there are none or they are known.
+func (m *Model) resolveRoleGroups() tea.Cmd {
+	names := m.config.RoleFilters[m.roleFilter].Groups
+	if m.roleFilter == "" || len(names) == 0 || m.roleGroups != nil {
+		return nil
+	}
+	client := m.client
+	ctx := m.ctx
+
+	return func() tea.Msg {
+		if client == nil {
+			return RoleGroupsMsg{}
+		}
+		groups, err := client.GetHostGroupsByName(ctx, names)
+		return RoleGroupsMsg{Groups: groups, Err: err}
+	}
+}
+
+// handleRoleGroupsMsg limits the lists to the host groups of the role
+// filter and reloads them.
+func (m Model) handleRoleGroupsMsg(msg RoleGroupsMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.notifyError("Role filter host groups not loaded", msg.Err)
+	}
+	var missing []string
+	for _, name := range m.config.RoleFilters[m.roleFilter].Groups {
+		i := slices.IndexFunc(msg.Groups, func(g zabbix.HostGroup) bool { return g.Name == name })
+		if i < 0 {
+			missing = append(missing, name)
+			continue
+		}
+		m.roleGroups = append(m.roleGroups, msg.Groups[i])
+	}
+	var cmds []tea.Cmd
+	if len(missing) > 0 {
+		cmds = append(cmds, m.notify(notify.Error, "Host groups not found: "+strings.Join(missing, ", ")))
+	}
+	if len(m.roleGroups) == 0 {
+		return m, tea.Batch(cmds...)
+	}
+	m.updateGroupLabel()
+	cmds = append(cmds, m.reloadCurrentTab())
+	return m, tea.Batch(cmds...)
+}
+
+// handleRoleFilterCommand turns the host group limit of the role filter off
+// or back on with ":role-filter off|on", or tells which filter applies.
+func (m *Model) handleRoleFilterCommand(arg string) tea.Cmd {
+	if m.roleFilter == "" {
+		m.statusBar.SetStatus("No role filter applies to you")
+		return nil
+	}
+	switch arg {
+	case "":
+		groups := make([]string, 0, len(m.roleGroups))
+		for _, g := range m.roleGroups {
+			groups = append(groups, g.Name)
+		}
+		status := "Role filter " + m.roleFilter
+		switch {
+		case len(groups) == 0:
+		case m.roleGroupsOff:
+			status += ", host groups off"
+		default:
+			status += ": " + strings.Join(groups, ", ")
+		}
+		m.statusBar.SetStatus(status)
+		return nil
+	case "off", "on":
+		off := arg == "off"
+		if off == m.roleGroupsOff {
+			return nil
+		}
+		m.roleGroupsOff = off
+		m.updateGroupLabel()
+		m.statusBar.SetStatus("Role filter host groups " + arg)
+		return m.reloadCurrentTab()
+	}
+	m.statusBar.SetStatus("Usage: :role-filter [on|off]")
+	return nil
+}
+
+// roleGroupIDs returns the host groups the role filter limits the lists
+// to, or nil for all hosts.
+func (m *Model) roleGroupIDs() []string {
+	if m.roleGroupsOff || len(m.roleGroups) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(m.roleGroups))
+	for _, g := range m.roleGroups {
+		ids = append(ids, g.GroupID)
+	}
+	return ids
+}
+
+// listGroupIDs returns the host groups the Alerts, Hosts and Events tabs are
+// limited to: the kiosk's current host group, else those of the role
+// filter, or nil for all hosts.
+func (m *Model) listGroupIDs() []string {
+	if ids := m.kioskGroupIDs(); ids != nil {
+		return ids
+	}
+	return m.roleGroupIDs()
+}
+
+// updateGroupLabel shows the host groups of the role filter at the end of
+// the tab bar, unless the kiosk shows its own.
+func (m *Model) updateGroupLabel() {
+	if len(m.kioskGroups) > 0 {
+		return
+	}
+	var names []string
+	if !m.roleGroupsOff {
+		for _, g := range m.roleGroups {
+			names = append(names, g.Name)
+		}
+	}
+	m.tabBar.SetLabel(strings.Join(names, ", "))
+}
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/command"
+	"github.com/harpchad/chotko/internal/components/search"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// searchLimit is the most hosts, triggers, items and templates a search
+// lists of each.
+const searchLimit = 50
+
+// handleSearchCommand searches for the text of ":search TEXT" on the Search
+// tab, or prompts for it when there is none.
+func (m Model) handleSearchCommand(text string) (tea.Model, tea.Cmd) {
+	if text == "" {
+		model, cmd := m.switchTab(TabSearch)
+		m = model.(Model)
+		m.mode = ModeSearch
+		m.commandInput.SetMode(command.ModeSearch)
+		return m, cmd
+	}
+	cmd := m.startSearch(text)
+	model, switchCmd := m.switchTab(TabSearch)
+	return model, tea.Batch(cmd, switchCmd)
+}
+
+// startSearch searches for text, showing that the search runs.
+func (m *Model) startSearch(text string) tea.Cmd {
+	if text == "" {
+		return nil
+	}
+	m.searchList.SetLoading(text)
+	m.statusBar.SetLoading(true)
+	return m.runSearch(text)
+}
+
+// runSearch searches the hosts, triggers, items and templates for text.
+func (m *Model) runSearch(text string) tea.Cmd {
+	client := m.client
+	ctx, seq := m.beginLoad(TabSearch)
+
+	return func() tea.Msg {
+		if client == nil || text == "" {
+			return SearchLoadedMsg{Query: text, Seq: seq}
+		}
+		start := time.Now()
+		results, err := client.Search(ctx, text, searchLimit)
+		return SearchLoadedMsg{Query: text, Results: results, Duration: time.Since(start), Seq: seq, Err: err}
+	}
+}
+
+// handleSearchLoadedMsg lists the results of a search on the Search tab.
+func (m Model) handleSearchLoadedMsg(msg SearchLoadedMsg) (tea.Model, tea.Cmd) {
+	if !m.finishLoad(TabSearch, msg.Seq) || errors.Is(msg.Err, context.Canceled) {
+		return m.dropLoad(TabSearch)
+	}
+	m.loading = false
+	m.statusBar.SetLoading(false)
+
+	if msg.Err != nil {
+		m.searchList.SetResults(msg.Query, nil)
+		return m.handleLoadError("Search Failed", msg.Err)
+	}
+
+	m.tabLoaded[TabSearch] = time.Now()
+	m.loadDurations[TabSearch] = msg.Duration
+	m.searchList.SetResults(msg.Query, msg.Results)
+	if m.tabBar.Active() == TabSearch {
+		m.setDetailSearchResult()
+	}
+	return m, nil
+}
+
+// setDetailSearchResult shows the selected host or item of the Search tab
+// in the detail pane.
+func (m *Model) setDetailSearchResult() {
+	selected := m.searchList.Selected()
+	switch {
+	case selected == nil:
+		m.detailPane.Clear()
+	case selected.Kind == search.KindHost:
+		m.detailPane.SetHost(selected.Host)
+	case selected.Kind == search.KindItem:
+		m.detailPane.SetItem(selected.Item, nil)
+	default:
+		m.detailPane.Clear()
+	}
+}
+
+// openSearchResult goes to a search result on its tab: a host on the Hosts
+// tab, an item on the Graphs tab, and a trigger's current problem on the
+// Alerts tab, or the trigger in the editor when it has none.
+func (m Model) openSearchResult(r search.Result) (tea.Model, tea.Cmd) {
+	switch r.Kind {
+	case search.KindHost:
+		return m.goToHost(r.Host)
+	case search.KindTrigger:
+		for _, p := range m.problems {
+			if p.Object == ObjectTypeTrigger && p.ObjectID == r.Trigger.TriggerID && m.alertList.SelectID(p.EventID) {
+				return m.switchTab(TabAlerts)
+			}
+		}
+		hostID, triggerID := searchResultIDs(&r)
+		if hostID == "" {
+			return m, nil
+		}
+		m.statusBar.SetStatus(r.Trigger.Description + " has no current problem")
+		return m, m.loadHostTriggers(hostID, triggerID)
+	case search.KindItem:
+		return m.goToItem(r.Item)
+	}
+	return m, nil
+}
+
+// goToHost selects a host on the Hosts tab, once the tab has loaded.
+func (m Model) goToHost(host *zabbix.Host) (tea.Model, tea.Cmd) {
+	if len(m.hosts) == 0 {
+		m.restoreSelected[TabHosts] = host.HostID
+	} else if !m.hostList.SelectID(host.HostID) {
+		m.statusBar.SetStatus(host.DisplayName() + " is not listed on the Hosts tab")
+	}
+	return m.switchTab(TabHosts)
+}
+
+// goToItem selects an item on the Graphs tab, once the tab has loaded.
+func (m Model) goToItem(item *zabbix.Item) (tea.Model, tea.Cmd) {
+	if m.items == nil {
+		m.searchItem = item.ItemID
+		return m.switchTab(TabGraphs)
+	}
+	cmd := m.selectGraphItem(item.ItemID)
+	model, switchCmd := m.switchTab(TabGraphs)
+	return model, tea.Batch(cmd, switchCmd)
+}
+
+// selectGraphItem selects the item with an ID on the Graphs tab, or tells
+// that it isn't listed there.
+func (m *Model) selectGraphItem(itemID string) tea.Cmd {
+	item := m.graphList.SelectItem(func(i *zabbix.Item) bool { return i.ItemID == itemID })
+	if item == nil {
+		m.statusBar.SetStatus("The item is not listed on the Graphs tab")
+		return nil
+	}
+	return m.showGraphItem(item)
+}
+
+// searchResultIDs returns the host and trigger IDs of a search result, for
+// the editors: those of a host, a trigger's first host and the trigger, or
+// an item's host.
+func searchResultIDs(r *search.Result) (hostID, triggerID string) {
+	if r == nil {
+		return "", ""
+	}
+	switch r.Kind {
+	case search.KindHost:
+		return r.Host.HostID, ""
+	case search.KindTrigger:
+		if len(r.Trigger.Hosts) > 0 {
+			hostID = r.Trigger.Hosts[0].HostID
+		}
+		return hostID, r.Trigger.TriggerID
+	case search.KindItem:
+		return r.Item.GetHostID(), ""
+	}
+	return "", ""
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// loadServerInfo loads the version, object counts, server statistics and
+// housekeeping settings for ":server-info".
+func (m *Model) loadServerInfo() tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+	m.statusBar.SetStatus("Loading server information...")
+
+	return func() tea.Msg {
+		if client == nil {
+			return ServerInfoLoadedMsg{}
+		}
+		info, err := client.GetServerInfo(ctx)
+		return ServerInfoLoadedMsg{Info: info, Err: err}
+	}
+}
+
+// handleServerInfoLoadedMsg shows what the API tells about the Zabbix
+// installation.
+func (m Model) handleServerInfoLoadedMsg(msg ServerInfoLoadedMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not load server information", msg.Err)
+	}
+	if msg.Info == nil {
+		return m, nil
+	}
+	info := msg.Info
+
+	lines := []string{
+		fmt.Sprintf("  %-22s %s", "Zabbix version", info.Version),
+	}
+	if db := zabbix.RequiredDBVersion(info.Version); db > 0 {
+		lines = append(lines, fmt.Sprintf("  %-22s %d or later", "Required DB version", db))
+	}
+	lines = append(lines,
+		fmt.Sprintf("  %-22s %d", "Hosts you can see", info.Hosts),
+		fmt.Sprintf("  %-22s %d", "Items you can see", info.Items),
+		fmt.Sprintf("  %-22s %d", "Triggers you can see", info.Triggers),
+	)
+
+	if len(info.Servers) == 0 {
+		lines = append(lines, "", "No zabbix[requiredperformance] or other internal items; link the",
+			"Zabbix server health template for server statistics.")
+	}
+	for _, s := range info.Servers {
+		lines = append(lines, "", s.Host.DisplayName()+":")
+		if s.Version != "" {
+			lines = append(lines, fmt.Sprintf("  %-22s %s", "Version", s.Version))
+		}
+		if s.Uptime > 0 {
+			lines = append(lines, fmt.Sprintf("  %-22s %s", "Uptime", format.Uptime(s.Uptime.Seconds())))
+		}
+		lines = append(lines,
+			fmt.Sprintf("  %-22s %.2f", "New values per second", s.NVPS),
+			fmt.Sprintf("  %-22s %d", "Monitored hosts", s.Hosts),
+			fmt.Sprintf("  %-22s %d (%d unsupported)", "Monitored items", s.Items, s.UnsupportedItems),
+			fmt.Sprintf("  %-22s %d", "Triggers", s.Triggers),
+			fmt.Sprintf("  %-22s %.1f%%", "Housekeeper busy", s.HousekeeperBusy),
+		)
+		if !s.Clock.IsZero() {
+			lines = append(lines, fmt.Sprintf("  %-22s %s", "Collected", m.timeFormat.Clock(s.Clock)))
+		}
+	}
+
+	if hk := info.Housekeeping; hk != nil {
+		lines = append(lines, "", "Housekeeping:",
+			housekeepingLine("Events and alerts", hk.EventsMode, hk.EventsTrigger, ""),
+			housekeepingLine("History", hk.HistoryMode, hk.History, hk.HistoryGlobal),
+			housekeepingLine("Trends", hk.TrendsMode, hk.Trends, hk.TrendsGlobal),
+			housekeepingLine("Audit log", hk.AuditMode, hk.Audit, ""),
+			housekeepingLine("Sessions", hk.SessionsMode, hk.Sessions, ""),
+		)
+		if hk.DBExtension != "" {
+			compression := "off"
+			if hk.Compression == "1" {
+				compression = "records older than " + hk.CompressOlder
+			}
+			lines = append(lines, fmt.Sprintf("  %-22s %s, compressing %s", "DB extension", hk.DBExtension, compression))
+		}
+	}
+
+	m.showError = true
+	m.errorModal.ShowText("Server Information", lines)
+	return m, nil
+}
+
+// housekeepingLine describes how long the housekeeper keeps a kind of
+// data: mode is "1" when it deletes it at all, and global "1" when the
+// period overrides the items' own.
+func housekeepingLine(name, mode, period, global string) string {
+	value := "kept " + period
+	switch {
+	case mode != "1":
+		value = "not housekept"
+	case global == "1":
+		value += " (overrides item settings)"
+	}
+	return fmt.Sprintf("  %-22s %s", name, value)
+}
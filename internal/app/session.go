@@ -0,0 +1,192 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/harpchad/chotko/internal/components/alerts"
+	"github.com/harpchad/chotko/internal/components/hosts"
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/session"
+)
+
+// RestoreSession applies the UI state saved by a previous run and keeps s to
+// save the state to on Shutdown. Rows are selected once their tab's data has
+// loaded.
+func (m *Model) RestoreSession(s *session.State) {
+	m.session = s
+
+	if s.MinSeverity != nil {
+		m.minSeverity = *s.MinSeverity
+		m.alertList.SetMinSeverity(m.minSeverity)
+	}
+	if s.RelativeTime != nil {
+		m.timeFormat.Relative = *s.RelativeTime
+		m.applyTimeFormat()
+	}
+
+	for tab := range TabCount {
+		name := sessionTabName(tab)
+		if filter := s.Filters[name]; filter != "" {
+			m.setListFilter(tab, filter)
+		}
+		if tab != TabGraphs {
+			m.restoreSelected[tab] = s.Selected[name]
+		}
+	}
+	m.graphList.RestoreState(s.Expanded, s.Selected[sessionTabName(TabGraphs)])
+
+	scope := m.eventScope
+	if window, err := format.ParseSpan(s.EventWindow); err == nil {
+		scope.Window = window
+	}
+	if t, ok := parseEventType(s.EventType); ok {
+		scope.Type = t
+	}
+	scope.MinSeverity = s.EventSeverity
+	m.eventScope = scope
+	m.eventList.SetScope(scope.String())
+
+	if by, err := alerts.ParseGroupBy(s.AlertGroupBy); err == nil {
+		m.alertList.SetGroupBy(by)
+	}
+	if f, ok := hosts.ParseStateFilter(s.HostState); ok {
+		m.hostList.SetStateFilter(f)
+	}
+	if o, ok := hosts.ParseSortOrder(s.HostSort); ok {
+		m.hostList.SetSortOrder(o)
+	}
+	m.hostList.SetTreeView(s.HostTree)
+	m.recentActions = s.RecentActions
+
+	for tab := range TabCount {
+		if sessionTabName(tab) == s.Tab {
+			m.tabBar.SetActive(tab)
+			m.updateListFocus()
+		}
+	}
+	m.textFilter = m.listFilter(m.tabBar.Active())
+	m.statusBar.SetFilter(m.minSeverity, m.textFilter)
+}
+
+// saveSession writes the current UI state to the session file.
+func (m *Model) saveSession() error {
+	s := m.session
+	s.Tab = sessionTabName(m.tabBar.Active())
+
+	// Settings that match the config are left unset, so config changes apply
+	s.MinSeverity = nil
+	if m.minSeverity != m.config.Display.MinSeverity {
+		severity := m.minSeverity
+		s.MinSeverity = &severity
+	}
+	s.RelativeTime = nil
+	if m.timeFormat.Relative != m.config.GetRelativeTime() {
+		relative := m.timeFormat.Relative
+		s.RelativeTime = &relative
+	}
+
+	s.Filters = make(map[string]string)
+	for tab := range TabCount {
+		if filter := m.listFilter(tab); filter != "" {
+			s.Filters[sessionTabName(tab)] = filter
+		}
+	}
+
+	// Selections of tabs that were never loaded are kept as they were
+	selected := make(map[string]string)
+	for tab, id := range m.restoreSelected {
+		if id != "" {
+			selected[sessionTabName(tab)] = id
+		}
+	}
+	if p := m.alertList.Selected(); p != nil {
+		selected[sessionTabName(TabAlerts)] = p.EventID
+	}
+	if h := m.hostList.Selected(); h != nil {
+		selected[sessionTabName(TabHosts)] = h.HostID
+	}
+	if e := m.eventList.Selected(); e != nil {
+		selected[sessionTabName(TabEvents)] = e.EventID
+	}
+	if m.items != nil {
+		s.Expanded = m.graphList.ExpandedIDs()
+		delete(selected, sessionTabName(TabGraphs))
+		if node := m.graphList.Selected(); node != nil {
+			selected[sessionTabName(TabGraphs)] = node.ID
+		}
+	} else if id := s.Selected[sessionTabName(TabGraphs)]; id != "" {
+		selected[sessionTabName(TabGraphs)] = id
+	}
+	s.Selected = selected
+
+	s.EventWindow = ""
+	if m.eventScope.Window != defaultEventWindow {
+		s.EventWindow = format.Span(m.eventScope.Window)
+	}
+	s.EventType = ""
+	if m.eventScope.Type != eventsAll {
+		s.EventType = m.eventScope.Type.String()
+	}
+	s.EventSeverity = m.eventScope.MinSeverity
+	s.AlertGroupBy = string(m.alertList.GroupBy())
+	s.HostState = ""
+	if f := m.hostList.StateFilter(); f != hosts.StateAll {
+		s.HostState = f.String()
+	}
+	s.HostSort = ""
+	if o := m.hostList.SortOrder(); o != hosts.SortName {
+		s.HostSort = o.String()
+	}
+	s.HostTree = m.hostList.TreeView()
+	s.RecentActions = m.recentActions
+
+	return s.Save()
+}
+
+// restoreSelection selects the row saved for a tab by the previous session,
+// once the tab's data has loaded.
+func (m *Model) restoreSelection(tab int) {
+	id := m.restoreSelected[tab]
+	if id == "" {
+		return
+	}
+	m.restoreSelected[tab] = ""
+	switch tab {
+	case TabAlerts:
+		m.alertList.SelectID(id)
+	case TabHosts:
+		m.hostList.SelectID(id)
+	case TabEvents:
+		m.eventList.SelectID(id)
+	}
+}
+
+// listFilter returns the text filter of a tab's list.
+func (m *Model) listFilter(tab int) string {
+	switch tab {
+	case TabAlerts:
+		return m.alertList.TextFilter()
+	case TabHosts:
+		return m.hostList.TextFilter()
+	case TabEvents:
+		return m.eventList.TextFilter()
+	}
+	return ""
+}
+
+// setListFilter sets the text filter of a tab's list.
+func (m *Model) setListFilter(tab int, filter string) {
+	switch tab {
+	case TabAlerts:
+		m.alertList.SetTextFilter(filter)
+	case TabHosts:
+		m.hostList.SetTextFilter(filter)
+	case TabEvents:
+		m.eventList.SetTextFilter(filter)
+	}
+}
+
+// sessionTabName returns the name a tab is saved under.
+func sessionTabName(tab int) string {
+	return strings.ToLower(tabNames[tab])
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// applySettings names the severities as the server does and, with
+// server_severity_colors, colors them so. Unknown settings keep the names
+// and colors in use.
+func (m *Model) applySettings(s *zabbix.Settings) {
+	if s == nil {
+		return
+	}
+	theme.SetSeverityNames(s.SeverityNames)
+	m.serverColors = s.SeverityColors
+	// No-color labels show the names, so the styles change either way
+	m.restyle()
+}
+
+// restyle rebuilds the styles shared by the components from the theme and
+// the display settings.
+func (m *Model) restyle() {
+	t := m.theme
+	if m.config.Display.ServerSeverityColors {
+		t = t.WithSeverityColors(m.serverColors)
+	}
+	*m.styles = *newStyles(m.config, t)
+	m.alertList.Restyle()
+	m.hostList.Restyle()
+	m.eventList.Restyle()
+}
+
+// loadSettings loads the global settings of the server for ":settings".
+func (m *Model) loadSettings() tea.Cmd {
+	if m.client != nil && !m.client.Capabilities().Settings {
+		m.statusBar.SetStatus("Reading the settings needs Zabbix 5.2 or later")
+		return nil
+	}
+	client := m.client
+	ctx := m.ctx
+	m.statusBar.SetStatus("Loading settings...")
+
+	return func() tea.Msg {
+		if client == nil {
+			return SettingsLoadedMsg{}
+		}
+		settings, err := client.GetSettings(ctx)
+		return SettingsLoadedMsg{Settings: settings, Err: err}
+	}
+}
+
+// hexColor matches the severity colors of the settings.
+var hexColor = regexp.MustCompile(`^[0-9A-Fa-f]{6}$`)
+
+// handleSettingsLoadedMsg shows the global settings that matter to
+// operators, read-only, and applies any severity names changed since
+// connecting.
+func (m Model) handleSettingsLoadedMsg(msg SettingsLoadedMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not load the settings", msg.Err)
+	}
+	if msg.Settings == nil {
+		return m, nil
+	}
+	s := msg.Settings
+	m.applySettings(s)
+
+	lines := []string{"Severities:"}
+	for severity := 5; severity >= 0; severity-- {
+		line := fmt.Sprintf("  %d  %-16s", severity, theme.SeverityName(severity))
+		if color := s.SeverityColors[severity]; hexColor.MatchString(color) {
+			swatch := lipgloss.NewStyle().Foreground(lipgloss.Color("#" + color)).Render("■■")
+			line += " " + swatch + " #" + strings.ToUpper(color)
+		}
+		lines = append(lines, line)
+	}
+
+	if periods := zabbix.WorkingTime(s.WorkPeriod); len(periods) > 0 {
+		lines = append(lines, "", "Working time:")
+		for _, p := range periods {
+			lines = append(lines, "  "+p)
+		}
+	}
+
+	lines = append(lines, "")
+	if s.RefreshUnsupported != "" {
+		lines = append(lines, fmt.Sprintf("  %-30s %s", "Unsupported items checked every", s.RefreshUnsupported))
+	}
+	if s.OKPeriod != "" {
+		lines = append(lines, fmt.Sprintf("  %-30s %s", "Resolved problems shown for", s.OKPeriod))
+	}
+	if s.BlinkPeriod != "" {
+		lines = append(lines, fmt.Sprintf("  %-30s %s", "Changed problems blink for", s.BlinkPeriod))
+	}
+	if s.DefaultTimezone != "" {
+		lines = append(lines, fmt.Sprintf("  %-30s %s", "Default time zone", s.DefaultTimezone))
+	}
+
+	m.showError = true
+	m.errorModal.ShowText("Settings", lines)
+	return m, nil
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// hookTimeout bounds how long the hooks of one refresh may run.
+const hookTimeout = time.Minute
+
+// runHooks runs the hooks of the loaded problems that are new or
+// unacknowledged disasters, leaving out ignored and muted ones. Muting sound
+// only silences the sounds: commands still run. The hooks forget the
+// problems that closed only when the problems are complete, as those left
+// out by a filter or a further page are still open and must not run again.
+func (m *Model) runHooks(problems []zabbix.Problem, complete bool) tea.Cmd {
+	visible := make([]zabbix.Problem, 0, len(problems))
+	for i := range problems {
+		if !m.isHidden(&problems[i]) {
+			visible = append(visible, problems[i])
+		}
+	}
+	events := m.hooks.Check(visible, time.Now())
+	if complete {
+		m.hooks.Prune(problems)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	runner := m.hooks
+	muted := m.soundMuted
+	ctx := m.ctx
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+		defer cancel()
+		return HooksRunMsg{Err: runner.Run(ctx, events, muted)}
+	}
+}
+
+// handleHooksRunMsg reports hooks that failed.
+func (m Model) handleHooksRunMsg(msg HooksRunMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.notifyError("Hook failed", msg.Err)
+	}
+	return m, nil
+}
+
+// toggleSound mutes or unmutes the sounds of the hooks.
+func (m *Model) toggleSound() tea.Cmd {
+	if m.hooks == nil {
+		return m.notify(notify.Info, "No sound hooks configured")
+	}
+	m.soundMuted = !m.soundMuted
+	m.statusBar.SetSound(true, m.soundMuted)
+	if m.soundMuted {
+		return m.notify(notify.Info, "Sound hooks muted")
+	}
+	return m.notify(notify.Info, "Sound hooks unmuted")
+}
@@ -0,0 +1,185 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/command"
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// suppressPresets are the suppression lengths offered by the suppress key,
+// chosen with 1 and 2; 3 suppresses until suppressMorning.
+var suppressPresets = []time.Duration{time.Hour, 4 * time.Hour}
+
+// suppressMorning is the time of day, in hours, that 3 suppresses until.
+const suppressMorning = 9
+
+// suppressRequest is a suppression of problems awaiting its end.
+type suppressRequest struct {
+	eventIDs []string
+	name     string // The problem, or the number of problems
+	custom   bool   // The end is being typed in the command bar
+}
+
+// selectedProblems returns the problems an action on the Alerts tab applies
+// to: the marked problems, or those of the selected group, or the selected
+// problem.
+func (m *Model) selectedProblems() []zabbix.Problem {
+	if marked := m.alertList.Marked(); marked != nil {
+		return marked
+	}
+	if group := m.alertList.SelectedGroup(); group != nil {
+		return group
+	}
+	if selected := m.alertList.Selected(); selected != nil {
+		return []zabbix.Problem{*selected}
+	}
+	return nil
+}
+
+// startSuppressPrompt asks until when to suppress the selected problems.
+func (m *Model) startSuppressPrompt() {
+	if m.tabBar.Active() != TabAlerts {
+		return
+	}
+	problems := m.selectedProblems()
+	if len(problems) == 0 {
+		return
+	}
+	if m.client != nil && !m.client.Capabilities().ManualSuppression {
+		m.statusBar.SetStatus("Suppressing problems requires Zabbix 6.2 or later")
+		return
+	}
+
+	req := &suppressRequest{name: fmt.Sprintf("%d problems", len(problems))}
+	for _, p := range problems {
+		req.eventIDs = append(req.eventIDs, p.EventID)
+	}
+	if len(problems) == 1 {
+		req.name = truncate(problems[0].Name, 30)
+	}
+	m.pendingSuppress = req
+	m.statusBar.SetStatus(m.suppressPrompt())
+}
+
+// suppressPrompt returns the status bar prompt for the pending suppression.
+func (m *Model) suppressPrompt() string {
+	presets := make([]string, len(suppressPresets))
+	for i, d := range suppressPresets {
+		presets[i] = fmt.Sprintf("%d) %s", i+1, format.Span(d))
+	}
+	morning := m.timeFormat.ClockShort(nextTimeOfDay(time.Now(), suppressMorning, 0, m.timeFormat.Location))
+	return fmt.Sprintf("Suppress %s for: %s %d) until %s c) until...; Esc cancel",
+		m.pendingSuppress.name, strings.Join(presets, " "), len(presets)+1, morning)
+}
+
+// handleSuppressKey handles the keys of the suppress prompt: a preset
+// number suppresses the problems, c asks for the end in the command bar.
+func (m Model) handleSuppressKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	now := time.Now()
+	var until time.Time
+	switch s := msg.String(); s {
+	case "1", "2":
+		until = now.Add(suppressPresets[s[0]-'1'])
+	case "3":
+		until = nextTimeOfDay(now, suppressMorning, 0, m.timeFormat.Location)
+	case "c", "C":
+		req := *m.pendingSuppress
+		req.custom = true
+		m.pendingSuppress = &req
+		m.statusBar.SetStatus("")
+		m.mode = ModeSuppressUntil
+		m.commandInput.SetMode(command.ModeSuppressUntil)
+		return m, nil
+	case "esc", "n", "N":
+		m.pendingSuppress = nil
+		m.statusBar.SetStatus("Canceled")
+		return m, nil
+	default:
+		// Ignore other keys while awaiting the end
+		return m, nil
+	}
+
+	req := *m.pendingSuppress
+	m.pendingSuppress = nil
+	return m, m.suppressProblems(req, until)
+}
+
+// suppressProblems suppresses the problems of req until a time.
+func (m *Model) suppressProblems(req suppressRequest, until time.Time) tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+	m.statusBar.SetStatus(fmt.Sprintf("Suppressing %s...", req.name))
+
+	return func() tea.Msg {
+		if client == nil {
+			return SuppressResultMsg{Name: req.name, Until: until}
+		}
+		err := client.SuppressProblems(ctx, req.eventIDs, until)
+		return SuppressResultMsg{Name: req.name, Until: until, Err: err}
+	}
+}
+
+// handleSuppressResultMsg reports suppressed problems and reloads them to
+// show it.
+func (m Model) handleSuppressResultMsg(msg SuppressResultMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not suppress "+msg.Name, msg.Err)
+	}
+	m.alertList.ClearMarks()
+	absolute := m.timeFormat
+	absolute.Relative = false
+	text := fmt.Sprintf("Suppressed %s until %s", msg.Name, absolute.Full(msg.Until))
+	return m, tea.Batch(m.notify(notify.Success, text), m.loadProblems())
+}
+
+// parseSuppressUntil parses the end of a suppression typed in the command
+// bar: a length such as "90m" or "3d", a time of day such as "17:30" or
+// "5pm", taken as the next one, or a date and time such as
+// "2025-01-02 09:00" in loc.
+func parseSuppressUntil(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if d, err := format.ParseSpan(s); err == nil {
+		return now.Add(d), nil
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	for _, layout := range []string{"15:04", "3:04pm", "3pm"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return nextTimeOfDay(now, t.Hour(), t.Minute(), loc), nil
+		}
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02"} {
+		t, err := time.ParseInLocation(layout, s, loc)
+		if err != nil {
+			continue
+		}
+		if !t.After(now) {
+			return time.Time{}, fmt.Errorf("%s is in the past", s)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("not a duration or time: %q", s)
+}
+
+// nextTimeOfDay returns the next time after now that it is hour:minute in
+// loc, local time if nil.
+func nextTimeOfDay(now time.Time, hour, minute int, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.Local
+	}
+	local := now.In(loc)
+	t := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, loc)
+	if !t.After(now) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
@@ -0,0 +1,223 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/config"
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// handleTokensCommand lists the user's API tokens for ":tokens", or asks to
+// confirm replacing the one chotko uses for ":tokens rotate".
+func (m *Model) handleTokensCommand(cmd string) tea.Cmd {
+	switch strings.TrimSpace(strings.TrimPrefix(cmd, "tokens")) {
+	case "":
+		return m.loadTokens()
+	case "rotate":
+		if !m.config.UseToken() {
+			m.statusBar.SetStatus("chotko logs in with a password; there is no token to rotate")
+			return nil
+		}
+		lines := []string{
+			"Creates a new token, lasting as long as the current one,",
+			"and switches to it once the server accepts it.",
+			"",
+		}
+		if m.tokenSavedInFile() {
+			lines = append(lines, "The new token is saved to "+m.configPath+",")
+		} else {
+			lines = append(lines, "The token isn't from the config file; the new one is shown to copy,")
+		}
+		lines = append(lines, "then the current token is deleted.")
+		m.pendingRotate = true
+		m.showError = true
+		m.errorModal.ShowConfirm("Rotate the API token?", lines)
+	default:
+		m.statusBar.SetStatus("Usage: :tokens [rotate]")
+	}
+	return nil
+}
+
+// tokenSavedInFile reports whether the token in use comes from the watched
+// config file, rather than the command line or environment.
+func (m *Model) tokenSavedInFile() bool {
+	return m.configPath != "" && m.fileConfig != nil && m.fileConfig.Auth.Token == m.config.Auth.Token
+}
+
+// loadTokens loads the API tokens of the current user.
+func (m *Model) loadTokens() tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+	userID := m.userID
+	useToken := m.config.UseToken()
+	m.statusBar.SetStatus("Loading API tokens...")
+
+	return func() tea.Msg {
+		if client == nil {
+			return TokensLoadedMsg{}
+		}
+		var currentID string
+		if useToken {
+			// Also finds the user when Zabbix is too old to tell who a token is for
+			current, err := client.CurrentToken(ctx)
+			if err != nil {
+				return TokensLoadedMsg{Err: err}
+			}
+			currentID, userID = current.TokenID, current.UserID
+		}
+		if userID == "" {
+			return TokensLoadedMsg{Err: fmt.Errorf("current user: %w", zabbix.ErrNotFound)}
+		}
+		tokens, err := client.GetTokens(ctx, userID)
+		return TokensLoadedMsg{Tokens: tokens, CurrentID: currentID, Err: err}
+	}
+}
+
+// handleTokensLoadedMsg lists the user's API tokens with their state and
+// expiry, marking the one chotko uses.
+func (m Model) handleTokensLoadedMsg(msg TokensLoadedMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Err != nil {
+		return m, m.notifyError("Could not load API tokens", msg.Err)
+	}
+	if msg.Tokens == nil {
+		return m, nil
+	}
+
+	now := time.Now()
+	width := len("NAME")
+	for _, t := range msg.Tokens {
+		width = max(width, len(t.Name))
+	}
+	lines := []string{fmt.Sprintf("  %-*s  %-8s  %-16s  %-16s  %s", width, "NAME", "STATE", "CREATED", "LAST USED", "EXPIRES")}
+	for _, t := range msg.Tokens {
+		state := "enabled"
+		switch {
+		case t.IsExpired(now):
+			state = "expired"
+		case !t.IsEnabled():
+			state = "disabled"
+		}
+		marker := " "
+		if t.TokenID == msg.CurrentID {
+			marker = "*"
+		}
+		lines = append(lines, fmt.Sprintf("%s %-*s  %-8s  %-16s  %-16s  %s",
+			marker, width, t.Name, state, tokenTime(t.Created(), now, "-"), tokenTime(t.LastUsed(), now, "never"),
+			tokenTime(t.Expires(), now, "never")))
+	}
+	if len(msg.Tokens) == 0 {
+		lines = append(lines, "  No API tokens")
+	}
+	if msg.CurrentID != "" {
+		lines = append(lines, "", "* in use by chotko; :tokens rotate replaces it")
+	}
+
+	m.showError = true
+	m.errorModal.ShowText("API Tokens", lines)
+	return m, nil
+}
+
+// tokenTime formats a time of a token relative to now, or as none if it is
+// unset.
+func tokenTime(t, now time.Time, none string) string {
+	if t.IsZero() {
+		return none
+	}
+	return format.Relative(t, now)
+}
+
+// handleRotateConfirmKey handles the keys of the token rotation
+// confirmation: y rotates the token, n or Esc cancels.
+func (m Model) handleRotateConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		m.pendingRotate = false
+		m.showError = false
+		m.errorModal.Hide()
+		return m, m.rotateToken()
+	case "n", "N", "esc", "q":
+		m.pendingRotate = false
+		m.showError = false
+		m.errorModal.Hide()
+		m.statusBar.SetStatus("Token rotation canceled")
+	}
+	return m, nil
+}
+
+// rotateToken replaces the API token chotko uses with a new one, saves it
+// to the config file if the token came from there, and deletes the old
+// token. If saving fails, the old token is kept and used again.
+func (m *Model) rotateToken() tea.Cmd {
+	client := m.client
+	ctx := m.ctx
+	path := ""
+	if m.tokenSavedInFile() {
+		path = m.configPath
+	}
+	previous := m.config.Auth.Token
+	m.statusBar.SetStatus("Rotating the API token...")
+
+	return func() tea.Msg {
+		if client == nil {
+			return TokenRotatedMsg{}
+		}
+		now := time.Now()
+		current, secret, err := client.RotateToken(ctx, "chotko "+now.Format("2006-01-02 15:04:05"), now)
+		if err != nil {
+			return TokenRotatedMsg{Err: err}
+		}
+		if path != "" {
+			if err := config.SaveToken(path, secret); err != nil {
+				// Drops the new token while still signed in with it
+				if replacement, lookupErr := client.CurrentToken(ctx); lookupErr == nil {
+					_ = client.DeleteTokens(ctx, replacement.TokenID)
+				}
+				client.SetToken(previous)
+				return TokenRotatedMsg{Err: err}
+			}
+		}
+		msg := TokenRotatedMsg{Secret: secret, Path: path}
+		if err := client.DeleteTokens(ctx, current.TokenID); err != nil {
+			msg.Err = fmt.Errorf("the new token is in use, but the old one %q was not deleted: %w", current.Name, err)
+		}
+		return msg
+	}
+}
+
+// handleTokenRotatedMsg switches to the rotated token, showing it to copy if
+// it could not be saved to the config file.
+func (m Model) handleTokenRotatedMsg(msg TokenRotatedMsg) (tea.Model, tea.Cmd) {
+	m.statusBar.SetStatus("")
+	if msg.Secret == "" {
+		if msg.Err != nil {
+			return m, m.notifyError("Could not rotate the API token", msg.Err)
+		}
+		return m, nil
+	}
+
+	m.config.Auth.Token = msg.Secret
+	var cmds []tea.Cmd
+	if msg.Path != "" {
+		m.fileConfig.Auth.Token = msg.Secret
+		cmds = append(cmds, m.notify(notify.Success, "API token rotated and saved to "+msg.Path))
+	} else {
+		m.showError = true
+		m.errorModal.ShowText("New API Token", []string{
+			"The API token was rotated. Replace the old token where it is set",
+			"(--token or CHOTKO_TOKEN) with:",
+			"",
+			"  " + msg.Secret,
+		})
+	}
+	if msg.Err != nil {
+		cmds = append(cmds, m.notifyError("Old API token not deleted", msg.Err))
+	}
+	return m, tea.Batch(cmds...)
+}
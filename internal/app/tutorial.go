@@ -0,0 +1,124 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/harpchad/chotko/internal/components/tutorial"
+)
+
+// tutorialSteps returns the guided tour, naming the keys currently bound to
+// each action.
+func (m Model) tutorialSteps() []tutorial.Step {
+	k := m.keys
+	label := func(b key.Binding) string { return keyLabel(b.Keys()) }
+
+	return []tutorial.Step{
+		{
+			Title:  "Welcome to chotko",
+			Text:   "This short tour shows the parts of the screen and the most useful keys.",
+			Target: tutorial.TargetNone,
+		},
+		{
+			Title:  "Status bar",
+			Text:   "Host counts, connection state and the time of the last refresh. Messages such as errors appear here too.",
+			Target: tutorial.TargetStatusBar,
+		},
+		{
+			Title: "Tabs",
+			Text: fmt.Sprintf("Alerts, Hosts, Events, Graphs and Search. Switch with %s and %s, or jump with %s-%s.",
+				label(k.NextTab), label(k.PrevTab), label(k.Tab1), label(k.Tab5)),
+			Target: tutorial.TargetTabs,
+		},
+		{
+			Title: "List",
+			Text: fmt.Sprintf("Current problems, most severe first. Move with %s and %s; %s filters by text and %s by minimum severity.",
+				label(k.Up), label(k.Down), label(k.Filter), label(k.SeverityFilter)),
+			Target: tutorial.TargetList,
+		},
+		{
+			Title: "Detail",
+			Text: fmt.Sprintf("Everything about the selected item. %s switches between the list and the detail.",
+				label(k.NextPane)),
+			Target: tutorial.TargetDetail,
+		},
+		{
+			Title: "Acting on problems",
+			Text: fmt.Sprintf("%s acknowledges the selected problem, %s adds a message, and %s refreshes now.",
+				label(k.Acknowledge), label(k.AckMessage), label(k.Refresh)),
+			Target: tutorial.TargetList,
+		},
+		{
+			Title: "Commands",
+			Text: fmt.Sprintf("%s opens the command line, e.g. :stats or :tutorial to see this tour again. %s lists every key.",
+				label(k.Command), label(k.Help)),
+			Target: tutorial.TargetCommand,
+		},
+	}
+}
+
+// StartTutorial starts the guided tour.
+func (m *Model) StartTutorial() {
+	m.tutorial.Start(m.tutorialSteps())
+	m.applyTutorialStep()
+}
+
+// handleTutorialKey advances or dismisses the tour.
+func (m Model) handleTutorialKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.tutorial, _ = m.tutorial.Update(msg)
+	if !m.tutorial.Active() {
+		m.setFocus(PaneList)
+		return m, nil
+	}
+	m.applyTutorialStep()
+	return m, nil
+}
+
+// applyTutorialStep focuses the pane the current step is about, so that it
+// is highlighted (and shown in compact mode).
+func (m *Model) applyTutorialStep() {
+	switch m.tutorial.Current().Target {
+	case tutorial.TargetList:
+		m.setFocus(PaneList)
+	case tutorial.TargetDetail:
+		m.setFocus(PaneDetail)
+	}
+}
+
+// tutorialRegion returns where the target of the current step is on screen,
+// measured from the rendered bars and the height of the rendered view.
+func (m Model) tutorialRegion(viewHeight int) tutorial.Region {
+	statusHeight := lipgloss.Height(m.statusBar.View())
+	tabsHeight := lipgloss.Height(m.tabBar.View())
+	commandHeight := lipgloss.Height(m.commandInput.View())
+	top := statusHeight + tabsHeight
+	contentHeight := viewHeight - top - commandHeight
+
+	switch m.tutorial.Current().Target {
+	case tutorial.TargetStatusBar:
+		return tutorial.Region{Width: m.width, Height: statusHeight}
+	case tutorial.TargetTabs:
+		return tutorial.Region{Y: statusHeight, Width: m.width, Height: tabsHeight}
+	case tutorial.TargetList:
+		height := contentHeight
+		if m.stacked {
+			height = m.listPaneHeight
+		}
+		return tutorial.Region{X: m.listPaneX, Y: top, Width: m.listPaneWidth, Height: height}
+	case tutorial.TargetDetail:
+		if m.stacked {
+			return tutorial.Region{
+				Y:      top + m.listPaneHeight,
+				Width:  m.width,
+				Height: contentHeight - m.listPaneHeight,
+			}
+		}
+		return tutorial.Region{X: m.detailPaneX, Y: top, Width: m.width - m.detailPaneX, Height: contentHeight}
+	case tutorial.TargetCommand:
+		return tutorial.Region{Y: viewHeight - commandHeight, Width: m.width, Height: commandHeight}
+	}
+	return tutorial.Region{Width: m.width, Height: m.height}
+}
@@ -1,7 +1,11 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -10,10 +14,21 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	zone "github.com/lrstanley/bubblezone"
 
+	"github.com/harpchad/chotko/internal/components/alerts"
 	"github.com/harpchad/chotko/internal/components/command"
+	"github.com/harpchad/chotko/internal/components/dashboard"
+	"github.com/harpchad/chotko/internal/components/detail"
 	"github.com/harpchad/chotko/internal/components/editor"
 	"github.com/harpchad/chotko/internal/components/graphs"
+	"github.com/harpchad/chotko/internal/components/menu"
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/components/palette"
+	"github.com/harpchad/chotko/internal/components/search"
+	"github.com/harpchad/chotko/internal/components/statusbar"
+	"github.com/harpchad/chotko/internal/format"
 	"github.com/harpchad/chotko/internal/ignores"
+	"github.com/harpchad/chotko/internal/query"
+	"github.com/harpchad/chotko/internal/zabbix"
 )
 
 // Update handles all incoming messages and updates the model accordingly.
@@ -23,6 +38,41 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if _, ok := msg.(RefreshTickMsg); ok {
 		return m.handleRefreshTickMsg()
 	}
+	if msg, ok := msg.(ClockTickMsg); ok {
+		m.updateClock(msg.Time)
+		m.updateStale(msg.Time)
+		return m, m.tickClock()
+	}
+	if msg, ok := msg.(ReconnectTickMsg); ok {
+		return m.handleReconnectTickMsg(msg)
+	}
+	if _, ok := msg.(PingTickMsg); ok {
+		return m.handlePingTickMsg()
+	}
+	if msg, ok := msg.(PingResultMsg); ok {
+		return m.handlePingResultMsg(msg)
+	}
+	if _, ok := msg.(ConfigChangedMsg); ok {
+		return m.handleConfigChangedMsg()
+	}
+	if _, ok := msg.(KioskTickMsg); ok {
+		return m.handleKioskTickMsg()
+	}
+	if _, ok := msg.(KioskGroupTickMsg); ok {
+		return m.handleKioskGroupTickMsg()
+	}
+	if msg, ok := msg.(notify.ExpireMsg); ok {
+		m.notifications.Expire(msg.ID)
+		return m, nil
+	}
+
+	// Nobody is at the keyboard of a kiosk
+	if m.kiosk {
+		switch msg.(type) {
+		case tea.KeyMsg, tea.MouseMsg:
+			return m.handleKioskInput(msg)
+		}
+	}
 
 	// Handle editor modal first if visible
 	if m.showEditor {
@@ -30,10 +80,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	// Handle modals (help or error) first if visible
-	if m.showHelp || m.showError {
+	if m.showHelp {
+		if _, ok := msg.(tea.KeyMsg); ok {
+			m.errorModal, _ = m.errorModal.Update(msg)
+			m.showHelp = m.errorModal.Visible()
+		}
+		return m, nil
+	}
+	if m.showError {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			if m.pendingImport != nil {
+				return m.handleImportConfirmKey(keyMsg)
+			}
+			if m.pendingRotate {
+				return m.handleRotateConfirmKey(keyMsg)
+			}
 			if key.Matches(keyMsg, key.NewBinding(key.WithKeys("esc", "enter", "q", "?"))) {
-				m.showHelp = false
 				m.showError = false
 				m.errorModal.Hide()
 				return m, nil
@@ -42,6 +104,41 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// A shown dashboard takes the keys and clicks, data keeps loading below it
+	if m.showDashboard {
+		switch msg := msg.(type) {
+		case tea.KeyMsg, tea.MouseMsg:
+			return m.handleDashboardInput(msg)
+		}
+	}
+
+	// The tour takes all keys until it is finished or dismissed
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.tutorial.Active() {
+		return m.handleTutorialKey(keyMsg)
+	}
+
+	// An open context menu takes the keys and clicks until it is closed
+	if m.contextMenu.Visible() {
+		switch msg.(type) {
+		case tea.KeyMsg, tea.MouseMsg:
+			var cmd tea.Cmd
+			m.contextMenu, cmd = m.contextMenu.Update(msg)
+			return m, cmd
+		}
+	}
+
+	// An open command palette takes the keys until it is closed
+	if m.palette.Visible() {
+		switch msg.(type) {
+		case tea.KeyMsg:
+			var cmd tea.Cmd
+			m.palette, cmd = m.palette.Update(msg)
+			return m, cmd
+		case tea.MouseMsg:
+			return m, nil
+		}
+	}
+
 	// Route messages to appropriate handlers
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
@@ -52,8 +149,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleMouseMsg(msg)
 	case tea.KeyMsg:
 		return m.handleKeyMsg(msg)
+	case menu.SelectedMsg:
+		return m.handleMenuSelectedMsg(msg)
+	case palette.SelectedMsg:
+		return m.handlePaletteSelectedMsg(msg)
 	case ConnectedMsg:
 		return m.handleConnectedMsg(msg)
+	case KioskGroupsMsg:
+		return m.handleKioskGroupsMsg(msg)
+	case RoleGroupsMsg:
+		return m.handleRoleGroupsMsg(msg)
+	case HooksRunMsg:
+		return m.handleHooksRunMsg(msg)
+	case ConnectFailedMsg:
+		return m.handleConnectFailedMsg(msg)
 	case DisconnectedMsg:
 		return m.handleDisconnectedMsg(msg)
 	case ProblemsLoadedMsg:
@@ -66,12 +175,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleItemsLoadedMsg(msg)
 	case graphs.HostExpandedMsg:
 		return m, m.loadHostHistory(msg.HostID)
+	case detail.ReferenceSelectedMsg:
+		return m.handleReferenceSelectedMsg(msg)
 	case HostHistoryLoadedMsg:
 		return m.handleHostHistoryLoadedMsg(msg)
 	case HostCountsLoadedMsg:
 		return m.handleHostCountsLoadedMsg(msg)
+	case TriggerRelationsLoadedMsg:
+		return m.handleTriggerRelationsLoadedMsg(msg)
+	case FilterDebounceMsg:
+		return m.handleFilterDebounceMsg(msg)
 	case AcknowledgeResultMsg:
 		return m.handleAcknowledgeResultMsg(msg)
+	case AssignResultMsg:
+		return m.handleAssignResultMsg(msg)
 	case ErrorMsg:
 		m.showError = true
 		m.errorModal.ShowError(msg.Title, msg.Message, msg.Err)
@@ -84,12 +201,86 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleHostTriggersLoadedMsg(msg)
 	case HostMacrosLoadedMsg:
 		return m.handleHostMacrosLoadedMsg(msg)
+	case HostInterfacesLoadedMsg:
+		return m.handleHostInterfacesLoadedMsg(msg)
+	case InterfaceUpdatedMsg:
+		return m.handleInterfaceUpdatedMsg(msg)
 	case TriggerUpdateResultMsg:
 		return m.handleTriggerUpdateResultMsg(msg)
 	case MacroUpdateResultMsg:
 		return m.handleMacroUpdateResultMsg(msg)
 	case HostUpdateResultMsg:
 		return m.handleHostUpdateResultMsg(msg)
+	case MaintenanceResultMsg:
+		return m.handleMaintenanceResultMsg(msg)
+	case HostClonedMsg:
+		return m.handleHostClonedMsg(msg)
+	case ConfigurationExportedMsg:
+		return m.handleConfigurationExportedMsg(msg)
+	case ReportWrittenMsg:
+		return m.handleReportWrittenMsg(msg)
+	case HandoverWrittenMsg:
+		return m.handleHandoverWrittenMsg(msg)
+	case FlappingLoadedMsg:
+		return m.handleFlappingLoadedMsg(msg)
+	case BaselinesLoadedMsg:
+		return m.handleBaselinesLoadedMsg(msg)
+	case CompareLoadedMsg:
+		return m.handleCompareLoadedMsg(msg)
+	case HeatmapLoadedMsg:
+		return m.handleHeatmapLoadedMsg(msg)
+	case CalendarLoadedMsg:
+		return m.handleCalendarLoadedMsg(msg)
+	case TokensLoadedMsg:
+		return m.handleTokensLoadedMsg(msg)
+	case TokenRotatedMsg:
+		return m.handleTokenRotatedMsg(msg)
+	case AddressResolvedMsg:
+		return m.handleAddressResolvedMsg(msg)
+	case ChartExportedMsg:
+		return m.handleChartExportedMsg(msg)
+	case ImportPreviewMsg:
+		return m.handleImportPreviewMsg(msg)
+	case ImportResultMsg:
+		return m.handleImportResultMsg(msg)
+	case SuppressResultMsg:
+		return m.handleSuppressResultMsg(msg)
+	case HostDiagnosticsLoadedMsg:
+		return m.handleHostDiagnosticsLoadedMsg(msg)
+	case AvailabilityCheckMsg:
+		return m.handleAvailabilityCheckMsg(msg)
+	case ItemUpdateResultMsg:
+		return m.handleItemUpdateResultMsg(msg)
+	case ItemCheckResultMsg:
+		return m.handleItemCheckResultMsg(msg)
+	case FollowTickMsg:
+		return m.handleFollowTickMsg(msg)
+	case LogLinesMsg:
+		return m.handleLogLinesMsg(msg)
+	case SettingsLoadedMsg:
+		return m.handleSettingsLoadedMsg(msg)
+	case ServerInfoLoadedMsg:
+		return m.handleServerInfoLoadedMsg(msg)
+	case QueuesLoadedMsg:
+		return m.handleQueuesLoadedMsg(msg)
+	case TriggerEvaluatedMsg:
+		return m.handleTriggerEvaluatedMsg(msg)
+	case DashboardsLoadedMsg:
+		return m.handleDashboardsLoadedMsg(msg)
+	case DashboardLoadedMsg:
+		return m.handleDashboardLoadedMsg(msg)
+	case WidgetDataLoadedMsg:
+		return m.handleWidgetDataLoadedMsg(msg)
+	case dashboard.OpenMsg:
+		return m, m.loadDashboard(msg.DashboardID)
+	case SearchLoadedMsg:
+		return m.handleSearchLoadedMsg(msg)
+	case search.OpenMsg:
+		return m.openSearchResult(msg.Result)
+	case dashboard.PageMsg, dashboard.RefreshMsg:
+		return m, m.loadWidgetData()
+	case dashboard.GroupMsg:
+		return m.handleDashboardGroupMsg(msg)
 	}
 
 	return m.handleFocusedComponentUpdate(msg)
@@ -97,87 +288,254 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleConnectedMsg handles successful connection to Zabbix.
 func (m Model) handleConnectedMsg(msg ConnectedMsg) (tea.Model, tea.Cmd) {
+	reconnected := m.reconnecting
 	m.connected = true
 	m.version = msg.Version
 	m.client = msg.Client
+	m.userID = msg.UserID
+	m.alertList.SetUserID(msg.UserID)
+	m.username = msg.Username
+	m.alertList.SetUsername(msg.Username)
+	m.applyPermissions(msg.Permissions)
+	m.applySettings(msg.Settings)
+	m.failures = 0
+	m.reconnecting = false
+	m.reconnectTries = 0
+	m.updateBanner(time.Now())
 	m.statusBar.SetConnected(true, msg.Version)
-	return m, tea.Batch(m.loadProblems(), m.loadHostCounts(), m.updateWindowTitle())
+
+	if reconnected {
+		m.loading = true
+		m.statusBar.SetLoading(true)
+		m.statusBar.SetStatus("Reconnected")
+		cmds := m.loadDataForCurrentTab()
+		cmds = append(cmds, m.updateWindowTitle(), m.resolveKioskGroups(), m.resolveRoleGroups())
+		return m, tea.Batch(cmds...)
+	}
+	// Only applied once, so that filters changed since are kept
+	m.applyRoleFilter(msg.Profile)
+	cmds := []tea.Cmd{
+		m.loadProblems(), m.loadHostCounts(), m.updateWindowTitle(),
+		m.resolveKioskGroups(), m.resolveRoleGroups(),
+	}
+	if m.config.GetPingInterval() > 0 {
+		cmds = append(cmds, m.ping())
+	}
+	if tab := m.tabBar.Active(); tab != TabAlerts {
+		// A restored session may start on another tab
+		cmds = append(cmds, m.loadTab(tab))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleConnectFailedMsg handles a failed connection attempt.
+// The first failure at startup is reported in a modal; afterwards the
+// reconnect loop keeps retrying with exponential backoff.
+func (m Model) handleConnectFailedMsg(msg ConnectFailedMsg) (tea.Model, tea.Cmd) {
+	// A kiosk shows the reconnect banner instead, as nobody can close it
+	if !m.reconnecting && m.version == "" && !m.kiosk {
+		m.showError = true
+		m.errorModal.ShowError(msg.Title, msg.Message, msg.Err)
+	} else {
+		m.reconnectTries++
+	}
+
+	m.reconnecting = true
+	now := time.Now()
+	m.reconnectAt = now.Add(reconnectDelay(m.reconnectTries))
+	m.updateBanner(now)
+	return m, m.tickReconnect()
 }
 
 // handleDisconnectedMsg handles disconnection from Zabbix.
-func (m Model) handleDisconnectedMsg(msg DisconnectedMsg) (tea.Model, tea.Cmd) {
+func (m Model) handleDisconnectedMsg(_ DisconnectedMsg) (tea.Model, tea.Cmd) {
+	return m.startReconnect()
+}
+
+// handleReconnectTickMsg counts down to the next reconnect attempt.
+func (m Model) handleReconnectTickMsg(msg ReconnectTickMsg) (tea.Model, tea.Cmd) {
+	if !m.reconnecting {
+		return m, nil
+	}
+	m.updateStale(msg.Time)
+	if msg.Time.Before(m.reconnectAt) {
+		m.updateBanner(msg.Time)
+		return m, m.tickReconnect()
+	}
+	m.updateBanner(msg.Time)
+	return m, m.connect()
+}
+
+// startReconnect marks the connection as lost, keeping the last known data
+// on screen, and starts the reconnect loop.
+func (m Model) startReconnect() (tea.Model, tea.Cmd) {
 	m.connected = false
 	m.statusBar.SetConnected(false, "")
-	if msg.Err != nil {
-		m.showError = true
-		m.errorModal.ShowError("Connection Lost", "Lost connection to Zabbix server", msg.Err)
+	if m.reconnecting {
+		return m, nil
+	}
+
+	m.reconnecting = true
+	m.reconnectTries = 0
+	now := time.Now()
+	m.reconnectAt = now.Add(reconnectDelay(0))
+	m.updateBanner(now)
+	return m, tea.Batch(m.tickReconnect(), m.updateWindowTitle())
+}
+
+// handleLoadError handles a failed data load without blocking the UI.
+// Isolated failures are shown in the status bar; repeated failures are
+// treated as a lost connection. An expired session logs in again right
+// away, while refused and rate-limited loads are left to the next refresh
+// as reconnecting would not help.
+func (m Model) handleLoadError(title string, err error) (tea.Model, tea.Cmd) {
+	switch {
+	case errors.Is(err, zabbix.ErrAuth):
+		return m.startReconnect()
+	case errors.Is(err, zabbix.ErrRateLimited):
+		m.statusBar.SetStatus(title + ": server busy, retrying with the next refresh")
+		return m, nil
+	case errors.Is(err, zabbix.ErrPermission):
+		m.statusBar.SetStatus(fmt.Sprintf("%s: %v", title, err))
+		return m, nil
+	}
+
+	m.failures++
+	if m.failures >= MaxRefreshFailures {
+		return m.startReconnect()
 	}
-	return m, m.updateWindowTitle()
+	m.statusBar.SetStatus(fmt.Sprintf("%s: %v", title, err))
+	return m, nil
+}
+
+// dropLoad discards the result of a canceled or superseded load, keeping the
+// loading indicator on while newer loads are still running.
+func (m Model) dropLoad(tab int) (tea.Model, tea.Cmd) {
+	m.syncMore(tab)
+	m.loading = m.loadsInFlight()
+	m.statusBar.SetLoading(m.loading)
+	return m, nil
 }
 
 // handleProblemsLoadedMsg handles loaded problems data.
 func (m Model) handleProblemsLoadedMsg(msg ProblemsLoadedMsg) (tea.Model, tea.Cmd) {
+	if !m.finishLoad(TabAlerts, msg.Seq) || errors.Is(msg.Err, context.Canceled) {
+		return m.dropLoad(TabAlerts)
+	}
 	m.loading = false
 	m.statusBar.SetLoading(false)
-	m.lastRefresh = time.Now()
-	m.statusBar.SetLastUpdate(m.lastRefresh.Format("15:04:05"))
 
 	if msg.Err != nil {
-		m.showError = true
-		m.errorModal.ShowError("Failed to Load Problems", "Could not retrieve problems from Zabbix", msg.Err)
-		return m, nil
+		m.syncMore(TabAlerts)
+		return m.handleLoadError("Failed to Load Problems", msg.Err)
 	}
 
-	m.problems = msg.Problems
-	m.alertList.SetProblems(msg.Problems)
+	m.failures = 0
+	m.lastRefresh = time.Now()
+	m.tabLoaded[TabAlerts] = m.lastRefresh
+	m.loadDurations[TabAlerts] = msg.Duration
+	m.updateStale(m.lastRefresh)
+	m.statusBar.SetLastUpdate(m.timeFormat.Clock(m.lastRefresh))
+
+	m.pageNext[TabAlerts] = msg.Next
+	if msg.Append {
+		m.pages[TabAlerts] = max(1, m.pages[TabAlerts]) + 1
+		m.problems = append(m.problems, msg.Problems...)
+	} else {
+		m.problems = msg.Problems
+	}
+	m.alertList.SetProblems(m.problems)
+	m.updateAlertCounts()
+	if !msg.Append {
+		total := 0
+		for _, n := range m.getAlertCountsBySeverity() {
+			total += n
+		}
+		m.recordProblemTrend(total)
+	}
+	m.restoreSelection(TabAlerts)
+	m.syncMore(TabAlerts)
 
 	if m.tabBar.Active() == TabAlerts {
 		if selected := m.alertList.Selected(); selected != nil {
-			m.detailPane.SetProblem(selected)
+			m.setDetailProblem(selected)
 		}
 	}
-	return m, m.updateWindowTitle()
+	return m, tea.Batch(m.updateWindowTitle(), m.loadTriggerRelations(), m.runHooks(msg.Problems, msg.Complete))
 }
 
 // handleHostsLoadedMsg handles loaded hosts data.
 func (m Model) handleHostsLoadedMsg(msg HostsLoadedMsg) (tea.Model, tea.Cmd) {
+	if !m.finishLoad(TabHosts, msg.Seq) || errors.Is(msg.Err, context.Canceled) {
+		return m.dropLoad(TabHosts)
+	}
 	m.loading = false
 	m.statusBar.SetLoading(false)
-	m.lastRefresh = time.Now()
-	m.statusBar.SetLastUpdate(m.lastRefresh.Format("15:04:05"))
 
 	if msg.Err != nil {
-		m.showError = true
-		m.errorModal.ShowError("Failed to Load Hosts", "Could not retrieve hosts from Zabbix", msg.Err)
-		return m, nil
+		return m.handleLoadError("Failed to Load Hosts", msg.Err)
 	}
 
+	m.failures = 0
+	m.lastRefresh = time.Now()
+	m.tabLoaded[TabHosts] = m.lastRefresh
+	m.loadDurations[TabHosts] = msg.Duration
+	m.updateStale(m.lastRefresh)
+	m.statusBar.SetLastUpdate(m.timeFormat.Clock(m.lastRefresh))
+
 	m.hosts = msg.Hosts
+	if msg.Counts != nil {
+		m.hostCounts = msg.Counts
+		m.statusBar.SetCounts(msg.Counts)
+	}
+	m.detailPane.SetMaintenances(msg.Maintenances)
+	m.hostList.SetProblems(msg.Problems)
 	m.hostList.SetHosts(msg.Hosts)
+	m.restoreSelection(TabHosts)
 
+	var cmd tea.Cmd
 	if m.tabBar.Active() == TabHosts {
 		if selected := m.hostList.Selected(); selected != nil {
 			m.detailPane.SetHost(selected)
+			if m.detailPane.DiagnosedHost() == selected.HostID {
+				// Refresh the diagnostics along with the host
+				cmd = m.loadHostDiagnostics(selected.HostID)
+			}
 		}
 	}
-	return m, nil
+	return m, cmd
 }
 
 // handleEventsLoadedMsg handles loaded events data.
 func (m Model) handleEventsLoadedMsg(msg EventsLoadedMsg) (tea.Model, tea.Cmd) {
+	if !m.finishLoad(TabEvents, msg.Seq) || errors.Is(msg.Err, context.Canceled) {
+		return m.dropLoad(TabEvents)
+	}
 	m.loading = false
 	m.statusBar.SetLoading(false)
-	m.lastRefresh = time.Now()
-	m.statusBar.SetLastUpdate(m.lastRefresh.Format("15:04:05"))
 
 	if msg.Err != nil {
-		m.showError = true
-		m.errorModal.ShowError("Failed to Load Events", "Could not retrieve events from Zabbix", msg.Err)
-		return m, nil
+		m.syncMore(TabEvents)
+		return m.handleLoadError("Failed to Load Events", msg.Err)
 	}
 
-	m.events = msg.Events
-	m.eventList.SetEvents(msg.Events)
+	m.failures = 0
+	m.lastRefresh = time.Now()
+	m.tabLoaded[TabEvents] = m.lastRefresh
+	m.loadDurations[TabEvents] = msg.Duration
+	m.updateStale(m.lastRefresh)
+	m.statusBar.SetLastUpdate(m.timeFormat.Clock(m.lastRefresh))
+
+	m.pageNext[TabEvents] = msg.Next
+	if msg.Append {
+		m.pages[TabEvents] = max(1, m.pages[TabEvents]) + 1
+		m.events = append(m.events, msg.Events...)
+	} else {
+		m.events = msg.Events
+	}
+	m.eventList.SetEvents(m.events)
+	m.restoreSelection(TabEvents)
+	m.syncMore(TabEvents)
 
 	if m.tabBar.Active() == TabEvents {
 		if selected := m.eventList.Selected(); selected != nil {
@@ -189,17 +547,23 @@ func (m Model) handleEventsLoadedMsg(msg EventsLoadedMsg) (tea.Model, tea.Cmd) {
 
 // handleItemsLoadedMsg handles loaded items data.
 func (m Model) handleItemsLoadedMsg(msg ItemsLoadedMsg) (tea.Model, tea.Cmd) {
+	if !m.finishLoad(TabGraphs, msg.Seq) || errors.Is(msg.Err, context.Canceled) {
+		return m.dropLoad(TabGraphs)
+	}
 	m.loading = false
 	m.statusBar.SetLoading(false)
-	m.lastRefresh = time.Now()
-	m.statusBar.SetLastUpdate(m.lastRefresh.Format("15:04:05"))
 
 	if msg.Err != nil {
-		m.showError = true
-		m.errorModal.ShowError("Failed to Load Items", "Could not retrieve items from Zabbix", msg.Err)
-		return m, nil
+		return m.handleLoadError("Failed to Load Items", msg.Err)
 	}
 
+	m.failures = 0
+	m.lastRefresh = time.Now()
+	m.tabLoaded[TabGraphs] = m.lastRefresh
+	m.loadDurations[TabGraphs] = msg.Duration
+	m.updateStale(m.lastRefresh)
+	m.statusBar.SetLastUpdate(m.timeFormat.Clock(m.lastRefresh))
+
 	m.items = msg.Items
 	m.graphList.SetItems(msg.Items, m.config.GetGraphCategories())
 
@@ -209,7 +573,18 @@ func (m Model) handleItemsLoadedMsg(msg ItemsLoadedMsg) (tea.Model, tea.Cmd) {
 			m.detailPane.SetItem(selected, history)
 		}
 	}
-	return m, nil
+
+	// Hosts expanded by a restored session still need their history
+	var cmds []tea.Cmd
+	if id := m.searchItem; id != "" {
+		m.searchItem = ""
+		cmds = append(cmds, m.selectGraphItem(id))
+	}
+	for _, hostID := range m.graphList.UnloadedHosts() {
+		cmds = append(cmds, m.loadHostHistory(hostID))
+	}
+	cmds = append(cmds, m.reloadComparison())
+	return m, tea.Batch(cmds...)
 }
 
 // handleHostHistoryLoadedMsg handles loaded history data.
@@ -244,11 +619,60 @@ func (m Model) handleHostCountsLoadedMsg(msg HostCountsLoadedMsg) (tea.Model, te
 // handleAcknowledgeResultMsg handles acknowledge result.
 func (m Model) handleAcknowledgeResultMsg(msg AcknowledgeResultMsg) (tea.Model, tea.Cmd) {
 	if msg.Err != nil {
-		m.showError = true
-		m.errorModal.ShowError("Acknowledge Failed", "Could not acknowledge problem", msg.Err)
+		if msg.Closed {
+			// The server applies all actions or none, so the problem is unchanged
+			return m, m.notifyError(fmt.Sprintf("Could not close the problem, nor acknowledge it (%s acknowledges without closing)",
+				keyLabel(m.keys.Acknowledge.Keys())), msg.Err)
+		}
+		return m, m.notifyError("Could not acknowledge", msg.Err)
+	}
+	var notice tea.Cmd
+	switch {
+	case msg.Closed:
+		notice = m.notify(notify.Success, "Problem acknowledged and closed")
+	case msg.Count > 0:
+		notice = m.notify(notify.Success, fmt.Sprintf("Acknowledged %d problems", msg.Count))
+		m.alertList.ClearMarks()
+	default:
+		notice = m.notify(notify.Success, "Problem acknowledged")
+	}
+	return m, tea.Batch(notice, m.loadProblems())
+}
+
+// handleAssignResultMsg handles the result of assigning or unassigning
+// problems.
+func (m Model) handleAssignResultMsg(msg AssignResultMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		if msg.Username == "" {
+			return m, m.notifyError("Could not unassign", msg.Err)
+		}
+		return m, m.notifyError("Could not assign to "+msg.Username, msg.Err)
+	}
+	if msg.Count == 0 {
 		return m, nil
 	}
-	return m, m.loadProblems()
+	m.alertList.ClearMarks()
+	text := fmt.Sprintf("Unassigned %d problems", msg.Count)
+	switch {
+	case msg.Username != "" && msg.Count == 1:
+		text = "Problem assigned to " + msg.Username
+	case msg.Username != "":
+		text = fmt.Sprintf("Assigned %d problems to %s", msg.Count, msg.Username)
+	case msg.Count == 1:
+		text = "Problem unassigned"
+	}
+	return m, tea.Batch(m.notify(notify.Success, text), m.loadProblems())
+}
+
+// handleConfigChangedMsg reloads the config and theme after they change on disk.
+func (m Model) handleConfigChangedMsg() (tea.Model, tea.Cmd) {
+	cmd, err := m.reloadConfig()
+	if err != nil {
+		m.statusBar.SetStatus(fmt.Sprintf("Config reload failed: %v", err))
+	} else {
+		m.statusBar.SetStatus("Config reloaded")
+	}
+	return m, tea.Batch(cmd, m.waitForConfigChange())
 }
 
 // handleRefreshTickMsg handles periodic refresh.
@@ -259,16 +683,48 @@ func (m Model) handleRefreshTickMsg() (tea.Model, tea.Cmd) {
 		m.statusBar.SetLoading(true)
 		cmds = append(cmds, m.loadDataForCurrentTab()...)
 	}
+	if m.showDashboard && m.connected {
+		cmds = append(cmds, m.loadWidgetData())
+	}
+	m.nextRefresh = time.Now().Add(m.refreshInterval)
+	m.updateClock(time.Now())
+	m.updateStale(time.Now())
 	cmds = append(cmds, m.tickRefresh())
 	return m, tea.Batch(cmds...)
 }
 
+// handlePingTickMsg measures the server's latency while connected.
+func (m Model) handlePingTickMsg() (tea.Model, tea.Cmd) {
+	cmds := []tea.Cmd{m.tickPing()}
+	if m.connected && !m.reconnecting {
+		cmds = append(cmds, m.ping())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handlePingResultMsg shows the server's latency in the status bar, hiding
+// it when the health check failed.
+func (m Model) handlePingResultMsg(msg PingResultMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.statusBar.SetLatency(0, statusbar.LatencyNormal)
+		return m, nil
+	}
+	warning, critical := m.config.GetLatencyThresholds()
+	level := statusbar.LatencyNormal
+	switch {
+	case msg.Latency >= critical:
+		level = statusbar.LatencyCritical
+	case msg.Latency >= warning:
+		level = statusbar.LatencySlow
+	}
+	m.statusBar.SetLatency(msg.Latency, level)
+	return m, nil
+}
+
 // handleHostTriggersLoadedMsg handles loaded triggers for editor.
 func (m Model) handleHostTriggersLoadedMsg(msg HostTriggersLoadedMsg) (tea.Model, tea.Cmd) {
 	if msg.Err != nil {
-		m.showError = true
-		m.errorModal.ShowError("Failed to Load Triggers", "Could not retrieve triggers from Zabbix", msg.Err)
-		return m, nil
+		return m, m.notifyError("Could not load triggers", msg.Err)
 	}
 	host := m.findHostByID(msg.HostID)
 	if host != nil {
@@ -281,9 +737,7 @@ func (m Model) handleHostTriggersLoadedMsg(msg HostTriggersLoadedMsg) (tea.Model
 // handleHostMacrosLoadedMsg handles loaded macros for editor.
 func (m Model) handleHostMacrosLoadedMsg(msg HostMacrosLoadedMsg) (tea.Model, tea.Cmd) {
 	if msg.Err != nil {
-		m.showError = true
-		m.errorModal.ShowError("Failed to Load Macros", "Could not retrieve macros from Zabbix", msg.Err)
-		return m, nil
+		return m, m.notifyError("Could not load macros", msg.Err)
 	}
 	host := m.findHostByID(msg.HostID)
 	if host != nil {
@@ -295,39 +749,43 @@ func (m Model) handleHostMacrosLoadedMsg(msg HostMacrosLoadedMsg) (tea.Model, te
 
 // handleTriggerUpdateResultMsg handles trigger update result.
 func (m Model) handleTriggerUpdateResultMsg(msg TriggerUpdateResultMsg) (tea.Model, tea.Cmd) {
+	what := "the trigger"
+	if msg.Count > 1 {
+		what = fmt.Sprintf("%d triggers", msg.Count)
+	}
 	if msg.Err != nil {
-		m.showError = true
-		m.errorModal.ShowError("Trigger Update Failed", "Could not update trigger", msg.Err)
-		return m, nil
+		return m, m.notifyError("Could not "+msg.Action+" "+what, msg.Err)
+	}
+	notice := m.notify(notify.Success, "Trigger "+actionDone[msg.Action])
+	if msg.Count > 1 {
+		notice = m.notify(notify.Success, fmt.Sprintf("%d triggers %s", msg.Count, actionDone[msg.Action]))
 	}
 	hostID := m.getSelectedHostID()
 	if hostID != "" {
-		return m, tea.Batch(m.loadHosts(), m.loadHostTriggers(hostID, msg.TriggerID))
+		return m, tea.Batch(notice, m.loadHosts(), m.loadHostTriggers(hostID, msg.TriggerID))
 	}
-	return m, m.loadHosts()
+	return m, tea.Batch(notice, m.loadHosts())
 }
 
 // handleMacroUpdateResultMsg handles macro update result.
 func (m Model) handleMacroUpdateResultMsg(msg MacroUpdateResultMsg) (tea.Model, tea.Cmd) {
 	if msg.Err != nil {
-		m.showError = true
-		m.errorModal.ShowError("Macro Update Failed", "Could not update macro", msg.Err)
-		return m, nil
+		return m, m.notifyError("Could not "+msg.Action+" the macro", msg.Err)
 	}
+	notice := m.notify(notify.Success, "Macro "+actionDone[msg.Action])
 	if selected := m.hostList.Selected(); selected != nil {
-		return m, m.loadHostMacros(selected.HostID)
+		return m, tea.Batch(notice, m.loadHostMacros(selected.HostID))
 	}
-	return m, nil
+	return m, notice
 }
 
 // handleHostUpdateResultMsg handles host update result.
 func (m Model) handleHostUpdateResultMsg(msg HostUpdateResultMsg) (tea.Model, tea.Cmd) {
 	if msg.Err != nil {
-		m.showError = true
-		m.errorModal.ShowError("Host Update Failed", "Could not update host", msg.Err)
-		return m, nil
+		return m, m.notifyError("Could not "+msg.Action+" the host", msg.Err)
 	}
-	return m, m.loadHosts()
+	notice := m.notify(notify.Success, "Host "+actionDone[msg.Action])
+	return m, tea.Batch(notice, m.loadHosts())
 }
 
 // handleFocusedComponentUpdate updates the focused component.
@@ -369,7 +827,7 @@ func (m Model) updateListPane(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Update detail when selection changes
 		if selected := m.alertList.Selected(); selected != nil {
-			m.detailPane.SetProblem(selected)
+			m.setDetailProblem(selected)
 		}
 	case TabHosts:
 		var cmd tea.Cmd
@@ -402,32 +860,47 @@ func (m Model) updateListPane(msg tea.Msg) (tea.Model, tea.Cmd) {
 			history := m.graphList.GetHistory(selected.ItemID)
 			m.detailPane.SetItem(selected, history)
 		}
+	case TabSearch:
+		var cmd tea.Cmd
+		m.searchList, cmd = m.searchList.Update(msg)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		m.setDetailSearchResult()
 	}
 
+	cmds = append(cmds, m.maybeLoadMore(), m.maybeLoadBaselines())
 	return m, tea.Batch(cmds...)
 }
 
-// loadDataForCurrentTab returns commands to load data for the current tab.
+// loadDataForCurrentTab returns commands to load data for the current tab,
+// along with the status bar's host counts. The Hosts tab derives the counts
+// from the whole host list it loads, so they aren't fetched separately unless
+// a server search narrows that list.
 func (m *Model) loadDataForCurrentTab() []tea.Cmd {
-	cmds := []tea.Cmd{m.loadHostCounts()}
+	tab := m.tabBar.Active()
+	if tab == TabHosts && m.serverSearch[TabHosts] == nil {
+		return []tea.Cmd{m.loadTab(tab)}
+	}
+	return []tea.Cmd{m.loadHostCounts(), m.loadTab(tab)}
+}
 
-	switch m.tabBar.Active() {
-	case TabAlerts:
-		cmds = append(cmds, m.loadProblems())
+// loadTab returns the command that loads the data shown on a tab.
+func (m *Model) loadTab(tab int) tea.Cmd {
+	switch tab {
 	case TabHosts:
-		cmds = append(cmds, m.loadHosts())
+		return m.loadHosts()
 	case TabEvents:
-		cmds = append(cmds, m.loadEvents())
+		return m.loadEvents()
 	case TabGraphs:
-		// Load both items and history for graphs tab
-		// History will be loaded after ItemsLoadedMsg is received
-		cmds = append(cmds, m.loadItems())
+		// History is loaded lazily when hosts are expanded
+		return m.loadItems()
+	case TabSearch:
+		return m.runSearch(m.searchList.Query())
 	default:
-		// For other tabs, load problems by default
-		cmds = append(cmds, m.loadProblems())
+		// Alerts, and any other tab, show problems
+		return m.loadProblems()
 	}
-
-	return cmds
 }
 
 // handleKeyMsg processes keyboard input.
@@ -436,6 +909,12 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.awaitingIgnoreConfirm {
 		return m.handleIgnoreConfirm(msg)
 	}
+	if m.pendingMaintenance != nil {
+		return m.handleMaintenanceKey(msg)
+	}
+	if m.pendingSuppress != nil && !m.pendingSuppress.custom {
+		return m.handleSuppressKey(msg)
+	}
 
 	if m.commandInput.IsActive() {
 		return m.handleCommandInput(msg)
@@ -468,9 +947,17 @@ func (m Model) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 		return m, tea.Quit, true
 	case key.Matches(msg, m.keys.Help):
 		m.showHelp = true
-		m.errorModal.ShowHelp()
+		m.errorModal.ShowHelp(m.keys.HelpSections())
+		return m, nil, true
+	case key.Matches(msg, m.keys.Palette):
+		m.palette.Show(m.paletteEntries(), m.recentActions)
 		return m, nil, true
 	case key.Matches(msg, m.keys.Refresh):
+		if m.reconnecting {
+			// Skip the remaining backoff and retry now
+			m.reconnectAt = time.Now()
+			return m, nil, true
+		}
 		if !m.loading && m.connected {
 			m.loading = true
 			m.statusBar.SetLoading(true)
@@ -484,6 +971,14 @@ func (m Model) handleGlobalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 // handleNavigationKeys handles tab and pane navigation.
 func (m Model) handleNavigationKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 	switch {
+	case m.compact && m.focused == PaneList && key.Matches(msg, m.keys.Select) && m.hasSelection():
+		// Compact mode: open the detail as a full-width overlay
+		m.setFocus(PaneDetail)
+		return m, nil, true
+	case m.compact && m.focused == PaneDetail && key.Matches(msg, m.keys.Escape):
+		// Compact mode: close the detail overlay
+		m.setFocus(PaneList)
+		return m, nil, true
 	case key.Matches(msg, m.keys.NextTab):
 		model, cmd := m.switchTab(m.tabBar.Active() + 1)
 		return model, cmd, true
@@ -502,6 +997,9 @@ func (m Model) handleNavigationKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 	case key.Matches(msg, m.keys.Tab4):
 		model, cmd := m.switchTab(3)
 		return model, cmd, true
+	case key.Matches(msg, m.keys.Tab5):
+		model, cmd := m.switchTab(4)
+		return model, cmd, true
 	case key.Matches(msg, m.keys.NextPane):
 		m.cycleFocus(1)
 		return m, nil, true
@@ -514,20 +1012,125 @@ func (m Model) handleNavigationKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 
 // handleActionKeys handles acknowledge, filter, edit, and other actions.
 func (m Model) handleActionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if action := m.deniedAction(msg); action != "" {
+		return m, m.notify(notify.Error, "Your user role cannot "+action), true
+	}
 	switch {
+	case key.Matches(msg, m.keys.ToggleSound):
+		return m, m.toggleSound(), true
+	case key.Matches(msg, m.keys.ToggleTime):
+		m.timeFormat.Relative = !m.timeFormat.Relative
+		m.applyTimeFormat()
+		if m.timeFormat.Relative {
+			m.statusBar.SetStatus("Showing relative times")
+		} else {
+			m.statusBar.SetStatus("Showing absolute times")
+		}
+		return m, nil, true
 	case key.Matches(msg, m.keys.Acknowledge):
 		if m.tabBar.Active() == TabAlerts && m.alertList.Selected() != nil {
-			return m, m.acknowledgeProblem(""), true
+			if m.config.Ack.MinMessageLength > 0 {
+				m.promptAckMessage(false)
+				return m, nil, true
+			}
+			return m, m.acknowledgeProblem("", false), true
 		}
 		return m, nil, true
+	case key.Matches(msg, m.keys.AckClose):
+		if m.tabBar.Active() != TabAlerts {
+			return m, nil, true
+		}
+		if m.alertList.SelectedGroup() != nil {
+			m.statusBar.SetStatus("Expand the group to close its problems one at a time")
+			return m, nil, true
+		}
+		if m.alertList.Marked() != nil {
+			m.statusBar.SetStatus("Selected problems can only be closed one at a time")
+			return m, nil, true
+		}
+		if selected := m.alertList.Selected(); selected != nil {
+			if !selected.AllowsManualClose() {
+				m.statusBar.SetStatus("This trigger does not allow closing problems manually")
+				return m, nil, true
+			}
+			if m.config.Ack.MinMessageLength > 0 {
+				m.promptAckMessage(true)
+				return m, nil, true
+			}
+			return m, m.acknowledgeProblem("", true), true
+		}
+		return m, nil, true
+	case key.Matches(msg, m.keys.Escape) && m.tabBar.Active() == TabAlerts && m.alertList.Marked() != nil:
+		m.alertList.ClearMarks()
+		m.statusBar.SetStatus("Selection cleared")
+		return m, nil, true
+	case m.tabBar.Active() == TabGraphs && key.Matches(msg, m.keys.UnsupportedItems):
+		return m, m.toggleUnsupportedItems(), true
+	case key.Matches(msg, m.keys.AckFilter):
+		if m.tabBar.Active() != TabAlerts {
+			return m, nil, true
+		}
+		return m, m.cycleAckFilter(), true
 	case key.Matches(msg, m.keys.AckMessage):
 		if m.tabBar.Active() == TabAlerts && m.alertList.Selected() != nil {
-			m.mode = ModeAckMessage
-			m.commandInput.SetMode(command.ModeAckMessage)
+			m.promptAckMessage(false)
 		}
 		return m, nil, true
+	case m.tabBar.Active() == TabGraphs && key.Matches(msg, m.keys.Follow):
+		return m, m.toggleFollow(), true
+	case m.tabBar.Active() == TabGraphs && key.Matches(msg, m.keys.ExportChart):
+		return m, m.exportChart(""), true
+	case m.tabBar.Active() == TabGraphs && key.Matches(msg, m.keys.Baseline):
+		return m, m.toggleBaseline(), true
+	case m.tabBar.Active() == TabGraphs && key.Matches(msg, m.keys.Compare):
+		return m, m.toggleComparison(), true
+	case key.Matches(msg, m.keys.HostState):
+		if m.tabBar.Active() == TabHosts {
+			m.cycleHostState()
+		}
+		return m, nil, true
+	case key.Matches(msg, m.keys.HostSort):
+		if m.tabBar.Active() == TabHosts {
+			m.cycleHostSort()
+		}
+		return m, nil, true
+	case key.Matches(msg, m.keys.CopyAddress):
+		if m.tabBar.Active() == TabHosts {
+			return m, m.copyHostAddress(), true
+		}
+		return m, nil, true
+	case key.Matches(msg, m.keys.EventWindow):
+		if m.tabBar.Active() != TabEvents {
+			return m, nil, true
+		}
+		scope := m.eventScope
+		scope.Window = nextEventWindow(scope.Window)
+		return m, m.setEventScope(scope), true
+	case key.Matches(msg, m.keys.EventType):
+		if m.tabBar.Active() != TabEvents {
+			return m, nil, true
+		}
+		scope := m.eventScope
+		scope.Type = (scope.Type + 1) % eventTypeCount
+		return m, m.setEventScope(scope), true
+	case key.Matches(msg, m.keys.HostHistory):
+		model, cmd := m.showHostHistory()
+		return model, cmd, true
+	case key.Matches(msg, m.keys.Explain):
+		return m, m.explainTrigger(), true
+	case m.tabBar.Active() == TabHosts && key.Matches(msg, m.keys.CloneHost):
+		m.startClonePrompt()
+		return m, nil, true
+	case key.Matches(msg, m.keys.OpenBrowser):
+		m.openInBrowser()
+		return m, nil, true
+	case m.tabBar.Active() == TabSearch && key.Matches(msg, m.keys.Filter):
+		m.mode = ModeSearch
+		m.commandInput.SetMode(command.ModeSearch)
+		return m, nil, true
 	case key.Matches(msg, m.keys.Filter):
 		m.mode = ModeFilter
+		m.filterBefore = m.textFilter
 		m.commandInput.SetMode(command.ModeFilter)
 		return m, nil, true
 	case key.Matches(msg, m.keys.Command):
@@ -540,8 +1143,23 @@ func (m Model) handleActionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 		return m.handleEditTriggers()
 	case key.Matches(msg, m.keys.EditMacros):
 		return m.handleEditMacros()
+	case key.Matches(msg, m.keys.EditInterfaces):
+		return m.handleEditInterfaces()
 	case key.Matches(msg, m.keys.ToggleMonitor):
 		return m.handleToggleMonitor()
+	case key.Matches(msg, m.keys.Maintenance):
+		m.startMaintenancePrompt()
+		return m, nil, true
+	case key.Matches(msg, m.keys.Suppress):
+		m.startSuppressPrompt()
+		return m, nil, true
+	case key.Matches(msg, m.keys.Diagnose):
+		return m, m.toggleDiagnostics(), true
+	case key.Matches(msg, m.keys.ForceCheck):
+		if m.tabBar.Active() == TabGraphs {
+			return m, m.checkItemNow(), true
+		}
+		return m, m.forceAvailabilityCheck(), true
 	case key.Matches(msg, m.keys.ClearFilter):
 		return m.handleClearFilter()
 	case key.Matches(msg, m.keys.Ignore):
@@ -554,16 +1172,53 @@ func (m Model) handleActionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 
 // handleSeverityFilter handles severity filter keys (0-5).
 func (m Model) handleSeverityFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
-	if m.tabBar.Active() == TabAlerts {
-		if severity, err := strconv.Atoi(msg.String()); err == nil {
-			m.minSeverity = severity
-			m.alertList.SetMinSeverity(severity)
-			m.statusBar.SetFilter(m.minSeverity, m.textFilter)
-		}
+	severity, err := strconv.Atoi(msg.String())
+	if err != nil {
+		return m, nil, true
+	}
+	switch m.tabBar.Active() {
+	case TabAlerts:
+		m.setMinSeverity(severity)
+	case TabEvents:
+		scope := m.eventScope
+		scope.MinSeverity = severity
+		return m, m.setEventScope(scope), true
 	}
 	return m, nil, true
 }
 
+// setMinSeverity hides alerts below a severity.
+func (m *Model) setMinSeverity(severity int) {
+	m.minSeverity = severity
+	m.alertList.SetMinSeverity(severity)
+	m.statusBar.SetFilter(m.minSeverity, m.textFilter)
+}
+
+// cycleAckFilter switches the alerts shown between all, unacknowledged,
+// acknowledged by the current user and assigned to them, and reloads them.
+// The last two are skipped when the current user is unknown.
+func (m *Model) cycleAckFilter() tea.Cmd {
+	f := m.alertList.AckFilter().Next()
+	if f == alerts.AckMine && m.userID == "" || f == alerts.AckAssigned && m.username == "" {
+		f = alerts.AckAll
+		m.statusBar.SetStatus("Alerts: all (your acknowledgements and assignments need a password login or Zabbix 6.4+)")
+	} else if f == alerts.AckAll {
+		m.statusBar.SetStatus("Alerts: all")
+	} else {
+		m.statusBar.SetStatus("Alerts: " + f.Label() + " only")
+	}
+	m.alertList.SetAckFilter(f)
+	if selected := m.alertList.Selected(); selected != nil {
+		m.setDetailProblem(selected)
+	}
+
+	if !m.connected {
+		return nil
+	}
+	m.statusBar.SetLoading(true)
+	return m.loadProblems()
+}
+
 // handleEditTriggers opens the trigger editor.
 func (m Model) handleEditTriggers() (tea.Model, tea.Cmd, bool) {
 	hostID, triggerID := m.getSelectedHostAndTriggerID()
@@ -582,8 +1237,12 @@ func (m Model) handleEditMacros() (tea.Model, tea.Cmd, bool) {
 	return m, nil, true
 }
 
-// handleToggleMonitor toggles host monitoring.
+// handleToggleMonitor toggles monitoring of the selected host, or of the
+// selected item on the Graphs tab.
 func (m Model) handleToggleMonitor() (tea.Model, tea.Cmd, bool) {
+	if m.tabBar.Active() == TabGraphs {
+		return m, m.toggleItem(), true
+	}
 	if m.tabBar.Active() == TabHosts && m.hostList.Selected() != nil {
 		host := m.hostList.Selected()
 		if host.IsMonitored() {
@@ -603,7 +1262,33 @@ func (m Model) handleClearFilter() (tea.Model, tea.Cmd, bool) {
 	m.hostList.SetTextFilter("")
 	m.eventList.SetTextFilter("")
 	m.statusBar.SetFilter(0, "")
-	return m, nil, true
+
+	// Reload tabs whose data was narrowed by a server-side search, or by
+	// showing a single host's events
+	reload := make(map[int]bool)
+	for tab, search := range m.serverSearch {
+		if search != nil {
+			m.serverSearch[tab] = nil
+			reload[tab] = true
+		}
+	}
+	if m.alertGroup != nil {
+		m.alertGroup = nil
+		reload[TabAlerts] = m.connected
+	}
+	if m.eventScope.HostID != "" {
+		scope := m.eventScope
+		scope.HostID, scope.HostName = "", ""
+		m.applyEventScope(scope)
+		reload[TabEvents] = m.connected
+	}
+	var cmds []tea.Cmd
+	for tab := range TabCount {
+		if reload[tab] {
+			cmds = append(cmds, m.loadTab(tab))
+		}
+	}
+	return m, tea.Batch(cmds...), true
 }
 
 // handleIgnore initiates the ignore flow for the selected alert.
@@ -653,15 +1338,17 @@ func (m Model) handleIgnoreConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y":
 		// Add the ignore rule
+		var notice tea.Cmd
+		m.statusBar.SetStatus("")
 		if m.ignoreList != nil && m.pendingIgnore != nil {
 			if err := m.ignoreList.Add(*m.pendingIgnore); err != nil {
-				m.statusBar.SetStatus(err.Error())
+				notice = m.notify(notify.Error, err.Error())
 			} else {
 				// Save to disk
 				if err := m.ignoreList.Save(); err != nil {
-					m.statusBar.SetStatus(fmt.Sprintf("Ignored (save failed: %v)", err))
+					notice = m.notifyError("Ignored, but not saved", err)
 				} else {
-					m.statusBar.SetStatus(fmt.Sprintf("Ignored: %s / %s", m.pendingIgnore.HostName, truncate(m.pendingIgnore.TriggerName, 20)))
+					notice = m.notify(notify.Success, fmt.Sprintf("Ignored: %s / %s", m.pendingIgnore.HostName, truncate(m.pendingIgnore.TriggerName, 20)))
 				}
 				// Refresh alerts to hide the ignored one
 				m.alertList.SetIgnoreChecker(m.ignoreList.IsIgnored)
@@ -669,7 +1356,7 @@ func (m Model) handleIgnoreConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.pendingIgnore = nil
 		m.awaitingIgnoreConfirm = false
-		return m, nil
+		return m, notice
 
 	case "n", "N", "esc":
 		// Cancel
@@ -747,39 +1434,52 @@ func (m Model) switchTab(newTab int) (tea.Model, tea.Cmd) {
 	oldTab := m.tabBar.Active()
 	m.tabBar.SetActive(newTab)
 
+	// Loads for the tab being left are no longer wanted
+	if oldTab != newTab {
+		m.cancelLoadsExcept(newTab)
+	}
+
+	// Close the compact detail overlay so the new tab's list is visible
+	if m.compact && m.focused == PaneDetail {
+		m.focused = PaneList
+		m.detailPane.SetFocused(false)
+	}
+
 	// Update focus when switching tabs
 	m.updateListFocus()
 
 	// Update detail pane for new tab
 	m.updateDetailForCurrentTab()
 
-	// Load data if switching to a different tab type
+	// Load data if switching to a different tab type and its data is missing or stale
 	var cmds []tea.Cmd
 	if oldTab != newTab && m.connected {
+		stale := m.isStale(newTab, time.Now())
 		switch newTab {
 		case TabAlerts:
-			if len(m.problems) == 0 {
+			if len(m.problems) == 0 || stale {
 				m.statusBar.SetLoading(true)
 				cmds = append(cmds, m.loadProblems())
 			}
 		case TabHosts:
-			if len(m.hosts) == 0 {
+			if len(m.hosts) == 0 || stale {
 				m.statusBar.SetLoading(true)
 				cmds = append(cmds, m.loadHosts())
 			}
 		case TabEvents:
-			if len(m.events) == 0 {
+			if len(m.events) == 0 || stale {
 				m.statusBar.SetLoading(true)
 				cmds = append(cmds, m.loadEvents())
 			}
 		case TabGraphs:
-			if len(m.items) == 0 {
+			if len(m.items) == 0 || stale {
 				m.statusBar.SetLoading(true)
 				cmds = append(cmds, m.loadItems())
 			}
 			// History is now lazy-loaded when hosts are expanded
 		}
 	}
+	cmds = append(cmds, m.maybeLoadBaselines())
 
 	return m, tea.Batch(cmds...)
 }
@@ -793,6 +1493,7 @@ func (m *Model) updateListFocus() {
 	m.hostList.SetFocused(false)
 	m.eventList.SetFocused(false)
 	m.graphList.SetFocused(false)
+	m.searchList.SetFocused(false)
 
 	// Set focus for the active tab's list
 	switch m.tabBar.Active() {
@@ -804,17 +1505,35 @@ func (m *Model) updateListFocus() {
 		m.eventList.SetFocused(isFocused)
 	case TabGraphs:
 		m.graphList.SetFocused(isFocused)
+	case TabSearch:
+		m.searchList.SetFocused(isFocused)
 	}
 }
 
+// hasSelection reports whether the current tab's list has a selected item.
+// On the graphs tab only item nodes count, so Enter still expands hosts and categories.
+func (m *Model) hasSelection() bool {
+	switch m.tabBar.Active() {
+	case TabAlerts:
+		return m.alertList.Selected() != nil
+	case TabHosts:
+		return m.hostList.Selected() != nil
+	case TabEvents:
+		return m.eventList.Selected() != nil
+	case TabGraphs:
+		return m.graphList.SelectedItem() != nil
+	}
+	return false
+}
+
 // updateDetailForCurrentTab updates the detail pane content for the current tab.
 func (m *Model) updateDetailForCurrentTab() {
 	switch m.tabBar.Active() {
 	case TabAlerts:
 		if selected := m.alertList.Selected(); selected != nil {
-			m.detailPane.SetProblem(selected)
+			m.setDetailProblem(selected)
 		} else {
-			m.detailPane.SetProblem(nil)
+			m.setDetailProblem(nil)
 		}
 	case TabHosts:
 		if selected := m.hostList.Selected(); selected != nil {
@@ -835,6 +1554,8 @@ func (m *Model) updateDetailForCurrentTab() {
 		} else {
 			m.detailPane.SetItem(nil, nil)
 		}
+	case TabSearch:
+		m.setDetailSearchResult()
 	default:
 		m.detailPane.Clear()
 	}
@@ -844,35 +1565,81 @@ func (m *Model) updateDetailForCurrentTab() {
 func (m Model) handleCommandInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
+		mode := m.commandInput.Mode()
 		m.mode = ModeNormal
 		m.commandInput.Hide()
+		if mode == command.ModeSuppressUntil {
+			m.pendingSuppress = nil
+		}
+		if mode == command.ModeCloneHost {
+			m.pendingClone = nil
+		}
+		m.ackClose = false
+		if mode == command.ModeFilter {
+			// Drop pending keystrokes and restore the filter from before typing
+			m.filterSeq++
+			if m.textFilter != m.filterBefore {
+				return m, m.applyTextFilter(m.filterBefore)
+			}
+		}
 		return m, nil
 
 	case "enter":
 		value := m.commandInput.Value()
 		mode := m.commandInput.Mode()
+		if mode == command.ModeFilter {
+			if _, err := query.Parse(value); err != nil {
+				m.commandInput.SetError(err.Error())
+				return m, nil
+			}
+		}
+		if mode == command.ModeSuppressUntil {
+			until, err := parseSuppressUntil(value, time.Now(), m.timeFormat.Location)
+			if err != nil {
+				m.commandInput.SetError(err.Error())
+				return m, nil
+			}
+			m.mode = ModeNormal
+			m.commandInput.Hide()
+			req := *m.pendingSuppress
+			m.pendingSuppress = nil
+			return m, m.suppressProblems(req, until)
+		}
+		if mode == command.ModeCloneHost {
+			name, address, err := parseCloneTarget(value)
+			if err != nil {
+				m.commandInput.SetError(err.Error())
+				return m, nil
+			}
+			m.mode = ModeNormal
+			m.commandInput.Hide()
+			req := *m.pendingClone
+			m.pendingClone = nil
+			return m, m.cloneHost(req, name, address)
+		}
+		if mode == command.ModeAckMessage {
+			if err := m.checkAckMessage(value); err != nil {
+				m.commandInput.SetError(err.Error())
+				return m, nil
+			}
+		}
 		m.mode = ModeNormal
 		m.commandInput.Hide()
 
 		switch mode {
 		case command.ModeFilter:
-			m.textFilter = value
-			// Apply filter to current tab's list
-			switch m.tabBar.Active() {
-			case TabAlerts:
-				m.alertList.SetTextFilter(value)
-			case TabHosts:
-				m.hostList.SetTextFilter(value)
-			case TabEvents:
-				m.eventList.SetTextFilter(value)
-			}
-			m.statusBar.SetFilter(m.minSeverity, m.textFilter)
+			m.filterSeq++ // Apply now rather than after the debounce
+			return m, m.applyTextFilter(value)
 		case command.ModeAckMessage:
+			closeProblem := m.ackClose
+			m.ackClose = false
 			if m.tabBar.Active() == TabAlerts && m.alertList.Selected() != nil {
-				return m, m.acknowledgeProblem(value)
+				return m, m.acknowledgeProblem(value, closeProblem)
 			}
 		case command.ModeCommand:
 			return m.executeCommand(value)
+		case command.ModeSearch:
+			return m, m.startSearch(value)
 		default:
 			// Other modes don't need special handling
 		}
@@ -880,11 +1647,87 @@ func (m Model) handleCommandInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// Forward to command input
+	before := m.commandInput.Value()
 	var cmd tea.Cmd
 	m.commandInput, cmd = m.commandInput.Update(msg)
+
+	// Filter as the user types, once typing pauses
+	if m.commandInput.Mode() == command.ModeFilter && m.commandInput.Value() != before {
+		m.filterSeq++
+		seq := m.filterSeq
+		cmd = tea.Batch(cmd, tea.Tick(FilterDebounce, func(_ time.Time) tea.Msg {
+			return FilterDebounceMsg{Seq: seq}
+		}))
+	}
 	return m, cmd
 }
 
+// handleFilterDebounceMsg applies the filter being typed once typing pauses.
+func (m Model) handleFilterDebounceMsg(msg FilterDebounceMsg) (tea.Model, tea.Cmd) {
+	if msg.Seq != m.filterSeq || m.commandInput.Mode() != command.ModeFilter {
+		return m, nil
+	}
+	return m, m.applyTextFilter(m.commandInput.Value())
+}
+
+// applyTextFilter filters the current tab's list by a query. A query that
+// doesn't parse leaves the filter as it is, and the error is shown in the
+// command bar. The plain words of lists larger than the server search
+// threshold, or with pages not loaded yet, are searched by the API instead
+// of in memory; the returned command reloads the tab when its search term
+// changes.
+func (m *Model) applyTextFilter(value string) tea.Cmd {
+	q, err := query.Parse(value)
+	if err != nil {
+		m.commandInput.SetError(err.Error())
+		return nil
+	}
+	m.commandInput.SetError("")
+	m.textFilter = value
+	tab := m.tabBar.Active()
+	switch tab {
+	case TabAlerts:
+		m.alertList.SetTextFilter(value)
+	case TabHosts:
+		m.hostList.SetTextFilter(value)
+	case TabEvents:
+		m.eventList.SetTextFilter(value)
+	default:
+		return nil
+	}
+	m.statusBar.SetFilter(m.minSeverity, m.textFilter)
+
+	// Only lists above the threshold are searched by the API, whose
+	// results the filter still narrows down
+	var search []string
+	threshold := m.config.GetServerSearchThreshold()
+	if words := q.Search(); words != nil && threshold > 0 && (m.serverSearch[tab] != nil || m.tabRows(tab) > threshold) {
+		search = words
+	}
+	if slices.Equal(search, m.serverSearch[tab]) || !m.connected {
+		return nil
+	}
+
+	m.serverSearch[tab] = search
+	m.statusBar.SetLoading(true)
+	return m.loadTab(tab)
+}
+
+// tabRows returns the number of unfiltered rows loaded for a tab.
+func (m *Model) tabRows(tab int) int {
+	switch tab {
+	case TabAlerts:
+		return len(m.problems)
+	case TabHosts:
+		return len(m.hosts)
+	case TabEvents:
+		return len(m.events)
+	case TabGraphs:
+		return len(m.items)
+	}
+	return 0
+}
+
 // executeCommand processes a command entered in command mode.
 func (m Model) executeCommand(cmd string) (tea.Model, tea.Cmd) {
 	switch {
@@ -899,15 +1742,211 @@ func (m Model) executeCommand(cmd string) (tea.Model, tea.Cmd) {
 		}
 	case cmd == "help":
 		m.showHelp = true
-		m.errorModal.ShowHelp()
+		m.errorModal.ShowHelp(m.keys.HelpSections())
+	case cmd == "tutorial":
+		m.StartTutorial()
 	case cmd == "ignores":
 		m.showIgnoresModal()
+	case cmd == "log":
+		m.showDebugLog()
+	case cmd == "notifications":
+		m.showNotifications()
+	case cmd == "stats":
+		m.showStats()
+	case cmd == "queue":
+		return m, m.loadQueues()
+	case cmd == "server-info":
+		return m, m.loadServerInfo()
+	case cmd == "settings":
+		return m, m.loadSettings()
+	case cmd == "role-filter" || strings.HasPrefix(cmd, "role-filter "):
+		return m, m.handleRoleFilterCommand(strings.TrimSpace(strings.TrimPrefix(cmd, "role-filter")))
+	case cmd == "search" || strings.HasPrefix(cmd, "search "):
+		return m.handleSearchCommand(strings.TrimSpace(strings.TrimPrefix(cmd, "search")))
+	case cmd == "why":
+		return m, m.explainTrigger()
+	case cmd == "export" || strings.HasPrefix(cmd, "export "):
+		return m, m.handleExportCommand(cmd)
+	case cmd == "export-maintenances" || strings.HasPrefix(cmd, "export-maintenances "):
+		return m, m.handleExportMaintenancesCommand(cmd)
+	case cmd == "export-template" || strings.HasPrefix(cmd, "export-template "):
+		return m, m.handleExportTemplateCommand(cmd)
+	case cmd == "import" || strings.HasPrefix(cmd, "import "):
+		return m, m.handleImportCommand(cmd)
+	case cmd == "heatmap" || strings.HasPrefix(cmd, "heatmap "):
+		return m, m.handleHeatmapCommand(cmd)
+	case cmd == "calendar" || strings.HasPrefix(cmd, "calendar "):
+		return m, m.handleCalendarCommand(cmd)
+	case cmd == "compare" || strings.HasPrefix(cmd, "compare "):
+		return m, m.handleCompareCommand(cmd)
+	case cmd == "export-chart" || strings.HasPrefix(cmd, "export-chart "):
+		return m, m.exportChart(strings.TrimSpace(strings.TrimPrefix(cmd, "export-chart")))
+	case cmd == "report" || strings.HasPrefix(cmd, "report "):
+		return m, m.handleReportCommand(cmd)
+	case cmd == "handover" || strings.HasPrefix(cmd, "handover "):
+		return m, m.handleHandoverCommand(cmd)
+	case cmd == "flapping" || strings.HasPrefix(cmd, "flapping "):
+		return m, m.handleFlappingCommand(cmd)
+	case cmd == "tokens" || strings.HasPrefix(cmd, "tokens "):
+		return m, m.handleTokensCommand(cmd)
+	case cmd == "debug" || strings.HasPrefix(cmd, "debug "):
+		m.handleDebugCommand(cmd)
+	case cmd == "window" || strings.HasPrefix(cmd, "window "):
+		return m, m.handleWindowCommand(cmd)
+	case cmd == "group-by" || strings.HasPrefix(cmd, "group-by "):
+		m.handleGroupByCommand(cmd)
+	case cmd == "muted" || strings.HasPrefix(cmd, "muted "):
+		m.handleMutedCommand(cmd)
+	case cmd == "highlight" || strings.HasPrefix(cmd, "highlight "):
+		m.handleHighlightCommand(cmd)
+	case cmd == "dashboard" || strings.HasPrefix(cmd, "dashboard "):
+		return m, m.handleDashboardCommand(cmd)
+	case cmd == "assign" || strings.HasPrefix(cmd, "assign ") || cmd == "unassign":
+		return m, m.handleAssignCommand(cmd)
 	case strings.HasPrefix(cmd, "unignore "):
 		return m.handleUnignoreCommand(cmd)
 	}
 	return m, nil
 }
 
+// handleGroupByCommand sets how the alerts list groups problems, e.g.
+// ":group-by tag:service", or on the Hosts tab whether hosts are shown
+// under their host groups.
+func (m *Model) handleGroupByCommand(cmd string) {
+	parts := strings.Fields(cmd)
+	if m.tabBar.Active() == TabHosts {
+		m.handleHostGroupBy(parts)
+		return
+	}
+	if len(parts) != 2 {
+		m.statusBar.SetStatus(fmt.Sprintf("Usage: :group-by trigger|host|tag:<name>|none (currently %s)",
+			m.alertList.GroupBy()))
+		return
+	}
+	by, err := alerts.ParseGroupBy(parts[1])
+	if err != nil {
+		m.statusBar.SetStatus(fmt.Sprintf("Group by: %v", err))
+		return
+	}
+	m.alertList.SetGroupBy(by)
+	if selected := m.alertList.Selected(); selected != nil {
+		m.setDetailProblem(selected)
+	}
+	if by == alerts.GroupNone {
+		m.statusBar.SetStatus("Alerts not grouped")
+		return
+	}
+	m.statusBar.SetStatus(fmt.Sprintf("Alerts grouped by %s", by))
+}
+
+// handleAssignCommand assigns the selected alerts to a user, by default the
+// current one, e.g. ":assign jdoe", or unassigns them with ":unassign".
+func (m *Model) handleAssignCommand(cmd string) tea.Cmd {
+	parts := strings.Fields(cmd)
+	switch {
+	case m.tabBar.Active() != TabAlerts || m.alertList.Selected() == nil:
+		m.statusBar.SetStatus("Select an alert to assign")
+		return nil
+	case !m.keys.AckMessage.Enabled():
+		return m.notify(notify.Error, "Your user role cannot add messages to problems")
+	case parts[0] == "unassign":
+		return m.assignProblems("")
+	case len(parts) > 2:
+		m.statusBar.SetStatus("Usage: :assign [USER]")
+		return nil
+	case len(parts) == 2:
+		return m.assignProblems(parts[1])
+	case m.username == "":
+		m.statusBar.SetStatus("Your username is unknown; use :assign USER")
+		return nil
+	}
+	return m.assignProblems(m.username)
+}
+
+// handleDebugCommand turns API call logging on or off.
+func (m *Model) handleDebugCommand(cmd string) {
+	parts := strings.Fields(cmd)
+	if len(parts) != 2 || (parts[1] != "on" && parts[1] != "off") {
+		state := "off"
+		if m.debugLog.Enabled() {
+			state = "on"
+		}
+		m.statusBar.SetStatus("Usage: :debug on|off (currently " + state + ")")
+		return
+	}
+
+	if err := m.SetDebug(parts[1] == "on"); err != nil {
+		m.statusBar.SetStatus(fmt.Sprintf("Debug log: %v", err))
+		return
+	}
+	if parts[1] == "off" {
+		m.statusBar.SetStatus("Debug logging disabled")
+		return
+	}
+	m.statusBar.SetStatus("Debug logging to " + m.debugLog.Path())
+}
+
+// showDebugLog displays the most recent API calls in a modal.
+func (m *Model) showDebugLog() {
+	entries := m.debugLog.Entries()
+	var lines []string
+	if len(entries) == 0 {
+		if m.debugLog.Enabled() {
+			lines = []string{"No API calls recorded yet."}
+		} else {
+			lines = []string{"Debug logging is off.", "", "Enable it with :debug on or start chotko with --debug."}
+		}
+	}
+	for _, e := range entries {
+		lines = append(lines, e.String())
+	}
+
+	m.showError = true
+	m.errorModal.ShowText("API Log", lines)
+}
+
+// showStats displays API latencies, load durations and runtime figures in a modal.
+func (m *Model) showStats() {
+	lines := []string{"API calls (p50/p95 over the last 100 calls per method)", ""}
+	summary := m.latencies.Summary()
+	if len(summary) == 0 {
+		lines = append(lines, "  No API calls yet")
+	} else {
+		lines = append(lines, fmt.Sprintf("  %-28s %6s %6s %8s %8s %8s", "METHOD", "CALLS", "ERRORS", "P50", "P95", "LAST"))
+		for _, s := range summary {
+			lines = append(lines, fmt.Sprintf("  %-28s %6d %6d %8s %8s %8s",
+				s.Method, s.Calls, s.Errors, roundMs(s.P50), roundMs(s.P95), roundMs(s.Last)))
+		}
+	}
+
+	lines = append(lines, "", fmt.Sprintf("Last refresh (interval %s)", m.refreshInterval), "")
+	for tab := range TabCount {
+		took := "-"
+		if !m.tabLoaded[tab].IsZero() {
+			took = fmt.Sprintf("%s at %s", roundMs(m.loadDurations[tab]), m.timeFormat.Clock(m.tabLoaded[tab]))
+		}
+		lines = append(lines, fmt.Sprintf("  %-10s %s", tabNames[tab], took))
+	}
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	lines = append(lines, "", "Data & runtime", "",
+		fmt.Sprintf("  Problems %d  Hosts %d  Events %d  Items %d",
+			len(m.problems), len(m.hosts), len(m.events), len(m.items)),
+		fmt.Sprintf("  Goroutines %d  Heap %s  Sys %s  GC cycles %d",
+			runtime.NumGoroutine(), format.Bytes(float64(mem.HeapAlloc)), format.Bytes(float64(mem.Sys)), mem.NumGC),
+		"  Uptime "+format.Duration(time.Since(m.startedAt)),
+	)
+
+	m.showError = true
+	m.errorModal.ShowText("Stats", lines)
+}
+
+// roundMs rounds a duration to milliseconds for display.
+func roundMs(d time.Duration) time.Duration {
+	return d.Round(time.Millisecond)
+}
+
 // handleUnignoreCommand removes an ignore rule by index.
 func (m Model) handleUnignoreCommand(cmd string) (tea.Model, tea.Cmd) {
 	// Parse the index from "unignore N"
@@ -978,36 +2017,69 @@ func (m *Model) cycleFocus(direction int) {
 
 // handleMouseMsg processes mouse input.
 func (m Model) handleMouseMsg(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
-	// Scroll speed: 3 lines per wheel tick
-	const scrollSpeed = 3
-
-	// Handle scroll wheel - scrolls pane under mouse, not focused pane
-	switch msg.Button {
-	case tea.MouseButtonWheelUp:
+	// Scroll speed: 3 lines or 8 columns per wheel tick
+	const (
+		scrollSpeed           = 3
+		horizontalScrollSpeed = 8
+	)
+
+	// Handle scroll wheel - scrolls pane under mouse, not focused pane.
+	// Shift+wheel, or a horizontal wheel, scrolls the detail pane sideways
+	switch {
+	case msg.Button == tea.MouseButtonWheelLeft || msg.Shift && msg.Button == tea.MouseButtonWheelUp:
+		m.handleHorizontalScroll(-horizontalScrollSpeed, msg.X, msg.Y)
+		return m, nil
+	case msg.Button == tea.MouseButtonWheelRight || msg.Shift && msg.Button == tea.MouseButtonWheelDown:
+		m.handleHorizontalScroll(horizontalScrollSpeed, msg.X, msg.Y)
+		return m, nil
+	case msg.Button == tea.MouseButtonWheelUp:
 		return m.handleScroll(-scrollSpeed, msg.X, msg.Y)
-	case tea.MouseButtonWheelDown:
+	case msg.Button == tea.MouseButtonWheelDown:
 		return m.handleScroll(scrollSpeed, msg.X, msg.Y)
-	default:
-		// Other mouse buttons handled below or ignored
 	}
 
-	// Handle left click release (not press, to avoid double-firing)
+	// Dragging over alerts marks them for bulk actions
+	if msg.Button == tea.MouseButtonLeft {
+		switch msg.Action {
+		case tea.MouseActionPress:
+			m.startDrag(msg.X, msg.Y)
+			return m, nil
+		case tea.MouseActionMotion:
+			m.dragTo(msg.X, msg.Y)
+			return m, nil
+		}
+	}
+
+	// Handle left click release (not press, to avoid double-firing),
+	// unless it ends a drag
 	if msg.Action == tea.MouseActionRelease && msg.Button == tea.MouseButtonLeft {
+		moved := m.drag.moved
+		m.drag = dragState{}
+		if moved {
+			return m, nil
+		}
 		return m.handleClick(msg.X, msg.Y)
 	}
 
+	// Right click opens the context menu; the press is used, as not all
+	// terminals report which button was released
+	if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonRight {
+		return m.handleRightClick(msg.X, msg.Y)
+	}
+
 	return m, nil
 }
 
 // handleScroll handles scroll wheel events, scrolling the pane under the mouse.
 func (m Model) handleScroll(delta, mouseX, mouseY int) (tea.Model, tea.Cmd) {
 	// Check if mouse is in content area (not in status bar, tab bar, or command bar)
-	if mouseY < m.contentY || mouseY >= m.contentY+m.contentHeight {
+	pane, ok := m.paneAt(mouseX, mouseY)
+	if !ok {
 		return m, nil
 	}
 
-	// Determine which pane the mouse is over and scroll it
-	if mouseX < m.listPaneWidth {
+	// Scroll the pane under the mouse
+	if pane == PaneList {
 		// Mouse is over list pane - scroll the current tab's list
 		switch m.tabBar.Active() {
 		case TabAlerts:
@@ -1024,23 +2096,76 @@ func (m Model) handleScroll(delta, mouseX, mouseY int) (tea.Model, tea.Cmd) {
 		m.detailPane.Scroll(delta)
 	}
 
-	return m, nil
+	return m, m.maybeLoadMore()
+}
+
+// handleHorizontalScroll scrolls the detail pane sideways when the mouse
+// is over it.
+func (m *Model) handleHorizontalScroll(delta, mouseX, mouseY int) {
+	if pane, ok := m.paneAt(mouseX, mouseY); ok && pane == PaneDetail {
+		m.detailPane.ScrollHorizontal(delta)
+	}
+}
+
+// startDrag records the alert row a left button press is on, where a drag
+// over the Alerts list starts.
+func (m *Model) startDrag(mouseX, mouseY int) {
+	m.drag = dragState{}
+	if m.tabBar.Active() != TabAlerts {
+		return
+	}
+	if row, ok := m.rowAt(mouseX, mouseY); ok {
+		m.drag = dragState{from: row, active: true}
+	}
+}
+
+// dragTo marks the alerts from the row the drag started on to the row
+// under the mouse.
+func (m *Model) dragTo(mouseX, mouseY int) {
+	if !m.drag.active {
+		return
+	}
+	row, ok := m.rowAt(mouseX, mouseY)
+	if !ok || !m.drag.moved && row == m.drag.from {
+		return
+	}
+	m.drag.moved = true
+	m.alertList.MarkRange(m.drag.from, row)
+	if selected := m.alertList.Selected(); selected != nil {
+		m.setDetailProblem(selected)
+	}
 }
 
 // handleClick handles left mouse button clicks.
 func (m Model) handleClick(mouseX, mouseY int) (tea.Model, tea.Cmd) {
 	// Check for tab clicks using zone detection
-	for i := 0; i < 4; i++ {
+	for i := range TabCount {
 		tabID := fmt.Sprintf("tab_%d", i)
 		if zone.Get(tabID).InBounds(tea.MouseMsg{X: mouseX, Y: mouseY}) {
 			return m.switchTab(i)
 		}
 	}
 
+	// Clicking an alert count in the status bar shows alerts of that
+	// severity and above; clicking the filter indicator clears the filters
+	for sev := 1; sev <= 5; sev++ {
+		if zone.Get(fmt.Sprintf("status_severity_%d", sev)).InBounds(tea.MouseMsg{X: mouseX, Y: mouseY}) {
+			m.setMinSeverity(sev)
+			if m.tabBar.Active() != TabAlerts {
+				return m.switchTab(TabAlerts)
+			}
+			return m, nil
+		}
+	}
+	if zone.Get("status_filter").InBounds(tea.MouseMsg{X: mouseX, Y: mouseY}) {
+		model, cmd, _ := m.handleClearFilter()
+		return model, cmd
+	}
+
 	// Check if click is in content area
-	if mouseY >= m.contentY && mouseY < m.contentY+m.contentHeight {
+	if pane, ok := m.paneAt(mouseX, mouseY); ok {
 		// Determine which pane was clicked and set focus
-		if mouseX < m.listPaneWidth {
+		if pane == PaneList {
 			// Clicked on list pane
 			if m.focused != PaneList {
 				m.setFocus(PaneList)
@@ -1059,53 +2184,70 @@ func (m Model) handleClick(mouseX, mouseY int) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleListClick handles clicks on list items.
+// handleListClick handles clicks on list items. A second click on the same
+// row opens it.
 func (m Model) handleListClick(mouseX, mouseY int) (tea.Model, tea.Cmd) {
+	if m.tabBar.Active() == TabGraphs {
+		// Check for graph tree node clicks
+		return m.handleGraphsClick(mouseX, mouseY)
+	}
+
+	row, ok := m.rowAt(mouseX, mouseY)
+	if !ok {
+		return m, nil
+	}
+	if m.isDoubleClick(row, time.Now()) {
+		return m.openRow()
+	}
 	switch m.tabBar.Active() {
 	case TabAlerts:
-		// Check for alert row clicks
-		for i := 0; i < m.alertList.FilteredCount(); i++ {
-			rowID := fmt.Sprintf("alert_%d", i)
-			if zone.Get(rowID).InBounds(tea.MouseMsg{X: mouseX, Y: mouseY}) {
-				m.alertList.SetCursor(i)
-				if selected := m.alertList.Selected(); selected != nil {
-					m.detailPane.SetProblem(selected)
-				}
-				return m, nil
-			}
+		m.alertList.ClearMarks()
+		m.alertList.ClickRow(row)
+		if selected := m.alertList.Selected(); selected != nil {
+			m.setDetailProblem(selected)
 		}
 	case TabHosts:
-		// Check for host row clicks
-		for i := 0; i < m.hostList.FilteredCount(); i++ {
-			rowID := fmt.Sprintf("host_%d", i)
-			if zone.Get(rowID).InBounds(tea.MouseMsg{X: mouseX, Y: mouseY}) {
-				m.hostList.SetCursor(i)
-				if selected := m.hostList.Selected(); selected != nil {
-					m.detailPane.SetHost(selected)
-				}
-				return m, nil
-			}
+		m.hostList.ClickRow(row)
+		if selected := m.hostList.Selected(); selected != nil {
+			m.detailPane.SetHost(selected)
 		}
 	case TabEvents:
-		// Check for event row clicks
-		for i := 0; i < m.eventList.FilteredCount(); i++ {
-			rowID := fmt.Sprintf("event_%d", i)
-			if zone.Get(rowID).InBounds(tea.MouseMsg{X: mouseX, Y: mouseY}) {
-				m.eventList.SetCursor(i)
-				if selected := m.eventList.Selected(); selected != nil {
-					m.detailPane.SetEvent(selected)
-				}
-				return m, nil
-			}
+		m.eventList.ClickRow(row)
+		if selected := m.eventList.Selected(); selected != nil {
+			m.detailPane.SetEvent(selected)
 		}
-	case TabGraphs:
-		// Check for graph tree node clicks
-		return m.handleGraphsClick(mouseX, mouseY)
+	case TabSearch:
+		m.searchList.ClickRow(row)
+		m.setDetailSearchResult()
 	}
-
 	return m, nil
 }
 
+// rowAt returns the index of the list row at a screen position on the
+// Alerts, Hosts, Events and Search tabs.
+func (m *Model) rowAt(mouseX, mouseY int) (int, bool) {
+	var prefix string
+	var count int
+	switch m.tabBar.Active() {
+	case TabAlerts:
+		prefix, count = "alert", m.alertList.FilteredCount()
+	case TabHosts:
+		prefix, count = "host", m.hostList.FilteredCount()
+	case TabEvents:
+		prefix, count = "event", m.eventList.FilteredCount()
+	case TabSearch:
+		prefix, count = "search", m.searchList.FilteredCount()
+	default:
+		return 0, false
+	}
+	for i := range count {
+		if zone.Get(fmt.Sprintf("%s_%d", prefix, i)).InBounds(tea.MouseMsg{X: mouseX, Y: mouseY}) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
 // handleGraphsClick handles clicks on the graphs tree view.
 func (m Model) handleGraphsClick(mouseX, mouseY int) (tea.Model, tea.Cmd) {
 	// Check for tree node clicks
@@ -1119,7 +2261,7 @@ func (m Model) handleGraphsClick(mouseX, mouseY int) (tea.Model, tea.Cmd) {
 				history := m.graphList.GetHistory(selected.ItemID)
 				m.detailPane.SetItem(selected, history)
 			}
-			return m, cmd
+			return m, tea.Batch(cmd, m.maybeLoadBaselines())
 		}
 	}
 	return m, nil
@@ -1157,10 +2299,27 @@ func (m Model) handleEditorUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.showEditor = false
 		return m, m.toggleTrigger(msg.TriggerID, msg.Enable, msg.HostID)
 
+	case editor.TriggersToggleMsg:
+		// Marked triggers enable/disable request
+		m.editorPane.Hide()
+		m.showEditor = false
+		return m, m.toggleTriggers(msg.TriggerIDs, msg.Enable)
+
 	case editor.MacroEditedMsg:
-		// Macro value changed
+		// Macro value changed, or an inherited macro overridden on the host
+		if msg.MacroID == "" {
+			return m, m.createHostMacro(msg.HostID, msg.Macro, msg.NewValue)
+		}
 		return m, m.updateHostMacro(msg.MacroID, msg.NewValue, msg.HostID)
 
+	case editor.MuteRulesChangedMsg:
+		return m.handleMuteRulesChangedMsg(msg)
+
+	case editor.InterfaceEditedMsg:
+		m.editorPane.Hide()
+		m.showEditor = false
+		return m, m.updateInterface(msg.HostID, msg.Interface)
+
 	case editor.MacroDeleteMsg:
 		// Macro delete request
 		m.editorPane.Hide()
@@ -1,12 +1,46 @@
 package app
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone"
+
+	"github.com/harpchad/chotko/internal/components/alerts"
+	"github.com/harpchad/chotko/internal/components/dashboard"
+	"github.com/harpchad/chotko/internal/components/detail"
+	"github.com/harpchad/chotko/internal/components/editor"
+	"github.com/harpchad/chotko/internal/components/hosts"
+	"github.com/harpchad/chotko/internal/components/notify"
+	"github.com/harpchad/chotko/internal/components/search"
 	"github.com/harpchad/chotko/internal/config"
+	"github.com/harpchad/chotko/internal/demo"
+	"github.com/harpchad/chotko/internal/report"
+	"github.com/harpchad/chotko/internal/session"
 	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
 )
 
+// TestMain initializes the zone manager for tests that call View().
+func TestMain(m *testing.M) {
+	zone.NewGlobal()
+	os.Exit(m.Run())
+}
+
 // testConfig returns a minimal config for testing.
 func testConfig() *config.Config {
 	return &config.Config{
@@ -138,3 +172,3294 @@ func TestRefreshTickMsg_AlreadyLoading(t *testing.T) {
 		t.Error("RefreshTickMsg should always return a command for the next tick")
 	}
 }
+
+// TestClockTickMsg verifies the status bar clock, countdown, and on-call info
+// are rendered and that the clock keeps ticking while modals are open.
+func TestClockTickMsg(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Display.ShowClock = true
+	cfg.Display.ShowCountdown = true
+	cfg.Display.OnCall = "alice"
+	cfg.Display.Timezone = "UTC"
+	m := New(cfg, theme.DefaultTheme())
+	m.SetSize(200, 40)
+	m.connected = true
+	m.statusBar.SetConnected(true, "7.0")
+	m.showHelp = true
+
+	now := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+	m.nextRefresh = now.Add(12 * time.Second)
+
+	newModel, cmd := m.Update(ClockTickMsg{Time: now})
+	updated, ok := newModel.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", newModel)
+	}
+	if cmd == nil {
+		t.Error("expected clock to schedule the next tick")
+	}
+
+	view := updated.statusBar.View()
+	for _, want := range []string{"15:04:05", "Next: 12s", "on-call: alice"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("status bar missing %q: %s", want, view)
+		}
+	}
+}
+
+// TestReconnectDelay verifies the exponential backoff schedule.
+func TestReconnectDelay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 2 * time.Second},
+		{1, 4 * time.Second},
+		{3, 16 * time.Second},
+		{20, ReconnectMaxDelay},
+	}
+
+	for _, tt := range tests {
+		if got := reconnectDelay(tt.attempt); got != tt.want {
+			t.Errorf("reconnectDelay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+// TestLoadErrors_Kinds verifies that an expired session reconnects at once,
+// while refused and rate-limited loads don't count towards reconnecting and
+// an unavailable server does.
+func TestLoadErrors_Kinds(t *testing.T) {
+	t.Parallel()
+
+	expired := &zabbix.APIError{Code: -32602, Message: "Invalid params.", Data: "Session terminated, re-login, please."}
+	refused := &zabbix.APIError{Code: -32500, Message: "Application error.", Data: "No permissions to call \"problem.get\"."}
+	tests := []struct {
+		name         string
+		err          error
+		reconnecting bool
+	}{
+		{"expired session", expired, true},
+		{"no permissions", refused, false},
+		{"rate limited", &zabbix.StatusError{Code: 429}, false},
+		{"unavailable", &zabbix.StatusError{Code: 503}, true},
+	}
+	for _, tt := range tests {
+		m := New(testConfig(), theme.DefaultTheme())
+		m.connected = true
+		m.version = "7.0"
+
+		var model tea.Model = *m
+		for range MaxRefreshFailures {
+			model, _ = model.Update(ProblemsLoadedMsg{Err: fmt.Errorf("failed to get problems: %w", tt.err)})
+			if model.(Model).reconnecting {
+				break
+			}
+		}
+		updated := model.(Model)
+		if updated.reconnecting != tt.reconnecting {
+			t.Errorf("%s: reconnecting = %v, want %v", tt.name, updated.reconnecting, tt.reconnecting)
+		}
+		if !tt.reconnecting && updated.failures != 0 {
+			t.Errorf("%s: counted %d failures, want none", tt.name, updated.failures)
+		}
+	}
+}
+
+// TestLoadErrors_StartReconnect verifies that repeated load failures switch to
+// the reconnect loop with a banner instead of a blocking modal, keeping the
+// last known data.
+func TestLoadErrors_StartReconnect(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.connected = true
+	m.version = "7.0"
+	m.problems = []zabbix.Problem{{EventID: "1", Name: "CPU high"}}
+	m.alertList.SetProblems(m.problems)
+
+	var model tea.Model = *m
+	for i := range MaxRefreshFailures {
+		var cmd tea.Cmd
+		model, cmd = model.Update(ProblemsLoadedMsg{Err: errors.New("timeout")})
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		if updated.showError {
+			t.Fatalf("failure %d: expected no blocking modal", i+1)
+		}
+		if i == MaxRefreshFailures-1 && cmd == nil {
+			t.Error("expected reconnect tick to be scheduled")
+		}
+	}
+
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.connected || !updated.reconnecting {
+		t.Errorf("expected disconnected and reconnecting, got connected=%v reconnecting=%v",
+			updated.connected, updated.reconnecting)
+	}
+	if !strings.Contains(updated.statusBar.Banner(), "DISCONNECTED") {
+		t.Errorf("expected disconnected banner, got %q", updated.statusBar.Banner())
+	}
+	if len(updated.problems) != 1 {
+		t.Error("expected last known problems to be kept")
+	}
+
+	// A successful reconnect clears the banner
+	model, _ = updated.Update(ConnectedMsg{Version: "7.0"})
+	updated, ok = model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.reconnecting || updated.statusBar.Banner() != "" {
+		t.Error("expected reconnect to clear the banner")
+	}
+}
+
+// TestUpdateStale verifies per-tab stale detection based on the last load.
+func TestUpdateStale(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	now := time.Now()
+
+	if m.isStale(TabAlerts, now) {
+		t.Error("never-loaded tab should not be stale")
+	}
+
+	m.tabLoaded[TabAlerts] = now.Add(-m.refreshInterval)
+	if m.isStale(TabAlerts, now) {
+		t.Error("data within the refresh window should not be stale")
+	}
+
+	m.tabLoaded[TabAlerts] = now.Add(-5 * time.Minute)
+	if !m.isStale(TabAlerts, now) {
+		t.Error("data older than the stale threshold should be stale")
+	}
+}
+
+// TestPingLatency verifies that health check results are shown in the
+// status bar, and hidden when the check fails.
+func TestPingLatency(t *testing.T) {
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(200, 40)
+	m.statusBar.SetConnected(true, "7.0.0")
+
+	var model tea.Model = *m
+	model, _ = model.Update(PingResultMsg{Latency: 42 * time.Millisecond})
+	if view := ansi.Strip(model.(Model).statusBar.View()); !strings.Contains(view, "42ms") {
+		t.Errorf("status bar = %q, want the 42ms latency", view)
+	}
+	model, _ = model.Update(PingResultMsg{Latency: 2500 * time.Millisecond})
+	if view := ansi.Strip(model.(Model).statusBar.View()); !strings.Contains(view, "2.5s") {
+		t.Errorf("status bar = %q, want the 2.5s latency", view)
+	}
+	model, _ = model.Update(PingResultMsg{Err: errors.New("timeout")})
+	if view := ansi.Strip(model.(Model).statusBar.View()); strings.Contains(view, "2.5s") {
+		t.Errorf("status bar = %q, want the latency hidden after a failed check", view)
+	}
+}
+
+// TestPermissions verifies that actions the user's role doesn't allow are
+// hidden from the context menu, and refused with a notification.
+func TestPermissions(t *testing.T) {
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+	m.tabBar.SetActive(TabHosts)
+	m.hostList.SetHosts([]zabbix.Host{{HostID: "1", Host: "web01"}})
+
+	var model tea.Model = *m
+	model, _ = model.Update(ConnectedMsg{Version: "7.0", Permissions: &zabbix.Permissions{
+		UserType: zabbix.UserTypeUser, Acknowledge: true, CheckNow: true,
+	}})
+	updated := model.(Model)
+	if !updated.keys.Acknowledge.Enabled() || updated.keys.EditTriggers.Enabled() {
+		t.Error("expected acknowledging enabled and trigger editing disabled")
+	}
+	var labels []string
+	for _, item := range updated.contextMenuItems() {
+		labels = append(labels, item.Label)
+	}
+	if slices.Contains(labels, "Edit triggers") || !slices.Contains(labels, "Check host or item now") {
+		t.Errorf("context menu = %v, want checks but no trigger editing", labels)
+	}
+
+	model, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	updated = model.(Model)
+	if updated.showEditor {
+		t.Error("expected the trigger editor to stay closed")
+	}
+	if view := ansi.Strip(updated.notifications.View()); !strings.Contains(view, "cannot edit triggers") {
+		t.Errorf("notifications = %q, want the refused action", view)
+	}
+
+	// Unknown permissions allow everything again
+	model, _ = updated.Update(ConnectedMsg{Version: "7.0"})
+	if !model.(Model).keys.EditTriggers.Enabled() {
+		t.Error("expected trigger editing enabled without known permissions")
+	}
+}
+
+// TestNotifications verifies that action results are notified and listed
+// by :notifications.
+func TestNotifications(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+	m.errorModal.SetScreenSize(120, 40)
+
+	var model tea.Model = *m
+	model, cmd := model.Update(AcknowledgeResultMsg{Count: 3, Success: true})
+	if cmd == nil {
+		t.Fatal("expected the notification to expire and problems to reload")
+	}
+	model, _ = model.Update(MacroUpdateResultMsg{MacroID: "1", Action: "update", Err: errors.New("permission denied")})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.showError {
+		t.Error("a failed action should not open a modal")
+	}
+	view := ansi.Strip(updated.View())
+	for _, want := range []string{"Acknowledged 3 problems", "Could not update the macro: permission denied"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should show %q:\n%s", want, view)
+		}
+	}
+
+	model, _ = updated.Update(notify.ExpireMsg{ID: updated.notifications.Shown()[0].ID})
+	updated, ok = model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if strings.Contains(updated.View(), "Acknowledged 3 problems") {
+		t.Error("an expired notification should leave the drawer")
+	}
+
+	model, _ = updated.executeCommand("notifications")
+	updated, ok = model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	history := updated.errorModal.View()
+	if !updated.showError || !strings.Contains(history, "done  Acknowledged 3 problems") ||
+		!strings.Contains(history, "error Could not update the macro") {
+		t.Errorf(":notifications should list both results:\n%s", history)
+	}
+}
+
+// TestStatsCommand verifies that :stats shows API latencies and load durations.
+func TestStatsCommand(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.errorModal.SetScreenSize(120, 40)
+	m.latencies.Record("problem.get", 120*time.Millisecond, false)
+
+	var model tea.Model = *m
+	model, _ = model.Update(ProblemsLoadedMsg{Problems: []zabbix.Problem{{EventID: "1"}}, Duration: 250 * time.Millisecond})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+
+	model, _ = updated.executeCommand("stats")
+	updated, ok = model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if !updated.showError {
+		t.Fatal("expected stats modal to be shown")
+	}
+
+	view := updated.errorModal.View()
+	for _, want := range []string{"problem.get", "120ms", "250ms", "Problems 1", "Goroutines"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("stats view missing %q", want)
+		}
+	}
+}
+
+// TestProblemTrend verifies that each refresh adds its alert count to the
+// status bar sparkline, keeping the configured number of refreshes.
+func TestProblemTrend(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Display.ProblemTrend = 3
+	m := New(cfg, theme.DefaultTheme())
+
+	var model tea.Model = *m
+	for n := 1; n <= 4; n++ {
+		problems := make([]zabbix.Problem, n)
+		for i := range problems {
+			problems[i] = zabbix.Problem{EventID: strconv.Itoa(i), Severity: "4"}
+		}
+		model, _ = model.Update(ProblemsLoadedMsg{Problems: problems})
+	}
+	model, _ = model.Update(ProblemsLoadedMsg{Problems: []zabbix.Problem{{EventID: "9"}}, Append: true})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if want := []int{2, 3, 4}; !slices.Equal(updated.problemTrend, want) {
+		t.Errorf("problemTrend = %v, want %v", updated.problemTrend, want)
+	}
+	if view := updated.statusBar.View(); !strings.Contains(view, "▄▆█") {
+		t.Errorf("status bar should show the trend:\n%s", view)
+	}
+}
+
+// TestQueuesLoaded verifies that the queue is shown by server or proxy and
+// delay.
+func TestQueuesLoaded(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.errorModal.SetScreenSize(120, 40)
+
+	var model tea.Model = *m
+	model, _ = model.Update(QueuesLoadedMsg{Queues: []zabbix.Queue{{
+		Host: zabbix.Host{Host: "zabbix-server"},
+		Buckets: []zabbix.QueueBucket{
+			{From: zabbix.DefaultQueueDelay, Count: 42},
+			{From: 10 * time.Minute, Count: 3},
+		},
+	}}})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if !updated.showError {
+		t.Fatal("expected the queue to be shown")
+	}
+	view := updated.errorModal.View()
+	for _, want := range []string{"zabbix-server", "6s+ 42", "10m+ 3"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("queue view missing %q:\n%s", want, view)
+		}
+	}
+
+	model, _ = New(testConfig(), theme.DefaultTheme()).Update(QueuesLoadedMsg{})
+	if updated, ok = model.(Model); !ok || updated.showError {
+		t.Error("no queue items should only be reported in the status bar")
+	}
+}
+
+func TestFlappingLoaded(t *testing.T) {
+	t.Parallel()
+
+	window, threshold, ok := parseFlappingArgs([]string{"10", "1d"}, 6*time.Hour)
+	if !ok || window != 24*time.Hour || threshold != 10 {
+		t.Errorf("parseFlappingArgs = %v, %d, %v; want 1d, 10", window, threshold, ok)
+	}
+	if _, _, ok := parseFlappingArgs([]string{"often"}, 6*time.Hour); ok {
+		t.Error("parseFlappingArgs should reject a word")
+	}
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+	m.errorModal.SetScreenSize(120, 40)
+	model, _ := m.Update(FlappingLoadedMsg{Window: 6 * time.Hour, Threshold: 5, Flaps: []report.Flap{
+		{TriggerID: "1", Host: "web01", Name: "CPU load", Severity: 2, Count: 12, MeanDuration: 90 * time.Second},
+		{TriggerID: "2", Host: "db01", Name: "Ping loss", Severity: 4, Count: 7},
+	}})
+	updated := model.(Model)
+	if !updated.showError {
+		t.Fatal("expected the flapping triggers to be shown")
+	}
+	view := updated.errorModal.View()
+	for _, want := range []string{"more than 5 problems in the last 6h", "web01: CPU load", "1m ", "db01: Ping loss"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("flapping view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Index(view, "CPU load") > strings.Index(view, "Ping loss") {
+		t.Error("the trigger with the most problems should come first")
+	}
+
+	model, _ = New(testConfig(), theme.DefaultTheme()).Update(FlappingLoadedMsg{Window: time.Hour, Threshold: 5})
+	if model.(Model).showError {
+		t.Error("no flapping trigger should only be reported in the status bar")
+	}
+}
+
+func TestHeatmapLoaded(t *testing.T) {
+	t.Parallel()
+
+	req, err := parseHeatmapArgs([]string{"Web*", "problems", "1d"}, 3*time.Hour)
+	if err != nil || !req.problems || req.window != 24*time.Hour || req.pattern != "web*" {
+		t.Errorf("parseHeatmapArgs = %+v, %v; want problems of web* in 1d", req, err)
+	}
+	if _, err := parseHeatmapArgs([]string{"web*", "db*"}, 3*time.Hour); err == nil {
+		t.Error("parseHeatmapArgs should reject two host patterns")
+	}
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+	m.errorModal.SetScreenSize(120, 40)
+	till := time.Now()
+	from := till.Add(-time.Hour)
+	model, _ := m.Update(HeatmapLoadedMsg{From: from, Till: till, Spans: []report.Span{
+		{Host: "web01", Severity: 4, Start: from, End: from.Add(10 * time.Minute)},
+		{Host: "db01", Severity: 2, Start: from.Add(30 * time.Minute), End: till},
+	}})
+	updated := model.(Model)
+	if !updated.showError {
+		t.Fatal("expected the heatmap to be shown")
+	}
+	view := updated.errorModal.View()
+	for _, want := range []string{"Problems of 2 hosts in the last 1h", "db01", "web01", "none"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("heatmap view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Index(view, "db01") > strings.Index(view, "web01") {
+		t.Error("the hosts should be sorted by name")
+	}
+
+	cells := worstSeverities([]report.Span{
+		{Severity: 2, Start: from, End: from.Add(40 * time.Minute)},
+		{Severity: 4, Start: from.Add(20 * time.Minute), End: from.Add(25 * time.Minute)},
+	}, from, till, 4)
+	if cells[0] != 2 || cells[1] != 4 || cells[2] != 2 || !math.IsNaN(cells[3]) {
+		t.Errorf("worstSeverities() = %v, want [2 4 2 NaN]", cells)
+	}
+
+	item := zabbix.Item{ItemID: "1", Name: "CPU idle", Key: "system.cpu.util[,idle]", ValueType: zabbix.ItemValueTypeFloat, Units: "%"}
+	point := func(offset time.Duration, value string) zabbix.History {
+		return zabbix.History{Clock: strconv.FormatInt(till.Add(-offset).Unix(), 10), Value: value}
+	}
+	web, db := item, item
+	web.Hosts, db.Hosts = []zabbix.Host{{Host: "web01"}}, []zabbix.Host{{Host: "db01"}}
+	model, _ = m.Update(HeatmapLoadedMsg{From: from, Till: till, Item: &item, Series: []detail.Series{
+		{Item: db, History: []zabbix.History{point(50*time.Minute, "20"), point(10*time.Minute, "40")}},
+		{Item: web, History: []zabbix.History{point(30*time.Minute, "95")}},
+	}})
+	view = model.(Model).errorModal.View()
+	for _, want := range []string{"CPU idle (system.cpu.util[,idle]) on 2 hosts, the last 1h", "░ 20.0%", "█ 95.0%", "web01"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("item heatmap view missing %q:\n%s", want, view)
+		}
+	}
+
+	model, _ = New(testConfig(), theme.DefaultTheme()).Update(HeatmapLoadedMsg{From: from, Till: till})
+	if model.(Model).showError {
+		t.Error("no problems should only be reported in the status bar")
+	}
+}
+
+func TestCalendarLoaded(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+	m.errorModal.SetScreenSize(120, 40)
+	y, mo, d := time.Now().Date()
+	from := time.Date(y, mo, d, 12, 0, 0, 0, time.Local)
+	till := from.AddDate(0, 0, 14)
+	unix := func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) }
+	maintenances := []zabbix.Maintenance{
+		{
+			MaintenanceID: "1", Name: "Nightly backup", MaintenanceType: zabbix.MaintenanceWithData,
+			ActiveSince: unix(from.AddDate(0, 0, -1)), ActiveTill: unix(from.AddDate(0, 0, 3)),
+			TimePeriods: []zabbix.TimePeriod{{TimePeriodType: zabbix.TimePeriodDaily, StartTime: "3600", Period: "3600"}},
+		},
+		{
+			MaintenanceID: "2", Name: "Rack move", MaintenanceType: zabbix.MaintenanceNoData,
+			ActiveSince: unix(from), ActiveTill: unix(till),
+			TimePeriods: []zabbix.TimePeriod{{TimePeriodType: zabbix.TimePeriodOnce, StartDate: unix(from.Add(time.Hour)), Period: "7200"}},
+		},
+		{MaintenanceID: "3", Name: "Last year", ActiveSince: unix(from.AddDate(-1, 0, 0)), ActiveTill: unix(from.AddDate(0, -11, 0))},
+	}
+	model, _ := m.Update(CalendarLoadedMsg{Host: zabbix.Host{Name: "web01"}, Maintenances: maintenances, From: from, Till: till})
+	updated := model.(Model)
+	if !updated.showError {
+		t.Fatal("expected the calendar to be shown")
+	}
+	view := ansi.Strip(updated.errorModal.View())
+	for _, want := range []string{
+		"Maintenance of web01 in the next 14d", "Next: Rack move in 1h", "Mo Tu We Th Fr Sa Su",
+		"13:00 - 15:00  2h 0m   Rack move  no data", "01:00 - 02:00  1h 0m   Nightly backup", "Nightly backup: daily at 01:00",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("calendar view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Last year") {
+		t.Error("maintenances without windows in the calendar should be left out")
+	}
+	// The backup runs on the next three nights
+	if n := strings.Count(view, "Nightly backup"); n != 4 {
+		t.Errorf("calendar shows %d lines of the backup, want 3 windows and its schedule:\n%s", n, view)
+	}
+
+	model, _ = m.Update(CalendarLoadedMsg{Host: zabbix.Host{Name: "web01"}, Maintenances: maintenances[2:], From: from, Till: till})
+	if updated = model.(Model); updated.showError || !strings.Contains(updated.statusBar.View(), "No maintenance of web01 in the next 14d") {
+		t.Error("a host without maintenance windows should be told in the status bar")
+	}
+}
+
+func TestCalendarTimezone(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Display.Timezone = "UTC"
+	m := New(cfg, theme.DefaultTheme())
+	m.problems = []zabbix.Problem{{EventID: "1", Hosts: []zabbix.Host{{HostID: "10084", Name: "web01"}}}}
+	m.alertList.SetProblems(m.problems)
+
+	cmd := m.handleCalendarCommand("calendar")
+	if cmd == nil {
+		t.Fatal("expected a command to load the maintenances")
+	}
+	if msg, ok := cmd().(CalendarLoadedMsg); !ok || msg.From.Location() != time.UTC {
+		t.Errorf("calendar = %+v, want its days in the display timezone", msg)
+	}
+}
+
+func TestExportMaintenances(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	model, cmd := m.executeCommand("export-maintenances maintenances.csv")
+	if updated := model.(Model); cmd != nil || !strings.Contains(updated.statusBar.View(), "Usage: :export-maintenances") {
+		t.Error("exporting to a file other than .ics should show the usage")
+	}
+
+	_, cmd = m.executeCommand("export-maintenances")
+	if cmd == nil {
+		t.Fatal("expected a command to export the maintenances")
+	}
+	if msg, ok := cmd().(ConfigurationExportedMsg); !ok || msg.Name != "maintenances" || msg.Err != nil {
+		t.Errorf("export without a client = %+v, want nothing exported", msg)
+	}
+}
+
+func TestServerInfoLoaded(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.errorModal.SetScreenSize(120, 60)
+
+	var model tea.Model = *m
+	model, _ = model.Update(ServerInfoLoadedMsg{Info: &zabbix.ServerInfo{
+		Version: "6.4.12", Hosts: 42, Items: 1200, Triggers: 310,
+		Servers: []zabbix.ServerStats{{Host: zabbix.Host{Host: "Zabbix server"}, NVPS: 135.2, Items: 1300, UnsupportedItems: 17}},
+		Housekeeping: &zabbix.Housekeeping{
+			EventsMode: "1", EventsTrigger: "365d", HistoryMode: "1", HistoryGlobal: "1", History: "31d", TrendsMode: "0",
+		},
+	}})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if !updated.showError {
+		t.Fatal("expected the server information to be shown")
+	}
+	view := updated.errorModal.View()
+	for _, want := range []string{"6.4.12", "6040000 or later", "1200", "135.20", "1300 (17 unsupported)", "kept 31d (overrides item settings)", "not housekept"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("server information missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestSettingsLoaded(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.errorModal.SetScreenSize(120, 40)
+
+	var model tea.Model = *m
+	model, _ = model.Update(SettingsLoadedMsg{Settings: &zabbix.Settings{
+		SeverityColors: [6]string{5: "e45959"},
+		WorkPeriod:     "1-5,09:00-18:00",
+		OKPeriod:       "5m",
+	}})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if !updated.showError {
+		t.Fatal("expected the settings to be shown")
+	}
+	view := updated.errorModal.View()
+	for _, want := range []string{"Disaster", "#E45959", "Mon-Fri 09:00-18:00", "Resolved problems shown for", "5m"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("settings missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestSettingsServerSeverityColors(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Display.ServerSeverityColors = true
+	m := New(cfg, theme.DefaultTheme())
+
+	var model tea.Model = *m
+	model, _ = model.Update(SettingsLoadedMsg{Settings: &zabbix.Settings{
+		SeverityColors: [6]string{5: "E45959"},
+	}})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if got := updated.styles.AlertSeverity[5].GetForeground(); got != lipgloss.Color("#E45959") {
+		t.Errorf("disaster color = %v, want the server's #E45959", got)
+	}
+	if got := updated.styles.AlertSeverity[4].GetForeground(); got != theme.DefaultTheme().Colors.High {
+		t.Errorf("high color = %v, want the theme's", got)
+	}
+}
+
+func TestTriggerEvaluated(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.errorModal.SetScreenSize(120, 40)
+
+	var model tea.Model = *m
+	model, _ = model.Update(TriggerEvaluatedMsg{Evaluation: &zabbix.TriggerEvaluation{
+		Trigger:    zabbix.Trigger{Description: "High CPU on web01", Value: "1"},
+		Expression: "last(/web01/system.cpu.util) > 90 or nodata(/web01/agent.ping,5m) = 1",
+		State:      zabbix.TruthTrue,
+		Conditions: []zabbix.Condition{
+			{Text: "last(/web01/system.cpu.util) > 90", Values: "93.5 > 90", State: zabbix.TruthTrue},
+			{Text: "nodata(/web01/agent.ping,5m) = 1", Values: "? = 1", State: zabbix.TruthUnknown},
+		},
+		Notes: []string{"nodata(/web01/agent.ping,5m): item not supported: Timeout"},
+	}})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if !updated.showError {
+		t.Fatal("expected the evaluation to be shown")
+	}
+	view := updated.errorModal.View()
+	for _, want := range []string{"true with the latest values", "in PROBLEM", "[true]  last(/web01/system.cpu.util) > 90", "93.5 > 90", "[?]", "Timeout"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("evaluation view missing %q:\n%s", want, view)
+		}
+	}
+
+	model, _ = New(testConfig(), theme.DefaultTheme()).Update(TriggerEvaluatedMsg{})
+	if updated, ok = model.(Model); !ok || updated.showError {
+		t.Error("nothing should be shown without an evaluation")
+	}
+}
+
+// TestLoadCancellation verifies that superseded loads are dropped and that
+// switching tabs cancels the in-flight load of the tab being left.
+func TestLoadCancellation(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	_ = m.loadProblems()
+	_ = m.loadProblems() // Supersedes the first load
+
+	var model tea.Model = *m
+	model, _ = model.Update(ProblemsLoadedMsg{Seq: 1, Problems: []zabbix.Problem{{EventID: "old"}}})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if len(updated.problems) != 0 {
+		t.Fatal("expected superseded result to be dropped")
+	}
+
+	model, _ = updated.Update(ProblemsLoadedMsg{Seq: 2, Problems: []zabbix.Problem{{EventID: "new"}}})
+	updated, ok = model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if len(updated.problems) != 1 || updated.problems[0].EventID != "new" {
+		t.Fatalf("expected current result to be applied, got %+v", updated.problems)
+	}
+
+	// A canceled load must not surface an error
+	ctx, seq := updated.beginLoad(TabHosts)
+	updated.tabBar.SetActive(TabHosts)
+	model, _ = updated.switchTab(TabEvents)
+	updated, ok = model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if ctx.Err() == nil {
+		t.Error("expected hosts load to be canceled when leaving the tab")
+	}
+
+	model, _ = updated.Update(HostsLoadedMsg{Seq: seq, Err: ctx.Err()})
+	updated, ok = model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.failures != 0 || updated.statusBar.Banner() != "" {
+		t.Error("expected canceled load to be ignored, not counted as a failure")
+	}
+}
+
+func TestHostsTabCounts(t *testing.T) {
+	m := New(testConfig(), theme.DefaultTheme())
+	m.tabBar.SetActive(TabHosts)
+
+	// The whole host list stands in for the separate host count call
+	if cmds := m.loadDataForCurrentTab(); len(cmds) != 1 {
+		t.Errorf("hosts tab loads %d commands, want 1", len(cmds))
+	}
+	m.serverSearch[TabHosts] = []string{"web"}
+	if cmds := m.loadDataForCurrentTab(); len(cmds) != 2 {
+		t.Errorf("searched hosts tab loads %d commands, want 2 with the host counts", len(cmds))
+	}
+
+	counts := &zabbix.HostCounts{OK: 2, Problem: 1, Total: 3}
+	model, _ := m.Update(HostsLoadedMsg{Counts: counts, Seq: m.loads[TabHosts].seq})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.hostCounts != counts {
+		t.Errorf("host counts = %+v, want %+v from the hosts load", updated.hostCounts, counts)
+	}
+}
+
+// TestTextFilter_DebounceAndServerSearch verifies that the filter is applied
+// once typing pauses, that large lists are searched by the API, and that Esc
+// restores the previous filter.
+func TestTextFilter_DebounceAndServerSearch(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Display.ServerSearchThreshold = 2
+	m := New(cfg, theme.DefaultTheme())
+	m.connected = true
+	m.problems = []zabbix.Problem{{EventID: "1"}, {EventID: "2"}, {EventID: "3"}}
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+
+	updated, _ := update(*m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	updated, cmd := update(updated, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if cmd == nil {
+		t.Fatal("expected debounce tick after typing")
+	}
+	if updated.textFilter != "" {
+		t.Error("filter should not apply before the debounce fires")
+	}
+
+	// A stale tick from earlier typing is ignored
+	updated, _ = update(updated, FilterDebounceMsg{Seq: updated.filterSeq - 1})
+	if updated.textFilter != "" {
+		t.Error("superseded debounce tick should be ignored")
+	}
+
+	updated, cmd = update(updated, FilterDebounceMsg{Seq: updated.filterSeq})
+	if updated.textFilter != "d" {
+		t.Errorf("textFilter = %q, want %q", updated.textFilter, "d")
+	}
+	if !slices.Equal(updated.serverSearch[TabAlerts], []string{"d"}) || cmd == nil {
+		t.Error("expected list above threshold to be reloaded with a server-side search")
+	}
+
+	updated, cmd = update(updated, tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.textFilter != "" || updated.serverSearch[TabAlerts] != nil {
+		t.Errorf("Esc should restore the previous filter, got %q / %q",
+			updated.textFilter, updated.serverSearch[TabAlerts])
+	}
+	if cmd == nil {
+		t.Error("expected reload without the server-side search")
+	}
+}
+
+// TestTextFilter_Query verifies that a filter query is applied on Enter,
+// that one that doesn't parse keeps the command bar open with the error,
+// and that queries other than plain words aren't searched by the API.
+func TestTextFilter_Query(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Display.ServerSearchThreshold = 2
+	m := New(cfg, theme.DefaultTheme())
+	m.connected = true
+	m.SetSize(120, 40)
+
+	var model tea.Model = *m
+	model, _ = model.Update(ProblemsLoadedMsg{Problems: []zabbix.Problem{
+		{EventID: "1", Name: "Disk full", Severity: "4", Hosts: []zabbix.Host{{HostID: "1", Host: "web-01"}}},
+		{EventID: "2", Name: "Disk full", Severity: "2", Hosts: []zabbix.Host{{HostID: "2", Host: "db-01"}}},
+		{EventID: "3", Name: "High CPU", Severity: "5", Hosts: []zabbix.Host{{HostID: "1", Host: "web-01"}}},
+	}})
+	typeFilter := func(model tea.Model, filter string) Model {
+		t.Helper()
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(filter)})
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated
+	}
+
+	updated := typeFilter(model, "host:web* sev>=4 OR db")
+	if _, shown := updated.alertList.Count(); shown != 3 {
+		t.Errorf("shown alerts = %d, want 3", shown)
+	}
+	if updated.serverSearch[TabAlerts] != nil {
+		t.Errorf("serverSearch = %q, want no API search for a query", updated.serverSearch[TabAlerts])
+	}
+
+	updated = typeFilter(updated, "disk AND sev>=4")
+	if _, shown := updated.alertList.Count(); shown != 1 {
+		t.Errorf("shown alerts = %d, want 1", shown)
+	}
+
+	updated = typeFilter(updated, "sev>=9")
+	if !updated.commandInput.IsActive() || updated.commandInput.Error() == "" {
+		t.Error("expected the command bar to stay open with the parse error")
+	}
+	if updated.textFilter != "disk AND sev>=4" {
+		t.Errorf("textFilter = %q, want the previous filter kept", updated.textFilter)
+	}
+}
+
+// TestTextFilter_PagedList verifies that a list below the threshold is
+// filtered locally even when it has further pages, and that each word of a
+// search goes to the API on its own.
+func TestTextFilter_PagedList(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Display.ServerSearchThreshold = 2
+	m := New(cfg, theme.DefaultTheme())
+	m.connected = true
+	m.SetSize(120, 40)
+
+	var model tea.Model = *m
+	model, _ = model.Update(ProblemsLoadedMsg{
+		Problems: []zabbix.Problem{{EventID: "9", Name: "Disk full", Hosts: []zabbix.Host{{HostID: "1", Name: "web-01"}}}},
+		Next:     "8",
+	})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("full web")})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.serverSearch[TabAlerts] != nil {
+		t.Errorf("serverSearch = %q, want the loaded rows filtered", updated.serverSearch[TabAlerts])
+	}
+	if _, shown := updated.alertList.Count(); shown != 1 {
+		t.Errorf("shown alerts = %d, want the problem matching by name and host", shown)
+	}
+
+	model, _ = updated.Update(ProblemsLoadedMsg{Problems: []zabbix.Problem{{EventID: "8"}, {EventID: "7"}}, Append: true})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("full web")})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if updated = model.(Model); !slices.Equal(updated.serverSearch[TabAlerts], []string{"full", "web"}) {
+		t.Errorf("serverSearch = %q, want each word above the threshold", updated.serverSearch[TabAlerts])
+	}
+}
+
+// TestPagination verifies that the next page is fetched near the end of the
+// list, appended, and kept on refresh.
+func TestPagination(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Display.PageSize = 2
+	m := New(cfg, theme.DefaultTheme())
+	m.connected = true
+	m.SetSize(120, 40)
+
+	var model tea.Model = *m
+	model, _ = model.Update(ProblemsLoadedMsg{
+		Problems: []zabbix.Problem{{EventID: "9"}, {EventID: "8"}},
+		Next:     "7",
+	})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if !strings.Contains(updated.alertList.View(), "More available") {
+		t.Error("expected load more row")
+	}
+
+	if cmd := updated.maybeLoadMore(); cmd == nil {
+		t.Fatal("expected next page to be fetched near the end of the list")
+	}
+	if cmd := updated.maybeLoadMore(); cmd != nil {
+		t.Error("expected no second fetch while a page is loading")
+	}
+
+	model, _ = updated.Update(ProblemsLoadedMsg{
+		Problems: []zabbix.Problem{{EventID: "7"}},
+		Append:   true,
+		Seq:      updated.loads[TabAlerts].seq,
+	})
+	updated, ok = model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if len(updated.problems) != 3 {
+		t.Errorf("len(problems) = %d, want 3", len(updated.problems))
+	}
+	if updated.pageNext[TabAlerts] != "" || strings.Contains(updated.alertList.View(), "More available") {
+		t.Error("expected no more pages after a short page")
+	}
+	if got := updated.pageLimit(TabAlerts); got != 4 {
+		t.Errorf("pageLimit() = %d, want 4 (two pages kept on refresh)", got)
+	}
+}
+
+// TestSession verifies that the UI state is restored from the session file
+// and saved back on shutdown.
+func TestSession(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	state, err := session.Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	severity := 3
+	state.Tab = "hosts"
+	state.MinSeverity = &severity
+	state.Filters = map[string]string{"hosts": "web"}
+	state.Selected = map[string]string{"hosts": "2", "events": "77"}
+	state.AlertGroupBy = "tag:service"
+	state.HostSort = "group"
+	state.HostTree = true
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+	m.RestoreSession(state)
+	if m.tabBar.Active() != TabHosts {
+		t.Errorf("active tab = %d, want hosts", m.tabBar.Active())
+	}
+	if m.minSeverity != 3 || m.textFilter != "web" {
+		t.Errorf("minSeverity = %d, textFilter = %q; want 3 and \"web\"", m.minSeverity, m.textFilter)
+	}
+	if m.alertList.GroupBy() != "tag:service" {
+		t.Errorf("alerts grouped by %q, want tag:service", m.alertList.GroupBy())
+	}
+	if m.hostList.SortOrder() != hosts.SortGroup {
+		t.Errorf("hosts sorted by %v, want group", m.hostList.SortOrder())
+	}
+
+	var model tea.Model = *m
+	model, _ = model.Update(HostsLoadedMsg{Hosts: []zabbix.Host{
+		{HostID: "1", Host: "web01"},
+		{HostID: "2", Host: "web02"},
+		{HostID: "3", Host: "db01"},
+	}})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if h := updated.hostList.Selected(); h == nil || h.HostID != "2" {
+		t.Errorf("selected host = %v, want restored host 2", h)
+	}
+
+	updated.hostList.MoveUp()
+	updated.Shutdown()
+	saved, err := session.Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if saved.Tab != "hosts" || saved.Filters["hosts"] != "web" {
+		t.Errorf("saved tab = %q, filters = %v", saved.Tab, saved.Filters)
+	}
+	if saved.Selected["hosts"] != "1" {
+		t.Errorf("saved host = %q, want 1", saved.Selected["hosts"])
+	}
+	if saved.Selected["events"] != "77" {
+		t.Errorf("saved event = %q, want 77 kept from the unvisited tab", saved.Selected["events"])
+	}
+	if saved.MinSeverity == nil || *saved.MinSeverity != 3 {
+		t.Errorf("saved min severity = %v, want 3", saved.MinSeverity)
+	}
+	if saved.AlertGroupBy != "tag:service" {
+		t.Errorf("saved grouping = %q, want tag:service", saved.AlertGroupBy)
+	}
+	if saved.HostSort != "group" || saved.HostState != "" {
+		t.Errorf("saved host sort = %q, state = %q; want group and unset", saved.HostSort, saved.HostState)
+	}
+	if !saved.HostTree {
+		t.Error("saved session should keep hosts under their host groups")
+	}
+	if saved.RelativeTime != nil {
+		t.Errorf("saved relative time = %v, want unset as it matches the config", *saved.RelativeTime)
+	}
+}
+
+// TestAckClose verifies that ack & close is only sent for problems whose
+// trigger allows manual closing, and that a rejected close is reported.
+func TestAckClose(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+	m.errorModal.SetScreenSize(120, 40)
+	m.alertList.SetProblems([]zabbix.Problem{
+		{EventID: "1", RelatedObject: zabbix.RelatedObject{ManualClose: zabbix.ManualCloseNotAllowed}},
+		{EventID: "2", RelatedObject: zabbix.RelatedObject{ManualClose: zabbix.ManualCloseAllowed}},
+	})
+	press := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+
+	model, cmd := m.Update(press)
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if cmd != nil {
+		t.Error("expected no API call for a trigger without manual close")
+	}
+	if !strings.Contains(updated.statusBar.View(), "does not allow closing") {
+		t.Error("expected a status explaining why the problem can't be closed")
+	}
+
+	updated.alertList.MoveDown()
+	if _, cmd := updated.Update(press); cmd == nil {
+		t.Error("expected ack & close to be sent for a closable problem")
+	}
+
+	model, _ = updated.Update(AcknowledgeResultMsg{EventID: "2", Closed: true, Err: errors.New("rejected")})
+	updated, ok = model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.showError || !strings.Contains(updated.notifications.View(), "Could not close the problem") {
+		t.Errorf("expected the rejected close to be notified:\n%s", updated.notifications.View())
+	}
+}
+
+// TestRequiredAckMessage verifies that a, and x, ask for a message when the
+// config requires one, and that short messages are rejected.
+func TestRequiredAckMessage(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Ack.MinMessageLength = 10
+	m := New(cfg, theme.DefaultTheme())
+	m.SetSize(120, 40)
+	m.alertList.SetProblems([]zabbix.Problem{
+		{EventID: "1", RelatedObject: zabbix.RelatedObject{ManualClose: zabbix.ManualCloseAllowed}},
+	})
+
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	updated := model.(Model)
+	if cmd != nil || updated.mode != ModeAckMessage {
+		t.Fatalf("a should ask for a message, mode = %v", updated.mode)
+	}
+	if !strings.Contains(updated.commandInput.View(), "at least 10 characters") {
+		t.Errorf("the prompt should tell the minimum length:\n%s", updated.commandInput.View())
+	}
+
+	for _, s := range []string{"  short   ", ""} {
+		updated.commandInput.SetValue(s)
+		model, cmd = updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		updated = model.(Model)
+		if cmd != nil || updated.mode != ModeAckMessage || updated.commandInput.Error() == "" {
+			t.Errorf("message %q should be rejected, keeping the prompt open", s)
+		}
+	}
+
+	updated.commandInput.SetValue("Restarted the web server")
+	model, cmd = updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated = model.(Model)
+	if cmd == nil || updated.mode != ModeNormal {
+		t.Error("a long enough message should acknowledge the problem")
+	}
+
+	model, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	updated = model.(Model)
+	if updated.mode != ModeAckMessage || !updated.ackClose {
+		t.Fatal("x should ask for a message and close the problem with it")
+	}
+	model, _ = updated.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(Model).ackClose {
+		t.Error("Esc should forget the pending close")
+	}
+}
+
+// TestEventScope verifies the Events tab window, type and severity controls.
+func TestEventScope(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+	m.tabBar.SetActive(TabEvents)
+
+	var model tea.Model = *m
+	for _, k := range []string{"w", "v", "3"} {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	want := eventScope{Window: 7 * 24 * time.Hour, Type: eventsProblems, MinSeverity: 3}
+	if updated.eventScope != want {
+		t.Errorf("eventScope = %+v, want %+v", updated.eventScope, want)
+	}
+	if got := updated.eventScope.String(); got != "7d, problems, Average+" {
+		t.Errorf("scope = %q", got)
+	}
+	if updated.minSeverity != 0 {
+		t.Errorf("minSeverity = %d, want the alerts filter unchanged", updated.minSeverity)
+	}
+
+	now := time.Now()
+	params := updated.eventScope.params(now)
+	if params.TimeFrom != now.Add(-7*24*time.Hour).Unix() {
+		t.Errorf("TimeFrom = %d, want 7 days ago", params.TimeFrom)
+	}
+	if len(params.Values) != 1 || params.Values[0] != 1 || len(params.Severities) != 3 {
+		t.Errorf("Values = %v, Severities = %v", params.Values, params.Severities)
+	}
+
+	updated.handleWindowCommand("window 90m")
+	if updated.eventScope.Window != 90*time.Minute {
+		t.Errorf("Window = %v after :window 90m", updated.eventScope.Window)
+	}
+	updated.handleWindowCommand("window soon")
+	if updated.eventScope.Window != 90*time.Minute {
+		t.Errorf("Window = %v, want unchanged after an invalid :window", updated.eventScope.Window)
+	}
+	if next := nextEventWindow(updated.eventScope.Window); next != 6*time.Hour {
+		t.Errorf("nextEventWindow(90m) = %v, want 6h", next)
+	}
+}
+
+func TestHostHistory(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+	m.alertList.SetProblems([]zabbix.Problem{
+		{EventID: "1", Hosts: []zabbix.Host{{HostID: "10084", Host: "web-01"}}},
+	})
+	m.events = []zabbix.Event{{EventID: "9"}}
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.tabBar.Active() != TabEvents {
+		t.Errorf("active tab = %d, want the Events tab", updated.tabBar.Active())
+	}
+	if updated.eventScope.HostID != "10084" || updated.events != nil {
+		t.Errorf("HostID = %q, events = %v, want 10084 and the other events dropped",
+			updated.eventScope.HostID, updated.events)
+	}
+	if params := updated.eventScope.params(time.Now()); len(params.HostIDs) != 1 || params.HostIDs[0] != "10084" {
+		t.Errorf("HostIDs = %v", params.HostIDs)
+	}
+	if got := updated.eventScope.String(); got != "web-01, 1d" {
+		t.Errorf("scope = %q", got)
+	}
+
+	model, _ = updated.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	updated, ok = model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.eventScope.HostID != "" {
+		t.Errorf("HostID = %q after clearing the filters", updated.eventScope.HostID)
+	}
+}
+
+// TestImpact verifies that a problem is related to the problems of the
+// triggers it depends on, those depending on it, and those that started
+// shortly after it on hosts sharing a group.
+func TestImpact(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	host := func(id string) []zabbix.Host { return []zabbix.Host{{HostID: id}} }
+	m.problems = []zabbix.Problem{
+		{EventID: "1", ObjectID: "100", Clock: "1000", Hosts: host("1")}, // Switch down
+		{EventID: "2", ObjectID: "200", Clock: "1060", Hosts: host("2")}, // Agent behind the switch
+		{EventID: "3", ObjectID: "300", Clock: "1120", Hosts: host("3")}, // Ping behind the agent
+		{EventID: "4", ObjectID: "400", Clock: "1200", Hosts: host("4")}, // Same group, shortly after
+		{EventID: "5", ObjectID: "500", Clock: "9000", Hosts: host("5")}, // Same group, much later
+		{EventID: "6", ObjectID: "600", Clock: "1010", Hosts: host("6")}, // Unrelated group
+	}
+	network := []zabbix.HostGroup{{GroupID: "7", Name: "Network"}}
+	m.triggerRelations = map[string]zabbix.Trigger{
+		"100": {TriggerID: "100", Groups: network},
+		"200": {TriggerID: "200", Dependencies: []zabbix.Trigger{{TriggerID: "100"}}},
+		"300": {TriggerID: "300", Dependencies: []zabbix.Trigger{{TriggerID: "200"}}},
+		"400": {TriggerID: "400", Groups: network},
+		"500": {TriggerID: "500", Groups: network},
+		"600": {TriggerID: "600", Groups: []zabbix.HostGroup{{GroupID: "8"}}},
+	}
+
+	ids := func(problems []zabbix.Problem) []string {
+		var ids []string
+		for _, p := range problems {
+			ids = append(ids, p.EventID)
+		}
+		return ids
+	}
+	impact := m.impactOf(&m.problems[0])
+	if got := ids(impact.Dependents); !slices.Equal(got, []string{"2", "3"}) {
+		t.Errorf("Dependents = %v, want [2 3]", got)
+	}
+	if got := ids(impact.Related); !slices.Equal(got, []string{"4"}) {
+		t.Errorf("Related = %v, want [4]", got)
+	}
+	if len(impact.DependsOn) != 0 {
+		t.Errorf("DependsOn = %v, want none", ids(impact.DependsOn))
+	}
+
+	impact = m.impactOf(&m.problems[2])
+	if got := ids(impact.DependsOn); !slices.Equal(got, []string{"1", "2"}) {
+		t.Errorf("DependsOn = %v, want [1 2]", got)
+	}
+}
+
+// TestConfigReload verifies that changes to the config file are applied at
+// runtime, keeping command-line overrides for settings the file leaves unchanged.
+func TestConfigReload(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	path := filepath.Join(dir, "config.yaml")
+
+	write := func(refresh, severity int, themeName string) {
+		t.Helper()
+		cfg := testConfig()
+		cfg.Display.RefreshInterval = refresh
+		cfg.Display.MinSeverity = severity
+		cfg.Display.Theme = themeName
+		if err := config.SaveToFile(cfg, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reload := func(m Model) Model {
+		t.Helper()
+		model, _ := m.Update(ConfigChangedMsg{})
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated
+	}
+
+	write(30, 0, theme.ThemeNord)
+	cfg := testConfig()
+	cfg.Display.Theme = theme.ThemeDracula // As if set with --theme
+	m := New(cfg, theme.DraculaTheme())
+	if err := m.WatchConfig(path); err != nil {
+		t.Fatalf("WatchConfig() error = %v", err)
+	}
+	defer m.Shutdown()
+	m.statusBar.SetWidth(120)
+
+	write(60, 3, theme.ThemeNord)
+	updated := reload(*m)
+	if updated.refreshInterval != 60*time.Second {
+		t.Errorf("refreshInterval = %v, want 60s", updated.refreshInterval)
+	}
+	if updated.minSeverity != 3 {
+		t.Errorf("minSeverity = %d, want 3", updated.minSeverity)
+	}
+	if updated.theme.Name != theme.ThemeDracula {
+		t.Errorf("theme = %q, want command-line theme %q kept", updated.theme.Name, theme.ThemeDracula)
+	}
+	if !strings.Contains(updated.statusBar.View(), "Config reloaded") {
+		t.Error("expected \"Config reloaded\" status")
+	}
+
+	write(60, 3, theme.ThemeSolarizedLight)
+	updated = reload(updated)
+	if updated.theme.Name != theme.ThemeSolarizedLight {
+		t.Errorf("theme = %q, want %q", updated.theme.Name, theme.ThemeSolarizedLight)
+	}
+	want := theme.SolarizedLightTheme().Colors.Background
+	if got := updated.styles.App.GetBackground(); got != want {
+		t.Error("shared styles not updated to the new theme")
+	}
+
+	write(1, 3, theme.ThemeSolarizedLight)
+	updated = reload(updated)
+	if updated.refreshInterval != 60*time.Second {
+		t.Errorf("refreshInterval = %v, want invalid reload ignored", updated.refreshInterval)
+	}
+	if !strings.Contains(updated.statusBar.View(), "Config reload failed") {
+		t.Error("expected \"Config reload failed\" status")
+	}
+}
+
+// TestMute verifies that alerts matching the configured mute rules are
+// hidden and counted, and that rules edited in the app are saved to the
+// config file.
+func TestMute(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	path := filepath.Join(dir, "config.yaml")
+
+	cfg := testConfig()
+	cfg.Mute = []config.MuteRule{{Trigger: "^Zabbix agent"}}
+	if err := config.SaveToFile(cfg, path); err != nil {
+		t.Fatal(err)
+	}
+	m := New(cfg, theme.DefaultTheme())
+	if err := m.WatchConfig(path); err != nil {
+		t.Fatalf("WatchConfig() error = %v", err)
+	}
+	defer m.Shutdown()
+	m.SetSize(120, 40)
+
+	var model tea.Model = *m
+	model, _ = model.Update(ProblemsLoadedMsg{Problems: []zabbix.Problem{
+		{EventID: "1", Name: "Zabbix agent is not available"},
+		{EventID: "2", Name: "High CPU", Tags: []zabbix.Tag{{Tag: "env", Value: "dev"}}},
+		{EventID: "3", Name: "Disk full"},
+	}})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if _, shown := updated.alertList.Count(); shown != 2 {
+		t.Errorf("shown alerts = %d, want the muted one hidden", shown)
+	}
+	if !strings.Contains(updated.statusBar.View(), "1 muted") {
+		t.Error("expected \"1 muted\" in the status bar")
+	}
+	if counts := updated.getAlertCountsBySeverity(); counts[0] != 2 {
+		t.Errorf("title counts = %v, want muted alerts left out", counts)
+	}
+
+	updated.handleMutedCommand("muted show")
+	if _, shown := updated.alertList.Count(); shown != 3 {
+		t.Errorf("shown alerts = %d after :muted show, want 3", shown)
+	}
+
+	rules := []config.MuteRule{{Trigger: "^Zabbix agent"}, {Tag: "env=dev", Comment: "test lab"}}
+	model, _ = updated.handleMuteRulesChangedMsg(editor.MuteRulesChangedMsg{Rules: rules})
+	updated, ok = model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.alertList.MutedCount() != 2 {
+		t.Errorf("MutedCount() = %d, want 2", updated.alertList.MutedCount())
+	}
+	saved, err := config.LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile() error = %v", err)
+	}
+	if len(saved.Mute) != 2 || saved.Mute[1].Tag != "env=dev" {
+		t.Errorf("saved mute rules = %+v", saved.Mute)
+	}
+}
+
+// TestAckFilter verifies that u cycles the alerts between all,
+// unacknowledged, the user's own acknowledgements and their assignments,
+// reloading them, and skips the last two when the user is unknown.
+func TestAckFilter(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Display.AckFilter = config.AckFilterUnacked
+	m := New(cfg, theme.DefaultTheme())
+	m.SetSize(120, 40)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+	shown := func(m Model) int {
+		_, shown := m.alertList.Count()
+		return shown
+	}
+	u := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")}
+
+	updated, _ := update(*m, ConnectedMsg{Version: "7.0.0", UserID: "7", Username: "jdoe"})
+	updated, _ = update(updated, ProblemsLoadedMsg{Problems: []zabbix.Problem{
+		{EventID: "1", Name: "Agent down", Acknowledged: "0"},
+		{EventID: "2", Name: "Disk full", Acknowledged: "1", Acknowledges: []zabbix.Ack{{UserID: "7", Username: "jdoe", Action: "2"}}},
+		{EventID: "3", Name: "High CPU", Acknowledged: "1", Acknowledges: []zabbix.Ack{{UserID: "8", Username: "asmith", Action: "2"}}},
+	}, Seq: updated.loads[TabAlerts].seq})
+	if shown(updated) != 1 {
+		t.Errorf("shown = %d, want the unacknowledged alert from the config", shown(updated))
+	}
+
+	updated, cmd := update(updated, u)
+	if updated.alertList.AckFilter() != alerts.AckMine || shown(updated) != 1 {
+		t.Errorf("filter = %v showing %d, want mine showing 1", updated.alertList.AckFilter(), shown(updated))
+	}
+	if cmd == nil {
+		t.Error("expected the alerts to be reloaded")
+	}
+
+	updated, _ = update(updated, u)
+	if updated.alertList.AckFilter() != alerts.AckAssigned || shown(updated) != 1 {
+		t.Errorf("filter = %v showing %d, want assigned showing 1", updated.alertList.AckFilter(), shown(updated))
+	}
+
+	updated, _ = update(updated, u)
+	if updated.alertList.AckFilter() != alerts.AckAll || shown(updated) != 3 {
+		t.Errorf("filter = %v showing %d, want all showing 3", updated.alertList.AckFilter(), shown(updated))
+	}
+
+	// Without the user's ID, "mine" and "assigned" are skipped
+	updated.userID = ""
+	updated.username = ""
+	updated, _ = update(updated, u)
+	updated, _ = update(updated, u)
+	if updated.alertList.AckFilter() != alerts.AckAll {
+		t.Errorf("filter = %v, want all after skipping mine", updated.alertList.AckFilter())
+	}
+}
+
+// TestAssignCommand verifies that :assign and :unassign need a selected
+// alert and a known user, and that the result is notified.
+func TestAssignCommand(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+	model, _ := m.Update(ProblemsLoadedMsg{Problems: []zabbix.Problem{
+		{EventID: "1", Name: "Agent down", Acknowledged: "0"},
+	}, Seq: m.loads[TabAlerts].seq})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+
+	model, cmd := updated.executeCommand("assign")
+	updated, _ = model.(Model)
+	if cmd != nil || !strings.Contains(updated.statusBar.View(), "use :assign USER") {
+		t.Errorf("expected :assign to need the username, got:\n%s", updated.statusBar.View())
+	}
+	if _, cmd := updated.executeCommand("assign jdoe"); cmd == nil {
+		t.Error("expected :assign USER to assign the alert")
+	}
+	if _, cmd := updated.executeCommand("unassign"); cmd == nil {
+		t.Error("expected :unassign to unassign the alert")
+	}
+
+	model, _ = updated.Update(AssignResultMsg{Username: "jdoe", Count: 1})
+	updated, _ = model.(Model)
+	if view := updated.notifications.View(); !strings.Contains(view, "assigned to jdoe") {
+		t.Errorf("expected the assignment to be notified:\n%s", view)
+	}
+	model, _ = updated.Update(AssignResultMsg{Count: 2, Err: errors.New("no permissions")})
+	updated, _ = model.(Model)
+	if view := updated.notifications.View(); !strings.Contains(view, "Could not unassign") {
+		t.Errorf("expected the failure to be notified:\n%s", view)
+	}
+}
+
+// TestHostStateAndSort verifies that the Hosts tab keys filter hosts by
+// availability state and sort them by problem count.
+func TestHostStateAndSort(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+	m.tabBar.SetActive(TabHosts)
+
+	update := func(model tea.Model, msg tea.Msg) Model {
+		t.Helper()
+		model, _ = model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated
+	}
+	names := func(m Model) string {
+		var names []string
+		for i := range m.hostList.FilteredCount() {
+			m.hostList.SetCursor(i)
+			names = append(names, m.hostList.Selected().Host)
+		}
+		return strings.Join(names, " ")
+	}
+	f := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")}
+	s := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")}
+
+	updated := update(*m, ConnectedMsg{Version: "7.0.0"})
+	updated = update(updated, HostsLoadedMsg{Hosts: []zabbix.Host{
+		{HostID: "1", Host: "app01", Status: "0", ActiveAvailable: "1"},
+		{HostID: "2", Host: "db01", Status: "0", ActiveAvailable: "2"},
+		{HostID: "3", Host: "web01", Status: "0", ActiveAvailable: "1"},
+		{HostID: "4", Host: "old01", Status: "1"},
+	}, Problems: map[string]zabbix.HostProblems{
+		"2": {Count: 1, Worst: 4},
+		"3": {Count: 2, Worst: 2},
+	}, Seq: updated.loads[TabHosts].seq})
+
+	updated = update(updated, f)
+	if got := names(updated); got != "db01" {
+		t.Errorf("unavailable hosts = %q, want db01", got)
+	}
+	for range 3 {
+		updated = update(updated, f)
+	}
+	if got := names(updated); got != "old01" {
+		t.Errorf("disabled hosts = %q, want old01", got)
+	}
+	updated = update(updated, f)
+
+	for range 3 {
+		updated = update(updated, s)
+	}
+	if got := names(updated); got != "web01 db01 app01 old01" {
+		t.Errorf("hosts by problems = %q, want web01 db01 app01 old01", got)
+	}
+	if !strings.Contains(updated.statusBar.View(), "Hosts sorted by problems") {
+		t.Error("status bar should show the new order")
+	}
+	if !strings.Contains(updated.hostList.View(), "by problems") {
+		t.Error("list header should show the order")
+	}
+
+	model, _ := updated.executeCommand("group-by hostgroup")
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if !updated.hostList.TreeView() {
+		t.Fatal(":group-by hostgroup should show hosts under their host groups")
+	}
+	if !strings.Contains(updated.hostList.View(), "No group (4)") {
+		t.Error("hosts without groups should be under No group")
+	}
+	model, _ = updated.executeCommand("group-by none")
+	if updated, ok = model.(Model); !ok || updated.hostList.TreeView() {
+		t.Error(":group-by none should show the list of hosts")
+	}
+}
+
+// TestSuppressAction verifies the suppress prompt on the Alerts tab, with
+// its presets and a typed end.
+func TestSuppressAction(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 40)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+	press := func(s string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+
+	updated, _ := update(*m, ConnectedMsg{Version: "7.0.0"})
+	updated, _ = update(updated, ProblemsLoadedMsg{Problems: []zabbix.Problem{
+		{EventID: "1", Name: "Disk full", Acknowledged: "0"},
+	}, Seq: updated.loads[TabAlerts].seq})
+
+	updated, _ = update(updated, press("S"))
+	if !strings.Contains(updated.statusBar.View(), "Suppress Disk full for: 1) 1h 2) 4h 3) until 09:00") {
+		t.Fatalf("status bar should offer the suppression ends:\n%s", updated.statusBar.View())
+	}
+	updated, cmd := update(updated, press("2"))
+	if updated.pendingSuppress != nil || cmd == nil {
+		t.Fatal("a preset should suppress the problem")
+	}
+	result, ok := cmd().(SuppressResultMsg)
+	if !ok || time.Until(result.Until).Round(time.Minute) != 4*time.Hour {
+		t.Fatalf("result = %+v, want 4h from now", result)
+	}
+	updated, _ = update(updated, result)
+	if !strings.Contains(updated.notifications.View(), "Suppressed Disk full until") {
+		t.Error("a notification should confirm the suppression")
+	}
+
+	updated, _ = update(updated, press("S"))
+	updated, _ = update(updated, press("c"))
+	if updated.mode != ModeSuppressUntil {
+		t.Fatal("c should ask for the end")
+	}
+	for _, r := range "later" {
+		updated, _ = update(updated, press(string(r)))
+	}
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyEnter})
+	if updated.mode != ModeSuppressUntil || updated.commandInput.Error() == "" {
+		t.Fatal("an end that doesn't parse should be refused")
+	}
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.pendingSuppress != nil || updated.mode != ModeNormal {
+		t.Error("Esc should cancel the suppression")
+	}
+}
+
+// TestParseSuppressUntil verifies the ends of suppressions that can be
+// typed.
+func TestParseSuppressUntil(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 2, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "90m", want: now.Add(90 * time.Minute)},
+		{in: "2d", want: now.Add(48 * time.Hour)},
+		{in: "17:30", want: time.Date(2025, 1, 2, 17, 30, 0, 0, time.UTC)},
+		{in: "9am", want: time.Date(2025, 1, 3, 9, 0, 0, 0, time.UTC)},
+		{in: "5:15PM", want: time.Date(2025, 1, 2, 17, 15, 0, 0, time.UTC)},
+		{in: "2025-01-06 08:00", want: time.Date(2025, 1, 6, 8, 0, 0, 0, time.UTC)},
+		{in: "2025-01-01", wantErr: true},
+		{in: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseSuppressUntil(tt.in, now, time.UTC)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSuppressUntil(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !got.Equal(tt.want) {
+			t.Errorf("parseSuppressUntil(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestMaintenanceAction verifies the maintenance prompt on the Hosts tab.
+func TestMaintenanceAction(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 40)
+	m.tabBar.SetActive(TabHosts)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+	press := func(s string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+
+	updated, _ := update(*m, ConnectedMsg{Version: "7.0.0"})
+	updated, _ = update(updated, HostsLoadedMsg{Hosts: []zabbix.Host{
+		{HostID: "1", Host: "web01", Status: "0", ActiveAvailable: "1"},
+	}, Seq: updated.loads[TabHosts].seq})
+
+	updated, _ = update(updated, press("M"))
+	if !strings.Contains(updated.statusBar.View(), "Maintenance for web01: 1) 30m 2) 1h 3) 4h") {
+		t.Fatalf("status bar should offer the maintenance lengths:\n%s", updated.statusBar.View())
+	}
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.pendingMaintenance != nil {
+		t.Fatal("Esc should cancel the maintenance")
+	}
+
+	updated, _ = update(updated, press("M"))
+	updated, _ = update(updated, press("d"))
+	if !strings.Contains(updated.statusBar.View(), "data collection off") {
+		t.Error("d should turn data collection off")
+	}
+	updated, _ = update(updated, press("x"))
+	if updated.pendingMaintenance == nil {
+		t.Fatal("other keys should leave the prompt open")
+	}
+	updated, cmd := update(updated, press("2"))
+	if updated.pendingMaintenance != nil || cmd == nil {
+		t.Fatal("a preset should create the maintenance")
+	}
+	result, ok := cmd().(MaintenanceResultMsg)
+	if !ok || result.Duration != time.Hour || result.HostName != "web01" {
+		t.Fatalf("result = %+v, want 1h for web01", result)
+	}
+	updated, _ = update(updated, result)
+	if !strings.Contains(updated.notifications.View(), "web01 in maintenance for 1h") {
+		t.Error("a notification should confirm the maintenance")
+	}
+}
+
+// TestCloneAction verifies cloning the selected host from the Hosts tab.
+func TestCloneAction(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 40)
+	m.tabBar.SetActive(TabHosts)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+	press := func(s string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+
+	updated, _ := update(*m, ConnectedMsg{Version: "7.0.0"})
+	updated, _ = update(updated, HostsLoadedMsg{Hosts: []zabbix.Host{
+		{HostID: "1", Host: "web01", Status: "0", ActiveAvailable: "1"},
+	}, Seq: updated.loads[TabHosts].seq})
+
+	updated, _ = update(updated, press("c"))
+	if updated.mode != ModeCloneHost || updated.pendingClone == nil {
+		t.Fatal("c should ask for the clone's name")
+	}
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.pendingClone != nil {
+		t.Fatal("Esc should cancel the clone")
+	}
+
+	updated, _ = update(updated, press("c"))
+	updated, _ = update(updated, press("web02 10.0.0.2 extra"))
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyEnter})
+	if updated.pendingClone == nil || updated.commandInput.Error() == "" {
+		t.Fatal("a name with two addresses should be refused")
+	}
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyCtrlU})
+	updated, _ = update(updated, press("web02 10.0.0.2"))
+	updated, cmd := update(updated, tea.KeyMsg{Type: tea.KeyEnter})
+	if updated.pendingClone != nil || cmd == nil {
+		t.Fatal("a name and address should clone the host")
+	}
+	result, ok := cmd().(HostClonedMsg)
+	if !ok || result.Source != "web01" || result.Name != "web02" {
+		t.Fatalf("result = %+v, want web01 cloned as web02", result)
+	}
+	updated, _ = update(updated, HostClonedMsg{Source: "web01", Name: "web02", Clone: &zabbix.HostClone{
+		HostID: "2", SkippedMacros: []string{"{$PASSWORD}"},
+	}})
+	if view := updated.notifications.View(); !strings.Contains(view, "Cloned web01 as web02") || !strings.Contains(view, "{$PASSWORD}") {
+		t.Errorf("a notification should confirm the clone and list the secret macros:\n%s", view)
+	}
+}
+
+// TestKiosk verifies that kiosk mode hides the command bar, rotates through
+// the configured tabs and ignores every key but quit.
+func TestKiosk(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Kiosk.Tabs = []string{"hosts", "alerts"}
+	m := New(cfg, theme.DefaultTheme())
+	m.SetSize(160, 40)
+	m.SetKiosk(true)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+
+	if m.tabBar.Active() != TabHosts {
+		t.Errorf("kiosk starts on tab %d, want the first configured (Hosts)", m.tabBar.Active())
+	}
+	if view := m.View(); strings.Contains(view, "Press : for commands") {
+		t.Error("kiosk should hide the command bar")
+	}
+	if !m.compact {
+		t.Error("kiosk should show the list alone")
+	}
+
+	updated, cmd := update(*m, KioskTickMsg{})
+	if updated.tabBar.Active() != TabAlerts || cmd == nil {
+		t.Errorf("tick shows tab %d, want Alerts and the next tick scheduled", updated.tabBar.Active())
+	}
+	updated, _ = update(updated, KioskTickMsg{})
+	if updated.tabBar.Active() != TabHosts {
+		t.Errorf("tick shows tab %d, want Hosts again", updated.tabBar.Active())
+	}
+
+	for _, k := range []tea.KeyMsg{{Type: tea.KeyTab}, {Type: tea.KeyRunes, Runes: []rune(":")}, {Type: tea.KeyRunes, Runes: []rune("?")}} {
+		updated, cmd = update(updated, k)
+		if cmd != nil || updated.showHelp || updated.commandInput.IsActive() || updated.tabBar.Active() != TabHosts {
+			t.Errorf("kiosk should ignore %q", k.String())
+		}
+	}
+	if _, cmd = update(updated, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); cmd == nil {
+		t.Fatal("q should quit the kiosk")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("q should quit the kiosk")
+	}
+}
+
+// TestKioskGroups verifies that kiosk mode limits the lists to each
+// configured host group in turn and shows it in the tab bar.
+func TestKioskGroups(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Kiosk.Groups = []string{"Databases", "Linux servers", "Retired"}
+	m := New(cfg, theme.DefaultTheme())
+	m.SetSize(160, 40)
+	m.SetKiosk(true)
+
+	model, _ := m.Update(KioskGroupsMsg{Groups: []zabbix.HostGroup{
+		{GroupID: "2", Name: "Linux servers"},
+		{GroupID: "5", Name: "Databases"},
+	}})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if ids := updated.kioskGroupIDs(); !slices.Equal(ids, []string{"5"}) {
+		t.Errorf("kioskGroupIDs() = %v, want the first configured group", ids)
+	}
+	if !strings.Contains(updated.tabBar.View(), "Databases") {
+		t.Error("tab bar should show the host group")
+	}
+	if !strings.Contains(updated.View(), "Host groups not found: Retired") {
+		t.Error("a missing host group should be reported")
+	}
+
+	for _, want := range []string{"2", "5"} {
+		model, _ = updated.Update(KioskGroupTickMsg{})
+		if updated, ok = model.(Model); !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		if ids := updated.kioskGroupIDs(); !slices.Equal(ids, []string{want}) {
+			t.Errorf("kioskGroupIDs() = %v, want [%s]", ids, want)
+		}
+	}
+
+	host := zabbix.Host{Groups: []zabbix.HostGroup{{GroupID: "2"}, {GroupID: "7"}}}
+	if !inGroups(host, []string{"7"}) || inGroups(host, []string{"5"}) || !inGroups(host, nil) {
+		t.Error("inGroups() should match any of the host's groups, and all hosts without groups")
+	}
+}
+
+func TestRoleFilter(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.RoleFilters = config.RoleFilters{
+		"Database team": {Groups: []string{"Databases"}, MinSeverity: 3, AckFilter: config.AckFilterUnacked},
+	}
+	m := New(cfg, theme.DefaultTheme())
+	m.SetSize(160, 40)
+
+	model, cmd := m.Update(ConnectedMsg{Version: "7.0", Profile: &zabbix.UserProfile{
+		Role: "User role", Groups: []string{"Database team"},
+	}})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.roleFilter != "Database team" || updated.minSeverity != 3 {
+		t.Errorf("role filter = %q, min severity %d; want Database team's", updated.roleFilter, updated.minSeverity)
+	}
+	if got := updated.alertList.AckFilter().String(); got != config.AckFilterUnacked {
+		t.Errorf("ack filter = %s, want %s", got, config.AckFilterUnacked)
+	}
+	if cmd == nil {
+		t.Fatal("expected the host groups to be looked up")
+	}
+
+	model, _ = updated.Update(RoleGroupsMsg{Groups: []zabbix.HostGroup{{GroupID: "5", Name: "Databases"}}})
+	if updated, ok = model.(Model); !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if ids := updated.listGroupIDs(); !slices.Equal(ids, []string{"5"}) {
+		t.Errorf("listGroupIDs() = %v, want [5]", ids)
+	}
+	if !strings.Contains(updated.tabBar.View(), "Databases") {
+		t.Error("tab bar should show the host group")
+	}
+
+	model, _ = updated.executeCommand("role-filter off")
+	if updated, ok = model.(Model); !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if ids := updated.listGroupIDs(); ids != nil {
+		t.Errorf("listGroupIDs() = %v after :role-filter off, want all hosts", ids)
+	}
+	if strings.Contains(updated.tabBar.View(), "Databases") {
+		t.Error("tab bar should no longer show the host group")
+	}
+}
+
+// TestImportConfirm verifies that importing a configuration file shows its
+// changes and waits for confirmation.
+func TestImportConfirm(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 40)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+	preview := ImportPreviewMsg{Path: "hosts.yaml", Preview: &zabbix.ImportPreview{
+		Format:   zabbix.ConfigFormatYAML,
+		Hosts:    []string{"web01", "web02"},
+		Existing: []string{"web01"},
+		Diff:     []string{"-    name: Web", "+    name: Web server"},
+	}}
+
+	updated, _ := update(*m, preview)
+	if !updated.showError || updated.pendingImport == nil {
+		t.Fatal("expected the changes to be shown for confirmation")
+	}
+	view := updated.errorModal.View()
+	for _, want := range []string{"Import hosts.yaml?", "Creates: web02", "Updates: web01", "+    name: Web server"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("import confirmation missing %q:\n%s", want, view)
+		}
+	}
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyEnter})
+	if updated.pendingImport == nil {
+		t.Fatal("Enter should not answer the confirmation")
+	}
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.pendingImport != nil || updated.showError {
+		t.Fatal("Esc should cancel the import")
+	}
+
+	updated, _ = update(updated, preview)
+	updated, cmd := update(updated, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if updated.pendingImport != nil || cmd == nil {
+		t.Fatal("y should import the file")
+	}
+	if result, ok := cmd().(ImportResultMsg); !ok || result.Path != "hosts.yaml" {
+		t.Fatalf("result = %+v, want hosts.yaml imported", result)
+	}
+
+	updated, _ = update(updated, ImportPreviewMsg{Path: "same.yaml", Preview: &zabbix.ImportPreview{Hosts: []string{"web01"}}})
+	if updated.showError || !strings.Contains(updated.statusBar.View(), "nothing to import") {
+		t.Error("a file matching the server should not be imported")
+	}
+}
+
+func TestExportFileName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"web01":                 "web01.yaml",
+		"Linux by Zabbix agent": "Linux_by_Zabbix_agent.yaml",
+		"db/primary (10.0.0.5)": "db_primary_10.0.0.5.yaml",
+	}
+	for name, want := range tests {
+		if got := exportFileName(name); got != want {
+			t.Errorf("exportFileName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestParseReportArgs(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		args []string
+		want reportRequest
+	}{
+		{nil, reportRequest{window: 6 * time.Hour, path: "incident-20260301-1530.md"}},
+		{[]string{"12h", "outage.html"}, reportRequest{window: 12 * time.Hour, path: "outage.html"}},
+		{[]string{"Linux", "servers", "2d"}, reportRequest{window: 6 * time.Hour, group: "Linux servers 2d", path: "incident-20260301-1530.md"}},
+		{[]string{"out.md", "1w", "Databases"}, reportRequest{window: 7 * 24 * time.Hour, group: "Databases", path: "out.md"}},
+	}
+	for _, tt := range tests {
+		if got := parseReportArgs(tt.args, 6*time.Hour, now); got != tt.want {
+			t.Errorf("parseReportArgs(%q) = %+v, want %+v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestParseHandoverArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args []string
+		want handoverRequest
+	}{
+		{nil, handoverRequest{minSeverity: 2, window: 8 * time.Hour}},
+		{[]string{"high", "12h"}, handoverRequest{minSeverity: 4, window: 12 * time.Hour}},
+		{[]string{"notes.md", "0", "1d"}, handoverRequest{minSeverity: 0, window: 24 * time.Hour, path: "notes.md"}},
+	}
+	for _, tt := range tests {
+		got, err := parseHandoverArgs(tt.args, 2)
+		if err != nil || got != tt.want {
+			t.Errorf("parseHandoverArgs(%q) = %+v, %v; want %+v", tt.args, got, err, tt.want)
+		}
+	}
+	if _, err := parseHandoverArgs([]string{"a.md", "b.md"}, 2); err == nil {
+		t.Error("parseHandoverArgs should reject two files")
+	}
+}
+
+// TestHandoverWritten verifies that a handover without a file is copied to
+// the clipboard.
+func TestHandoverWritten(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	var copied string
+	m.copyText = func(text string) { copied = text }
+
+	model, _ := m.Update(HandoverWrittenMsg{Text: "# Shift handover\n", Open: 3})
+	updated := model.(Model)
+	if copied != "# Shift handover\n" {
+		t.Errorf("copied %q, want the handover", copied)
+	}
+	if !strings.Contains(updated.notifications.View(), "Copied the handover to the clipboard (3 open problems)") {
+		t.Errorf("expected a notification of the copy:\n%s", updated.notifications.View())
+	}
+}
+
+// TestDiagnoseAction verifies the availability diagnostics of the Hosts tab.
+func TestDiagnoseAction(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 40)
+	m.tabBar.SetActive(TabHosts)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+	press := func(s string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+
+	host := zabbix.Host{HostID: "1", Host: "web01", Status: "0", Interfaces: []zabbix.Interface{
+		{InterfaceID: "7", Type: "1", IP: "10.0.0.1", Available: "2", Error: "No route to host"},
+	}}
+	updated, _ := update(*m, ConnectedMsg{Version: "7.0.0"})
+	updated, _ = update(updated, HostsLoadedMsg{Hosts: []zabbix.Host{host}, Seq: updated.loads[TabHosts].seq})
+
+	updated, cmd := update(updated, press("D"))
+	if cmd == nil {
+		t.Fatal("D should load the diagnostics")
+	}
+	if msg, ok := cmd().(HostDiagnosticsLoadedMsg); !ok || msg.HostID != "1" {
+		t.Fatalf("D should load the selected host, got %+v", msg)
+	}
+	updated, _ = update(updated, HostDiagnosticsLoadedMsg{HostID: "1", Diagnostics: &zabbix.HostDiagnostics{Host: host}})
+	if updated.detailPane.DiagnosedHost() != "1" {
+		t.Fatal("the detail pane should show the diagnostics")
+	}
+
+	// Refreshing the hosts refreshes the diagnostics shown
+	updated, cmd = update(updated, HostsLoadedMsg{Hosts: []zabbix.Host{host}, Seq: updated.loads[TabHosts].seq})
+	if cmd == nil || updated.detailPane.DiagnosedHost() != "1" {
+		t.Error("a refresh should keep and reload the diagnostics")
+	}
+
+	updated, cmd = update(updated, press("F"))
+	if cmd == nil {
+		t.Fatal("F should check the host")
+	}
+	result, ok := cmd().(AvailabilityCheckMsg)
+	if !ok || result.HostName != "web01" {
+		t.Fatalf("result = %+v, want a check of web01", result)
+	}
+	updated, _ = update(updated, AvailabilityCheckMsg{HostName: "web01", Items: 1})
+	if !strings.Contains(updated.notifications.View(), "Checking 1 interface of web01 now") {
+		t.Errorf("a notification should confirm the check:\n%s", updated.notifications.View())
+	}
+
+	updated, _ = update(updated, press("D"))
+	if updated.detailPane.DiagnosedHost() != "" {
+		t.Error("D should show the host detail again")
+	}
+}
+
+// TestItemActions verifies enabling, disabling and checking items on the
+// Graphs tab.
+func TestItemActions(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 40)
+	m.tabBar.SetActive(TabGraphs)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+	press := func(s string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+
+	updated, _ := update(*m, ConnectedMsg{Version: "7.0.0"})
+	updated, _ = update(updated, ItemsLoadedMsg{Items: []zabbix.Item{{
+		ItemID: "5", HostID: "1", Name: "CPU utilization", Key: "system.cpu.util",
+		ValueType: zabbix.ItemValueTypeFloat, Status: zabbix.ItemStatusEnabled,
+		Hosts: []zabbix.Host{{HostID: "1", Host: "web01"}},
+	}}, Seq: updated.loads[TabGraphs].seq})
+	updated.graphList.ExpandAll()
+	for updated.graphList.SelectedItem() == nil {
+		updated.graphList.MoveDown()
+	}
+
+	updated, cmd := update(updated, press("e"))
+	if cmd == nil {
+		t.Fatal("e should disable the item")
+	}
+	result, ok := cmd().(ItemUpdateResultMsg)
+	if !ok || result.ItemID != "5" || result.Status != zabbix.ItemStatusDisabled {
+		t.Fatalf("result = %+v, want item 5 disabled", result)
+	}
+	updated, _ = update(updated, result)
+	if !updated.graphList.SelectedItem().IsDisabled() || !strings.Contains(updated.notifications.View(), "CPU utilization disabled") {
+		t.Error("the item should be shown as disabled")
+	}
+
+	// A disabled item is enabled again
+	_, cmd = update(updated, press("e"))
+	if result, ok = cmd().(ItemUpdateResultMsg); !ok || result.Status != zabbix.ItemStatusEnabled {
+		t.Errorf("result = %+v, want the item enabled", result)
+	}
+
+	updated, cmd = update(updated, press("F"))
+	if cmd == nil {
+		t.Fatal("F should check the item")
+	}
+	if _, ok := cmd().(ItemCheckResultMsg); !ok {
+		t.Fatal("F should check the selected item")
+	}
+	updated, _ = update(updated, ItemCheckResultMsg{ItemName: "CPU utilization", Err: errors.New("wrong item type")})
+	if !strings.Contains(updated.notifications.View(), "Could not check CPU utilization now: wrong item type") {
+		t.Errorf("a notification should show the error:\n%s", updated.notifications.View())
+	}
+}
+
+// TestExportChart verifies exporting the chart of an item on the Graphs tab.
+func TestExportChart(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Graphs.ExportDir = filepath.Join(t.TempDir(), "charts")
+	cfg.Graphs.ExportPNG = true
+	m := New(cfg, theme.DefaultTheme())
+	m.SetSize(160, 40)
+	m.tabBar.SetActive(TabGraphs)
+	var copied string
+	m.copyText = func(text string) { copied = text }
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+
+	updated, _ := update(*m, ConnectedMsg{Version: "7.0.0"})
+	updated, _ = update(updated, ItemsLoadedMsg{Items: []zabbix.Item{{
+		ItemID: "5", HostID: "1", Name: "CPU utilization", Key: "system.cpu.util", Units: "%",
+		ValueType: zabbix.ItemValueTypeFloat, Status: zabbix.ItemStatusEnabled,
+		Hosts: []zabbix.Host{{HostID: "1", Host: "web01"}},
+	}}, Seq: updated.loads[TabGraphs].seq})
+	updated, _ = update(updated, HostHistoryLoadedMsg{HostID: "1", History: map[string][]zabbix.History{
+		"5": {{ItemID: "5", Clock: "1772355600", Value: "12.5"}, {ItemID: "5", Clock: "1772355660", Value: "40"}},
+	}})
+	updated.graphList.ExpandAll()
+	for updated.graphList.SelectedItem() == nil {
+		updated.graphList.MoveDown()
+	}
+
+	updated, cmd := update(updated, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	if cmd == nil {
+		t.Fatal("X should export the chart")
+	}
+	msg, ok := cmd().(ChartExportedMsg)
+	if !ok || msg.Err != nil || len(msg.Paths) != 2 {
+		t.Fatalf("export = %+v, want a CSV and a PNG file", msg)
+	}
+	data, err := os.ReadFile(msg.Paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(msg.Paths[0], ".csv") || !strings.Contains(string(data), "time,clock,value\n") ||
+		!strings.Contains(string(data), ",1772355660,40\n") {
+		t.Errorf("%s =\n%s\nwant the history as CSV", msg.Paths[0], data)
+	}
+	if data, err := os.ReadFile(msg.Paths[1]); err != nil || !bytes.HasPrefix(data, []byte("\x89PNG")) {
+		t.Errorf("%s should be a PNG image (%v)", msg.Paths[1], err)
+	}
+	if !strings.HasPrefix(filepath.Base(msg.Paths[0]), "web01_system.cpu.util_") {
+		t.Errorf("file %s should be named after the host and item key", msg.Paths[0])
+	}
+	updated, _ = update(updated, msg)
+	if !strings.Contains(updated.notifications.View(), "Exported the chart of CPU utilization") {
+		t.Errorf("a notification should confirm the export:\n%s", updated.notifications.View())
+	}
+
+	updated.exportChart("clipboard")
+	if !strings.HasPrefix(copied, "time,clock,value\n") {
+		t.Errorf("clipboard = %q, want the CSV", copied)
+	}
+}
+
+// TestFollowLog verifies following a log item on the Graphs tab.
+func TestFollowLog(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 40)
+	m.tabBar.SetActive(TabGraphs)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+	press := func(s string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+
+	updated, _ := update(*m, ConnectedMsg{Version: "7.0.0"})
+	updated, _ = update(updated, ItemsLoadedMsg{Items: []zabbix.Item{{
+		ItemID: "7", HostID: "1", Name: "Syslog", Key: "log[/var/log/syslog]",
+		ValueType: zabbix.ItemValueTypeLog, Status: zabbix.ItemStatusEnabled,
+		Hosts: []zabbix.Host{{HostID: "1", Host: "web01"}},
+	}}, Seq: updated.loads[TabGraphs].seq})
+	updated.graphList.ExpandAll()
+	for updated.graphList.SelectedItem() == nil {
+		updated.graphList.MoveDown()
+	}
+
+	updated, cmd := update(updated, press("f"))
+	if updated.follow == nil || cmd == nil {
+		t.Fatal("f should follow the log item")
+	}
+	msg, ok := cmd().(LogLinesMsg)
+	if !ok || msg.ItemID != "7" {
+		t.Fatalf("f should poll the item, got %+v", msg)
+	}
+	msg.History = []zabbix.History{{ItemID: "7", Clock: "1700000000", Value: "sshd: Accepted publickey"}}
+	updated, cmd = update(updated, msg)
+	if cmd == nil || updated.graphList.SelectedItem().LastValue != "sshd: Accepted publickey" {
+		t.Fatal("new lines should be appended and polled again")
+	}
+	if view := updated.detailPane.View(); !strings.Contains(view, "(following)") || !strings.Contains(view, "Accepted publickey") {
+		t.Errorf("detail should show the followed lines:\n%s", view)
+	}
+
+	model, _ := updated.executeCommand("highlight [")
+	if updated, ok = model.(Model); !ok || !strings.Contains(updated.statusBar.View(), "Invalid pattern") {
+		t.Error(":highlight should reject invalid patterns")
+	}
+	model, _ = updated.executeCommand("highlight Accepted")
+	if updated, ok = model.(Model); !ok || !strings.Contains(updated.detailPane.View(), "Highlight:") {
+		t.Error(":highlight should show the pattern")
+	}
+
+	// Polls stop once another tab is shown
+	updated.tabBar.SetActive(TabHosts)
+	updated, cmd = update(updated, FollowTickMsg{Seq: updated.follow.seq})
+	if updated.follow != nil || cmd != nil {
+		t.Error("following should stop when the item is no longer shown")
+	}
+}
+
+// TestFormulaReference verifies that Enter in the detail of a calculated
+// item selects the item its formula references.
+func TestFormulaReference(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 40)
+	m.tabBar.SetActive(TabGraphs)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+
+	hosts := []zabbix.Host{{HostID: "1", Host: "web01"}}
+	updated, _ := update(*m, ConnectedMsg{Version: "7.0.0"})
+	updated, _ = update(updated, ItemsLoadedMsg{Items: []zabbix.Item{
+		{
+			ItemID: "1", HostID: "1", Name: "/: Free space %", Key: "vfs.fs.pfree[/]", Type: zabbix.ItemTypeCalculated,
+			Params: "100*last(//vfs.fs.size[/,free])/last(//vfs.fs.size[/,total])", ValueType: zabbix.ItemValueTypeFloat, Hosts: hosts,
+		},
+		{ItemID: "2", HostID: "1", Name: "/: Free space", Key: "vfs.fs.size[/,free]", ValueType: zabbix.ItemValueTypeUnsigned, Hosts: hosts},
+		{ItemID: "3", HostID: "1", Name: "/: Total space", Key: "vfs.fs.size[/,total]", ValueType: zabbix.ItemValueTypeUnsigned, Hosts: hosts},
+	}, Seq: updated.loads[TabGraphs].seq})
+	updated.graphList.ExpandAll()
+	for item := updated.graphList.SelectedItem(); item == nil || item.ItemID != "1"; item = updated.graphList.SelectedItem() {
+		updated.graphList.MoveDown()
+	}
+	updated.detailPane.SetItem(updated.graphList.SelectedItem(), nil)
+	updated.setFocus(PaneDetail)
+	if view := updated.detailPane.View(); !strings.Contains(view, "//vfs.fs.size[/,total]") {
+		t.Fatalf("detail should show the formula's references:\n%s", view)
+	}
+
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyRight})
+	updated, cmd := update(updated, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter should go to the selected reference")
+	}
+	updated, _ = update(updated, cmd())
+	if item := updated.graphList.SelectedItem(); item == nil || item.ItemID != "3" {
+		t.Errorf("selected item = %+v, want the total space item", item)
+	}
+	if updated.focused != PaneList {
+		t.Error("the list should be focused on the referenced item")
+	}
+}
+
+// TestBaseline verifies that b compares the selected item's chart with its
+// history of a day and a week ago.
+func TestBaseline(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 60)
+	m.tabBar.SetActive(TabGraphs)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+
+	now := time.Now()
+	point := func(offset time.Duration, value string) zabbix.History {
+		return zabbix.History{ItemID: "1", Clock: strconv.FormatInt(now.Add(-offset).Unix(), 10), Value: value}
+	}
+	hosts := []zabbix.Host{{HostID: "1", Host: "web01"}}
+	updated, _ := update(*m, ConnectedMsg{Version: "7.0.0"})
+	updated, _ = update(updated, ItemsLoadedMsg{Items: []zabbix.Item{
+		{ItemID: "1", HostID: "1", Name: "CPU load", Key: "system.cpu.load", ValueType: zabbix.ItemValueTypeFloat, Hosts: hosts},
+	}, Seq: updated.loads[TabGraphs].seq})
+	updated.graphList.ExpandAll()
+	for updated.graphList.SelectedItem() == nil {
+		updated.graphList.MoveDown()
+	}
+	updated.detailPane.SetItem(updated.graphList.SelectedItem(), []zabbix.History{point(time.Hour, "2"), point(0, "4")})
+
+	updated, cmd := update(updated, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if cmd == nil {
+		t.Fatal("b should load the item's past history")
+	}
+	if msg, ok := cmd().(BaselinesLoadedMsg); !ok || msg.ItemID != "1" {
+		t.Fatalf("b sent %+v, want the past history of item 1", msg)
+	}
+	updated, _ = update(updated, BaselinesLoadedMsg{ItemID: "1", Baselines: []detail.Baseline{
+		{Offset: 24 * time.Hour, History: []zabbix.History{point(25*time.Hour, "1"), point(24*time.Hour, "2")}},
+		{Offset: 7 * 24 * time.Hour},
+	}})
+	if view := updated.detailPane.View(); !strings.Contains(view, "1d ago: Avg: 1.50 (now +100%)") || !strings.Contains(view, "━ 7d ago") {
+		t.Errorf("the chart should be compared with 1d and 7d ago:\n%s", view)
+	}
+
+	updated, cmd = update(updated, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if cmd != nil || strings.Contains(updated.detailPane.View(), "1d ago") {
+		t.Error("b again should stop comparing")
+	}
+}
+
+// TestCompareAcrossHosts verifies that = compares the selected item with
+// the same key on other hosts, then overlays them, then closes.
+func TestCompareAcrossHosts(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 60)
+	m.tabBar.SetActive(TabGraphs)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+
+	item := func(id, host, key string) zabbix.Item {
+		return zabbix.Item{
+			ItemID: id, HostID: id, Name: "CPU idle", Key: key, ValueType: zabbix.ItemValueTypeFloat,
+			LastValue: "90", Hosts: []zabbix.Host{{HostID: id, Host: host}},
+		}
+	}
+	items := []zabbix.Item{
+		item("1", "web02", "system.cpu.util[,idle]"),
+		item("2", "web01", "system.cpu.util[,idle]"),
+		item("3", "db01", "system.cpu.util[,idle]"),
+		item("4", "web03", "vm.memory.size"),
+	}
+	updated, _ := update(*m, ConnectedMsg{Version: "7.0.0"})
+	updated, _ = update(updated, ItemsLoadedMsg{Items: items, Seq: updated.loads[TabGraphs].seq})
+	updated.graphList.ExpandAll()
+	for updated.graphList.SelectedItem() == nil || updated.graphList.SelectedItem().ItemID != "1" {
+		updated.graphList.MoveDown()
+	}
+
+	got := comparedItems(items, &items[0], "web*")
+	if len(got) != 2 || got[0].ItemID != "1" || got[1].ItemID != "2" {
+		t.Errorf("comparedItems(web*) = %+v, want web02 then web01", got)
+	}
+
+	updated, cmd := update(updated, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("=")})
+	if cmd == nil {
+		t.Fatal("= should load the history of the item's key on the other hosts")
+	}
+	if msg, ok := cmd().(CompareLoadedMsg); !ok || msg.ItemID != "1" || msg.Hosts != 3 {
+		t.Fatalf("= sent %+v, want item 1 compared across 3 hosts", msg)
+	}
+
+	history := []zabbix.History{{ItemID: "1", Clock: strconv.FormatInt(time.Now().Unix(), 10), Value: "90"}}
+	var series []detail.Series
+	for _, i := range comparedItems(items, &items[0], "") {
+		series = append(series, detail.Series{Item: i, History: history})
+	}
+	updated, _ = update(updated, CompareLoadedMsg{ItemID: "1", Series: series, Hosts: 3})
+	if view := updated.detailPane.View(); !strings.Contains(view, "COMPARE HOSTS") || !strings.Contains(view, "db01") {
+		t.Fatalf("the item should be compared across hosts:\n%s", view)
+	}
+
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("=")})
+	if !updated.detailPane.Overlaid() {
+		t.Error("= again should overlay the hosts on one chart")
+	}
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("=")})
+	if updated.detailPane.ComparedItem() != "" {
+		t.Error("= a third time should show the item's detail again")
+	}
+}
+
+// TestSLA verifies that unacknowledged alerts past their severity's limit
+// are counted in the status bar.
+func TestSLA(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.SLA = map[string]string{"disaster": "15m"}
+	m := New(cfg, theme.DefaultTheme())
+	m.SetSize(120, 40)
+
+	hourAgo := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	var model tea.Model = *m
+	model, _ = model.Update(ProblemsLoadedMsg{Problems: []zabbix.Problem{
+		{EventID: "1", Name: "Agent down", Severity: "5", Clock: hourAgo},
+		{EventID: "2", Name: "Link down", Severity: "5", Clock: hourAgo},
+		{EventID: "3", Name: "Disk full", Severity: "5", Clock: hourAgo, Acknowledged: "1"},
+	}})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if !strings.Contains(updated.statusBar.View(), "2 past SLA") {
+		t.Error("expected \"2 past SLA\" in the status bar")
+	}
+}
+
+// TestScreenReaderView verifies the plain layout: no box drawing and the
+// selected item announced with a "selected:" prefix.
+func TestScreenReaderView(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Display.ScreenReader = true
+	m := New(cfg, theme.DefaultTheme())
+	m.SetSize(120, 40)
+
+	var model tea.Model = *m
+	model, _ = model.Update(ProblemsLoadedMsg{Problems: []zabbix.Problem{
+		{EventID: "1", Name: "CPU usage high", Severity: "5", Hosts: []zabbix.Host{{Name: "web01"}}},
+		{EventID: "2", Name: "Disk space low", Severity: "2", Hosts: []zabbix.Host{{Name: "db01"}}},
+	}})
+
+	view := model.View()
+	for _, want := range []string{
+		"Tab 1 of 5: Alerts, list focused",
+		"Alerts: 2 of 2 problems",
+		"selected: Disaster, web01, CPU usage high",
+		"Warning, db01, Disk space low",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("screen reader view missing %q", want)
+		}
+	}
+	if strings.ContainsAny(view, "╭│─●") {
+		t.Error("screen reader view should not contain box drawing or glyphs")
+	}
+	if lines := strings.Count(view, "\n") + 1; lines > 40 {
+		t.Errorf("screen reader view has %d lines, want at most the terminal height 40", lines)
+	}
+}
+
+// TestHelpScreen verifies that the help screen is built from the active
+// keymap and can be searched by action name.
+func TestHelpScreen(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+	m.keys.Acknowledge = key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "acknowledge"))
+
+	var model tea.Model = *m
+	press := func(s string) {
+		for _, r := range s {
+			model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	press("?")
+	if !model.(Model).showHelp {
+		t.Fatal("expected help to be shown")
+	}
+	view := model.View()
+	if !strings.Contains(view, "Acknowledge") || !strings.Contains(view, "Edit triggers") {
+		t.Error("help should list the keymap actions")
+	}
+
+	press("/ack")
+	view = model.View()
+	if !strings.Contains(view, "x") || !strings.Contains(view, "Acknowledge") {
+		t.Error("search should find the remapped acknowledge binding")
+	}
+	if strings.Contains(view, "Edit triggers") {
+		t.Error("search should hide non-matching actions")
+	}
+
+	// q is typed into the search box rather than closing the help
+	press("q")
+	if !model.(Model).showHelp {
+		t.Fatal("typing in the search box should not close help")
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(Model).showHelp {
+		t.Error("expected Esc to close help after leaving search")
+	}
+}
+
+// TestTutorial verifies that :tutorial starts the tour, that steps focus the
+// pane they describe, and that dismissing it does not quit.
+func TestTutorial(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+
+	model, _ := m.executeCommand("tutorial")
+	if !model.(Model).tutorial.Active() {
+		t.Fatal("expected :tutorial to start the tour")
+	}
+	if !strings.Contains(model.View(), "Welcome to chotko") {
+		t.Error("expected the first step in the view")
+	}
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	for range 4 {
+		model, _ = model.Update(enter)
+	}
+	if got := model.(Model).tutorial.Current().Title; got != "Detail" {
+		t.Fatalf("step = %q, want Detail", got)
+	}
+	if model.(Model).focused != PaneDetail {
+		t.Error("the detail step should focus the detail pane")
+	}
+
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if cmd != nil {
+		t.Error("q should dismiss the tour, not quit")
+	}
+	if model.(Model).tutorial.Active() || model.(Model).focused != PaneList {
+		t.Error("expected the tour to end with the list focused")
+	}
+}
+
+// TestClick_StatusBar verifies that clicking an alert count in the status
+// bar filters by its severity and clicking the filter indicator clears it.
+// It isn't parallel, as the views of other tests would replace the zones.
+func TestClick_StatusBar(t *testing.T) {
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 40)
+
+	var model tea.Model = *m
+	model, _ = model.Update(ProblemsLoadedMsg{Problems: []zabbix.Problem{
+		{EventID: "1", Name: "Agent down", Severity: "5"},
+		{EventID: "2", Name: "Disk full", Severity: "4"},
+		{EventID: "3", Name: "Swap low", Severity: "2"},
+	}})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+
+	click := func(id string) {
+		t.Helper()
+		model.View()
+		z := waitForZone(t, id)
+		model, _ = model.Update(tea.MouseMsg{X: z.StartX, Y: z.StartY, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft})
+	}
+
+	click("status_filter")
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.minSeverity != 0 {
+		t.Errorf("minSeverity = %d after clicking the filter indicator, want 0", updated.minSeverity)
+	}
+
+	click("status_severity_4")
+	updated, ok = model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.minSeverity != 4 {
+		t.Errorf("minSeverity = %d after clicking the High count, want 4", updated.minSeverity)
+	}
+	if n := updated.alertList.FilteredCount(); n != 2 {
+		t.Errorf("%d alerts shown, want 2", n)
+	}
+}
+
+// waitForZone returns the zone of a rendered view, which bubblezone
+// records in the background.
+func waitForZone(t *testing.T, id string) *zone.ZoneInfo {
+	t.Helper()
+	var z *zone.ZoneInfo
+	for range 100 {
+		if z = zone.Get(id); !z.IsZero() {
+			return z
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("zone %s not rendered", id)
+	return nil
+}
+
+// TestContextMenu verifies that right-clicking an alert selects it and
+// opens its context menu, whose items run the actions of their keys, and
+// that double-clicking a row opens the detail pane. Like
+// TestClick_StatusBar, it isn't parallel.
+func TestContextMenu(t *testing.T) {
+	cfg := testConfig()
+	cfg.Server.URL = "https://zabbix.example.com/"
+	m := New(cfg, theme.DefaultTheme())
+	m.SetSize(160, 40)
+	var opened string
+	m.openURL = func(page string) error {
+		opened = page
+		return nil
+	}
+
+	var model tea.Model = *m
+	model, _ = model.Update(ProblemsLoadedMsg{Problems: []zabbix.Problem{
+		{EventID: "1", Name: "Agent down", Severity: "5", Object: "0", ObjectID: "100", Hosts: []zabbix.Host{{HostID: "10", Name: "web01"}}},
+		{EventID: "2", Name: "Disk full", Severity: "4", Object: "0", ObjectID: "200", Hosts: []zabbix.Host{{HostID: "20", Name: "db01"}}},
+	}})
+	model.View()
+	row := waitForZone(t, "alert_1")
+	model, _ = model.Update(tea.MouseMsg{X: row.StartX + 2, Y: row.StartY, Action: tea.MouseActionPress, Button: tea.MouseButtonRight})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if !updated.contextMenu.Visible() {
+		t.Fatal("right-clicking an alert should open the context menu")
+	}
+	if selected := updated.alertList.Selected(); selected == nil || selected.EventID != "2" {
+		t.Error("right-clicking an alert should select it")
+	}
+	for _, want := range []string{"Acknowledge", "Edit triggers", "Open in browser"} {
+		if !strings.Contains(model.View(), want) {
+			t.Errorf("context menu should offer %q", want)
+		}
+	}
+
+	// Up from the first item wraps to the last, "Open in browser"
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyUp})
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter should choose the menu item")
+	}
+	model, _ = model.Update(cmd())
+	if want := "https://zabbix.example.com/tr_events.php?triggerid=200&eventid=2"; opened != want {
+		t.Errorf("opened %q, want %q", opened, want)
+	}
+
+	model.View()
+	row = waitForZone(t, "alert_0")
+	click := tea.MouseMsg{X: row.StartX + 2, Y: row.StartY, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft}
+	model, _ = model.Update(click)
+	if updated, _ = model.(Model); updated.focused != PaneList {
+		t.Fatal("a single click should keep the focus on the list")
+	}
+	model, _ = model.Update(click)
+	if updated, _ = model.(Model); updated.focused != PaneDetail {
+		t.Error("a double-click should open the detail pane")
+	}
+}
+
+// TestCommandPalette verifies that Ctrl+K lists actions with and without
+// keys, that choosing one runs it, and that it is listed first next time.
+func TestCommandPalette(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 40)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+	typeText := func(model Model, s string) Model {
+		t.Helper()
+		for _, r := range s {
+			model, _ = update(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		return model
+	}
+
+	updated, _ := update(*m, tea.KeyMsg{Type: tea.KeyCtrlK})
+	if !updated.palette.Visible() {
+		t.Fatal("Ctrl+K should open the command palette")
+	}
+	if !strings.Contains(updated.View(), "Acknowledge") {
+		t.Error("palette should list the key actions")
+	}
+
+	// A command without a key, run as typed in command mode
+	updated = typeText(updated, "queue")
+	if !strings.Contains(updated.View(), "Show items late to be collected") {
+		t.Error("palette should list the commands")
+	}
+	updated, cmd := update(updated, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter should choose the action")
+	}
+	updated, _ = update(updated, cmd())
+	if updated.palette.Visible() {
+		t.Error("running an action should close the palette")
+	}
+	if !slices.Equal(updated.recentActions, []string{"cmd::queue"}) {
+		t.Errorf("recentActions = %v, want the queue command", updated.recentActions)
+	}
+
+	// A command needing arguments is completed in command mode
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyCtrlK})
+	if got := updated.palette.Matches()[0].ID; got != "cmd::queue" {
+		t.Errorf("first action = %s, want the one used last", got)
+	}
+	updated = typeText(updated, "events lookback")
+	updated, cmd = update(updated, tea.KeyMsg{Type: tea.KeyEnter})
+	updated, _ = update(updated, cmd())
+	if updated.mode != ModeCommand || updated.commandInput.Value() != "window " {
+		t.Errorf("mode %d, input %q; want command mode with \"window \"", updated.mode, updated.commandInput.Value())
+	}
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyEsc})
+
+	// A key action runs as if its key was pressed
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyCtrlK})
+	updated = typeText(updated, "hosts tab")
+	updated, cmd = update(updated, tea.KeyMsg{Type: tea.KeyEnter})
+	updated, _ = update(updated, cmd())
+	if updated.tabBar.Active() != TabHosts {
+		t.Errorf("tab = %d, want the Hosts tab", updated.tabBar.Active())
+	}
+	if len(updated.recentActions) != 3 {
+		t.Errorf("recentActions = %v, want the three actions run", updated.recentActions)
+	}
+}
+
+// TestDragSelect verifies that dragging over alerts marks them for bulk
+// actions and a click clears the marks. Like TestClick_StatusBar, it
+// isn't parallel.
+func TestDragSelect(t *testing.T) {
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 40)
+
+	var model tea.Model = *m
+	model, _ = model.Update(ProblemsLoadedMsg{Problems: []zabbix.Problem{
+		{EventID: "1", Name: "Agent down", Severity: "5"},
+		{EventID: "2", Name: "Disk full", Severity: "4"},
+		{EventID: "3", Name: "Swap low", Severity: "2"},
+	}})
+	model.View()
+	first := waitForZone(t, "alert_0")
+	last := waitForZone(t, "alert_1")
+
+	mouse := func(row *zone.ZoneInfo, action tea.MouseAction) {
+		model, _ = model.Update(tea.MouseMsg{X: row.StartX + 2, Y: row.StartY, Action: action, Button: tea.MouseButtonLeft})
+	}
+	mouse(first, tea.MouseActionPress)
+	mouse(last, tea.MouseActionMotion)
+	mouse(last, tea.MouseActionRelease)
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if n := len(updated.alertList.Marked()); n != 2 {
+		t.Fatalf("%d alerts marked after dragging over two rows, want 2", n)
+	}
+	if updated.focused != PaneList {
+		t.Error("the release ending a drag should not count as a click")
+	}
+
+	mouse(first, tea.MouseActionPress)
+	mouse(first, tea.MouseActionRelease)
+	if updated, _ = model.(Model); updated.alertList.Marked() != nil {
+		t.Error("a click should clear the marks")
+	}
+}
+
+// TestDashboard verifies opening a dashboard of the server with :dashboard.
+func TestDashboard(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+
+	list := []zabbix.Dashboard{{DashboardID: "1", Name: "Global view"}, {DashboardID: "2", Name: "Network"}}
+	updated, _ := update(*m, DashboardsLoadedMsg{Name: "nope", Dashboards: list})
+	if updated.showDashboard || !strings.Contains(updated.statusBar.View(), `No dashboard named "nope"`) {
+		t.Error("an unknown dashboard name should be reported")
+	}
+	updated, _ = update(updated, DashboardsLoadedMsg{Dashboards: list})
+	if !updated.showDashboard || !strings.Contains(updated.View(), "Network") {
+		t.Fatalf("the dashboards should be listed:\n%s", updated.View())
+	}
+
+	d := &zabbix.Dashboard{DashboardID: "2", Name: "Network", Columns: 24, Pages: []zabbix.DashboardPage{{Widgets: []zabbix.Widget{
+		{WidgetID: "1", Type: zabbix.WidgetTypeProblems, Name: "Uplink problems", Width: "24", Height: "4"},
+	}}}}
+	updated, cmd := update(updated, DashboardLoadedMsg{Dashboard: d})
+	if cmd == nil {
+		t.Fatal("the widget data should be loaded")
+	}
+	updated, _ = update(updated, WidgetDataLoadedMsg{DashboardID: "2", Data: &zabbix.WidgetData{
+		Problems: map[string][]zabbix.Problem{"1": {{EventID: "9", Name: "Link down", Severity: "3"}}},
+	}})
+	if view := updated.View(); !strings.Contains(view, "Uplink problems") || !strings.Contains(view, "Link down") {
+		t.Errorf("the dashboard should show its widgets:\n%s", view)
+	}
+
+	// Esc goes back to the list, and again to the tabs
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyEsc})
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.showDashboard {
+		t.Error("esc should close the dashboards")
+	}
+}
+
+// TestDashboardGroup verifies that clicking a host group on a dashboard shows
+// its problems in the Alerts tab until the filters are cleared.
+func TestDashboardGroup(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(120, 40)
+	m.tabBar.SetActive(TabHosts)
+	m.showDashboard = true
+	m.dashboardView.ShowList([]zabbix.Dashboard{{DashboardID: "1", Name: "Global view"}})
+
+	model, _ := m.Update(dashboard.GroupMsg{Group: zabbix.HostGroup{GroupID: "2", Name: "Web servers"}})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.showDashboard || updated.dashboardView.Visible() {
+		t.Error("the dashboard should be closed")
+	}
+	if updated.tabBar.Active() != TabAlerts || updated.alertGroup == nil || updated.alertGroup.GroupID != "2" {
+		t.Errorf("the Alerts tab should show the group's problems, tab %d group %v", updated.tabBar.Active(), updated.alertGroup)
+	}
+	if !strings.Contains(updated.statusBar.View(), "Alerts of host group Web servers") {
+		t.Errorf("the status bar should tell the group:\n%s", updated.statusBar.View())
+	}
+
+	model, _, _ = updated.handleClearFilter()
+	if updated, _ = model.(Model); updated.alertGroup != nil {
+		t.Error("clearing the filters should show the problems of all host groups")
+	}
+}
+
+// TestSoundHooksPartialLoads verifies that problems left out of a further
+// page or a filtered load are not forgotten by the hooks, so they don't run
+// again on the next refresh.
+// TestHostCountsGroups verifies that the status bar counts of a kiosk or
+// role filter are those of its host groups, whichever tab loads them.
+func TestHostCountsGroups(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.client = zabbix.NewClient(demo.URL, zabbix.WithTransport(demo.New(1)))
+	all := m.loadHostCounts()().(HostCountsLoadedMsg)
+	if all.Err != nil {
+		t.Fatalf("loadHostCounts() error = %v", all.Err)
+	}
+
+	m.kioskGroups = []zabbix.HostGroup{{GroupID: "2", Name: "Linux servers"}}
+	counts := m.loadHostCounts()().(HostCountsLoadedMsg)
+	hosts := m.loadHosts()().(HostsLoadedMsg)
+	if counts.Err != nil || hosts.Err != nil {
+		t.Fatalf("loads of the kiosk's group failed: %v, %v", counts.Err, hosts.Err)
+	}
+	if *counts.Counts != *hosts.Counts {
+		t.Errorf("counts = %+v, those of the Hosts tab = %+v", *counts.Counts, *hosts.Counts)
+	}
+	if counts.Counts.Total == 0 || counts.Counts.Total >= all.Counts.Total {
+		t.Errorf("counts of the kiosk's group = %+v, want some of the %d hosts", *counts.Counts, all.Counts.Total)
+	}
+}
+
+func TestSoundHooksPartialLoads(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Hooks.Severities = map[string]config.Hook{"high": {Command: "true"}}
+	m := New(cfg, theme.DefaultTheme())
+	m.SetSize(160, 40)
+
+	clock := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+	first := []zabbix.Problem{{EventID: "1", Severity: "4", Clock: clock}}
+	page := []zabbix.Problem{{EventID: "2", Severity: "4", Clock: clock}}
+	var model tea.Model = *m
+	load := func(msg ProblemsLoadedMsg) {
+		t.Helper()
+		updated := model.(Model)
+		_, msg.Seq = updated.beginLoad(TabAlerts)
+		model, _ = updated.Update(msg)
+	}
+	load(ProblemsLoadedMsg{Problems: first, Next: "1"})
+	load(ProblemsLoadedMsg{Problems: page, Append: true})
+	// A kiosk rotation to another group's problems
+	load(ProblemsLoadedMsg{Problems: page})
+	updated := model.(Model)
+	if updated.runHooks(first, false) != nil || updated.runHooks(page, false) != nil {
+		t.Error("problems left out of a partial load should not run their hooks again")
+	}
+	load(ProblemsLoadedMsg{Problems: page, Complete: true})
+	if updated = model.(Model); updated.runHooks(first, false) == nil {
+		t.Error("a problem missing from a complete load is closed, and should run its hook if it opens again")
+	}
+
+	// Loads scoped to the kiosk's group are never complete
+	client := zabbix.NewClient(demo.URL, zabbix.WithTransport(demo.New(1)))
+	m.client = client
+	if msg, ok := m.fetchProblems("", 1000)().(ProblemsLoadedMsg); !ok || msg.Err != nil || !msg.Complete {
+		t.Fatalf("load of all problems = %+v, want complete", msg)
+	}
+	m.kioskGroups = []zabbix.HostGroup{{GroupID: "1", Name: "Linux servers"}}
+	if msg := m.fetchProblems("", 1000)().(ProblemsLoadedMsg); msg.Complete {
+		t.Error("a load of the kiosk's group should not be complete")
+	}
+	m.kioskGroups = nil
+	if msg := m.fetchProblems("", 1)().(ProblemsLoadedMsg); msg.Complete {
+		t.Error("a load cut short by its limit should not be complete")
+	}
+}
+
+func TestSoundHooks(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Hooks.Severities = map[string]config.Hook{"high": {Command: "true"}}
+	m := New(cfg, theme.DefaultTheme())
+	m.SetSize(160, 40)
+
+	newProblem := func(id string) []zabbix.Problem {
+		clock := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+		return []zabbix.Problem{{EventID: id, Severity: "4", Clock: clock}}
+	}
+	if m.runHooks(newProblem("1"), true) == nil {
+		t.Fatal("a new high problem should run its hook")
+	}
+	if m.runHooks(newProblem("1"), true) != nil {
+		t.Error("a problem's hook should run once")
+	}
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if !updated.soundMuted || !strings.Contains(ansi.Strip(updated.statusBar.View()), "♪ off") {
+		t.Fatal("m should mute the hooks and show it in the status bar")
+	}
+	if updated.runHooks(newProblem("2"), true) == nil {
+		t.Error("muted hooks should still run their commands")
+	}
+	updated.toggleSound()
+	if updated.runHooks(newProblem("2"), true) != nil {
+		t.Error("problems that appeared while muted should not run their hooks on unmute")
+	}
+	if !strings.Contains(ansi.Strip(updated.statusBar.View()), "♪ on") {
+		t.Error("the status bar should show the hooks unmuted")
+	}
+
+	if plain := New(testConfig(), theme.DefaultTheme()); strings.Contains(ansi.Strip(plain.statusBar.View()), "♪") {
+		t.Error("the status bar should not show sound without hooks")
+	}
+}
+
+// TestTokens verifies the API token list and that rotating the token waits
+// for confirmation.
+func TestTokens(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 40)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+	unix := func(d time.Duration) string { return strconv.FormatInt(time.Now().Add(d).Unix(), 10) }
+
+	updated, _ := update(*m, TokensLoadedMsg{CurrentID: "2", Tokens: []zabbix.Token{
+		{TokenID: "1", Name: "old script", Status: "0", CreatedAt: unix(-48 * time.Hour), ExpiresAt: unix(-time.Hour), LastAccess: "0"},
+		{TokenID: "2", Name: "chotko", Status: "0", CreatedAt: unix(-24 * time.Hour), ExpiresAt: "0", LastAccess: unix(-time.Minute)},
+	}})
+	view := updated.errorModal.View()
+	if !updated.showError || !strings.Contains(view, "API Tokens") {
+		t.Fatalf("expected the tokens to be listed:\n%s", view)
+	}
+	for _, want := range []string{"  old script  expired", "* chotko", "1m ago", "never"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("token list missing %q:\n%s", want, view)
+		}
+	}
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyEsc})
+
+	model, _ := updated.executeCommand("tokens rotate")
+	updated = model.(Model)
+	if !updated.pendingRotate || !strings.Contains(updated.errorModal.View(), "Rotate the API token?") {
+		t.Fatal("expected the rotation to wait for confirmation")
+	}
+	updated, _ = update(updated, tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.pendingRotate || updated.showError {
+		t.Fatal("Esc should cancel the rotation")
+	}
+	model, _ = updated.executeCommand("tokens rotate")
+	updated = model.(Model)
+	updated, cmd := update(updated, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if updated.pendingRotate || cmd == nil {
+		t.Fatal("y should rotate the token")
+	}
+
+	updated, _ = update(updated, TokenRotatedMsg{Secret: "new-secret"})
+	if updated.config.Auth.Token != "new-secret" || !strings.Contains(updated.errorModal.View(), "new-secret") {
+		t.Errorf("expected the new token to be used and shown, token = %q", updated.config.Auth.Token)
+	}
+}
+
+// TestCopyHostAddress verifies that y copies the selected host's address,
+// resolving DNS names.
+func TestCopyHostAddress(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 40)
+	var copied string
+	m.copyText = func(text string) { copied = text }
+	m.lookupHost = func(_ context.Context, name string) ([]string, error) {
+		if name == "web01.example.com" {
+			return []string{"2001:db8::10"}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+	m.tabBar.SetActive(TabHosts)
+	updated, _ := update(*m, HostsLoadedMsg{Seq: m.loads[TabHosts].seq, Hosts: []zabbix.Host{
+		{HostID: "1", Host: "db01", Interfaces: []zabbix.Interface{{IP: "2001:db8::1", UseIP: "1", Port: "10050", Main: "1"}}},
+		{HostID: "2", Host: "web01", Interfaces: []zabbix.Interface{{IP: "192.0.2.1", DNS: "web01.example.com", UseIP: "0", Main: "1"}}},
+		{HostID: "3", Host: "web02", Interfaces: []zabbix.Interface{{DNS: "web02.example.com", UseIP: "0", Main: "1"}}},
+	}})
+
+	y := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}
+	updated, cmd := update(updated, y)
+	if cmd != nil || copied != "2001:db8::1" {
+		t.Errorf("copied %q, want the IP without resolving", copied)
+	}
+
+	updated.hostList.MoveDown()
+	updated, cmd = update(updated, y)
+	if cmd == nil {
+		t.Fatal("expected the DNS name to be resolved")
+	}
+	updated, _ = update(updated, cmd())
+	if copied != "2001:db8::10" {
+		t.Errorf("copied %q, want the resolved address", copied)
+	}
+
+	updated.hostList.MoveDown()
+	updated, cmd = update(updated, y)
+	_, _ = update(updated, cmd())
+	if copied != "web02.example.com" {
+		t.Errorf("copied %q, want the name that did not resolve", copied)
+	}
+}
+
+// TestSearch verifies that :search lists its results on the Search tab and
+// that opening them goes to the host on the Hosts tab and to the trigger's
+// problem on the Alerts tab.
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	m := New(testConfig(), theme.DefaultTheme())
+	m.SetSize(160, 40)
+	m.hosts = []zabbix.Host{{HostID: "1", Host: "db01"}, {HostID: "2", Host: "web01"}}
+	m.hostList.SetHosts(m.hosts)
+	m.problems = []zabbix.Problem{{EventID: "9", Object: ObjectTypeTrigger, ObjectID: "20", Name: "Web down"}}
+	m.alertList.SetProblems(m.problems)
+
+	update := func(model tea.Model, msg tea.Msg) (Model, tea.Cmd) {
+		t.Helper()
+		model, cmd := model.Update(msg)
+		updated, ok := model.(Model)
+		if !ok {
+			t.Fatalf("expected Model type, got %T", model)
+		}
+		return updated, cmd
+	}
+
+	model, cmd := m.executeCommand("search web")
+	updated, ok := model.(Model)
+	if !ok {
+		t.Fatalf("expected Model type, got %T", model)
+	}
+	if updated.tabBar.Active() != TabSearch || cmd == nil {
+		t.Fatalf("tab = %d, cmd %v; want the Search tab and a search", updated.tabBar.Active(), cmd)
+	}
+
+	updated, _ = update(updated, SearchLoadedMsg{
+		Query: "web",
+		Results: &zabbix.SearchResults{
+			Hosts:    []zabbix.Host{{HostID: "2", Host: "web01"}},
+			Triggers: []zabbix.Trigger{{TriggerID: "20", Description: "Web down", Hosts: []zabbix.Host{{HostID: "2"}}}},
+		},
+		Seq: updated.loads[TabSearch].seq,
+	})
+	if updated.searchList.Count() != 2 {
+		t.Fatalf("search lists %d results, want 2", updated.searchList.Count())
+	}
+	if !strings.Contains(updated.View(), "Web down") {
+		t.Error("the Search tab should show the trigger found")
+	}
+
+	selected := updated.searchList.Selected()
+	if selected == nil || selected.Kind != search.KindHost {
+		t.Fatalf("selected %+v, want the host", selected)
+	}
+	afterHost, _ := update(updated, search.OpenMsg{Result: *selected})
+	if afterHost.tabBar.Active() != TabHosts {
+		t.Errorf("opening a host went to tab %d, want Hosts", afterHost.tabBar.Active())
+	}
+	if h := afterHost.hostList.Selected(); h == nil || h.HostID != "2" {
+		t.Errorf("selected host %+v, want web01", h)
+	}
+
+	updated.searchList.MoveDown()
+	afterTrigger, _ := update(updated, search.OpenMsg{Result: *updated.searchList.Selected()})
+	if afterTrigger.tabBar.Active() != TabAlerts {
+		t.Errorf("opening a trigger with a problem went to tab %d, want Alerts", afterTrigger.tabBar.Active())
+	}
+	if p := afterTrigger.alertList.Selected(); p == nil || p.EventID != "9" {
+		t.Errorf("selected problem %+v, want the trigger's", p)
+	}
+}
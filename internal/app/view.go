@@ -1,15 +1,27 @@
 package app
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 	zone "github.com/lrstanley/bubblezone"
+
+	"github.com/harpchad/chotko/internal/components/plain"
 )
 
 // View renders the entire application UI.
 func (m Model) View() string {
+	if m.config.Display.ScreenReader {
+		return m.plainView()
+	}
+
 	// Show editor modal if active
 	if m.showEditor {
-		return m.editorPane.View()
+		return m.notifications.Overlay(m.editorPane.View())
+	}
+	if m.showDashboard {
+		return m.notifications.Overlay(m.dashboardView.View())
 	}
 
 	// Show error modal if active
@@ -33,6 +45,8 @@ func (m Model) View() string {
 		listPane = m.eventList.View()
 	case TabGraphs:
 		listPane = m.graphList.View()
+	case TabSearch:
+		listPane = m.searchList.View()
 	default:
 		// For unimplemented tabs, show alerts as fallback
 		listPane = m.alertList.View()
@@ -40,15 +54,85 @@ func (m Model) View() string {
 
 	detailPane := m.detailPane.View()
 
-	// Join panes horizontally
-	contentArea := lipgloss.JoinHorizontal(lipgloss.Top, listPane, detailPane)
-
-	// Stack everything vertically and scan for mouse zones
-	return zone.Scan(lipgloss.JoinVertical(
-		lipgloss.Left,
-		statusBar,
-		tabBar,
-		contentArea,
-		commandBar,
-	))
+	// Join panes side by side, stack them in the vertical layout, or show
+	// only the focused pane in compact mode
+	var contentArea string
+	switch {
+	case m.compact && m.focused == PaneDetail:
+		contentArea = detailPane
+	case m.compact:
+		contentArea = listPane
+	case m.stacked:
+		contentArea = lipgloss.JoinVertical(lipgloss.Left, listPane, detailPane)
+	default:
+		contentArea = lipgloss.JoinHorizontal(lipgloss.Top, listPane, detailPane)
+	}
+
+	// Stack everything vertically and scan for mouse zones; a kiosk has no
+	// use for the command bar and its hints
+	rows := []string{statusBar, tabBar, contentArea, commandBar}
+	if m.kiosk {
+		rows = rows[:3]
+	}
+	view := zone.Scan(lipgloss.JoinVertical(lipgloss.Left, rows...))
+	view = m.contextMenu.Overlay(view)
+	view = m.palette.Overlay(view)
+	view = m.notifications.Overlay(view)
+	return m.tutorial.Overlay(view, m.tutorialRegion(lipgloss.Height(view)))
+}
+
+// plainView renders the screen-reader layout: plain lines without boxes or
+// decorative symbols, in reading order - status, tab, list, detail and the
+// command input - trimmed to the terminal height.
+func (m Model) plainView() string {
+	if m.showEditor {
+		return strings.Join(plain.Text(m.editorPane.View()), "\n")
+	}
+	if m.showDashboard {
+		return strings.Join(plain.Text(m.dashboardView.View()), "\n")
+	}
+	if m.showError || m.showHelp {
+		return strings.Join(plain.Text(m.errorModal.View()), "\n")
+	}
+	if m.tutorial.Active() {
+		return strings.Join(plain.Text(m.tutorial.Callout()), "\n")
+	}
+	if m.palette.Visible() {
+		return strings.Join(plain.Text(m.palette.View()), "\n")
+	}
+
+	active := m.tabBar.Active()
+	focus := "list"
+	if m.focused == PaneDetail {
+		focus = "detail"
+	}
+	lines := plain.Text(m.statusBar.View())
+	for _, n := range m.notifications.Shown() {
+		lines = append(lines, n.Text)
+	}
+	lines = append(lines, fmt.Sprintf("Tab %d of %d: %s, %s focused", active+1, TabCount, tabNames[active], focus))
+	command := plain.Text(m.commandInput.View())
+
+	listHeight := max(3, (m.height-len(lines)-len(command))/2)
+	var list string
+	switch active {
+	case TabHosts:
+		list = m.hostList.PlainView(listHeight)
+	case TabEvents:
+		list = m.eventList.PlainView(listHeight)
+	case TabGraphs:
+		list = m.graphList.PlainView(listHeight)
+	case TabSearch:
+		list = m.searchList.PlainView(listHeight)
+	default:
+		list = m.alertList.PlainView(listHeight)
+	}
+	lines = append(lines, strings.Split(list, "\n")...)
+
+	detail := plain.Text(m.detailPane.View())
+	if room := m.height - len(lines) - len(command); room > 0 {
+		lines = append(lines, detail[:min(len(detail), room)]...)
+	}
+	lines = append(lines, command...)
+	return strings.Join(lines, "\n")
 }
@@ -0,0 +1,85 @@
+package alerts
+
+import "github.com/harpchad/chotko/internal/zabbix"
+
+// AckFilter limits the list to problems by acknowledgement.
+type AckFilter int
+
+// Acknowledgement filters, in the order the ack filter key cycles through
+// them.
+const (
+	AckAll      AckFilter = iota // All problems
+	AckUnacked                   // Only unacknowledged problems
+	AckMine                      // Only problems acknowledged by the current user
+	AckAssigned                  // Only problems assigned to the current user
+	ackFilterCount
+)
+
+// String returns the name of the filter, as used in the config.
+func (f AckFilter) String() string {
+	switch f {
+	case AckUnacked:
+		return "unacked"
+	case AckMine:
+		return "mine"
+	case AckAssigned:
+		return "assigned"
+	}
+	return "all"
+}
+
+// Label describes the filter for the list header, e.g. "unacknowledged".
+func (f AckFilter) Label() string {
+	switch f {
+	case AckUnacked:
+		return "unacknowledged"
+	case AckMine:
+		return "acknowledged by me"
+	case AckAssigned:
+		return "assigned to me"
+	}
+	return ""
+}
+
+// Next returns the filter after f, wrapping around.
+func (f AckFilter) Next() AckFilter {
+	return (f + 1) % ackFilterCount
+}
+
+// ParseAckFilter returns the filter with the given name.
+func ParseAckFilter(name string) (AckFilter, bool) {
+	for f := range ackFilterCount {
+		if f.String() == name {
+			return f, true
+		}
+	}
+	return AckAll, false
+}
+
+// Acknowledged returns the acknowledgement state of the problems the
+// filter passes, for the server to filter by, or nil for any.
+func (f AckFilter) Acknowledged() *bool {
+	var acknowledged bool
+	switch f {
+	case AckUnacked:
+	case AckMine:
+		acknowledged = true
+	default:
+		return nil
+	}
+	return &acknowledged
+}
+
+// match reports whether a problem passes the filter. userID and username
+// are the current user's.
+func (f AckFilter) match(p *zabbix.Problem, userID, username string) bool {
+	switch f {
+	case AckUnacked:
+		return !p.IsAcknowledged()
+	case AckMine:
+		return p.IsAcknowledged() && p.AcknowledgedBy(userID)
+	case AckAssigned:
+		return p.AssignedTo(username)
+	}
+	return true
+}
@@ -3,55 +3,132 @@ package alerts
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	zone "github.com/lrstanley/bubblezone"
 
+	"github.com/harpchad/chotko/internal/components/plain"
+	"github.com/harpchad/chotko/internal/components/rowcache"
+	"github.com/harpchad/chotko/internal/query"
 	"github.com/harpchad/chotko/internal/theme"
 	"github.com/harpchad/chotko/internal/zabbix"
 )
 
+// rowKind is what a row of the list shows.
+type rowKind int
+
+const (
+	rowProblem rowKind = iota // A problem on its own
+	rowGroup                  // Problems grouped together, shown as one row
+	rowMember                 // A problem of an expanded group
+)
+
+// entry is a row of the list.
+type entry struct {
+	kind    rowKind
+	problem zabbix.Problem // The problem, or the first of a group
+	group   *group         // The group of a group row or member
+	last    bool           // The last member of its group
+	muted   bool           // The problem is muted but muted problems are shown
+}
+
 // Model represents the alerts list component.
 type Model struct {
 	styles   *theme.Styles
 	problems []zabbix.Problem
-	filtered []zabbix.Problem
+	filtered []entry
+	matched  int             // Problems in filtered, counting each of a group
+	groupBy  GroupBy         // How problems are grouped
+	expanded map[string]bool // Expanded groups by key
 	cursor   int
 	offset   int
 	width    int
 	height   int
 	focused  bool
+	stale    string          // Age of outdated data, e.g. "5m"; empty when fresh
+	rows     *rowcache.Cache // Rendered non-selected rows, reset on any change
+
+	// Pagination: more rows can be fetched from the server
+	hasMore     bool
+	loadingMore bool
 
 	// Filter state
 	minSeverity  int
 	textFilter   string
-	ignoredCount int // Number of alerts hidden by ignore rules
+	query        *query.Query // Parsed textFilter
+	ignoredCount int          // Number of alerts hidden by ignore rules
+	mutedCount   int          // Number of alerts matching mute rules
+	showMuted    bool
+	ackFilter    AckFilter
+	userID       string           // Current user, for AckMine
+	username     string           // Current user, for AckAssigned
+	sla          [6]time.Duration // How long problems may stay unacknowledged, by severity; 0 for no limit
+	marked       map[string]bool  // Problems marked for bulk actions, by event ID
 
 	// Ignore checker function - returns true if hostID+triggerID should be hidden
 	isIgnored func(hostID, triggerID string) bool
+	// Mute checker function - returns true if the problem matches a mute rule
+	isMuted func(p *zabbix.Problem) bool
 }
 
 // New creates a new alerts list model.
 func New(styles *theme.Styles) Model {
 	return Model{
-		styles: styles,
+		styles:   styles,
+		expanded: make(map[string]bool),
+		marked:   make(map[string]bool),
+		rows:     rowcache.New(),
 	}
 }
 
 // SetSize sets the component dimensions.
 func (m *Model) SetSize(width, height int) {
+	if width != m.width {
+		m.rows.Reset()
+	}
 	m.width = width
 	m.height = height
 }
 
+// SetMore sets whether more problems can be fetched and whether a fetch is running.
+// When more are available a "load more" row is shown below the list.
+func (m *Model) SetMore(hasMore, loading bool) {
+	m.hasMore = hasMore
+	m.loadingMore = loading
+	m.ensureVisible()
+}
+
+// NearEnd reports whether the viewport is within a page of the end of the list,
+// so the next page should be fetched.
+func (m Model) NearEnd() bool {
+	return m.offset+2*m.visibleRows() >= len(m.filtered)
+}
+
+// Restyle discards rendered rows after the shared styles have changed.
+func (m *Model) Restyle() {
+	m.rows.Reset()
+}
+
 // SetFocused sets the focus state.
 func (m *Model) SetFocused(focused bool) {
 	m.focused = focused
 }
 
+// SetStale marks the data as outdated with the given age, e.g. "5m".
+// Stale rows are dimmed and the age is shown in the header. Pass empty string when fresh.
+func (m *Model) SetStale(age string) {
+	if age != m.stale {
+		m.rows.Reset()
+	}
+	m.stale = age
+}
+
 // SetProblems updates the problems list.
 func (m *Model) SetProblems(problems []zabbix.Problem) {
 	m.problems = problems
@@ -64,12 +141,98 @@ func (m *Model) SetMinSeverity(severity int) {
 	m.applyFilter()
 }
 
-// SetTextFilter sets the text filter.
+// SetTextFilter sets the text filter, a query such as "host:web* sev>=4".
+// A filter that isn't a valid query is matched as plain text.
 func (m *Model) SetTextFilter(filter string) {
-	m.textFilter = strings.ToLower(filter)
+	m.textFilter = filter
+	m.query = query.Lenient(filter)
 	m.applyFilter()
 }
 
+// TextFilter returns the text filter.
+func (m Model) TextFilter() string {
+	return m.textFilter
+}
+
+// SetGroupBy sets how problems are grouped. Groups start collapsed.
+func (m *Model) SetGroupBy(by GroupBy) {
+	m.groupBy = by
+	clear(m.expanded)
+	m.applyFilter()
+}
+
+// GroupBy returns how problems are grouped.
+func (m Model) GroupBy() GroupBy {
+	return m.groupBy
+}
+
+// SetAckFilter limits the list to problems by acknowledgement.
+func (m *Model) SetAckFilter(f AckFilter) {
+	m.ackFilter = f
+	m.applyFilter()
+}
+
+// AckFilter returns the acknowledgement filter.
+func (m Model) AckFilter() AckFilter {
+	return m.ackFilter
+}
+
+// SetUserID sets the ID of the current user, whose acknowledgements
+// AckMine shows.
+func (m *Model) SetUserID(userID string) {
+	m.userID = userID
+	m.applyFilter()
+}
+
+// SetUsername sets the username of the current user, whose assigned
+// problems AckAssigned shows.
+func (m *Model) SetUsername(username string) {
+	m.username = username
+	m.applyFilter()
+}
+
+// SetSLA sets how long problems may stay unacknowledged, by severity.
+// Problems past their limit are highlighted.
+func (m *Model) SetSLA(limits [6]time.Duration) {
+	m.sla = limits
+	m.rows.Reset()
+}
+
+// SLABreaches returns the number of unacknowledged problems past their
+// severity's limit, counting those hidden by filters but not ignored or
+// muted ones.
+func (m Model) SLABreaches() int {
+	count := 0
+	for i := range m.problems {
+		p := &m.problems[i]
+		if m.breached(p) && !m.ignored(p) && (m.isMuted == nil || !m.isMuted(p)) {
+			count++
+		}
+	}
+	return count
+}
+
+// breached reports whether an unacknowledged problem is past the limit of
+// its severity.
+func (m Model) breached(p *zabbix.Problem) bool {
+	limit := m.sla[p.SeverityInt()]
+	return limit > 0 && !p.IsAcknowledged() && p.Duration() > limit
+}
+
+// rowBreached reports whether a row is past its limit; a group is when any
+// of its problems are.
+func (m Model) rowBreached(r entry) bool {
+	if r.kind != rowGroup {
+		return m.breached(&r.problem)
+	}
+	for i := range r.group.problems {
+		if m.breached(&r.group.problems[i]) {
+			return true
+		}
+	}
+	return false
+}
+
 // SetIgnoreChecker sets the function used to determine if an alert should be hidden.
 // The function takes hostID and triggerID and returns true if the alert should be ignored.
 func (m *Model) SetIgnoreChecker(fn func(hostID, triggerID string) bool) {
@@ -77,42 +240,114 @@ func (m *Model) SetIgnoreChecker(fn func(hostID, triggerID string) bool) {
 	m.applyFilter()
 }
 
-// applyFilter filters problems based on current filter settings.
+// SetMuteChecker sets the function used to determine if an alert is muted.
+// Muted alerts are hidden unless SetShowMuted is set.
+func (m *Model) SetMuteChecker(fn func(p *zabbix.Problem) bool) {
+	m.isMuted = fn
+	m.applyFilter()
+}
+
+// SetShowMuted sets whether muted alerts are shown, dimmed.
+func (m *Model) SetShowMuted(show bool) {
+	m.showMuted = show
+	m.applyFilter()
+}
+
+// ShowMuted returns whether muted alerts are shown.
+func (m Model) ShowMuted() bool {
+	return m.showMuted
+}
+
+// MutedCount returns the number of alerts matching mute rules, whether or
+// not they are shown.
+func (m Model) MutedCount() int {
+	return m.mutedCount
+}
+
+// ignored reports whether a problem's host and trigger are on the ignore
+// list.
+func (m Model) ignored(p *zabbix.Problem) bool {
+	if m.isIgnored == nil {
+		return false
+	}
+	hostID := ""
+	triggerID := ""
+	if len(p.Hosts) > 0 {
+		hostID = p.Hosts[0].HostID
+	}
+	// Object "0" means trigger-based problem
+	if p.Object == "0" {
+		triggerID = p.ObjectID
+	}
+	if triggerID == "" && p.RelatedObject.TriggerID != "" {
+		triggerID = p.RelatedObject.TriggerID
+	}
+	return hostID != "" && triggerID != "" && m.isIgnored(hostID, triggerID)
+}
+
+// applyFilter builds the rows from the problems that match the filters.
+// When grouping, problems of a group with others are shown as one row, at
+// the place of the group's first problem in the list.
 func (m *Model) applyFilter() {
+	m.rows.Reset()
 	m.filtered = nil
+	m.matched = 0
 	m.ignoredCount = 0
+	m.mutedCount = 0
+	muted := make(map[string]bool) // Shown muted problems by event ID
+	var matched []zabbix.Problem
 	for _, p := range m.problems {
 		// Check ignore list first - skip if host+trigger is ignored
-		if m.isIgnored != nil {
-			hostID := ""
-			triggerID := ""
-			if len(p.Hosts) > 0 {
-				hostID = p.Hosts[0].HostID
-			}
-			// Object "0" means trigger-based problem
-			if p.Object == "0" {
-				triggerID = p.ObjectID
-			}
-			if triggerID == "" && p.RelatedObject.TriggerID != "" {
-				triggerID = p.RelatedObject.TriggerID
-			}
-			if hostID != "" && triggerID != "" && m.isIgnored(hostID, triggerID) {
-				m.ignoredCount++
+		if m.ignored(&p) {
+			m.ignoredCount++
+			continue
+		}
+
+		if m.isMuted != nil && m.isMuted(&p) {
+			m.mutedCount++
+			if !m.showMuted {
 				continue
 			}
+			muted[p.EventID] = true
 		}
 
-		if p.SeverityInt() < m.minSeverity {
+		if p.SeverityInt() < m.minSeverity || !m.ackFilter.match(&p, m.userID, m.username) {
 			continue
 		}
-		if m.textFilter != "" {
-			name := strings.ToLower(p.Name)
-			host := strings.ToLower(p.HostName())
-			if !strings.Contains(name, m.textFilter) && !strings.Contains(host, m.textFilter) {
-				continue
+		if m.textFilter != "" && !m.query.Match(query.Problem(&p)) {
+			continue
+		}
+		matched = append(matched, p)
+	}
+	m.matched = len(matched)
+
+	var groups []*group
+	byKey := make(map[string]*group)
+	for _, p := range matched {
+		key := m.groupBy.key(&p)
+		if g := byKey[key]; g != nil && key != "" {
+			g.problems = append(g.problems, p)
+			continue
+		}
+		g := &group{key: key, problems: []zabbix.Problem{p}}
+		byKey[key] = g
+		groups = append(groups, g)
+	}
+	for _, g := range groups {
+		if len(g.problems) == 1 {
+			p := g.problems[0]
+			m.filtered = append(m.filtered, entry{kind: rowProblem, problem: p, muted: muted[p.EventID]})
+			continue
+		}
+		allMuted := !slices.ContainsFunc(g.problems, func(p zabbix.Problem) bool { return !muted[p.EventID] })
+		m.filtered = append(m.filtered, entry{kind: rowGroup, problem: g.first(), group: g, muted: allMuted})
+		if m.expanded[g.key] {
+			for i, p := range g.problems {
+				m.filtered = append(m.filtered, entry{
+					kind: rowMember, problem: p, group: g, last: i == len(g.problems)-1, muted: muted[p.EventID],
+				})
 			}
 		}
-		m.filtered = append(m.filtered, p)
 	}
 
 	// Reset cursor if out of bounds
@@ -122,17 +357,90 @@ func (m *Model) applyFilter() {
 	m.ensureVisible()
 }
 
-// Selected returns the currently selected problem.
+// Selected returns the currently selected problem. For a group this is the
+// problem that started first.
 // Returns a pointer to the element in the filtered slice. The pointer remains
 // valid until the next call to SetProblems or filter changes. Callers should
 // not store this pointer long-term.
 func (m Model) Selected() *zabbix.Problem {
 	if m.cursor >= 0 && m.cursor < len(m.filtered) {
-		return &m.filtered[m.cursor]
+		return &m.filtered[m.cursor].problem
+	}
+	return nil
+}
+
+// SelectedGroup returns the problems of the selected group, or nil if a
+// single problem is selected.
+func (m Model) SelectedGroup() []zabbix.Problem {
+	if m.cursor >= 0 && m.cursor < len(m.filtered) && m.filtered[m.cursor].kind == rowGroup {
+		return m.filtered[m.cursor].group.problems
 	}
 	return nil
 }
 
+// Toggle expands the selected group to show its problems, or collapses it
+// again. On a problem of an expanded group it collapses the group and
+// selects it.
+func (m *Model) Toggle() {
+	if m.cursor < 0 || m.cursor >= len(m.filtered) {
+		return
+	}
+	row := m.filtered[m.cursor]
+	switch row.kind {
+	case rowGroup:
+		m.expanded[row.group.key] = !m.expanded[row.group.key]
+		m.applyFilter()
+	case rowMember:
+		for m.filtered[m.cursor].kind != rowGroup {
+			m.cursor--
+		}
+		delete(m.expanded, row.group.key)
+		m.applyFilter()
+	}
+}
+
+// ExpandAll expands every group.
+func (m *Model) ExpandAll() {
+	for _, row := range m.filtered {
+		if row.kind == rowGroup {
+			m.expanded[row.group.key] = true
+		}
+	}
+	m.applyFilter()
+}
+
+// CollapseAll collapses every group, keeping the selection on the same row
+// or group.
+func (m *Model) CollapseAll() {
+	row := 0
+	for i, r := range m.filtered[:min(m.cursor+1, len(m.filtered))] {
+		if r.kind != rowMember {
+			row = i
+		}
+	}
+	// The problems of the expanded groups above go away
+	m.cursor = row
+	for _, r := range m.filtered[:row] {
+		if r.kind == rowMember {
+			m.cursor--
+		}
+	}
+	clear(m.expanded)
+	m.applyFilter()
+}
+
+// ClickRow selects the row at index, and expands or collapses it if it is
+// a group.
+func (m *Model) ClickRow(index int) {
+	if index < 0 || index >= len(m.filtered) {
+		return
+	}
+	m.SetCursor(index)
+	if m.filtered[index].kind == rowGroup {
+		m.Toggle()
+	}
+}
+
 // SelectedIndex returns the index of the selected problem in the original list.
 func (m Model) SelectedIndex() int {
 	if selected := m.Selected(); selected != nil {
@@ -145,10 +453,11 @@ func (m Model) SelectedIndex() int {
 	return -1
 }
 
-// Count returns the total and filtered problem counts.
+// Count returns the total and filtered problem counts. Each problem of a
+// group is counted.
 // Total excludes ignored alerts (they are not counted as real alerts).
 func (m Model) Count() (total, filtered int) {
-	return len(m.problems) - m.ignoredCount, len(m.filtered)
+	return len(m.problems) - m.ignoredCount, m.matched
 }
 
 // MoveUp moves the cursor up.
@@ -215,7 +524,7 @@ func (m *Model) Scroll(delta int) {
 	}
 }
 
-// FilteredCount returns the number of filtered items.
+// FilteredCount returns the number of rows shown.
 func (m Model) FilteredCount() int {
 	return len(m.filtered)
 }
@@ -228,9 +537,33 @@ func (m *Model) SetCursor(index int) {
 	}
 }
 
+// SelectID moves the cursor to the problem with the given event ID, or to
+// the collapsed group it belongs to. It returns false if the problem is not
+// shown.
+func (m *Model) SelectID(id string) bool {
+	for i, row := range m.filtered {
+		var found bool
+		switch {
+		case row.kind == rowGroup && !m.expanded[row.group.key]:
+			found = slices.ContainsFunc(row.group.problems, func(p zabbix.Problem) bool { return p.EventID == id })
+		case row.kind != rowGroup:
+			found = row.problem.EventID == id
+		}
+		if found {
+			m.SetCursor(i)
+			return true
+		}
+	}
+	return false
+}
+
 // visibleRows returns the number of visible rows.
 func (m Model) visibleRows() int {
-	return m.height - 2 // Account for header and border
+	rows := m.height - 2 // Account for header and border
+	if m.hasMore {
+		rows-- // "Load more" row
+	}
+	return rows
 }
 
 // ensureVisible ensures the cursor is visible in the viewport.
@@ -272,12 +605,67 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.GoToTop()
 		case key.Matches(msg, key.NewBinding(key.WithKeys("end", "G"))):
 			m.GoToBottom()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("enter", " "))):
+			m.Toggle()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("E"))):
+			m.ExpandAll()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("C"))):
+			m.CollapseAll()
 		}
 	}
 
 	return m, nil
 }
 
+// PlainView renders the list for screen readers: a summary line, then one
+// line per problem around the cursor, within height lines.
+func (m Model) PlainView(height int) string {
+	total, filtered := m.Count()
+	lines := []string{fmt.Sprintf("Alerts: %d of %d problems", filtered, total)}
+
+	start, end := plain.Window(m.cursor, len(m.filtered), height-1)
+	for i := start; i < end; i++ {
+		row := m.filtered[i]
+		p := row.problem
+		if row.kind == rowGroup {
+			g := row.group
+			ack := "not all acknowledged"
+			if g.acknowledged() {
+				ack = "all acknowledged"
+			}
+			state := "collapsed"
+			if m.expanded[g.key] {
+				state = "expanded"
+			}
+			lines = append(lines, plain.Item(i == m.cursor, "group, "+theme.SeverityName(g.severity()),
+				g.hosts(), g.label(m.groupBy), p.DurationString(), ack, state))
+			continue
+		}
+		ack := "unacknowledged"
+		if p.IsAcknowledged() {
+			ack = "acknowledged"
+		}
+		if row.muted {
+			ack += ", muted"
+		}
+		if assignee := p.Assignee(); assignee != "" {
+			ack += ", assigned to " + assignee
+		}
+		if m.breached(&p) {
+			ack += ", past SLA"
+		}
+		if m.marked[p.EventID] {
+			ack += ", marked"
+		}
+		lines = append(lines, plain.Item(i == m.cursor,
+			theme.SeverityName(p.SeverityInt()), p.HostName(), p.Name, p.DurationString(), ack))
+	}
+	if m.hasMore {
+		lines = append(lines, "More problems available")
+	}
+	return strings.Join(lines, "\n")
+}
+
 // View implements tea.Model.
 func (m Model) View() string {
 	// Handle zero-size case
@@ -295,6 +683,22 @@ func (m Model) View() string {
 	}
 	header += ")"
 	b.WriteString(m.styles.PaneTitle.Render(header))
+	var scope []string
+	if m.groupBy != GroupNone {
+		scope = append(scope, "by "+string(m.groupBy))
+	}
+	if m.ackFilter != AckAll {
+		scope = append(scope, m.ackFilter.Label())
+	}
+	if marked := m.Marked(); len(marked) > 0 {
+		scope = append(scope, fmt.Sprintf("%d selected", len(marked)))
+	}
+	if len(scope) > 0 {
+		b.WriteString(m.styles.Subtle.Render(" " + strings.Join(scope, ", ")))
+	}
+	if m.stale != "" {
+		b.WriteString(m.styles.Subtle.Render(" (stale " + m.stale + ")"))
+	}
 	b.WriteString("\n")
 
 	// Calculate visible range
@@ -307,11 +711,7 @@ func (m Model) View() string {
 
 	// Render rows
 	for i := m.offset; i < endIdx; i++ {
-		p := m.filtered[i]
-		row := m.renderRow(p, i == m.cursor)
-		// Mark row with zone for mouse click detection
-		rowID := fmt.Sprintf("alert_%d", i)
-		b.WriteString(zone.Mark(rowID, row))
+		b.WriteString(m.row(i))
 		if i < endIdx-1 {
 			b.WriteString("\n")
 		}
@@ -323,6 +723,15 @@ func (m Model) View() string {
 		b.WriteString("\n")
 	}
 
+	if m.hasMore {
+		b.WriteString("\n")
+		if m.loadingMore {
+			b.WriteString(m.styles.Subtle.Render("  Loading more..."))
+		} else {
+			b.WriteString(m.styles.Subtle.Render("  ▼ More available, scroll down to load"))
+		}
+	}
+
 	// Apply pane style
 	content := b.String()
 	if m.focused {
@@ -331,65 +740,125 @@ func (m Model) View() string {
 	return m.styles.PaneBlurred.Width(m.width).Height(m.height).Render(content)
 }
 
-// renderRow renders a single problem row.
-func (m Model) renderRow(p zabbix.Problem, selected bool) string {
+// row returns the rendered row at index i. Non-selected rows are reused from
+// the cache while its displayed duration is unchanged.
+func (m Model) row(i int) string {
+	p := m.filtered[i]
+	selected := i == m.cursor
+	key := p.problem.DurationString()
+	if m.rowBreached(p) {
+		key += "!"
+	}
+	if m.rowMarked(p) {
+		key += "*"
+	}
+	if !selected {
+		if row, ok := m.rows.Get(i, key); ok {
+			return row
+		}
+	}
+
+	row := m.renderRow(p, selected)
+	if (m.stale != "" || p.muted) && !selected {
+		row = m.styles.Subtle.Render(ansi.Strip(row))
+	}
+	// Mark row with zone for mouse click detection
+	row = zone.Mark(fmt.Sprintf("alert_%d", i), row)
+	if !selected {
+		m.rows.Put(i, key, row)
+	}
+	return row
+}
+
+// renderRow renders a single row. When grouping, groups are marked ▸ when
+// collapsed and ▾ when expanded, with their problems below.
+func (m Model) renderRow(r entry, selected bool) string {
+	p := r.problem
+
 	// Severity indicator
 	severity := p.SeverityInt()
+	if r.kind == rowGroup {
+		severity = r.group.severity()
+	}
 
-	var indicator string
-	switch severity {
-	case 5:
-		indicator = "●"
-	case 4:
-		indicator = "●"
-	case 3:
-		indicator = "◐"
-	case 2:
-		indicator = "○"
-	case 1:
-		indicator = "○"
-	default:
-		indicator = "○"
+	iconWidth := m.styles.SeverityIconWidth()
+	indicator := m.styles.SeverityIcon[severity]
+	if pad := iconWidth - lipgloss.Width(indicator); pad > 0 {
+		indicator += strings.Repeat(" ", pad)
 	}
 
 	// Host name
 	host := p.HostName()
+	if r.kind == rowGroup {
+		host = r.group.hosts()
+	}
 	if len(host) > 15 {
 		host = host[:12] + "..."
 	}
 
-	// Problem name
+	// Problem name, after the group marker when grouping
 	name := p.Name
-	nameWidth := m.width - 15 - 12 - 6 // host, duration, icon, padding
+	if r.kind == rowGroup {
+		name = r.group.label(m.groupBy)
+	}
+	nameWidth := m.width - 15 - 12 - 3 - 5 - iconWidth // host, duration, assignee, icon, padding
 	if nameWidth < 10 {
 		nameWidth = 10
 	}
-	if len(name) > nameWidth {
-		name = name[:nameWidth-3] + "..."
+	marker := 0
+	if m.groupBy != GroupNone {
+		marker = 2
+	}
+	if len(name) > nameWidth-marker {
+		name = name[:nameWidth-marker-3] + "..."
+	}
+	switch {
+	case m.groupBy == GroupNone:
+	case r.kind == rowGroup && m.expanded[r.group.key]:
+		name = "▾ " + name
+	case r.kind == rowGroup:
+		name = "▸ " + name
+	case r.kind == rowMember && r.last:
+		name = "└ " + name
+	case r.kind == rowMember:
+		name = "├ " + name
+	default:
+		name = "  " + name
 	}
 
 	// Duration
 	duration := p.DurationString()
 
+	// Assignee, the acknowledging user unless assigned to another
+	assignee := p.AssigneeInitials()
+	if r.kind == rowGroup {
+		assignee = r.group.assignee()
+	}
+
 	// Ack indicator
-	var ackIndicator string
-	if p.IsAcknowledged() {
-		ackIndicator = "✓"
-	} else {
-		ackIndicator = " "
+	ackIndicator := m.styles.StatusIcon.Acked
+	if !(r.kind == rowGroup && r.group.acknowledged() || r.kind != rowGroup && p.IsAcknowledged()) {
+		ackIndicator = strings.Repeat(" ", lipgloss.Width(ackIndicator))
 	}
 
-	if selected {
+	breached := m.rowBreached(r)
+	marked := m.rowMarked(r)
+	if selected || breached || marked {
 		// Build plain text row, then apply highlight style to the whole thing
 		// This prevents ANSI code fragmentation from individual column styles
 		hostPadded := fmt.Sprintf("%-15s", host)
 		namePadded := fmt.Sprintf("%-*s", nameWidth, name)
 		durationPadded := fmt.Sprintf("%10s", duration)
+		assigneePadded := fmt.Sprintf("%-2s", assignee)
 
-		row := fmt.Sprintf("%s %s %s %s %s", indicator, hostPadded, namePadded, durationPadded, ackIndicator)
+		row := fmt.Sprintf("%s %s %s %s %s %s", indicator, hostPadded, namePadded, durationPadded, assigneePadded, ackIndicator)
 		// Pad to full width for consistent highlight
-		if len(row) < m.width-2 {
-			row += strings.Repeat(" ", m.width-2-len(row))
+		if width := lipgloss.Width(row); width < m.width-2 {
+			row += strings.Repeat(" ", m.width-2-width)
+		}
+		if !selected && !marked {
+			// Past the SLA: inverted in the severity color
+			return m.styles.AlertSeverity[severity].Reverse(true).Render(row)
 		}
 		return m.styles.AlertSelected.Render(row)
 	}
@@ -399,8 +868,9 @@ func (m Model) renderRow(p zabbix.Problem, selected bool) string {
 	hostStr := m.styles.AlertHost.Width(15).Render(host)
 	nameStr := m.styles.AlertName.Width(nameWidth).Render(name)
 	durationStr := m.styles.AlertDuration.Width(10).Align(lipgloss.Right).Render(duration)
+	assigneeStr := m.styles.AlertAcked.Width(2).Render(assignee)
 	ackStr := m.styles.AlertAcked.Render(ackIndicator)
 
-	row := fmt.Sprintf("%s %s %s %s %s", severityIcon, hostStr, nameStr, durationStr, ackStr)
+	row := fmt.Sprintf("%s %s %s %s %s %s", severityIcon, hostStr, nameStr, durationStr, assigneeStr, ackStr)
 	return m.styles.AlertNormal.Width(m.width - 2).Render(row)
 }
@@ -2,8 +2,11 @@ package alerts
 
 import (
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	zone "github.com/lrstanley/bubblezone"
@@ -274,6 +277,29 @@ func TestModel_SelectedIndex(t *testing.T) {
 	})
 }
 
+func TestModel_SelectID(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(80, 20)
+	m.SetProblems(testProblems())
+
+	if !m.SelectID("4") {
+		t.Fatal("SelectID(\"4\") = false, want true")
+	}
+	if selected := m.Selected(); selected == nil || selected.EventID != "4" {
+		t.Errorf("Selected() = %v, want event 4", selected)
+	}
+
+	m.SetTextFilter("Memory")
+	if m.TextFilter() != "Memory" {
+		t.Errorf("TextFilter() = %q, want %q", m.TextFilter(), "Memory")
+	}
+	if m.SelectID("5") {
+		t.Error("SelectID(\"5\") = true for a filtered out problem, want false")
+	}
+}
+
 func TestModel_MoveUp(t *testing.T) {
 	t.Parallel()
 
@@ -560,6 +586,68 @@ func TestModel_View_FilteredCount(t *testing.T) {
 	}
 }
 
+func TestModel_View_Stale(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetProblems(testProblems())
+	m.SetSize(80, 20)
+
+	if strings.Contains(m.View(), "stale") {
+		t.Error("View should not show stale marker for fresh data")
+	}
+
+	m.SetStale("5m")
+	if !strings.Contains(m.View(), "(stale 5m)") {
+		t.Error("View should show stale marker in header")
+	}
+}
+
+func TestModel_View_RowCache(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	problems := testProblems()
+	m.SetProblems(problems)
+	m.SetSize(80, 20)
+
+	first := m.View()
+	if m.rows.Len() != len(problems)-1 {
+		t.Errorf("cached rows = %d, want %d (all but the selected row)", m.rows.Len(), len(problems)-1)
+	}
+	if m.View() != first {
+		t.Error("View from cache should match the first render")
+	}
+
+	// New data invalidates the cache
+	renamed := testProblems()
+	renamed[1].Name = "Renamed problem"
+	m.SetProblems(renamed)
+	if !strings.Contains(m.View(), "Renamed problem") {
+		t.Error("View should render updated rows after SetProblems")
+	}
+}
+
+func TestModel_View_NoColor(t *testing.T) {
+	t.Parallel()
+
+	styles := testStyles()
+	styles.Monochrome()
+	m := New(styles)
+	m.SetProblems(testProblems())
+	m.SetSize(100, 20)
+
+	view := m.View()
+	for _, want := range []string{"[DIS]", "[AVG]", "[WARN]", "[HIGH]"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("no-color view missing severity label %q", want)
+		}
+	}
+	if strings.Contains(view, "●") {
+		t.Error("no-color view should not use glyph indicators")
+	}
+}
+
 func TestModel_View_FocusedVsBlurred(t *testing.T) {
 	t.Parallel()
 
@@ -807,3 +895,234 @@ func TestModel_LongProblemName(t *testing.T) {
 		t.Error("View should not be empty")
 	}
 }
+
+func TestModel_GroupBy(t *testing.T) {
+	t.Parallel()
+
+	host := func(id, name string) []zabbix.Host { return []zabbix.Host{{HostID: id, Name: name}} }
+	problems := []zabbix.Problem{
+		{
+			EventID: "1", Name: "Agent down", Severity: "3", Clock: "300", Hosts: host("1", "web-01"),
+			Tags: []zabbix.Tag{{Tag: "service", Value: "web"}},
+		},
+		{EventID: "2", Name: "Disk full", Severity: "2", Clock: "200", Hosts: host("1", "web-01")},
+		{
+			EventID: "3", Name: "Agent down", Severity: "4", Clock: "100", Hosts: host("2", "web-02"),
+			Tags: []zabbix.Tag{{Tag: "service", Value: "web"}},
+		},
+	}
+
+	m := New(testStyles())
+	m.SetSize(80, 20)
+	m.SetProblems(problems)
+	m.SetGroupBy(GroupTrigger)
+
+	if m.FilteredCount() != 2 {
+		t.Fatalf("FilteredCount() = %d, want 2 rows", m.FilteredCount())
+	}
+	if _, filtered := m.Count(); filtered != 3 {
+		t.Errorf("Count() filtered = %d, want every problem counted", filtered)
+	}
+	// The group is shown by the problem that started first
+	if selected := m.Selected(); selected == nil || selected.EventID != "3" {
+		t.Errorf("Selected() = %v, want event 3", selected)
+	}
+	if group := m.SelectedGroup(); len(group) != 2 {
+		t.Errorf("SelectedGroup() = %v, want 2 problems", group)
+	}
+	view := m.View()
+	if !strings.Contains(view, "▸ Agent down (2)") || !strings.Contains(view, "2 hosts") {
+		t.Errorf("View() should show the collapsed group, got:\n%s", view)
+	}
+
+	m.Toggle()
+	if m.FilteredCount() != 4 {
+		t.Fatalf("FilteredCount() = %d after expanding, want 4 rows", m.FilteredCount())
+	}
+	m.MoveDown()
+	if m.SelectedGroup() != nil {
+		t.Error("SelectedGroup() should be nil on a problem of the group")
+	}
+	m.Toggle()
+	if m.FilteredCount() != 2 || m.SelectedGroup() == nil {
+		t.Errorf("Toggle() on a problem should collapse its group and select it")
+	}
+
+	if !m.SelectID("1") || m.SelectedGroup() == nil {
+		t.Error("SelectID() should select the collapsed group of the problem")
+	}
+
+	by, err := ParseGroupBy("tag:service")
+	if err != nil {
+		t.Fatalf("ParseGroupBy() error = %v", err)
+	}
+	m.SetGroupBy(by)
+	if m.FilteredCount() != 2 {
+		t.Errorf("FilteredCount() = %d grouped by tag, want 2 rows", m.FilteredCount())
+	}
+	m.SetGroupBy(GroupHost)
+	if m.FilteredCount() != 2 {
+		t.Errorf("FilteredCount() = %d grouped by host, want 2 rows", m.FilteredCount())
+	}
+
+	if _, err := ParseGroupBy("tag:"); err == nil {
+		t.Error("ParseGroupBy(\"tag:\") should fail")
+	}
+}
+
+func TestModel_Mute(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(80, 20)
+	m.SetProblems([]zabbix.Problem{
+		{EventID: "1", Name: "Agent down", Severity: "3", Clock: "300"},
+		{EventID: "2", Name: "Disk full", Severity: "2", Clock: "200"},
+	})
+	m.SetMuteChecker(func(p *zabbix.Problem) bool { return p.Name == "Agent down" })
+
+	if m.MutedCount() != 1 {
+		t.Errorf("MutedCount() = %d, want 1", m.MutedCount())
+	}
+	if m.FilteredCount() != 1 || m.Selected().EventID != "2" {
+		t.Errorf("FilteredCount() = %d, want the muted problem hidden", m.FilteredCount())
+	}
+
+	m.SetShowMuted(true)
+	if m.FilteredCount() != 2 {
+		t.Errorf("FilteredCount() = %d with muted shown, want 2", m.FilteredCount())
+	}
+	if !strings.Contains(m.PlainView(10), "muted") {
+		t.Error("PlainView() should mark muted problems")
+	}
+}
+
+func TestModel_AckFilter(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(80, 20)
+	m.SetUserID("7")
+	m.SetUsername("jdoe")
+	m.SetProblems([]zabbix.Problem{
+		{EventID: "1", Name: "Agent down", Acknowledged: "0"},
+		{EventID: "2", Name: "Disk full", Acknowledged: "1", Acknowledges: []zabbix.Ack{
+			{UserID: "7", Username: "jdoe", Action: "2", Clock: "100"},
+		}},
+		{EventID: "3", Name: "High CPU", Acknowledged: "1", Acknowledges: []zabbix.Ack{
+			{UserID: "8", Username: "asmith", Action: "4", Message: "assign: jdoe", Clock: "200"},
+			{UserID: "8", Username: "asmith", Action: "2", Clock: "100"},
+		}},
+		{EventID: "4", Name: "Link down", Acknowledged: "1", Acknowledges: []zabbix.Ack{
+			{UserID: "7", Username: "jdoe", Action: "2", Clock: "100"},
+			{UserID: "7", Username: "jdoe", Action: "4", Message: "unassign: back to the queue", Clock: "200"},
+		}},
+	})
+
+	tests := []struct {
+		filter AckFilter
+		want   []string
+	}{
+		{AckAll, []string{"1", "2", "3", "4"}},
+		{AckUnacked, []string{"1"}},
+		{AckAssigned, []string{"2", "3"}},
+		{AckMine, []string{"2", "4"}},
+	}
+	for _, tt := range tests {
+		m.SetAckFilter(tt.filter)
+		var got []string
+		for i := range m.FilteredCount() {
+			m.cursor = i
+			got = append(got, m.Selected().EventID)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: shown %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+	if !strings.Contains(m.View(), "acknowledged by me") {
+		t.Error("expected the filter in the header")
+	}
+	m.SetAckFilter(AckAssigned)
+	if view := m.View(); strings.Count(view, "JD") != 2 {
+		t.Errorf("expected the assignee's initials on both rows, got:\n%s", view)
+	}
+
+	if f, ok := ParseAckFilter("unacked"); !ok || f != AckUnacked {
+		t.Errorf("ParseAckFilter(unacked) = %v, %v", f, ok)
+	}
+	if AckAssigned.Next() != AckAll {
+		t.Error("Next() should wrap around")
+	}
+	if AckAssigned.Acknowledged() != nil || *AckMine.Acknowledged() != true {
+		t.Error("only unacked and mine should be filtered by the server")
+	}
+}
+
+func TestModel_SLA(t *testing.T) {
+	t.Parallel()
+
+	hourAgo := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	m := New(testStyles())
+	m.SetSize(80, 20)
+	m.SetProblems([]zabbix.Problem{
+		{EventID: "1", Name: "Agent down", Severity: "5", Clock: hourAgo},
+		{EventID: "2", Name: "Disk full", Severity: "5", Clock: hourAgo, Acknowledged: "1"},
+		{EventID: "3", Name: "High CPU", Severity: "3", Clock: hourAgo},
+		{EventID: "4", Name: "Link down", Severity: "5", Clock: strconv.FormatInt(time.Now().Unix(), 10)},
+	})
+	if m.SLABreaches() != 0 {
+		t.Errorf("SLABreaches() = %d without limits, want 0", m.SLABreaches())
+	}
+
+	m.SetSLA([6]time.Duration{5: 15 * time.Minute})
+	if m.SLABreaches() != 1 {
+		t.Errorf("SLABreaches() = %d, want only the old unacknowledged disaster", m.SLABreaches())
+	}
+	if !strings.Contains(m.PlainView(10), "past SLA") {
+		t.Error("PlainView() should mark problems past their SLA")
+	}
+
+	m.SetMuteChecker(func(p *zabbix.Problem) bool { return p.EventID == "1" })
+	if m.SLABreaches() != 0 {
+		t.Errorf("SLABreaches() = %d, want muted problems left out", m.SLABreaches())
+	}
+}
+
+func TestModel_MarkRange(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(80, 20)
+	m.SetProblems([]zabbix.Problem{
+		{EventID: "1", Name: "Agent down", Severity: "5"},
+		{EventID: "2", Name: "Disk full", Severity: "4"},
+		{EventID: "3", Name: "Swap low", Severity: "2"},
+		{EventID: "4", Name: "Link down", Severity: "3"},
+	})
+
+	m.MarkRange(2, 0)
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want the row the drag ended on", m.cursor)
+	}
+	marked := m.Marked()
+	if len(marked) != 3 {
+		t.Fatalf("Marked() has %d problems, want 3", len(marked))
+	}
+	if !strings.Contains(m.View(), "3 selected") {
+		t.Error("the header should count the marked problems")
+	}
+
+	// Marks are kept by event ID across a refresh, for problems still shown
+	m.SetProblems([]zabbix.Problem{
+		{EventID: "2", Name: "Disk full", Severity: "4"},
+		{EventID: "4", Name: "Link down", Severity: "3"},
+	})
+	if marked = m.Marked(); len(marked) != 1 || marked[0].EventID != "2" {
+		t.Errorf("Marked() = %v after a refresh, want only problem 2", marked)
+	}
+
+	m.ClearMarks()
+	if m.Marked() != nil {
+		t.Error("ClearMarks() should unmark all problems")
+	}
+}
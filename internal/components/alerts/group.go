@@ -0,0 +1,140 @@
+package alerts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// GroupBy selects how the list groups problems: not at all, by trigger
+// name, by host, or by the value of a tag, e.g. "tag:service".
+type GroupBy string
+
+// Groupings other than by tag.
+const (
+	GroupNone    GroupBy = ""
+	GroupTrigger GroupBy = "trigger"
+	GroupHost    GroupBy = "host"
+)
+
+// tagPrefix starts the grouping by the value of a tag.
+const tagPrefix = "tag:"
+
+// ParseGroupBy parses a grouping: "trigger", "host", "tag:<name>" or "none".
+func ParseGroupBy(s string) (GroupBy, error) {
+	switch {
+	case s == "none" || s == "":
+		return GroupNone, nil
+	case s == string(GroupTrigger) || s == string(GroupHost):
+		return GroupBy(s), nil
+	case strings.HasPrefix(s, tagPrefix) && len(s) > len(tagPrefix):
+		return GroupBy(s), nil
+	}
+	return GroupNone, fmt.Errorf("unknown grouping %q; use trigger, host, tag:<name> or none", s)
+}
+
+// tag returns the name of the tag grouped by, if any.
+func (g GroupBy) tag() string {
+	return strings.TrimPrefix(string(g), tagPrefix)
+}
+
+// String returns the grouping as ParseGroupBy accepts it.
+func (g GroupBy) String() string {
+	if g == GroupNone {
+		return "none"
+	}
+	return string(g)
+}
+
+// key returns the group of a problem, or empty if the problem is shown on
+// its own.
+func (g GroupBy) key(p *zabbix.Problem) string {
+	switch g {
+	case GroupNone:
+		return ""
+	case GroupTrigger:
+		return p.Name
+	case GroupHost:
+		if len(p.Hosts) == 0 {
+			return ""
+		}
+		return p.Hosts[0].HostID
+	}
+	for _, t := range p.Tags {
+		if t.Tag == g.tag() {
+			return t.Tag + "=" + t.Value
+		}
+	}
+	return ""
+}
+
+// group is a set of problems shown as one row.
+type group struct {
+	key      string
+	problems []zabbix.Problem
+}
+
+// first returns the problem that started first, the likely root of the
+// others.
+func (g *group) first() zabbix.Problem {
+	first := g.problems[0]
+	for _, p := range g.problems[1:] {
+		if p.StartTime().Before(first.StartTime()) {
+			first = p
+		}
+	}
+	return first
+}
+
+// severity returns the highest severity of the problems.
+func (g *group) severity() int {
+	severity := 0
+	for _, p := range g.problems {
+		severity = max(severity, p.SeverityInt())
+	}
+	return severity
+}
+
+// acknowledged reports whether all of the problems are acknowledged.
+func (g *group) acknowledged() bool {
+	for _, p := range g.problems {
+		if !p.IsAcknowledged() {
+			return false
+		}
+	}
+	return true
+}
+
+// assignee returns the initials of the assignee of all of the problems,
+// or empty when they are not all assigned to the same user.
+func (g *group) assignee() string {
+	initials := g.problems[0].AssigneeInitials()
+	for _, p := range g.problems[1:] {
+		if p.AssigneeInitials() != initials {
+			return ""
+		}
+	}
+	return initials
+}
+
+// hosts returns the host column of the group: the host if all of the
+// problems are on the same one, else the number of hosts.
+func (g *group) hosts() string {
+	hosts := make(map[string]bool)
+	for _, p := range g.problems {
+		hosts[p.HostName()] = true
+	}
+	if len(hosts) == 1 {
+		return g.problems[0].HostName()
+	}
+	return fmt.Sprintf("%d hosts", len(hosts))
+}
+
+// label returns the name column of the group.
+func (g *group) label(by GroupBy) string {
+	if by == GroupHost {
+		return fmt.Sprintf("%d problems", len(g.problems))
+	}
+	return fmt.Sprintf("%s (%d)", g.key, len(g.problems))
+}
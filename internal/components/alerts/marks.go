@@ -0,0 +1,71 @@
+package alerts
+
+import "github.com/harpchad/chotko/internal/zabbix"
+
+// MarkRange marks the rows from one index to another, in either order, as
+// when the mouse is dragged over them, and moves the cursor to the last.
+// Marking a group marks all of its problems. Marks are kept by event ID, so
+// they survive a refresh.
+func (m *Model) MarkRange(from, to int) {
+	if from < 0 || to < 0 || from >= len(m.filtered) || to >= len(m.filtered) {
+		return
+	}
+	m.SetCursor(to)
+	clear(m.marked)
+	for i := min(from, to); i <= max(from, to); i++ {
+		r := m.filtered[i]
+		if r.kind == rowGroup {
+			for _, p := range r.group.problems {
+				m.marked[p.EventID] = true
+			}
+		} else {
+			m.marked[r.problem.EventID] = true
+		}
+	}
+	m.rows.Reset()
+}
+
+// ClearMarks unmarks all rows.
+func (m *Model) ClearMarks() {
+	if len(m.marked) > 0 {
+		clear(m.marked)
+		m.rows.Reset()
+	}
+}
+
+// Marked returns the marked problems that are shown, or nil if none are.
+func (m Model) Marked() []zabbix.Problem {
+	if len(m.marked) == 0 {
+		return nil
+	}
+	var marked []zabbix.Problem
+	for _, r := range m.filtered {
+		switch {
+		case r.kind == rowGroup && m.expanded[r.group.key]:
+			// Counted by its member rows
+		case r.kind == rowGroup:
+			for _, p := range r.group.problems {
+				if m.marked[p.EventID] {
+					marked = append(marked, p)
+				}
+			}
+		case m.marked[r.problem.EventID]:
+			marked = append(marked, r.problem)
+		}
+	}
+	return marked
+}
+
+// rowMarked reports whether a row is marked; a group is when all of its
+// problems are.
+func (m Model) rowMarked(r entry) bool {
+	if r.kind != rowGroup {
+		return m.marked[r.problem.EventID]
+	}
+	for _, p := range r.group.problems {
+		if !m.marked[p.EventID] {
+			return false
+		}
+	}
+	return true
+}
@@ -17,6 +17,9 @@ const (
 	ModeCommand
 	ModeFilter
 	ModeAckMessage
+	ModeSuppressUntil
+	ModeCloneHost
+	ModeSearch
 )
 
 // Model represents the command input component.
@@ -26,6 +29,7 @@ type Model struct {
 	mode   Mode
 	width  int
 	hint   string
+	err    string // Shown in place of the hint, e.g. a filter that doesn't parse
 }
 
 // New creates a new command input model.
@@ -50,6 +54,7 @@ func (m *Model) SetWidth(width int) {
 // SetMode activates a specific input mode.
 func (m *Model) SetMode(mode Mode) {
 	m.mode = mode
+	m.err = ""
 	m.input.Reset()
 
 	switch mode {
@@ -61,13 +66,28 @@ func (m *Model) SetMode(mode Mode) {
 	case ModeFilter:
 		m.input.Prompt = "/ "
 		m.input.Placeholder = "filter"
-		m.hint = "Type to filter alerts"
+		m.hint = "Type to filter, Enter to keep, Esc to cancel"
 		m.input.Focus()
 	case ModeAckMessage:
 		m.input.Prompt = "Message: "
 		m.input.Placeholder = "acknowledgment message"
 		m.hint = "Enter message and press Enter"
 		m.input.Focus()
+	case ModeSuppressUntil:
+		m.input.Prompt = "Suppress until: "
+		m.input.Placeholder = "2h, 3d, 17:30 or 2025-01-02 09:00"
+		m.hint = "Enter a duration or time and press Enter"
+		m.input.Focus()
+	case ModeCloneHost:
+		m.input.Prompt = "Clone as: "
+		m.input.Placeholder = "name [IP or DNS name]"
+		m.hint = "Enter the new host's name and address and press Enter"
+		m.input.Focus()
+	case ModeSearch:
+		m.input.Prompt = "Search: "
+		m.input.Placeholder = "host, trigger, item or template"
+		m.hint = "Enter text found in names or item keys and press Enter"
+		m.input.Focus()
 	default:
 		m.input.Blur()
 		m.hint = ""
@@ -77,15 +97,38 @@ func (m *Model) SetMode(mode Mode) {
 // Hide hides the command input.
 func (m *Model) Hide() {
 	m.mode = ModeHidden
+	m.err = ""
 	m.input.Blur()
 	m.input.Reset()
 }
 
+// SetHint replaces the hint shown beside the input of the current mode.
+func (m *Model) SetHint(hint string) {
+	m.hint = hint
+}
+
+// SetError shows an error about the input in place of the hint, or clears
+// it when empty.
+func (m *Model) SetError(err string) {
+	m.err = err
+}
+
+// Error returns the error shown about the input.
+func (m Model) Error() string {
+	return m.err
+}
+
 // Value returns the current input value.
 func (m Model) Value() string {
 	return m.input.Value()
 }
 
+// SetValue replaces the input value, with the cursor at its end.
+func (m *Model) SetValue(value string) {
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+}
+
 // Mode returns the current mode.
 func (m Model) Mode() Mode {
 	return m.mode
@@ -117,14 +160,16 @@ func (m Model) View() string {
 	if m.mode == ModeHidden {
 		// Show hint bar when hidden
 		return m.styles.CommandHint.Width(m.width).Render(
-			"Press : for commands, / to filter, ? for help",
+			"Press : for commands, Ctrl+K for all actions, / to filter, ? for help",
 		)
 	}
 
 	// Show active input (textinput.View() already includes the prompt)
 	input := m.input.View()
 	hint := ""
-	if m.hint != "" {
+	if m.err != "" {
+		hint = "  " + m.styles.StatusProblem.Render(m.err)
+	} else if m.hint != "" {
 		hint = "  " + m.styles.CommandHint.Render(m.hint)
 	}
 
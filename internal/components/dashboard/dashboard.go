@@ -0,0 +1,602 @@
+// Package dashboard provides a full screen view of Zabbix dashboards: a
+// list to choose one from, and its widgets laid out like on the server.
+package dashboard
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NimbleMarkets/ntcharts/barchart"
+	tslc "github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/harpchad/chotko/internal/components/overlay"
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// OpenMsg is sent when a dashboard is chosen from the list.
+type OpenMsg struct {
+	DashboardID string
+}
+
+// PageMsg is sent when another page of the dashboard is shown, whose
+// widget data must be loaded.
+type PageMsg struct {
+	Page int
+}
+
+// RefreshMsg is sent when the widget data of the shown page should be
+// loaded again.
+type RefreshMsg struct{}
+
+// GroupMsg is sent when a host group is clicked on a problems by severity
+// widget, to show its problems.
+type GroupMsg struct {
+	Group zabbix.HostGroup
+}
+
+// placement is where a widget is drawn on the grid.
+type placement struct {
+	widget        zabbix.Widget
+	left, top     int
+	width, height int
+}
+
+// keyMap defines the keys of the dashboard view.
+type keyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	Open     key.Binding
+	PrevPage key.Binding
+	NextPage key.Binding
+	Refresh  key.Binding
+	Back     key.Binding
+}
+
+var keys = keyMap{
+	Up:       key.NewBinding(key.WithKeys("up", "k")),
+	Down:     key.NewBinding(key.WithKeys("down", "j")),
+	Open:     key.NewBinding(key.WithKeys("enter")),
+	PrevPage: key.NewBinding(key.WithKeys("[", "left", "h")),
+	NextPage: key.NewBinding(key.WithKeys("]", "right", "l")),
+	Refresh:  key.NewBinding(key.WithKeys("r")),
+	Back:     key.NewBinding(key.WithKeys("esc", "q")),
+}
+
+// Model represents the dashboard view.
+type Model struct {
+	styles     *theme.Styles
+	timeFormat format.TimeFormat
+	visible    bool
+	width      int
+	height     int
+
+	// The dashboards to choose from, when no dashboard is shown
+	dashboards []zabbix.Dashboard
+	cursor     int
+
+	// The shown dashboard and page, and the data of its widgets; data is
+	// nil while it loads
+	dashboard *zabbix.Dashboard
+	page      int
+	data      *zabbix.WidgetData
+	updated   time.Time
+}
+
+// New creates a new dashboard view.
+func New(styles *theme.Styles) Model {
+	return Model{styles: styles}
+}
+
+// SetScreenSize sets the size of the view, the whole screen.
+func (m *Model) SetScreenSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetTimeFormat sets how times are displayed.
+func (m *Model) SetTimeFormat(f format.TimeFormat) {
+	m.timeFormat = f
+}
+
+// ShowList shows the dashboards to choose from.
+func (m *Model) ShowList(dashboards []zabbix.Dashboard) {
+	m.visible = true
+	m.dashboards = dashboards
+	m.cursor = 0
+	m.dashboard = nil
+	m.data = nil
+}
+
+// ShowDashboard shows the first page of a dashboard, loading until its
+// widget data is set with SetData.
+func (m *Model) ShowDashboard(d *zabbix.Dashboard) {
+	m.visible = true
+	m.dashboard = d
+	m.page = 0
+	m.data = nil
+}
+
+// SetData sets the widget data of a page, ignored if another page or
+// dashboard is shown by now.
+func (m *Model) SetData(dashboardID string, page int, data *zabbix.WidgetData) {
+	if m.dashboard == nil || m.dashboard.DashboardID != dashboardID || m.page != page {
+		return
+	}
+	m.data = data
+	m.updated = time.Now()
+}
+
+// Hide closes the view.
+func (m *Model) Hide() {
+	m.visible = false
+	m.dashboards = nil
+	m.dashboard = nil
+	m.data = nil
+}
+
+// Visible returns true if the view is shown.
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// Dashboard returns the shown dashboard, or nil if none is.
+func (m Model) Dashboard() *zabbix.Dashboard {
+	return m.dashboard
+}
+
+// Page returns the index of the shown page.
+func (m Model) Page() int {
+	return m.page
+}
+
+// Widgets returns the widgets of the shown page.
+func (m Model) Widgets() []zabbix.Widget {
+	if m.dashboard == nil {
+		return nil
+	}
+	pages := m.dashboard.PageList()
+	return pages[min(m.page, len(pages)-1)].Widgets
+}
+
+// Init initializes the component.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles keys: choosing a dashboard from the list, and switching
+// pages and refreshing a dashboard. Clicks on the host groups of problems
+// by severity widgets send a GroupMsg.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if mouseMsg, ok := msg.(tea.MouseMsg); ok && m.visible {
+		return m, m.click(mouseMsg)
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !m.visible {
+		return m, nil
+	}
+
+	if m.dashboard == nil {
+		switch {
+		case key.Matches(keyMsg, keys.Up):
+			m.cursor = max(0, m.cursor-1)
+		case key.Matches(keyMsg, keys.Down):
+			m.cursor = min(len(m.dashboards)-1, m.cursor+1)
+		case key.Matches(keyMsg, keys.Open):
+			if m.cursor < len(m.dashboards) {
+				id := m.dashboards[m.cursor].DashboardID
+				return m, func() tea.Msg { return OpenMsg{DashboardID: id} }
+			}
+		case key.Matches(keyMsg, keys.Back):
+			m.Hide()
+		}
+		return m, nil
+	}
+
+	pages := len(m.dashboard.PageList())
+	switch {
+	case key.Matches(keyMsg, keys.PrevPage) && m.page > 0:
+		return m.showPage(m.page - 1)
+	case key.Matches(keyMsg, keys.NextPage) && m.page < pages-1:
+		return m.showPage(m.page + 1)
+	case key.Matches(keyMsg, keys.Refresh):
+		return m, func() tea.Msg { return RefreshMsg{} }
+	case key.Matches(keyMsg, keys.Back):
+		if len(m.dashboards) == 0 {
+			m.Hide()
+			return m, nil
+		}
+		// Back to the list, on the dashboard just closed
+		m.dashboard = nil
+		m.data = nil
+	}
+	return m, nil
+}
+
+// click returns a GroupMsg for a left click on a host group of a problems
+// by severity widget, or nil.
+func (m Model) click(msg tea.MouseMsg) tea.Cmd {
+	if msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionRelease || m.dashboard == nil || m.data == nil {
+		return nil
+	}
+	// The grid starts below the title line
+	x, y := msg.X, msg.Y-1
+	for _, p := range m.placements(m.Widgets(), m.width, max(1, m.height-2)) {
+		if p.widget.Type != zabbix.WidgetTypeProblemsBySeverity ||
+			x <= p.left || x >= p.left+p.width-1 || y <= p.top || y >= p.top+p.height-1 {
+			continue
+		}
+		// Below the border and the widget title, a host group a row
+		groups := m.data.Groups[p.widget.WidgetID]
+		i := y - p.top - 2
+		if i < 0 || i >= shownGroups(len(groups), p.height-3) {
+			return nil
+		}
+		group := groups[i].Group
+		return func() tea.Msg { return GroupMsg{Group: group} }
+	}
+	return nil
+}
+
+// showPage shows another page of the dashboard and asks for its data.
+func (m Model) showPage(page int) (Model, tea.Cmd) {
+	m.page = page
+	m.data = nil
+	return m, func() tea.Msg { return PageMsg{Page: page} }
+}
+
+// View renders the list of dashboards or the shown dashboard.
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+	if m.dashboard == nil {
+		return m.viewList()
+	}
+	return m.viewDashboard()
+}
+
+// viewList renders the dashboards to choose from.
+func (m Model) viewList() string {
+	lines := []string{m.styles.Title.Render("Dashboards"), strings.Repeat("─", m.width), ""}
+	if len(m.dashboards) == 0 {
+		lines = append(lines, m.styles.Subtle.Render("  No dashboards found"))
+	}
+	for i, d := range m.dashboards {
+		line := "  " + d.Name
+		if i == m.cursor {
+			line = m.styles.AlertSelected.Render(fitWidth("> "+d.Name, m.width))
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "", m.styles.Subtle.Render("[enter] open [esc] close"))
+	return strings.Join(lines, "\n")
+}
+
+// viewDashboard renders the widgets of the shown page on a grid scaled to
+// the screen.
+func (m Model) viewDashboard() string {
+	pages := m.dashboard.PageList()
+	title := m.styles.Title.Render(m.dashboard.Name)
+	if len(pages) > 1 {
+		page := fmt.Sprintf("  page %d/%d", m.page+1, len(pages))
+		if name := pages[m.page].Name; name != "" {
+			page += ": " + name
+		}
+		title += m.styles.Subtle.Render(page)
+	}
+	if !m.updated.IsZero() && m.data != nil {
+		title += m.styles.Subtle.Render("  updated " + m.timeFormat.Clock(m.updated))
+	}
+	hint := "[r]efresh [esc] back"
+	if len(pages) > 1 {
+		hint = "[ ] page " + hint
+	}
+
+	gridHeight := max(1, m.height-2)
+	var grid string
+	switch widgets := m.Widgets(); {
+	case m.data == nil:
+		grid = m.styles.Subtle.Render("  Loading dashboard...")
+	case len(widgets) == 0:
+		grid = m.styles.Subtle.Render("  This page has no widgets")
+	default:
+		grid = m.viewGrid(widgets, m.width, gridHeight)
+	}
+	grid = lipgloss.NewStyle().Height(gridHeight).MaxHeight(gridHeight).Render(grid)
+	return strings.Join([]string{title, grid, m.styles.Subtle.Render(hint)}, "\n")
+}
+
+// viewGrid places the widgets on a width by height area, scaling the
+// dashboard grid to it.
+func (m Model) viewGrid(widgets []zabbix.Widget, width, height int) string {
+	canvas := strings.TrimSuffix(strings.Repeat(strings.Repeat(" ", width)+"\n", height), "\n")
+	for _, p := range m.placements(widgets, width, height) {
+		canvas = overlay.Place(canvas, m.viewWidget(p.widget, p.width, p.height), p.left, p.top)
+	}
+	return canvas
+}
+
+// placements returns where the widgets are drawn on a width by height area,
+// leaving out those too small to show anything.
+func (m Model) placements(widgets []zabbix.Widget, width, height int) []placement {
+	columns, rows := m.dashboard.Columns, 1
+	for _, w := range widgets {
+		x, y, ww, wh := w.Rect()
+		columns = max(columns, x+ww)
+		rows = max(rows, y+wh)
+	}
+
+	var placements []placement
+	for _, w := range widgets {
+		x, y, ww, wh := w.Rect()
+		left, right := x*width/columns, (x+ww)*width/columns
+		top, bottom := y*height/rows, (y+wh)*height/rows
+		if right-left < 4 || bottom-top < 3 {
+			continue
+		}
+		placements = append(placements, placement{widget: w, left: left, top: top, width: right - left, height: bottom - top})
+	}
+	return placements
+}
+
+// viewWidget renders a widget in a box of width by height.
+func (m Model) viewWidget(w zabbix.Widget, width, height int) string {
+	innerWidth, innerHeight := width-2, height-2
+	title := w.Name
+	if title == "" {
+		title = widgetTypeName(w.Type)
+	}
+	lines := []string{m.styles.PaneTitle.Render(fitWidth(title, innerWidth-2))}
+
+	bodyHeight := innerHeight - 1
+	switch w.Type {
+	case zabbix.WidgetTypeProblems:
+		lines = append(lines, m.problemLines(m.data.Problems[w.WidgetID], innerWidth, bodyHeight)...)
+	case zabbix.WidgetTypeGraph, zabbix.WidgetTypeSVGGraph:
+		lines = append(lines, m.graphLines(m.data.Items[w.WidgetID], innerWidth, bodyHeight)...)
+	case zabbix.WidgetTypeItem:
+		lines = append(lines, m.itemLines(m.data.Items[w.WidgetID], innerWidth)...)
+	case zabbix.WidgetTypeProblemsBySeverity:
+		lines = append(lines, m.groupLines(m.data.Groups[w.WidgetID], innerWidth, bodyHeight)...)
+	default:
+		lines = append(lines, m.styles.Subtle.Render(fitWidth("Not shown in the terminal", innerWidth)))
+	}
+
+	for i := range lines {
+		lines[i] = ansi.Truncate(lines[i], innerWidth, "…")
+	}
+	return m.styles.PaneBlurred.
+		Width(innerWidth).
+		Height(innerHeight).
+		MaxHeight(height).
+		Render(strings.Join(lines[:min(len(lines), innerHeight)], "\n"))
+}
+
+// problemLines renders the problems of a problems widget, newest first,
+// as many as fit.
+func (m Model) problemLines(problems []zabbix.Problem, width, height int) []string {
+	if len(problems) == 0 {
+		return []string{m.styles.StatusOK.Render("No problems")}
+	}
+	var lines []string
+	for i, p := range problems {
+		if i == height-1 && len(problems) > height {
+			lines = append(lines, m.styles.Subtle.Render(fmt.Sprintf("and %d more", len(problems)-i)))
+			break
+		}
+		severity := p.SeverityInt()
+		icon := m.styles.AlertSeverity[severity].Render(m.styles.SeverityIcon[severity])
+		host := ""
+		if len(p.Hosts) > 0 {
+			host = m.styles.AlertHost.Render(p.Hosts[0].DisplayName()) + " "
+		}
+		lines = append(lines, ansi.Truncate(icon+" "+host+p.Name, width, "…"))
+	}
+	return lines
+}
+
+// groupLines charts the problem counts of a problems by severity widget as
+// horizontal bars, a host group a row, each colored by the worst severity of
+// its problems.
+func (m Model) groupLines(groups []zabbix.GroupProblems, width, height int) []string {
+	if len(groups) == 0 {
+		return []string{m.styles.StatusOK.Render("No problems")}
+	}
+	shown := shownGroups(len(groups), height)
+	if shown == 0 {
+		return []string{m.styles.Subtle.Render(fmt.Sprintf("%d host groups", len(groups)))}
+	}
+
+	// The chart measures labels in bytes, so they are drawn beside it
+	countWidth, nameWidth, most := 1, 0, 1
+	for _, g := range groups[:shown] {
+		total := g.Problems.Total()
+		countWidth = max(countWidth, len(strconv.Itoa(total)))
+		nameWidth = max(nameWidth, ansi.StringWidth(g.Group.Name))
+		most = max(most, total)
+	}
+	nameWidth = max(1, min(nameWidth, width/2-countWidth-1))
+	chartWidth := max(1, width-nameWidth-countWidth-2)
+
+	chart := barchart.New(chartWidth, shown,
+		barchart.WithHorizontalBars(),
+		barchart.WithNoAxis(),
+		barchart.WithNoAutoBarWidth(),
+		barchart.WithBarWidth(1),
+		barchart.WithBarGap(0),
+		barchart.WithMaxValue(float64(most)),
+	)
+	for _, g := range groups[:shown] {
+		style := m.styles.StatusOK
+		if worst := g.Problems.Worst(); worst >= 0 {
+			style = m.styles.AlertSeverity[worst]
+		}
+		chart.Push(barchart.BarData{
+			Label:  g.Group.Name,
+			Values: []barchart.BarValue{{Name: g.Group.Name, Value: float64(g.Problems.Total()), Style: style}},
+		})
+	}
+	chart.Draw()
+	bars := strings.Split(chart.View(), "\n")
+
+	lines := make([]string, 0, height)
+	for i, g := range groups[:shown] {
+		count := fmt.Sprintf("%*d", countWidth, g.Problems.Total())
+		line := fitWidth(g.Group.Name, nameWidth) + " " + m.styles.Title.Render(count) + " "
+		if i < len(bars) {
+			line += bars[i]
+		}
+		lines = append(lines, line)
+	}
+	if shown < len(groups) {
+		lines = append(lines, m.styles.Subtle.Render(fmt.Sprintf("and %d more", len(groups)-shown)))
+	}
+	return lines
+}
+
+// shownGroups returns how many of count host groups fit in height rows,
+// keeping the last row to tell how many more there are.
+func shownGroups(count, height int) int {
+	if count > height {
+		return max(0, height-1)
+	}
+	return count
+}
+
+// graphLines charts the numeric items of a graph widget, with a legend of
+// their latest values below.
+func (m Model) graphLines(items []zabbix.Item, width, height int) []string {
+	var charted []zabbix.Item
+	var first, last time.Time
+	for _, item := range items {
+		history := m.data.History[item.ItemID]
+		if !item.IsNumeric() || len(history) == 0 {
+			continue
+		}
+		charted = append(charted, item)
+		if t := history[0].Time(); first.IsZero() || t.Before(first) {
+			first = t
+		}
+		if t := history[len(history)-1].Time(); t.After(last) {
+			last = t
+		}
+	}
+	if len(charted) == 0 {
+		return []string{m.styles.Subtle.Render("No data")}
+	}
+
+	legendHeight := min(len(charted), max(1, height/4))
+	chartHeight := height - legendHeight
+	var lines []string
+	if chartHeight >= 3 {
+		chart := tslc.New(width, chartHeight,
+			tslc.WithXLabelFormatter(func(_ int, v float64) string {
+				return m.timeFormat.ClockShort(time.Unix(int64(v), 0))
+			}),
+			tslc.WithYLabelFormatter(func(_ int, v float64) string {
+				return format.YAxisValue(v, charted[0].Units)
+			}),
+			tslc.WithAxesStyles(m.styles.ChartAxis, m.styles.ChartLabel),
+			tslc.WithTimeRange(first, last),
+		)
+		for i, item := range charted {
+			chart.SetDataSetStyle(item.ItemID, m.seriesStyle(i))
+			for _, h := range m.data.History[item.ItemID] {
+				chart.PushDataSet(item.ItemID, tslc.TimePoint{Time: h.Time(), Value: h.ValueFloat()})
+			}
+		}
+		chart.DrawBrailleAll()
+		lines = strings.Split(chart.View(), "\n")
+	} else {
+		legendHeight = height
+	}
+
+	for i, item := range charted[:min(len(charted), legendHeight)] {
+		label := item.Name
+		if len(charted) > 1 && len(item.Hosts) > 0 {
+			label = item.HostName() + ": " + label
+		}
+		lines = append(lines, m.seriesStyle(i).Render("●")+" "+label+" "+m.styles.Title.Render(itemValue(item)))
+	}
+	return lines
+}
+
+// itemLines renders the latest value of the item of an item widget.
+func (m Model) itemLines(items []zabbix.Item, width int) []string {
+	if len(items) == 0 {
+		return []string{m.styles.Subtle.Render("No data")}
+	}
+	item := items[0]
+	label := item.Name
+	if len(item.Hosts) > 0 {
+		label = item.HostName() + ": " + label
+	}
+	lines := []string{
+		m.styles.Subtle.Render(label),
+		lipgloss.PlaceHorizontal(width, lipgloss.Center, m.styles.Title.Render(itemValue(item))),
+	}
+	if t := item.LastTime(); !t.IsZero() {
+		lines = append(lines, m.styles.Subtle.Render(lipgloss.PlaceHorizontal(width, lipgloss.Center, m.timeFormat.Clock(t))))
+	}
+	return lines
+}
+
+// seriesStyle returns the style of the i-th charted item.
+func (m Model) seriesStyle(i int) lipgloss.Style {
+	styles := []lipgloss.Style{
+		m.styles.ChartLine,
+		m.styles.StatusOK,
+		m.styles.AlertSeverity[2],
+		m.styles.AlertSeverity[4],
+		m.styles.AlertHost,
+		m.styles.StatusMaint,
+		m.styles.AlertSeverity[1],
+		m.styles.AlertSeverity[3],
+	}
+	return styles[i%len(styles)]
+}
+
+// itemValue formats the latest value of an item, mapped by its value map
+// if it has one.
+func itemValue(item zabbix.Item) string {
+	if mapped := item.MappedValue(item.LastValue); mapped != "" {
+		return mapped
+	}
+	if !item.IsNumeric() {
+		line, _, _ := strings.Cut(item.LastValue, "\n")
+		return line
+	}
+	return format.Value(item.LastValueFloat(), item.Units)
+}
+
+// widgetTypeName returns the name Zabbix shows for a widget type, used as
+// the title of widgets without a name.
+func widgetTypeName(widgetType string) string {
+	switch widgetType {
+	case zabbix.WidgetTypeGraph:
+		return "Graph (classic)"
+	case zabbix.WidgetTypeSVGGraph:
+		return "Graph"
+	case zabbix.WidgetTypeProblems:
+		return "Problems"
+	case zabbix.WidgetTypeItem:
+		return "Item value"
+	case zabbix.WidgetTypeProblemsBySeverity:
+		return "Problems by severity"
+	}
+	return widgetType
+}
+
+// fitWidth truncates or pads text to width.
+func fitWidth(text string, width int) string {
+	text = ansi.Truncate(text, max(0, width), "…")
+	return text + strings.Repeat(" ", max(0, width-ansi.StringWidth(text)))
+}
@@ -0,0 +1,166 @@
+package dashboard
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+func testStyles() *theme.Styles {
+	return theme.NewStyles(theme.DefaultTheme())
+}
+
+func testDashboard() *zabbix.Dashboard {
+	return &zabbix.Dashboard{
+		DashboardID: "1",
+		Name:        "Overview",
+		Columns:     24,
+		Pages: []zabbix.DashboardPage{
+			{Name: "Problems", Widgets: []zabbix.Widget{
+				{WidgetID: "10", Type: zabbix.WidgetTypeProblems, Name: "Current problems", X: "0", Y: "0", Width: "12", Height: "4"},
+				{WidgetID: "11", Type: zabbix.WidgetTypeItem, X: "12", Y: "0", Width: "12", Height: "4"},
+				{WidgetID: "12", Type: zabbix.WidgetTypeSVGGraph, Name: "CPU", X: "0", Y: "4", Width: "24", Height: "4"},
+				{WidgetID: "13", Type: "clock", X: "0", Y: "8", Width: "24", Height: "2"},
+			}},
+			{Name: "Network"},
+		},
+	}
+}
+
+func testData() *zabbix.WidgetData {
+	cpu := zabbix.Item{ItemID: "23", Name: "CPU utilization", ValueType: zabbix.ItemValueTypeFloat, Units: "%", LastValue: "42"}
+	history := make([]zabbix.History, 30)
+	for i := range history {
+		history[i] = zabbix.History{ItemID: "23", Clock: strconv.Itoa(1_700_000_000 + 60*i), Value: strconv.Itoa(i)}
+	}
+	return &zabbix.WidgetData{
+		Items: map[string][]zabbix.Item{
+			"11": {{
+				ItemID: "7", Name: "Uplink status", ValueType: zabbix.ItemValueTypeUnsigned, LastValue: "1",
+				ValueMap: &zabbix.ValueMap{Mappings: []zabbix.ValueMapping{{Value: "1", NewValue: "up"}}},
+			}},
+			"12": {cpu},
+		},
+		History: map[string][]zabbix.History{"23": history},
+		Problems: map[string][]zabbix.Problem{
+			"10": {{EventID: "100", Name: "Disk is full", Severity: "4", Hosts: []zabbix.Host{{Name: "db01"}}}},
+		},
+	}
+}
+
+func TestList(t *testing.T) {
+	m := New(testStyles())
+	m.SetScreenSize(100, 30)
+	m.ShowList([]zabbix.Dashboard{{DashboardID: "1", Name: "Global view"}, {DashboardID: "2", Name: "Network"}})
+	if !m.Visible() || !strings.Contains(m.View(), "Network") {
+		t.Fatalf("view should list the dashboards:\n%s", m.View())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should open the selected dashboard")
+	}
+	if msg, ok := cmd().(OpenMsg); !ok || msg.DashboardID != "2" {
+		t.Errorf("message = %v, want OpenMsg for dashboard 2", msg)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Visible() {
+		t.Error("esc should close the list")
+	}
+}
+
+func TestDashboard(t *testing.T) {
+	m := New(testStyles())
+	m.SetScreenSize(120, 40)
+	m.ShowList([]zabbix.Dashboard{{DashboardID: "1", Name: "Overview"}})
+	m.ShowDashboard(testDashboard())
+	if !strings.Contains(m.View(), "Loading dashboard") {
+		t.Errorf("view should show the dashboard loading:\n%s", m.View())
+	}
+
+	m.SetData("1", 0, testData())
+	view := m.View()
+	for _, want := range []string{"Overview", "page 1/2: Problems", "Current problems", "db01 Disk is full", "up (1)", "CPU utilization 42.0%", "Not shown in the terminal"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+	if lines := strings.Split(view, "\n"); len(lines) != 40 {
+		t.Errorf("view has %d lines, want the screen height", len(lines))
+	}
+
+	// Switching pages asks for their data, and data of other pages is ignored
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")})
+	if msg, ok := cmd().(PageMsg); !ok || msg.Page != 1 {
+		t.Errorf("message = %v, want PageMsg for page 1", msg)
+	}
+	m.SetData("1", 0, testData())
+	if !strings.Contains(m.View(), "Loading dashboard") {
+		t.Error("data of another page should be ignored")
+	}
+	m.SetData("1", 1, &zabbix.WidgetData{})
+	if !strings.Contains(m.View(), "This page has no widgets") {
+		t.Errorf("view should show the empty page:\n%s", m.View())
+	}
+
+	// Esc goes back to the list it was opened from
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.Visible() || m.Dashboard() != nil {
+		t.Error("esc should go back to the list")
+	}
+}
+
+func TestProblemsBySeverity(t *testing.T) {
+	m := New(testStyles())
+	m.SetScreenSize(80, 20)
+	m.ShowDashboard(&zabbix.Dashboard{
+		DashboardID: "1",
+		Name:        "Groups",
+		Columns:     24,
+		Pages: []zabbix.DashboardPage{{Widgets: []zabbix.Widget{
+			{WidgetID: "10", Type: zabbix.WidgetTypeProblemsBySeverity, X: "0", Y: "0", Width: "24", Height: "4"},
+		}}},
+	})
+	databases := zabbix.ProblemCounts{}
+	databases.Severities[5] = 1
+	web := zabbix.ProblemCounts{}
+	web.Severities[2] = 3
+	web.Severities[4] = 9
+	m.SetData("1", 0, &zabbix.WidgetData{Groups: map[string][]zabbix.GroupProblems{"10": {
+		{Group: zabbix.HostGroup{GroupID: "3", Name: "Databases"}, Problems: databases},
+		{Group: zabbix.HostGroup{GroupID: "2", Name: "Web servers"}, Problems: web},
+	}}})
+
+	view := m.View()
+	for _, want := range []string{"Problems by severity", "Databases    1", "Web servers 12"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+	if !strings.Contains(view, "█") {
+		t.Errorf("view should chart the problem counts:\n%s", view)
+	}
+
+	// Below the title line, the border and the widget title, a group a row
+	click := func(y int) tea.Cmd {
+		_, cmd := m.Update(tea.MouseMsg{X: 5, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionRelease})
+		return cmd
+	}
+	cmd := click(4)
+	if cmd == nil {
+		t.Fatal("clicking a host group should show its problems")
+	}
+	if msg, ok := cmd().(GroupMsg); !ok || msg.Group.Name != "Web servers" {
+		t.Errorf("message = %v, want GroupMsg for Web servers", msg)
+	}
+	if click(2) != nil || click(5) != nil {
+		t.Error("clicks beside the host groups should do nothing")
+	}
+}
@@ -0,0 +1,93 @@
+package detail
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tslc "github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// Baseline is the history of an item some time ago, drawn over its chart
+// shifted by Offset to compare it with the present.
+type Baseline struct {
+	Offset  time.Duration
+	History []zabbix.History
+}
+
+// Label names the baseline, e.g. "7d ago".
+func (b Baseline) Label() string {
+	return format.Span(b.Offset) + " ago"
+}
+
+// SetBaselines sets the past histories drawn over the chart of the item
+// with an ID, the nearest first; nil stops drawing them.
+func (m *Model) SetBaselines(itemID string, baselines []Baseline) {
+	m.baselineItem = itemID
+	m.baselines = baselines
+}
+
+// currentBaselines returns the baselines of the displayed item.
+func (m Model) currentBaselines() []Baseline {
+	if m.item == nil || m.item.ItemID != m.baselineItem {
+		return nil
+	}
+	return m.baselines
+}
+
+// pushBaselines adds the baselines of the displayed item to a chart as data
+// sets of their own, shifted to the present, and returns the names of those
+// with history: the chart stops drawing at an empty data set.
+func (m Model) pushBaselines(chart *tslc.Model) []string {
+	var names []string
+	for i, b := range m.currentBaselines() {
+		name := b.Label()
+		pushed := false
+		for _, h := range b.History {
+			if t := h.Time(); !t.IsZero() {
+				chart.PushDataSet(name, tslc.TimePoint{Time: t.Add(b.Offset), Value: h.ValueFloat()})
+				pushed = true
+			}
+		}
+		if pushed {
+			chart.SetDataSetStyle(name, m.baselineStyle(i))
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// baselineStyle returns the style of the ith baseline.
+func (m Model) baselineStyle(i int) lipgloss.Style {
+	return m.styles.ChartBaselines[min(i, len(m.styles.ChartBaselines)-1)]
+}
+
+// baselineLines returns the legend of the chart's baselines and how the
+// average of each compares with the present one.
+func (m Model) baselineLines(avgVal float64) []string {
+	baselines := m.currentBaselines()
+	if len(baselines) == 0 {
+		return nil
+	}
+
+	legend := []string{m.styles.ChartLine.Render("━ now")}
+	var lines []string
+	for i, b := range baselines {
+		legend = append(legend, m.baselineStyle(i).Render("━ "+b.Label()))
+		if len(b.History) == 0 {
+			lines = append(lines, m.styles.Subtle.Render(b.Label()+": no history"))
+			continue
+		}
+		_, _, avg := calcStats(b.History)
+		line := fmt.Sprintf("%s: Avg: %s", b.Label(), format.Value(avg, m.item.Units))
+		if avg != 0 {
+			line += fmt.Sprintf(" (now %+.0f%%)", (avgVal-avg)/avg*100)
+		}
+		lines = append(lines, m.styles.Subtle.Render(line))
+	}
+	return append([]string{strings.Join(legend, "  ")}, lines...)
+}
@@ -0,0 +1,260 @@
+package detail
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tslc "github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// Layout of the grid of small charts comparing hosts.
+const (
+	minCellWidth    = 30 // Columns of a grid cell, at least
+	cellChartHeight = 4  // Rows of a grid cell's chart
+)
+
+// Series is the history of the compared item on one host.
+type Series struct {
+	Item    zabbix.Item
+	History []zabbix.History
+}
+
+// SetComparison shows the history of an item on several hosts side by side,
+// in a grid of small charts on the same scale. The comparison is kept when
+// the item with ID itemID, which it was opened from, is refreshed with
+// SetItem, until another item is shown.
+func (m *Model) SetComparison(itemID string, series []Series) {
+	same := m.ComparedItem() == itemID
+	m.mode = ViewModeCompare
+	m.compareItem = itemID
+	m.series = series
+	if !same {
+		m.overlay = false
+		m.scrollToStart()
+	}
+}
+
+// ComparedItem returns the ID of the item whose comparison across hosts is
+// shown, or empty if none is.
+func (m Model) ComparedItem() string {
+	if m.mode != ViewModeCompare {
+		return ""
+	}
+	return m.compareItem
+}
+
+// Overlaid returns true if the compared hosts are drawn on one chart rather
+// than a grid.
+func (m Model) Overlaid() bool {
+	return m.overlay
+}
+
+// SetOverlaid draws the compared hosts on one chart, or on a grid of small
+// charts.
+func (m *Model) SetOverlaid(overlay bool) {
+	m.overlay = overlay
+	m.scrollToStart()
+}
+
+// CloseComparison shows the detail of the item the comparison was opened
+// from again.
+func (m *Model) CloseComparison() {
+	if m.ComparedItem() == "" {
+		return
+	}
+	m.mode = ViewModeGraph
+	m.series = nil
+	m.scrollToStart()
+}
+
+// compareLines returns the body lines for the comparison view.
+func (m Model) compareLines() []string {
+	if len(m.series) == 0 {
+		return []string{"", m.styles.Subtle.Render("  No hosts to compare")}
+	}
+	item := m.series[0].Item
+	lines := []string{
+		m.renderField("Item", item.Name),
+		m.renderField("Key", item.Key),
+		m.renderField("Hosts", fmt.Sprint(len(m.series))),
+		"",
+	}
+
+	first, last, minVal, maxVal, ok := m.seriesRange()
+	if !ok {
+		lines = append(lines, m.styles.Subtle.Render("  No history data available"))
+	} else {
+		if m.overlay {
+			lines = append(lines, m.overlayLines(first, last, minVal, maxVal)...)
+		} else {
+			lines = append(lines, m.gridLines(first, last, minVal, maxVal)...)
+		}
+		lines = append(lines, "", m.styles.Subtle.Render(fmt.Sprintf("%s - %s  Scale: %s - %s",
+			m.timeFormat.ClockShort(first), m.timeFormat.ClockShort(last),
+			format.Value(minVal, item.Units), format.Value(maxVal, item.Units))))
+	}
+
+	hint := "[=] overlay [r]efresh"
+	if m.overlay {
+		hint = "[=] close [r]efresh"
+	}
+	return append(lines,
+		"",
+		strings.Repeat("─", max(0, m.width-4)),
+		m.styles.Subtle.Render(hint),
+	)
+}
+
+// seriesRange returns the time and value range of all compared hosts, so
+// that they are charted on the same scale. ok is false if none has history.
+func (m Model) seriesRange() (first, last time.Time, minVal, maxVal float64, ok bool) {
+	for _, s := range m.series {
+		for _, h := range s.History {
+			t := h.Time()
+			if t.IsZero() {
+				continue
+			}
+			v := h.ValueFloat()
+			if !ok {
+				first, last, minVal, maxVal, ok = t, t, v, v, true
+				continue
+			}
+			if t.Before(first) {
+				first = t
+			}
+			if t.After(last) {
+				last = t
+			}
+			minVal = min(minVal, v)
+			maxVal = max(maxVal, v)
+		}
+	}
+	if minVal == maxVal {
+		// A flat line needs a range to be drawn in
+		maxVal++
+	}
+	return first, last, minVal, maxVal, ok
+}
+
+// gridLines renders the compared hosts as a grid of small charts, as many
+// cells a row as fit the pane.
+func (m Model) gridLines(first, last time.Time, minVal, maxVal float64) []string {
+	width := max(minCellWidth, m.width-8)
+	columns := max(1, width/minCellWidth)
+	cellWidth := width / columns
+
+	var lines []string
+	for row := 0; row < len(m.series); row += columns {
+		if row > 0 {
+			lines = append(lines, "")
+		}
+		var cells []string
+		for _, s := range m.series[row:min(row+columns, len(m.series))] {
+			cells = append(cells, m.cell(s, cellWidth, first, last, minVal, maxVal))
+		}
+		lines = append(lines, strings.Split(lipgloss.JoinHorizontal(lipgloss.Top, cells...), "\n")...)
+	}
+	return lines
+}
+
+// cell renders a host's name and latest value above a chart of its history,
+// in a cell of width columns.
+func (m Model) cell(s Series, width int, first, last time.Time, minVal, maxVal float64) string {
+	inner := width - 2 // A gap between the cells
+	value := m.styles.Title.Render(seriesValue(s.Item))
+	host := ansi.Truncate(s.Item.HostName(), max(1, inner-lipgloss.Width(value)-1), "…")
+	header := m.styles.AlertHost.Render(host) + " " + value
+
+	body := m.styles.Subtle.Render("no data")
+	if points := timePoints(s.History); len(points) > 0 {
+		chart := tslc.New(inner, cellChartHeight,
+			tslc.WithXYSteps(0, 0),
+			tslc.WithTimeRange(first, last),
+			tslc.WithYRange(minVal, maxVal),
+			tslc.WithStyle(m.styles.ChartLine),
+		)
+		for _, p := range points {
+			chart.Push(p)
+		}
+		chart.DrawBraille()
+		body = chart.View()
+	}
+	return lipgloss.NewStyle().Width(width).Height(1 + cellChartHeight).Render(header + "\n" + body)
+}
+
+// overlayLines renders the compared hosts on one chart, with a legend of
+// their latest values below.
+func (m Model) overlayLines(first, last time.Time, minVal, maxVal float64) []string {
+	units := m.series[0].Item.Units
+	chart := tslc.New(max(20, m.width-8), max(5, min(15, m.height-len(m.series)-16)),
+		tslc.WithXLabelFormatter(m.timeLabelFormatter()),
+		tslc.WithYLabelFormatter(humanReadableYLabelFormatter(units)),
+		tslc.WithAxesStyles(m.styles.ChartAxis, m.styles.ChartLabel),
+		tslc.WithTimeRange(first, last),
+		tslc.WithYRange(minVal, maxVal),
+	)
+
+	// The chart stops drawing at an empty data set, so only those with
+	// history are drawn
+	var names, legend []string
+	for i, s := range m.series {
+		name := s.Item.ItemID
+		points := timePoints(s.History)
+		for _, p := range points {
+			chart.PushDataSet(name, p)
+		}
+		if len(points) > 0 {
+			chart.SetDataSetStyle(name, m.seriesStyle(i))
+			names = append(names, name)
+		}
+		legend = append(legend, m.seriesStyle(i).Render("●")+" "+
+			m.styles.AlertHost.Render(s.Item.HostName())+" "+m.styles.Title.Render(seriesValue(s.Item)))
+	}
+	chart.DrawBrailleDataSets(names)
+	return append(append(strings.Split(chart.View(), "\n"), ""), legend...)
+}
+
+// seriesStyle returns the style of the ith host on the overlaid chart.
+func (m Model) seriesStyle(i int) lipgloss.Style {
+	styles := []lipgloss.Style{
+		m.styles.ChartLine,
+		m.styles.StatusOK,
+		m.styles.AlertSeverity[2],
+		m.styles.AlertSeverity[4],
+		m.styles.AlertHost,
+		m.styles.StatusMaint,
+		m.styles.AlertSeverity[1],
+		m.styles.AlertSeverity[3],
+	}
+	return styles[i%len(styles)]
+}
+
+// timePoints returns the chart points of a history, skipping values without
+// a time.
+func timePoints(history []zabbix.History) []tslc.TimePoint {
+	points := make([]tslc.TimePoint, 0, len(history))
+	for _, h := range history {
+		if t := h.Time(); !t.IsZero() {
+			points = append(points, tslc.TimePoint{Time: t, Value: h.ValueFloat()})
+		}
+	}
+	return points
+}
+
+// seriesValue formats the latest value of a compared item, mapped by its
+// value map if it has one.
+func seriesValue(item zabbix.Item) string {
+	if item.LastValue == "" {
+		return "-"
+	}
+	if mapped := item.MappedValue(item.LastValue); mapped != "" {
+		return mapped
+	}
+	return format.Value(item.LastValueFloat(), item.Units)
+}
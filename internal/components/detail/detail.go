@@ -3,10 +3,14 @@ package detail
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/NimbleMarkets/ntcharts/linechart"
 	tslc "github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
@@ -24,6 +28,8 @@ const (
 	ViewModeHost
 	ViewModeEvent
 	ViewModeGraph
+	ViewModeDiagnostics
+	ViewModeCompare
 )
 
 // Model represents the detail pane component.
@@ -31,21 +37,61 @@ type Model struct {
 	styles  *theme.Styles
 	mode    ViewMode
 	problem *zabbix.Problem
+	impact  Impact // Related problems of the displayed problem
 	host    *zabbix.Host
 	event   *zabbix.Event
+
+	// maintenances holds the maintenances hosts are in, by ID
+	maintenances map[string]zabbix.Maintenance
+
+	// diagnostics holds the availability details of the diagnosed host
+	diagnostics *zabbix.HostDiagnostics
+
 	item    *zabbix.Item
 	history []zabbix.History
+	// baselines are past histories drawn over the chart of the item with
+	// ID baselineItem
+	baselineItem string
+	baselines    []Baseline
+	// series are the histories of the same item on several hosts, compared
+	// from the item with ID compareItem, overlaid on one chart if overlay
+	// is set
+	compareItem string
+	series      []Series
+	overlay     bool
+	// refCursor is the selected item the calculated item's formula references
+	refCursor int
+
+	// following is set while new values of the text item are polled, and
+	// highlight marks matches in its values
+	following bool
+	highlight *regexp.Regexp
+
 	width   int
 	height  int
 	focused bool
-	scroll  int
+
+	// viewport holds the scrollable body below the pane header, and
+	// xOffset its horizontal scroll position
+	viewport viewport.Model
+	xOffset  int
+
+	// timeFormat controls how problem and event times are displayed
+	timeFormat format.TimeFormat
 }
 
+// Layout constants for the detail pane.
+const (
+	headerHeight   = 2 // Title line plus separator
+	scrollbarWidth = 2 // Gap plus scrollbar column
+)
+
 // New creates a new detail pane model.
 func New(styles *theme.Styles) Model {
 	return Model{
-		styles: styles,
-		mode:   ViewModeProblem,
+		styles:   styles,
+		mode:     ViewModeProblem,
+		viewport: viewport.New(0, 0),
 	}
 }
 
@@ -53,6 +99,9 @@ func New(styles *theme.Styles) Model {
 func (m *Model) SetSize(width, height int) {
 	m.width = width
 	m.height = height
+	m.viewport.Width = max(0, width-scrollbarWidth)
+	m.viewport.Height = max(1, height-headerHeight)
+	m.syncViewport()
 }
 
 // SetFocused sets the focus state.
@@ -60,74 +109,184 @@ func (m *Model) SetFocused(focused bool) {
 	m.focused = focused
 }
 
+// SetTimeFormat sets how problem and event times are displayed.
+func (m *Model) SetTimeFormat(f format.TimeFormat) {
+	m.timeFormat = f
+}
+
 // SetProblem sets the problem to display.
+// The scroll position is kept when the same problem is refreshed.
 func (m *Model) SetProblem(p *zabbix.Problem) {
+	same := m.mode == ViewModeProblem && m.problem != nil && p != nil && m.problem.EventID == p.EventID
 	m.mode = ViewModeProblem
 	m.problem = p
+	m.impact = Impact{}
 	m.host = nil
-	m.scroll = 0
+	if !same {
+		m.scrollToStart()
+	}
 }
 
 // SetHost sets the host to display.
+// The scroll position is kept when the same host is refreshed.
 func (m *Model) SetHost(h *zabbix.Host) {
+	if m.DiagnosedHost() != "" && h != nil && h.HostID == m.DiagnosedHost() {
+		// Keep showing the diagnostics of the refreshed host
+		return
+	}
+	same := m.mode == ViewModeHost && m.host != nil && h != nil && m.host.HostID == h.HostID
 	m.mode = ViewModeHost
 	m.host = h
+	m.diagnostics = nil
 	m.problem = nil
 	m.event = nil
-	m.scroll = 0
+	if !same {
+		m.scrollToStart()
+	}
+}
+
+// SetMaintenances sets the maintenances that hosts in maintenance are in,
+// by maintenance ID.
+func (m *Model) SetMaintenances(maintenances map[string]zabbix.Maintenance) {
+	m.maintenances = maintenances
 }
 
 // SetEvent sets the event to display.
+// The scroll position is kept when the same event is refreshed.
 func (m *Model) SetEvent(e *zabbix.Event) {
+	same := m.mode == ViewModeEvent && m.event != nil && e != nil && m.event.EventID == e.EventID
 	m.mode = ViewModeEvent
 	m.event = e
 	m.problem = nil
 	m.host = nil
 	m.item = nil
 	m.history = nil
-	m.scroll = 0
+	if !same {
+		m.scrollToStart()
+	}
 }
 
 // SetItem sets the item to display with its history data.
+// The scroll position is kept when the same item is refreshed. The values
+// of text items are shown from the end, like a tail of the log, and keep
+// following new values while scrolled to the end.
 func (m *Model) SetItem(i *zabbix.Item, history []zabbix.History) {
+	if m.ComparedItem() != "" && i != nil && i.ItemID == m.ComparedItem() {
+		// Keep showing the comparison of the refreshed item
+		m.item = i
+		m.history = history
+		return
+	}
+	same := m.mode == ViewModeGraph && m.item != nil && i != nil && m.item.ItemID == i.ItemID
+	following := false
+	if same && !i.IsNumeric() {
+		m.syncViewport()
+		following = m.viewport.AtBottom()
+	}
 	m.mode = ViewModeGraph
 	m.item = i
 	m.history = history
 	m.problem = nil
 	m.host = nil
 	m.event = nil
-	m.scroll = 0
+	if !same {
+		m.scrollToStart()
+		m.refCursor = 0
+	}
+	if i != nil && !i.IsNumeric() && (!same || following) {
+		m.GoToBottom()
+	}
 }
 
 // Clear clears the displayed content.
 func (m *Model) Clear() {
 	m.problem = nil
 	m.host = nil
+	m.diagnostics = nil
 	m.event = nil
 	m.item = nil
 	m.history = nil
-	m.scroll = 0
+	m.scrollToStart()
 }
 
 // ScrollUp scrolls the detail view up.
 func (m *Model) ScrollUp() {
-	if m.scroll > 0 {
-		m.scroll--
-	}
+	m.Scroll(-1)
 }
 
 // ScrollDown scrolls the detail view down.
 func (m *Model) ScrollDown() {
-	m.scroll++
+	m.Scroll(1)
 }
 
 // Scroll scrolls the detail view by delta lines (positive = down, negative = up).
+// The offset is bounded by the length of the rendered content.
 func (m *Model) Scroll(delta int) {
-	m.scroll += delta
-	if m.scroll < 0 {
-		m.scroll = 0
+	m.syncViewport()
+	if delta < 0 {
+		m.viewport.ScrollUp(-delta)
+	} else {
+		m.viewport.ScrollDown(delta)
 	}
-	// Note: maxScroll isn't bounded here as it depends on dynamic content
+}
+
+// ScrollHorizontal scrolls the detail view by delta columns (positive =
+// right), showing the ends of lines too long for the pane.
+func (m *Model) ScrollHorizontal(delta int) {
+	_, lines := m.content()
+	widest := 0
+	for _, line := range lines {
+		widest = max(widest, lipgloss.Width(line))
+	}
+	// The viewport doesn't keep the offset in bounds when the content is
+	// narrower than the pane
+	m.xOffset = max(0, min(m.xOffset+delta, widest-m.viewport.Width))
+	m.syncViewport()
+	m.viewport.SetXOffset(m.xOffset)
+}
+
+// PageUp scrolls the detail view up by one page.
+func (m *Model) PageUp() {
+	m.syncViewport()
+	m.viewport.PageUp()
+}
+
+// PageDown scrolls the detail view down by one page.
+func (m *Model) PageDown() {
+	m.syncViewport()
+	m.viewport.PageDown()
+}
+
+// GoToTop scrolls to the beginning of the content.
+func (m *Model) GoToTop() {
+	m.viewport.GotoTop()
+}
+
+// GoToBottom scrolls to the end of the content.
+func (m *Model) GoToBottom() {
+	m.syncViewport()
+	m.viewport.GotoBottom()
+}
+
+// ScrollOffset returns the index of the first visible content line.
+func (m Model) ScrollOffset() int {
+	return m.viewport.YOffset
+}
+
+// scrollToStart scrolls to the top of the content and back to the start of
+// its lines, for newly shown content.
+func (m *Model) scrollToStart() {
+	m.viewport.GotoTop()
+	m.xOffset = 0
+	m.viewport.SetXOffset(0)
+}
+
+// syncViewport refreshes the viewport content so scroll bounds match what
+// will be rendered, clamping the offset if the content shrank.
+func (m *Model) syncViewport() {
+	_, lines := m.content()
+	m.viewport.SetContent(strings.Join(lines, "\n"))
+	m.viewport.SetYOffset(m.viewport.YOffset)
 }
 
 // Init implements tea.Model.
@@ -136,10 +295,72 @@ func (m Model) Init() tea.Cmd {
 }
 
 // Update implements tea.Model.
-func (m Model) Update(_ tea.Msg) (Model, tea.Cmd) {
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.focused {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+			m.ScrollUp()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+			m.ScrollDown()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("pgup", "ctrl+u"))):
+			m.PageUp()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("pgdown", "ctrl+d"))):
+			m.PageDown()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("home", "g"))):
+			m.GoToTop()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("end", "G"))):
+			m.GoToBottom()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("left", "h"))):
+			m.moveReference(-1)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("right", "l"))):
+			m.moveReference(1)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			return m, m.selectReference()
+		}
+	}
+
 	return m, nil
 }
 
+// ReferenceSelectedMsg is sent when Enter is pressed on an item that the
+// formula of the shown calculated or aggregate item references.
+type ReferenceSelectedMsg struct {
+	Item      *zabbix.Item // The calculated or aggregate item
+	Reference zabbix.ItemReference
+}
+
+// references returns the items the shown item's formula references.
+func (m Model) references() []zabbix.ItemReference {
+	if m.mode != ViewModeGraph || m.item == nil {
+		return nil
+	}
+	return m.item.References()
+}
+
+// moveReference selects the next or previous referenced item.
+func (m *Model) moveReference(delta int) {
+	if refs := m.references(); len(refs) > 0 {
+		m.refCursor = (m.refCursor + delta + len(refs)) % len(refs)
+	}
+}
+
+// selectReference returns a command sending the selected referenced item,
+// or nil if the shown item references none.
+func (m Model) selectReference() tea.Cmd {
+	refs := m.references()
+	if len(refs) == 0 {
+		return nil
+	}
+	item, ref := m.item, refs[min(m.refCursor, len(refs)-1)]
+	return func() tea.Msg {
+		return ReferenceSelectedMsg{Item: item, Reference: ref}
+	}
+}
+
 // View implements tea.Model.
 func (m Model) View() string {
 	// Handle zero-size case
@@ -147,356 +368,439 @@ func (m Model) View() string {
 		return ""
 	}
 
+	title, lines := m.content()
+
+	vp := m.viewport
+	vp.SetContent(strings.Join(lines, "\n"))
+	vp.SetYOffset(vp.YOffset)
+
+	var b strings.Builder
+
+	// Header with scroll position indicator
+	b.WriteString(m.styles.PaneTitle.Render(title))
+	if total := vp.TotalLineCount(); total > vp.Height {
+		first := vp.YOffset + 1
+		last := min(vp.YOffset+vp.Height, total)
+		b.WriteString(m.styles.Subtle.Render(fmt.Sprintf("  %d-%d/%d", first, last, total)))
+	}
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", max(0, m.width-4)))
+	b.WriteString("\n")
+
+	body := vp.View()
+	if vp.TotalLineCount() > vp.Height {
+		body = lipgloss.JoinHorizontal(lipgloss.Top, body, " ", m.renderScrollbar(vp))
+	}
+	b.WriteString(body)
+
+	return m.renderPane(b.String())
+}
+
+// content returns the pane title and the scrollable body lines for the current mode.
+func (m Model) content() (title string, lines []string) {
 	switch m.mode {
 	case ViewModeHost:
-		return m.viewHost()
+		return "HOST DETAIL", m.hostLines()
+	case ViewModeDiagnostics:
+		return "HOST DIAGNOSTICS", m.diagnosticsLines()
 	case ViewModeEvent:
-		return m.viewEvent()
+		return "EVENT DETAIL", m.eventLines()
+	case ViewModeCompare:
+		return "COMPARE HOSTS", m.compareLines()
 	case ViewModeGraph:
-		return m.viewGraph()
+		if m.item != nil && !m.item.IsNumeric() {
+			if m.following {
+				return "ITEM HISTORY (following)", m.textHistoryLines()
+			}
+			return "ITEM HISTORY", m.textHistoryLines()
+		}
+		return "GRAPH DETAIL", m.graphLines()
 	default:
-		return m.viewProblem()
+		return "ALERT DETAIL", m.problemLines()
 	}
 }
 
-// viewProblem renders the problem detail view.
-func (m Model) viewProblem() string {
-	var b strings.Builder
+// renderScrollbar renders a vertical scrollbar for the viewport.
+func (m Model) renderScrollbar(vp viewport.Model) string {
+	height := vp.Height
+	total := vp.TotalLineCount()
+	if height <= 0 || total <= height {
+		return ""
+	}
 
-	// Header
-	b.WriteString(m.styles.PaneTitle.Render("ALERT DETAIL"))
-	b.WriteString("\n")
-	b.WriteString(strings.Repeat("─", max(0, m.width-4)))
-	b.WriteString("\n")
+	thumbSize := max(1, height*height/total)
+	maxOffset := total - height
+	thumbStart := 0
+	if maxOffset > 0 {
+		thumbStart = vp.YOffset * (height - thumbSize) / maxOffset
+	}
 
-	if m.problem == nil {
-		b.WriteString("\n")
-		b.WriteString(m.styles.Subtle.Render("  Select an alert to view details"))
-		b.WriteString("\n")
-	} else {
-		p := m.problem
+	bar := make([]string, height)
+	for i := range bar {
+		if i >= thumbStart && i < thumbStart+thumbSize {
+			bar[i] = m.styles.Title.Render("┃")
+		} else {
+			bar[i] = m.styles.Subtle.Render("│")
+		}
+	}
+	return strings.Join(bar, "\n")
+}
 
-		// Build detail lines
-		lines := []string{}
+// problemLines returns the body lines for the problem detail view.
+func (m Model) problemLines() []string {
+	if m.problem == nil {
+		return []string{"", m.styles.Subtle.Render("  Select an alert to view details")}
+	}
 
-		// Host
-		lines = append(lines, m.renderField("Host", p.HostName()))
+	p := m.problem
+	lines := []string{}
 
-		// IP Address
-		if ip := p.HostIP(); ip != "" {
-			lines = append(lines, m.renderField("IP", ip))
-		}
+	// Host
+	lines = append(lines, m.renderField("Host", p.HostName()))
 
-		// Trigger/Problem name
-		lines = append(lines, m.renderField("Trigger", p.Name))
+	// IP Address
+	if ip := p.HostIP(); ip != "" {
+		lines = append(lines, m.renderField("IP", ip))
+	}
 
-		// Severity, Duration, Start time
-		sevName := theme.SeverityName(p.SeverityInt())
-		sevStyle := m.styles.AlertSeverity[p.SeverityInt()]
-		lines = append(lines,
-			m.renderFieldStyled("Severity", sevName, sevStyle),
-			m.renderField("Duration", p.DurationString()),
-			m.renderField("Started", p.StartTime().Format("2006-01-02 15:04:05")),
-		)
+	// Trigger/Problem name
+	lines = append(lines, m.renderField("Trigger", p.Name))
+
+	// Severity, Duration, Start time
+	sevName := theme.SeverityName(p.SeverityInt())
+	sevStyle := m.styles.AlertSeverity[p.SeverityInt()]
+	lines = append(lines,
+		m.renderFieldStyled("Severity", sevName, sevStyle),
+		m.renderField("Duration", p.DurationString()),
+		m.renderField("Started", m.timeFormat.Full(p.StartTime())),
+	)
+
+	// Acknowledged
+	if p.IsAcknowledged() {
+		lines = append(lines, m.renderFieldStyled("Status", "Acknowledged", m.styles.AlertAcked))
+	} else {
+		lines = append(lines, m.renderFieldStyled("Status", "Unacknowledged", m.styles.AlertSeverity[4]))
+	}
+	if assignee := p.Assignee(); assignee != "" {
+		lines = append(lines, m.renderField("Assigned", assignee))
+	}
 
-		// Acknowledged
-		if p.IsAcknowledged() {
-			lines = append(lines, m.renderFieldStyled("Status", "Acknowledged", m.styles.AlertAcked))
-		} else {
-			lines = append(lines, m.renderFieldStyled("Status", "Unacknowledged", m.styles.AlertSeverity[4]))
+	// Suppressed, and until when
+	if until, ok := p.SuppressedUntil(); ok {
+		value := "Indefinitely"
+		if !until.IsZero() {
+			value = "Until " + m.timeFormat.Full(until)
+			if m.timeFormat.Relative {
+				value = "Ends " + m.timeFormat.Full(until)
+			}
 		}
+		lines = append(lines, m.renderField("Suppressed", value))
+	}
 
-		// Suppressed
-		if p.IsSuppressed() {
-			lines = append(lines, m.renderField("Suppressed", "Yes"))
-		}
+	// Manual close
+	if p.AllowsManualClose() {
+		lines = append(lines, m.renderField("Closable", "Yes"))
+	}
+
+	// Event ID
+	lines = append(lines, m.renderField("Event ID", p.EventID))
+
+	// Problems that are likely the cause or consequences of this one
+	lines = append(lines, m.impactLines()...)
 
-		// Event ID
-		lines = append(lines, m.renderField("Event ID", p.EventID))
-
-		// Tags
-		if len(p.Tags) > 0 {
-			lines = append(lines, "", m.styles.DetailLabel.Render("Tags:"))
-			for _, tag := range p.Tags {
-				tagStr := tag.Tag
-				if tag.Value != "" {
-					tagStr += "=" + tag.Value
-				}
-				lines = append(lines, "  "+m.styles.DetailTag.Render(tagStr))
+	// Tags
+	if len(p.Tags) > 0 {
+		lines = append(lines, "", m.styles.DetailLabel.Render("Tags:"))
+		for _, tag := range p.Tags {
+			tagStr := tag.Tag
+			if tag.Value != "" {
+				tagStr += "=" + tag.Value
 			}
+			lines = append(lines, "  "+m.styles.DetailTag.Render(tagStr))
 		}
+	}
 
-		// Acknowledgments
-		if len(p.Acknowledges) > 0 {
-			lines = append(lines, "", m.styles.DetailLabel.Render("History:"))
-			for _, ack := range p.Acknowledges {
-				user := ack.Username
-				if user == "" {
-					user = "system"
-				}
-				msg := fmt.Sprintf("  %s: %s", user, ack.Message)
-				if len(msg) > m.width-6 {
-					msg = msg[:m.width-9] + "..."
-				}
-				lines = append(lines, m.styles.Subtle.Render(msg))
+	// Acknowledgments
+	if len(p.Acknowledges) > 0 {
+		lines = append(lines, "", m.styles.DetailLabel.Render("History:"))
+		for _, ack := range p.Acknowledges {
+			user := ack.Username
+			if user == "" {
+				user = "system"
+			}
+			msg := fmt.Sprintf("  %s: %s", user, ack.Message)
+			if len(msg) > m.width-6 {
+				msg = msg[:m.width-9] + "..."
 			}
+			lines = append(lines, m.styles.Subtle.Render(msg))
 		}
+	}
 
-		// Actions hint
-		lines = append(lines,
-			"",
-			strings.Repeat("─", max(0, m.width-4)),
-			m.styles.Subtle.Render("[a]ck [A]ck+msg [t]riggers [m]acros [r]efresh"),
-		)
-
-		b.WriteString(m.renderLines(lines))
+	// Actions hint
+	hint := "[a]ck [A]ck+msg [t]riggers [$]macros [r]efresh"
+	if p.AllowsManualClose() {
+		hint = "[a]ck [A]ck+msg [x]ack+close [t]riggers [$]macros [r]efresh"
 	}
+	lines = append(lines,
+		"",
+		strings.Repeat("─", max(0, m.width-4)),
+		m.styles.Subtle.Render(hint),
+	)
 
-	return m.renderPane(b.String())
+	return lines
 }
 
-// viewEvent renders the event detail view.
-func (m Model) viewEvent() string {
-	var b strings.Builder
-
-	// Header
-	b.WriteString(m.styles.PaneTitle.Render("EVENT DETAIL"))
-	b.WriteString("\n")
-	b.WriteString(strings.Repeat("─", max(0, m.width-4)))
-	b.WriteString("\n")
-
+// eventLines returns the body lines for the event detail view.
+func (m Model) eventLines() []string {
 	if m.event == nil {
-		b.WriteString("\n")
-		b.WriteString(m.styles.Subtle.Render("  Select an event to view details"))
-		b.WriteString("\n")
-	} else {
-		e := m.event
-
-		// Build detail lines
-		lines := []string{}
+		return []string{"", m.styles.Subtle.Render("  Select an event to view details")}
+	}
 
-		// Event type (Problem or Recovery)
-		if e.IsRecovery() {
-			lines = append(lines, m.renderFieldStyled("Type", "Recovery (OK)", m.styles.StatusOK))
-		} else {
-			lines = append(lines, m.renderFieldStyled("Type", "Problem", m.styles.StatusProblem))
-		}
+	e := m.event
+	lines := []string{}
 
-		// Host
-		lines = append(lines, m.renderField("Host", e.HostName()))
+	// Event type (Problem or Recovery)
+	if e.IsRecovery() {
+		lines = append(lines, m.renderFieldStyled("Type", "Recovery (OK)", m.styles.StatusOK))
+	} else {
+		lines = append(lines, m.renderFieldStyled("Type", "Problem", m.styles.StatusProblem))
+	}
 
-		// IP Address
-		if ip := e.HostIP(); ip != "" {
-			lines = append(lines, m.renderField("IP", ip))
-		}
+	// Host
+	lines = append(lines, m.renderField("Host", e.HostName()))
 
-		// Trigger/Event name
-		lines = append(lines, m.renderField("Trigger", e.Name))
+	// IP Address
+	if ip := e.HostIP(); ip != "" {
+		lines = append(lines, m.renderField("IP", ip))
+	}
 
-		// Severity
-		sevName := theme.SeverityName(e.SeverityInt())
-		sevStyle := m.styles.AlertSeverity[e.SeverityInt()]
-		// Time
+	// Trigger/Event name
+	lines = append(lines, m.renderField("Trigger", e.Name))
+
+	// Severity
+	sevName := theme.SeverityName(e.SeverityInt())
+	sevStyle := m.styles.AlertSeverity[e.SeverityInt()]
+	// Time
+	lines = append(lines,
+		m.renderFieldStyled("Severity", sevName, sevStyle),
+		m.renderField("Time", m.timeFormat.Full(e.StartTime())),
+	)
+
+	// Duration / Resolved info. Recovery events themselves have neither.
+	switch {
+	case e.Value == zabbix.EventValueOK:
+	case e.IsRecovery():
 		lines = append(lines,
-			m.renderFieldStyled("Severity", sevName, sevStyle),
-			m.renderField("Time", e.StartTime().Format("2006-01-02 15:04:05")),
+			m.renderField("Resolved", m.timeFormat.Full(e.RecoveryTime())),
+			m.renderField("Duration", e.ResolvedDurationString()),
 		)
+	default:
+		lines = append(lines, m.renderField("Duration", e.DurationString()))
+	}
 
-		// Duration / Resolved info
-		if e.IsRecovery() {
-			lines = append(lines,
-				m.renderField("Resolved", e.RecoveryTime().Format("2006-01-02 15:04:05")),
-				m.renderField("Duration", e.ResolvedDurationString()),
-			)
-		} else {
-			lines = append(lines, m.renderField("Duration", e.DurationString()))
-		}
-
-		// Acknowledged
-		if e.IsAcknowledged() {
-			lines = append(lines, m.renderFieldStyled("Ack", "Yes", m.styles.AlertAcked))
-		} else {
-			lines = append(lines, m.renderFieldStyled("Ack", "No", m.styles.Subtle))
-		}
+	// Acknowledged
+	if e.IsAcknowledged() {
+		lines = append(lines, m.renderFieldStyled("Ack", "Yes", m.styles.AlertAcked))
+	} else {
+		lines = append(lines, m.renderFieldStyled("Ack", "No", m.styles.Subtle))
+	}
 
-		// Event ID
-		lines = append(lines, m.renderField("Event ID", e.EventID))
+	// Event ID
+	lines = append(lines, m.renderField("Event ID", e.EventID))
 
-		// Recovery Event ID (if resolved)
-		if e.REventID != "" && e.REventID != "0" {
-			lines = append(lines, m.renderField("Recovery ID", e.REventID))
-		}
+	// Recovery Event ID (if resolved)
+	if e.REventID != "" && e.REventID != "0" {
+		lines = append(lines, m.renderField("Recovery ID", e.REventID))
+	}
 
-		// Tags
-		if len(e.Tags) > 0 {
-			lines = append(lines, "", m.styles.DetailLabel.Render("Tags:"))
-			for _, tag := range e.Tags {
-				tagStr := tag.Tag
-				if tag.Value != "" {
-					tagStr += "=" + tag.Value
-				}
-				lines = append(lines, "  "+m.styles.DetailTag.Render(tagStr))
+	// Tags
+	if len(e.Tags) > 0 {
+		lines = append(lines, "", m.styles.DetailLabel.Render("Tags:"))
+		for _, tag := range e.Tags {
+			tagStr := tag.Tag
+			if tag.Value != "" {
+				tagStr += "=" + tag.Value
 			}
+			lines = append(lines, "  "+m.styles.DetailTag.Render(tagStr))
 		}
+	}
 
-		// Acknowledgments
-		if len(e.Acknowledges) > 0 {
-			lines = append(lines, "", m.styles.DetailLabel.Render("History:"))
-			for _, ack := range e.Acknowledges {
-				user := ack.Username
-				if user == "" {
-					user = "system"
-				}
-				msg := fmt.Sprintf("  %s: %s", user, ack.Message)
-				if len(msg) > m.width-6 {
-					msg = msg[:m.width-9] + "..."
-				}
-				lines = append(lines, m.styles.Subtle.Render(msg))
+	// Acknowledgments
+	if len(e.Acknowledges) > 0 {
+		lines = append(lines, "", m.styles.DetailLabel.Render("History:"))
+		for _, ack := range e.Acknowledges {
+			user := ack.Username
+			if user == "" {
+				user = "system"
+			}
+			msg := fmt.Sprintf("  %s: %s", user, ack.Message)
+			if len(msg) > m.width-6 {
+				msg = msg[:m.width-9] + "..."
 			}
+			lines = append(lines, m.styles.Subtle.Render(msg))
 		}
-
-		// Actions hint
-		lines = append(lines,
-			"",
-			strings.Repeat("─", max(0, m.width-4)),
-			m.styles.Subtle.Render("[t]riggers [m]acros [r]efresh"),
-		)
-
-		b.WriteString(m.renderLines(lines))
 	}
 
-	return m.renderPane(b.String())
-}
+	// Actions hint
+	lines = append(lines,
+		"",
+		strings.Repeat("─", max(0, m.width-4)),
+		m.styles.Subtle.Render("[t]riggers [$]macros [r]efresh"),
+	)
 
-// viewHost renders the host detail view.
-func (m Model) viewHost() string {
-	var b strings.Builder
-
-	// Header
-	b.WriteString(m.styles.PaneTitle.Render("HOST DETAIL"))
-	b.WriteString("\n")
-	b.WriteString(strings.Repeat("─", max(0, m.width-4)))
-	b.WriteString("\n")
+	return lines
+}
 
+// hostLines returns the body lines for the host detail view.
+func (m Model) hostLines() []string {
 	if m.host == nil {
-		b.WriteString("\n")
-		b.WriteString(m.styles.Subtle.Render("  Select a host to view details"))
-		b.WriteString("\n")
-	} else {
-		h := m.host
+		return []string{"", m.styles.Subtle.Render("  Select a host to view details")}
+	}
 
-		// Build detail lines
-		lines := []string{}
+	h := m.host
+	lines := []string{}
 
-		// Host name
-		lines = append(lines, m.renderField("Name", h.DisplayName()))
+	// Host name
+	lines = append(lines, m.renderField("Name", h.DisplayName()))
 
-		// Technical name (if different)
-		if h.Name != "" && h.Name != h.Host {
-			lines = append(lines, m.renderField("Host", h.Host))
-		}
+	// Technical name (if different)
+	if h.Name != "" && h.Name != h.Host {
+		lines = append(lines, m.renderField("Host", h.Host))
+	}
 
-		// Host ID
-		lines = append(lines, m.renderField("Host ID", h.HostID))
+	// Host ID
+	lines = append(lines, m.renderField("Host ID", h.HostID))
 
-		// Status
-		if h.InMaintenance() {
-			lines = append(lines, m.renderFieldStyled("Status", "In Maintenance", m.styles.StatusMaint))
-		} else {
-			switch h.IsAvailable() {
-			case 1:
-				lines = append(lines, m.renderFieldStyled("Status", "Available", m.styles.StatusOK))
-			case 2:
-				lines = append(lines, m.renderFieldStyled("Status", "Unavailable", m.styles.StatusProblem))
-			default:
-				lines = append(lines, m.renderFieldStyled("Status", "Unknown", m.styles.StatusUnknown))
-			}
+	// Status
+	if h.InMaintenance() {
+		lines = append(lines, m.renderFieldStyled("Status", "In Maintenance", m.styles.StatusMaint))
+	} else {
+		switch h.IsAvailable() {
+		case 1:
+			lines = append(lines, m.renderFieldStyled("Status", "Available", m.styles.StatusOK))
+		case 2:
+			lines = append(lines, m.renderFieldStyled("Status", "Unavailable", m.styles.StatusProblem))
+		default:
+			lines = append(lines, m.renderFieldStyled("Status", "Unknown", m.styles.StatusUnknown))
 		}
+	}
 
-		// Monitoring status
-		if h.IsMonitored() {
-			lines = append(lines, m.renderFieldStyled("Monitoring", "Enabled", m.styles.StatusOK))
-		} else {
-			lines = append(lines, m.renderFieldStyled("Monitoring", "Disabled", m.styles.StatusUnknown))
-		}
+	// Maintenance window
+	if mt, ok := m.maintenances[h.MaintenanceID]; ok && h.InMaintenance() {
+		lines = append(lines, m.maintenanceLines(&mt)...)
+	}
 
-		// Interfaces
-		if len(h.Interfaces) > 0 {
-			lines = append(lines, "", m.styles.DetailLabel.Render("Interfaces:"))
-			for _, iface := range h.Interfaces {
-				ifaceType := m.interfaceTypeName(iface.Type)
-				addr := iface.IP
-				if addr == "" {
-					addr = iface.DNS
-				}
-				if iface.Port != "" && iface.Port != "0" {
-					addr += ":" + iface.Port
-				}
-
-				mainStr := ""
-				if iface.Main == "1" {
-					mainStr = " (default)"
-				}
-
-				var availStr string
-				switch iface.Available {
-				case "1":
-					availStr = m.styles.StatusOK.Render(" [OK]")
-				case "2":
-					availStr = m.styles.StatusProblem.Render(" [FAIL]")
-				default:
-					availStr = m.styles.StatusUnknown.Render(" [?]")
-				}
-
-				line := fmt.Sprintf("  %s: %s%s%s", ifaceType, addr, mainStr, availStr)
-				lines = append(lines, line)
-			}
-		}
+	// Monitoring status
+	if h.IsMonitored() {
+		lines = append(lines, m.renderFieldStyled("Monitoring", "Enabled", m.styles.StatusOK))
+	} else {
+		lines = append(lines, m.renderFieldStyled("Monitoring", "Disabled", m.styles.StatusUnknown))
+	}
 
-		// Host groups
-		if len(h.Groups) > 0 {
-			lines = append(lines, "", m.styles.DetailLabel.Render("Groups:"))
-			for _, group := range h.Groups {
-				lines = append(lines, "  "+m.styles.DetailTag.Render(group.Name))
+	// Interfaces
+	if len(h.Interfaces) > 0 {
+		lines = append(lines, "", m.styles.DetailLabel.Render("Interfaces:"))
+		for _, iface := range h.Interfaces {
+			lines = append(lines, m.interfaceLine(iface))
+			if iface.IsSNMP() && iface.Details != nil {
+				lines = append(lines, m.styles.Subtle.Render("    "+snmpSummary(iface.Details)))
 			}
 		}
+	}
 
-		// Actions hint
-		lines = append(lines,
-			"",
-			strings.Repeat("─", max(0, m.width-4)),
-			m.styles.Subtle.Render("[t]riggers [m]acros [e]nable/disable [r]efresh"),
-		)
+	// Host groups
+	if len(h.Groups) > 0 {
+		lines = append(lines, "", m.styles.DetailLabel.Render("Groups:"))
+		for _, group := range h.Groups {
+			lines = append(lines, "  "+m.styles.DetailTag.Render(group.Name))
+		}
+	}
 
-		b.WriteString(m.renderLines(lines))
+	// Actions hint
+	hint := "[t]riggers [$]macros i[n]terfaces [e]nable/disable [M]aintenance [r]efresh"
+	if h.IsAvailable() == 2 {
+		hint = "[D]iagnose [F]orce check [t]riggers [$]macros [M]aintenance [r]efresh"
 	}
+	lines = append(lines,
+		"",
+		strings.Repeat("─", max(0, m.width-4)),
+		m.styles.Subtle.Render(hint),
+	)
 
-	return m.renderPane(b.String())
+	return lines
 }
 
-// renderLines applies scrolling and renders lines.
-func (m Model) renderLines(lines []string) string {
-	var b strings.Builder
+// maintenanceLines returns the lines describing the maintenance a host is
+// in: its name, when it ends and whether data is collected.
+func (m Model) maintenanceLines(mt *zabbix.Maintenance) []string {
+	lines := []string{m.renderFieldStyled("Maintenance", mt.Name, m.styles.StatusMaint)}
+	now := time.Now()
+	if end := mt.End(now); !end.IsZero() {
+		ends := "in " + format.Duration(end.Sub(now)) + " (" + m.timeFormat.Full(end) + ")"
+		if m.timeFormat.Relative {
+			ends = format.Relative(end, now)
+		}
+		lines = append(lines, m.renderField("Ends", ends))
+	}
+	if mt.CollectsData() {
+		lines = append(lines, m.renderField("Data", "Collected"))
+	} else {
+		lines = append(lines, m.renderFieldStyled("Data", "Not collected", m.styles.StatusUnknown))
+	}
+	return lines
+}
 
-	visible := m.height - 4
-	if visible < 1 {
-		visible = 1
+// interfaceLine renders an interface: its type, address and availability.
+func (m Model) interfaceLine(iface zabbix.Interface) string {
+	addr := iface.Endpoint()
+	if iface.UsesDNS() && iface.IP != "" {
+		addr += " (IP " + iface.IP + ")"
 	}
 
-	start := m.scroll
-	if start >= len(lines) {
-		start = max(0, len(lines)-1)
+	mainStr := ""
+	if iface.Main == "1" {
+		mainStr = " (default)"
 	}
-	end := min(start+visible, len(lines))
 
-	for i := start; i < end; i++ {
-		b.WriteString(lines[i])
-		if i < end-1 {
-			b.WriteString("\n")
-		}
+	var availStr string
+	switch iface.Available {
+	case "1":
+		availStr = m.styles.StatusOK.Render(" [OK]")
+	case "2":
+		availStr = m.styles.StatusProblem.Render(" [FAIL]")
+	default:
+		availStr = m.styles.StatusUnknown.Render(" [?]")
 	}
 
-	return b.String()
+	return fmt.Sprintf("  %s: %s%s%s", m.interfaceTypeName(iface.Type), addr, mainStr, availStr)
+}
+
+// snmpSummary describes the SNMP settings of an interface, with the
+// community masked, e.g. "SNMPv2c, community ******, bulk, max repetitions
+// 10".
+func snmpSummary(d *zabbix.InterfaceDetails) string {
+	parts := []string{d.VersionName()}
+	if d.Version == zabbix.SNMPv3 {
+		security := "security name " + d.SecurityName + " (" + d.SecurityLevelName()
+		if protocols := d.Protocols(); protocols != "" {
+			security += ", " + protocols
+		}
+		parts = append(parts, security+")")
+		if d.ContextName != "" {
+			parts = append(parts, "context "+d.ContextName)
+		}
+	} else {
+		parts = append(parts, "community "+zabbix.MaskCommunity(d.Community))
+	}
+	if d.UsesBulk() {
+		parts = append(parts, "bulk")
+	} else {
+		parts = append(parts, "no bulk")
+	}
+	if d.MaxRepetitions != "" {
+		parts = append(parts, "max repetitions "+d.MaxRepetitions)
+	}
+	return strings.Join(parts, ", ")
 }
 
 // renderPane applies the pane style.
@@ -533,112 +837,188 @@ func (m Model) interfaceTypeName(t string) string {
 	}
 }
 
-// viewGraph renders the item/graph detail view.
-func (m Model) viewGraph() string {
-	var b strings.Builder
+// graphLines returns the body lines for the item/graph detail view.
+func (m Model) graphLines() []string {
+	if m.item == nil {
+		return []string{"", m.styles.Subtle.Render("  Select an item to view graph")}
+	}
 
-	// Header
-	b.WriteString(m.styles.PaneTitle.Render("GRAPH DETAIL"))
-	b.WriteString("\n")
-	b.WriteString(strings.Repeat("─", max(0, m.width-4)))
-	b.WriteString("\n")
+	item := m.item
+	lines := []string{}
 
-	if m.item == nil {
-		b.WriteString("\n")
-		b.WriteString(m.styles.Subtle.Render("  Select an item to view graph"))
-		b.WriteString("\n")
-	} else {
-		item := m.item
+	// Item name, Host, and Key
+	lines = append(lines,
+		m.renderField("Item", item.Name),
+		m.renderField("Host", item.HostName()),
+		m.renderField("Key", item.Key),
+	)
 
-		// Build detail lines
-		lines := []string{}
+	// Current value, as mapped by the item's value map if any
+	value := item.MappedValue(item.LastValue)
+	if value == "" {
+		value = format.Value(item.LastValueFloat(), item.Units)
+	}
+	lines = append(lines, m.renderField("Value", value))
+	lines = append(lines, m.itemStatusLines(item)...)
 
-		// Item name, Host, and Key
-		lines = append(lines,
-			m.renderField("Item", item.Name),
-			m.renderField("Host", item.HostName()),
-			m.renderField("Key", item.Key),
-		)
+	// Last update
+	if !item.LastTime().IsZero() {
+		lines = append(lines, m.renderField("Updated", m.timeFormat.Clock(item.LastTime())))
+	}
+
+	// Units
+	if item.Units != "" {
+		lines = append(lines, m.renderField("Units", item.Units))
+	}
 
-		// Current value
-		value := format.Value(item.LastValueFloat(), item.Units)
-		lines = append(lines, m.renderField("Value", value))
+	// Item ID
+	lines = append(lines, m.renderField("Item ID", item.ItemID))
 
-		// Last update
-		if !item.LastTime().IsZero() {
-			lines = append(lines, m.renderField("Updated", item.LastTime().Format("15:04:05")))
+	// Chart section
+	if len(m.history) > 0 {
+		lines = append(lines, "", m.styles.DetailLabel.Render("History Chart:"), "")
+
+		// Create time series chart
+		chartWidth := m.width - 8
+		chartHeight := m.height - len(lines) - 8
+		if chartHeight < 5 {
+			chartHeight = 5
+		}
+		if chartHeight > 15 {
+			chartHeight = 15
 		}
 
-		// Units
-		if item.Units != "" {
-			lines = append(lines, m.renderField("Units", item.Units))
+		chart := tslc.New(chartWidth, chartHeight,
+			tslc.WithXLabelFormatter(m.timeLabelFormatter()),
+			tslc.WithYLabelFormatter(humanReadableYLabelFormatter(item.Units)),
+			tslc.WithAxesStyles(m.styles.ChartAxis, m.styles.ChartLabel),
+			tslc.WithStyle(m.styles.ChartLine),
+		)
+
+		// Push history data points, and the baselines to draw under them
+		names := m.pushBaselines(&chart)
+		for _, h := range m.history {
+			t := h.Time()
+			if !t.IsZero() {
+				chart.Push(tslc.TimePoint{Time: t, Value: h.ValueFloat()})
+			}
 		}
 
-		// Item ID
-		lines = append(lines, m.renderField("Item ID", item.ItemID))
+		// Draw the chart using braille characters for better resolution
+		chart.DrawBrailleDataSets(append(names, tslc.DefaultDataSetName))
+
+		// Add chart lines
+		chartLines := strings.Split(chart.View(), "\n")
+		lines = append(lines, chartLines...)
 
-		// Chart section
+		// Add time range info
 		if len(m.history) > 0 {
-			lines = append(lines, "", m.styles.DetailLabel.Render("History Chart:"), "")
+			first := m.history[0].Time()
+			last := m.history[len(m.history)-1].Time()
+			timeRange := fmt.Sprintf("%s - %s", m.timeFormat.ClockShort(first), m.timeFormat.ClockShort(last))
+			lines = append(lines, m.styles.Subtle.Render(timeRange))
+		}
+	} else {
+		lines = append(lines, "", m.styles.Subtle.Render("  No history data available"))
+	}
 
-			// Create time series chart
-			chartWidth := m.width - 8
-			chartHeight := m.height - len(lines) - 8
-			if chartHeight < 5 {
-				chartHeight = 5
-			}
-			if chartHeight > 15 {
-				chartHeight = 15
-			}
+	// Stats section
+	if len(m.history) > 0 {
+		lines = append(lines, "", strings.Repeat("─", max(0, m.width-4)))
+
+		// Calculate stats
+		minVal, maxVal, avgVal := calcStats(m.history)
+		statsLine := fmt.Sprintf("Min: %s  Max: %s  Avg: %s",
+			format.Value(minVal, item.Units),
+			format.Value(maxVal, item.Units),
+			format.Value(avgVal, item.Units))
+		lines = append(lines, m.styles.Subtle.Render(statsLine))
+		lines = append(lines, m.baselineLines(avgVal)...)
+	}
 
-			chart := tslc.New(chartWidth, chartHeight,
-				tslc.WithXLabelFormatter(tslc.HourTimeLabelFormatter()),
-				tslc.WithYLabelFormatter(humanReadableYLabelFormatter(item.Units)),
-			)
-
-			// Push history data points
-			for _, h := range m.history {
-				t := h.Time()
-				if !t.IsZero() {
-					chart.Push(tslc.TimePoint{Time: t, Value: h.ValueFloat()})
-				}
-			}
+	lines = append(lines, m.formulaLines(item)...)
+	lines = append(lines, m.preprocessingLines(item.Preprocessing)...)
 
-			// Draw the chart using braille characters for better resolution
-			chart.DrawBraille()
+	// Actions hint
+	lines = append(lines,
+		"",
+		strings.Repeat("─", max(0, m.width-4)),
+		m.styles.Subtle.Render("[e]nable/disable [F] check now [b]aseline [r]efresh"),
+	)
 
-			// Add chart lines
-			chartLines := strings.Split(chart.View(), "\n")
-			lines = append(lines, chartLines...)
+	return lines
+}
 
-			// Add time range info
-			if len(m.history) > 0 {
-				first := m.history[0].Time()
-				last := m.history[len(m.history)-1].Time()
-				timeRange := fmt.Sprintf("%s - %s", first.Format("15:04"), last.Format("15:04"))
-				lines = append(lines, m.styles.Subtle.Render(timeRange))
-			}
-		} else {
-			lines = append(lines, "", m.styles.Subtle.Render("  No history data available"))
+// itemStatusLines returns the status lines of a disabled or unsupported
+// item, with the error that makes it unsupported. Returns nil for others.
+func (m Model) itemStatusLines(item *zabbix.Item) []string {
+	switch {
+	case item.IsDisabled():
+		return []string{m.renderFieldStyled("Status", "Disabled", m.styles.StatusUnknown)}
+	case item.IsUnsupported():
+		lines := []string{m.renderFieldStyled("Status", "UNSUPPORTED", m.styles.StatusProblem)}
+		if item.Error != "" {
+			lines = append(lines, m.renderFieldStyled("Error", item.Error, m.styles.StatusProblem))
 		}
+		return lines
+	}
+	return nil
+}
 
-		// Stats section
-		if len(m.history) > 0 {
-			lines = append(lines, "", strings.Repeat("─", max(0, m.width-4)))
-
-			// Calculate stats
-			minVal, maxVal, avgVal := calcStats(m.history)
-			statsLine := fmt.Sprintf("Min: %s  Max: %s  Avg: %s",
-				format.Value(minVal, item.Units),
-				format.Value(maxVal, item.Units),
-				format.Value(avgVal, item.Units))
-			lines = append(lines, m.styles.Subtle.Render(statsLine))
+// formulaLines returns the section showing the formula of a calculated or
+// aggregate item and the items it references, the selected one highlighted
+// while the pane is focused. Returns nil for other items.
+func (m Model) formulaLines(item *zabbix.Item) []string {
+	formula := item.Formula()
+	if formula == "" {
+		return nil
+	}
+	lines := []string{"", m.styles.DetailLabel.Render("Formula:")}
+	for _, line := range strings.Split(strings.TrimRight(formula, "\n"), "\n") {
+		lines = append(lines, "  "+line)
+	}
+	refs := item.References()
+	if len(refs) == 0 {
+		return lines
+	}
+	lines = append(lines, "", m.styles.DetailLabel.Render("References:"))
+	for i, ref := range refs {
+		line := "  → " + ref.String()
+		if m.focused && i == min(m.refCursor, len(refs)-1) {
+			line = m.styles.AlertSelected.Render(line)
 		}
-
-		b.WriteString(m.renderLines(lines))
+		lines = append(lines, line)
 	}
+	return append(lines, m.styles.Subtle.Render("  [←/→] select [Enter] go to item"))
+}
 
-	return m.renderPane(b.String())
+// maxStepParamLines is how many lines of a preprocessing step's parameters
+// are shown, so a long JavaScript step doesn't push the rest off the pane.
+const maxStepParamLines = 3
+
+// preprocessingLines returns the section listing an item's preprocessing
+// steps with their parameters and what happens when they fail. Returns nil
+// for items without preprocessing.
+func (m Model) preprocessingLines(steps []zabbix.PreprocessingStep) []string {
+	if len(steps) == 0 {
+		return nil
+	}
+	lines := []string{"", m.styles.DetailLabel.Render("Preprocessing:")}
+	for i, step := range steps {
+		lines = append(lines, fmt.Sprintf("  %d. %s", i+1, step.TypeName()))
+		params := step.Parameters()
+		for j, param := range params {
+			if j == maxStepParamLines {
+				lines = append(lines, m.styles.Subtle.Render(fmt.Sprintf("     ... %d more", len(params)-j)))
+				break
+			}
+			lines = append(lines, m.styles.Subtle.Render("     "+param))
+		}
+		if onFail := step.OnFail(); onFail != "" {
+			lines = append(lines, m.styles.Subtle.Render("     On fail: "+onFail))
+		}
+	}
+	return lines
 }
 
 // calcStats calculates minVal, maxVal, avgVal for history data.
@@ -666,6 +1046,15 @@ func calcStats(history []zabbix.History) (minVal, maxVal, avgVal float64) {
 	return minVal, maxVal, avgVal
 }
 
+// timeLabelFormatter returns a LabelFormatter for the X axis that shows
+// times of day in the configured timezone and clock style.
+func (m Model) timeLabelFormatter() linechart.LabelFormatter {
+	f := m.timeFormat
+	return func(_ int, v float64) string {
+		return f.ClockShort(time.Unix(int64(v), 0))
+	}
+}
+
 // humanReadableYLabelFormatter returns a LabelFormatter that formats Y axis
 // values in human-readable form (e.g., 16G instead of 16000000000).
 func humanReadableYLabelFormatter(units string) linechart.LabelFormatter {
@@ -0,0 +1,404 @@
+package detail
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// testStyles returns a theme.Styles instance for testing.
+func testStyles() *theme.Styles {
+	return theme.NewStyles(theme.DefaultTheme())
+}
+
+// testHost returns a host with enough groups to overflow a small pane.
+func testHost(id string, groups int) *zabbix.Host {
+	h := &zabbix.Host{HostID: id, Host: "server" + id, Name: "Server " + id}
+	for i := range groups {
+		h.Groups = append(h.Groups, zabbix.HostGroup{GroupID: string(rune('a' + i)), Name: "group"})
+	}
+	return h
+}
+
+func TestScrollBoundedByContent(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(60, 10)
+	m.SetHost(testHost("1", 20))
+
+	m.Scroll(1000)
+	_, lines := m.content()
+	maxOffset := len(lines) - m.viewport.Height
+	if m.ScrollOffset() != maxOffset {
+		t.Errorf("Expected offset clamped to %d, got %d", maxOffset, m.ScrollOffset())
+	}
+
+	m.Scroll(-1000)
+	if m.ScrollOffset() != 0 {
+		t.Errorf("Expected offset 0 after scrolling up, got %d", m.ScrollOffset())
+	}
+}
+
+func TestScrollShortContent(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(60, 40)
+	m.SetHost(testHost("1", 0))
+
+	m.Scroll(5)
+	if m.ScrollOffset() != 0 {
+		t.Errorf("Expected no scrolling when content fits, got offset %d", m.ScrollOffset())
+	}
+}
+
+func TestScrollHorizontal(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(40, 20)
+	m.SetProblem(&zabbix.Problem{EventID: "1", Name: "Disk space is low on /var/lib/postgresql (used > 90%)"})
+	if strings.Contains(m.View(), "90%") {
+		t.Fatal("the end of the trigger name should not fit the pane")
+	}
+
+	m.ScrollHorizontal(1000)
+	if !strings.Contains(m.View(), "90%") {
+		t.Error("scrolling right should show the end of the trigger name")
+	}
+
+	m.ScrollHorizontal(-1000)
+	if !strings.Contains(m.View(), "Trigger:") {
+		t.Error("scrolling left should show the start of the lines again")
+	}
+
+	m.SetProblem(&zabbix.Problem{EventID: "2", Name: "Agent down"})
+	m.ScrollHorizontal(-5)
+	if !strings.Contains(m.View(), "Trigger:") {
+		t.Error("scrolling left past the start should stay at the start")
+	}
+}
+
+func TestSetHostKeepsScrollForSameHost(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(60, 10)
+	m.SetHost(testHost("1", 20))
+	m.Scroll(3)
+
+	m.SetHost(testHost("1", 20))
+	if m.ScrollOffset() != 3 {
+		t.Errorf("Expected offset preserved on refresh, got %d", m.ScrollOffset())
+	}
+
+	m.SetHost(testHost("2", 20))
+	if m.ScrollOffset() != 0 {
+		t.Errorf("Expected offset reset for a different host, got %d", m.ScrollOffset())
+	}
+}
+
+func TestHostMaintenance(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(80, 30)
+	end := time.Now().Add(90*time.Minute + 30*time.Second)
+	m.SetMaintenances(map[string]zabbix.Maintenance{"5": {
+		MaintenanceID:   "5",
+		Name:            "Reboot",
+		MaintenanceType: zabbix.MaintenanceNoData,
+		ActiveTill:      strconv.FormatInt(end.Unix(), 10),
+	}})
+	m.SetHost(&zabbix.Host{HostID: "1", Host: "web01", MaintenanceStatus: "1", MaintenanceID: "5"})
+
+	view := m.View()
+	for _, want := range []string{"Maintenance: Reboot", "in 1h 30m (", "Not collected"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+
+	m.SetHost(&zabbix.Host{HostID: "2", Host: "web02", MaintenanceID: "5"})
+	if strings.Contains(m.View(), "Reboot") {
+		t.Error("a host out of maintenance should not show one")
+	}
+}
+
+func TestHostDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(100, 40)
+	host := zabbix.Host{HostID: "1", Host: "web01", Interfaces: []zabbix.Interface{{
+		InterfaceID: "7",
+		Type:        "1",
+		IP:          "10.0.0.1",
+		Port:        "10050",
+		Available:   "2",
+		Error:       "Get value from agent failed: No route to host",
+		ErrorsFrom:  strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10),
+	}}}
+	m.SetHost(&host)
+	m.SetDiagnostics(&zabbix.HostDiagnostics{Host: host, Items: []zabbix.Item{
+		{ItemID: "1", Name: "Ping", Type: zabbix.ItemTypeAgent, InterfaceID: "7", LastClock: strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)},
+		{ItemID: "2", Name: "Disk", Type: zabbix.ItemTypeAgent, InterfaceID: "7", State: "1", Error: "Unsupported item key."},
+	}})
+	if m.DiagnosedHost() != "1" {
+		t.Fatalf("DiagnosedHost() = %q, want 1", m.DiagnosedHost())
+	}
+
+	view := m.View()
+	for _, want := range []string{"HOST DIAGNOSTICS", "No route to host", "Failing since", "Polled data: 1h 0m ago", "Disk: Unsupported item key."} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+
+	m.SetHost(&host)
+	if m.DiagnosedHost() != "1" {
+		t.Error("refreshing the host should keep its diagnostics")
+	}
+	m.CloseDiagnostics()
+	if m.DiagnosedHost() != "" || strings.Contains(m.View(), "HOST DIAGNOSTICS") {
+		t.Error("CloseDiagnostics should show the host detail")
+	}
+
+	m.SetDiagnostics(&zabbix.HostDiagnostics{Host: host})
+	m.SetHost(&zabbix.Host{HostID: "2", Host: "web02"})
+	if m.DiagnosedHost() != "" {
+		t.Error("another host should close the diagnostics")
+	}
+}
+
+func TestTextHistory(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(80, 15)
+	item := &zabbix.Item{ItemID: "7", Name: "Syslog", Key: "log[/var/log/syslog]", ValueType: zabbix.ItemValueTypeLog}
+	lines := func(n int) []zabbix.History {
+		history := make([]zabbix.History, n)
+		for i := range history {
+			history[i] = zabbix.History{ItemID: "7", Clock: strconv.Itoa(1_700_000_000 + 60*i), Value: fmt.Sprintf("line %d", i)}
+		}
+		return history
+	}
+
+	m.SetItem(item, lines(30))
+	view := m.View()
+	if !strings.Contains(view, "ITEM HISTORY") || !strings.Contains(view, "line 29") || strings.Contains(view, "line 0 ") {
+		t.Errorf("view should show the tail of the log:\n%s", view)
+	}
+
+	// New lines are followed at the end of the log
+	m.SetItem(item, lines(31))
+	if !strings.Contains(m.View(), "line 30") {
+		t.Error("the view should follow new lines")
+	}
+
+	// but not when scrolled back
+	m.Scroll(-5)
+	offset := m.ScrollOffset()
+	m.SetItem(item, lines(32))
+	if m.ScrollOffset() != offset {
+		t.Errorf("offset = %d, want %d kept while reading back", m.ScrollOffset(), offset)
+	}
+
+	m.SetFollowing(true)
+	m.SetHighlight(regexp.MustCompile("x*"))
+	m.GoToBottom()
+	if view := m.View(); !strings.Contains(view, "ITEM HISTORY (following)") || !strings.Contains(view, "line 31") {
+		t.Errorf("view should show the followed lines, with empty matches ignored:\n%s", view)
+	}
+}
+
+func TestValueMappedItem(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(80, 30)
+	m.SetItem(&zabbix.Item{
+		ItemID:    "8",
+		Name:      "Operational status",
+		ValueType: zabbix.ItemValueTypeUnsigned,
+		LastValue: "2",
+		ValueMap:  &zabbix.ValueMap{Mappings: []zabbix.ValueMapping{{Value: "1", NewValue: "up"}, {Value: "2", NewValue: "down"}}},
+	}, nil)
+	if view := m.View(); !strings.Contains(view, "down (2)") {
+		t.Errorf("view should show the mapped value:\n%s", view)
+	}
+}
+
+func TestUnsupportedItem(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(100, 30)
+	m.SetItem(&zabbix.Item{
+		ItemID:    "9",
+		Name:      "Free space on /data",
+		ValueType: zabbix.ItemValueTypeFloat,
+		State:     zabbix.ItemStateNotSupported,
+		Error:     "Cannot obtain filesystem information",
+	}, nil)
+	view := m.View()
+	if !strings.Contains(view, "UNSUPPORTED") || !strings.Contains(view, "Cannot obtain filesystem information") {
+		t.Errorf("view should show the item is unsupported and why:\n%s", view)
+	}
+}
+
+func TestUpdatePageKeys(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(60, 10)
+	m.SetHost(testHost("1", 30))
+
+	// Unfocused pane ignores keys
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if m.ScrollOffset() != 0 {
+		t.Errorf("Expected unfocused pane not to scroll, got offset %d", m.ScrollOffset())
+	}
+
+	m.SetFocused(true)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if m.ScrollOffset() != m.viewport.Height {
+		t.Errorf("Expected offset %d after PgDn, got %d", m.viewport.Height, m.ScrollOffset())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	if m.ScrollOffset() != 0 {
+		t.Errorf("Expected offset 0 after PgUp, got %d", m.ScrollOffset())
+	}
+}
+
+func TestViewScrollIndicator(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(60, 10)
+	m.SetHost(testHost("1", 20))
+
+	view := m.View()
+	if !strings.Contains(view, "1-8/") {
+		t.Errorf("Expected scroll position indicator in view, got:\n%s", view)
+	}
+	if !strings.Contains(view, "┃") {
+		t.Error("Expected scrollbar thumb in view")
+	}
+}
+
+func TestItemPreprocessing(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(100, 60)
+	m.SetItem(&zabbix.Item{
+		ItemID:    "10",
+		Name:      "Queue length",
+		ValueType: zabbix.ItemValueTypeUnsigned,
+		State:     zabbix.ItemStateNotSupported,
+		Preprocessing: []zabbix.PreprocessingStep{
+			{Type: "12", Params: "$.queue.length", ErrorHandler: zabbix.PreprocessingErrorValue, ErrorHandlerParams: "0"},
+			{Type: "21", Params: "var v = JSON.parse(value);\nv = v * 2;\nv = v + 1;\nreturn v;"},
+		},
+	}, nil)
+	view := m.View()
+	for _, want := range []string{"1. JSONPath", "$.queue.length", "On fail: Set value to 0", "2. JavaScript", "... 1 more"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "return v;") {
+		t.Errorf("view should cut long step parameters short:\n%s", view)
+	}
+}
+
+func TestBaselines(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().Truncate(time.Minute)
+	series := func(offset time.Duration, value int) []zabbix.History {
+		var h []zabbix.History
+		for i := range 30 {
+			clock := now.Add(-offset - time.Duration(30-i)*time.Minute).Unix()
+			h = append(h, zabbix.History{ItemID: "7", Clock: strconv.FormatInt(clock, 10), Value: strconv.Itoa(value)})
+		}
+		return h
+	}
+
+	m := New(testStyles())
+	m.SetSize(100, 50)
+	item := &zabbix.Item{ItemID: "7", Name: "CPU load", ValueType: zabbix.ItemValueTypeFloat}
+	m.SetItem(item, series(0, 150))
+	m.SetBaselines("7", []Baseline{
+		{Offset: 24 * time.Hour, History: series(24*time.Hour, 100)},
+		{Offset: 7 * 24 * time.Hour},
+	})
+
+	view := m.View()
+	for _, want := range []string{"━ now", "━ 1d ago", "━ 7d ago", "1d ago: Avg: 100 (now +50%)", "7d ago: no history"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	m.SetItem(&zabbix.Item{ItemID: "8", Name: "Memory", ValueType: zabbix.ItemValueTypeFloat}, series(0, 1))
+	if strings.Contains(m.View(), "1d ago") {
+		t.Error("baselines of another item should not be drawn")
+	}
+}
+
+func TestComparison(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().Truncate(time.Minute)
+	series := func(itemID, host string, value int) Series {
+		s := Series{Item: zabbix.Item{
+			ItemID: itemID, Name: "CPU idle", Key: "system.cpu.util[,idle]", ValueType: zabbix.ItemValueTypeFloat,
+			LastValue: strconv.Itoa(value), Hosts: []zabbix.Host{{Host: host}},
+		}}
+		for i := range 30 {
+			clock := now.Add(-time.Duration(30-i) * time.Minute).Unix()
+			s.History = append(s.History, zabbix.History{ItemID: itemID, Clock: strconv.FormatInt(clock, 10), Value: strconv.Itoa(value + i)})
+		}
+		return s
+	}
+
+	m := New(testStyles())
+	m.SetSize(100, 50)
+	item := series("1", "web01", 90).Item
+	m.SetItem(&item, nil)
+	m.SetComparison("1", []Series{series("1", "web01", 90), series("2", "web02", 40), {Item: zabbix.Item{ItemID: "3", Hosts: []zabbix.Host{{Host: "web03"}}}}})
+
+	view := m.View()
+	for _, want := range []string{"COMPARE HOSTS", "web01 90", "web02 40", "web03 -", "no data", "Scale: 40 - 119", "[=] overlay"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("grid view missing %q:\n%s", want, view)
+		}
+	}
+
+	m.SetOverlaid(true)
+	if view := m.View(); !strings.Contains(view, "● web02 40") || !strings.Contains(view, "[=] close") {
+		t.Errorf("overlaid view should have a legend of the hosts:\n%s", view)
+	}
+
+	m.SetItem(&item, nil)
+	if m.ComparedItem() != "1" || !m.Overlaid() {
+		t.Error("refreshing the compared item should keep the comparison")
+	}
+	m.SetItem(&zabbix.Item{ItemID: "2", Name: "CPU idle"}, nil)
+	if m.ComparedItem() != "" || strings.Contains(m.View(), "COMPARE HOSTS") {
+		t.Error("showing another item should close the comparison")
+	}
+}
@@ -0,0 +1,122 @@
+package detail
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// maxNotSupportedLines is how many items that cannot be collected the
+// diagnostics list.
+const maxNotSupportedLines = 5
+
+// SetDiagnostics shows why a host is unavailable: the errors of its
+// interfaces and when its agents last sent data. The diagnostics are kept
+// when the host is refreshed with SetHost, until another host is shown.
+func (m *Model) SetDiagnostics(d *zabbix.HostDiagnostics) {
+	same := m.DiagnosedHost() == d.Host.HostID
+	m.mode = ViewModeDiagnostics
+	m.diagnostics = d
+	m.host = &d.Host
+	m.problem = nil
+	m.event = nil
+	m.item = nil
+	m.history = nil
+	if !same {
+		m.scrollToStart()
+	}
+}
+
+// DiagnosedHost returns the ID of the host whose diagnostics are shown, or
+// empty if none are.
+func (m Model) DiagnosedHost() string {
+	if m.mode != ViewModeDiagnostics || m.diagnostics == nil {
+		return ""
+	}
+	return m.diagnostics.Host.HostID
+}
+
+// CloseDiagnostics shows the detail of the diagnosed host again.
+func (m *Model) CloseDiagnostics() {
+	if m.DiagnosedHost() == "" {
+		return
+	}
+	m.mode = ViewModeHost
+	m.diagnostics = nil
+	m.scrollToStart()
+}
+
+// diagnosticsLines returns the body lines for the diagnostics view.
+func (m Model) diagnosticsLines() []string {
+	d := m.diagnostics
+	h := &d.Host
+	now := time.Now()
+	lines := []string{m.renderField("Host", h.DisplayName())}
+
+	switch h.IsAvailable() {
+	case 1:
+		lines = append(lines, m.renderFieldStyled("Status", "Available", m.styles.StatusOK))
+	case 2:
+		lines = append(lines, m.renderFieldStyled("Status", "Unavailable", m.styles.StatusProblem))
+	default:
+		lines = append(lines, m.renderFieldStyled("Status", "Unknown", m.styles.StatusUnknown))
+	}
+	switch h.ActiveAvailable {
+	case "1":
+		lines = append(lines, m.renderFieldStyled("Active", "Available", m.styles.StatusOK))
+	case "2":
+		lines = append(lines, m.renderFieldStyled("Active", "Unavailable", m.styles.StatusProblem))
+	case "0":
+		lines = append(lines, m.renderFieldStyled("Active", "Unknown", m.styles.StatusUnknown))
+	}
+	lines = append(lines,
+		m.renderField("Polled data", m.lastSeen(d.LastData(zabbix.ItemTypeAgent), now)),
+		m.renderField("Active data", m.lastSeen(d.LastData(zabbix.ItemTypeAgentActive), now)),
+	)
+
+	if len(h.Interfaces) > 0 {
+		lines = append(lines, "", m.styles.DetailLabel.Render("Interfaces:"))
+		for _, iface := range h.Interfaces {
+			lines = append(lines, m.interfaceLine(iface))
+			if iface.Error != "" {
+				lines = append(lines, "    "+m.styles.StatusProblem.Render(iface.Error))
+			}
+			if since := iface.ErrorsSince(); !since.IsZero() && iface.Available == "2" {
+				lines = append(lines, "    Failing since "+m.timeFormat.Full(since)+" ("+format.Duration(now.Sub(since))+")")
+			}
+			if next := iface.NextCheck(); next.After(now) {
+				lines = append(lines, "    Next check "+format.Relative(next, now))
+			}
+			lines = append(lines, "    Last data "+m.lastSeen(d.InterfaceLastData(iface.InterfaceID), now))
+		}
+	}
+
+	if items := d.NotSupported(); len(items) > 0 {
+		lines = append(lines, "", m.styles.DetailLabel.UnsetWidth().Render("Not supported items:"))
+		for i, item := range items {
+			if i == maxNotSupportedLines {
+				lines = append(lines, m.styles.Subtle.Render(fmt.Sprintf("  and %d more", len(items)-i)))
+				break
+			}
+			lines = append(lines, "  "+item.Name+": "+m.styles.Subtle.Render(item.Error))
+		}
+	}
+
+	lines = append(lines,
+		"",
+		strings.Repeat("─", max(0, m.width-4)),
+		m.styles.Subtle.Render("[F]orce availability check [D] host detail [r]efresh"),
+	)
+	return lines
+}
+
+// lastSeen formats when data was last received, or "never".
+func (m Model) lastSeen(t, now time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return format.Relative(t, now)
+}
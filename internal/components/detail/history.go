@@ -0,0 +1,93 @@
+package detail
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SetFollowing marks whether new values of the shown text item are being
+// polled, like tail -f.
+func (m *Model) SetFollowing(following bool) {
+	m.following = following
+}
+
+// SetHighlight highlights the matches of a pattern in the values of text
+// items. Pass nil to remove the highlight.
+func (m *Model) SetHighlight(re *regexp.Regexp) {
+	m.highlight = re
+}
+
+// textHistoryLines returns the body lines for a character, log or text
+// item: its latest values, oldest first, one line of text per line.
+func (m Model) textHistoryLines() []string {
+	item := m.item
+	lines := []string{
+		m.renderField("Item", item.Name),
+		m.renderField("Host", item.HostName()),
+		m.renderField("Key", item.Key),
+	}
+	if !item.LastTime().IsZero() {
+		lines = append(lines, m.renderField("Updated", m.timeFormat.Clock(item.LastTime())))
+	}
+	lines = append(lines, m.itemStatusLines(item)...)
+	lines = append(lines, m.renderField("Item ID", item.ItemID))
+	if m.highlight != nil {
+		lines = append(lines, m.renderField("Highlight", m.highlight.String()))
+	}
+	lines = append(lines, m.formulaLines(item)...)
+	lines = append(lines, m.preprocessingLines(item.Preprocessing)...)
+
+	if len(m.history) == 0 {
+		lines = append(lines, "", m.styles.Subtle.Render("  No history data available"))
+	} else {
+		lines = append(lines, "", m.styles.DetailLabel.UnsetWidth().Render(fmt.Sprintf("Latest values (%d):", len(m.history))))
+		width := m.timeFormat.ShortWidth()
+		indent := strings.Repeat(" ", width+1)
+		for _, h := range m.history {
+			clock := m.styles.Subtle.Render(fmt.Sprintf("%*s", width, m.timeFormat.Clock(h.Time())))
+			value := h.Value
+			if mapped := item.MappedValue(value); mapped != "" {
+				value = mapped
+			}
+			for i, text := range strings.Split(strings.TrimRight(value, "\n"), "\n") {
+				text = m.highlightMatches(strings.TrimRight(text, "\r"))
+				if i == 0 {
+					lines = append(lines, clock+" "+text)
+				} else {
+					lines = append(lines, indent+text)
+				}
+			}
+		}
+	}
+
+	hint := "[f]ollow [e]nable/disable [F] check now [r]efresh"
+	if m.following {
+		hint = "[f] stop following [e]nable/disable [F] check now"
+	}
+	lines = append(lines,
+		"",
+		strings.Repeat("─", max(0, m.width-4)),
+		m.styles.Subtle.Render(hint),
+	)
+	return lines
+}
+
+// highlightMatches styles the matches of the highlight pattern in text.
+func (m Model) highlightMatches(text string) string {
+	if m.highlight == nil {
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for _, match := range m.highlight.FindAllStringIndex(text, -1) {
+		if match[0] == match[1] {
+			continue // Empty matches have nothing to show
+		}
+		b.WriteString(text[last:match[0]])
+		b.WriteString(m.styles.StatusFilter.Render(text[match[0]:match[1]]))
+		last = match[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
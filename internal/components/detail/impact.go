@@ -0,0 +1,57 @@
+package detail
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// maxImpactLines is how many problems each impact list shows.
+const maxImpactLines = 5
+
+// Impact relates a problem to the other active problems, to help find the
+// root alert in a storm.
+type Impact struct {
+	// DependsOn are problems of triggers the problem's trigger depends on:
+	// the likely cause
+	DependsOn []zabbix.Problem
+	// Dependents are problems of triggers that depend on the problem's
+	// trigger: likely consequences
+	Dependents []zabbix.Problem
+	// Related are problems on the same host or hosts sharing a group that
+	// started shortly after the problem
+	Related []zabbix.Problem
+	// Window is how soon after the problem the related ones started
+	Window string
+}
+
+// SetImpact sets the related problems shown for the displayed problem.
+func (m *Model) SetImpact(impact Impact) {
+	m.impact = impact
+}
+
+// impactLines returns the body lines listing the related problems, if any.
+func (m Model) impactLines() []string {
+	var lines []string
+	section := func(title string, problems []zabbix.Problem) {
+		if len(problems) == 0 {
+			return
+		}
+		// The label width fits field names, not these longer titles
+		title = fmt.Sprintf("%s (%d):", title, len(problems))
+		lines = append(lines, "", m.styles.DetailLabel.UnsetWidth().Render(title))
+		for _, p := range problems[:min(len(problems), maxImpactLines)] {
+			line := ansi.Truncate(fmt.Sprintf("  %s: %s", p.HostName(), p.Name), max(10, m.width-6), "...")
+			lines = append(lines, m.styles.AlertSeverity[p.SeverityInt()].Render(line))
+		}
+		if more := len(problems) - maxImpactLines; more > 0 {
+			lines = append(lines, m.styles.Subtle.Render(fmt.Sprintf("  and %d more", more)))
+		}
+	}
+	section("Likely caused by", m.impact.DependsOn)
+	section("Likely consequences", m.impact.Dependents)
+	section("Started within "+m.impact.Window+" on related hosts", m.impact.Related)
+	return lines
+}
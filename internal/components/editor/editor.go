@@ -1,4 +1,5 @@
-// Package editor provides modal editing components for hosts, triggers, and macros.
+// Package editor provides modal editing components for hosts, triggers, macros,
+// interfaces and mute rules.
 package editor
 
 import (
@@ -9,6 +10,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/harpchad/chotko/internal/config"
 	"github.com/harpchad/chotko/internal/theme"
 	"github.com/harpchad/chotko/internal/zabbix"
 )
@@ -22,8 +24,10 @@ const (
 	TypeHost
 	TypeTrigger
 	TypeMacro
-	TypeHostTriggers // List of triggers for a host
-	TypeHostMacros   // List of macros for a host
+	TypeHostTriggers   // List of triggers for a host
+	TypeHostMacros     // List of macros for a host
+	TypeMuteRules      // List of mute rules
+	TypeHostInterfaces // List of interfaces of a host
 )
 
 // Field represents an editable field.
@@ -40,11 +44,19 @@ type TriggerItem struct {
 	Selected bool
 }
 
-// MacroItem represents a macro in the macro list.
+// MacroItem represents a macro in the macro list: its effective definition
+// and all of its definitions in resolution order.
 type MacroItem struct {
-	Macro    zabbix.HostMacro
-	Selected bool
-	Editing  bool
+	Macro       zabbix.HostMacro
+	Definitions []zabbix.MacroDefinition
+	Selected    bool
+	Editing     bool
+}
+
+// Inherited returns true if the value of the macro comes from a template or
+// a global macro rather than the host.
+func (i MacroItem) Inherited() bool {
+	return len(i.Definitions) > 0 && i.Definitions[0].Level != zabbix.MacroLevelHost
 }
 
 // Model represents the editor modal component.
@@ -61,11 +73,17 @@ type Model struct {
 	// Host being edited
 	host *zabbix.Host
 
-	// Trigger list
+	// Trigger list, with the cursor on the triggers passing the filters
 	triggers      []TriggerItem
 	triggerCursor int
 	triggerOffset int
 
+	// Trigger list filters
+	triggerShown       []int // Indexes of the triggers passing the filters
+	triggerSearch      textinput.Model
+	searchingTriggers  bool
+	triggerMinSeverity int
+
 	// Macro list
 	macros      []MacroItem
 	macroCursor int
@@ -75,16 +93,49 @@ type Model struct {
 	editingMacroValue textinput.Model
 	editingMacroIdx   int
 
+	// Mute rule list
+	mutes      []config.MuteRule
+	muteCounts []int // Alerts matching each rule
+	muteCursor int
+	muteOffset int
+	mutePrefix string // Trigger pattern suggested for a new rule
+
+	// Edit mode for mute rules
+	muteForm    []textinput.Model // Trigger, tag and comment
+	muteFocus   int
+	editingMute int // Rule being edited, len(mutes) for a new one, -1 when not editing
+	muteErr     string
+
+	// Interface list
+	ifaces      []zabbix.Interface
+	ifaceCursor int
+	ifaceOffset int
+
+	// Edit mode for interfaces
+	ifaceFields  []string // Labels of the form, which depend on the interface
+	ifaceForm    []textinput.Model
+	ifaceFocus   int
+	editingIface int // Interface being edited, -1 when not editing
+	ifaceErr     string
+
 	// Confirmation state
-	confirmAction string
-	confirmTarget string
+	confirmAction     string
+	confirmTarget     string
+	confirmTriggerIDs []string // Triggers to enable or disable
 }
 
 // New creates a new editor model.
 func New(styles *theme.Styles) Model {
+	search := textinput.New()
+	search.Prompt = "/"
+	search.Placeholder = "search triggers"
+	search.CharLimit = 256
 	return Model{
 		styles:          styles,
 		editingMacroIdx: -1,
+		editingMute:     -1,
+		editingIface:    -1,
+		triggerSearch:   search,
 	}
 }
 
@@ -112,38 +163,34 @@ func (m *Model) SetScreenSize(width, height int) {
 // ShowHostTriggers opens the host triggers editor.
 // If selectTriggerID is non-empty, the cursor will be positioned on that trigger.
 func (m *Model) ShowHostTriggers(host *zabbix.Host, triggers []zabbix.Trigger, selectTriggerID string) {
+	// The filters stay when the triggers of the same host are reloaded
+	if m.host == nil || m.host.HostID != host.HostID {
+		m.triggerSearch.SetValue("")
+		m.triggerMinSeverity = 0
+	}
+
 	m.visible = true
 	m.editorType = TypeHostTriggers
 	m.title = fmt.Sprintf("Triggers: %s", host.DisplayName())
 	m.host = host
-	m.triggerCursor = 0
-	m.triggerOffset = 0
 	m.confirmAction = ""
 
 	// Convert to trigger items
 	m.triggers = make([]TriggerItem, len(triggers))
 	for i, t := range triggers {
 		m.triggers[i] = TriggerItem{Trigger: t}
-		// Position cursor on the selected trigger
-		if selectTriggerID != "" && t.TriggerID == selectTriggerID {
-			m.triggerCursor = i
-		}
 	}
+	m.filterTriggers(selectTriggerID)
 
 	// Adjust offset to ensure selected trigger is visible
-	if m.triggerCursor > 0 {
-		maxVisible := m.height - 12
-		if maxVisible < 3 {
-			maxVisible = 3
-		}
-		if m.triggerCursor >= maxVisible {
-			m.triggerOffset = m.triggerCursor - maxVisible/2
-		}
+	if maxVisible := m.triggerRows(); m.triggerCursor >= maxVisible {
+		m.triggerOffset = m.triggerCursor - maxVisible/2
 	}
 }
 
-// ShowHostMacros opens the host macros editor.
-func (m *Model) ShowHostMacros(host *zabbix.Host, macros []zabbix.HostMacro) {
+// ShowHostMacros opens the host macros editor with the macros of the host
+// and those it inherits.
+func (m *Model) ShowHostMacros(host *zabbix.Host, macros []zabbix.EffectiveMacro) {
 	m.visible = true
 	m.editorType = TypeHostMacros
 	m.title = fmt.Sprintf("Macros: %s", host.DisplayName())
@@ -156,7 +203,7 @@ func (m *Model) ShowHostMacros(host *zabbix.Host, macros []zabbix.HostMacro) {
 	// Convert to macro items
 	m.macros = make([]MacroItem, len(macros))
 	for i, macro := range macros {
-		m.macros[i] = MacroItem{Macro: macro}
+		m.macros[i] = MacroItem{Macro: macro.Effective().HostMacro, Definitions: macro.Definitions}
 	}
 }
 
@@ -166,6 +213,10 @@ func (m *Model) Hide() {
 	m.editorType = TypeNone
 	m.confirmAction = ""
 	m.editingMacroIdx = -1
+	m.editingMute = -1
+	m.editingIface = -1
+	m.searchingTriggers = false
+	m.triggerSearch.Blur()
 }
 
 // Visible returns true if the editor is visible.
@@ -185,10 +236,10 @@ func (m Model) Host() *zabbix.Host {
 
 // SelectedTrigger returns the currently selected trigger.
 func (m Model) SelectedTrigger() *zabbix.Trigger {
-	if m.editorType != TypeHostTriggers || len(m.triggers) == 0 {
+	if m.editorType != TypeHostTriggers || len(m.triggerShown) == 0 {
 		return nil
 	}
-	return &m.triggers[m.triggerCursor].Trigger
+	return &m.triggers[m.triggerShown[m.triggerCursor]].Trigger
 }
 
 // SelectedMacro returns the currently selected macro.
@@ -225,6 +276,21 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		return m.updateMacroEdit(msg)
 	}
 
+	// Handle editing a mute rule
+	if m.editingMute >= 0 {
+		return m.updateMuteEdit(msg)
+	}
+
+	// Handle editing an interface
+	if m.editingIface >= 0 {
+		return m.updateInterfaceEdit(msg)
+	}
+
+	// Handle typing a trigger search
+	if m.searchingTriggers {
+		return m.updateTriggerSearch(msg)
+	}
+
 	// Handle confirmation
 	if m.confirmAction != "" {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
@@ -239,6 +305,10 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m.updateTriggerList(keyMsg)
 		case TypeHostMacros:
 			return m.updateMacroList(keyMsg)
+		case TypeMuteRules:
+			return m.updateMuteList(keyMsg)
+		case TypeHostInterfaces:
+			return m.updateInterfaceList(keyMsg)
 		default:
 			// Unknown editor type, ignore input
 		}
@@ -263,26 +333,33 @@ func (m Model) updateTriggerList(msg tea.KeyMsg) (Model, tea.Cmd) {
 		}
 
 	case "down", "j":
-		if m.triggerCursor < len(m.triggers)-1 {
+		if m.triggerCursor < len(m.triggerShown)-1 {
 			m.triggerCursor++
-			maxVisible := m.height - 10
-			if m.triggerCursor >= m.triggerOffset+maxVisible {
-				m.triggerOffset = m.triggerCursor - maxVisible + 1
-			}
+			m.scrollToTriggerCursor()
 		}
 
 	case " ":
 		// Toggle enable/disable for selected trigger
-		if len(m.triggers) > 0 {
-			t := &m.triggers[m.triggerCursor].Trigger
-			if t.IsEnabled() {
-				m.confirmAction = "disable"
-				m.confirmTarget = t.Description
-			} else {
-				m.confirmAction = "enable"
-				m.confirmTarget = t.Description
-			}
+		if t := m.SelectedTrigger(); t != nil {
+			m.confirmToggle(!t.IsEnabled(), []*zabbix.Trigger{t})
 		}
+
+	case "x":
+		m.markTrigger()
+
+	case "a":
+		m.markAllTriggers()
+
+	case "e", "d":
+		m.confirmToggle(msg.String() == "e", m.markedTriggers())
+
+	case "/":
+		m.searchingTriggers = true
+		return m, m.triggerSearch.Focus()
+
+	case "0", "1", "2", "3", "4", "5":
+		m.triggerMinSeverity = int(msg.String()[0] - '0')
+		m.filterTriggers(m.selectedTriggerID())
 	}
 
 	return m, nil
@@ -327,8 +404,8 @@ func (m Model) updateMacroList(msg tea.KeyMsg) (Model, tea.Cmd) {
 		}
 
 	case "d":
-		// Delete macro
-		if len(m.macros) > 0 {
+		// Delete macro; inherited ones are not the host's to delete
+		if len(m.macros) > 0 && !m.macros[m.macroCursor].Inherited() {
 			m.confirmAction = "delete"
 			m.confirmTarget = m.macros[m.macroCursor].Macro.Macro
 		}
@@ -349,6 +426,10 @@ func (m Model) updateMacroEdit(msg tea.Msg) (Model, tea.Cmd) {
 			if m.editingMacroIdx >= 0 && m.editingMacroIdx < len(m.macros) {
 				newValue := m.editingMacroValue.Value()
 				macro := m.macros[m.editingMacroIdx].Macro
+				if m.macros[m.editingMacroIdx].Inherited() {
+					// Override the inherited value on the host
+					macro.HostMacroID = ""
+				}
 
 				m.editingMacroIdx = -1
 
@@ -381,15 +462,25 @@ func (m Model) updateConfirm(msg tea.KeyMsg) (Model, tea.Cmd) {
 
 		switch m.editorType {
 		case TypeHostTriggers:
-			if len(m.triggers) > 0 {
-				t := m.triggers[m.triggerCursor].Trigger
+			ids := m.confirmTriggerIDs
+			hostID := m.host.HostID
+			switch {
+			case len(ids) == 1:
 				return m, func() tea.Msg {
 					return TriggerToggleMsg{
-						TriggerID: t.TriggerID,
+						TriggerID: ids[0],
 						Enable:    action == "enable",
-						HostID:    m.host.HostID,
+						HostID:    hostID,
 					}
 				}
+			case len(ids) > 1:
+				return m, func() tea.Msg {
+					return TriggersToggleMsg{TriggerIDs: ids, Enable: action == "enable", HostID: hostID}
+				}
+			}
+		case TypeMuteRules:
+			if action == "delete" && len(m.mutes) > 0 {
+				return m, m.deleteMute()
 			}
 		case TypeHostMacros:
 			if action == "delete" && len(m.macros) > 0 {
@@ -420,7 +511,8 @@ type TriggerToggleMsg struct {
 	HostID    string
 }
 
-// MacroEditedMsg is sent when a macro value is edited.
+// MacroEditedMsg is sent when a macro value is edited. MacroID is empty
+// when an inherited macro is edited, to override it on the host.
 type MacroEditedMsg struct {
 	MacroID  string
 	Macro    string
@@ -454,6 +546,10 @@ func (m Model) View() string {
 		content.WriteString(m.viewTriggerList())
 	case TypeHostMacros:
 		content.WriteString(m.viewMacroList())
+	case TypeMuteRules:
+		content.WriteString(m.viewMuteList())
+	case TypeHostInterfaces:
+		content.WriteString(m.viewInterfaceList())
 	default:
 		// Unknown editor type, show nothing
 	}
@@ -465,6 +561,10 @@ func (m Model) View() string {
 		content.WriteString("\n")
 		confirmMsg := fmt.Sprintf("Are you sure you want to %s '%s'? (y/n)",
 			m.confirmAction, truncate(m.confirmTarget, 30))
+		if len(m.confirmTriggerIDs) > 1 {
+			confirmMsg = fmt.Sprintf("Are you sure you want to %s %d triggers? (y/n)",
+				m.confirmAction, len(m.confirmTriggerIDs))
+		}
 		content.WriteString(m.styles.AlertSeverity[4].Render(confirmMsg))
 	}
 
@@ -485,33 +585,46 @@ func (m Model) View() string {
 func (m Model) viewTriggerList() string {
 	var b strings.Builder
 
-	if len(m.triggers) == 0 {
+	if len(m.triggers) > 0 {
+		b.WriteString(m.viewTriggerFilters())
+		b.WriteString("\n")
+	}
+
+	switch {
+	case len(m.triggers) == 0:
 		b.WriteString(m.styles.Subtle.Render("  No triggers found for this host"))
 		b.WriteString("\n")
-	} else {
-		maxVisible := m.height - 12
-		if maxVisible < 3 {
-			maxVisible = 3
-		}
+	case len(m.triggerShown) == 0:
+		b.WriteString(m.styles.Subtle.Render("  No triggers match the filters"))
+		b.WriteString("\n")
+	default:
+		maxVisible := m.triggerRows()
 
 		end := m.triggerOffset + maxVisible
-		if end > len(m.triggers) {
-			end = len(m.triggers)
+		if end > len(m.triggerShown) {
+			end = len(m.triggerShown)
 		}
 
 		for i := m.triggerOffset; i < end; i++ {
-			t := m.triggers[i]
+			t := m.triggers[m.triggerShown[i]]
 			isSelected := i == m.triggerCursor
 
 			cursor := "  "
 			if isSelected {
 				cursor = "> "
 			}
+			mark := "  "
+			if t.Selected {
+				mark = "* "
+			}
 
 			// Status indicator
-			statusText := "[ON] "
+			statusText := m.styles.StatusIcon.Enabled
 			if t.Trigger.IsDisabled() {
-				statusText = "[OFF]"
+				statusText = m.styles.StatusIcon.Disabled
+			}
+			if pad := m.statusIconWidth() - lipgloss.Width(statusText); pad > 0 {
+				statusText += strings.Repeat(" ", pad)
 			}
 
 			// Problem indicator
@@ -521,17 +634,18 @@ func (m Model) viewTriggerList() string {
 			}
 
 			// Priority/severity
-			priority := theme.SeverityName(t.Trigger.PriorityInt())
-			desc := truncate(t.Trigger.Description, m.width-35)
+			severity := t.Trigger.PriorityInt()
+			priority := fmt.Sprintf("%s [%-4s]", m.severityIcon(severity), theme.SeverityName(severity))
+			desc := truncate(t.Trigger.Description, m.width-37)
 
 			// Build the line - apply styles only if not selected
 			var line string
 			if isSelected {
 				// Plain text for selected row, will be styled as a whole
-				line = fmt.Sprintf("%s%-5s [%-4s] %s%s", cursor, statusText, priority, desc, problemText)
+				line = fmt.Sprintf("%s%s%s %s %s%s", cursor, mark, statusText, priority, desc, problemText)
 				// Pad to full width for consistent highlight
-				if len(line) < m.width-6 {
-					line += strings.Repeat(" ", m.width-6-len(line))
+				if w := lipgloss.Width(line); w < m.width-6 {
+					line += strings.Repeat(" ", m.width-6-w)
 				}
 				b.WriteString(m.styles.AlertSelected.Render(line))
 			} else {
@@ -543,30 +657,32 @@ func (m Model) viewTriggerList() string {
 					status = m.styles.StatusOK.Render(statusText)
 				}
 
-				priorityStyle := m.styles.AlertSeverity[t.Trigger.PriorityInt()]
+				priorityStyle := m.styles.AlertSeverity[severity]
 				var problem string
 				if problemText != "" {
 					problem = m.styles.StatusProblem.Render(problemText)
 				}
 
-				line = fmt.Sprintf("%s%s %s %s%s", cursor, status,
-					priorityStyle.Render(fmt.Sprintf("[%s]", priority)), desc, problem)
+				line = fmt.Sprintf("%s%s%s %s %s%s", cursor, mark, status,
+					priorityStyle.Render(priority), desc, problem)
 				b.WriteString(line)
 			}
 			b.WriteString("\n")
 		}
 
 		// Scroll indicator
-		if len(m.triggers) > maxVisible {
+		if len(m.triggerShown) > maxVisible {
 			b.WriteString(m.styles.Subtle.Render(
-				fmt.Sprintf("\n  (%d/%d triggers)", m.triggerCursor+1, len(m.triggers))))
+				fmt.Sprintf("\n  (%d/%d triggers)", m.triggerCursor+1, len(m.triggerShown))))
 		}
 	}
 
 	b.WriteString("\n")
 	b.WriteString(strings.Repeat("─", m.width-4))
 	b.WriteString("\n")
-	b.WriteString(m.styles.Subtle.Render("[Space] toggle  [Esc] close"))
+	b.WriteString(m.styles.Subtle.Render("[Space] toggle  [x] mark  [a] mark all  [e]nable/[d]isable marked"))
+	b.WriteString("\n")
+	b.WriteString(m.styles.Subtle.Render("[/] search  [0-5] minimum severity  [Esc] close"))
 
 	return b.String()
 }
@@ -579,7 +695,7 @@ func (m Model) viewMacroList() string {
 		b.WriteString(m.styles.Subtle.Render("  No macros found for this host"))
 		b.WriteString("\n")
 	} else {
-		maxVisible := m.height - 12
+		maxVisible := m.height - 13
 		if maxVisible < 3 {
 			maxVisible = 3
 		}
@@ -606,22 +722,16 @@ func (m Model) viewMacroList() string {
 				continue
 			}
 
-			// Show value (mask if secret)
-			value := macro.Macro.Value
-			if macro.Macro.Type == zabbix.MacroTypeSecret {
-				value = "******"
-			}
-
-			line := fmt.Sprintf("%s%s = %s", cursor, macro.Macro.Macro, truncate(value, m.width-len(macro.Macro.Macro)-10))
+			// Show value and where it comes from, right-aligned
+			source := macroSource(macro)
+			value := truncate(macroValue(macro.Macro), m.width-len(macro.Macro.Macro)-len(source)-14)
+			line := fmt.Sprintf("%s%s = %s", cursor, macro.Macro.Macro, value)
+			padding := strings.Repeat(" ", max(1, m.width-6-len(line)-len(source)))
 
 			if i == m.macroCursor {
-				// Pad to full width for consistent highlight
-				if len(line) < m.width-6 {
-					line += strings.Repeat(" ", m.width-6-len(line))
-				}
-				b.WriteString(m.styles.AlertSelected.Render(line))
+				b.WriteString(m.styles.AlertSelected.Render(line + padding + source))
 			} else {
-				b.WriteString(line)
+				b.WriteString(line + padding + m.styles.Subtle.Render(source))
 			}
 			b.WriteString("\n")
 		}
@@ -631,23 +741,54 @@ func (m Model) viewMacroList() string {
 			b.WriteString(m.styles.Subtle.Render(
 				fmt.Sprintf("\n  (%d/%d macros)", m.macroCursor+1, len(m.macros))))
 		}
+
+		// Resolution order of the selected macro
+		if defs := m.macros[m.macroCursor].Definitions; len(defs) > 0 {
+			steps := make([]string, len(defs))
+			for i, d := range defs {
+				steps[i] = d.Source() + " = " + macroValue(d.HostMacro)
+			}
+			b.WriteString("\n")
+			b.WriteString(truncate("  Resolution: "+strings.Join(steps, " → "), m.width-6))
+		}
 	}
 
 	b.WriteString("\n")
 	b.WriteString(strings.Repeat("─", m.width-4))
 	b.WriteString("\n")
-	b.WriteString(m.styles.Subtle.Render("[e]dit value  [d]elete  [Esc] close"))
+	help := "[e]dit value  [d]elete  [Esc] close"
+	if len(m.macros) > 0 && m.macros[m.macroCursor].Inherited() {
+		help = "[e] override on host  [Esc] close"
+	}
+	b.WriteString(m.styles.Subtle.Render(help))
 
 	return b.String()
 }
 
+// macroValue returns the value of a macro to show, masking secrets.
+func macroValue(macro zabbix.HostMacro) string {
+	if macro.Type == zabbix.MacroTypeSecret {
+		return "******"
+	}
+	return macro.Value
+}
+
+// macroSource returns where the value of a macro comes from.
+func macroSource(item MacroItem) string {
+	if len(item.Definitions) == 0 {
+		return "host"
+	}
+	return item.Definitions[0].Source()
+}
+
 // truncate truncates a string to the given length with ellipsis.
 func truncate(s string, length int) string {
-	if len(s) <= length {
+	r := []rune(s)
+	if len(r) <= length {
 		return s
 	}
 	if length <= 3 {
-		return s[:length]
+		return string(r[:max(0, length)])
 	}
-	return s[:length-3] + "..."
+	return string(r[:length-3]) + "..."
 }
@@ -0,0 +1,312 @@
+package editor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// InterfaceEditedMsg is sent when an interface is edited, to save it.
+type InterfaceEditedMsg struct {
+	HostID    string
+	Interface zabbix.Interface
+}
+
+// ShowHostInterfaces opens the interface editor with the interfaces of a
+// host.
+func (m *Model) ShowHostInterfaces(host *zabbix.Host, ifaces []zabbix.Interface) {
+	if m.host == nil || m.host.HostID != host.HostID || m.editorType != TypeHostInterfaces {
+		m.ifaceCursor = 0
+		m.ifaceOffset = 0
+	}
+	m.visible = true
+	m.editorType = TypeHostInterfaces
+	m.title = fmt.Sprintf("Interfaces: %s", host.DisplayName())
+	m.host = host
+	m.confirmAction = ""
+	m.editingIface = -1
+	m.ifaces = ifaces
+	if m.ifaceCursor >= len(ifaces) {
+		m.ifaceCursor = max(0, len(ifaces)-1)
+	}
+}
+
+// interfaceTypeName returns the name of an interface type.
+func interfaceTypeName(t string) string {
+	switch t {
+	case "1":
+		return "Agent"
+	case "2":
+		return "SNMP"
+	case "3":
+		return "IPMI"
+	case "4":
+		return "JMX"
+	}
+	return "Unknown"
+}
+
+// editsSNMP reports whether the form edits the SNMP details of an
+// interface; SNMPv3 security is left to the frontend.
+func editsSNMP(iface zabbix.Interface) bool {
+	return iface.IsSNMP() && iface.Details != nil && iface.Details.Version != zabbix.SNMPv3
+}
+
+// updateInterfaceList handles key input for the interface list.
+func (m Model) updateInterfaceList(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.Hide()
+		return m, nil
+
+	case "up", "k":
+		if m.ifaceCursor > 0 {
+			m.ifaceCursor--
+			if m.ifaceCursor < m.ifaceOffset {
+				m.ifaceOffset = m.ifaceCursor
+			}
+		}
+
+	case "down", "j":
+		if m.ifaceCursor < len(m.ifaces)-1 {
+			m.ifaceCursor++
+			maxVisible := m.height - 12
+			if m.ifaceCursor >= m.ifaceOffset+maxVisible {
+				m.ifaceOffset = m.ifaceCursor - maxVisible + 1
+			}
+		}
+
+	case "e", "enter":
+		if len(m.ifaces) > 0 {
+			m.startInterfaceEdit(m.ifaceCursor)
+		}
+	}
+
+	return m, nil
+}
+
+// startInterfaceEdit opens the form for the interface at index.
+func (m *Model) startInterfaceEdit(index int) {
+	iface := m.ifaces[index]
+	addressLabel, address := "IP", iface.IP
+	if iface.UsesDNS() {
+		addressLabel, address = "DNS name", iface.DNS
+	}
+	m.ifaceFields = []string{addressLabel, "Port"}
+	values := []string{address, iface.Port}
+	if editsSNMP(iface) {
+		bulk := "no"
+		if iface.Details.UsesBulk() {
+			bulk = "yes"
+		}
+		m.ifaceFields = append(m.ifaceFields, "SNMP version", "Community", "Bulk")
+		values = append(values, iface.Details.Version, iface.Details.Community, bulk)
+		// Only servers that have it return it
+		if iface.Details.MaxRepetitions != "" {
+			m.ifaceFields = append(m.ifaceFields, "Max repetitions")
+			values = append(values, iface.Details.MaxRepetitions)
+		}
+	}
+
+	m.ifaceForm = make([]textinput.Model, len(m.ifaceFields))
+	for i := range m.ifaceForm {
+		ti := textinput.New()
+		ti.SetValue(values[i])
+		ti.CharLimit = 255
+		ti.Width = m.width - 24
+		m.ifaceForm[i] = ti
+	}
+	m.ifaceForm[0].Focus()
+	m.ifaceFocus = 0
+	m.ifaceErr = ""
+	m.editingIface = index
+}
+
+// updateInterfaceEdit handles key input in the interface form.
+func (m Model) updateInterfaceEdit(msg tea.Msg) (Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			m.editingIface = -1
+			return m, nil
+		case "tab", "down":
+			m.focusInterfaceField((m.ifaceFocus + 1) % len(m.ifaceForm))
+			return m, nil
+		case "shift+tab", "up":
+			m.focusInterfaceField((m.ifaceFocus + len(m.ifaceForm) - 1) % len(m.ifaceForm))
+			return m, nil
+		case "enter":
+			iface, err := m.editedInterface()
+			if err != nil {
+				m.ifaceErr = err.Error()
+				return m, nil
+			}
+			m.ifaces[m.editingIface] = iface
+			m.editingIface = -1
+			hostID := m.host.HostID
+			return m, func() tea.Msg { return InterfaceEditedMsg{HostID: hostID, Interface: iface} }
+		}
+	}
+
+	var cmd tea.Cmd
+	m.ifaceForm[m.ifaceFocus], cmd = m.ifaceForm[m.ifaceFocus].Update(msg)
+	return m, cmd
+}
+
+// editedInterface returns the interface being edited with the values of
+// the form, or an error if one is invalid.
+func (m Model) editedInterface() (zabbix.Interface, error) {
+	iface := m.ifaces[m.editingIface]
+	value := func(i int) string { return strings.TrimSpace(m.ifaceForm[i].Value()) }
+
+	address := value(0)
+	if address == "" {
+		return iface, fmt.Errorf("%s is required", m.ifaceFields[0])
+	}
+	if iface.UsesDNS() {
+		iface.DNS = address
+	} else {
+		iface.IP = address
+	}
+	iface.Port = value(1)
+	if iface.Port == "" {
+		return iface, fmt.Errorf("port is required")
+	}
+	if !editsSNMP(iface) {
+		return iface, nil
+	}
+
+	details := *iface.Details
+	details.Version = value(2)
+	if details.Version != zabbix.SNMPv1 && details.Version != zabbix.SNMPv2c {
+		return iface, fmt.Errorf("SNMP version must be 1 or 2 (SNMPv2c)")
+	}
+	details.Community = value(3)
+	if details.Community == "" {
+		return iface, fmt.Errorf("community is required")
+	}
+	switch strings.ToLower(value(4)) {
+	case "yes", "y", "on", "1":
+		details.Bulk = "1"
+	case "no", "n", "off", "0":
+		details.Bulk = "0"
+	default:
+		return iface, fmt.Errorf("bulk must be yes or no")
+	}
+	if len(m.ifaceForm) > 5 {
+		n, err := strconv.Atoi(value(5))
+		if err != nil || n < 1 || n > 100 {
+			return iface, fmt.Errorf("max repetitions must be from 1 to 100")
+		}
+		details.MaxRepetitions = strconv.Itoa(n)
+	}
+	iface.Details = &details
+	return iface, nil
+}
+
+// focusInterfaceField moves the focus to a field of the interface form.
+func (m *Model) focusInterfaceField(i int) {
+	m.ifaceForm[m.ifaceFocus].Blur()
+	m.ifaceFocus = i
+	m.ifaceForm[i].Focus()
+}
+
+// viewInterfaceList renders the interface list, or the form while editing.
+func (m Model) viewInterfaceList() string {
+	var b strings.Builder
+
+	if m.editingIface >= 0 {
+		iface := m.ifaces[m.editingIface]
+		b.WriteString(m.styles.DetailLabel.UnsetWidth().Render("Edit " + interfaceTypeName(iface.Type) + " interface"))
+		b.WriteString("\n\n")
+		for i, field := range m.ifaceFields {
+			b.WriteString(m.styles.DetailLabel.Render(field + ":"))
+			b.WriteString(m.ifaceForm[i].View())
+			b.WriteString("\n")
+		}
+		if iface.IsSNMP() && !editsSNMP(iface) {
+			b.WriteString("\n")
+			b.WriteString(m.styles.Subtle.Render("SNMPv3 security settings are edited in the frontend."))
+		} else if editsSNMP(iface) {
+			b.WriteString("\n")
+			b.WriteString(m.styles.Subtle.Render("SNMP version is 1 or 2 (SNMPv2c); the community may be a macro."))
+		}
+		if m.ifaceErr != "" {
+			b.WriteString("\n\n")
+			b.WriteString(m.styles.AlertSeverity[4].Render(m.ifaceErr))
+		}
+		b.WriteString("\n")
+		b.WriteString(strings.Repeat("─", m.width-4))
+		b.WriteString("\n")
+		b.WriteString(m.styles.Subtle.Render("[Tab] next field  [Enter] save  [Esc] cancel"))
+		return b.String()
+	}
+
+	if len(m.ifaces) == 0 {
+		b.WriteString(m.styles.Subtle.Render("  No interfaces found for this host"))
+		b.WriteString("\n")
+	} else {
+		maxVisible := max(m.height-12, 3)
+		end := min(m.ifaceOffset+maxVisible, len(m.ifaces))
+
+		for i := m.ifaceOffset; i < end; i++ {
+			iface := m.ifaces[i]
+			cursor := "  "
+			if i == m.ifaceCursor {
+				cursor = "> "
+			}
+			line := fmt.Sprintf("%s%-7s %s", cursor, interfaceTypeName(iface.Type), iface.Endpoint())
+			if iface.Main == "1" {
+				line += " (default)"
+			}
+			if iface.IsSNMP() && iface.Details != nil {
+				line += "\n" + truncate("          "+snmpSettings(iface.Details), m.width-6)
+			}
+
+			if i == m.ifaceCursor {
+				b.WriteString(m.styles.AlertSelected.Render(line))
+			} else {
+				b.WriteString(line)
+			}
+			b.WriteString("\n")
+		}
+
+		// Scroll indicator
+		if len(m.ifaces) > maxVisible {
+			b.WriteString(m.styles.Subtle.Render(
+				fmt.Sprintf("\n  (%d/%d interfaces)", m.ifaceCursor+1, len(m.ifaces))))
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", m.width-4))
+	b.WriteString("\n")
+	b.WriteString(m.styles.Subtle.Render("[e]dit  [Esc] close"))
+
+	return b.String()
+}
+
+// snmpSettings describes the SNMP settings of an interface in the list,
+// with the community masked.
+func snmpSettings(d *zabbix.InterfaceDetails) string {
+	parts := []string{d.VersionName()}
+	if d.Version == zabbix.SNMPv3 {
+		parts = append(parts, "security name "+d.SecurityName, d.SecurityLevelName())
+	} else {
+		parts = append(parts, "community "+zabbix.MaskCommunity(d.Community))
+	}
+	bulk := "bulk off"
+	if d.UsesBulk() {
+		bulk = "bulk on"
+	}
+	parts = append(parts, bulk)
+	if d.MaxRepetitions != "" {
+		parts = append(parts, "max repetitions "+d.MaxRepetitions)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,64 @@
+package editor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+func TestInterfaceEditor(t *testing.T) {
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetScreenSize(120, 40)
+	m.ShowHostInterfaces(&zabbix.Host{HostID: "10", Name: "sw01"}, []zabbix.Interface{
+		{InterfaceID: "1", Type: zabbix.InterfaceTypeSNMP, IP: "2001:db8::2", UseIP: "1", Port: "161", Main: "1", Details: &zabbix.InterfaceDetails{
+			Version: zabbix.SNMPv2c, Bulk: "1", Community: "s3cret", MaxRepetitions: "10",
+		}},
+		{InterfaceID: "2", Type: zabbix.InterfaceTypeSNMP, DNS: "sw01.example.com", UseIP: "0", Port: "161", Details: &zabbix.InterfaceDetails{
+			Version: zabbix.SNMPv3, Bulk: "0", SecurityName: "monitor", SecurityLevel: "2",
+		}},
+	})
+	view := m.View()
+	for _, want := range []string{"[2001:db8::2]:161 (default)", "SNMPv2c, community ******, bulk on, max repetitions 10", "SNMPv3, security name monitor, authPriv"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("interface list missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "s3cret") {
+		t.Error("the community should be masked")
+	}
+
+	// Invalid values are refused with the reason
+	m, _ = m.Update(key("e"))
+	m.ifaceForm[3].SetValue("")
+	m, cmd := m.Update(key("enter"))
+	if cmd != nil || !strings.Contains(m.View(), "community is required") {
+		t.Fatalf("an empty community should be refused:\n%s", m.View())
+	}
+
+	m.ifaceForm[1].SetValue("1161")
+	m.ifaceForm[3].SetValue("{$SNMP_COMMUNITY}")
+	m.ifaceForm[4].SetValue("no")
+	m.ifaceForm[5].SetValue("25")
+	m, cmd = m.Update(key("enter"))
+	msg, ok := cmd().(InterfaceEditedMsg)
+	if !ok || msg.HostID != "10" {
+		t.Fatalf("message = %+v, want the edited interface of host 10", msg)
+	}
+	want := zabbix.InterfaceDetails{Version: zabbix.SNMPv2c, Bulk: "0", Community: "{$SNMP_COMMUNITY}", MaxRepetitions: "25"}
+	if iface := msg.Interface; iface.Port != "1161" || iface.IP != "2001:db8::2" || *iface.Details != want {
+		t.Errorf("interface = %+v, details %+v; want port 1161 and %+v", iface, *iface.Details, want)
+	}
+
+	// SNMPv3 interfaces only have their address edited
+	m, _ = m.Update(key("j"))
+	m, _ = m.Update(key("e"))
+	if len(m.ifaceForm) != 2 || m.ifaceFields[0] != "DNS name" || !strings.Contains(m.View(), "edited in the frontend") {
+		t.Errorf("fields = %v, want the DNS name and port only", m.ifaceFields)
+	}
+	m, _ = m.Update(key("esc"))
+	if m.editingIface != -1 || !m.Visible() {
+		t.Error("Esc should cancel the edit and keep the list open")
+	}
+}
@@ -0,0 +1,42 @@
+package editor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+func TestInheritedMacros(t *testing.T) {
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetScreenSize(120, 40)
+	m.ShowHostMacros(&zabbix.Host{HostID: "10", Name: "db01"}, []zabbix.EffectiveMacro{
+		{Macro: "{$CPU.UTIL.CRIT}", Definitions: []zabbix.MacroDefinition{
+			{HostMacro: zabbix.HostMacro{HostMacroID: "1", Macro: "{$CPU.UTIL.CRIT}", Value: "95"}, Level: zabbix.MacroLevelHost},
+			{HostMacro: zabbix.HostMacro{HostMacroID: "7", Macro: "{$CPU.UTIL.CRIT}", Value: "90"}, Level: zabbix.MacroLevelTemplate, Template: "Linux"},
+		}},
+		{Macro: "{$SNMP_COMMUNITY}", Definitions: []zabbix.MacroDefinition{
+			{HostMacro: zabbix.HostMacro{Macro: "{$SNMP_COMMUNITY}", Value: "public"}, Level: zabbix.MacroLevelGlobal},
+		}},
+	})
+	if view := m.View(); !strings.Contains(view, "Resolution: host = 95 → Linux = 90") {
+		t.Errorf("view should show the resolution of the selected macro:\n%s", view)
+	}
+
+	// Inherited macros cannot be deleted, and editing one overrides it on the host
+	m, _ = m.Update(key("j"))
+	m, _ = m.Update(key("d"))
+	if m.IsConfirming() {
+		t.Error("d should not delete a global macro")
+	}
+	if view := m.View(); !strings.Contains(view, "global") || !strings.Contains(view, "[e] override on host") {
+		t.Errorf("view should show the macro is global:\n%s", view)
+	}
+	m, _ = m.Update(key("e"))
+	m, cmd := m.Update(key("enter"))
+	msg, ok := cmd().(MacroEditedMsg)
+	if !ok || msg.MacroID != "" || msg.Macro != "{$SNMP_COMMUNITY}" || msg.NewValue != "public" || msg.HostID != "10" {
+		t.Errorf("message = %+v, want a host override of {$SNMP_COMMUNITY}", msg)
+	}
+}
@@ -0,0 +1,258 @@
+package editor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/config"
+)
+
+// muteFields are the labels of the mute rule form, in order.
+var muteFields = []string{"Trigger", "Tag", "Comment"}
+
+// MuteRulesChangedMsg is sent when a mute rule is added, edited or deleted.
+type MuteRulesChangedMsg struct {
+	Rules []config.MuteRule
+}
+
+// ShowMuteRules opens the mute rule editor. counts are the alerts matching
+// each rule, and suggest is the trigger pattern offered for a new rule.
+func (m *Model) ShowMuteRules(rules []config.MuteRule, counts []int, suggest string) {
+	m.visible = true
+	m.editorType = TypeMuteRules
+	m.title = "Muted Triggers"
+	m.muteCursor = 0
+	m.muteOffset = 0
+	m.mutePrefix = suggest
+	m.confirmAction = ""
+	m.editingMute = -1
+	m.SetMuteRules(rules, counts)
+}
+
+// SetMuteRules updates the rules shown in the mute rule editor.
+func (m *Model) SetMuteRules(rules []config.MuteRule, counts []int) {
+	m.mutes = slices.Clone(rules)
+	m.muteCounts = counts
+	if m.muteCursor >= len(m.mutes) {
+		m.muteCursor = max(0, len(m.mutes)-1)
+	}
+}
+
+// updateMuteList handles key input for the mute rule list.
+func (m Model) updateMuteList(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.Hide()
+		return m, nil
+
+	case "up", "k":
+		if m.muteCursor > 0 {
+			m.muteCursor--
+			if m.muteCursor < m.muteOffset {
+				m.muteOffset = m.muteCursor
+			}
+		}
+
+	case "down", "j":
+		if m.muteCursor < len(m.mutes)-1 {
+			m.muteCursor++
+			maxVisible := m.height - 10
+			if m.muteCursor >= m.muteOffset+maxVisible {
+				m.muteOffset = m.muteCursor - maxVisible + 1
+			}
+		}
+
+	case "a":
+		m.startMuteEdit(len(m.mutes), config.MuteRule{Trigger: m.mutePrefix})
+
+	case "e", "enter":
+		if len(m.mutes) > 0 {
+			m.startMuteEdit(m.muteCursor, m.mutes[m.muteCursor])
+		}
+
+	case "d":
+		if len(m.mutes) > 0 {
+			m.confirmAction = "delete"
+			m.confirmTarget = describeMute(m.mutes[m.muteCursor])
+		}
+	}
+
+	return m, nil
+}
+
+// startMuteEdit opens the form for the rule at index, or for a new rule
+// when index is past the end.
+func (m *Model) startMuteEdit(index int, rule config.MuteRule) {
+	values := []string{rule.Trigger, rule.Tag, rule.Comment}
+	m.muteForm = make([]textinput.Model, len(muteFields))
+	for i := range m.muteForm {
+		ti := textinput.New()
+		ti.SetValue(values[i])
+		ti.CharLimit = 512
+		ti.Width = m.width - 20
+		m.muteForm[i] = ti
+	}
+	m.muteForm[0].Focus()
+	m.muteFocus = 0
+	m.muteErr = ""
+	m.editingMute = index
+}
+
+// updateMuteEdit handles key input in the mute rule form.
+func (m Model) updateMuteEdit(msg tea.Msg) (Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			m.editingMute = -1
+			return m, nil
+		case "tab", "down":
+			m.focusMuteField((m.muteFocus + 1) % len(m.muteForm))
+			return m, nil
+		case "shift+tab", "up":
+			m.focusMuteField((m.muteFocus + len(m.muteForm) - 1) % len(m.muteForm))
+			return m, nil
+		case "enter":
+			rule := config.MuteRule{
+				Trigger: strings.TrimSpace(m.muteForm[0].Value()),
+				Tag:     strings.TrimSpace(m.muteForm[1].Value()),
+				Comment: strings.TrimSpace(m.muteForm[2].Value()),
+			}
+			if err := rule.Validate(); err != nil {
+				m.muteErr = err.Error()
+				return m, nil
+			}
+
+			rules := slices.Clone(m.mutes)
+			if m.editingMute < len(rules) {
+				rules[m.editingMute] = rule
+			} else {
+				rules = append(rules, rule)
+			}
+			m.muteCursor = m.editingMute
+			m.editingMute = -1
+			m.mutes = rules
+			return m, func() tea.Msg { return MuteRulesChangedMsg{Rules: rules} }
+		}
+	}
+
+	var cmd tea.Cmd
+	m.muteForm[m.muteFocus], cmd = m.muteForm[m.muteFocus].Update(msg)
+	return m, cmd
+}
+
+// focusMuteField moves the focus to a field of the mute rule form.
+func (m *Model) focusMuteField(i int) {
+	m.muteForm[m.muteFocus].Blur()
+	m.muteFocus = i
+	m.muteForm[i].Focus()
+}
+
+// deleteMute removes the selected mute rule.
+func (m *Model) deleteMute() tea.Cmd {
+	rules := slices.Delete(slices.Clone(m.mutes), m.muteCursor, m.muteCursor+1)
+	m.mutes = rules
+	if m.muteCursor >= len(rules) {
+		m.muteCursor = max(0, len(rules)-1)
+	}
+	return func() tea.Msg { return MuteRulesChangedMsg{Rules: rules} }
+}
+
+// describeMute returns a one-line description of a mute rule.
+func describeMute(rule config.MuteRule) string {
+	var parts []string
+	if rule.Trigger != "" {
+		parts = append(parts, "/"+rule.Trigger+"/")
+	}
+	if rule.Tag != "" {
+		parts = append(parts, "tag "+rule.Tag)
+	}
+	return strings.Join(parts, " and ")
+}
+
+// viewMuteList renders the mute rule list, or the form while editing.
+func (m Model) viewMuteList() string {
+	var b strings.Builder
+
+	if m.editingMute >= 0 {
+		title := "New rule"
+		if m.editingMute < len(m.mutes) {
+			title = "Edit rule"
+		}
+		b.WriteString(m.styles.DetailLabel.UnsetWidth().Render(title))
+		b.WriteString("\n\n")
+		for i, field := range muteFields {
+			b.WriteString(m.styles.DetailLabel.Render(field + ":"))
+			b.WriteString(m.muteForm[i].View())
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(m.styles.Subtle.Render(
+			"Trigger is a regular expression on the problem name, e.g. ^Zabbix agent;\n" +
+				"tag is a tag name or name=value. A rule with both needs both to match."))
+		if m.muteErr != "" {
+			b.WriteString("\n\n")
+			b.WriteString(m.styles.AlertSeverity[4].Render(m.muteErr))
+		}
+		b.WriteString("\n")
+		b.WriteString(strings.Repeat("─", m.width-4))
+		b.WriteString("\n")
+		b.WriteString(m.styles.Subtle.Render("[Tab] next field  [Enter] save  [Esc] cancel"))
+		return b.String()
+	}
+
+	if len(m.mutes) == 0 {
+		b.WriteString(m.styles.Subtle.Render("  No muted triggers"))
+		b.WriteString("\n")
+	} else {
+		maxVisible := m.height - 12
+		if maxVisible < 3 {
+			maxVisible = 3
+		}
+
+		end := m.muteOffset + maxVisible
+		if end > len(m.mutes) {
+			end = len(m.mutes)
+		}
+
+		for i := m.muteOffset; i < end; i++ {
+			rule := m.mutes[i]
+			cursor := "  "
+			if i == m.muteCursor {
+				cursor = "> "
+			}
+
+			count := ""
+			if i < len(m.muteCounts) {
+				count = fmt.Sprintf("%d alerts", m.muteCounts[i])
+			}
+			line := fmt.Sprintf("%s%-*s %10s", cursor, m.width-22, truncate(describeMute(rule), m.width-22), count)
+			if rule.Comment != "" {
+				line += "\n    " + truncate(rule.Comment, m.width-12)
+			}
+
+			if i == m.muteCursor {
+				b.WriteString(m.styles.AlertSelected.Render(line))
+			} else {
+				b.WriteString(line)
+			}
+			b.WriteString("\n")
+		}
+
+		// Scroll indicator
+		if len(m.mutes) > maxVisible {
+			b.WriteString(m.styles.Subtle.Render(
+				fmt.Sprintf("\n  (%d/%d rules)", m.muteCursor+1, len(m.mutes))))
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", m.width-4))
+	b.WriteString("\n")
+	b.WriteString(m.styles.Subtle.Render("[a]dd  [e]dit  [d]elete  [Esc] close"))
+
+	return b.String()
+}
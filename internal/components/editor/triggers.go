@@ -0,0 +1,201 @@
+package editor
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// TriggersToggleMsg is sent when several triggers should be enabled or
+// disabled at once.
+type TriggersToggleMsg struct {
+	TriggerIDs []string
+	Enable     bool
+	HostID     string
+}
+
+// triggerRows returns how many triggers fit in the list.
+func (m Model) triggerRows() int {
+	return max(3, m.height-14)
+}
+
+// selectedTriggerID returns the ID of the trigger under the cursor, or
+// empty if no trigger is shown.
+func (m Model) selectedTriggerID() string {
+	if t := m.SelectedTrigger(); t != nil {
+		return t.TriggerID
+	}
+	return ""
+}
+
+// filterTriggers lists the triggers matching the search text with at least
+// the minimum severity, with the cursor on selectID if it is shown.
+func (m *Model) filterTriggers(selectID string) {
+	search := strings.ToLower(strings.TrimSpace(m.triggerSearch.Value()))
+	var shown []int
+	m.triggerCursor = 0
+	for i, t := range m.triggers {
+		if t.Trigger.PriorityInt() < m.triggerMinSeverity {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(t.Trigger.Description), search) {
+			continue
+		}
+		if t.Trigger.TriggerID == selectID {
+			m.triggerCursor = len(shown)
+		}
+		shown = append(shown, i)
+	}
+	m.triggerShown = shown
+	m.triggerOffset = 0
+	m.scrollToTriggerCursor()
+}
+
+// scrollToTriggerCursor scrolls the trigger list to show the cursor.
+func (m *Model) scrollToTriggerCursor() {
+	rows := m.triggerRows()
+	if m.triggerCursor < m.triggerOffset {
+		m.triggerOffset = m.triggerCursor
+	}
+	if m.triggerCursor >= m.triggerOffset+rows {
+		m.triggerOffset = m.triggerCursor - rows + 1
+	}
+}
+
+// markTrigger marks the trigger under the cursor for enabling or disabling,
+// or unmarks it, and moves to the next one.
+func (m *Model) markTrigger() {
+	if len(m.triggerShown) == 0 {
+		return
+	}
+	t := &m.triggers[m.triggerShown[m.triggerCursor]]
+	t.Selected = !t.Selected
+	if m.triggerCursor < len(m.triggerShown)-1 {
+		m.triggerCursor++
+		m.scrollToTriggerCursor()
+	}
+}
+
+// markAllTriggers marks all shown triggers, or unmarks them if they all
+// are marked already.
+func (m *Model) markAllTriggers() {
+	all := true
+	for _, i := range m.triggerShown {
+		all = all && m.triggers[i].Selected
+	}
+	for _, i := range m.triggerShown {
+		m.triggers[i].Selected = !all
+	}
+}
+
+// markedTriggers returns the shown triggers that are marked, or the one
+// under the cursor if none are.
+func (m Model) markedTriggers() []*zabbix.Trigger {
+	var marked []*zabbix.Trigger
+	for _, i := range m.triggerShown {
+		if m.triggers[i].Selected {
+			marked = append(marked, &m.triggers[i].Trigger)
+		}
+	}
+	if len(marked) == 0 {
+		if t := m.SelectedTrigger(); t != nil {
+			marked = append(marked, t)
+		}
+	}
+	return marked
+}
+
+// confirmToggle asks to enable or disable the triggers that are not
+// already in that state.
+func (m *Model) confirmToggle(enable bool, triggers []*zabbix.Trigger) {
+	var ids []string
+	var target string
+	for _, t := range triggers {
+		if t.IsEnabled() != enable {
+			ids = append(ids, t.TriggerID)
+			target = t.Description
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	m.confirmAction = "disable"
+	if enable {
+		m.confirmAction = "enable"
+	}
+	m.confirmTarget = target
+	m.confirmTriggerIDs = ids
+}
+
+// updateTriggerSearch handles key input while typing a trigger search,
+// filtering the list as it is typed. Esc clears the search.
+func (m Model) updateTriggerSearch(msg tea.Msg) (Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			m.triggerSearch.SetValue("")
+			m.filterTriggers(m.selectedTriggerID())
+			fallthrough
+		case "enter":
+			m.searchingTriggers = false
+			m.triggerSearch.Blur()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.triggerSearch, cmd = m.triggerSearch.Update(msg)
+	m.filterTriggers(m.selectedTriggerID())
+	return m, cmd
+}
+
+// viewTriggerFilters renders the search, minimum severity and counts of
+// the trigger list.
+func (m Model) viewTriggerFilters() string {
+	var parts []string
+	if m.searchingTriggers {
+		parts = append(parts, m.triggerSearch.View())
+	} else if search := m.triggerSearch.Value(); search != "" {
+		parts = append(parts, "/"+search)
+	}
+	if m.triggerMinSeverity > 0 {
+		parts = append(parts, "severity ≥ "+theme.SeverityName(m.triggerMinSeverity))
+	}
+
+	count := fmt.Sprintf("%d of %d triggers", len(m.triggerShown), len(m.triggers))
+	marked := 0
+	for _, i := range m.triggerShown {
+		if m.triggers[i].Selected {
+			marked++
+		}
+	}
+	if marked > 0 {
+		count += fmt.Sprintf(", %d marked", marked)
+	}
+
+	filters := strings.Join(parts, "  ")
+	if filters != "" {
+		filters += "  "
+	}
+	return "  " + filters + m.styles.Subtle.Render(count)
+}
+
+// statusIconWidth returns the width of the wider trigger status indicator.
+func (m Model) statusIconWidth() int {
+	return max(lipgloss.Width(m.styles.StatusIcon.Enabled), lipgloss.Width(m.styles.StatusIcon.Disabled))
+}
+
+// severityIcon returns the indicator of a severity, padded to the width of
+// the widest one.
+func (m Model) severityIcon(severity int) string {
+	icon := m.styles.SeverityIcon[severity]
+	if pad := m.styles.SeverityIconWidth() - lipgloss.Width(icon); pad > 0 {
+		icon += strings.Repeat(" ", pad)
+	}
+	return icon
+}
@@ -0,0 +1,92 @@
+package editor
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+func testTriggers() []zabbix.Trigger {
+	return []zabbix.Trigger{
+		{TriggerID: "1", Description: "High CPU utilization", Priority: "2", Status: zabbix.TriggerStatusEnabled},
+		{TriggerID: "2", Description: "Disk space is low", Priority: "3", Status: zabbix.TriggerStatusEnabled},
+		{TriggerID: "3", Description: "Disk space is critically low", Priority: "4", Status: zabbix.TriggerStatusDisabled},
+		{TriggerID: "4", Description: "Disk read latency is high", Priority: "4", Status: zabbix.TriggerStatusEnabled},
+	}
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestTriggerFilters(t *testing.T) {
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetScreenSize(120, 40)
+	host := &zabbix.Host{HostID: "10", Name: "db01"}
+	m.ShowHostTriggers(host, testTriggers(), "4")
+	if got := m.SelectedTrigger(); got == nil || got.TriggerID != "4" {
+		t.Fatalf("SelectedTrigger() = %v, want trigger 4", got)
+	}
+
+	// The search filters as it is typed and keeps the selected trigger
+	m, _ = m.Update(key("/"))
+	for _, r := range "disk" {
+		m, _ = m.Update(key(string(r)))
+	}
+	m, _ = m.Update(key("enter"))
+	if len(m.triggerShown) != 3 || m.SelectedTrigger().TriggerID != "4" {
+		t.Errorf("shown = %v, want the 3 disk triggers with trigger 4 selected", m.triggerShown)
+	}
+	m, _ = m.Update(key("4"))
+	if view := m.View(); !strings.Contains(view, "/disk  severity ≥ High") || !strings.Contains(view, "2 of 4 triggers") {
+		t.Errorf("view should show the filters:\n%s", view)
+	}
+
+	// Filters stay when the triggers of the host are reloaded
+	m.ShowHostTriggers(host, testTriggers(), "")
+	if len(m.triggerShown) != 2 {
+		t.Errorf("shown = %v, want the filters kept", m.triggerShown)
+	}
+	m.ShowHostTriggers(&zabbix.Host{HostID: "11"}, testTriggers(), "")
+	if len(m.triggerShown) != 4 {
+		t.Errorf("shown = %v, want no filters on another host", m.triggerShown)
+	}
+}
+
+func TestTriggerBulkToggle(t *testing.T) {
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetScreenSize(120, 40)
+	m.ShowHostTriggers(&zabbix.Host{HostID: "10", Name: "db01"}, testTriggers(), "")
+
+	// Marking all and disabling skips the trigger that is disabled already
+	m, _ = m.Update(key("a"))
+	m, _ = m.Update(key("d"))
+	if !strings.Contains(m.View(), "disable 3 triggers?") {
+		t.Fatalf("view should confirm disabling 3 triggers:\n%s", m.View())
+	}
+	m, cmd := m.Update(key("y"))
+	msg, ok := cmd().(TriggersToggleMsg)
+	if !ok || msg.Enable || strings.Join(msg.TriggerIDs, ",") != "1,2,4" || msg.HostID != "10" {
+		t.Errorf("message = %+v, want disabling triggers 1, 2 and 4", msg)
+	}
+
+	// Without marks, e acts on the trigger under the cursor
+	m, _ = m.Update(key("a"))
+	m, _ = m.Update(key("j"))
+	m, _ = m.Update(key("j"))
+	m, _ = m.Update(key("e"))
+	_, cmd = m.Update(key("y"))
+	if msg, ok := cmd().(TriggerToggleMsg); !ok || msg.TriggerID != "3" || !msg.Enable {
+		t.Errorf("message = %+v, want enabling trigger 3", msg)
+	}
+}
@@ -8,69 +8,196 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	zone "github.com/lrstanley/bubblezone"
 
+	"github.com/harpchad/chotko/internal/components/plain"
+	"github.com/harpchad/chotko/internal/components/rowcache"
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/query"
 	"github.com/harpchad/chotko/internal/theme"
 	"github.com/harpchad/chotko/internal/zabbix"
 )
 
+// rowKind is what a row of the list shows.
+type rowKind int
+
+const (
+	rowSingle   rowKind = iota // An event whose counterpart isn't loaded
+	rowPair                    // A problem and its recovery, shown as one row
+	rowProblem                 // The problem event of an expanded pair
+	rowRecovery                // The recovery event of an expanded pair
+)
+
+// entry is a row of the list.
+type entry struct {
+	kind     rowKind
+	event    zabbix.Event // The event, or the problem of a pair
+	recovery zabbix.Event // The recovery of a pair
+}
+
+// isPairEvent reports whether the row is one of the events of an expanded pair.
+func (e entry) isPairEvent() bool {
+	return e.kind == rowProblem || e.kind == rowRecovery
+}
+
 // Model represents the events list component.
 type Model struct {
 	styles   *theme.Styles
 	events   []zabbix.Event
-	filtered []zabbix.Event
+	filtered []entry
+	matched  int             // Events in filtered, counting both events of a pair
+	expanded map[string]bool // Expanded pairs by problem event ID
 	cursor   int
 	offset   int
 	width    int
 	height   int
 	focused  bool
+	stale    string          // Age of outdated data, e.g. "5m"; empty when fresh
+	rows     *rowcache.Cache // Rendered non-selected rows, reset on any change
+
+	// Pagination: more rows can be fetched from the server
+	hasMore     bool
+	loadingMore bool
 
 	// Filter state
 	textFilter string
+	query      *query.Query // Parsed textFilter
+	scope      string       // Server-side filters, shown in the header
+
+	// timeFormat controls how event times are displayed
+	timeFormat format.TimeFormat
 }
 
 // New creates a new events list model.
 func New(styles *theme.Styles) Model {
 	return Model{
-		styles: styles,
+		styles:   styles,
+		expanded: make(map[string]bool),
+		rows:     rowcache.New(),
 	}
 }
 
 // SetSize sets the component dimensions.
 func (m *Model) SetSize(width, height int) {
+	if width != m.width {
+		m.rows.Reset()
+	}
 	m.width = width
 	m.height = height
 }
 
+// SetMore sets whether more events can be fetched and whether a fetch is running.
+// When more are available a "load more" row is shown below the list.
+func (m *Model) SetMore(hasMore, loading bool) {
+	m.hasMore = hasMore
+	m.loadingMore = loading
+	m.ensureVisible()
+}
+
+// NearEnd reports whether the viewport is within a page of the end of the list,
+// so the next page should be fetched.
+func (m Model) NearEnd() bool {
+	return m.offset+2*m.visibleRows() >= len(m.filtered)
+}
+
+// Restyle discards rendered rows after the shared styles have changed.
+func (m *Model) Restyle() {
+	m.rows.Reset()
+}
+
 // SetFocused sets the focus state.
 func (m *Model) SetFocused(focused bool) {
 	m.focused = focused
 }
 
+// SetStale marks the data as outdated with the given age, e.g. "5m".
+// Stale rows are dimmed and the age is shown in the header. Pass empty string when fresh.
+func (m *Model) SetStale(age string) {
+	if age != m.stale {
+		m.rows.Reset()
+	}
+	m.stale = age
+}
+
+// SetTimeFormat sets how event times are displayed.
+func (m *Model) SetTimeFormat(f format.TimeFormat) {
+	m.timeFormat = f
+	m.rows.Reset()
+}
+
+// SetScope sets a description of the events that were fetched, e.g.
+// "24h, problems", shown in the header.
+func (m *Model) SetScope(scope string) {
+	m.scope = scope
+}
+
 // SetEvents updates the events list.
 func (m *Model) SetEvents(events []zabbix.Event) {
 	m.events = events
 	m.applyFilter()
 }
 
-// SetTextFilter sets the text filter.
+// SetTextFilter sets the text filter, a query such as "host:web* sev>=4".
+// A filter that isn't a valid query is matched as plain text.
 func (m *Model) SetTextFilter(filter string) {
-	m.textFilter = strings.ToLower(filter)
+	m.textFilter = filter
+	m.query = query.Lenient(filter)
 	m.applyFilter()
 }
 
-// applyFilter filters events based on current filter settings.
+// TextFilter returns the text filter.
+func (m Model) TextFilter() string {
+	return m.textFilter
+}
+
+// applyFilter builds the rows from the events that match the filter. A
+// problem whose recovery event is loaded too is shown as a single row, at
+// the problem's place in the list.
 func (m *Model) applyFilter() {
+	m.rows.Reset()
 	m.filtered = nil
+	m.matched = 0
+
+	recoveries := make(map[string]int) // Index of each recovery event by ID
+	for i, e := range m.events {
+		if e.Value == zabbix.EventValueOK {
+			recoveries[e.EventID] = i
+		}
+	}
+	paired := make(map[string]bool) // Recovery events shown with their problem
 	for _, e := range m.events {
-		if m.textFilter != "" {
-			name := strings.ToLower(e.Name)
-			host := strings.ToLower(e.HostName())
-			if !strings.Contains(name, m.textFilter) && !strings.Contains(host, m.textFilter) {
-				continue
-			}
+		if _, ok := recoveries[e.REventID]; ok && e.Value != zabbix.EventValueOK {
+			paired[e.REventID] = true
+		}
+	}
+
+	for _, e := range m.events {
+		if e.Value == zabbix.EventValueOK && paired[e.EventID] {
+			continue
+		}
+		if m.textFilter != "" && !m.query.Match(query.Problem(&e)) {
+			continue
+		}
+
+		i, ok := recoveries[e.REventID]
+		if !ok || e.Value == zabbix.EventValueOK {
+			m.filtered = append(m.filtered, entry{kind: rowSingle, event: e})
+			m.matched++
+			continue
+		}
+		recovery := m.events[i]
+		if e.RecoveryTime().IsZero() {
+			// event.get doesn't return r_clock, so the outage ends at the recovery
+			e.RClock = recovery.Clock
+		}
+		m.filtered = append(m.filtered, entry{kind: rowPair, event: e, recovery: recovery})
+		m.matched += 2
+		if m.expanded[e.EventID] {
+			m.filtered = append(m.filtered,
+				entry{kind: rowProblem, event: e},
+				entry{kind: rowRecovery, event: recovery})
 		}
-		m.filtered = append(m.filtered, e)
 	}
 
 	// Reset cursor if out of bounds
@@ -80,22 +207,87 @@ func (m *Model) applyFilter() {
 	m.ensureVisible()
 }
 
-// Selected returns the currently selected event.
+// Selected returns the currently selected event. For a collapsed pair this
+// is the problem event, with its recovery time set.
 // Returns a pointer to the element in the filtered slice. The pointer remains
 // valid until the next call to SetEvents or filter changes. Callers should
 // not store this pointer long-term.
 func (m Model) Selected() *zabbix.Event {
 	if m.cursor >= 0 && m.cursor < len(m.filtered) {
-		return &m.filtered[m.cursor]
+		return &m.filtered[m.cursor].event
 	}
 	return nil
 }
 
+// Toggle expands the selected pair to show both of its events, or collapses
+// it again. On one of the events of an expanded pair it collapses the pair
+// and selects it.
+func (m *Model) Toggle() {
+	if m.cursor < 0 || m.cursor >= len(m.filtered) {
+		return
+	}
+	row := m.filtered[m.cursor]
+	switch row.kind {
+	case rowPair:
+		m.expanded[row.event.EventID] = !m.expanded[row.event.EventID]
+		m.applyFilter()
+	case rowProblem, rowRecovery:
+		pair := m.cursor - 1
+		if row.kind == rowRecovery {
+			pair--
+		}
+		delete(m.expanded, m.filtered[pair].event.EventID)
+		m.cursor = pair
+		m.applyFilter()
+	}
+}
+
+// ExpandAll expands every pair.
+func (m *Model) ExpandAll() {
+	for _, row := range m.filtered {
+		if row.kind == rowPair {
+			m.expanded[row.event.EventID] = true
+		}
+	}
+	m.applyFilter()
+}
+
+// CollapseAll collapses every pair, keeping the selection on the same pair.
+func (m *Model) CollapseAll() {
+	pair := 0
+	for i, row := range m.filtered[:min(m.cursor+1, len(m.filtered))] {
+		if !row.isPairEvent() {
+			pair = i
+		}
+	}
+	// The events of the expanded pairs above go away
+	m.cursor = pair
+	for _, row := range m.filtered[:pair] {
+		if row.isPairEvent() {
+			m.cursor--
+		}
+	}
+	clear(m.expanded)
+	m.applyFilter()
+}
+
+// ClickRow selects the row at index, and expands or collapses it if it is
+// a pair.
+func (m *Model) ClickRow(index int) {
+	if index < 0 || index >= len(m.filtered) {
+		return
+	}
+	m.SetCursor(index)
+	if m.filtered[index].kind == rowPair {
+		m.Toggle()
+	}
+}
+
 // SelectedIndex returns the index of the selected event in the original list.
 func (m Model) SelectedIndex() int {
 	if selected := m.Selected(); selected != nil {
 		for i, e := range m.events {
-			if e.EventID == selected.EventID {
+			if e.EventID == selected.EventID && e.Value == selected.Value {
 				return i
 			}
 		}
@@ -103,9 +295,10 @@ func (m Model) SelectedIndex() int {
 	return -1
 }
 
-// Count returns the total and filtered event counts.
+// Count returns the total and filtered event counts. Both events of a pair
+// are counted.
 func (m Model) Count() (total, filtered int) {
-	return len(m.events), len(m.filtered)
+	return len(m.events), m.matched
 }
 
 // MoveUp moves the cursor up.
@@ -172,7 +365,7 @@ func (m *Model) Scroll(delta int) {
 	}
 }
 
-// FilteredCount returns the number of filtered items.
+// FilteredCount returns the number of rows shown.
 func (m Model) FilteredCount() int {
 	return len(m.filtered)
 }
@@ -185,9 +378,25 @@ func (m *Model) SetCursor(index int) {
 	}
 }
 
+// SelectID moves the cursor to the event with the given event ID, or to the
+// pair it belongs to. It returns false if the event is not shown.
+func (m *Model) SelectID(id string) bool {
+	for i, row := range m.filtered {
+		if row.event.EventID == id || (row.kind == rowPair && row.recovery.EventID == id) {
+			m.SetCursor(i)
+			return true
+		}
+	}
+	return false
+}
+
 // visibleRows returns the number of visible rows.
 func (m Model) visibleRows() int {
-	return m.height - 2 // Account for header and border
+	rows := m.height - 2 // Account for header and border
+	if m.hasMore {
+		rows-- // "Load more" row
+	}
+	return rows
 }
 
 // ensureVisible ensures the cursor is visible in the viewport.
@@ -229,12 +438,55 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.GoToTop()
 		case key.Matches(msg, key.NewBinding(key.WithKeys("end", "G"))):
 			m.GoToBottom()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("enter", " "))):
+			m.Toggle()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("E"))):
+			m.ExpandAll()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("C"))):
+			m.CollapseAll()
 		}
 	}
 
 	return m, nil
 }
 
+// PlainView renders the list for screen readers: a summary line, then one
+// line per event around the cursor, within height lines.
+func (m Model) PlainView(height int) string {
+	total, filtered := m.Count()
+	lines := []string{fmt.Sprintf("Events: %d of %d events", filtered, total)}
+
+	start, end := plain.Window(m.cursor, len(m.filtered), height-1)
+	for i := start; i < end; i++ {
+		row := m.filtered[i]
+		e := row.event
+		kind := "problem, " + theme.SeverityName(e.SeverityInt())
+		duration := e.DurationString()
+		switch {
+		case row.kind == rowPair && m.expanded[e.EventID]:
+			kind = "resolved problem, " + theme.SeverityName(e.SeverityInt()) + ", expanded"
+			duration = e.ResolvedDurationString()
+		case row.kind == rowPair:
+			kind = "resolved problem, " + theme.SeverityName(e.SeverityInt()) + ", collapsed"
+			duration = e.ResolvedDurationString()
+		case row.kind == rowProblem:
+			duration = ""
+		case row.kind == rowRecovery:
+			kind = "recovery"
+			duration = ""
+		case e.IsRecovery():
+			kind = "recovery"
+			duration = e.ResolvedDurationString()
+		}
+		lines = append(lines, plain.Item(i == m.cursor,
+			m.timeFormat.Short(e.StartTime()), kind, e.HostName(), e.Name, duration))
+	}
+	if m.hasMore {
+		lines = append(lines, "More events available")
+	}
+	return strings.Join(lines, "\n")
+}
+
 // View implements tea.Model.
 func (m Model) View() string {
 	// Handle zero-size case
@@ -252,6 +504,12 @@ func (m Model) View() string {
 	}
 	header += ")"
 	b.WriteString(m.styles.PaneTitle.Render(header))
+	if m.scope != "" {
+		b.WriteString(m.styles.Subtle.Render(" " + m.scope))
+	}
+	if m.stale != "" {
+		b.WriteString(m.styles.Subtle.Render(" (stale " + m.stale + ")"))
+	}
 	b.WriteString("\n")
 
 	// Calculate visible range
@@ -264,11 +522,7 @@ func (m Model) View() string {
 
 	// Render rows
 	for i := m.offset; i < endIdx; i++ {
-		e := m.filtered[i]
-		row := m.renderRow(e, i == m.cursor)
-		// Mark row with zone for mouse click detection
-		rowID := fmt.Sprintf("event_%d", i)
-		b.WriteString(zone.Mark(rowID, row))
+		b.WriteString(m.row(i))
 		if i < endIdx-1 {
 			b.WriteString("\n")
 		}
@@ -280,6 +534,15 @@ func (m Model) View() string {
 		b.WriteString("\n")
 	}
 
+	if m.hasMore {
+		b.WriteString("\n")
+		if m.loadingMore {
+			b.WriteString(m.styles.Subtle.Render("  Loading more..."))
+		} else {
+			b.WriteString(m.styles.Subtle.Render("  ▼ More available, scroll down to load"))
+		}
+	}
+
 	// Apply pane style
 	content := b.String()
 	if m.focused {
@@ -288,24 +551,61 @@ func (m Model) View() string {
 	return m.styles.PaneBlurred.Width(m.width).Height(m.height).Render(content)
 }
 
-// renderRow renders a single event row.
-func (m Model) renderRow(e zabbix.Event, selected bool) string {
+// row returns the rendered row at index i. Non-selected rows are reused from
+// the cache while its displayed time and duration are unchanged.
+func (m Model) row(i int) string {
+	e := m.filtered[i]
+	selected := i == m.cursor
+	key := m.timeFormat.Short(e.event.StartTime()) + " " + e.event.DurationString()
+	if !selected {
+		if row, ok := m.rows.Get(i, key); ok {
+			return row
+		}
+	}
+
+	row := m.renderRow(e, selected)
+	if m.stale != "" && !selected {
+		row = m.styles.Subtle.Render(ansi.Strip(row))
+	}
+	// Mark row with zone for mouse click detection
+	row = zone.Mark(fmt.Sprintf("event_%d", i), row)
+	if !selected {
+		m.rows.Put(i, key, row)
+	}
+	return row
+}
+
+// renderRow renders a single row. Pairs are marked ▸ when collapsed and ▾
+// when expanded, with their two events below.
+func (m Model) renderRow(r entry, selected bool) string {
+	e := r.event
+
 	// Status indicator - recovery (OK) or problem
 	var indicator string
 	var statusStyle lipgloss.Style
 
-	if e.IsRecovery() {
-		indicator = "OK"
+	if e.IsRecovery() && r.kind != rowProblem {
+		indicator = m.styles.StatusIcon.Resolved
 		statusStyle = m.styles.StatusOK
 	} else {
 		// Use severity colors for problems
 		severity := e.SeverityInt()
-		indicator = "!!"
+		indicator = m.styles.StatusIcon.Problem
 		statusStyle = m.styles.AlertSeverity[severity]
+		if m.styles.NoColor {
+			indicator = m.styles.SeverityIcon[severity]
+		}
+	}
+	iconWidth := max(lipgloss.Width(m.styles.StatusIcon.Resolved), lipgloss.Width(m.styles.StatusIcon.Problem))
+	if m.styles.NoColor {
+		iconWidth = max(iconWidth, m.styles.SeverityIconWidth())
+	}
+	if pad := iconWidth - lipgloss.Width(indicator); pad > 0 {
+		indicator += strings.Repeat(" ", pad)
 	}
 
 	// Time
-	timeStr := e.StartTime().Format("15:04:05")
+	timeStr := m.timeFormat.Short(e.StartTime())
 
 	// Host name
 	host := e.HostName()
@@ -313,21 +613,37 @@ func (m Model) renderRow(e zabbix.Event, selected bool) string {
 		host = host[:9] + "..."
 	}
 
-	// Event name
+	// Event name, after the pair marker
 	name := e.Name
-	nameWidth := m.width - 12 - 12 - 8 - 8 // time, host, status, padding
+	timeWidth := m.timeFormat.ShortWidth()
+	nameWidth := m.width - 2 - iconWidth - timeWidth - 12 - 8 - 8 // time, host, status, padding
 	if nameWidth < 10 {
 		nameWidth = 10
 	}
-	if len(name) > nameWidth {
-		name = name[:nameWidth-3] + "..."
+	if len(name) > nameWidth-2 {
+		name = name[:nameWidth-5] + "..."
+	}
+	switch {
+	case r.kind == rowPair && m.expanded[e.EventID]:
+		name = "▾ " + name
+	case r.kind == rowPair:
+		name = "▸ " + name
+	case r.kind == rowProblem:
+		name = "├ " + name
+	case r.kind == rowRecovery:
+		name = "└ " + name
+	default:
+		name = "  " + name
 	}
 
-	// Duration (for resolved events, show how long it lasted)
+	// Duration (for resolved events, show how long it lasted). The events
+	// of a pair show it on the pair's row.
 	var duration string
-	if e.IsRecovery() {
+	switch {
+	case r.isPairEvent():
+	case e.IsRecovery():
 		duration = e.ResolvedDurationString()
-	} else {
+	default:
 		duration = e.DurationString()
 	}
 	if len(duration) > 8 {
@@ -337,22 +653,22 @@ func (m Model) renderRow(e zabbix.Event, selected bool) string {
 	if selected {
 		// Build plain text row, then apply highlight style to the whole thing
 		// This prevents ANSI code fragmentation from individual column styles
-		timePadded := fmt.Sprintf("%-8s", timeStr)
+		timePadded := fmt.Sprintf("%-*s", timeWidth, timeStr)
 		hostPadded := fmt.Sprintf("%-12s", host)
 		namePadded := fmt.Sprintf("%-*s", nameWidth, name)
 		durationPadded := fmt.Sprintf("%8s", duration)
 
 		row := fmt.Sprintf("%s %s %s %s %s", indicator, timePadded, hostPadded, namePadded, durationPadded)
 		// Pad to full width for consistent highlight
-		if len(row) < m.width-2 {
-			row += strings.Repeat(" ", m.width-2-len(row))
+		if width := lipgloss.Width(row); width < m.width-2 {
+			row += strings.Repeat(" ", m.width-2-width)
 		}
 		return m.styles.AlertSelected.Render(row)
 	}
 
 	// Normal row rendering
 	statusIcon := statusStyle.Render(indicator)
-	timeStrStyled := m.styles.Subtle.Width(8).Render(timeStr)
+	timeStrStyled := m.styles.Subtle.Width(timeWidth).Render(timeStr)
 	hostStr := m.styles.AlertHost.Width(12).Render(host)
 	nameStr := m.styles.AlertName.Width(nameWidth).Render(name)
 	durationStr := m.styles.AlertDuration.Width(8).Align(lipgloss.Right).Render(duration)
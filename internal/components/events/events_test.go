@@ -2,8 +2,10 @@ package events
 
 import (
 	"os"
+	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
 	zone "github.com/lrstanley/bubblezone"
 
 	"github.com/harpchad/chotko/internal/theme"
@@ -410,3 +412,64 @@ func TestRecoveryEvent(t *testing.T) {
 		t.Error("Expected event to be identified as recovery")
 	}
 }
+
+func TestPairing(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(100, 20)
+	m.SetFocused(true)
+
+	hosts := []zabbix.Host{{Host: "web-01"}}
+	m.SetEvents([]zabbix.Event{
+		{EventID: "30", Value: "0", Name: "Nginx is down", Severity: "0", Clock: "1700003600", Hosts: hosts},
+		{EventID: "20", Value: "1", Name: "CPU high", Severity: "4", Clock: "1700002000", Hosts: hosts},
+		{EventID: "10", Value: "1", Name: "Nginx is down", Severity: "4", Clock: "1700000000", REventID: "30", Hosts: hosts},
+		{EventID: "5", Value: "0", Name: "Disk full", Clock: "1699990000", Hosts: hosts},
+	})
+
+	// The recovery is shown with its problem; the unmatched recovery on its own
+	if m.FilteredCount() != 3 {
+		t.Fatalf("FilteredCount() = %d, want 3 rows", m.FilteredCount())
+	}
+	if total, filtered := m.Count(); total != 4 || filtered != 4 {
+		t.Errorf("Count() = %d, %d, want 4, 4", total, filtered)
+	}
+
+	if !m.SelectID("30") {
+		t.Fatal("SelectID() of a paired recovery should select its pair")
+	}
+	pair := m.Selected()
+	if pair.EventID != "10" || pair.ResolvedDurationString() != "1h 0m" {
+		t.Errorf("pair = %s, outage %s, want 10, 1h 0m", pair.EventID, pair.ResolvedDurationString())
+	}
+	if !strings.Contains(m.View(), "▸ Nginx is down") {
+		t.Error("collapsed pair should be marked ▸")
+	}
+
+	// Expanding shows both raw events under the pair
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if m.FilteredCount() != 5 {
+		t.Fatalf("FilteredCount() = %d after expanding, want 5", m.FilteredCount())
+	}
+	m.MoveDown()
+	m.MoveDown()
+	if e := m.Selected(); e.EventID != "30" || !e.IsRecovery() {
+		t.Errorf("Selected() = %s, want the recovery event 30", e.EventID)
+	}
+
+	// Toggling on a raw event collapses the pair and selects it
+	m.Toggle()
+	if m.FilteredCount() != 3 || m.Selected().EventID != "10" {
+		t.Errorf("after collapsing: %d rows, selected %s, want 3 rows, 10",
+			m.FilteredCount(), m.Selected().EventID)
+	}
+
+	m.ExpandAll()
+	m.GoToBottom()
+	m.CollapseAll()
+	if m.FilteredCount() != 3 || m.Selected().EventID != "5" {
+		t.Errorf("after CollapseAll: %d rows, selected %s, want 3 rows, 5",
+			m.FilteredCount(), m.Selected().EventID)
+	}
+}
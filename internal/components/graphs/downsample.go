@@ -0,0 +1,49 @@
+package graphs
+
+import "github.com/harpchad/chotko/internal/zabbix"
+
+// MaxHistoryPoints is the most history values kept of a numeric item. Longer
+// histories are downsampled, as neither the detail chart nor a sparkline can
+// show more points than that.
+const MaxHistoryPoints = 500
+
+// downsample reduces history to at most maxPoints values. The first and last
+// values are kept, so the charted time range doesn't change, and the values
+// between them are split into equal buckets of which only the minimum and
+// maximum are kept, in time order, so spikes and dips survive and the
+// minimum and maximum of the whole history stay exact. Shorter histories are
+// returned as they are.
+func downsample(history []zabbix.History, maxPoints int) []zabbix.History {
+	if len(history) <= maxPoints || maxPoints < 4 {
+		return history
+	}
+
+	inner := history[1 : len(history)-1]
+	buckets := (maxPoints - 2) / 2
+	result := make([]zabbix.History, 0, maxPoints)
+	result = append(result, history[0])
+	for b := range buckets {
+		bucket := inner[b*len(inner)/buckets : (b+1)*len(inner)/buckets]
+		lo, hi := 0, 0
+		loVal := bucket[0].ValueFloat()
+		hiVal := loVal
+		for i := 1; i < len(bucket); i++ {
+			v := bucket[i].ValueFloat()
+			if v < loVal {
+				lo, loVal = i, v
+			}
+			if v > hiVal {
+				hi, hiVal = i, v
+			}
+		}
+		switch {
+		case lo == hi:
+			result = append(result, bucket[lo])
+		case lo < hi:
+			result = append(result, bucket[lo], bucket[hi])
+		default:
+			result = append(result, bucket[hi], bucket[lo])
+		}
+	}
+	return append(result, history[len(history)-1])
+}
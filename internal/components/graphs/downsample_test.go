@@ -0,0 +1,81 @@
+package graphs
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// testHistory returns n values of item 1, one a minute, with a spike and a dip.
+func testHistory(n int) []zabbix.History {
+	history := make([]zabbix.History, n)
+	for i := range history {
+		value := 50 + i%7
+		switch i {
+		case n / 3:
+			value = 1000
+		case 2 * n / 3:
+			value = -5
+		}
+		history[i] = zabbix.History{
+			ItemID: "1",
+			Clock:  strconv.Itoa(1700000000 + 60*i),
+			Value:  strconv.Itoa(value),
+		}
+	}
+	return history
+}
+
+func TestDownsample(t *testing.T) {
+	history := testHistory(10000)
+	got := downsample(history, 100)
+
+	if len(got) > 100 {
+		t.Fatalf("downsampled to %d points, want at most 100", len(got))
+	}
+	if got[0] != history[0] || got[len(got)-1] != history[len(history)-1] {
+		t.Error("expected the first and last values to be kept")
+	}
+	for i := 1; i < len(got); i++ {
+		if !got[i].After(got[i-1]) {
+			t.Fatalf("point %d at %s is not after %s", i, got[i].Clock, got[i-1].Clock)
+		}
+	}
+
+	minVal, maxVal := calcRange(got)
+	if minVal != -5 || maxVal != 1000 {
+		t.Errorf("downsampled range = %v..%v, want the dip and spike -5..1000", minVal, maxVal)
+	}
+
+	short := testHistory(50)
+	if got := downsample(short, 100); len(got) != len(short) {
+		t.Errorf("short history downsampled to %d points, want all %d", len(got), len(short))
+	}
+}
+
+func TestMergeHistoryDownsamples(t *testing.T) {
+	m := New(testStyles())
+	m.SetItems(createTestItems(), []string{"system.cpu", "vm.memory"})
+
+	m.MergeHistory(map[string][]zabbix.History{"1": testHistory(5000)})
+	if got := len(m.GetHistory("1")); got > MaxHistoryPoints {
+		t.Errorf("numeric item keeps %d values, want at most %d", got, MaxHistoryPoints)
+	}
+
+	// Values of items not in the tree can't be told to be numeric
+	m.MergeHistory(map[string][]zabbix.History{"99": testHistory(600)})
+	if got := len(m.GetHistory("99")); got != 600 {
+		t.Errorf("unknown item keeps %d values, want all 600", got)
+	}
+}
+
+// calcRange returns the minimum and maximum values of history.
+func calcRange(history []zabbix.History) (minVal, maxVal float64) {
+	minVal, maxVal = history[0].ValueFloat(), history[0].ValueFloat()
+	for _, h := range history {
+		minVal = min(minVal, h.ValueFloat())
+		maxVal = max(maxVal, h.ValueFloat())
+	}
+	return minVal, maxVal
+}
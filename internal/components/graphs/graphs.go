@@ -2,14 +2,17 @@ package graphs
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/NimbleMarkets/ntcharts/sparkline"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	zone "github.com/lrstanley/bubblezone"
 
+	"github.com/harpchad/chotko/internal/components/plain"
 	"github.com/harpchad/chotko/internal/format"
 	"github.com/harpchad/chotko/internal/theme"
 	"github.com/harpchad/chotko/internal/zabbix"
@@ -24,14 +27,29 @@ type Model struct {
 	width      int
 	height     int
 	focused    bool
+	stale      string // Age of outdated data, e.g. "5m"; empty when fresh
 	textFilter string
 
+	// Items and categories the tree was built from
+	items      []zabbix.Item
+	categories []string
+	// Only unsupported items are shown
+	unsupportedOnly bool
+
 	// Sparkline cache: itemID -> rendered sparkline string
 	sparklines map[string]string
 	// History data: itemID -> []History
 	history map[string][]zabbix.History
 	// Loading state: hostID -> is loading
 	loadingHosts map[string]bool
+	// State to apply when the tree is next built, set by RestoreState
+	restore *treeState
+}
+
+// treeState is the expanded nodes and selection of a tree.
+type treeState struct {
+	expanded map[string]bool
+	selected string
 }
 
 // New creates a new graphs tree model.
@@ -56,8 +74,16 @@ func (m *Model) SetFocused(focused bool) {
 	m.focused = focused
 }
 
+// SetStale marks the data as outdated with the given age, e.g. "5m".
+// Stale rows are dimmed and the age is shown in the header. Pass empty string when fresh.
+func (m *Model) SetStale(age string) {
+	m.stale = age
+}
+
 // SetItems updates the items and rebuilds the tree, preserving expanded state.
 func (m *Model) SetItems(items []zabbix.Item, categories []string) {
+	m.items = items
+	m.categories = categories
 	// Save current expanded state before rebuilding
 	expandedNodes := make(map[string]bool)
 	if m.tree != nil {
@@ -74,7 +100,23 @@ func (m *Model) SetItems(items []zabbix.Item, categories []string) {
 		selectedID = selected.ID
 	}
 
+	// A restored state replaces the current one
+	if m.restore != nil {
+		expandedNodes = m.restore.expanded
+		selectedID = m.restore.selected
+		m.restore = nil
+	}
+
 	// Rebuild tree
+	if m.unsupportedOnly {
+		var unsupported []zabbix.Item
+		for _, item := range items {
+			if item.IsUnsupported() {
+				unsupported = append(unsupported, item)
+			}
+		}
+		items = unsupported
+	}
 	m.tree = BuildTree(items, categories)
 
 	// Restore expanded state
@@ -96,23 +138,126 @@ func (m *Model) SetItems(items []zabbix.Item, categories []string) {
 	m.sparklines = make(map[string]string)
 }
 
+// SetUnsupportedOnly shows only the unsupported items, under their hosts
+// and categories, all expanded, or all items again.
+func (m *Model) SetUnsupportedOnly(only bool) {
+	if only == m.unsupportedOnly {
+		return
+	}
+	m.unsupportedOnly = only
+	m.SetItems(m.items, m.categories)
+	if only {
+		m.tree.ExpandAll()
+	}
+	m.regenerateSparklines()
+	m.cursor = min(m.cursor, max(0, m.tree.VisibleCount()-1))
+	m.ensureVisible()
+}
+
+// UnsupportedOnly returns whether only unsupported items are shown.
+func (m Model) UnsupportedOnly() bool {
+	return m.unsupportedOnly
+}
+
+// SetItemStatus updates the status of a listed item after it was enabled or
+// disabled, until the items are next loaded.
+func (m *Model) SetItemStatus(itemID, status string) {
+	if m.tree == nil {
+		return
+	}
+	if node, ok := m.tree.AllNodes["item:"+itemID]; ok && node.Item != nil {
+		node.Item.Status = status
+	}
+}
+
+// RestoreState expands the nodes with the given IDs and selects a node when
+// the tree is next built by SetItems, e.g. to restore a previous session.
+func (m *Model) RestoreState(expanded []string, selectedID string) {
+	state := &treeState{expanded: make(map[string]bool, len(expanded)), selected: selectedID}
+	for _, id := range expanded {
+		state.expanded[id] = true
+	}
+	m.restore = state
+}
+
+// ExpandedIDs returns the IDs of the expanded nodes, sorted.
+func (m Model) ExpandedIDs() []string {
+	var ids []string
+	for id, node := range m.tree.AllNodes {
+		if !node.Collapsed && len(node.Children) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// UnloadedHosts returns the IDs of expanded hosts whose history is neither
+// loaded nor loading, and marks them as loading. Hosts are expanded without
+// loading their history when the tree is restored.
+func (m *Model) UnloadedHosts() []string {
+	var hostIDs []string
+	for _, node := range m.tree.Roots {
+		if node.Type == NodeTypeHost && !node.Collapsed && !m.HasHostHistory(node.HostID) && !m.IsHostLoading(node.HostID) {
+			m.SetHostLoading(node.HostID, true)
+			hostIDs = append(hostIDs, node.HostID)
+		}
+	}
+	return hostIDs
+}
+
 // SetHistory updates history data for items and regenerates sparklines.
 func (m *Model) SetHistory(history map[string][]zabbix.History) {
-	m.history = history
-	m.regenerateSparklines()
+	m.history = make(map[string][]zabbix.History, len(history))
+	m.MergeHistory(history)
 }
 
 // MergeHistory adds history data for items without clearing existing data.
+// Long histories of numeric items are downsampled to MaxHistoryPoints.
 func (m *Model) MergeHistory(history map[string][]zabbix.History) {
 	if m.history == nil {
 		m.history = make(map[string][]zabbix.History)
 	}
 	for itemID, hist := range history {
+		if m.isNumeric(itemID) {
+			hist = downsample(hist, MaxHistoryPoints)
+		}
 		m.history[itemID] = hist
 	}
 	m.regenerateSparklines()
 }
 
+// isNumeric reports whether the item is known to have numeric values.
+func (m *Model) isNumeric(itemID string) bool {
+	if m.tree == nil {
+		return false
+	}
+	node, ok := m.tree.AllNodes["item:"+itemID]
+	return ok && node.Item != nil && node.Item.IsNumeric()
+}
+
+// AppendHistory adds newer values of an item, such as log lines, keeping
+// the latest limit values, and shows the latest as the item's last value.
+func (m *Model) AppendHistory(itemID string, history []zabbix.History, limit int) {
+	if len(history) == 0 {
+		return
+	}
+	if m.history == nil {
+		m.history = make(map[string][]zabbix.History)
+	}
+	all := append(m.history[itemID], history...)
+	m.history[itemID] = all[max(0, len(all)-limit):]
+
+	if m.tree == nil {
+		return
+	}
+	if node, ok := m.tree.AllNodes["item:"+itemID]; ok && node.Item != nil {
+		last := history[len(history)-1]
+		node.Item.LastValue = last.Value
+		node.Item.LastClock = last.Clock
+	}
+}
+
 // regenerateSparklines creates sparkline strings for all items.
 func (m *Model) regenerateSparklines() {
 	m.sparklines = make(map[string]string)
@@ -121,6 +266,10 @@ func (m *Model) regenerateSparklines() {
 		if len(hist) == 0 {
 			continue
 		}
+		if node, ok := m.tree.AllNodes["item:"+itemID]; ok && node.Item != nil && !node.Item.IsNumeric() {
+			// Text values are not charted
+			continue
+		}
 
 		// Extract values for sparkline
 		values := make([]float64, len(hist))
@@ -155,6 +304,28 @@ func (m Model) SelectedItem() *zabbix.Item {
 	return nil
 }
 
+// SelectItem selects the first listed item, in tree order, that match
+// reports true for, expanding its host and category. Returns the item, or
+// nil if no listed item matches.
+func (m *Model) SelectItem(match func(*zabbix.Item) bool) *zabbix.Item {
+	for _, host := range m.tree.Roots {
+		for _, cat := range host.Children {
+			for _, node := range cat.Children {
+				if node.Item == nil || !match(node.Item) {
+					continue
+				}
+				host.Collapsed = false
+				cat.Collapsed = false
+				m.tree.RebuildFlatList()
+				m.cursor = m.tree.FindNodeIndex(node.ID)
+				m.ensureVisible()
+				return node.Item
+			}
+		}
+	}
+	return nil
+}
+
 // Count returns the total item count and visible node count.
 func (m Model) Count() (total, visible int) {
 	return m.tree.ItemCount(), m.tree.VisibleCount()
@@ -307,6 +478,52 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, nil
 }
 
+// PlainView renders the tree for screen readers: a summary line, then one
+// line per visible node around the cursor, within height lines.
+func (m Model) PlainView(height int) string {
+	total, _ := m.Count()
+	lines := []string{fmt.Sprintf("Graphs: %d items", total)}
+
+	start, end := plain.Window(m.cursor, m.tree.VisibleCount(), height-1)
+	for i := start; i < end; i++ {
+		node := m.tree.GetVisibleNode(i)
+		if node == nil {
+			continue
+		}
+		level := fmt.Sprintf("level %d", node.Depth+1)
+		state := "expanded"
+		if node.Collapsed {
+			state = "collapsed"
+		}
+		var fields []string
+		switch node.Type {
+		case NodeTypeHost:
+			itemCount := 0
+			for _, cat := range node.Children {
+				itemCount += len(cat.Children)
+			}
+			loading := ""
+			if m.loadingHosts[node.HostID] {
+				loading = "loading"
+			}
+			fields = []string{node.Name, fmt.Sprintf("%d items", itemCount), state, level, loading}
+		case NodeTypeCategory:
+			fields = []string{node.Name, fmt.Sprintf("%d items", len(node.Children)), state, level}
+		case NodeTypeItem:
+			if node.Item == nil {
+				fields = []string{node.Name, level}
+				break
+			}
+			fields = []string{node.Item.Name, itemValue(node.Item), level}
+			if node.Item.IsUnsupported() {
+				fields = append(fields, node.Item.Error)
+			}
+		}
+		lines = append(lines, plain.Item(i == m.cursor, fields...))
+	}
+	return strings.Join(lines, "\n")
+}
+
 // View implements tea.Model.
 func (m Model) View() string {
 	// Handle zero-size case
@@ -322,8 +539,14 @@ func (m Model) View() string {
 	if visible != total {
 		header += fmt.Sprintf(", %d visible", visible)
 	}
+	if m.unsupportedOnly {
+		header += ", unsupported only"
+	}
 	header += ")"
 	b.WriteString(m.styles.PaneTitle.Render(header))
+	if m.stale != "" {
+		b.WriteString(m.styles.Subtle.Render(" (stale " + m.stale + ")"))
+	}
 	b.WriteString("\n")
 
 	// Calculate visible range
@@ -341,6 +564,9 @@ func (m Model) View() string {
 			continue
 		}
 		row := m.renderNode(node, i == m.cursor)
+		if m.stale != "" && i != m.cursor {
+			row = m.styles.Subtle.Render(ansi.Strip(row))
+		}
 		// Mark row with zone for mouse click detection
 		nodeID := fmt.Sprintf("graph_node_%d", i)
 		b.WriteString(zone.Mark(nodeID, row))
@@ -408,15 +634,29 @@ func (m Model) renderNode(node *TreeNode, selected bool) string {
 	return m.styles.AlertNormal.Width(m.width - 2).Render(row)
 }
 
-// renderHostNode renders a host node.
-func (m Model) renderHostNode(node *TreeNode, _ bool) string {
+// renderHostNode renders a host node, with the number of its unsupported
+// items if any.
+func (m Model) renderHostNode(node *TreeNode, selected bool) string {
 	// Count items under this host
 	itemCount := 0
+	unsupported := 0
 	for _, cat := range node.Children {
 		itemCount += len(cat.Children)
+		for _, child := range cat.Children {
+			if child.Item != nil && child.Item.IsUnsupported() {
+				unsupported++
+			}
+		}
 	}
 
 	name := fmt.Sprintf("%s (%d)", node.Name, itemCount)
+	if unsupported > 0 && !m.unsupportedOnly {
+		text := fmt.Sprintf("%d unsupported", unsupported)
+		if !selected {
+			text = m.styles.StatusProblem.Render(text)
+		}
+		name += " " + text
+	}
 
 	// Show loading indicator if history is being loaded
 	if m.loadingHosts[node.HostID] {
@@ -438,9 +678,7 @@ func (m Model) renderItemNode(node *TreeNode, selected bool) string {
 	}
 
 	item := node.Item
-
-	// Format value with units
-	value := format.Value(item.LastValueFloat(), item.Units)
+	value := itemValue(item)
 
 	// Get sparkline if available
 	spark := ""
@@ -461,6 +699,7 @@ func (m Model) renderItemNode(node *TreeNode, selected bool) string {
 	if len(name) > nameWidth {
 		name = name[:nameWidth-3] + "..."
 	}
+	value = ansi.Truncate(value, valueWidth, "…")
 
 	// When selected, render plain text to allow background highlighting
 	if selected {
@@ -473,11 +712,33 @@ func (m Model) renderItemNode(node *TreeNode, selected bool) string {
 	// Build the row with styles for non-selected items
 	nameStyle := m.styles.AlertHost.Width(nameWidth)
 	valueStyle := m.styles.AlertDuration.Width(valueWidth).Align(lipgloss.Right)
+	if item.IsUnsupported() {
+		valueStyle = m.styles.StatusProblem.Width(valueWidth).Align(lipgloss.Right)
+	}
 	sparkStyle := m.styles.Subtle.Width(sparkWidth)
 
 	return nameStyle.Render(name) + valueStyle.Render(value) + "  " + sparkStyle.Render(spark)
 }
 
+// itemValue formats the last value of an item with its units, or as mapped
+// by its value map, or the first line of the last value of a text item, or
+// shows that it is disabled or unsupported.
+func itemValue(item *zabbix.Item) string {
+	mapped := item.MappedValue(item.LastValue)
+	switch {
+	case item.IsDisabled():
+		return "disabled"
+	case item.IsUnsupported():
+		return "UNSUPPORTED"
+	case mapped != "":
+		return mapped
+	case !item.IsNumeric():
+		line, _, _ := strings.Cut(item.LastValue, "\n")
+		return strings.TrimSpace(line)
+	}
+	return format.Value(item.LastValueFloat(), item.Units)
+}
+
 // GetHistory returns the history data for an item.
 func (m Model) GetHistory(itemID string) []zabbix.History {
 	return m.history[itemID]
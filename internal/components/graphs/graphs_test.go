@@ -1,7 +1,9 @@
 package graphs
 
 import (
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	zone "github.com/lrstanley/bubblezone"
@@ -81,6 +83,38 @@ func TestBuildTree(t *testing.T) {
 	}
 }
 
+func TestBuildHostGroupTree(t *testing.T) {
+	web := zabbix.HostGroup{GroupID: "1", Name: "Web"}
+	db := zabbix.HostGroup{GroupID: "2", Name: "databases"}
+	tree := BuildHostGroupTree([]zabbix.Host{
+		{HostID: "10", Name: "web-02", Groups: []zabbix.HostGroup{web}},
+		{HostID: "11", Name: "web-01", Groups: []zabbix.HostGroup{web, db}},
+		{HostID: "12", Name: "lonely"},
+	})
+
+	var groups []string
+	for _, root := range tree.Roots {
+		groups = append(groups, fmt.Sprintf("%s(%d)", root.Name, len(root.Children)))
+	}
+	if got := strings.Join(groups, " "); got != "databases(1) No group(1) Web(2)" {
+		t.Errorf("groups = %q, want sorted by name ignoring case", got)
+	}
+	if got := tree.VisibleCount(); got != 3 {
+		t.Errorf("VisibleCount() = %d, want 3 collapsed groups", got)
+	}
+
+	webNode := tree.GetNode("group:1")
+	if webNode == nil || webNode.Type != NodeTypeGroup {
+		t.Fatalf("group node = %v, want a group", webNode)
+	}
+	if webNode.Children[0].HostID != "10" || webNode.Children[1].HostID != "11" {
+		t.Error("hosts should keep their order within a group")
+	}
+	if tree.GetNode("group:2:host:11") == nil {
+		t.Error("a host in two groups should be listed under both")
+	}
+}
+
 func TestExpandCollapse(t *testing.T) {
 	items := []zabbix.Item{
 		{
@@ -325,6 +359,38 @@ func TestClickNode(t *testing.T) {
 	}
 }
 
+func TestRestoreState(t *testing.T) {
+	m := New(testStyles())
+	m.SetSize(80, 20)
+	m.RestoreState([]string{"host:200"}, "host:200")
+	m.SetItems(createTestItems(), []string{"system.cpu", "vm.memory"})
+
+	if got := m.ExpandedIDs(); len(got) != 1 || got[0] != "host:200" {
+		t.Errorf("ExpandedIDs() = %v, want [host:200]", got)
+	}
+	if node := m.Selected(); node == nil || node.ID != "host:200" {
+		t.Errorf("Selected() = %v, want host:200", node)
+	}
+
+	hostIDs := m.UnloadedHosts()
+	if len(hostIDs) != 1 || hostIDs[0] != "200" {
+		t.Errorf("UnloadedHosts() = %v, want [200]", hostIDs)
+	}
+	if !m.IsHostLoading("200") {
+		t.Error("host 200 should be loading")
+	}
+	if again := m.UnloadedHosts(); len(again) != 0 {
+		t.Errorf("UnloadedHosts() = %v while loading, want none", again)
+	}
+
+	// The restored state is only applied once
+	m.tree.ToggleNode("host:200")
+	m.SetItems(createTestItems(), []string{"system.cpu", "vm.memory"})
+	if got := m.ExpandedIDs(); len(got) != 0 {
+		t.Errorf("ExpandedIDs() = %v after collapsing, want none", got)
+	}
+}
+
 func TestView(t *testing.T) {
 	m := New(testStyles())
 	items := createTestItems()
@@ -471,6 +537,56 @@ func TestSelectedItem(t *testing.T) {
 	}
 }
 
+func TestSetItemStatus(t *testing.T) {
+	m := New(testStyles())
+	m.SetItems(createTestItems(), []string{"system.cpu", "vm.memory"})
+	m.SetSize(80, 20)
+	m.ExpandAll()
+
+	m.SetItemStatus("1", zabbix.ItemStatusDisabled)
+	if !containsString(m.View(), "disabled") {
+		t.Error("a disabled item should be shown as disabled")
+	}
+	m.SetItemStatus("1", zabbix.ItemStatusEnabled)
+	if containsString(m.View(), "disabled") {
+		t.Error("an enabled item should show its value")
+	}
+	m.SetItemStatus("99", zabbix.ItemStatusDisabled) // Unknown items are ignored
+}
+
+func TestUnsupportedOnly(t *testing.T) {
+	items := createTestItems()
+	items[1].State = zabbix.ItemStateNotSupported
+	items[1].Error = "Cannot read /proc/meminfo"
+
+	m := New(testStyles())
+	m.SetItems(items, []string{"system.cpu", "vm.memory"})
+	m.SetSize(100, 20)
+	if view := m.View(); !containsString(view, "1 unsupported") {
+		t.Errorf("the host should show its unsupported item:\n%s", view)
+	}
+
+	m.SetUnsupportedOnly(true)
+	if total, _ := m.Count(); total != 1 {
+		t.Fatalf("Count() = %d, want only the unsupported item", total)
+	}
+	view := m.View()
+	if !containsString(view, "Memory available") || !containsString(view, "UNSUPPORTED") || containsString(view, "CPU utilization") {
+		t.Errorf("only the unsupported item should be listed, expanded:\n%s", view)
+	}
+
+	// Refreshed items stay filtered
+	m.SetItems(items, []string{"system.cpu", "vm.memory"})
+	if total, _ := m.Count(); total != 1 {
+		t.Errorf("Count() after refresh = %d, want 1", total)
+	}
+
+	m.SetUnsupportedOnly(false)
+	if total, _ := m.Count(); total != len(items) {
+		t.Errorf("Count() = %d, want all %d items", total, len(items))
+	}
+}
+
 func TestSetHostLoading(t *testing.T) {
 	m := New(testStyles())
 
@@ -577,3 +693,18 @@ func containsStringHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestValueMappedItem(t *testing.T) {
+	items := createTestItems()
+	items[0].LastValue = "1"
+	items[0].ValueMap = &zabbix.ValueMap{Mappings: []zabbix.ValueMapping{{Value: "1", NewValue: "Up"}}}
+
+	m := New(testStyles())
+	m.SetItems(items, []string{"system.cpu", "vm.memory"})
+	m.SetSize(80, 20)
+	m.ExpandAll()
+
+	if !containsString(m.View(), "Up (1)") {
+		t.Error("an item with a value map should show its mapped value")
+	}
+}
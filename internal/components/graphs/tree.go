@@ -16,6 +16,7 @@ const (
 	NodeTypeHost NodeType = iota
 	NodeTypeCategory
 	NodeTypeItem
+	NodeTypeGroup // Host group, in the host group tree of the Hosts tab
 )
 
 // TreeNode represents a node in the tree structure.
@@ -29,6 +30,7 @@ type TreeNode struct {
 	Item      *zabbix.Item // For item nodes, the actual item
 	HostID    string       // Host ID for category/item nodes
 	Category  string       // Category name for item nodes
+	GroupID   string       // Host group ID for group nodes
 }
 
 // Tree represents a collapsible tree of hosts, categories, and items.
@@ -157,6 +159,54 @@ func BuildTree(items []zabbix.Item, categories []string) *Tree {
 	return tree
 }
 
+// BuildHostGroupTree constructs a tree of hosts under their host groups,
+// sorted by group name. Hosts keep their order within a group, and a host
+// in several groups is listed under each of them. Hosts without a group
+// are listed under "No group".
+func BuildHostGroupTree(hosts []zabbix.Host) *Tree {
+	tree := NewTree()
+
+	groups := make(map[string]*TreeNode)
+	for _, h := range hosts {
+		hostGroups := h.Groups
+		if len(hostGroups) == 0 {
+			hostGroups = []zabbix.HostGroup{{Name: "No group"}}
+		}
+		for _, g := range hostGroups {
+			groupNode := groups[g.GroupID]
+			if groupNode == nil {
+				groupNode = &TreeNode{
+					ID:        "group:" + g.GroupID,
+					Name:      g.Name,
+					Type:      NodeTypeGroup,
+					Collapsed: true, // Start collapsed
+					GroupID:   g.GroupID,
+					Children:  make([]*TreeNode, 0),
+				}
+				groups[g.GroupID] = groupNode
+				tree.AllNodes[groupNode.ID] = groupNode
+				tree.Roots = append(tree.Roots, groupNode)
+			}
+
+			hostNode := &TreeNode{
+				ID:     groupNode.ID + ":host:" + h.HostID,
+				Name:   h.DisplayName(),
+				Type:   NodeTypeHost,
+				Depth:  1,
+				HostID: h.HostID,
+			}
+			tree.AllNodes[hostNode.ID] = hostNode
+			groupNode.Children = append(groupNode.Children, hostNode)
+		}
+	}
+
+	sort.SliceStable(tree.Roots, func(i, j int) bool {
+		return strings.ToLower(tree.Roots[i].Name) < strings.ToLower(tree.Roots[j].Name)
+	})
+	tree.RebuildFlatList()
+	return tree
+}
+
 // ExtractCategory determines the category for an item based on its key.
 // Returns the matching category prefix or "Other" if no match.
 func ExtractCategory(key string, categories []string) string {
@@ -178,6 +228,8 @@ func FormatCategoryName(prefix string) string {
 		"vfs.fs":      "Filesystem",
 		"net.if":      "Network",
 		"proc":        "Processes",
+		"log":         "Logs",
+		"eventlog":    "Event logs",
 	}
 
 	if name, ok := nameMap[prefix]; ok {
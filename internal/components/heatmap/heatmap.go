@@ -0,0 +1,131 @@
+// Package heatmap renders rows of values over time, such as the hosts of a
+// fleet, as a grid of cells colored by value, one cell a time bucket: a
+// compact way to spot patterns across many hosts in a terminal.
+package heatmap
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// maxLabelWidth is how wide the labels of the rows are at most.
+const maxLabelWidth = 24
+
+// Row is a line of the heatmap with a value per time bucket, NaN where
+// there is none.
+type Row struct {
+	Label string
+	Cells []float64
+}
+
+// Heatmap maps the values of its rows to cells. Levels are the rendered
+// cells from the lowest value, Min, to the highest, Max, and Empty is the
+// cell of a bucket without a value.
+type Heatmap struct {
+	Rows       []Row
+	From, Till time.Time
+	Levels     []string
+	Min, Max   float64
+	Empty      string
+}
+
+// Columns returns how many buckets fit in width beside the labels of the
+// rows, at least one.
+func Columns(labels []string, width int) int {
+	return max(1, width-labelWidth(labels)-1)
+}
+
+// labelWidth returns the width of the widest label, up to maxLabelWidth.
+func labelWidth(labels []string) int {
+	width := 0
+	for _, l := range labels {
+		width = max(width, ansi.StringWidth(l))
+	}
+	return min(width, maxLabelWidth)
+}
+
+// Lines renders a line per row, its label and then its cells, and below
+// them the time range of the buckets.
+func (h Heatmap) Lines(tf format.TimeFormat) []string {
+	labels := make([]string, len(h.Rows))
+	columns := 0
+	for i, r := range h.Rows {
+		labels[i] = r.Label
+		columns = max(columns, len(r.Cells))
+	}
+	width := labelWidth(labels)
+
+	lines := make([]string, 0, len(h.Rows)+1)
+	for _, r := range h.Rows {
+		var b strings.Builder
+		b.WriteString(pad(r.Label, width))
+		b.WriteString(" ")
+		for _, v := range r.Cells {
+			b.WriteString(h.cell(v))
+		}
+		lines = append(lines, b.String())
+	}
+
+	from, till := tf.ClockShort(h.From), tf.ClockShort(h.Till)
+	if h.Till.Sub(h.From) > 24*time.Hour {
+		from, till = tf.Full(h.From), tf.Full(h.Till)
+	}
+	gap := columns - ansi.StringWidth(from) - ansi.StringWidth(till)
+	axis := from + strings.Repeat(" ", max(1, gap)) + till
+	return append(lines, strings.Repeat(" ", width+1)+axis)
+}
+
+// cell returns the rendered cell of a value.
+func (h Heatmap) cell(v float64) string {
+	if math.IsNaN(v) || len(h.Levels) == 0 {
+		return h.Empty
+	}
+	level := 0
+	if h.Max > h.Min {
+		level = int(math.Round((v - h.Min) / (h.Max - h.Min) * float64(len(h.Levels)-1)))
+	}
+	return h.Levels[max(0, min(level, len(h.Levels)-1))]
+}
+
+// Average returns the average of the values of a history in each of n
+// buckets of equal length between from and till, NaN where there is none.
+func Average(history []zabbix.History, from, till time.Time, n int) []float64 {
+	sums := make([]float64, n)
+	counts := make([]int, n)
+	for _, h := range history {
+		if i, ok := Bucket(h.Time(), from, till, n); ok {
+			sums[i] += h.ValueFloat()
+			counts[i]++
+		}
+	}
+	cells := make([]float64, n)
+	for i := range cells {
+		cells[i] = math.NaN()
+		if counts[i] > 0 {
+			cells[i] = sums[i] / float64(counts[i])
+		}
+	}
+	return cells
+}
+
+// Bucket returns which of n buckets of equal length between from and till
+// a time falls in; ok is false if it is outside of them.
+func Bucket(t, from, till time.Time, n int) (int, bool) {
+	if n <= 0 || t.Before(from) || t.After(till) || !till.After(from) {
+		return 0, false
+	}
+	i := int(int64(t.Sub(from)) * int64(n) / int64(till.Sub(from)))
+	return min(i, n-1), true
+}
+
+// pad truncates or pads text to width.
+func pad(text string, width int) string {
+	text = ansi.Truncate(text, width, "…")
+	return text + strings.Repeat(" ", max(0, width-ansi.StringWidth(text)))
+}
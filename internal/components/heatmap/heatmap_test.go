@@ -0,0 +1,58 @@
+package heatmap
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+func TestAverage(t *testing.T) {
+	from := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	till := from.Add(time.Hour)
+	point := func(minute int, value string) zabbix.History {
+		return zabbix.History{Clock: strconv.FormatInt(from.Add(time.Duration(minute)*time.Minute).Unix(), 10), Value: value}
+	}
+	history := []zabbix.History{point(0, "1"), point(10, "3"), point(45, "8"), point(60, "4"), point(90, "100")}
+
+	cells := Average(history, from, till, 4)
+	if cells[0] != 2 || !math.IsNaN(cells[1]) || !math.IsNaN(cells[2]) || cells[3] != 6 {
+		t.Errorf("Average() = %v, want [2 NaN NaN 6]", cells)
+	}
+}
+
+func TestLines(t *testing.T) {
+	from := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	h := Heatmap{
+		Rows: []Row{
+			{Label: "web01", Cells: []float64{0, 5, 10, math.NaN()}},
+			{Label: "a-very-long-host-name-of-the-fleet", Cells: []float64{10, 10, 0, 0}},
+		},
+		From:   from,
+		Till:   from.Add(time.Hour),
+		Levels: []string{"0", "1", "2"},
+		Min:    0,
+		Max:    10,
+		Empty:  ".",
+	}
+	lines := h.Lines(format.TimeFormat{Location: time.UTC})
+	want := []string{
+		"web01                    012.",
+		"a-very-long-host-name-o… 2200",
+	}
+	for i, w := range want {
+		if lines[i] != w {
+			t.Errorf("line %d = %q, want %q", i, lines[i], w)
+		}
+	}
+	if axis := lines[2]; !strings.Contains(axis, "09:00") || !strings.HasSuffix(axis, "10:00") {
+		t.Errorf("axis = %q, want the time range of the buckets", axis)
+	}
+	if n := Columns([]string{"web01"}, 40); n != 34 {
+		t.Errorf("Columns() = %d, want 34", n)
+	}
+}
@@ -3,13 +3,20 @@ package hosts
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	zone "github.com/lrstanley/bubblezone"
 
+	"github.com/harpchad/chotko/internal/components/graphs"
+	"github.com/harpchad/chotko/internal/components/plain"
+	"github.com/harpchad/chotko/internal/components/rowcache"
+	"github.com/harpchad/chotko/internal/query"
 	"github.com/harpchad/chotko/internal/theme"
 	"github.com/harpchad/chotko/internal/zabbix"
 )
@@ -24,70 +31,163 @@ type Model struct {
 	width    int
 	height   int
 	focused  bool
+	stale    string          // Age of outdated data, e.g. "5m"; empty when fresh
+	rows     *rowcache.Cache // Rendered non-selected rows, reset on any change
 
 	// Filter state
-	textFilter string
+	textFilter  string
+	query       *query.Query // Parsed textFilter
+	stateFilter StateFilter
+	sortOrder   SortOrder
+	problems    map[string]zabbix.HostProblems // Active problems by host ID
+
+	// Host group tree, nil in the list layout
+	tree   *graphs.Tree
+	byHost map[string]int // Index in filtered by host ID, for tree rows
 }
 
 // New creates a new hosts list model.
 func New(styles *theme.Styles) Model {
 	return Model{
 		styles: styles,
+		rows:   rowcache.New(),
 	}
 }
 
 // SetSize sets the component dimensions.
 func (m *Model) SetSize(width, height int) {
+	if width != m.width {
+		m.rows.Reset()
+	}
 	m.width = width
 	m.height = height
 }
 
+// Restyle discards rendered rows after the shared styles have changed.
+func (m *Model) Restyle() {
+	m.rows.Reset()
+}
+
 // SetFocused sets the focus state.
 func (m *Model) SetFocused(focused bool) {
 	m.focused = focused
 }
 
+// SetStale marks the data as outdated with the given age, e.g. "5m".
+// Stale rows are dimmed and the age is shown in the header. Pass empty string when fresh.
+func (m *Model) SetStale(age string) {
+	if age != m.stale {
+		m.rows.Reset()
+	}
+	m.stale = age
+}
+
 // SetHosts updates the hosts list.
 func (m *Model) SetHosts(hosts []zabbix.Host) {
 	m.hosts = hosts
 	m.applyFilter()
 }
 
-// SetTextFilter sets the text filter.
+// SetTextFilter sets the text filter, a query such as "host:web*". A filter
+// that isn't a valid query is matched as plain text.
 func (m *Model) SetTextFilter(filter string) {
-	m.textFilter = strings.ToLower(filter)
+	m.textFilter = filter
+	m.query = query.Lenient(filter)
+	m.applyFilter()
+}
+
+// TextFilter returns the text filter.
+func (m Model) TextFilter() string {
+	return m.textFilter
+}
+
+// SetStateFilter limits the list to hosts in an availability state.
+func (m *Model) SetStateFilter(f StateFilter) {
+	m.stateFilter = f
+	m.applyFilter()
+}
+
+// StateFilter returns the availability state filter.
+func (m Model) StateFilter() StateFilter {
+	return m.stateFilter
+}
+
+// SetSortOrder sets the order of the list.
+func (m *Model) SetSortOrder(o SortOrder) {
+	m.sortOrder = o
+	m.applyFilter()
+}
+
+// SortOrder returns the order of the list.
+func (m Model) SortOrder() SortOrder {
+	return m.sortOrder
+}
+
+// SetProblems sets the summary of each host's active problems, by host ID,
+// shown as a badge and used to sort by problem count.
+func (m *Model) SetProblems(problems map[string]zabbix.HostProblems) {
+	m.problems = problems
 	m.applyFilter()
 }
 
 // applyFilter filters hosts based on current filter settings.
 func (m *Model) applyFilter() {
+	m.rows.Reset()
 	m.filtered = nil
 	for _, h := range m.hosts {
-		if m.textFilter != "" {
-			name := strings.ToLower(h.DisplayName())
-			host := strings.ToLower(h.Host)
-			ip := strings.ToLower(m.getHostIP(h))
-			if !strings.Contains(name, m.textFilter) &&
-				!strings.Contains(host, m.textFilter) &&
-				!strings.Contains(ip, m.textFilter) {
-				continue
-			}
+		if !m.stateFilter.match(&h) {
+			continue
+		}
+		if m.textFilter != "" && !m.query.Match(m.record(h)) {
+			continue
 		}
 		m.filtered = append(m.filtered, h)
 	}
+	slices.SortStableFunc(m.filtered, func(a, b zabbix.Host) int {
+		return m.sortOrder.compareHosts(&a, &b, m.problems)
+	})
+	if m.tree != nil {
+		m.buildTree()
+	}
 
 	// Reset cursor if out of bounds
-	if m.cursor >= len(m.filtered) {
-		m.cursor = max(0, len(m.filtered)-1)
+	if m.cursor >= m.rowCount() {
+		m.cursor = max(0, m.rowCount()-1)
 	}
 	m.ensureVisible()
 }
 
-// Selected returns the currently selected host.
+// rowCount returns the number of rows: hosts in the list layout, visible
+// group and host nodes in the tree.
+func (m Model) rowCount() int {
+	if m.tree != nil {
+		return m.tree.VisibleCount()
+	}
+	return len(m.filtered)
+}
+
+// record returns the fields of a host that the text filter matches: its
+// names and IP are searched as text. Hosts have no severity.
+func (m Model) record(h zabbix.Host) *query.Record {
+	return &query.Record{
+		Text:     []string{h.DisplayName(), h.Host, m.getHostIP(h)},
+		Hosts:    []string{h.DisplayName(), h.Host},
+		Severity: -1,
+	}
+}
+
+// Selected returns the currently selected host, or nil on a group row.
 // Returns a pointer to the element in the filtered slice. The pointer remains
 // valid until the next call to SetHosts or filter changes. Callers should
 // not store this pointer long-term.
 func (m Model) Selected() *zabbix.Host {
+	if m.tree != nil {
+		node := m.tree.GetVisibleNode(m.cursor)
+		if node == nil || node.Type != graphs.NodeTypeHost {
+			return nil
+		}
+		return &m.filtered[m.byHost[node.HostID]]
+	}
 	if m.cursor >= 0 && m.cursor < len(m.filtered) {
 		return &m.filtered[m.cursor]
 	}
@@ -121,7 +221,7 @@ func (m *Model) MoveUp() {
 
 // MoveDown moves the cursor down.
 func (m *Model) MoveDown() {
-	if m.cursor < len(m.filtered)-1 {
+	if m.cursor < m.rowCount()-1 {
 		m.cursor++
 		m.ensureVisible()
 	}
@@ -139,8 +239,8 @@ func (m *Model) PageUp() {
 // PageDown moves the cursor down by one page.
 func (m *Model) PageDown() {
 	m.cursor += m.visibleRows()
-	if m.cursor >= len(m.filtered) {
-		m.cursor = len(m.filtered) - 1
+	if m.cursor >= m.rowCount() {
+		m.cursor = m.rowCount() - 1
 	}
 	if m.cursor < 0 {
 		m.cursor = 0
@@ -156,7 +256,7 @@ func (m *Model) GoToTop() {
 
 // GoToBottom moves the cursor to the last item.
 func (m *Model) GoToBottom() {
-	m.cursor = max(0, len(m.filtered)-1)
+	m.cursor = max(0, m.rowCount()-1)
 	m.ensureVisible()
 }
 
@@ -166,7 +266,7 @@ func (m *Model) Scroll(delta int) {
 	if m.offset < 0 {
 		m.offset = 0
 	}
-	maxOffset := len(m.filtered) - m.visibleRows()
+	maxOffset := m.rowCount() - m.visibleRows()
 	if maxOffset < 0 {
 		maxOffset = 0
 	}
@@ -175,19 +275,35 @@ func (m *Model) Scroll(delta int) {
 	}
 }
 
-// FilteredCount returns the number of filtered items.
+// FilteredCount returns the number of rows shown: filtered hosts, or
+// visible nodes in the host group tree.
 func (m Model) FilteredCount() int {
-	return len(m.filtered)
+	return m.rowCount()
 }
 
-// SetCursor sets the cursor to a specific index.
+// SetCursor sets the cursor to a specific row.
 func (m *Model) SetCursor(index int) {
-	if index >= 0 && index < len(m.filtered) {
+	if index >= 0 && index < m.rowCount() {
 		m.cursor = index
 		m.ensureVisible()
 	}
 }
 
+// SelectID moves the cursor to the host with the given host ID, expanding
+// its first group in the tree. It returns false if the host is not shown.
+func (m *Model) SelectID(id string) bool {
+	if m.tree != nil {
+		return m.selectTreeHost(id)
+	}
+	for i, h := range m.filtered {
+		if h.HostID == id {
+			m.SetCursor(i)
+			return true
+		}
+	}
+	return false
+}
+
 // visibleRows returns the number of visible rows.
 func (m Model) visibleRows() int {
 	return m.height - 2 // Account for header and border
@@ -232,12 +348,61 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.GoToTop()
 		case key.Matches(msg, key.NewBinding(key.WithKeys("end", "G"))):
 			m.GoToBottom()
+		case m.tree != nil && key.Matches(msg, key.NewBinding(key.WithKeys("enter", " "))):
+			m.Toggle()
+		case m.tree != nil && key.Matches(msg, key.NewBinding(key.WithKeys("E"))):
+			m.ExpandAll()
+		case m.tree != nil && key.Matches(msg, key.NewBinding(key.WithKeys("C"))):
+			m.CollapseAll()
 		}
 	}
 
 	return m, nil
 }
 
+// PlainView renders the list for screen readers: a summary line, then one
+// line per host around the cursor, within height lines.
+func (m Model) PlainView(height int) string {
+	total, filtered := m.Count()
+	lines := []string{fmt.Sprintf("Hosts: %d of %d hosts", filtered, total)}
+
+	start, end := plain.Window(m.cursor, m.rowCount(), height-1)
+	for i := start; i < end; i++ {
+		if m.tree != nil {
+			lines = append(lines, plain.Item(i == m.cursor, m.plainNode(m.tree.GetVisibleNode(i))...))
+			continue
+		}
+		lines = append(lines, plain.Item(i == m.cursor, m.plainHost(m.filtered[i])...))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// plainHost returns the fields describing a host in the plain view.
+func (m Model) plainHost(h zabbix.Host) []string {
+	var status string
+	switch {
+	case h.Status == zabbix.HostStatusUnmonitored:
+		status = "disabled"
+	case h.InMaintenance():
+		status = "in maintenance"
+	case h.IsAvailable() == 1:
+		status = "available"
+	case h.IsAvailable() == 2:
+		status = "unavailable"
+	default:
+		status = "availability unknown"
+	}
+	group := ""
+	if len(h.Groups) > 0 {
+		group = h.Groups[0].Name
+	}
+	problems := ""
+	if p := m.problems[h.HostID]; p.Count > 0 {
+		problems = fmt.Sprintf("%d problems, worst %s", p.Count, theme.SeverityName(p.Worst))
+	}
+	return []string{h.DisplayName(), status, problems, m.getHostIP(h), group}
+}
+
 // View implements tea.Model.
 func (m Model) View() string {
 	// Handle zero-size case
@@ -255,6 +420,22 @@ func (m Model) View() string {
 	}
 	header += ")"
 	b.WriteString(m.styles.PaneTitle.Render(header))
+	var scope []string
+	if m.stateFilter != StateAll {
+		scope = append(scope, m.stateFilter.Label())
+	}
+	if m.tree != nil {
+		scope = append(scope, "by host group")
+	}
+	if m.sortOrder != SortName {
+		scope = append(scope, "sorted by "+m.sortOrder.String())
+	}
+	if len(scope) > 0 {
+		b.WriteString(m.styles.Subtle.Render(" " + strings.Join(scope, ", ")))
+	}
+	if m.stale != "" {
+		b.WriteString(m.styles.Subtle.Render(" (stale " + m.stale + ")"))
+	}
 	b.WriteString("\n")
 
 	// Calculate visible range
@@ -263,15 +444,11 @@ func (m Model) View() string {
 		visible = 1
 	}
 
-	endIdx := min(m.offset+visible, len(m.filtered))
+	endIdx := min(m.offset+visible, m.rowCount())
 
 	// Render rows
 	for i := m.offset; i < endIdx; i++ {
-		h := m.filtered[i]
-		row := m.renderRow(h, i == m.cursor)
-		// Mark row with zone for mouse click detection
-		rowID := fmt.Sprintf("host_%d", i)
-		b.WriteString(zone.Mark(rowID, row))
+		b.WriteString(m.row(i))
 		if i < endIdx-1 {
 			b.WriteString("\n")
 		}
@@ -291,51 +468,86 @@ func (m Model) View() string {
 	return m.styles.PaneBlurred.Width(m.width).Height(m.height).Render(content)
 }
 
-// getHostIP returns the primary IP address of a host.
+// getHostIP returns the address Zabbix connects to on the primary interface
+// of a host.
 func (m Model) getHostIP(h zabbix.Host) string {
-	for _, iface := range h.Interfaces {
-		if iface.Main == "1" {
-			if iface.IP != "" {
-				return iface.IP
-			}
-			return iface.DNS
-		}
+	if iface := h.MainInterface(); iface != nil {
+		return iface.Address()
 	}
-	if len(h.Interfaces) > 0 {
-		if h.Interfaces[0].IP != "" {
-			return h.Interfaces[0].IP
+	return ""
+}
+
+// row returns the rendered row at index i. Non-selected rows are reused from
+// the cache until the list changes.
+func (m Model) row(i int) string {
+	selected := i == m.cursor
+	var node *graphs.TreeNode
+	cacheKey := ""
+	if m.tree != nil {
+		node = m.tree.GetVisibleNode(i)
+		cacheKey = node.ID
+	}
+	if !selected {
+		if row, ok := m.rows.Get(i, cacheKey); ok {
+			return row
 		}
-		return h.Interfaces[0].DNS
 	}
-	return ""
+
+	var row string
+	switch {
+	case node == nil:
+		row = m.renderRow(m.filtered[i], "", selected)
+	case node.Type == graphs.NodeTypeGroup:
+		row = m.renderGroupRow(node, selected)
+	default:
+		row = m.renderRow(m.filtered[m.byHost[node.HostID]], "    ", selected)
+	}
+	if m.stale != "" && !selected {
+		row = m.styles.Subtle.Render(ansi.Strip(row))
+	}
+	// Mark row with zone for mouse click detection
+	row = zone.Mark(fmt.Sprintf("host_%d", i), row)
+	if !selected {
+		m.rows.Put(i, cacheKey, row)
+	}
+	return row
 }
 
-// renderRow renders a single host row.
-func (m Model) renderRow(h zabbix.Host, selected bool) string {
+// renderRow renders a single host row, indented by indent.
+func (m Model) renderRow(h zabbix.Host, indent string, selected bool) string {
 	// Status indicator based on availability
 	var indicator string
 	var statusStyle lipgloss.Style
 
-	if h.InMaintenance() {
-		indicator = "M"
+	icons := m.styles.StatusIcon
+	switch {
+	case h.Status == zabbix.HostStatusUnmonitored:
+		indicator = icons.Disabled
+		statusStyle = m.styles.Subtle
+	case h.InMaintenance():
+		indicator = icons.Maintenance
 		statusStyle = m.styles.StatusMaint
-	} else {
+	default:
 		switch h.IsAvailable() {
 		case 1: // Available
-			indicator = "+"
+			indicator = icons.Available
 			statusStyle = m.styles.StatusOK
 		case 2: // Unavailable
-			indicator = "!"
+			indicator = icons.Unavailable
 			statusStyle = m.styles.StatusProblem
 		default: // Unknown
-			indicator = "?"
+			indicator = icons.Unknown
 			statusStyle = m.styles.StatusUnknown
 		}
 	}
 
+	// Problem badge
+	badge, worst := m.badge(h)
+	badgeWidth := m.badgeWidth()
+
 	// Host name
 	name := h.DisplayName()
-	nameWidth := m.width - 20 - 18 - 6 // IP width, status width, padding
+	nameWidth := m.width - 20 - 18 - 6 - badgeWidth - 1 - len(indent) // IP width, status width, badge, padding
 	if nameWidth < 10 {
 		nameWidth = 10
 	}
@@ -361,24 +573,47 @@ func (m Model) renderRow(h zabbix.Host, selected bool) string {
 	if selected {
 		// Build plain text row, then apply highlight style to the whole thing
 		// This prevents ANSI code fragmentation from individual column styles
+		badgePadded := badge + strings.Repeat(" ", badgeWidth-ansi.StringWidth(badge))
 		namePadded := fmt.Sprintf("%-*s", nameWidth, name)
 		ipPadded := fmt.Sprintf("%-18s", ip)
 		groupPadded := fmt.Sprintf("%15s", group)
 
-		row := fmt.Sprintf("%s %s %s %s", indicator, namePadded, ipPadded, groupPadded)
+		row := indent + fmt.Sprintf("%s %s %s %s %s", indicator, badgePadded, namePadded, ipPadded, groupPadded)
 		// Pad to full width for consistent highlight
-		if len(row) < m.width-2 {
-			row += strings.Repeat(" ", m.width-2-len(row))
+		if w := ansi.StringWidth(row); w < m.width-2 {
+			row += strings.Repeat(" ", m.width-2-w)
 		}
 		return m.styles.AlertSelected.Render(row)
 	}
 
 	// Normal row rendering
 	statusIcon := statusStyle.Render(indicator)
+	badgeStr := m.styles.AlertSeverity[worst].Width(badgeWidth).Render(badge)
 	nameStr := m.styles.AlertHost.Width(nameWidth).Render(name)
 	ipStr := m.styles.Subtle.Width(18).Render(ip)
 	groupStr := m.styles.Subtle.Width(15).Align(lipgloss.Right).Render(group)
 
-	row := fmt.Sprintf("%s %s %s %s", statusIcon, nameStr, ipStr, groupStr)
+	row := indent + fmt.Sprintf("%s %s %s %s %s", statusIcon, badgeStr, nameStr, ipStr, groupStr)
 	return m.styles.AlertNormal.Width(m.width - 2).Render(row)
 }
+
+// badgeWidth returns the width of the problem badge column: a severity
+// indicator and a count of up to three characters.
+func (m Model) badgeWidth() int {
+	return m.styles.SeverityIconWidth() + 4
+}
+
+// badge returns the problem badge of a host, the indicator of its worst
+// severity and its number of active problems, and that severity. The badge
+// is empty for hosts without problems.
+func (m Model) badge(h zabbix.Host) (badge string, worst int) {
+	p := m.problems[h.HostID]
+	if p.Count == 0 {
+		return "", 0
+	}
+	count := strconv.Itoa(p.Count)
+	if p.Count > 99 {
+		count = "99+"
+	}
+	return m.styles.SeverityIcon[p.Worst] + " " + count, p.Worst
+}
@@ -2,8 +2,10 @@ package hosts
 
 import (
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/charmbracelet/x/ansi"
 	zone "github.com/lrstanley/bubblezone"
 
 	"github.com/harpchad/chotko/internal/theme"
@@ -394,3 +396,162 @@ func TestGoToTopBottom(t *testing.T) {
 		t.Errorf("GoToTop should move to first item (0), got %d", m.cursor)
 	}
 }
+
+func TestStateFilterAndSort(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(80, 20)
+	m.SetHosts([]zabbix.Host{
+		{HostID: "1", Name: "alpha", Status: "0", ActiveAvailable: "1", Groups: []zabbix.HostGroup{{Name: "Web"}}},
+		{HostID: "2", Name: "bravo", Status: "0", ActiveAvailable: "2", Groups: []zabbix.HostGroup{{Name: "DB"}}},
+		{HostID: "3", Name: "charlie", Status: "0", ActiveAvailable: "0"},
+		{HostID: "4", Name: "delta", Status: "0", MaintenanceStatus: "1", ActiveAvailable: "1"},
+		{HostID: "5", Name: "echo", Status: "1"},
+	})
+	order := func() string {
+		var names []string
+		for _, h := range m.filtered {
+			names = append(names, h.Name)
+		}
+		return strings.Join(names, " ")
+	}
+
+	tests := []struct {
+		filter StateFilter
+		want   string
+	}{
+		{StateAll, "alpha bravo charlie delta echo"},
+		{StateProblem, "bravo"},
+		{StateUnknown, "charlie"},
+		{StateMaintenance, "delta"},
+		{StateDisabled, "echo"},
+	}
+	for _, tt := range tests {
+		m.SetStateFilter(tt.filter)
+		if got := order(); got != tt.want {
+			t.Errorf("filter %v shows %q, want %q", tt.filter, got, tt.want)
+		}
+	}
+	if !strings.Contains(m.View(), "disabled") {
+		t.Error("header should show the state filter")
+	}
+	m.SetStateFilter(StateAll)
+
+	sorts := []struct {
+		order SortOrder
+		want  string
+	}{
+		{SortAvailability, "bravo charlie delta alpha echo"},
+		{SortGroup, "charlie delta echo bravo alpha"},
+		{SortProblems, "delta alpha bravo charlie echo"},
+	}
+	m.SetProblems(map[string]zabbix.HostProblems{"1": {Count: 2, Worst: 3}, "4": {Count: 5, Worst: 2}})
+	for _, tt := range sorts {
+		m.SetSortOrder(tt.order)
+		if got := order(); got != tt.want {
+			t.Errorf("sorted by %v: %q, want %q", tt.order, got, tt.want)
+		}
+	}
+
+	if f, ok := ParseStateFilter("maintenance"); !ok || f != StateMaintenance {
+		t.Errorf("ParseStateFilter(maintenance) = %v, %v", f, ok)
+	}
+	if o, ok := ParseSortOrder("group"); !ok || o != SortGroup {
+		t.Errorf("ParseSortOrder(group) = %v, %v", o, ok)
+	}
+}
+
+func TestProblemBadge(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(80, 10)
+	m.SetHosts([]zabbix.Host{
+		{HostID: "1", Name: "alpha", Status: "0", ActiveAvailable: "1"},
+		{HostID: "2", Name: "bravo", Status: "0", ActiveAvailable: "1"},
+		{HostID: "3", Name: "charlie", Status: "0", ActiveAvailable: "1"},
+	})
+	m.SetProblems(map[string]zabbix.HostProblems{"2": {Count: 3, Worst: 4}, "3": {Count: 250, Worst: 5}})
+
+	lines := strings.Split(ansi.Strip(m.View()), "\n")
+	icons := testStyles().SeverityIcon
+	tests := []struct {
+		line int
+		want string
+	}{
+		{2, "alpha"},
+		{3, icons[4] + " 3 "},
+		{4, icons[5] + " 99+"},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(lines[tt.line], tt.want) {
+			t.Errorf("row %q should contain %q", lines[tt.line], tt.want)
+		}
+	}
+	if strings.Contains(lines[2], icons[4]) || strings.Contains(lines[2], icons[5]) {
+		t.Errorf("host without problems should have no badge: %q", lines[2])
+	}
+	if plain := m.PlainView(10); !strings.Contains(plain, "3 problems, worst High") {
+		t.Errorf("plain view should describe the problems:\n%s", plain)
+	}
+}
+
+func TestTreeView(t *testing.T) {
+	t.Parallel()
+
+	web := zabbix.HostGroup{GroupID: "1", Name: "Web"}
+	db := zabbix.HostGroup{GroupID: "2", Name: "Databases"}
+	m := New(testStyles())
+	m.SetSize(80, 12)
+	m.SetHosts([]zabbix.Host{
+		{HostID: "1", Name: "web01", Status: "0", ActiveAvailable: "1", Groups: []zabbix.HostGroup{web}},
+		{HostID: "2", Name: "web02", Status: "0", ActiveAvailable: "2", Groups: []zabbix.HostGroup{web}},
+		{HostID: "3", Name: "db01", Status: "0", ActiveAvailable: "1", Groups: []zabbix.HostGroup{db}},
+	})
+	m.SetCursor(1) // web01
+	m.SetTreeView(true)
+
+	// The selected host stays selected, in its expanded group
+	if h := m.Selected(); h == nil || h.HostID != "1" {
+		t.Fatalf("selected = %v, want web01", h)
+	}
+	if m.FilteredCount() != 4 {
+		t.Errorf("FilteredCount() = %d, want 2 groups and 2 hosts of Web", m.FilteredCount())
+	}
+
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"by host group", "▸ Databases (1)  ▲1", "▾ Web (2)  ▲1 ▼1"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+
+	// Group and host indicators follow the glyph set
+	m.styles.SetGlyphs(theme.Glyphs(theme.GlyphsASCII))
+	m.Restyle()
+	view = ansi.Strip(m.View())
+	for _, want := range []string{"▾ Web (2)  +1 !1", "!       web02"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("ascii view should contain %q:\n%s", want, view)
+		}
+	}
+
+	// Clicking a group collapses it; group rows select no host
+	m.ClickRow(1)
+	if m.Selected() != nil || m.FilteredCount() != 2 {
+		t.Errorf("after collapsing Web: selected %v, %d rows; want a group and 2 rows", m.Selected(), m.FilteredCount())
+	}
+
+	// Refreshing keeps expanded groups
+	m.ExpandAll()
+	m.SetHosts(m.hosts)
+	if m.FilteredCount() != 5 {
+		t.Errorf("FilteredCount() = %d after refresh, want all 5 rows expanded", m.FilteredCount())
+	}
+
+	m.SetTreeView(false)
+	if m.FilteredCount() != 3 {
+		t.Errorf("FilteredCount() = %d in the list, want 3", m.FilteredCount())
+	}
+}
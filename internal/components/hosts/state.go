@@ -0,0 +1,172 @@
+package hosts
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// StateFilter limits the list to hosts in one availability state.
+type StateFilter int
+
+// State filters, in the order the state filter key cycles through them.
+const (
+	StateAll         StateFilter = iota // All hosts
+	StateProblem                        // Only unavailable hosts
+	StateUnknown                        // Only hosts of unknown availability
+	StateMaintenance                    // Only hosts in maintenance
+	StateDisabled                       // Only hosts that are not monitored
+	stateFilterCount
+)
+
+// String returns the name of the filter, as saved in the session.
+func (f StateFilter) String() string {
+	switch f {
+	case StateProblem:
+		return "problem"
+	case StateUnknown:
+		return "unknown"
+	case StateMaintenance:
+		return "maintenance"
+	case StateDisabled:
+		return "disabled"
+	}
+	return "all"
+}
+
+// Label describes the filter for the list header, e.g. "unavailable".
+func (f StateFilter) Label() string {
+	switch f {
+	case StateProblem:
+		return "unavailable"
+	case StateUnknown:
+		return "availability unknown"
+	case StateMaintenance:
+		return "in maintenance"
+	case StateDisabled:
+		return "disabled"
+	}
+	return ""
+}
+
+// Next returns the filter after f, wrapping around.
+func (f StateFilter) Next() StateFilter {
+	return (f + 1) % stateFilterCount
+}
+
+// ParseStateFilter returns the filter with the given name.
+func ParseStateFilter(name string) (StateFilter, bool) {
+	for f := range stateFilterCount {
+		if f.String() == name {
+			return f, true
+		}
+	}
+	return StateAll, false
+}
+
+// match reports whether a host passes the filter.
+func (f StateFilter) match(h *zabbix.Host) bool {
+	return f == StateAll || hostState(h) == f
+}
+
+// hostState returns the state a host is filtered by, the one its row
+// indicator shows. Available hosts are in no state but StateAll.
+func hostState(h *zabbix.Host) StateFilter {
+	switch {
+	case h.Status == zabbix.HostStatusUnmonitored:
+		return StateDisabled
+	case h.InMaintenance():
+		return StateMaintenance
+	}
+	switch h.IsAvailable() {
+	case 1:
+		return StateAll
+	case 2:
+		return StateProblem
+	}
+	return StateUnknown
+}
+
+// SortOrder orders the list.
+type SortOrder int
+
+// Sort orders, in the order the sort key cycles through them.
+const (
+	SortName         SortOrder = iota // By name
+	SortAvailability                  // Unavailable hosts first
+	SortGroup                         // By first host group, then name
+	SortProblems                      // Most problems first
+	sortOrderCount
+)
+
+// String returns the name of the order, as saved in the session.
+func (o SortOrder) String() string {
+	switch o {
+	case SortAvailability:
+		return "availability"
+	case SortGroup:
+		return "group"
+	case SortProblems:
+		return "problems"
+	}
+	return "name"
+}
+
+// Next returns the order after o, wrapping around.
+func (o SortOrder) Next() SortOrder {
+	return (o + 1) % sortOrderCount
+}
+
+// ParseSortOrder returns the order with the given name.
+func ParseSortOrder(name string) (SortOrder, bool) {
+	for o := range sortOrderCount {
+		if o.String() == name {
+			return o, true
+		}
+	}
+	return SortName, false
+}
+
+// availabilityRank orders hosts from the most to the least in need of
+// attention: unavailable, unknown, in maintenance, available, disabled.
+func availabilityRank(h *zabbix.Host) int {
+	switch hostState(h) {
+	case StateProblem:
+		return 0
+	case StateUnknown:
+		return 1
+	case StateMaintenance:
+		return 2
+	case StateDisabled:
+		return 4
+	}
+	return 3
+}
+
+// compareHosts compares two hosts in the order o, falling back to their
+// names. problems holds the active problems by host ID.
+func (o SortOrder) compareHosts(a, b *zabbix.Host, problems map[string]zabbix.HostProblems) int {
+	var c int
+	switch o {
+	case SortAvailability:
+		c = cmp.Compare(availabilityRank(a), availabilityRank(b))
+	case SortGroup:
+		c = strings.Compare(strings.ToLower(firstGroup(a)), strings.ToLower(firstGroup(b)))
+	case SortProblems:
+		pa, pb := problems[a.HostID], problems[b.HostID]
+		c = cmp.Or(cmp.Compare(pb.Count, pa.Count), cmp.Compare(pb.Worst, pa.Worst))
+	}
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(strings.ToLower(a.DisplayName()), strings.ToLower(b.DisplayName()))
+}
+
+// firstGroup returns the name of a host's first group, as shown in its row.
+func firstGroup(h *zabbix.Host) string {
+	if len(h.Groups) > 0 {
+		return h.Groups[0].Name
+	}
+	return ""
+}
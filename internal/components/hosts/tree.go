@@ -0,0 +1,261 @@
+package hosts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/harpchad/chotko/internal/components/graphs"
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// SetTreeView switches between the list of hosts and the tree of hosts
+// under their host groups.
+func (m *Model) SetTreeView(tree bool) {
+	if tree == (m.tree != nil) {
+		return
+	}
+	selected := ""
+	if h := m.Selected(); h != nil {
+		selected = h.HostID
+	}
+	m.tree = nil
+	if tree {
+		m.tree = graphs.NewTree()
+	}
+	m.cursor = 0
+	m.offset = 0
+	m.applyFilter()
+	if selected != "" {
+		m.SelectID(selected)
+	}
+}
+
+// TreeView reports whether hosts are shown under their host groups.
+func (m Model) TreeView() bool {
+	return m.tree != nil
+}
+
+// buildTree rebuilds the host group tree from the filtered hosts, keeping
+// the expanded groups and the selected node.
+func (m *Model) buildTree() {
+	expanded := make(map[string]bool)
+	for id, node := range m.tree.AllNodes {
+		if !node.Collapsed {
+			expanded[id] = true
+		}
+	}
+	selectedID := ""
+	if node := m.tree.GetVisibleNode(m.cursor); node != nil {
+		selectedID = node.ID
+	}
+
+	m.byHost = make(map[string]int, len(m.filtered))
+	for i, h := range m.filtered {
+		m.byHost[h.HostID] = i
+	}
+	m.tree = graphs.BuildHostGroupTree(m.filtered)
+	for id := range expanded {
+		if node, ok := m.tree.AllNodes[id]; ok {
+			node.Collapsed = false
+		}
+	}
+	m.tree.RebuildFlatList()
+
+	if i := m.tree.FindNodeIndex(selectedID); i >= 0 {
+		m.cursor = i
+	}
+}
+
+// selectTreeHost moves the cursor to a host in the tree, expanding its
+// first group if needed. It returns false if the host is not shown.
+func (m *Model) selectTreeHost(id string) bool {
+	for _, group := range m.tree.Roots {
+		for _, node := range group.Children {
+			if node.HostID != id {
+				continue
+			}
+			if group.Collapsed {
+				m.tree.ToggleNode(group.ID)
+				m.rows.Reset()
+			}
+			m.SetCursor(m.tree.FindNodeIndex(node.ID))
+			return true
+		}
+	}
+	return false
+}
+
+// Toggle expands or collapses the selected group. In a group's host row it
+// collapses the group and selects it.
+func (m *Model) Toggle() {
+	if m.tree == nil {
+		return
+	}
+	node := m.tree.GetVisibleNode(m.cursor)
+	if node == nil {
+		return
+	}
+	if node.Type == graphs.NodeTypeHost {
+		group := m.tree.GetNode(strings.TrimSuffix(node.ID, ":host:"+node.HostID))
+		if group == nil {
+			return
+		}
+		node = group
+	}
+	m.tree.ToggleNode(node.ID)
+	m.rows.Reset()
+	m.SetCursor(m.tree.FindNodeIndex(node.ID))
+}
+
+// ExpandAll expands all groups.
+func (m *Model) ExpandAll() {
+	if m.tree == nil {
+		return
+	}
+	selectedID := ""
+	if node := m.tree.GetVisibleNode(m.cursor); node != nil {
+		selectedID = node.ID
+	}
+	m.tree.ExpandAll()
+	m.rows.Reset()
+	m.SetCursor(m.tree.FindNodeIndex(selectedID))
+}
+
+// CollapseAll collapses all groups.
+func (m *Model) CollapseAll() {
+	if m.tree == nil {
+		return
+	}
+	m.tree.CollapseAll()
+	m.rows.Reset()
+	m.cursor = 0
+	m.offset = 0
+}
+
+// ClickRow selects a row, expanding or collapsing it if it is a group.
+func (m *Model) ClickRow(index int) {
+	if index < 0 || index >= m.rowCount() {
+		return
+	}
+	m.SetCursor(index)
+	if m.tree != nil && m.tree.GetVisibleNode(index).Type == graphs.NodeTypeGroup {
+		m.Toggle()
+	}
+}
+
+// groupSummary is the availability and problems of a group's hosts.
+type groupSummary struct {
+	states   map[StateFilter]int // Hosts by state; available hosts under StateAll
+	problems zabbix.HostProblems
+}
+
+// summarize counts the hosts of a group node by state, and their problems.
+func (m Model) summarize(group *graphs.TreeNode) groupSummary {
+	s := groupSummary{states: make(map[StateFilter]int)}
+	for _, node := range group.Children {
+		h := &m.filtered[m.byHost[node.HostID]]
+		s.states[hostState(h)]++
+		p := m.problems[h.HostID]
+		s.problems.Count += p.Count
+		if p.Count > 0 {
+			s.problems.Worst = max(s.problems.Worst, p.Worst)
+		}
+	}
+	return s
+}
+
+// groupStates lists the host states in the order they are counted in a
+// group row.
+var groupStates = []StateFilter{StateAll, StateProblem, StateUnknown, StateMaintenance, StateDisabled}
+
+// stateIcon returns the indicator of a host state.
+func (m Model) stateIcon(state StateFilter) string {
+	icons := m.styles.StatusIcon
+	switch state {
+	case StateAll:
+		return icons.Available
+	case StateProblem:
+		return icons.Unavailable
+	case StateMaintenance:
+		return icons.Maintenance
+	case StateDisabled:
+		return icons.Disabled
+	}
+	return icons.Unknown
+}
+
+// stateStyle returns the style of a host state indicator.
+func (m Model) stateStyle(state StateFilter) lipgloss.Style {
+	switch state {
+	case StateAll:
+		return m.styles.StatusOK
+	case StateProblem:
+		return m.styles.StatusProblem
+	case StateMaintenance:
+		return m.styles.StatusMaint
+	case StateDisabled:
+		return m.styles.Subtle
+	}
+	return m.styles.StatusUnknown
+}
+
+// renderGroupRow renders a group node: its name and number of hosts, the
+// number of hosts in each state, and the badge of their problems.
+func (m Model) renderGroupRow(node *graphs.TreeNode, selected bool) string {
+	arrow := "▾ "
+	if node.Collapsed {
+		arrow = "▸ "
+	}
+	s := m.summarize(node)
+
+	var counts, plainCounts []string
+	for _, state := range groupStates {
+		if n := s.states[state]; n > 0 {
+			count := fmt.Sprintf("%s%d", m.stateIcon(state), n)
+			plainCounts = append(plainCounts, count)
+			counts = append(counts, m.stateStyle(state).Render(count))
+		}
+	}
+	badge := ""
+	if s.problems.Count > 0 {
+		badge = fmt.Sprintf("  %s %d", m.styles.SeverityIcon[s.problems.Worst], s.problems.Count)
+	}
+	name := fmt.Sprintf("%s (%d)  ", node.Name, len(node.Children))
+
+	if selected {
+		row := arrow + name + strings.Join(plainCounts, " ") + badge
+		if w := ansi.StringWidth(row); w < m.width-2 {
+			row += strings.Repeat(" ", m.width-2-w)
+		}
+		return m.styles.AlertSelected.Render(ansi.Truncate(row, m.width-2, "…"))
+	}
+	row := arrow + m.styles.AlertHost.Render(name) + strings.Join(counts, " ") +
+		m.styles.AlertSeverity[s.problems.Worst].Render(badge)
+	return m.styles.AlertNormal.Width(m.width - 2).Render(ansi.Truncate(row, m.width-2, "…"))
+}
+
+// plainNode returns the fields describing a tree node in the plain view.
+func (m Model) plainNode(node *graphs.TreeNode) []string {
+	if node.Type == graphs.NodeTypeHost {
+		return append(m.plainHost(m.filtered[m.byHost[node.HostID]]), "level 2")
+	}
+	state := "expanded"
+	if node.Collapsed {
+		state = "collapsed"
+	}
+	s := m.summarize(node)
+	counts := []string{
+		fmt.Sprintf("%d available", s.states[StateAll]),
+		fmt.Sprintf("%d unavailable", s.states[StateProblem]),
+		fmt.Sprintf("%d unknown", s.states[StateUnknown]),
+	}
+	problems := ""
+	if s.problems.Count > 0 {
+		problems = fmt.Sprintf("%d problems, worst %s", s.problems.Count, theme.SeverityName(s.problems.Worst))
+	}
+	return []string{node.Name, fmt.Sprintf("%d hosts", len(node.Children)), strings.Join(counts, ", "), problems, state, "level 1"}
+}
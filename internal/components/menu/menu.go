@@ -0,0 +1,173 @@
+// Package menu provides the context menu opened by right-clicking a row: a
+// small popup of actions drawn at the click position.
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/harpchad/chotko/internal/components/overlay"
+	"github.com/harpchad/chotko/internal/theme"
+)
+
+// Item is an action of the menu. Key is the key that runs the same action,
+// shown next to the label.
+type Item struct {
+	Label string
+	Key   string
+}
+
+// SelectedMsg is sent when an item is chosen.
+type SelectedMsg struct {
+	Item Item
+}
+
+// Model represents the context menu component.
+type Model struct {
+	styles  *theme.Styles
+	items   []Item
+	cursor  int
+	x, y    int // Requested top-left corner
+	visible bool
+	width   int // Screen size, to keep the popup on screen
+	height  int
+}
+
+// New creates a new context menu model.
+func New(styles *theme.Styles) Model {
+	return Model{styles: styles}
+}
+
+// SetSize sets the screen size the popup is kept within.
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Show opens the menu with its top-left corner at x, y, or as close to it
+// as fits on screen. A menu without items is not shown.
+func (m *Model) Show(x, y int, items []Item) {
+	m.items = items
+	m.cursor = 0
+	m.x = x
+	m.y = y
+	m.visible = len(items) > 0
+}
+
+// Hide closes the menu.
+func (m *Model) Hide() {
+	m.visible = false
+}
+
+// Visible returns true while the menu is open.
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// Init implements tea.Model.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model. ↑/↓ move, Enter chooses and Esc closes the
+// menu; a left click chooses the item under the mouse, and a click outside
+// the menu closes it.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k", "shift+tab":
+			m.cursor = (m.cursor - 1 + len(m.items)) % len(m.items)
+		case "down", "j", "tab":
+			m.cursor = (m.cursor + 1) % len(m.items)
+		case "enter", " ":
+			return m, m.choose(m.cursor)
+		case "esc", "q":
+			m.Hide()
+		}
+
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionPress && msg.Action != tea.MouseActionRelease {
+			return m, nil
+		}
+		i := m.itemAt(msg.X, msg.Y)
+		switch {
+		case msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionRelease && i >= 0:
+			return m, m.choose(i)
+		case i < 0 && msg.Action == tea.MouseActionPress && m.outside(msg.X, msg.Y):
+			m.Hide()
+		}
+	}
+	return m, nil
+}
+
+// choose closes the menu and returns a command sending the chosen item.
+func (m *Model) choose(i int) tea.Cmd {
+	m.Hide()
+	item := m.items[i]
+	return func() tea.Msg { return SelectedMsg{Item: item} }
+}
+
+// View renders the popup.
+func (m Model) View() string {
+	labelWidth, keyWidth := 0, 0
+	for _, item := range m.items {
+		labelWidth = max(labelWidth, len(item.Label))
+		keyWidth = max(keyWidth, len(item.Key))
+	}
+
+	lines := make([]string, len(m.items))
+	for i, item := range m.items {
+		label := fmt.Sprintf("%-*s  ", labelWidth, item.Label)
+		key := fmt.Sprintf("%-*s", keyWidth, item.Key)
+		if i == m.cursor {
+			lines[i] = m.styles.AlertSelected.Render(label + key)
+		} else {
+			lines[i] = label + m.styles.Subtle.Render(key)
+		}
+	}
+	return m.styles.ModalBox.Padding(0, 1).Render(strings.Join(lines, "\n"))
+}
+
+// Overlay draws the open menu on top of the rendered screen.
+func (m Model) Overlay(base string) string {
+	if !m.visible {
+		return base
+	}
+	x, y := m.position()
+	return overlay.Place(base, m.View(), x, y)
+}
+
+// position returns the top-left corner of the popup, moved left or up
+// from the requested one so that it fits on screen.
+func (m Model) position() (x, y int) {
+	box := m.View()
+	x = max(0, min(m.x, m.width-lipgloss.Width(box)))
+	y = max(0, min(m.y, m.height-lipgloss.Height(box)))
+	return x, y
+}
+
+// itemAt returns the index of the item at a screen position, or -1.
+func (m Model) itemAt(screenX, screenY int) int {
+	x, y := m.position()
+	width := lipgloss.Width(m.View())
+	i := screenY - y - 1 // Below the top border
+	if screenX <= x || screenX >= x+width-1 || i < 0 || i >= len(m.items) {
+		return -1
+	}
+	return i
+}
+
+// outside reports whether a screen position is outside the popup.
+func (m Model) outside(screenX, screenY int) bool {
+	x, y := m.position()
+	box := m.View()
+	return screenX < x || screenX >= x+lipgloss.Width(box) || screenY < y || screenY >= y+lipgloss.Height(box)
+}
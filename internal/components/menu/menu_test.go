@@ -0,0 +1,104 @@
+package menu
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/harpchad/chotko/internal/theme"
+)
+
+func testItems() []Item {
+	return []Item{
+		{Label: "Acknowledge", Key: "a"},
+		{Label: "Edit triggers", Key: "t"},
+		{Label: "Open in browser", Key: "o"},
+	}
+}
+
+func TestKeys(t *testing.T) {
+	t.Parallel()
+
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetSize(80, 24)
+	m.Show(10, 5, testItems())
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Visible() {
+		t.Error("choosing an item should close the menu")
+	}
+	if cmd == nil {
+		t.Fatal("Enter should choose the item")
+	}
+	if got := cmd().(SelectedMsg).Item.Key; got != "o" {
+		t.Errorf("Up from the first item chose %q, want the last item o", got)
+	}
+
+	m.Show(10, 5, testItems())
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Visible() {
+		t.Error("Esc should close the menu")
+	}
+
+	m.Show(10, 5, nil)
+	if m.Visible() {
+		t.Error("a menu without items should not be shown")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	t.Parallel()
+
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetSize(80, 24)
+	m.Show(10, 5, testItems())
+
+	// The right-button release of the click that opened the menu is ignored
+	m, cmd := m.Update(tea.MouseMsg{X: 12, Y: 6, Button: tea.MouseButtonRight, Action: tea.MouseActionRelease})
+	if cmd != nil || !m.Visible() {
+		t.Fatal("a right-button release should leave the menu open")
+	}
+
+	m, cmd = m.Update(tea.MouseMsg{X: 12, Y: 7, Button: tea.MouseButtonLeft, Action: tea.MouseActionRelease})
+	if cmd == nil {
+		t.Fatal("clicking an item should choose it")
+	}
+	if got := cmd().(SelectedMsg).Item.Key; got != "t" {
+		t.Errorf("clicked item %q, want t", got)
+	}
+
+	m.Show(10, 5, testItems())
+	m, _ = m.Update(tea.MouseMsg{X: 60, Y: 20, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if m.Visible() {
+		t.Error("clicking outside the menu should close it")
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	t.Parallel()
+
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetSize(40, 10)
+	base := strings.TrimSuffix(strings.Repeat(strings.Repeat(".", 40)+"\n", 10), "\n")
+	if got := m.Overlay(base); got != base {
+		t.Error("a closed menu should not change the view")
+	}
+
+	// Near the bottom-right corner, the popup moves to stay on screen
+	m.Show(35, 8, testItems())
+	lines := strings.Split(m.Overlay(base), "\n")
+	if len(lines) != 10 {
+		t.Fatalf("overlay has %d lines, want 10", len(lines))
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w != 40 {
+			t.Errorf("line %d width = %d, want 40", i, w)
+		}
+	}
+	if !strings.Contains(ansi.Strip(lines[9]), "╰") || !strings.HasSuffix(ansi.Strip(lines[9]), "╯") {
+		t.Error("popup should end in the bottom-right corner")
+	}
+}
@@ -0,0 +1,162 @@
+package modal
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HelpEntry is one line of the help screen: the keys and what they do.
+type HelpEntry struct {
+	Keys string
+	Desc string
+}
+
+// HelpSection is a titled group of help entries.
+type HelpSection struct {
+	Title   string
+	Entries []HelpEntry
+}
+
+// helpKeyWidth is the width of the key column in the help screen.
+const helpKeyWidth = 14
+
+// helpChrome is the number of lines the help modal uses besides the
+// entries: border, padding, title, search line and footer.
+const helpChrome = 10
+
+// updateHelp handles keys while the help screen is shown: scrolling,
+// searching by action name, and closing.
+func (m *Model) updateHelp(msg tea.KeyMsg) {
+	if m.helpSearching {
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.helpSearching = false
+			m.helpQuery = ""
+		case tea.KeyEnter:
+			m.helpSearching = false
+		case tea.KeyBackspace:
+			if r := []rune(m.helpQuery); len(r) > 0 {
+				m.helpQuery = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			m.helpQuery += string(msg.Runes)
+		}
+		m.helpScroll = 0
+		return
+	}
+
+	page := m.helpPageSize()
+	switch msg.String() {
+	case "esc", "enter", "q", "?":
+		m.Hide()
+	case "/":
+		m.helpSearching = true
+	case "up", "k":
+		m.helpScroll--
+	case "down", "j":
+		m.helpScroll++
+	case "pgup", "ctrl+u":
+		m.helpScroll -= page
+	case "pgdown", "ctrl+d":
+		m.helpScroll += page
+	case "home", "g":
+		m.helpScroll = 0
+	case "end", "G":
+		m.helpScroll = len(m.helpLines())
+	}
+	m.clampHelpScroll()
+}
+
+// helpPageSize returns how many help lines fit on screen.
+// Before the screen size is known, everything is shown.
+func (m Model) helpPageSize() int {
+	if m.screenHeight == 0 {
+		return len(m.helpLines())
+	}
+	return max(5, m.screenHeight-helpChrome)
+}
+
+// clampHelpScroll keeps the scroll offset within the help content.
+func (m *Model) clampHelpScroll() {
+	maxScroll := max(0, len(m.helpLines())-m.helpPageSize())
+	m.helpScroll = max(0, min(m.helpScroll, maxScroll))
+}
+
+// helpLines renders the sections matching the search query, one line per
+// section title, entry, and blank separator.
+func (m Model) helpLines() []string {
+	query := strings.ToLower(strings.TrimSpace(m.helpQuery))
+
+	var lines []string
+	for _, section := range m.helpSections {
+		var entries []string
+		for _, e := range section.Entries {
+			if query != "" &&
+				!strings.Contains(strings.ToLower(e.Desc), query) &&
+				!strings.Contains(strings.ToLower(e.Keys), query) {
+				continue
+			}
+			keyText := m.styles.HelpKey.Width(helpKeyWidth).Render(e.Keys)
+			desc := m.styles.HelpDesc.Render(capitalize(e.Desc))
+			entries = append(entries, "  "+keyText+" "+desc)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, m.styles.Title.Render(section.Title))
+		lines = append(lines, entries...)
+	}
+	return lines
+}
+
+// renderHelp renders the visible part of the help screen with the search
+// line and scroll position.
+func (m Model) renderHelp() string {
+	var b strings.Builder
+
+	switch {
+	case m.helpSearching:
+		b.WriteString(m.styles.HelpKey.Render("/") + " " + m.helpQuery + "█")
+	case m.helpQuery != "":
+		b.WriteString(m.styles.Subtle.Render("Matching: " + m.helpQuery))
+	default:
+		b.WriteString(m.styles.Subtle.Render("Press / to search by action"))
+	}
+	b.WriteString("\n\n")
+
+	lines := m.helpLines()
+	if len(lines) == 0 {
+		b.WriteString(m.styles.Subtle.Render("No matching keys"))
+		b.WriteString("\n")
+	}
+	page := m.helpPageSize()
+	start := min(m.helpScroll, len(lines))
+	end := min(len(lines), start+page)
+	for _, line := range lines[start:end] {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	hint := "↑/↓ scroll · / search · Esc close"
+	if len(lines) > page {
+		hint = fmt.Sprintf("%d-%d of %d · %s", start+1, end, len(lines), hint)
+	}
+	b.WriteString(m.styles.Subtle.Render(hint))
+
+	return b.String()
+}
+
+// capitalize upper-cases the first letter of s.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	return strings.ToUpper(string(r[0])) + string(r[1:])
+}
@@ -2,6 +2,7 @@
 package modal
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -18,6 +19,14 @@ type Type int
 const (
 	TypeError Type = iota
 	TypeHelp
+	TypeText    // Wide, preformatted text such as logs and stats
+	TypeConfirm // Wide, preformatted text asking for a yes or no
+)
+
+// Default modal dimensions.
+const (
+	defaultWidth  = 60
+	defaultHeight = 15
 )
 
 // Model represents a modal dialog component.
@@ -32,14 +41,20 @@ type Model struct {
 	height       int
 	screenWidth  int
 	screenHeight int
+
+	// Help screen state
+	helpSections  []HelpSection
+	helpScroll    int
+	helpQuery     string
+	helpSearching bool
 }
 
 // New creates a new modal model.
 func New(styles *theme.Styles) Model {
 	return Model{
 		styles: styles,
-		width:  60,
-		height: 15,
+		width:  defaultWidth,
+		height: defaultHeight,
 	}
 }
 
@@ -56,6 +71,7 @@ func (m *Model) Show(modalType Type, title, message string) {
 	m.title = title
 	m.message = message
 	m.details = ""
+	m.width = defaultWidth
 }
 
 // ShowError displays an error modal.
@@ -66,13 +82,17 @@ func (m *Model) ShowError(title, message string, err error) {
 	}
 }
 
-// ShowHelp displays the help modal.
-func (m *Model) ShowHelp() {
+// ShowHelp displays the help modal listing the given sections.
+func (m *Model) ShowHelp(sections []HelpSection) {
 	m.visible = true
 	m.modalType = TypeHelp
 	m.title = "Keyboard Shortcuts"
-	m.width = 50
+	m.width = 56
 	m.height = 24
+	m.helpSections = sections
+	m.helpScroll = 0
+	m.helpQuery = ""
+	m.helpSearching = false
 }
 
 // ShowMessage displays a simple message modal.
@@ -80,6 +100,34 @@ func (m *Model) ShowMessage(title, message string) {
 	m.Show(TypeError, title, message)
 }
 
+// ShowText displays preformatted lines in a wide modal, such as a log view.
+// When there are more lines than fit on screen, the most recent (last) lines are shown.
+func (m *Model) ShowText(title string, lines []string) {
+	m.Show(TypeText, title, "")
+	m.width = max(defaultWidth, m.screenWidth-8)
+
+	// Leave room for the border, title, and button hint
+	if maxLines := m.screenHeight - 10; maxLines > 0 && len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+	m.message = strings.Join(lines, "\n")
+}
+
+// ShowConfirm displays preformatted lines asking for a yes or no, such as
+// the changes an action would make. The caller handles the answer. When
+// there are more lines than fit on screen, the first ones are shown.
+func (m *Model) ShowConfirm(title string, lines []string) {
+	m.Show(TypeConfirm, title, "")
+	m.width = max(defaultWidth, m.screenWidth-8)
+
+	// Leave room for the border, title, and button hint
+	if maxLines := m.screenHeight - 10; maxLines > 1 && len(lines) > maxLines {
+		more := len(lines) - maxLines + 1
+		lines = append(lines[:maxLines-1:maxLines-1], fmt.Sprintf("... %d more lines", more))
+	}
+	m.message = strings.Join(lines, "\n")
+}
+
 // Hide hides the modal.
 func (m *Model) Hide() {
 	m.visible = false
@@ -102,6 +150,10 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	}
 
 	if msg, ok := msg.(tea.KeyMsg); ok {
+		if m.modalType == TypeHelp {
+			m.updateHelp(msg)
+			return m, nil
+		}
 		if key.Matches(msg, key.NewBinding(key.WithKeys("esc", "enter", "q"))) {
 			m.Hide()
 		}
@@ -126,7 +178,11 @@ func (m Model) View() string {
 		content.WriteString(m.renderHelp())
 	} else {
 		// Message
-		content.WriteString(m.styles.ModalText.Render(m.message))
+		if m.modalType == TypeText || m.modalType == TypeConfirm {
+			content.WriteString(m.message)
+		} else {
+			content.WriteString(m.styles.ModalText.Render(m.message))
+		}
 
 		// Details (for errors)
 		if m.details != "" {
@@ -136,9 +192,15 @@ func (m Model) View() string {
 
 		// Button hint
 		content.WriteString("\n\n")
-		content.WriteString(m.styles.ModalButton.Render(" OK "))
-		content.WriteString("  ")
-		content.WriteString(m.styles.Subtle.Render("(Press Enter or Esc)"))
+		if m.modalType == TypeConfirm {
+			content.WriteString(m.styles.ModalButton.Render(" Yes "))
+			content.WriteString("  ")
+			content.WriteString(m.styles.Subtle.Render("(Press y, or n or Esc to cancel)"))
+		} else {
+			content.WriteString(m.styles.ModalButton.Render(" OK "))
+			content.WriteString("  ")
+			content.WriteString(m.styles.Subtle.Render("(Press Enter or Esc)"))
+		}
 	}
 
 	// Create modal box
@@ -153,96 +215,3 @@ func (m Model) View() string {
 		box,
 	)
 }
-
-// renderHelp renders the help content.
-func (m Model) renderHelp() string {
-	var b strings.Builder
-
-	sections := []struct {
-		title string
-		keys  [][]string
-	}{
-		{
-			title: "Navigation",
-			keys: [][]string{
-				{"↑/k", "Move up"},
-				{"↓/j", "Move down"},
-				{"PgUp/Ctrl+U", "Page up"},
-				{"PgDn/Ctrl+D", "Page down"},
-				{"Home/g", "Go to top"},
-				{"End/G", "Go to bottom"},
-			},
-		},
-		{
-			title: "Tabs & Panes",
-			keys: [][]string{
-				{"]/L", "Next tab"},
-				{"[/H", "Previous tab"},
-				{"F1-F3", "Jump to tab"},
-				{"Tab", "Next pane"},
-				{"Shift+Tab", "Previous pane"},
-			},
-		},
-		{
-			title: "Actions",
-			keys: [][]string{
-				{"a", "Acknowledge problem"},
-				{"A", "Acknowledge with message"},
-				{"r", "Refresh data"},
-				{"Enter", "Select/Confirm"},
-			},
-		},
-		{
-			title: "Host Editing (Hosts tab)",
-			keys: [][]string{
-				{"t", "Edit triggers"},
-				{"m", "Edit macros"},
-				{"e", "Enable/disable host"},
-			},
-		},
-		{
-			title: "Alert Ignoring (Alerts tab)",
-			keys: [][]string{
-				{"i", "Ignore alert locally"},
-				{"I", "List ignored alerts"},
-				{":unignore N", "Remove ignore rule"},
-			},
-		},
-		{
-			title: "Filtering",
-			keys: [][]string{
-				{"/", "Filter mode"},
-				{"0-5", "Filter by severity"},
-				{"Ctrl+L", "Clear filter"},
-			},
-		},
-		{
-			title: "General",
-			keys: [][]string{
-				{":", "Command mode"},
-				{"?", "Show this help"},
-				{"Esc", "Cancel/Close"},
-				{"q", "Quit"},
-			},
-		},
-	}
-
-	for i, section := range sections {
-		if i > 0 {
-			b.WriteString("\n")
-		}
-		b.WriteString(m.styles.Title.Render(section.title))
-		b.WriteString("\n")
-
-		for _, kv := range section.keys {
-			keyText := m.styles.HelpKey.Width(14).Render(kv[0])
-			desc := m.styles.HelpDesc.Render(kv[1])
-			b.WriteString("  " + keyText + " " + desc + "\n")
-		}
-	}
-
-	b.WriteString("\n")
-	b.WriteString(m.styles.Subtle.Render("Press Esc to close"))
-
-	return b.String()
-}
@@ -0,0 +1,97 @@
+package modal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/theme"
+)
+
+func testSections() []HelpSection {
+	var entries []HelpEntry
+	for i := range 40 {
+		entries = append(entries, HelpEntry{Keys: fmt.Sprintf("k%d", i), Desc: fmt.Sprintf("action %d", i)})
+	}
+	return []HelpSection{
+		{Title: "Many", Entries: entries},
+		{Title: "Other", Entries: []HelpEntry{{Keys: "z", Desc: "zoom chart"}}},
+	}
+}
+
+func TestHelpScroll(t *testing.T) {
+	t.Parallel()
+
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetScreenSize(80, 20)
+	m.ShowHelp(testSections())
+
+	if view := m.View(); !strings.Contains(view, "Action 0") || strings.Contains(view, "Action 20") {
+		t.Error("expected only the first page of entries")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if m.helpScroll != m.helpPageSize() {
+		t.Errorf("helpScroll = %d after page down, want %d", m.helpScroll, m.helpPageSize())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	if !strings.Contains(m.View(), "Zoom chart") {
+		t.Error("expected the last entry after End")
+	}
+	maxScroll := len(m.helpLines()) - m.helpPageSize()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.helpScroll != maxScroll {
+		t.Errorf("helpScroll = %d, want it clamped to %d", m.helpScroll, maxScroll)
+	}
+}
+
+func TestHelpSearch(t *testing.T) {
+	t.Parallel()
+
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetScreenSize(80, 20)
+	m.ShowHelp(testSections())
+
+	for _, r := range "/zoom" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	view := m.View()
+	if !strings.Contains(view, "Zoom chart") || strings.Contains(view, "Action 1") {
+		t.Error("search should show only matching entries")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.Visible() || m.helpQuery != "zoom" {
+		t.Error("Enter should keep the filter and leave the help open")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Visible() {
+		t.Error("Esc should close the help outside the search box")
+	}
+}
+
+func TestShowConfirm(t *testing.T) {
+	t.Parallel()
+
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetScreenSize(80, 20)
+	var lines []string
+	for i := range 30 {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	m.ShowConfirm("Import", lines)
+
+	view := m.View()
+	for _, want := range []string{"line 0", "line 8", "... 21 more lines", "Yes"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("confirm view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "line 9") {
+		t.Error("expected the lines past the screen to be left out")
+	}
+}
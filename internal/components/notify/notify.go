@@ -0,0 +1,173 @@
+// Package notify provides the notification drawer showing the results of
+// actions as transient messages, and the history of recent messages.
+package notify
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/harpchad/chotko/internal/components/overlay"
+	"github.com/harpchad/chotko/internal/theme"
+)
+
+// Level is the kind of a notification.
+type Level int
+
+// Notification levels.
+const (
+	Info Level = iota
+	Success
+	Error
+)
+
+const (
+	// MaxHistory is the number of notifications kept in the history.
+	MaxHistory = 50
+
+	// maxShown is the number of notifications shown in the drawer at once.
+	maxShown = 3
+
+	// maxWidth is the widest the drawer gets.
+	maxWidth = 60
+)
+
+// Durations the notifications stay in the drawer; errors stay longer.
+const (
+	Duration      = 4 * time.Second
+	ErrorDuration = 10 * time.Second
+)
+
+// Notification is a message about the result of an action.
+type Notification struct {
+	ID    int
+	Time  time.Time
+	Level Level
+	Text  string
+}
+
+// ExpireMsg is sent when a notification should leave the drawer.
+type ExpireMsg struct {
+	ID int
+}
+
+// Model represents the notification drawer.
+type Model struct {
+	styles  *theme.Styles
+	width   int
+	height  int
+	history []Notification // Oldest first
+	shown   []Notification // In the drawer, oldest first
+	nextID  int
+}
+
+// New creates a new notification drawer.
+func New(styles *theme.Styles) Model {
+	return Model{styles: styles}
+}
+
+// SetSize sets the size of the screen the drawer is drawn on.
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Push adds a notification to the drawer and the history. The returned
+// command expires it after a few seconds.
+func (m *Model) Push(level Level, text string, now time.Time) tea.Cmd {
+	m.nextID++
+	n := Notification{ID: m.nextID, Time: now, Level: level, Text: text}
+
+	m.history = append(m.history, n)
+	if len(m.history) > MaxHistory {
+		m.history = m.history[len(m.history)-MaxHistory:]
+	}
+	m.shown = append(m.shown, n)
+	if len(m.shown) > maxShown {
+		m.shown = m.shown[len(m.shown)-maxShown:]
+	}
+
+	duration := Duration
+	if level == Error {
+		duration = ErrorDuration
+	}
+	id := n.ID
+	return tea.Tick(duration, func(time.Time) tea.Msg {
+		return ExpireMsg{ID: id}
+	})
+}
+
+// Expire removes a notification from the drawer. It stays in the history.
+func (m *Model) Expire(id int) {
+	for i, n := range m.shown {
+		if n.ID == id {
+			m.shown = append(m.shown[:i:i], m.shown[i+1:]...)
+			return
+		}
+	}
+}
+
+// Dismiss removes all notifications from the drawer.
+func (m *Model) Dismiss() {
+	m.shown = nil
+}
+
+// Shown returns the notifications in the drawer, oldest first.
+func (m Model) Shown() []Notification {
+	return m.shown
+}
+
+// History returns the recent notifications, oldest first.
+func (m Model) History() []Notification {
+	return m.history
+}
+
+// style returns the style of a notification level.
+func (m Model) style(level Level) lipgloss.Style {
+	switch level {
+	case Success:
+		return m.styles.StatusOK
+	case Error:
+		return m.styles.StatusProblem
+	}
+	return m.styles.ModalText
+}
+
+// View renders the drawer, empty when there are no notifications.
+func (m Model) View() string {
+	if len(m.shown) == 0 {
+		return ""
+	}
+	width := maxWidth
+	if m.width > 0 {
+		width = max(10, min(maxWidth, m.width-4))
+	}
+	lines := make([]string, 0, len(m.shown))
+	for _, n := range m.shown {
+		lines = append(lines, m.style(n.Level).Render(ansi.Truncate(n.Text, width, "…")))
+	}
+	border := m.styles.PaneBlurred.GetBorderTopForeground()
+	if m.shown[len(m.shown)-1].Level == Error {
+		border = m.styles.StatusProblem.GetForeground()
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
+
+// Overlay draws the drawer in the bottom right corner of a rendered screen,
+// above its last line.
+func (m Model) Overlay(base string) string {
+	box := m.View()
+	if box == "" {
+		return base
+	}
+	x := max(0, m.width-lipgloss.Width(box)-1)
+	y := max(0, m.height-lipgloss.Height(box)-1)
+	return overlay.Place(base, box, x, y)
+}
@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/harpchad/chotko/internal/theme"
+)
+
+func testModel() Model {
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetSize(100, 30)
+	return m
+}
+
+func TestPushAndExpire(t *testing.T) {
+	t.Parallel()
+
+	m := testModel()
+	if m.View() != "" {
+		t.Error("an empty drawer should not be shown")
+	}
+
+	now := time.Now()
+	cmd := m.Push(Success, "Acknowledged 3 problems", now)
+	if cmd == nil {
+		t.Fatal("Push() should return the command expiring the notification")
+	}
+	m.Push(Error, "Could not update the macro: permission denied", now)
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"Acknowledged 3 problems", "permission denied"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() should contain %q:\n%s", want, view)
+		}
+	}
+
+	first := m.Shown()[0].ID
+	m.Expire(first)
+	if len(m.Shown()) != 1 || strings.Contains(m.View(), "Acknowledged") {
+		t.Errorf("Shown() = %v after expiring the first notification", m.Shown())
+	}
+	if len(m.History()) != 2 {
+		t.Errorf("History() has %d notifications, want expired ones kept", len(m.History()))
+	}
+	m.Dismiss()
+	if m.View() != "" {
+		t.Error("Dismiss() should empty the drawer")
+	}
+}
+
+func TestLimits(t *testing.T) {
+	t.Parallel()
+
+	m := testModel()
+	for i := range MaxHistory + 10 {
+		m.Push(Info, fmt.Sprintf("message %d", i), time.Now())
+	}
+	history := m.History()
+	if len(history) != MaxHistory || history[0].Text != "message 10" || history[MaxHistory-1].Text != "message 59" {
+		t.Errorf("History() = %d from %q, want the last %d", len(history), history[0].Text, MaxHistory)
+	}
+	shown := m.Shown()
+	if len(shown) != maxShown || shown[maxShown-1].Text != "message 59" {
+		t.Errorf("Shown() = %v, want the last %d", shown, maxShown)
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	t.Parallel()
+
+	m := testModel()
+	base := strings.TrimSuffix(strings.Repeat(strings.Repeat(".", 100)+"\n", 30), "\n")
+	if m.Overlay(base) != base {
+		t.Error("Overlay() without notifications should return the screen unchanged")
+	}
+
+	m.Push(Success, "Macro updated", time.Now())
+	lines := strings.Split(ansi.Strip(m.Overlay(base)), "\n")
+	if len(lines) != 30 {
+		t.Fatalf("Overlay() has %d lines, want 30", len(lines))
+	}
+	if !strings.Contains(lines[27], "Macro updated") || !strings.HasSuffix(lines[27], "│.") {
+		t.Errorf("notification should be in the bottom right corner:\n%s", strings.Join(lines[25:], "\n"))
+	}
+	if lines[29] != base[:100] {
+		t.Error("the last line should stay uncovered")
+	}
+}
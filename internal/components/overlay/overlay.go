@@ -0,0 +1,30 @@
+// Package overlay draws boxes such as callouts and popups on top of a
+// rendered screen.
+package overlay
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Place draws box on top of base with its top-left corner at x, y,
+// keeping the styled content of base to the left and right of the box.
+func Place(base, box string, x, y int) string {
+	lines := strings.Split(base, "\n")
+	for i, boxLine := range strings.Split(box, "\n") {
+		row := y + i
+		for row >= len(lines) {
+			lines = append(lines, "")
+		}
+		line := lines[row]
+		lineWidth := ansi.StringWidth(line)
+		if lineWidth < x {
+			line += strings.Repeat(" ", x-lineWidth)
+		}
+		left := ansi.Truncate(line, x, "")
+		right := ansi.TruncateLeft(line, x+ansi.StringWidth(boxLine), "")
+		lines[row] = left + boxLine + right
+	}
+	return strings.Join(lines, "\n")
+}
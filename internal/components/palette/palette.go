@@ -0,0 +1,288 @@
+// Package palette provides the command palette: a popup listing every action
+// with fuzzy search, the recently used actions first.
+package palette
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/harpchad/chotko/internal/components/overlay"
+	"github.com/harpchad/chotko/internal/theme"
+)
+
+// Entry is an action of the palette.
+type Entry struct {
+	ID    string // Identifies the action in the recently used list
+	Label string
+	Group string // Where the action applies, e.g. "Hosts tab"
+	Key   string // Key or command that runs the same action
+}
+
+// SelectedMsg is sent when an entry is chosen.
+type SelectedMsg struct {
+	Entry Entry
+}
+
+// maxRows is the most entries the popup lists at once.
+const maxRows = 12
+
+// Model represents the command palette component.
+type Model struct {
+	styles  *theme.Styles
+	entries []Entry
+	recent  []string // Entry IDs, most recently used first
+	query   string
+	matches []Entry // Entries matching the query, best first
+	cursor  int
+	offset  int
+	visible bool
+	width   int // Screen size, to size and center the popup
+	height  int
+}
+
+// New creates a new command palette model.
+func New(styles *theme.Styles) Model {
+	return Model{styles: styles}
+}
+
+// SetSize sets the screen size the popup is centered in.
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Show opens the palette with an empty search, listing the entries whose
+// IDs are in recent first, in that order, then the others as given.
+func (m *Model) Show(entries []Entry, recent []string) {
+	m.entries = entries
+	m.recent = recent
+	m.query = ""
+	m.visible = true
+	m.filter()
+}
+
+// Hide closes the palette.
+func (m *Model) Hide() {
+	m.visible = false
+}
+
+// Visible returns true while the palette is open.
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// Query returns the search typed.
+func (m Model) Query() string {
+	return m.query
+}
+
+// Matches returns the entries matching the search, in the order listed.
+func (m Model) Matches() []Entry {
+	return m.matches
+}
+
+// filter lists the entries matching the query: the best matches first,
+// and among equal ones the most recently used.
+func (m *Model) filter() {
+	type scored struct {
+		entry  Entry
+		score  int
+		recent int // Position in the recently used list, or len(recent)
+		index  int
+	}
+	var found []scored
+	for i, e := range m.entries {
+		score, ok := Match(m.query, e.Label+" "+e.Group+" "+e.Key)
+		if !ok {
+			continue
+		}
+		recent := slices.Index(m.recent, e.ID)
+		if recent < 0 {
+			recent = len(m.recent)
+		}
+		found = append(found, scored{entry: e, score: score, recent: recent, index: i})
+	}
+	slices.SortStableFunc(found, func(a, b scored) int {
+		if a.score != b.score {
+			return b.score - a.score
+		}
+		if a.recent != b.recent {
+			return a.recent - b.recent
+		}
+		return a.index - b.index
+	})
+
+	m.matches = make([]Entry, len(found))
+	for i, f := range found {
+		m.matches[i] = f.entry
+	}
+	m.cursor = 0
+	m.offset = 0
+}
+
+// Match reports whether the letters of query appear in text in order,
+// ignoring case and spaces, and scores the match: runs of consecutive
+// letters and letters starting words score higher. Any text matches an
+// empty query with score 0.
+func Match(query, text string) (int, bool) {
+	q := []rune(strings.ToLower(strings.ReplaceAll(query, " ", "")))
+	t := []rune(strings.ToLower(text))
+	if len(q) == 0 {
+		return 0, true
+	}
+
+	// Match from every occurrence of the first letter and keep the best
+	best, found := 0, false
+	for start, r := range t {
+		if r != q[0] {
+			continue
+		}
+		if score, ok := matchFrom(q, t, start); ok && (!found || score > best) {
+			best, found = score, true
+		}
+	}
+	return best, found
+}
+
+// matchFrom matches the letters of q in t from index start, taking each
+// letter at its first occurrence, and scores the match.
+func matchFrom(q, t []rune, start int) (int, bool) {
+	score, run, qi := 0, 0, 0
+	for ti := start; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			run = 0
+			continue
+		}
+		qi++
+		run++
+		score += run
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 3
+		}
+	}
+	return score, qi == len(q)
+}
+
+// Init implements tea.Model.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model. Typing searches, ↑/↓ move, Enter chooses and
+// Esc closes the palette.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !m.visible || !ok {
+		return m, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyEsc, tea.KeyCtrlK, tea.KeyCtrlC:
+		m.Hide()
+	case tea.KeyEnter:
+		if m.cursor < len(m.matches) {
+			m.Hide()
+			entry := m.matches[m.cursor]
+			return m, func() tea.Msg { return SelectedMsg{Entry: entry} }
+		}
+	case tea.KeyUp, tea.KeyCtrlP, tea.KeyShiftTab:
+		m.move(-1)
+	case tea.KeyDown, tea.KeyCtrlN, tea.KeyTab:
+		m.move(1)
+	case tea.KeyPgUp:
+		m.move(-maxRows)
+	case tea.KeyPgDown:
+		m.move(maxRows)
+	case tea.KeyBackspace:
+		if r := []rune(m.query); len(r) > 0 {
+			m.query = string(r[:len(r)-1])
+			m.filter()
+		}
+	case tea.KeyCtrlU:
+		m.query = ""
+		m.filter()
+	case tea.KeyRunes, tea.KeySpace:
+		m.query += string(keyMsg.Runes)
+		m.filter()
+	}
+	return m, nil
+}
+
+// move moves the cursor by delta entries, within the matches.
+func (m *Model) move(delta int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.cursor = max(0, min(len(m.matches)-1, m.cursor+delta))
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+maxRows {
+		m.offset = m.cursor - maxRows + 1
+	}
+}
+
+// View renders the popup.
+func (m Model) View() string {
+	width := max(30, min(80, m.width-8))
+	inner := width - 4 // Border and padding
+
+	var b strings.Builder
+	b.WriteString(m.styles.HelpKey.Render(">") + " " + m.query + "█")
+	b.WriteString("\n\n")
+
+	if len(m.matches) == 0 {
+		b.WriteString(m.styles.Subtle.Render("No matching actions"))
+		b.WriteString("\n")
+	}
+	keyWidth := 0
+	for _, e := range m.matches {
+		keyWidth = max(keyWidth, lipgloss.Width(e.Key))
+	}
+	keyWidth = min(keyWidth, inner/3)
+	end := min(len(m.matches), m.offset+maxRows)
+	for i := m.offset; i < end; i++ {
+		e := m.matches[i]
+		label := e.Label
+		if e.Group != "" {
+			label += " · " + e.Group
+		}
+		label = ansi.Truncate(label, inner-keyWidth-2, "…")
+		key := ansi.Truncate(e.Key, keyWidth, "…")
+		pad := max(0, inner-keyWidth-2-lipgloss.Width(label))
+		if i == m.cursor {
+			line := label + strings.Repeat(" ", pad) + "  " + fmt.Sprintf("%-*s", keyWidth, key)
+			b.WriteString(m.styles.AlertSelected.Render(line))
+		} else {
+			b.WriteString(label + strings.Repeat(" ", pad) + "  " + m.styles.Subtle.Render(key))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	hint := "↑/↓ select · Enter run · Esc close"
+	if len(m.matches) > maxRows {
+		hint = fmt.Sprintf("%d-%d of %d · %s", m.offset+1, end, len(m.matches), hint)
+	}
+	b.WriteString(m.styles.Subtle.Render(hint))
+
+	title := m.styles.ModalTitle.Render("Actions")
+	return m.styles.ModalBox.Width(width).Padding(0, 1).Render(title + "\n" + b.String())
+}
+
+// Overlay draws the open palette on top of the rendered screen, centered
+// horizontally near the top.
+func (m Model) Overlay(base string) string {
+	if !m.visible {
+		return base
+	}
+	box := m.View()
+	x := max(0, (m.width-lipgloss.Width(box))/2)
+	y := max(0, min(m.height/6, m.height-lipgloss.Height(box)))
+	return overlay.Place(base, box, x, y)
+}
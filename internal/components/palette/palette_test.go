@@ -0,0 +1,132 @@
+package palette
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harpchad/chotko/internal/theme"
+)
+
+// testEntries returns a few entries as the app lists them.
+func testEntries() []Entry {
+	return []Entry{
+		{ID: "key:acknowledge", Label: "Acknowledge", Group: "Actions", Key: "a"},
+		{ID: "key:edit triggers", Label: "Edit triggers", Group: "Host editing", Key: "t"},
+		{ID: "cmd:stats", Label: "Show latencies and resource use", Key: ":stats"},
+		{ID: "cmd:queue", Label: "Show items late to be collected, by delay", Key: ":queue"},
+	}
+}
+
+// typeText sends the runes of s to the palette.
+func typeText(m Model, s string) Model {
+	for _, r := range s {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+// ids returns the IDs of entries.
+func ids(entries []Entry) []string {
+	result := make([]string, len(entries))
+	for i, e := range entries {
+		result[i] = e.ID
+	}
+	return result
+}
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query, text string
+		match       bool
+	}{
+		{"", "anything", true},
+		{"ack", "Acknowledge", true},
+		{"edtr", "Edit triggers", true},
+		{"edit trig", "Edit triggers", true},
+		{"zt", "Edit triggers", false},
+		{"xyz", "Acknowledge", false},
+	}
+	for _, tt := range tests {
+		if _, ok := Match(tt.query, tt.text); ok != tt.match {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.query, tt.text, ok, tt.match)
+		}
+	}
+
+	// Word starts and runs beat scattered letters
+	start, _ := Match("st", "Show latencies and resource use :stats")
+	scattered, _ := Match("st", "Edit triggers t")
+	if start <= scattered {
+		t.Errorf("Match scored a word start %d, scattered letters %d", start, scattered)
+	}
+}
+
+func TestRecentFirst(t *testing.T) {
+	t.Parallel()
+
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.Show(testEntries(), []string{"cmd:queue", "key:edit triggers"})
+
+	got := strings.Join(ids(m.Matches()), ",")
+	want := "cmd:queue,key:edit triggers,key:acknowledge,cmd:stats"
+	if got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+}
+
+func TestSearchAndSelect(t *testing.T) {
+	t.Parallel()
+
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetSize(120, 40)
+	m.Show(testEntries(), nil)
+
+	m = typeText(m, "show")
+	if got := ids(m.Matches()); len(got) != 2 {
+		t.Fatalf("matches for show = %v, want the two commands", got)
+	}
+	if view := m.View(); !strings.Contains(view, "> show") || strings.Contains(view, "Acknowledge") {
+		t.Errorf("View() should show the search and only its matches:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Visible() {
+		t.Error("choosing an entry should close the palette")
+	}
+	if cmd == nil {
+		t.Fatal("Enter returned no command")
+	}
+	msg, ok := cmd().(SelectedMsg)
+	if !ok || msg.Entry.ID != "cmd:queue" {
+		t.Errorf("Enter sent %+v, want the second match", msg)
+	}
+}
+
+func TestNoMatches(t *testing.T) {
+	t.Parallel()
+
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.Show(testEntries(), nil)
+	m = typeText(m, "zzz")
+
+	if !strings.Contains(m.View(), "No matching actions") {
+		t.Error("View() should say nothing matches")
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || !m.Visible() {
+		t.Error("Enter without matches should do nothing")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.Query() != "zz" {
+		t.Errorf("Query() after backspace = %q, want zz", m.Query())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Visible() {
+		t.Error("Esc should close the palette")
+	}
+}
@@ -0,0 +1,70 @@
+// Package plain renders lists and text for screen readers: one logical line
+// per item, without box drawing, styling or decorative symbols.
+package plain
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// SelectedPrefix marks the selected item so screen readers announce it.
+const SelectedPrefix = "selected: "
+
+// Item renders one list item as a comma-separated line, skipping empty fields.
+func Item(selected bool, fields ...string) string {
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			parts = append(parts, f)
+		}
+	}
+	line := strings.Join(parts, ", ")
+	if selected {
+		return SelectedPrefix + line
+	}
+	return line
+}
+
+// Window returns the range [start, end) of count items that fit in height
+// lines, centered on the cursor where possible.
+func Window(cursor, count, height int) (start, end int) {
+	height = max(1, height)
+	if count <= height {
+		return 0, count
+	}
+	start = min(max(0, cursor-height/2), count-height)
+	return start, start + height
+}
+
+// Text converts rendered output to plain lines: escape sequences, box drawing,
+// block and geometric symbols and braille charts are removed, runs of spaces
+// are collapsed and blank lines are dropped.
+func Text(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(ansi.Strip(s), "\n") {
+		line = strings.Map(func(r rune) rune {
+			if decorative(r) {
+				return ' '
+			}
+			return r
+		}, line)
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// decorative reports whether r is a drawing symbol that carries no text.
+func decorative(r rune) bool {
+	switch {
+	case r >= 0x2500 && r <= 0x25FF: // Box drawing, block elements, geometric shapes
+		return true
+	case r >= 0x2800 && r <= 0x28FF: // Braille patterns used by charts
+		return true
+	case r == '⟳':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package plain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestItem(t *testing.T) {
+	t.Parallel()
+
+	if got, want := Item(false, "High", " web01 ", "", "CPU high"), "High, web01, CPU high"; got != want {
+		t.Errorf("Item() = %q, want %q", got, want)
+	}
+	if got, want := Item(true, "web01"), "selected: web01"; got != want {
+		t.Errorf("Item(selected) = %q, want %q", got, want)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		cursor, count, height int
+		start, end            int
+	}{
+		{0, 3, 10, 0, 3},   // everything fits
+		{0, 20, 5, 0, 5},   // top
+		{10, 20, 5, 8, 13}, // centered
+		{19, 20, 5, 15, 20},
+		{0, 20, 0, 0, 1}, // at least one line
+	}
+
+	for _, tt := range tests {
+		start, end := Window(tt.cursor, tt.count, tt.height)
+		if start != tt.start || end != tt.end {
+			t.Errorf("Window(%d, %d, %d) = %d, %d, want %d, %d",
+				tt.cursor, tt.count, tt.height, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	t.Parallel()
+
+	in := "╭──────────╮\n│ \x1b[1mHOST\x1b[0m   web01 │\n│          │\n│ ▲ 3 OK ⣿⣀ │\n╰──────────╯"
+	want := []string{"HOST web01", "3 OK"}
+	if got := Text(in); !reflect.DeepEqual(got, want) {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+// Package rowcache caches rendered list rows between frames, so moving the
+// cursor through long lists only re-renders the rows that changed.
+package rowcache
+
+// Cache stores rendered rows by index. Each row is stored with a key holding
+// the parts of the row that change over time (such as a "5m" duration); a
+// lookup with a different key misses so the row is rendered again.
+// Reset must be called whenever the rows, their width or their styling change.
+//
+// A nil *Cache is valid and never caches anything.
+type Cache struct {
+	rows map[int]entry
+}
+
+type entry struct {
+	key string
+	row string
+}
+
+// New creates an empty cache.
+func New() *Cache {
+	return &Cache{rows: make(map[int]entry)}
+}
+
+// Get returns the cached row at index if it was stored with the same key.
+func (c *Cache) Get(index int, key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	e, ok := c.rows[index]
+	if !ok || e.key != key {
+		return "", false
+	}
+	return e.row, true
+}
+
+// Put stores a rendered row.
+func (c *Cache) Put(index int, key, row string) {
+	if c == nil {
+		return
+	}
+	c.rows[index] = entry{key: key, row: row}
+}
+
+// Reset drops all cached rows.
+func (c *Cache) Reset() {
+	if c == nil {
+		return
+	}
+	clear(c.rows)
+}
+
+// Len returns the number of cached rows.
+func (c *Cache) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.rows)
+}
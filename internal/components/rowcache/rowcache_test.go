@@ -0,0 +1,33 @@
+package rowcache
+
+import "testing"
+
+func TestCache(t *testing.T) {
+	c := New()
+
+	if _, ok := c.Get(0, "5m"); ok {
+		t.Fatal("Get() on empty cache should miss")
+	}
+
+	c.Put(0, "5m", "row zero")
+	if row, ok := c.Get(0, "5m"); !ok || row != "row zero" {
+		t.Errorf("Get() = %q, %v; want cached row", row, ok)
+	}
+	if _, ok := c.Get(0, "6m"); ok {
+		t.Error("Get() with a changed key should miss")
+	}
+
+	c.Reset()
+	if c.Len() != 0 {
+		t.Errorf("Len() after Reset() = %d, want 0", c.Len())
+	}
+}
+
+func TestCache_Nil(t *testing.T) {
+	var c *Cache
+	c.Put(0, "", "row")
+	c.Reset()
+	if _, ok := c.Get(0, ""); ok {
+		t.Error("nil cache should never hit")
+	}
+}
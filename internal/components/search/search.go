@@ -0,0 +1,503 @@
+// Package search provides the Search tab, listing the hosts, triggers, items
+// and templates found by a search, grouped by kind.
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+
+	"github.com/harpchad/chotko/internal/components/plain"
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// Kind is the kind of object a result is.
+type Kind int
+
+// Kinds of results, in the order they are listed.
+const (
+	KindHost Kind = iota
+	KindTrigger
+	KindItem
+	KindTemplate
+)
+
+// String returns the heading of the results of a kind.
+func (k Kind) String() string {
+	switch k {
+	case KindTrigger:
+		return "Triggers"
+	case KindItem:
+		return "Items"
+	case KindTemplate:
+		return "Templates"
+	default:
+		return "Hosts"
+	}
+}
+
+// Result is a found object, or a host linked to a found template. Only the
+// field of its kind is set.
+type Result struct {
+	Kind     Kind
+	Host     *zabbix.Host
+	Trigger  *zabbix.Trigger
+	Item     *zabbix.Item
+	Template *zabbix.Template
+	// Linked marks a host listed under an expanded template
+	Linked bool
+}
+
+// OpenMsg is sent when Enter is pressed on a result other than a template,
+// to show it on its tab.
+type OpenMsg struct {
+	Result Result
+}
+
+// row is a line of the list: a heading, or a result.
+type row struct {
+	heading string
+	result  Result
+}
+
+// Model represents the search results list.
+type Model struct {
+	styles   *theme.Styles
+	query    string
+	loading  bool
+	results  *zabbix.SearchResults
+	expanded map[string]bool // Expanded templates by ID
+	rows     []row
+	cursor   int // Index in rows, always of a result
+	offset   int
+	width    int
+	height   int
+	focused  bool
+}
+
+// New creates a new search results model.
+func New(styles *theme.Styles) Model {
+	return Model{
+		styles:   styles,
+		expanded: make(map[string]bool),
+	}
+}
+
+// SetSize sets the component dimensions.
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.ensureVisible()
+}
+
+// SetFocused sets the focus state.
+func (m *Model) SetFocused(focused bool) {
+	m.focused = focused
+}
+
+// SetLoading shows that a search for query is running.
+func (m *Model) SetLoading(query string) {
+	m.query = query
+	m.loading = true
+}
+
+// SetResults shows the results of a search, or none if results is nil.
+// The selection and expanded templates are kept when query is the one shown.
+func (m *Model) SetResults(query string, results *zabbix.SearchResults) {
+	if query != m.query || m.results == nil {
+		m.cursor = 0
+		m.offset = 0
+		clear(m.expanded)
+	}
+	m.query = query
+	m.loading = false
+	m.results = results
+	m.build()
+}
+
+// Query returns the search shown.
+func (m Model) Query() string {
+	return m.query
+}
+
+// build lists the results under a heading for each kind found.
+func (m *Model) build() {
+	m.rows = nil
+	if m.results == nil {
+		return
+	}
+	r := m.results
+	add := func(kind Kind, n int, result func(i int) Result) {
+		if n == 0 {
+			return
+		}
+		m.rows = append(m.rows, row{heading: fmt.Sprintf("%s (%d)", strings.ToUpper(kind.String()), n)})
+		for i := range n {
+			m.rows = append(m.rows, row{result: result(i)})
+		}
+	}
+	add(KindHost, len(r.Hosts), func(i int) Result { return Result{Kind: KindHost, Host: &r.Hosts[i]} })
+	add(KindTrigger, len(r.Triggers), func(i int) Result { return Result{Kind: KindTrigger, Trigger: &r.Triggers[i]} })
+	add(KindItem, len(r.Items), func(i int) Result { return Result{Kind: KindItem, Item: &r.Items[i]} })
+	if len(r.Templates) > 0 {
+		m.rows = append(m.rows, row{heading: fmt.Sprintf("TEMPLATES (%d)", len(r.Templates))})
+		for i := range r.Templates {
+			t := &r.Templates[i]
+			m.rows = append(m.rows, row{result: Result{Kind: KindTemplate, Template: t}})
+			if !m.expanded[t.TemplateID] {
+				continue
+			}
+			for j := range t.Hosts {
+				m.rows = append(m.rows, row{result: Result{Kind: KindHost, Host: &t.Hosts[j], Linked: true}})
+			}
+		}
+	}
+
+	m.cursor = min(m.cursor, max(0, len(m.rows)-1))
+	if m.cursor < len(m.rows) && m.rows[m.cursor].heading != "" {
+		m.move(1)
+	}
+	m.ensureVisible()
+}
+
+// Selected returns the selected result, or nil if there is none. The
+// pointers it holds remain valid until the next search.
+func (m Model) Selected() *Result {
+	if m.cursor >= 0 && m.cursor < len(m.rows) && m.rows[m.cursor].heading == "" {
+		return &m.rows[m.cursor].result
+	}
+	return nil
+}
+
+// Count returns the number of objects found.
+func (m Model) Count() int {
+	if m.results == nil {
+		return 0
+	}
+	return m.results.Count()
+}
+
+// FilteredCount returns the number of rows shown, headings included.
+func (m Model) FilteredCount() int {
+	return len(m.rows)
+}
+
+// Toggle expands the selected template to list its hosts, or collapses it.
+// On one of its hosts it collapses the template and selects it.
+func (m *Model) Toggle() {
+	selected := m.Selected()
+	if selected == nil {
+		return
+	}
+	switch {
+	case selected.Kind == KindTemplate:
+		m.expanded[selected.Template.TemplateID] = !m.expanded[selected.Template.TemplateID]
+	case selected.Linked:
+		for m.rows[m.cursor].result.Kind != KindTemplate {
+			m.cursor--
+		}
+		delete(m.expanded, m.rows[m.cursor].result.Template.TemplateID)
+	default:
+		return
+	}
+	m.build()
+}
+
+// Open expands or collapses the selected template, or returns the command
+// showing the selected object on its tab.
+func (m *Model) Open() tea.Cmd {
+	selected := m.Selected()
+	if selected == nil {
+		return nil
+	}
+	if selected.Kind == KindTemplate {
+		m.Toggle()
+		return nil
+	}
+	result := *selected
+	return func() tea.Msg { return OpenMsg{Result: result} }
+}
+
+// ClickRow selects the result at index; headings can't be selected.
+func (m *Model) ClickRow(index int) {
+	if index < 0 || index >= len(m.rows) || m.rows[index].heading != "" {
+		return
+	}
+	m.cursor = index
+	m.ensureVisible()
+}
+
+// move moves the cursor by delta results, skipping headings. It stays put
+// if there is no result that way.
+func (m *Model) move(delta int) {
+	step := 1
+	if delta < 0 {
+		step, delta = -1, -delta
+	}
+	cursor := m.cursor
+	for ; delta > 0; delta-- {
+		next := cursor + step
+		for next >= 0 && next < len(m.rows) && m.rows[next].heading != "" {
+			next += step
+		}
+		if next < 0 || next >= len(m.rows) {
+			break
+		}
+		cursor = next
+	}
+	m.cursor = cursor
+	m.ensureVisible()
+}
+
+// MoveUp moves the cursor up.
+func (m *Model) MoveUp() {
+	m.move(-1)
+}
+
+// MoveDown moves the cursor down.
+func (m *Model) MoveDown() {
+	m.move(1)
+}
+
+// PageUp moves the cursor up by one page.
+func (m *Model) PageUp() {
+	m.move(-m.visibleRows())
+}
+
+// PageDown moves the cursor down by one page.
+func (m *Model) PageDown() {
+	m.move(m.visibleRows())
+}
+
+// GoToTop moves the cursor to the first result.
+func (m *Model) GoToTop() {
+	m.cursor = 0
+	m.offset = 0
+	if len(m.rows) > 0 && m.rows[0].heading != "" {
+		m.move(1)
+	}
+	m.offset = 0
+}
+
+// GoToBottom moves the cursor to the last result.
+func (m *Model) GoToBottom() {
+	m.move(len(m.rows))
+}
+
+// visibleRows returns the number of visible rows.
+func (m Model) visibleRows() int {
+	return m.height - 2 // Account for header and border
+}
+
+// ensureVisible ensures the cursor is visible in the viewport.
+func (m *Model) ensureVisible() {
+	visible := m.visibleRows()
+	if visible <= 0 {
+		return
+	}
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+		// Keep the heading of the first group in view
+		if m.offset == 1 {
+			m.offset = 0
+		}
+	} else if m.cursor >= m.offset+visible {
+		m.offset = m.cursor - visible + 1
+	}
+}
+
+// Init implements tea.Model.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.focused {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+			m.MoveUp()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+			m.MoveDown()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("pgup", "ctrl+u"))):
+			m.PageUp()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("pgdown", "ctrl+d"))):
+			m.PageDown()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("home", "g"))):
+			m.GoToTop()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("end", "G"))):
+			m.GoToBottom()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			return m, m.Open()
+		case key.Matches(msg, key.NewBinding(key.WithKeys(" "))):
+			m.Toggle()
+		}
+	}
+
+	return m, nil
+}
+
+// header returns the title of the list, with the search and the number of
+// objects found.
+func (m Model) header() string {
+	switch {
+	case m.query == "":
+		return "SEARCH"
+	case m.loading:
+		return fmt.Sprintf("SEARCH %q ...", m.query)
+	}
+	return fmt.Sprintf("SEARCH %q (%d)", m.query, m.Count())
+}
+
+// placeholder returns what the list shows instead of results.
+func (m Model) placeholder() string {
+	switch {
+	case m.query == "":
+		return "Press / or type :search TEXT to search hosts, triggers, items and templates"
+	case m.loading:
+		return "Searching..."
+	case len(m.rows) == 0:
+		return "Nothing found"
+	}
+	return ""
+}
+
+// PlainView renders the list for screen readers: a summary line, then one
+// line per row around the cursor, within height lines.
+func (m Model) PlainView(height int) string {
+	lines := []string{m.header()}
+	if text := m.placeholder(); text != "" {
+		return strings.Join(append(lines, text), "\n")
+	}
+
+	start, end := plain.Window(m.cursor, len(m.rows), height-1)
+	for i := start; i < end; i++ {
+		r := m.rows[i]
+		if r.heading != "" {
+			lines = append(lines, r.heading)
+			continue
+		}
+		lines = append(lines, plain.Item(i == m.cursor, m.fields(r.result)...))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fields returns the name and details of a result, as shown in its row.
+func (m Model) fields(r Result) []string {
+	switch r.Kind {
+	case KindTrigger:
+		return []string{r.Trigger.Description, hostNames(r.Trigger.Hosts), theme.SeverityName(r.Trigger.PriorityInt())}
+	case KindItem:
+		return []string{r.Item.Name, r.Item.HostName(), r.Item.Key}
+	case KindTemplate:
+		marker := "▸ "
+		if m.expanded[r.Template.TemplateID] {
+			marker = "▾ "
+		}
+		return []string{marker + r.Template.Name, fmt.Sprintf("%d hosts", len(r.Template.Hosts))}
+	}
+	name := r.Host.DisplayName()
+	if r.Linked {
+		name = "└ " + name
+	}
+	return []string{name, r.Host.Host}
+}
+
+// hostNames returns the visible names of hosts, comma-separated.
+func hostNames(hosts []zabbix.Host) string {
+	names := make([]string, 0, len(hosts))
+	for i := range hosts {
+		names = append(names, hosts[i].DisplayName())
+	}
+	return strings.Join(names, ", ")
+}
+
+// View implements tea.Model.
+func (m Model) View() string {
+	if m.width < 10 || m.height < 5 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(m.styles.PaneTitle.Render(m.header()))
+	b.WriteString("\n")
+
+	visible := max(1, m.visibleRows())
+	rendered := 0
+	if text := m.placeholder(); text != "" {
+		b.WriteString(m.styles.Subtle.Render("  " + text))
+		rendered = 1
+	} else {
+		end := min(m.offset+visible, len(m.rows))
+		for i := m.offset; i < end; i++ {
+			b.WriteString(m.renderRow(i))
+			if i < end-1 {
+				b.WriteString("\n")
+			}
+		}
+		rendered = end - m.offset
+	}
+	for i := rendered; i < visible; i++ {
+		b.WriteString("\n")
+	}
+
+	content := b.String()
+	if m.focused {
+		return m.styles.PaneFocused.Width(m.width).Height(m.height).Render(content)
+	}
+	return m.styles.PaneBlurred.Width(m.width).Height(m.height).Render(content)
+}
+
+// renderRow renders the row at index i: a heading, or a result with its
+// name and, after it, its host or key.
+func (m Model) renderRow(i int) string {
+	r := m.rows[i]
+	if r.heading != "" {
+		return m.styles.PaneSubtitle.Render(r.heading)
+	}
+
+	fields := m.fields(r.result)
+	name, detail := "  "+fields[0], strings.Join(fields[1:], "  ")
+	nameWidth := max(10, (m.width-2)*3/5)
+	detailWidth := max(0, m.width-2-nameWidth-1)
+	name = truncate(name, nameWidth)
+	detail = truncate(detail, detailWidth)
+
+	var row string
+	if i == m.cursor {
+		row = fmt.Sprintf("%-*s %-*s", nameWidth, name, detailWidth, detail)
+		row = m.styles.AlertSelected.Render(row)
+	} else {
+		nameStyle := m.styles.AlertName
+		if r.result.Kind == KindTrigger {
+			nameStyle = m.styles.AlertSeverity[r.result.Trigger.PriorityInt()]
+		}
+		row = nameStyle.Width(nameWidth).Render(name) + " " + m.styles.AlertHost.Width(detailWidth).Render(detail)
+		row = m.styles.AlertNormal.Width(m.width - 2).Render(row)
+	}
+	return zone.Mark(fmt.Sprintf("search_%d", i), row)
+}
+
+// truncate shortens s to width cells, ending it with "..." if cut.
+func truncate(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	if width <= 3 {
+		return ""
+	}
+	runes := []rune(s)
+	for lipgloss.Width(string(runes)) > width-3 {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
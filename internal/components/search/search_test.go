@@ -0,0 +1,191 @@
+package search
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// TestMain initializes the zone manager for tests that call View().
+func TestMain(m *testing.M) {
+	zone.NewGlobal()
+	os.Exit(m.Run())
+}
+
+// testStyles returns a theme.Styles instance for testing.
+func testStyles() *theme.Styles {
+	return theme.NewStyles(theme.DefaultTheme())
+}
+
+// testResults returns a result of each kind, and a template linked to two
+// hosts.
+func testResults() *zabbix.SearchResults {
+	return &zabbix.SearchResults{
+		Hosts:    []zabbix.Host{{HostID: "10", Host: "web01", Name: "Web 01"}},
+		Triggers: []zabbix.Trigger{{TriggerID: "20", Description: "Web down", Priority: "4", Hosts: []zabbix.Host{{HostID: "10", Host: "web01"}}}},
+		Items:    []zabbix.Item{{ItemID: "30", Name: "Web response time", Key: "web.test.time"}},
+		Templates: []zabbix.Template{{
+			TemplateID: "40", Host: "Template Web", Name: "Template Web",
+			Hosts: []zabbix.Host{{HostID: "10", Host: "web01"}, {HostID: "11", Host: "web02"}},
+		}},
+	}
+}
+
+func TestSetResults(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetResults("web", testResults())
+
+	if m.Query() != "web" {
+		t.Errorf("Query() = %q, want web", m.Query())
+	}
+	if m.Count() != 4 {
+		t.Errorf("Count() = %d, want 4", m.Count())
+	}
+	// Four headings and four results
+	if m.FilteredCount() != 8 {
+		t.Errorf("FilteredCount() = %d, want 8", m.FilteredCount())
+	}
+	selected := m.Selected()
+	if selected == nil || selected.Kind != KindHost || selected.Host.HostID != "10" {
+		t.Fatalf("Selected() = %+v, want the host", selected)
+	}
+}
+
+func TestNavigationSkipsHeadings(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(80, 20)
+	m.SetFocused(true)
+	m.SetResults("web", testResults())
+
+	want := []Kind{KindTrigger, KindItem, KindTemplate, KindTemplate}
+	for _, kind := range want {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		if got := m.Selected().Kind; got != kind {
+			t.Fatalf("after down, Selected().Kind = %v, want %v", got, kind)
+		}
+	}
+
+	m.GoToTop()
+	if m.Selected().Kind != KindHost {
+		t.Errorf("GoToTop selected %v, want the host", m.Selected().Kind)
+	}
+	m.MoveUp()
+	if m.Selected().Kind != KindHost {
+		t.Errorf("MoveUp past the top selected %v, want the host", m.Selected().Kind)
+	}
+	m.GoToBottom()
+	if m.Selected().Kind != KindTemplate {
+		t.Errorf("GoToBottom selected %v, want the template", m.Selected().Kind)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(80, 20)
+	m.SetFocused(true)
+	m.SetResults("web", testResults())
+
+	m.MoveDown()
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter on a trigger returned no command")
+	}
+	msg, ok := cmd().(OpenMsg)
+	if !ok || msg.Result.Kind != KindTrigger || msg.Result.Trigger.TriggerID != "20" {
+		t.Errorf("Enter on a trigger sent %+v, want OpenMsg for the trigger", msg)
+	}
+}
+
+func TestTemplateExpand(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(80, 20)
+	m.SetFocused(true)
+	m.SetResults("web", testResults())
+	m.GoToBottom()
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Error("Enter on a template should expand it, not open it")
+	}
+	if m.FilteredCount() != 10 {
+		t.Fatalf("FilteredCount() after expanding = %d, want 10", m.FilteredCount())
+	}
+
+	m.GoToBottom()
+	selected := m.Selected()
+	if selected.Kind != KindHost || !selected.Linked || selected.Host.HostID != "11" {
+		t.Fatalf("last row = %+v, want linked host 11", selected)
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter on a linked host returned no command")
+	}
+	if msg := cmd().(OpenMsg); msg.Result.Host.HostID != "11" {
+		t.Errorf("opened host %s, want 11", msg.Result.Host.HostID)
+	}
+
+	// Space on a linked host collapses its template
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if m.FilteredCount() != 8 {
+		t.Errorf("FilteredCount() after collapsing = %d, want 8", m.FilteredCount())
+	}
+	if m.Selected().Kind != KindTemplate {
+		t.Errorf("collapsing selected %v, want the template", m.Selected().Kind)
+	}
+}
+
+func TestView(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetSize(100, 20)
+	if view := m.View(); !strings.Contains(view, ":search") {
+		t.Error("View() without a search should explain how to search")
+	}
+
+	m.SetLoading("web")
+	if view := m.View(); !strings.Contains(view, "Searching") {
+		t.Error("View() while loading should say so")
+	}
+
+	m.SetResults("web", testResults())
+	view := m.View()
+	for _, want := range []string{"HOSTS (1)", "TRIGGERS (1)", "ITEMS (1)", "TEMPLATES (1)", "Web down", "web.test.time"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() does not contain %q", want)
+		}
+	}
+
+	m.SetResults("nothing", &zabbix.SearchResults{})
+	if view := m.View(); !strings.Contains(view, "Nothing found") {
+		t.Error("View() without results should say nothing was found")
+	}
+}
+
+func TestPlainView(t *testing.T) {
+	t.Parallel()
+
+	m := New(testStyles())
+	m.SetResults("web", testResults())
+
+	view := m.PlainView(20)
+	for _, want := range []string{`SEARCH "web" (4)`, "TRIGGERS (1)", "Web down"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("PlainView() does not contain %q:\n%s", want, view)
+		}
+	}
+}
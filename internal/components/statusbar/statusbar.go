@@ -3,9 +3,14 @@ package statusbar
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone"
 
 	"github.com/harpchad/chotko/internal/theme"
 	"github.com/harpchad/chotko/internal/zabbix"
@@ -22,9 +27,35 @@ type Model struct {
 	lastUpdate    string
 	minSeverity   int
 	textFilter    string
-	statusMessage string // Temporary status message (takes precedence over filter display)
+	statusMessage string      // Temporary status message (takes precedence over filter display)
+	clock         string      // Current time, empty to hide
+	nextRefresh   string      // Time until next refresh, empty to hide
+	onCall        string      // On-call info, empty to hide
+	banner        string      // Persistent warning banner (replaces the whole bar)
+	muted         int         // Alerts matching mute rules
+	mutedShown    bool        // Muted alerts are shown in the list
+	slaBreaches   int         // Unacknowledged alerts past their SLA
+	severities    map[int]int // Alerts by severity
+	trend         []int       // Alert counts of recent refreshes, oldest first
+	latency       time.Duration
+	latencyLevel  int  // LatencyNormal, LatencySlow or LatencyCritical
+	sound         bool // Sound hooks are configured
+	soundMuted    bool
 }
 
+// Latency levels, coloring the API round-trip time.
+const (
+	LatencyNormal = iota
+	LatencySlow
+	LatencyCritical
+)
+
+// severityAbbrevs are the short severity names of the alert counts.
+var severityAbbrevs = [6]string{"N", "I", "W", "A", "H", "D"}
+
+// sparkRunes are the bars of the alert count sparkline, lowest first.
+var sparkRunes = []rune("▁▂▃▄▅▆▇█")
+
 // New creates a new status bar model.
 func New(styles *theme.Styles) Model {
 	return Model{
@@ -58,6 +89,72 @@ func (m *Model) SetLastUpdate(t string) {
 	m.lastUpdate = t
 }
 
+// SetClock sets the current time string. Pass empty string to hide the clock.
+func (m *Model) SetClock(t string) {
+	m.clock = t
+}
+
+// SetNextRefresh sets the time remaining until the next refresh.
+// Pass empty string to hide the countdown.
+func (m *Model) SetNextRefresh(remaining string) {
+	m.nextRefresh = remaining
+}
+
+// SetOnCall sets the on-call info text, e.g. "alice". Pass empty string to hide it.
+func (m *Model) SetOnCall(text string) {
+	m.onCall = text
+}
+
+// SetBanner sets a persistent warning banner, e.g. while disconnected.
+// The banner replaces the normal status bar content. Pass empty string to clear it.
+func (m *Model) SetBanner(text string) {
+	m.banner = text
+}
+
+// Banner returns the current banner text.
+func (m Model) Banner() string {
+	return m.banner
+}
+
+// SetMuted sets the number of alerts matching mute rules and whether they
+// are shown. Nothing is shown when none are muted.
+func (m *Model) SetMuted(count int, shown bool) {
+	m.muted = count
+	m.mutedShown = shown
+}
+
+// SetLatency sets the API round-trip time measured by the health check and
+// how slow it is. A zero latency hides it.
+func (m *Model) SetLatency(latency time.Duration, level int) {
+	m.latency = latency
+	m.latencyLevel = level
+}
+
+// SetSound sets whether sound hooks are configured and muted. Nothing is
+// shown without hooks.
+func (m *Model) SetSound(configured, muted bool) {
+	m.sound = configured
+	m.soundMuted = muted
+}
+
+// SetSLABreaches sets the number of unacknowledged alerts past their SLA.
+// Nothing is shown when there are none.
+func (m *Model) SetSLABreaches(count int) {
+	m.slaBreaches = count
+}
+
+// SetSeverityCounts sets the number of alerts of each severity. Each
+// count is a click zone named "status_severity_N".
+func (m *Model) SetSeverityCounts(counts map[int]int) {
+	m.severities = counts
+}
+
+// SetTrend sets the alert counts of recent refreshes, oldest first, shown
+// as a sparkline after the alert counts. Fewer than two counts hide it.
+func (m *Model) SetTrend(counts []int) {
+	m.trend = counts
+}
+
 // SetFilter sets the current filter state.
 func (m *Model) SetFilter(minSeverity int, textFilter string) {
 	m.minSeverity = minSeverity
@@ -87,72 +184,254 @@ func (m Model) Update(_ tea.Msg) (Model, tea.Cmd) {
 
 // View implements tea.Model.
 func (m Model) View() string {
+	if m.banner != "" {
+		return m.styles.StatusBanner.Width(m.width).Render(m.banner)
+	}
+
 	// Left side: host status counts
-	var left string
+	var hosts string
 	if m.counts != nil {
-		ok := m.styles.StatusOK.Render(fmt.Sprintf("▲ %d OK", m.counts.OK))
-		problem := m.styles.StatusProblem.Render(fmt.Sprintf("▼ %d Problem", m.counts.Problem))
-		unknown := m.styles.StatusUnknown.Render(fmt.Sprintf("? %d Unknown", m.counts.Unknown))
-		maint := m.styles.StatusMaint.Render(fmt.Sprintf("⚙ %d Maint", m.counts.Maintenance))
-		left = fmt.Sprintf("Hosts: %s │ %s │ %s │ %s", ok, problem, unknown, maint)
+		icons := m.styles.StatusIcon
+		ok := m.styles.StatusOK.Render(fmt.Sprintf("%s %d OK", icons.Available, m.counts.OK))
+		problem := m.styles.StatusProblem.Render(fmt.Sprintf("%s %d Problem", icons.Unavailable, m.counts.Problem))
+		unknown := m.styles.StatusUnknown.Render(fmt.Sprintf("%s %d Unknown", icons.Unknown, m.counts.Unknown))
+		maint := m.styles.StatusMaint.Render(fmt.Sprintf("%s %d Maint", icons.Maintenance, m.counts.Maintenance))
+		hosts = fmt.Sprintf("Hosts: %s │ %s │ %s │ %s", ok, problem, unknown, maint)
 	} else {
-		left = "Hosts: Loading..."
+		hosts = "Hosts: Loading..."
+	}
+	left := []part{{text: hosts, priority: priorityKept}}
+	var severities []string
+	for sev := 5; sev >= 1; sev-- {
+		if m.severities[sev] == 0 {
+			continue
+		}
+		count := fmt.Sprintf("%s:%d", severityAbbrevs[sev], m.severities[sev])
+		severities = append(severities, m.styles.AlertSeverity[sev].Render(zone.Mark(fmt.Sprintf("status_severity_%d", sev), count)))
+	}
+	if len(severities) > 0 {
+		left = append(left, part{text: "Alerts: " + strings.Join(severities, " "), priority: priorityAlerts})
+	}
+	if len(m.trend) >= 2 {
+		// The widest optional part, so the first to go
+		if len(severities) > 0 {
+			left = append(left, part{text: m.trendView(), priority: priorityTrend, attached: true})
+		} else {
+			left = append(left, part{text: "Alerts: " + m.trendView(), priority: priorityTrend})
+		}
+	}
+	if m.muted > 0 {
+		muted := fmt.Sprintf("%d muted", m.muted)
+		if m.mutedShown {
+			muted += " (shown)"
+		}
+		left = append(left, part{text: m.styles.Subtle.Render(muted), priority: priorityMuted})
+	}
+	if m.slaBreaches > 0 {
+		left = append(left, part{text: m.styles.StatusProblem.Render(fmt.Sprintf("%d past SLA", m.slaBreaches)), priority: prioritySLA})
 	}
 
-	// Center: status message or filter indicator (status message takes precedence)
+	// Center: status message or filter indicator (status message takes
+	// precedence). The indicator is a click zone named "status_filter".
 	var center string
 	if m.statusMessage != "" {
 		center = m.styles.StatusFilter.Render(m.statusMessage)
 	} else if m.HasActiveFilter() {
 		var parts []string
-		if m.minSeverity > 0 {
-			severityNames := []string{"", "Info+", "Warn+", "Avg+", "High+", "Disaster"}
-			if m.minSeverity <= 5 {
-				parts = append(parts, severityNames[m.minSeverity])
-			}
+		switch {
+		case m.minSeverity >= 5:
+			parts = append(parts, theme.SeverityName(5))
+		case m.minSeverity > 0:
+			parts = append(parts, theme.SeverityName(m.minSeverity)+"+")
 		}
 		if m.textFilter != "" {
 			parts = append(parts, fmt.Sprintf("%q", m.textFilter))
 		}
 		filterText := "⚡ Filter: " + joinParts(parts, ", ")
-		center = m.styles.StatusFilter.Render(filterText)
+		center = m.styles.StatusFilter.Render(zone.Mark("status_filter", filterText))
 	}
 
 	// Right side: connection status and refresh indicator
-	var right string
+	var right []part
+	if m.onCall != "" {
+		right = append(right, part{text: m.styles.StatusFilter.Render("on-call: " + m.onCall), priority: priorityOnCall})
+	}
 	switch {
 	case m.loading:
-		right = "⟳ Refreshing..."
+		right = append(right, part{text: "⟳ Refreshing...", priority: priorityKept})
 	case m.connected:
-		right = fmt.Sprintf("✓ Zabbix %s", m.version)
+		right = append(right, part{text: fmt.Sprintf("✓ Zabbix %s", m.version), priority: priorityKept})
+		if m.latency > 0 {
+			right = append(right, part{text: m.latencyView(), priority: priorityLatency})
+		}
 		if m.lastUpdate != "" {
-			right += fmt.Sprintf(" │ Updated: %s", m.lastUpdate)
+			right = append(right, part{text: "Updated: " + m.lastUpdate, priority: priorityUpdated})
 		}
 	default:
-		right = "✗ Disconnected"
+		right = append(right, part{text: "✗ Disconnected", priority: priorityKept})
+	}
+	if m.nextRefresh != "" && m.connected && !m.loading {
+		right = append(right, part{text: "Next: " + m.nextRefresh, priority: priorityNext})
 	}
+	if m.sound {
+		if m.soundMuted {
+			right = append(right, part{text: m.styles.StatusProblem.Render("♪ off"), priority: prioritySound})
+		} else {
+			right = append(right, part{text: "♪ on", priority: prioritySound})
+		}
+	}
+	if m.clock != "" {
+		right = append(right, part{text: m.clock, priority: priorityClock})
+	}
+
+	width := 0 // Not sized yet
+	if m.width > 0 {
+		width = max(1, m.width-m.styles.StatusBar.GetHorizontalFrameSize())
+	}
+	return m.styles.StatusBar.Width(m.width).Render(fit(left, center, right, width))
+}
+
+// Priorities of the parts of the bar: when it is too narrow, the parts of
+// the lowest priority are dropped first.
+const (
+	priorityTrend = iota
+	priorityUpdated
+	priorityLatency
+	priorityNext
+	prioritySound
+	priorityMuted
+	priorityOnCall
+	priorityClock
+	priorityAlerts
+	prioritySLA
+	priorityKept // Never dropped
+)
+
+// part is a part of a side of the bar.
+type part struct {
+	text     string
+	priority int
+	attached bool // Follows the previous part after a space
+}
 
-	// Calculate padding
-	leftLen := lipgloss.Width(left)
-	centerLen := lipgloss.Width(center)
-	rightLen := lipgloss.Width(right)
+// minGap is the least space between the sides of the bar and its center.
+const minGap = 2
 
-	// Distribute padding: some before center, some after
-	totalPadding := m.width - leftLen - centerLen - rightLen - 2
-	if totalPadding < 2 {
-		totalPadding = 2
+// fit lays out the sides of the bar and its center in width columns,
+// dropping the parts of the lowest priority until they fit, and truncating
+// the center and then the whole bar if they still don't. A width of 0, before
+// the bar is sized, leaves everything in.
+func fit(left []part, center string, right []part, width int) string {
+	gaps := minGap
+	if center != "" {
+		gaps *= 2
+	}
+	for width > 0 {
+		used := lipgloss.Width(join(left)) + lipgloss.Width(center) + lipgloss.Width(join(right)) + gaps
+		if used <= width {
+			break
+		}
+		var dropped bool
+		if left, right, dropped = dropLowest(left, right); !dropped {
+			if center != "" {
+				center = ansi.Truncate(center, max(0, lipgloss.Width(center)-(used-width)), "…")
+			}
+			break
+		}
 	}
 
+	l, r := join(left), join(right)
+	padding := max(minGap, width-lipgloss.Width(l)-lipgloss.Width(center)-lipgloss.Width(r))
 	var content string
 	if center != "" {
-		leftPad := totalPadding / 2
-		rightPad := totalPadding - leftPad
-		content = left + fmt.Sprintf("%*s", leftPad, "") + center + fmt.Sprintf("%*s", rightPad, "") + right
+		leftPad := padding / 2
+		content = l + strings.Repeat(" ", leftPad) + center + strings.Repeat(" ", padding-leftPad) + r
 	} else {
-		content = left + fmt.Sprintf("%*s", totalPadding, "") + right
+		content = l + strings.Repeat(" ", padding) + r
 	}
+	if width == 0 {
+		return content
+	}
+	return ansi.Truncate(content, width, "…")
+}
 
-	return m.styles.StatusBar.Width(m.width).Render(content)
+// dropLowest removes the part of the lowest priority from either side,
+// preferring the later one. dropped is false if only kept parts are left.
+func dropLowest(left, right []part) (newLeft, newRight []part, dropped bool) {
+	side, index, lowest := -1, -1, priorityKept
+	for s, parts := range [][]part{left, right} {
+		for i, p := range parts {
+			if p.priority <= lowest && p.priority < priorityKept {
+				side, index, lowest = s, i, p.priority
+			}
+		}
+	}
+	switch side {
+	case 0:
+		left = slices.Delete(slices.Clone(left), index, index+1)
+	case 1:
+		right = slices.Delete(slices.Clone(right), index, index+1)
+	default:
+		return left, right, false
+	}
+	return left, right, true
+}
+
+// join joins the parts of a side of the bar.
+func join(parts []part) string {
+	var b strings.Builder
+	for i, p := range parts {
+		switch {
+		case i == 0:
+		case p.attached:
+			b.WriteString(" ")
+		default:
+			b.WriteString(" │ ")
+		}
+		b.WriteString(p.text)
+	}
+	return b.String()
+}
+
+// latencyView renders the API round-trip time, colored when it is slow.
+func (m Model) latencyView() string {
+	text := fmt.Sprintf("%dms", m.latency.Milliseconds())
+	if m.latency >= time.Second {
+		text = fmt.Sprintf("%.1fs", m.latency.Seconds())
+	}
+	switch m.latencyLevel {
+	case LatencySlow:
+		return m.styles.StatusFilter.Render(text)
+	case LatencyCritical:
+		return m.styles.StatusProblem.Render(text)
+	}
+	return text
+}
+
+// trendView renders the alert count sparkline, scaled from zero to its
+// highest count and colored by whether the count rose or fell over it.
+func (m Model) trendView() string {
+	highest := 0
+	for _, n := range m.trend {
+		highest = max(highest, n)
+	}
+	spark := make([]rune, len(m.trend))
+	for i, n := range m.trend {
+		level := 0
+		if highest > 0 {
+			level = n * (len(sparkRunes) - 1) / highest
+		}
+		spark[i] = sparkRunes[level]
+	}
+
+	first, last := m.trend[0], m.trend[len(m.trend)-1]
+	switch {
+	case last > first:
+		return m.styles.StatusProblem.Render(string(spark))
+	case last < first:
+		return m.styles.StatusOK.Render(string(spark))
+	}
+	return m.styles.Subtle.Render(string(spark))
 }
 
 // joinParts joins non-empty strings with a separator.
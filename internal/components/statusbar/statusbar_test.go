@@ -0,0 +1,92 @@
+package statusbar
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone"
+
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// TestMain initializes the zone manager for tests that call View().
+func TestMain(m *testing.M) {
+	zone.NewGlobal()
+	os.Exit(m.Run())
+}
+
+// fullBar returns a status bar with every indicator set.
+func fullBar(width int) Model {
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetWidth(width)
+	m.SetCounts(&zabbix.HostCounts{OK: 1203, Problem: 17, Unknown: 4, Maintenance: 12})
+	m.SetConnected(true, "7.0.12")
+	m.SetLastUpdate("14:02:31")
+	m.SetClock("14:02:45")
+	m.SetNextRefresh("16s")
+	m.SetOnCall("alice")
+	m.SetMuted(3, true)
+	m.SetSLABreaches(2)
+	m.SetSeverityCounts(map[int]int{5: 2, 4: 11, 3: 40, 2: 7, 1: 3})
+	m.SetTrend([]int{50, 55, 61, 58, 63, 60, 62, 63})
+	m.SetLatency(1250*time.Millisecond, LatencySlow)
+	m.SetSound(true, true)
+	return m
+}
+
+func TestViewFitsWidth(t *testing.T) {
+	for _, width := range []int{80, 120, 200} {
+		for _, filtered := range []bool{false, true} {
+			m := fullBar(width)
+			if filtered {
+				m.SetFilter(3, "db")
+			}
+			view := m.View()
+			if h := lipgloss.Height(view); h != 1 {
+				t.Errorf("at %d columns the bar is %d lines, want 1:\n%s", width, h, ansi.Strip(view))
+			}
+			if w := lipgloss.Width(view); w != width {
+				t.Errorf("at %d columns the bar is %d wide", width, w)
+			}
+			text := ansi.Strip(view)
+			for _, kept := range []string{"Hosts:", "✓ Zabbix 7.0.12"} {
+				if !strings.Contains(text, kept) {
+					t.Errorf("at %d columns the bar is missing %q:\n%s", width, kept, text)
+				}
+			}
+		}
+	}
+
+	// The least useful parts go first
+	text := ansi.Strip(fullBar(120).View())
+	if strings.Contains(text, "Updated:") || !strings.Contains(text, "past SLA") {
+		t.Errorf("at 120 columns the bar should drop the last update before the SLA breaches:\n%s", text)
+	}
+	if text := ansi.Strip(fullBar(140).View()); strings.Contains(text, "▆") || !strings.Contains(text, "Alerts: D:2 H:11 A:40 W:7 I:3 │") {
+		t.Errorf("at 140 columns the bar should drop the sparkline before the alert counts:\n%s", text)
+	}
+	if text := ansi.Strip(fullBar(80).View()); strings.Contains(text, "1.2s") || strings.Contains(text, "…") {
+		t.Errorf("at 80 columns the bar should drop the latency rather than truncate it:\n%s", text)
+	}
+	if text := ansi.Strip(fullBar(300).View()); !strings.Contains(text, "Updated: 14:02:31") || !strings.Contains(text, "14:02:45") ||
+		!strings.Contains(text, "I:3 ▆▇▇▇█▇▇█ │") {
+		t.Errorf("a wide bar should show every part:\n%s", text)
+	}
+}
+
+func TestViewTruncatesStatus(t *testing.T) {
+	m := fullBar(80)
+	m.SetStatus(strings.Repeat("a long status message ", 10))
+	view := m.View()
+	if h, w := lipgloss.Height(view), lipgloss.Width(view); h != 1 || w != 80 {
+		t.Errorf("bar with a long status is %dx%d, want 80x1:\n%s", w, h, ansi.Strip(view))
+	}
+	if text := ansi.Strip(view); !strings.Contains(text, "…") || !strings.Contains(text, "Zabbix") {
+		t.Errorf("a long status should be truncated, keeping the connection:\n%s", text)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	zone "github.com/lrstanley/bubblezone"
 
 	"github.com/harpchad/chotko/internal/theme"
@@ -17,6 +18,7 @@ type Model struct {
 	tabs   []string
 	active int
 	width  int
+	label  string // Shown at the right end, e.g. the host group of a kiosk
 }
 
 // New creates a new tab bar model.
@@ -40,6 +42,12 @@ func (m *Model) SetActive(index int) {
 	}
 }
 
+// SetLabel sets the label shown at the right end of the tab bar, or hides
+// it if empty.
+func (m *Model) SetLabel(label string) {
+	m.label = label
+}
+
 // Active returns the current active tab index.
 func (m Model) Active() int {
 	return m.active
@@ -89,5 +97,10 @@ func (m Model) View() string {
 	}
 
 	row := strings.Join(tabs, " ")
+	if m.label != "" {
+		label := m.styles.TabActive.Render(m.label)
+		gap := max(1, m.width-lipgloss.Width(row)-lipgloss.Width(label))
+		row += strings.Repeat(" ", gap) + label
+	}
 	return m.styles.TabBar.Width(m.width).Render(row)
 }
@@ -2,8 +2,10 @@ package tabs
 
 import (
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/charmbracelet/lipgloss"
 	zone "github.com/lrstanley/bubblezone"
 
 	"github.com/harpchad/chotko/internal/theme"
@@ -192,6 +194,25 @@ func TestViewActiveTabHighlighted(t *testing.T) {
 	}
 }
 
+func TestViewLabel(t *testing.T) {
+	m := New(testStyles(), []string{"Tab1", "Tab2"}, 0)
+	m.SetWidth(80)
+
+	m.SetLabel("Linux servers")
+	view := m.View()
+	if !containsString(view, "Linux servers") {
+		t.Error("Expected the label in the tab bar")
+	}
+	if w := lipgloss.Width(strings.Split(view, "\n")[0]); w != 80 {
+		t.Errorf("Expected the label right-aligned to width 80, got %d", w)
+	}
+
+	m.SetLabel("")
+	if containsString(m.View(), "Linux servers") {
+		t.Error("Expected no label once cleared")
+	}
+}
+
 func TestActiveTabOutOfBounds(t *testing.T) {
 	m := New(testStyles(), []string{"Tab1"}, 0)
 	m.active = 10 // Force out of bounds
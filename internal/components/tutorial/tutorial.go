@@ -0,0 +1,173 @@
+// Package tutorial provides the guided tour shown on first launch: a
+// sequence of callouts, each pointing at a part of the screen.
+package tutorial
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/harpchad/chotko/internal/components/overlay"
+	"github.com/harpchad/chotko/internal/theme"
+)
+
+// calloutWidth is the width of the callout box, including its border.
+const calloutWidth = 48
+
+// Target identifies the part of the screen a step points at.
+type Target int
+
+// Target constants for the areas of the main screen.
+const (
+	TargetNone Target = iota // Centered, not pointing at anything
+	TargetStatusBar
+	TargetTabs
+	TargetList
+	TargetDetail
+	TargetCommand
+)
+
+// Step is one stop of the tour.
+type Step struct {
+	Title  string
+	Text   string
+	Target Target
+}
+
+// Region is a rectangle on screen, in cells.
+type Region struct {
+	X, Y          int
+	Width, Height int
+}
+
+// Model represents the tutorial component.
+type Model struct {
+	styles *theme.Styles
+	steps  []Step
+	step   int
+	active bool
+	width  int
+	height int
+}
+
+// New creates a new tutorial model.
+func New(styles *theme.Styles) Model {
+	return Model{styles: styles}
+}
+
+// SetSize sets the screen size the callouts are placed in.
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Start begins the tour at its first step.
+func (m *Model) Start(steps []Step) {
+	m.steps = steps
+	m.step = 0
+	m.active = len(steps) > 0
+}
+
+// Stop ends the tour.
+func (m *Model) Stop() {
+	m.active = false
+}
+
+// Active returns true while the tour is running.
+func (m Model) Active() bool {
+	return m.active
+}
+
+// Current returns the current step. It is only meaningful while active.
+func (m Model) Current() Step {
+	if m.step < len(m.steps) {
+		return m.steps[m.step]
+	}
+	return Step{}
+}
+
+// Update implements tea.Model. Enter, Space and → advance, ← goes back and
+// Esc or q dismisses the tour. Finishing the last step ends it.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !m.active || !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "enter", " ", "right", "l", "n":
+		m.step++
+		if m.step >= len(m.steps) {
+			m.Stop()
+		}
+	case "left", "h", "p", "backspace":
+		m.step = max(0, m.step-1)
+	case "esc", "q":
+		m.Stop()
+	}
+	return m, nil
+}
+
+// Callout renders the box for the current step.
+func (m Model) Callout() string {
+	if !m.active {
+		return ""
+	}
+	step := m.Current()
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render(step.Title))
+	b.WriteString("  ")
+	b.WriteString(m.styles.Subtle.Render(fmt.Sprintf("%d/%d", m.step+1, len(m.steps))))
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.ModalText.Render(step.Text))
+	b.WriteString("\n\n")
+
+	next := "next"
+	if m.step == len(m.steps)-1 {
+		next = "finish"
+	}
+	b.WriteString(m.styles.Subtle.Render(fmt.Sprintf("Enter %s · ← back · Esc skip", next)))
+
+	return m.styles.ModalBox.
+		Padding(0, 1).
+		Width(min(calloutWidth, max(20, m.width-2)) - 2).
+		Render(b.String())
+}
+
+// Overlay draws the callout for the current step on top of the rendered
+// screen, placed inside the target region when it fits and next to it
+// otherwise.
+func (m Model) Overlay(base string, target Region) string {
+	if !m.active {
+		return base
+	}
+	box := m.Callout()
+	boxWidth := lipgloss.Width(box)
+	boxHeight := lipgloss.Height(box)
+
+	var x, y int
+	switch {
+	case m.Current().Target == TargetNone:
+		x = (m.width - boxWidth) / 2
+		y = (m.height - boxHeight) / 2
+	case target.Height >= boxHeight+2:
+		// Inside the pane, below its top border
+		x = target.X + 2
+		y = target.Y + 1
+	case target.Y+target.Height+boxHeight <= m.height:
+		// Below a bar
+		x = target.X + 2
+		y = target.Y + target.Height
+	default:
+		// Above a bar at the bottom of the screen
+		x = target.X + 2
+		y = target.Y - boxHeight
+	}
+	x = max(0, min(x, m.width-boxWidth))
+	y = max(0, y)
+
+	return overlay.Place(base, box, x, y)
+}
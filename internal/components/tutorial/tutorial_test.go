@@ -0,0 +1,92 @@
+package tutorial
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/harpchad/chotko/internal/theme"
+)
+
+func testSteps() []Step {
+	return []Step{
+		{Title: "One", Text: "first", Target: TargetNone},
+		{Title: "Two", Text: "second", Target: TargetList},
+		{Title: "Three", Text: "third", Target: TargetCommand},
+	}
+}
+
+func TestNavigation(t *testing.T) {
+	t.Parallel()
+
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetSize(100, 30)
+	m.Start(testSteps())
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	m, _ = m.Update(enter)
+	if m.Current().Title != "Two" {
+		t.Fatalf("step = %q after Enter, want Two", m.Current().Title)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if m.Current().Title != "One" {
+		t.Fatalf("step = %q after Left, want One", m.Current().Title)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if m.Current().Title != "One" {
+		t.Fatal("Left on the first step should stay there")
+	}
+
+	for range 3 {
+		m, _ = m.Update(enter)
+	}
+	if m.Active() {
+		t.Error("finishing the last step should end the tour")
+	}
+
+	m.Start(testSteps())
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Active() {
+		t.Error("Esc should dismiss the tour")
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	t.Parallel()
+
+	m := New(theme.NewStyles(theme.DefaultTheme()))
+	m.SetSize(80, 24)
+
+	base := strings.TrimSuffix(strings.Repeat(strings.Repeat(".", 80)+"\n", 24), "\n")
+	if got := m.Overlay(base, Region{}); got != base {
+		t.Error("inactive tutorial should not change the view")
+	}
+
+	m.Start(testSteps())
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter}) // List step
+	got := m.Overlay(base, Region{X: 0, Y: 2, Width: 40, Height: 20})
+	lines := strings.Split(got, "\n")
+	if len(lines) != 24 {
+		t.Fatalf("overlay has %d lines, want 24", len(lines))
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w != 80 {
+			t.Errorf("line %d width = %d, want 80", i, w)
+		}
+	}
+	if !strings.Contains(got, "Two") || !strings.Contains(got, "second") {
+		t.Error("overlay should show the current step")
+	}
+	if strings.Contains(lines[2], "Two") || !strings.HasPrefix(ansi.Strip(lines[3]), "..") {
+		t.Error("callout should start inside the pane, right of its border")
+	}
+
+	// A bar at the bottom puts the callout above it
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	lines = strings.Split(m.Overlay(base, Region{Y: 23, Width: 80, Height: 1}), "\n")
+	if ansi.Strip(lines[23]) != strings.Repeat(".", 80) {
+		t.Error("callout should not cover the command bar it points at")
+	}
+}
@@ -5,9 +5,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"slices"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/theme"
 )
 
 // Config represents the application configuration.
@@ -16,18 +23,52 @@ type Config struct {
 	Auth    AuthConfig    `yaml:"auth"`
 	Display DisplayConfig `yaml:"display"`
 	Graphs  GraphsConfig  `yaml:"graphs,omitempty"`
+	Mute    []MuteRule    `yaml:"mute,omitempty"`
+	Kiosk   KioskConfig   `yaml:"kiosk,omitempty"`
+	Hooks   HooksConfig   `yaml:"hooks,omitempty"`
+	Ack     AckConfig     `yaml:"ack,omitempty"`
+	// SLA limits how long problems may stay unacknowledged, by severity
+	// name (see SeverityKeys), e.g. disaster: 15m
+	SLA map[string]string `yaml:"sla,omitempty"`
+	// RoleFilters are default filters by the name of a Zabbix user role or
+	// user group, applied to users with that role or in that group
+	RoleFilters RoleFilters `yaml:"role_filters,omitempty"`
 }
 
 // ServerConfig holds Zabbix server connection settings.
 type ServerConfig struct {
 	URL string `yaml:"url"`
+	// CompressRequests gzips large API requests; the web server must
+	// decompress them
+	CompressRequests bool `yaml:"compress_requests,omitempty"`
+	// Timeout is how long an API call may take, e.g. "30s" (default: 30s)
+	Timeout string `yaml:"timeout,omitempty"`
+	// MethodTimeouts overrides Timeout for API methods, e.g. history.get
+	MethodTimeouts map[string]string `yaml:"method_timeouts,omitempty"`
+	// PingInterval is how often the server's latency is measured, e.g.
+	// "15s" (default: 15s), or "off"
+	PingInterval string `yaml:"ping_interval,omitempty"`
+	// LatencyWarning and LatencyCritical are the round-trip times from which
+	// the latency is shown as slow (default: 500ms and 2s)
+	LatencyWarning  string `yaml:"latency_warning,omitempty"`
+	LatencyCritical string `yaml:"latency_critical,omitempty"`
 }
 
 // AuthConfig holds authentication settings.
 type AuthConfig struct {
+	// Mode is how chotko signs in: with the token or username and password
+	// (default), a session of the frontend, or either of them behind a
+	// proxy requiring HTTP basic auth; see AuthModeSession and AuthModeBasic
+	Mode     string `yaml:"mode,omitempty"`
 	Token    string `yaml:"token"`
 	Username string `yaml:"username"`
 	Password string `yaml:"password"`
+	// SessionID is a session ID of the frontend, or the value of its
+	// zbx_session cookie, used in session mode
+	SessionID string `yaml:"session_id,omitempty"`
+	// Credentials for the proxy in basic mode
+	BasicUsername string `yaml:"basic_username,omitempty"`
+	BasicPassword string `yaml:"basic_password,omitempty"`
 }
 
 // DisplayConfig holds display/UI settings.
@@ -38,6 +79,39 @@ type DisplayConfig struct {
 	WindowTitle      *bool  `yaml:"window_title,omitempty"`       // Enable window/tab title updates (default: true)
 	EmojiTitle       *bool  `yaml:"emoji_title,omitempty"`        // Use emoji in title (default: true), false for text
 	TitleMinSeverity int    `yaml:"title_min_severity,omitempty"` // Minimum severity to show in title (0-5)
+	Layout           string `yaml:"layout,omitempty"`             // Pane layout: auto, side-by-side, or stacked
+	TimeDisplay      string `yaml:"time_display,omitempty"`       // Timestamp display: absolute or relative
+	TimeFormat       string `yaml:"time_format,omitempty"`        // Go layout for absolute timestamps
+	Timezone         string `yaml:"timezone,omitempty"`           // IANA timezone name (default: local)
+	Clock            string `yaml:"clock,omitempty"`              // Clock style: 24h (default) or 12h
+	ShowClock        bool   `yaml:"show_clock,omitempty"`         // Show the current time in the status bar
+	ShowCountdown    bool   `yaml:"show_countdown,omitempty"`     // Show time until the next refresh in the status bar
+	OnCall           string `yaml:"on_call,omitempty"`            // Free-text on-call info shown in the status bar
+	// ServerSearchThreshold is the list size above which text filters are sent
+	// to the API instead of applied in memory (0 = default, negative = never)
+	ServerSearchThreshold int `yaml:"server_search_threshold,omitempty"`
+	// PageSize is how many problems or events are fetched per page (default: 500)
+	PageSize int `yaml:"page_size,omitempty"`
+	// ProblemTrend is how many refreshes the status bar's problem count
+	// sparkline covers (0 = default, negative = hidden)
+	ProblemTrend int `yaml:"problem_trend,omitempty"`
+	// NoColor conveys severity and status with text labels and emphasis instead
+	// of color; also enabled by the NO_COLOR environment variable
+	NoColor bool `yaml:"no_color,omitempty"`
+	// Glyphs selects the severity and status indicators: unicode (default),
+	// nerdfont for patched Nerd Fonts, or ascii
+	Glyphs string `yaml:"glyphs,omitempty"`
+	// SeverityGlyphs replaces the list severity indicators, indexed by severity (0-5)
+	SeverityGlyphs []string `yaml:"severity_glyphs,omitempty"`
+	// ServerSeverityColors colors severities as configured on the server
+	// (Zabbix 5.2+) instead of as the theme does
+	ServerSeverityColors bool `yaml:"server_severity_colors,omitempty"`
+	// ScreenReader renders plain, linear output without box drawing for screen readers
+	ScreenReader bool `yaml:"screen_reader,omitempty"`
+	// AckFilter limits the Alerts tab at startup: all, unacked, mine for
+	// problems acknowledged by the current user, or assigned for problems
+	// assigned to them
+	AckFilter string `yaml:"ack_filter,omitempty"`
 }
 
 // GraphsConfig holds settings for the graphs tab.
@@ -48,6 +122,105 @@ type GraphsConfig struct {
 	HistoryHours int `yaml:"history_hours"`
 	// MaxItemsPerHost limits items per host (0 = no limit)
 	MaxItemsPerHost int `yaml:"max_items_per_host"`
+	// ExportDir is where exported charts are saved (default: the current
+	// directory)
+	ExportDir string `yaml:"export_dir,omitempty"`
+	// ExportPNG renders exported charts as PNG images besides their CSV data
+	ExportPNG bool `yaml:"export_png,omitempty"`
+}
+
+// KioskConfig holds settings for kiosk mode (--kiosk) on unattended
+// wallboards.
+type KioskConfig struct {
+	// Interval is how long each tab is shown, e.g. "30s" (default: 30s)
+	Interval string `yaml:"interval,omitempty"`
+	// Tabs are the tabs rotated through, by name (see KioskTabs; default:
+	// alerts, hosts, events)
+	Tabs []string `yaml:"tabs,omitempty"`
+	// Groups are host group names the Alerts, Hosts and Events tabs are
+	// limited to in turn; empty for all hosts
+	Groups []string `yaml:"groups,omitempty"`
+	// GroupInterval is how long each host group is shown (default: one
+	// rotation through the tabs)
+	GroupInterval string `yaml:"group_interval,omitempty"`
+}
+
+// AckConfig holds the team's rules for acknowledging problems.
+type AckConfig struct {
+	// MinMessageLength is the fewest characters a message must have to
+	// acknowledge a problem; when set, acknowledging always asks for one
+	// (default: 0, messages are optional)
+	MinMessageLength int `yaml:"min_message_length,omitempty"`
+}
+
+// HooksConfig holds the sounds played and commands run when problems need
+// attention.
+type HooksConfig struct {
+	// Player is the command playing sound files, given the file as its last
+	// argument (default: afplay on macOS, paplay elsewhere)
+	Player string `yaml:"player,omitempty"`
+	// UnackedDisaster runs the disaster hook again for disasters left
+	// unacknowledged this long, e.g. "15m"; empty for never
+	UnackedDisaster string `yaml:"unacked_disaster,omitempty"`
+	// Severities are the hooks of new problems by severity name (see
+	// SeverityKeys)
+	Severities map[string]Hook `yaml:"severities,omitempty"`
+}
+
+// Hook is what happens when a problem needs attention: a sound, a command
+// or both.
+type Hook struct {
+	Sound   string `yaml:"sound,omitempty"`   // Sound file played
+	Command string `yaml:"command,omitempty"` // Shell command run, given the problem in CHOTKO_* variables
+}
+
+// MuteRule hides the problems of a known-noisy trigger. It matches problems
+// whose name matches Trigger and that have Tag; either may be left empty.
+type MuteRule struct {
+	Trigger string `yaml:"trigger,omitempty"` // Regular expression matched against the problem name
+	Tag     string `yaml:"tag,omitempty"`     // Tag name, or name=value
+	Comment string `yaml:"comment,omitempty"` // Why the trigger is muted
+}
+
+// Validate checks that the rule matches something and that its regular
+// expression compiles.
+func (r MuteRule) Validate() error {
+	if r.Trigger == "" && r.Tag == "" {
+		return fmt.Errorf("mute rule needs a trigger or a tag")
+	}
+	if _, err := regexp.Compile(r.Trigger); err != nil {
+		return fmt.Errorf("invalid mute trigger pattern %q: %w", r.Trigger, err)
+	}
+	return nil
+}
+
+// RoleFilter is the default filter of the users of a role or user group.
+// Empty fields keep the display settings.
+type RoleFilter struct {
+	// Groups are host group names the Alerts, Hosts and Events tabs are
+	// limited to
+	Groups      []string `yaml:"groups,omitempty"`
+	MinSeverity int      `yaml:"min_severity,omitempty"` // Like display.min_severity
+	AckFilter   string   `yaml:"ack_filter,omitempty"`   // Like display.ack_filter
+}
+
+// RoleFilters are role filters by user role or user group name.
+type RoleFilters map[string]RoleFilter
+
+// Match returns the filter of a user with the given role and user groups,
+// and the name it is configured under: the role's if there is one, else
+// that of the first of the user groups by name.
+func (r RoleFilters) Match(role string, groups []string) (string, RoleFilter, bool) {
+	if f, ok := r[role]; ok && role != "" {
+		return role, f, true
+	}
+	sorted := slices.Sorted(slices.Values(groups))
+	for _, group := range sorted {
+		if f, ok := r[group]; ok {
+			return group, f, true
+		}
+	}
+	return "", RoleFilter{}, false
 }
 
 // DefaultGraphCategories returns the default item key prefixes for the graphs tab.
@@ -59,6 +232,8 @@ func DefaultGraphCategories() []string {
 		"vfs.fs",
 		"net.if",
 		"proc",
+		"log",
+		"eventlog",
 	}
 }
 
@@ -169,6 +344,108 @@ func SaveToFile(cfg *Config, path string) error {
 	return nil
 }
 
+// SaveToken replaces auth.token in the config file at path, leaving the
+// rest of the file, comments included, as it is.
+func SaveToken(path, token string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to parse config file: not a mapping")
+	}
+	auth := mappingValue(doc.Content[0], "auth", yaml.MappingNode)
+	value := mappingValue(auth, "token", yaml.ScalarNode)
+	value.Value = token
+	value.Tag = "!!str"
+	value.Style = yaml.DoubleQuotedStyle
+
+	var buf strings.Builder
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	// Written whole then renamed, so that a failure leaves the old file
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(buf.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// mappingValue returns the value of a key of a YAML mapping, adding it
+// with an empty node of kind if it is missing.
+func mappingValue(mapping *yaml.Node, key string, kind yaml.Kind) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			value := mapping.Content[i+1]
+			if value.Kind != kind {
+				// e.g. "auth:" with nothing under it
+				*value = yaml.Node{Kind: kind}
+			}
+			return value
+		}
+	}
+	value := &yaml.Node{Kind: kind}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	return value
+}
+
+// Mode values for the auth.mode setting.
+const (
+	AuthModeDefault = "default" // API token, or username and password
+	AuthModeSession = "session" // Session ID obtained from the frontend, e.g. after SSO
+	AuthModeBasic   = "basic"   // HTTP basic auth in addition to the default
+)
+
+// Layout values for the display.layout setting.
+const (
+	LayoutAuto       = "auto"         // Choose based on terminal shape
+	LayoutSideBySide = "side-by-side" // List left, detail right
+	LayoutStacked    = "stacked"      // List above detail
+)
+
+// Time display values for the display.time_display setting.
+const (
+	TimeDisplayAbsolute = "absolute" // Show timestamps as date/time
+	TimeDisplayRelative = "relative" // Show timestamps as "3m ago"
+)
+
+// Clock values for the display.clock setting.
+const (
+	Clock24 = "24h"
+	Clock12 = "12h"
+)
+
+// Acknowledgement filter values for the display.ack_filter setting.
+const (
+	AckFilterAll      = "all"
+	AckFilterUnacked  = "unacked"
+	AckFilterMine     = "mine"
+	AckFilterAssigned = "assigned"
+)
+
+// SeverityKeys are the names of the severities in the config, indexed by
+// severity.
+var SeverityKeys = []string{"not_classified", "information", "warning", "average", "high", "disaster"}
+
+// DefaultServerSearchThreshold is the default display.server_search_threshold.
+const DefaultServerSearchThreshold = 1000
+
+// DefaultPageSize is the default display.page_size.
+const DefaultPageSize = 500
+
+// DefaultProblemTrend is the default display.problem_trend.
+const DefaultProblemTrend = 20
+
 // Config validation constants.
 const (
 	MinRefreshInterval = 5
@@ -182,8 +459,20 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("server URL is required")
 	}
 
-	if c.Auth.Token == "" && (c.Auth.Username == "" || c.Auth.Password == "") {
-		return fmt.Errorf("authentication required: provide either API token or username/password")
+	switch c.Auth.Mode {
+	case AuthModeSession:
+		if c.Auth.SessionID == "" {
+			return fmt.Errorf("authentication required: session mode needs a session ID")
+		}
+	case "", AuthModeDefault, AuthModeBasic:
+		if c.Auth.Token == "" && (c.Auth.Username == "" || c.Auth.Password == "") {
+			return fmt.Errorf("authentication required: provide either API token or username/password")
+		}
+		if c.Auth.Mode == AuthModeBasic && c.Auth.BasicUsername == "" {
+			return fmt.Errorf("basic mode needs a basic_username")
+		}
+	default:
+		return fmt.Errorf("auth mode must be one of %s, %s, %s", AuthModeDefault, AuthModeSession, AuthModeBasic)
 	}
 
 	if c.Display.RefreshInterval < MinRefreshInterval {
@@ -194,12 +483,144 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("severity must be between 0 and %d", MaxSeverity)
 	}
 
+	switch c.Display.Layout {
+	case "", LayoutAuto, LayoutSideBySide, LayoutStacked:
+	default:
+		return fmt.Errorf("layout must be one of %s, %s, %s", LayoutAuto, LayoutSideBySide, LayoutStacked)
+	}
+
+	switch c.Display.TimeDisplay {
+	case "", TimeDisplayAbsolute, TimeDisplayRelative:
+	default:
+		return fmt.Errorf("time display must be %s or %s", TimeDisplayAbsolute, TimeDisplayRelative)
+	}
+
+	switch c.Display.Clock {
+	case "", Clock24, Clock12:
+	default:
+		return fmt.Errorf("clock must be %s or %s", Clock24, Clock12)
+	}
+
+	switch c.Display.AckFilter {
+	case "", AckFilterAll, AckFilterUnacked, AckFilterMine, AckFilterAssigned:
+	default:
+		return fmt.Errorf("ack filter must be one of %s, %s, %s, %s",
+			AckFilterAll, AckFilterUnacked, AckFilterMine, AckFilterAssigned)
+	}
+
+	if c.Display.Glyphs != "" && !slices.Contains(theme.GlyphSetNames(), c.Display.Glyphs) {
+		return fmt.Errorf("glyphs must be one of %s", strings.Join(theme.GlyphSetNames(), ", "))
+	}
+
+	if n := len(c.Display.SeverityGlyphs); n != 0 && n != MaxSeverity+1 {
+		return fmt.Errorf("severity_glyphs must list %d glyphs (severity 0 to %d), got %d", MaxSeverity+1, MaxSeverity, n)
+	}
+
+	if c.Display.Timezone != "" {
+		if _, err := time.LoadLocation(c.Display.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", c.Display.Timezone, err)
+		}
+	}
+
+	for _, rule := range c.Mute {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.Server.Timeout != "" {
+		if _, err := format.ParseSpan(c.Server.Timeout); err != nil {
+			return fmt.Errorf("invalid server timeout: %w", err)
+		}
+	}
+	for method, timeout := range c.Server.MethodTimeouts {
+		if _, err := format.ParseSpan(timeout); err != nil {
+			return fmt.Errorf("invalid timeout for %s: %w", method, err)
+		}
+	}
+	if c.Server.PingInterval != "" && c.Server.PingInterval != PingOff {
+		if _, err := format.ParseSpan(c.Server.PingInterval); err != nil {
+			return fmt.Errorf("invalid ping interval: %w", err)
+		}
+	}
+	if c.Server.LatencyWarning != "" {
+		if _, err := format.ParseSpan(c.Server.LatencyWarning); err != nil {
+			return fmt.Errorf("invalid latency warning: %w", err)
+		}
+	}
+	if c.Server.LatencyCritical != "" {
+		if _, err := format.ParseSpan(c.Server.LatencyCritical); err != nil {
+			return fmt.Errorf("invalid latency critical: %w", err)
+		}
+	}
+
+	if c.Ack.MinMessageLength < 0 {
+		return fmt.Errorf("ack min_message_length must not be negative")
+	}
+
+	if c.Kiosk.Interval != "" {
+		if interval, err := format.ParseSpan(c.Kiosk.Interval); err != nil || interval <= 0 {
+			return fmt.Errorf("invalid kiosk interval %q", c.Kiosk.Interval)
+		}
+	}
+	if c.Kiosk.GroupInterval != "" {
+		if interval, err := format.ParseSpan(c.Kiosk.GroupInterval); err != nil || interval <= 0 {
+			return fmt.Errorf("invalid kiosk group interval %q", c.Kiosk.GroupInterval)
+		}
+	}
+	for _, tab := range c.Kiosk.Tabs {
+		if !slices.Contains(KioskTabs, tab) {
+			return fmt.Errorf("unknown kiosk tab %q; use %s", tab, strings.Join(KioskTabs, ", "))
+		}
+	}
+
+	for name, hook := range c.Hooks.Severities {
+		if !slices.Contains(SeverityKeys, name) {
+			return fmt.Errorf("unknown hook severity %q; use one of %s", name, strings.Join(SeverityKeys, ", "))
+		}
+		if hook.Sound == "" && hook.Command == "" {
+			return fmt.Errorf("hook for %s needs a sound or a command", name)
+		}
+	}
+	if c.Hooks.UnackedDisaster != "" {
+		if _, err := format.ParseSpan(c.Hooks.UnackedDisaster); err != nil {
+			return fmt.Errorf("invalid unacked_disaster: %w", err)
+		}
+	}
+
+	for name, f := range c.RoleFilters {
+		if f.MinSeverity < 0 || f.MinSeverity > MaxSeverity {
+			return fmt.Errorf("role filter %q: severity must be between 0 and %d", name, MaxSeverity)
+		}
+		switch f.AckFilter {
+		case "", AckFilterAll, AckFilterUnacked, AckFilterMine, AckFilterAssigned:
+		default:
+			return fmt.Errorf("role filter %q: ack filter must be one of %s, %s, %s, %s", name,
+				AckFilterAll, AckFilterUnacked, AckFilterMine, AckFilterAssigned)
+		}
+	}
+
+	for name, limit := range c.SLA {
+		if !slices.Contains(SeverityKeys, name) {
+			return fmt.Errorf("unknown SLA severity %q; use one of %s", name, strings.Join(SeverityKeys, ", "))
+		}
+		if _, err := format.ParseSpan(limit); err != nil {
+			return fmt.Errorf("invalid SLA limit for %s: %w", name, err)
+		}
+	}
+
 	return nil
 }
 
 // UseToken returns true if API token authentication should be used.
 func (c *Config) UseToken() bool {
-	return c.Auth.Token != ""
+	return !c.UseSession() && c.Auth.Token != ""
+}
+
+// UseSession returns true if an existing session of the frontend should be
+// used instead of signing in.
+func (c *Config) UseSession() bool {
+	return c.Auth.Mode == AuthModeSession
 }
 
 // GetGraphCategories returns the graph categories, using defaults if not configured.
@@ -218,6 +639,21 @@ func (c *Config) GetHistoryHours() int {
 	return c.Graphs.HistoryHours
 }
 
+// GetExportDir returns the directory exported charts are saved to, with a
+// leading "~/" expanded.
+func (c *Config) GetExportDir() string {
+	dir := c.Graphs.ExportDir
+	if dir == "" {
+		return "."
+	}
+	if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return dir
+}
+
 // GetWindowTitle returns whether window title updates are enabled (default: true).
 func (c *Config) GetWindowTitle() bool {
 	if c.Display.WindowTitle == nil {
@@ -234,7 +670,207 @@ func (c *Config) GetEmojiTitle() bool {
 	return *c.Display.EmojiTitle
 }
 
+// GetSLA returns how long problems may stay unacknowledged, indexed by
+// severity; 0 for no limit.
+func (c *Config) GetSLA() [MaxSeverity + 1]time.Duration {
+	var limits [MaxSeverity + 1]time.Duration
+	for severity, name := range SeverityKeys {
+		if limit, err := format.ParseSpan(c.SLA[name]); err == nil {
+			limits[severity] = limit
+		}
+	}
+	return limits
+}
+
+// DefaultTimeout is the default server.timeout.
+const DefaultTimeout = 30 * time.Second
+
+// GetTimeout returns how long an API call may take (default: 30s).
+func (c *Config) GetTimeout() time.Duration {
+	if timeout, err := format.ParseSpan(c.Server.Timeout); err == nil {
+		return timeout
+	}
+	return DefaultTimeout
+}
+
+// GetMethodTimeouts returns the timeouts of API methods that don't use
+// GetTimeout, by method name.
+func (c *Config) GetMethodTimeouts() map[string]time.Duration {
+	timeouts := make(map[string]time.Duration, len(c.Server.MethodTimeouts))
+	for method, timeout := range c.Server.MethodTimeouts {
+		if d, err := format.ParseSpan(timeout); err == nil {
+			timeouts[method] = d
+		}
+	}
+	return timeouts
+}
+
+// Kiosk mode defaults and the tabs it can rotate through.
+var (
+	KioskTabs            = []string{"alerts", "hosts", "events", "graphs"}
+	DefaultKioskTabs     = []string{"alerts", "hosts", "events"}
+	DefaultKioskInterval = 30 * time.Second
+)
+
+// GetKioskInterval returns how long kiosk mode shows each tab.
+func (c *Config) GetKioskInterval() time.Duration {
+	if interval, err := format.ParseSpan(c.Kiosk.Interval); err == nil && interval > 0 {
+		return interval
+	}
+	return DefaultKioskInterval
+}
+
+// GetKioskTabs returns the names of the tabs kiosk mode rotates through.
+func (c *Config) GetKioskTabs() []string {
+	if len(c.Kiosk.Tabs) > 0 {
+		return c.Kiosk.Tabs
+	}
+	return DefaultKioskTabs
+}
+
+// GetKioskGroupInterval returns how long kiosk mode shows each host group:
+// by default long enough to rotate through every tab once.
+func (c *Config) GetKioskGroupInterval() time.Duration {
+	if interval, err := format.ParseSpan(c.Kiosk.GroupInterval); err == nil && interval > 0 {
+		return interval
+	}
+	return c.GetKioskInterval() * time.Duration(len(c.GetKioskTabs()))
+}
+
+// GetHooks returns the hooks by severity (0-5); severities without one have
+// an empty hook.
+func (c *Config) GetHooks() [MaxSeverity + 1]Hook {
+	var hooks [MaxSeverity + 1]Hook
+	for severity, name := range SeverityKeys {
+		hooks[severity] = c.Hooks.Severities[name]
+	}
+	return hooks
+}
+
+// GetUnackedDisaster returns how long a disaster may stay unacknowledged
+// before its hook runs again, or 0 for never.
+func (c *Config) GetUnackedDisaster() time.Duration {
+	if limit, err := format.ParseSpan(c.Hooks.UnackedDisaster); err == nil && limit > 0 {
+		return limit
+	}
+	return 0
+}
+
+// Health check defaults.
+const (
+	PingOff                = "off" // server.ping_interval disabling the health check
+	DefaultPingInterval    = 15 * time.Second
+	DefaultLatencyWarning  = 500 * time.Millisecond
+	DefaultLatencyCritical = 2 * time.Second
+)
+
+// GetPingInterval returns how often the server's latency is measured, or 0
+// when the health check is off.
+func (c *Config) GetPingInterval() time.Duration {
+	if c.Server.PingInterval == PingOff {
+		return 0
+	}
+	if interval, err := format.ParseSpan(c.Server.PingInterval); err == nil {
+		return interval
+	}
+	return DefaultPingInterval
+}
+
+// GetLatencyThresholds returns the round-trip times from which the server's
+// latency is shown as slow and as critical.
+func (c *Config) GetLatencyThresholds() (warning, critical time.Duration) {
+	warning, critical = DefaultLatencyWarning, DefaultLatencyCritical
+	if d, err := format.ParseSpan(c.Server.LatencyWarning); err == nil {
+		warning = d
+	}
+	if d, err := format.ParseSpan(c.Server.LatencyCritical); err == nil {
+		critical = d
+	}
+	return warning, critical
+}
+
 // GetTitleMinSeverity returns the minimum severity to show in window title.
 func (c *Config) GetTitleMinSeverity() int {
 	return c.Display.TitleMinSeverity
 }
+
+// GetLayout returns the configured pane layout (default: auto).
+func (c *Config) GetLayout() string {
+	if c.Display.Layout == "" {
+		return LayoutAuto
+	}
+	return c.Display.Layout
+}
+
+// GetServerSearchThreshold returns the list size above which text filters are
+// sent to the API. Returns 0 when server-side search is disabled.
+func (c *Config) GetServerSearchThreshold() int {
+	switch {
+	case c.Display.ServerSearchThreshold < 0:
+		return 0
+	case c.Display.ServerSearchThreshold == 0:
+		return DefaultServerSearchThreshold
+	}
+	return c.Display.ServerSearchThreshold
+}
+
+// GetPageSize returns how many problems or events are fetched per page (default: 500).
+func (c *Config) GetPageSize() int {
+	if c.Display.PageSize <= 0 {
+		return DefaultPageSize
+	}
+	return c.Display.PageSize
+}
+
+// GetProblemTrend returns how many refreshes the problem count sparkline
+// covers. Returns 0 when it is hidden.
+func (c *Config) GetProblemTrend() int {
+	switch {
+	case c.Display.ProblemTrend < 0:
+		return 0
+	case c.Display.ProblemTrend == 0:
+		return DefaultProblemTrend
+	}
+	return c.Display.ProblemTrend
+}
+
+// GetNoColor returns whether no-color mode is enabled, either in the config
+// or by a non-empty NO_COLOR environment variable (https://no-color.org).
+func (c *Config) GetNoColor() bool {
+	return c.Display.NoColor || os.Getenv("NO_COLOR") != ""
+}
+
+// GetRelativeTime returns whether timestamps are shown relative to now (default: false).
+func (c *Config) GetRelativeTime() bool {
+	return c.Display.TimeDisplay == TimeDisplayRelative
+}
+
+// GetHour12 returns whether times of day use a 12-hour clock (default: false).
+func (c *Config) GetHour12() bool {
+	return c.Display.Clock == Clock12
+}
+
+// GetTimeFormat returns the layout for absolute timestamps.
+// The default layout follows the configured clock style.
+func (c *Config) GetTimeFormat() string {
+	if c.Display.TimeFormat != "" {
+		return c.Display.TimeFormat
+	}
+	if c.GetHour12() {
+		return format.DefaultTimeLayout12
+	}
+	return format.DefaultTimeLayout
+}
+
+// GetLocation returns the display timezone, falling back to local time
+// if none is configured or the name cannot be resolved.
+func (c *Config) GetLocation() *time.Location {
+	if c.Display.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(c.Display.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
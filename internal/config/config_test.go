@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -335,6 +338,132 @@ func TestConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "authentication",
 		},
+		{
+			name: "valid with session",
+			config: &Config{
+				Server:  ServerConfig{URL: "https://zabbix.example.com"},
+				Auth:    AuthConfig{Mode: AuthModeSession, SessionID: "0424bd59"},
+				Display: DisplayConfig{RefreshInterval: 30},
+			},
+			wantErr: false,
+		},
+		{
+			name: "session mode without session ID",
+			config: &Config{
+				Server:  ServerConfig{URL: "https://zabbix.example.com"},
+				Auth:    AuthConfig{Mode: AuthModeSession, Token: "test-token"},
+				Display: DisplayConfig{RefreshInterval: 30},
+			},
+			wantErr: true,
+			errMsg:  "session ID",
+		},
+		{
+			name: "basic mode without proxy username",
+			config: &Config{
+				Server:  ServerConfig{URL: "https://zabbix.example.com"},
+				Auth:    AuthConfig{Mode: AuthModeBasic, Token: "test-token"},
+				Display: DisplayConfig{RefreshInterval: 30},
+			},
+			wantErr: true,
+			errMsg:  "basic_username",
+		},
+		{
+			name: "unknown auth mode",
+			config: &Config{
+				Server:  ServerConfig{URL: "https://zabbix.example.com"},
+				Auth:    AuthConfig{Mode: "kerberos", Token: "test-token"},
+				Display: DisplayConfig{RefreshInterval: 30},
+			},
+			wantErr: true,
+			errMsg:  "auth mode",
+		},
+		{
+			name: "empty mute rule",
+			config: &Config{
+				Server:  ServerConfig{URL: "https://zabbix.example.com"},
+				Auth:    AuthConfig{Token: "test-token"},
+				Display: DisplayConfig{RefreshInterval: 30},
+				Mute:    []MuteRule{{Comment: "noisy"}},
+			},
+			wantErr: true,
+			errMsg:  "trigger or a tag",
+		},
+		{
+			name: "invalid mute pattern",
+			config: &Config{
+				Server:  ServerConfig{URL: "https://zabbix.example.com"},
+				Auth:    AuthConfig{Token: "test-token"},
+				Display: DisplayConfig{RefreshInterval: 30},
+				Mute:    []MuteRule{{Trigger: "disk (low"}},
+			},
+			wantErr: true,
+			errMsg:  "mute trigger pattern",
+		},
+		{
+			name: "unknown ack filter",
+			config: &Config{
+				Server:  ServerConfig{URL: "https://zabbix.example.com"},
+				Auth:    AuthConfig{Token: "test-token"},
+				Display: DisplayConfig{RefreshInterval: 30, AckFilter: "acked"},
+			},
+			wantErr: true,
+			errMsg:  "ack filter",
+		},
+		{
+			name: "unknown SLA severity",
+			config: &Config{
+				Server:  ServerConfig{URL: "https://zabbix.example.com"},
+				Auth:    AuthConfig{Token: "test-token"},
+				Display: DisplayConfig{RefreshInterval: 30},
+				SLA:     map[string]string{"critical": "15m"},
+			},
+			wantErr: true,
+			errMsg:  "SLA severity",
+		},
+		{
+			name: "invalid SLA limit",
+			config: &Config{
+				Server:  ServerConfig{URL: "https://zabbix.example.com"},
+				Auth:    AuthConfig{Token: "test-token"},
+				Display: DisplayConfig{RefreshInterval: 30},
+				SLA:     map[string]string{"disaster": "soon"},
+			},
+			wantErr: true,
+			errMsg:  "SLA limit",
+		},
+		{
+			name: "invalid timeout",
+			config: &Config{
+				Server:  ServerConfig{URL: "https://zabbix.example.com", Timeout: "-5s"},
+				Auth:    AuthConfig{Token: "test-token"},
+				Display: DisplayConfig{RefreshInterval: 30},
+			},
+			wantErr: true,
+			errMsg:  "server timeout",
+		},
+		{
+			name: "invalid method timeout",
+			config: &Config{
+				Server: ServerConfig{
+					URL:            "https://zabbix.example.com",
+					MethodTimeouts: map[string]string{"history.get": "long"},
+				},
+				Auth:    AuthConfig{Token: "test-token"},
+				Display: DisplayConfig{RefreshInterval: 30},
+			},
+			wantErr: true,
+			errMsg:  "timeout for history.get",
+		},
+		{
+			name: "invalid ping interval",
+			config: &Config{
+				Server:  ServerConfig{URL: "https://zabbix.example.com", PingInterval: "never"},
+				Auth:    AuthConfig{Token: "test-token"},
+				Display: DisplayConfig{RefreshInterval: 30},
+			},
+			wantErr: true,
+			errMsg:  "ping interval",
+		},
 	}
 
 	for _, tt := range tests {
@@ -399,6 +528,256 @@ func TestConfig_Validate_Severity(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate_Layout(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  string
+		wantErr bool
+	}{
+		{"empty", "", false},
+		{"auto", LayoutAuto, false},
+		{"side-by-side", LayoutSideBySide, false},
+		{"stacked", LayoutStacked, false},
+		{"unknown", "diagonal", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server:  ServerConfig{URL: "https://zabbix.example.com"},
+				Auth:    AuthConfig{Token: "test-token"},
+				Display: DisplayConfig{RefreshInterval: 30, Layout: tt.layout},
+			}
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_GetLayout(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetLayout(); got != LayoutAuto {
+		t.Errorf("GetLayout() = %q, want %q", got, LayoutAuto)
+	}
+
+	cfg.Display.Layout = LayoutStacked
+	if got := cfg.GetLayout(); got != LayoutStacked {
+		t.Errorf("GetLayout() = %q, want %q", got, LayoutStacked)
+	}
+}
+
+func TestConfig_GetServerSearchThreshold(t *testing.T) {
+	tests := []struct {
+		value int
+		want  int
+	}{
+		{0, DefaultServerSearchThreshold},
+		{200, 200},
+		{-1, 0},
+	}
+
+	for _, tt := range tests {
+		cfg := &Config{Display: DisplayConfig{ServerSearchThreshold: tt.value}}
+		if got := cfg.GetServerSearchThreshold(); got != tt.want {
+			t.Errorf("GetServerSearchThreshold() with %d = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_GetProblemTrend(t *testing.T) {
+	tests := []struct {
+		value int
+		want  int
+	}{
+		{0, DefaultProblemTrend},
+		{10, 10},
+		{-1, 0},
+	}
+
+	for _, tt := range tests {
+		cfg := &Config{Display: DisplayConfig{ProblemTrend: tt.value}}
+		if got := cfg.GetProblemTrend(); got != tt.want {
+			t.Errorf("GetProblemTrend() with %d = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_GetPageSize(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.GetPageSize(); got != DefaultPageSize {
+		t.Errorf("GetPageSize() = %d, want %d", got, DefaultPageSize)
+	}
+
+	cfg.Display.PageSize = 100
+	if got := cfg.GetPageSize(); got != 100 {
+		t.Errorf("GetPageSize() = %d, want 100", got)
+	}
+}
+
+func TestConfig_GetNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	cfg := &Config{}
+	if cfg.GetNoColor() {
+		t.Error("GetNoColor() = true, want false by default")
+	}
+
+	cfg.Display.NoColor = true
+	if !cfg.GetNoColor() {
+		t.Error("GetNoColor() = false, want true when no_color is set")
+	}
+
+	cfg.Display.NoColor = false
+	t.Setenv("NO_COLOR", "1")
+	if !cfg.GetNoColor() {
+		t.Error("GetNoColor() = false, want true when NO_COLOR is set")
+	}
+}
+
+func TestConfig_Validate_SeverityGlyphs(t *testing.T) {
+	tests := []struct {
+		name    string
+		set     string
+		glyphs  []string
+		wantErr bool
+	}{
+		{"unset", "", nil, false},
+		{"six glyphs", "", []string{".", "i", "w", "a", "H", "D"}, false},
+		{"too few", "", []string{"a", "b"}, true},
+		{"nerd font set", "nerdfont", nil, false},
+		{"ascii set with glyphs", "ascii", []string{".", "i", "w", "a", "H", "D"}, false},
+		{"unknown set", "emoji", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server:  ServerConfig{URL: "https://zabbix.example.com"},
+				Auth:    AuthConfig{Token: "test-token"},
+				Display: DisplayConfig{RefreshInterval: 30, Glyphs: tt.set, SeverityGlyphs: tt.glyphs},
+			}
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_RoleFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  RoleFilter
+		wantErr bool
+	}{
+		{"host groups", RoleFilter{Groups: []string{"Databases"}}, false},
+		{"severity and ack filter", RoleFilter{MinSeverity: 3, AckFilter: AckFilterUnacked}, false},
+		{"severity too high", RoleFilter{MinSeverity: 6}, true},
+		{"unknown ack filter", RoleFilter{AckFilter: "mine-only"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server:      ServerConfig{URL: "https://zabbix.example.com"},
+				Auth:        AuthConfig{Token: "test-token"},
+				Display:     DisplayConfig{RefreshInterval: 30},
+				RoleFilters: RoleFilters{"DBA": tt.filter},
+			}
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoleFilters_Match(t *testing.T) {
+	filters := RoleFilters{
+		"DBA":           {MinSeverity: 2},
+		"Database team": {Groups: []string{"Databases"}},
+		"Backend team":  {Groups: []string{"Backend"}},
+	}
+
+	tests := []struct {
+		name   string
+		role   string
+		groups []string
+		want   string
+	}{
+		{"role first", "DBA", []string{"Database team"}, "DBA"},
+		{"first user group by name", "User role", []string{"Database team", "Backend team"}, "Backend team"},
+		{"no match", "User role", []string{"Guests"}, ""},
+		{"no role", "", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, f, ok := filters.Match(tt.role, tt.groups)
+			if name != tt.want || ok != (tt.want != "") {
+				t.Fatalf("Match() = %q, %v, want %q", name, ok, tt.want)
+			}
+			if ok && (!slices.Equal(f.Groups, filters[name].Groups) || f.MinSeverity != filters[name].MinSeverity) {
+				t.Errorf("Match() filter = %+v, want %+v", f, filters[name])
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_Time(t *testing.T) {
+	tests := []struct {
+		name     string
+		display  string
+		timezone string
+		wantErr  bool
+	}{
+		{"defaults", "", "", false},
+		{"relative", TimeDisplayRelative, "", false},
+		{"absolute with timezone", TimeDisplayAbsolute, "Europe/Berlin", false},
+		{"unknown display", "fuzzy", "", true},
+		{"unknown timezone", "", "Mars/Olympus_Mons", true},
+	}
+
+	clockCfg := &Config{
+		Server:  ServerConfig{URL: "https://zabbix.example.com"},
+		Auth:    AuthConfig{Token: "test-token"},
+		Display: DisplayConfig{RefreshInterval: 30, Clock: "13h"},
+	}
+	if err := clockCfg.Validate(); err == nil {
+		t.Error("Validate() should reject unknown clock style")
+	}
+	clockCfg.Display.Clock = Clock12
+	if err := clockCfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v for 12h clock", err)
+	}
+	if !clockCfg.GetHour12() {
+		t.Error("GetHour12() = false, want true")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server: ServerConfig{URL: "https://zabbix.example.com"},
+				Auth:   AuthConfig{Token: "test-token"},
+				Display: DisplayConfig{
+					RefreshInterval: 30,
+					TimeDisplay:     tt.display,
+					Timezone:        tt.timezone,
+				},
+			}
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestConfig_UseToken(t *testing.T) {
 	tests := []struct {
 		name string
@@ -446,3 +825,200 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestConfig_GetTimeouts(t *testing.T) {
+	cfg := DefaultConfig()
+	if got := cfg.GetTimeout(); got != DefaultTimeout {
+		t.Errorf("GetTimeout() = %v, want default %v", got, DefaultTimeout)
+	}
+
+	cfg.Server.Timeout = "10s"
+	cfg.Server.MethodTimeouts = map[string]string{"history.get": "2m"}
+	if got := cfg.GetTimeout(); got != 10*time.Second {
+		t.Errorf("GetTimeout() = %v, want 10s", got)
+	}
+	if got := cfg.GetMethodTimeouts(); len(got) != 1 || got["history.get"] != 2*time.Minute {
+		t.Errorf("GetMethodTimeouts() = %v, want 2m for history.get", got)
+	}
+}
+
+func TestConfig_GetPing(t *testing.T) {
+	cfg := DefaultConfig()
+	if got := cfg.GetPingInterval(); got != DefaultPingInterval {
+		t.Errorf("GetPingInterval() = %v, want default %v", got, DefaultPingInterval)
+	}
+	if warning, critical := cfg.GetLatencyThresholds(); warning != DefaultLatencyWarning || critical != DefaultLatencyCritical {
+		t.Errorf("GetLatencyThresholds() = %v, %v; want the defaults", warning, critical)
+	}
+
+	cfg.Server.PingInterval = PingOff
+	cfg.Server.LatencyWarning = "1s"
+	if got := cfg.GetPingInterval(); got != 0 {
+		t.Errorf("GetPingInterval() = %v, want 0 when off", got)
+	}
+	if warning, critical := cfg.GetLatencyThresholds(); warning != time.Second || critical != DefaultLatencyCritical {
+		t.Errorf("GetLatencyThresholds() = %v, %v; want 1s and the default", warning, critical)
+	}
+}
+
+func TestConfig_Kiosk(t *testing.T) {
+	cfg := &Config{
+		Server:  ServerConfig{URL: "https://zabbix.example.com"},
+		Auth:    AuthConfig{Token: "test-token"},
+		Display: DisplayConfig{RefreshInterval: 30},
+	}
+	if got := cfg.GetKioskInterval(); got != DefaultKioskInterval {
+		t.Errorf("GetKioskInterval() = %v, want default %v", got, DefaultKioskInterval)
+	}
+	if got := cfg.GetKioskTabs(); !slices.Equal(got, DefaultKioskTabs) {
+		t.Errorf("GetKioskTabs() = %v, want default %v", got, DefaultKioskTabs)
+	}
+
+	cfg.Kiosk = KioskConfig{Interval: "1m", Tabs: []string{"alerts", "graphs"}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if got := cfg.GetKioskInterval(); got != time.Minute {
+		t.Errorf("GetKioskInterval() = %v, want 1m", got)
+	}
+	if got := cfg.GetKioskGroupInterval(); got != 2*time.Minute {
+		t.Errorf("GetKioskGroupInterval() = %v, want one rotation of 2m", got)
+	}
+	cfg.Kiosk.GroupInterval = "5m"
+	if got := cfg.GetKioskGroupInterval(); got != 5*time.Minute {
+		t.Errorf("GetKioskGroupInterval() = %v, want 5m", got)
+	}
+
+	for _, kiosk := range []KioskConfig{{Interval: "soon"}, {Interval: "0s"}, {Tabs: []string{"dashboards"}}, {GroupInterval: "-1m"}} {
+		cfg.Kiosk = kiosk
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate() with kiosk %+v = nil, want an error", kiosk)
+		}
+	}
+}
+
+func TestConfig_Hooks(t *testing.T) {
+	cfg := &Config{
+		Server:  ServerConfig{URL: "https://zabbix.example.com"},
+		Auth:    AuthConfig{Token: "test-token"},
+		Display: DisplayConfig{RefreshInterval: 30},
+		Hooks: HooksConfig{
+			UnackedDisaster: "15m",
+			Severities: map[string]Hook{
+				"disaster": {Sound: "alarm.wav", Command: "page-oncall"},
+				"high":     {Sound: "beep.wav"},
+			},
+		},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	hooks := cfg.GetHooks()
+	if hooks[5].Command != "page-oncall" || hooks[4].Sound != "beep.wav" || hooks[3] != (Hook{}) {
+		t.Errorf("GetHooks() = %+v, want disaster and high hooks", hooks)
+	}
+	if got := cfg.GetUnackedDisaster(); got != 15*time.Minute {
+		t.Errorf("GetUnackedDisaster() = %v, want 15m", got)
+	}
+
+	for _, hooks := range []HooksConfig{
+		{Severities: map[string]Hook{"critical": {Sound: "a.wav"}}},
+		{Severities: map[string]Hook{"high": {}}},
+		{UnackedDisaster: "later"},
+	} {
+		cfg.Hooks = hooks
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate() with hooks %+v = nil, want an error", hooks)
+		}
+	}
+	if got := (&Config{}).GetUnackedDisaster(); got != 0 {
+		t.Errorf("GetUnackedDisaster() = %v, want 0 when unset", got)
+	}
+}
+
+func TestSaveToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := `# My Zabbix
+server:
+  url: "https://zabbix.example.com"
+auth:
+  token: "old-secret"  # rotated by chotko
+display:
+  theme: nord
+`
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveToken(path, "new-secret"); err != nil {
+		t.Fatalf("SaveToken() error = %v", err)
+	}
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Auth.Token != "new-secret" || cfg.Display.Theme != "nord" || cfg.Server.URL != "https://zabbix.example.com" {
+		t.Errorf("config after SaveToken() = %+v", cfg)
+	}
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{"# My Zabbix", "# rotated by chotko"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("SaveToken() lost the comment %q:\n%s", want, data)
+		}
+	}
+
+	// A config without a token gets one
+	if err := os.WriteFile(path, []byte("server:\n  url: x\nauth:\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveToken(path, "secret"); err != nil {
+		t.Fatalf("SaveToken() error = %v", err)
+	}
+	if cfg, err := LoadFromFile(path); err != nil || cfg.Auth.Token != "secret" {
+		t.Errorf("token = %q (%v), want it added", cfg.Auth.Token, err)
+	}
+}
+
+func TestConfig_GetExportDir(t *testing.T) {
+	cfg := DefaultConfig()
+	if got := cfg.GetExportDir(); got != "." {
+		t.Errorf("GetExportDir() = %q, want the current directory", got)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip(err)
+	}
+	cfg.Graphs.ExportDir = "~/charts"
+	if got := cfg.GetExportDir(); got != filepath.Join(home, "charts") {
+		t.Errorf("GetExportDir() = %q, want ~ expanded", got)
+	}
+}
+
+func TestConfig_GetSLA(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SLA = map[string]string{"disaster": "15m", "high": "1h"}
+
+	limits := cfg.GetSLA()
+	if limits[5] != 15*time.Minute || limits[4] != time.Hour {
+		t.Errorf("GetSLA() = %v, want 15m for disaster and 1h for high", limits)
+	}
+	if limits[3] != 0 {
+		t.Errorf("GetSLA()[3] = %v, want no limit", limits[3])
+	}
+}
+
+func TestConfig_Validate_AckMinMessageLength(t *testing.T) {
+	cfg := &Config{
+		Server:  ServerConfig{URL: "https://zabbix.example.com"},
+		Auth:    AuthConfig{Token: "test-token"},
+		Display: DisplayConfig{RefreshInterval: 30},
+		Ack:     AckConfig{MinMessageLength: 10},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+
+	cfg.Ack.MinMessageLength = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "min_message_length") {
+		t.Errorf("Validate() error = %v, want a min_message_length error", err)
+	}
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchDebounce is how long file events are collected before a change is
+// reported, since editors often write a file in several steps.
+const WatchDebounce = 250 * time.Millisecond
+
+// Watcher reports changes to the config file and custom theme files.
+type Watcher struct {
+	fsw       *fsnotify.Watcher
+	path      string
+	themesDir string
+	changes   chan struct{}
+	done      chan struct{}
+}
+
+// Watch starts watching the config file at path and the custom themes in
+// themesDir. The directories are watched rather than the files so that
+// editors which save by replacing the file are handled.
+func Watch(path, themesDir string) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create watcher: %w", err)
+	}
+
+	path = filepath.Clean(path)
+	if err := fsw.Add(filepath.Dir(path)); err != nil {
+		_ = fsw.Close()
+		return nil, fmt.Errorf("failed to watch config directory: %w", err)
+	}
+	themesDir = filepath.Clean(themesDir)
+	// The themes directory is optional; it is picked up if created later
+	_ = fsw.Add(themesDir)
+
+	w := &Watcher{
+		fsw:       fsw,
+		path:      path,
+		themesDir: themesDir,
+		changes:   make(chan struct{}, 1),
+		done:      make(chan struct{}),
+	}
+	go w.run()
+	return w, nil
+}
+
+// Changes returns a channel that receives a value after the config file or a
+// theme file has changed. Bursts of changes are reported once. The channel is
+// closed when the watcher is closed.
+func (w *Watcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+// Close stops watching.
+func (w *Watcher) Close() error {
+	err := w.fsw.Close()
+	<-w.done
+	return err
+}
+
+// run forwards relevant file events to the changes channel, debounced.
+func (w *Watcher) run() {
+	defer close(w.done)
+	defer close(w.changes)
+
+	timer := time.NewTimer(WatchDebounce)
+	timer.Stop()
+
+	for {
+		select {
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			if event.Has(fsnotify.Create) && filepath.Clean(event.Name) == w.themesDir {
+				_ = w.fsw.Add(w.themesDir)
+			}
+			if w.relevant(event) {
+				timer.Reset(WatchDebounce)
+			}
+		case _, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+		case <-timer.C:
+			select {
+			case w.changes <- struct{}{}:
+			default: // A change is already pending
+			}
+		}
+	}
+}
+
+// relevant reports whether an event affects the config file or a theme file.
+func (w *Watcher) relevant(event fsnotify.Event) bool {
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+		return false
+	}
+	name := filepath.Clean(event.Name)
+	if name == w.path {
+		return true
+	}
+	return filepath.Dir(name) == w.themesDir && strings.HasSuffix(name, ".yaml")
+}
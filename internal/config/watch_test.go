@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("display:\n  refresh_interval: 30\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := Watch(path, filepath.Join(dir, "themes"))
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer func() { _ = w.Close() }()
+
+	expectChange := func(what string) {
+		t.Helper()
+		select {
+		case <-w.Changes():
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no change reported after %s", what)
+		}
+	}
+	expectQuiet := func(what string) {
+		t.Helper()
+		select {
+		case <-w.Changes():
+			t.Fatalf("change reported after %s", what)
+		case <-time.After(2 * WatchDebounce):
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("display:\n  refresh_interval: 60\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	expectChange("writing the config file")
+
+	if err := os.WriteFile(filepath.Join(dir, "ignores.yaml"), []byte("rules: []\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	expectQuiet("writing an unrelated file")
+
+	if err := os.Mkdir(filepath.Join(dir, "themes"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	// Give the watcher a moment to add the new directory
+	time.Sleep(WatchDebounce)
+	if err := os.WriteFile(filepath.Join(dir, "themes", "mine.yaml"), []byte("name: mine\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	expectChange("writing a theme file")
+
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if _, ok := <-w.Changes(); ok {
+		t.Error("Changes() not closed after Close()")
+	}
+}
@@ -2,13 +2,19 @@ package config
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
 )
 
+// wizardTimeout bounds the connection test and token creation in the wizard.
+const wizardTimeout = 30 * time.Second
+
 // RunWizard runs the interactive setup wizard.
 func RunWizard() (*Config, error) {
 	reader := bufio.NewReader(os.Stdin)
@@ -41,7 +47,7 @@ func RunWizard() (*Config, error) {
 	fmt.Println()
 	fmt.Println("Authentication method:")
 	fmt.Println("  1. API Token (recommended for Zabbix 5.4+)")
-	fmt.Println("  2. Username/Password")
+	fmt.Println("  2. Username/Password (an API token can be created for you)")
 	fmt.Print("Select [1/2]: ")
 	authChoice, err := reader.ReadString('\n')
 	if err != nil {
@@ -91,6 +97,11 @@ func RunWizard() (*Config, error) {
 		cfg.Auth.Password = password
 	}
 
+	// Test the connection before saving
+	if err := verifyConnection(reader, cfg); err != nil {
+		return nil, err
+	}
+
 	// Theme selection
 	fmt.Println()
 	fmt.Println("Available themes:")
@@ -98,7 +109,7 @@ func RunWizard() (*Config, error) {
 	for i, t := range themes {
 		fmt.Printf("  %d. %s\n", i+1, t)
 	}
-	fmt.Print("Select theme [1-7, default=2 (nord)]: ")
+	fmt.Printf("Select theme [1-%d, default=2 (nord)]: ", len(themes))
 	themeChoice, err := reader.ReadString('\n')
 	if err != nil {
 		return nil, fmt.Errorf("failed to read theme choice: %w", err)
@@ -158,6 +169,114 @@ func RunWizard() (*Config, error) {
 	return cfg, nil
 }
 
+// verifyConnection tests the server URL and credentials. When logging in
+// with a password it offers to create an API token, which is then saved
+// instead of the password.
+func verifyConnection(reader *bufio.Reader, cfg *Config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), wizardTimeout)
+	defer cancel()
+
+	fmt.Println()
+	fmt.Print("Testing connection... ")
+	client, version, err := testConnection(ctx, cfg)
+	if err != nil {
+		fmt.Println("failed")
+		fmt.Printf("  %v\n", err)
+		fmt.Println()
+		if !askYesNo(reader, "Save the configuration anyway? [y/N]: ", false) {
+			return fmt.Errorf("connection test failed: %w", err)
+		}
+		return nil
+	}
+	fmt.Printf("✓ Connected to Zabbix %s\n", version)
+
+	if cfg.UseToken() {
+		return nil
+	}
+	defer func() { _ = client.Logout(ctx) }()
+
+	if !client.Capabilities().APITokens {
+		fmt.Println("API tokens require Zabbix 5.4 or later; the password will be saved.")
+		return nil
+	}
+
+	fmt.Println()
+	if !askYesNo(reader, "Create an API token so the password is not stored? [Y/n]: ", true) {
+		return nil
+	}
+	token, err := createToken(ctx, client, cfg.Auth.Username)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+		fmt.Println("The password will be saved instead.")
+		return nil
+	}
+
+	cfg.Auth.Token = token
+	cfg.Auth.Username = ""
+	cfg.Auth.Password = ""
+	fmt.Println("✓ API token created")
+	return nil
+}
+
+// testConnection connects with the configured credentials and returns the
+// authenticated client and the server version.
+func testConnection(ctx context.Context, cfg *Config) (*zabbix.Client, string, error) {
+	client := zabbix.NewClient(cfg.Server.URL)
+
+	caps, err := client.Negotiate(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("server not reachable: %w", err)
+	}
+
+	if cfg.UseToken() {
+		if !caps.APITokens {
+			return nil, "", fmt.Errorf("API tokens require Zabbix 5.4 or later (server is %s)", caps.Version)
+		}
+		client.SetToken(cfg.Auth.Token)
+		if err := client.CheckAuth(ctx); err != nil {
+			return nil, "", err
+		}
+	} else if err := client.Login(ctx, cfg.Auth.Username, cfg.Auth.Password); err != nil {
+		return nil, "", err
+	}
+
+	return client, caps.Version, nil
+}
+
+// createToken creates a long-lived API token for the logged-in user, named
+// after this machine so it can be recognized in the Zabbix frontend.
+func createToken(ctx context.Context, client *zabbix.Client, username string) (string, error) {
+	userID, err := client.UserID(ctx, username)
+	if err != nil {
+		return "", err
+	}
+
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown host"
+	}
+	name := fmt.Sprintf("chotko on %s (%s)", host, time.Now().Format("2006-01-02 15:04:05"))
+
+	return client.CreateToken(ctx, userID, name)
+}
+
+// askYesNo prompts for a yes/no answer, returning def for an empty answer
+// or a read error.
+func askYesNo(reader *bufio.Reader, prompt string, def bool) bool {
+	fmt.Print(prompt)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return def
+	}
+	switch strings.TrimSpace(strings.ToLower(response)) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	}
+	return def
+}
+
 // PromptForConfig prompts the user to run the wizard or exit.
 func PromptForConfig() bool {
 	reader := bufio.NewReader(os.Stdin)
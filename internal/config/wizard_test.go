@@ -0,0 +1,164 @@
+package config
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newWizardServer returns a fake Zabbix API that accepts the API token
+// "good-token" and the login Admin/zabbix, and hands out the token "created".
+func newWizardServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+			ID     int64           `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+
+		authorized := r.Header.Get("Authorization") == "Bearer good-token" ||
+			r.Header.Get("Authorization") == "Bearer session"
+		var result any
+		var apiErr map[string]any
+		switch req.Method {
+		case "apiinfo.version":
+			result = "6.4.0"
+		case "user.login":
+			if strings.Contains(string(req.Params), `"password":"zabbix"`) {
+				result = "session"
+			} else {
+				apiErr = map[string]any{"code": -32602, "message": "Invalid params", "data": "Incorrect user name or password."}
+			}
+		case "user.logout":
+			result = true
+		case "user.get":
+			result = []map[string]string{{"userid": "1"}}
+		case "token.create":
+			result = map[string][]string{"tokenids": {"5"}}
+		case "token.generate":
+			result = []map[string]string{{"tokenid": "5", "token": "created"}}
+		case "host.get":
+			if authorized {
+				result = "3"
+			} else {
+				apiErr = map[string]any{"code": -32602, "message": "Invalid params", "data": "Not authorized."}
+			}
+		}
+
+		resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		if apiErr != nil {
+			resp["error"] = apiErr
+		} else {
+			resp["result"] = result
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestTestConnection(t *testing.T) {
+	server := newWizardServer(t)
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		auth    AuthConfig
+		wantErr bool
+	}{
+		{"valid token", AuthConfig{Token: "good-token"}, false},
+		{"invalid token", AuthConfig{Token: "bad-token"}, true},
+		{"valid password", AuthConfig{Username: "Admin", Password: "zabbix"}, false},
+		{"invalid password", AuthConfig{Username: "Admin", Password: "wrong"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Server.URL = server.URL
+			cfg.Auth = tt.auth
+
+			_, version, err := testConnection(context.Background(), cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("testConnection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && version != "6.4.0" {
+				t.Errorf("version = %q, want 6.4.0", version)
+			}
+		})
+	}
+}
+
+func TestTestConnection_Unreachable(t *testing.T) {
+	server := newWizardServer(t)
+	server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Server.URL = server.URL
+	cfg.Auth.Token = "good-token"
+
+	if _, _, err := testConnection(context.Background(), cfg); err == nil {
+		t.Fatal("testConnection() expected error for unreachable server")
+	}
+}
+
+func TestVerifyConnection_CreatesToken(t *testing.T) {
+	server := newWizardServer(t)
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Server.URL = server.URL
+	cfg.Auth = AuthConfig{Username: "Admin", Password: "zabbix"}
+
+	// Accept the token offer with the default answer
+	reader := bufio.NewReader(strings.NewReader("\n"))
+	if err := verifyConnection(reader, cfg); err != nil {
+		t.Fatalf("verifyConnection() error = %v", err)
+	}
+	if cfg.Auth.Token != "created" {
+		t.Errorf("token = %q, want the created token", cfg.Auth.Token)
+	}
+	if cfg.Auth.Username != "" || cfg.Auth.Password != "" {
+		t.Error("password should not be saved when a token was created")
+	}
+}
+
+func TestVerifyConnection_DeclineToken(t *testing.T) {
+	server := newWizardServer(t)
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Server.URL = server.URL
+	cfg.Auth = AuthConfig{Username: "Admin", Password: "zabbix"}
+
+	reader := bufio.NewReader(strings.NewReader("n\n"))
+	if err := verifyConnection(reader, cfg); err != nil {
+		t.Fatalf("verifyConnection() error = %v", err)
+	}
+	if cfg.Auth.Token != "" || cfg.Auth.Password != "zabbix" {
+		t.Error("declining the token should keep the password")
+	}
+}
+
+func TestVerifyConnection_FailureSaveAnyway(t *testing.T) {
+	server := newWizardServer(t)
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Server.URL = server.URL
+	cfg.Auth.Token = "bad-token"
+
+	if err := verifyConnection(bufio.NewReader(strings.NewReader("\n")), cfg); err == nil {
+		t.Error("expected an error when not saving after a failed test")
+	}
+	if err := verifyConnection(bufio.NewReader(strings.NewReader("y\n")), cfg); err != nil {
+		t.Errorf("verifyConnection() error = %v, want nil when saving anyway", err)
+	}
+}
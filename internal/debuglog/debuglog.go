@@ -0,0 +1,174 @@
+// Package debuglog records Zabbix API calls for troubleshooting.
+// Entries are kept in a fixed-size ring buffer and optionally appended to a file.
+package debuglog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/harpchad/chotko/internal/format"
+)
+
+// DefaultCapacity is the number of entries kept in memory.
+const DefaultCapacity = 500
+
+// Entry is a single recorded API call.
+type Entry struct {
+	Time          time.Time
+	Method        string
+	Duration      time.Duration
+	RequestBytes  int
+	ResponseBytes int
+	Err           string
+}
+
+// String formats the entry as a single log line.
+func (e Entry) String() string {
+	line := fmt.Sprintf("%s %-24s %7s  req=%s resp=%s",
+		e.Time.Format("15:04:05.000"),
+		e.Method,
+		e.Duration.Round(time.Millisecond),
+		size(e.RequestBytes),
+		size(e.ResponseBytes))
+	if e.Err != "" {
+		line += "  error: " + e.Err
+	}
+	return line
+}
+
+// size formats a payload size, e.g. "512B" or "20.0K".
+func size(n int) string {
+	if n < 1024 {
+		return fmt.Sprintf("%dB", n)
+	}
+	return format.BytesShort(float64(n))
+}
+
+// Log is a thread-safe ring buffer of API call entries.
+type Log struct {
+	mu      sync.Mutex
+	entries []Entry
+	next    int  // Index of the next write
+	full    bool // Buffer has wrapped around
+	enabled bool
+	file    *os.File
+	path    string
+}
+
+// New creates a disabled log holding up to capacity entries.
+func New(capacity int) *Log {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Log{
+		entries: make([]Entry, capacity),
+	}
+}
+
+// Enable starts recording and appends entries to the file at path.
+// Pass an empty path to record in memory only.
+func (l *Log) Enable(path string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if path != "" && l.file == nil {
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return fmt.Errorf("failed to create log directory: %w", err)
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to open debug log: %w", err)
+		}
+		l.file = f
+		l.path = path
+	}
+
+	l.enabled = true
+	return nil
+}
+
+// Disable stops recording and closes the log file.
+// Entries already in memory are kept.
+func (l *Log) Disable() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.enabled = false
+	return l.closeFile()
+}
+
+// Enabled returns true if calls are being recorded.
+func (l *Log) Enabled() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.enabled
+}
+
+// Path returns the log file path, or empty if logging to memory only.
+func (l *Log) Path() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.path
+}
+
+// Record adds an entry. It is a no-op while the log is disabled.
+func (l *Log) Record(e Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.enabled {
+		return
+	}
+
+	l.entries[l.next] = e
+	l.next = (l.next + 1) % len(l.entries)
+	if l.next == 0 {
+		l.full = true
+	}
+
+	if l.file != nil {
+		// Best effort: a failing log file must not break the UI
+		_, _ = fmt.Fprintln(l.file, e.Time.Format(time.RFC3339Nano)+" "+e.String())
+	}
+}
+
+// Entries returns the recorded entries, oldest first.
+func (l *Log) Entries() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.full {
+		out := make([]Entry, l.next)
+		copy(out, l.entries[:l.next])
+		return out
+	}
+
+	out := make([]Entry, 0, len(l.entries))
+	out = append(out, l.entries[l.next:]...)
+	out = append(out, l.entries[:l.next]...)
+	return out
+}
+
+// Close closes the log file, if any.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.closeFile()
+}
+
+// closeFile closes the log file. The caller must hold l.mu.
+func (l *Log) closeFile() error {
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	l.path = ""
+	if err != nil {
+		return fmt.Errorf("failed to close debug log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package debuglog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLog_DisabledIsNoop(t *testing.T) {
+	l := New(3)
+	l.Record(Entry{Method: "problem.get"})
+	if got := len(l.Entries()); got != 0 {
+		t.Errorf("Entries() len = %d, want 0", got)
+	}
+}
+
+func TestLog_RingBuffer(t *testing.T) {
+	l := New(3)
+	if err := l.Enable(""); err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+
+	for _, method := range []string{"a", "b", "c", "d", "e"} {
+		l.Record(Entry{Method: method})
+	}
+
+	entries := l.Entries()
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Method)
+	}
+	if strings.Join(got, ",") != "c,d,e" {
+		t.Errorf("Entries() = %v, want [c d e]", got)
+	}
+}
+
+func TestLog_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "debug.log")
+	l := New(10)
+	if err := l.Enable(path); err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+	if l.Path() != path {
+		t.Errorf("Path() = %q, want %q", l.Path(), path)
+	}
+
+	l.Record(Entry{
+		Time:          time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC),
+		Method:        "host.get",
+		Duration:      120 * time.Millisecond,
+		RequestBytes:  100,
+		ResponseBytes: 4096,
+		Err:           errors.New("boom").Error(),
+	})
+	if err := l.Disable(); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+	l.Record(Entry{Method: "ignored"})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	line := string(data)
+	for _, want := range []string{"host.get", "120ms", "req=100B", "resp=4.0K", "error: boom"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("log line %q missing %q", line, want)
+		}
+	}
+	if strings.Contains(line, "ignored") {
+		t.Error("entry recorded after Disable()")
+	}
+}
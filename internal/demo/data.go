@@ -0,0 +1,409 @@
+package demo
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// hostDef describes a simulated host. Kinds select its triggers and items.
+type hostDef struct {
+	name   string
+	ip     string
+	groups []string
+	kinds  []string
+}
+
+var hostDefs = []hostDef{
+	{"web-01", "10.0.1.11", []string{"Linux servers", "Web servers"}, []string{"linux", "web"}},
+	{"web-02", "10.0.1.12", []string{"Linux servers", "Web servers"}, []string{"linux", "web"}},
+	{"web-03", "10.0.1.13", []string{"Linux servers", "Web servers"}, []string{"linux", "web"}},
+	{"app-01", "10.0.1.21", []string{"Linux servers"}, []string{"linux"}},
+	{"app-02", "10.0.1.22", []string{"Linux servers"}, []string{"linux"}},
+	{"app-03", "10.0.1.23", []string{"Linux servers"}, []string{"linux"}},
+	{"db-primary", "10.0.2.10", []string{"Linux servers", "Databases"}, []string{"linux", "db"}},
+	{"db-replica-01", "10.0.2.11", []string{"Linux servers", "Databases"}, []string{"linux", "db"}},
+	{"db-replica-02", "10.0.2.12", []string{"Linux servers", "Databases"}, []string{"linux", "db"}},
+	{"cache-01", "10.0.2.20", []string{"Linux servers"}, []string{"linux"}},
+	{"k8s-node-01", "10.0.3.11", []string{"Linux servers", "Kubernetes nodes"}, []string{"linux", "k8s"}},
+	{"k8s-node-02", "10.0.3.12", []string{"Linux servers", "Kubernetes nodes"}, []string{"linux", "k8s"}},
+	{"k8s-node-03", "10.0.3.13", []string{"Linux servers", "Kubernetes nodes"}, []string{"linux", "k8s"}},
+	{"k8s-node-04", "10.0.3.14", []string{"Linux servers", "Kubernetes nodes"}, []string{"linux", "k8s"}},
+	{"mail-01", "10.0.1.31", []string{"Linux servers"}, []string{"linux"}},
+	{"backup-01", "10.0.1.41", []string{"Linux servers"}, []string{"linux"}},
+	{"core-sw-01", "10.0.0.2", []string{"Network"}, []string{"network"}},
+	{"core-sw-02", "10.0.0.3", []string{"Network"}, []string{"network"}},
+	{"edge-rtr-01", "10.0.0.1", []string{"Network"}, []string{"network"}},
+	{"fw-01", "10.0.0.4", []string{"Network"}, []string{"network"}},
+	{"ad-dc-01", "10.0.4.10", []string{"Windows servers"}, []string{"windows"}},
+	{"file-srv-01", "10.0.4.11", []string{"Windows servers"}, []string{"windows"}},
+	{"rdp-gw-01", "10.0.4.12", []string{"Windows servers"}, []string{"windows"}},
+}
+
+// groupIDs are the IDs of the simulated host groups.
+var groupIDs = map[string]string{
+	"Linux servers":    "2",
+	"Databases":        "21",
+	"Network":          "22",
+	"Kubernetes nodes": "23",
+	"Windows servers":  "24",
+	"Web servers":      "25",
+}
+
+// templateDef describes a template. It is linked to the hosts of its host
+// group, and the templates in templates are linked to it.
+type templateDef struct {
+	id        string
+	name      string
+	group     string
+	templates []string
+	macros    map[string]string
+}
+
+var templateDefs = []templateDef{
+	{"10001", "Linux by Zabbix agent", "Linux servers", []string{"10010"}, map[string]string{
+		"{$CPU.UTIL.CRIT}":             "90",
+		"{$LOAD_AVG_PER_CPU.MAX.WARN}": "1.5",
+		"{$MEMORY.UTIL.MAX}":           "90",
+		"{$VFS.FS.PUSED.MAX.CRIT}":     "90",
+		"{$VFS.FS.PUSED.MAX.WARN}":     "80",
+	}},
+	{"10002", "Windows by Zabbix agent", "Windows servers", []string{"10010"}, map[string]string{
+		"{$CPU.UTIL.CRIT}":         "90",
+		"{$MEMORY.UTIL.MAX}":       "90",
+		"{$VFS.FS.PUSED.MAX.CRIT}": "90",
+	}},
+	{"10003", "Network Generic Device by SNMP", "Network", nil, map[string]string{
+		"{$ICMP_LOSS_WARN}":          "20",
+		"{$ICMP_RESPONSE_TIME_WARN}": "0.15",
+		"{$IF.UTIL.MAX}":             "95",
+		"{$SNMP.TIMEOUT}":            "5m",
+	}},
+	{"10010", "Zabbix agent", "", nil, map[string]string{
+		"{$AGENT.TIMEOUT}": "5m",
+	}},
+}
+
+// globalMacros are the global user macros.
+var globalMacros = map[string]string{
+	"{$MEMORY.UTIL.MAX}": "95",
+	"{$SNMP_COMMUNITY}":  "public",
+}
+
+// triggerDef describes a trigger template. {HOST} in the expression is
+// replaced with the host name, and in the description with the {HOST.NAME}
+// macro, resolved in event names and by trigger.get with expandDescription.
+type triggerDef struct {
+	description string
+	expression  string
+	priority    int
+	tags        []zabbix.Tag
+}
+
+func tags(kv ...string) []zabbix.Tag {
+	t := make([]zabbix.Tag, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		t = append(t, zabbix.Tag{Tag: kv[i], Value: kv[i+1]})
+	}
+	return t
+}
+
+var triggerDefs = map[string][]triggerDef{
+	"linux": {
+		{"High CPU utilization (over 90% for 5m)", "min(/{HOST}/system.cpu.util,5m)>90", 2, tags("component", "cpu", "scope", "performance")},
+		{"Load average is too high (per CPU load over 1.5 for 5m)", "min(/{HOST}/system.cpu.load[all,avg1],5m)/last(/{HOST}/system.cpu.num)>1.5", 2, tags("component", "cpu", "scope", "performance")},
+		{"High memory utilization (>90% for 5m)", "min(/{HOST}/vm.memory.utilization,5m)>90", 3, tags("component", "memory", "scope", "capacity")},
+		{"/: Disk space is low (used > 80%)", "last(/{HOST}/vfs.fs.size[/,pused])>80", 2, tags("component", "storage", "scope", "capacity")},
+		{"/: Disk space is critically low (used > 90%)", "last(/{HOST}/vfs.fs.size[/,pused])>90", 3, tags("component", "storage", "scope", "capacity")},
+		{"Zabbix agent is not available (for 3m)", "max(/{HOST}/agent.ping,3m)=0", 3, tags("component", "system", "scope", "availability")},
+		{"System time is out of sync (diff with Zabbix server > 60s)", "fuzzytime(/{HOST}/system.localtime,60)=0", 2, tags("component", "system")},
+		{"{HOST} has been restarted (uptime < 10m)", "last(/{HOST}/system.uptime)<10m", 1, tags("component", "system", "scope", "notice")},
+	},
+	"web": {
+		{"Nginx: Service is down", "last(/{HOST}/net.tcp.service[http,,80])=0", 4, tags("component", "application", "service", "nginx")},
+		{"Nginx: Failed to fetch stub status page (or no data for 30m)", "nodata(/{HOST}/web.page.get[localhost,basic_status,80],30m)=1", 2, tags("component", "application", "service", "nginx")},
+		{"Nginx: Version has changed (new version received)", "change(/{HOST}/nginx.version)=1", 1, tags("component", "application", "service", "nginx")},
+	},
+	"db": {
+		{"MySQL: Service is down", "last(/{HOST}/mysql.ping)=0", 4, tags("component", "application", "service", "mysql")},
+		{"MySQL: Replication lag is too high (over 30s for 5m)", "min(/{HOST}/mysql.seconds_behind_master,5m)>30", 3, tags("component", "replication", "service", "mysql")},
+		{"MySQL: Refused connections (max_connections limit reached)", "last(/{HOST}/mysql.connection_errors_max_connections.rate)>0", 3, tags("component", "connections", "service", "mysql")},
+		{"MySQL: Buffer pool utilization is too low (less than 50% for 5m)", "max(/{HOST}/mysql.buffer_pool_utilization,5m)<50", 1, tags("component", "memory", "service", "mysql")},
+		{"MySQL: Cluster has lost quorum", "last(/{HOST}/mysql.wsrep_cluster_status)<>1", 5, tags("component", "replication", "service", "mysql")},
+	},
+	"k8s": {
+		{"Kubelet: Node is not ready", "last(/{HOST}/kube.node.ready)=0", 4, tags("component", "kubernetes", "scope", "availability")},
+		{"Kubelet: Pod is crash looping (namespace: payments)", "last(/{HOST}/kube.pod.restarts[payments])>5", 3, tags("component", "kubernetes", "namespace", "payments")},
+		{"Kubelet: Too many pods (over 95% of capacity)", "last(/{HOST}/kube.node.pods)/last(/{HOST}/kube.node.capacity.pods)>0.95", 2, tags("component", "kubernetes", "scope", "capacity")},
+	},
+	"network": {
+		{"Unavailable by ICMP ping", "max(/{HOST}/icmpping,#3)=0", 4, tags("component", "network", "scope", "availability")},
+		{"High ICMP ping loss", "min(/{HOST}/icmppingloss,5m)>20", 2, tags("component", "network", "scope", "performance")},
+		{"High ICMP ping response time", "avg(/{HOST}/icmppingsec,5m)>0.15", 2, tags("component", "network", "scope", "performance")},
+		{"Interface Gi0/1(Uplink): Link down", "last(/{HOST}/net.if.status[ifOperStatus.1])=2", 3, tags("component", "network", "interface", "Gi0/1")},
+		{"Interface Gi0/24(Server farm): High bandwidth usage (>90%)", "min(/{HOST}/net.if.in[ifHCInOctets.24],15m)>0.9*1G", 2, tags("component", "network", "interface", "Gi0/24")},
+		{"Device has been replaced (new serial number received)", "change(/{HOST}/system.hw.serialnumber)=1", 1, tags("component", "inventory")},
+	},
+	"windows": {
+		{"Windows: High CPU utilization (over 90% for 5m)", "min(/{HOST}/system.cpu.util,5m)>90", 2, tags("component", "cpu", "scope", "performance")},
+		{"Windows: C:: Disk space is critically low (used > 90%)", "last(/{HOST}/vfs.fs.size[C:,pused])>90", 3, tags("component", "storage", "scope", "capacity")},
+		{"Windows: Zabbix agent is not available (for 3m)", "max(/{HOST}/agent.ping,3m)=0", 3, tags("component", "system", "scope", "availability")},
+		{`Windows: "Print Spooler" (Spooler) is not running`, `last(/{HOST}/service.info["Spooler",state])<>0`, 2, tags("component", "service")},
+	},
+}
+
+// triggerDependencies maps trigger descriptions to the trigger on the same
+// host they depend on, as in the Zabbix templates.
+var triggerDependencies = map[string]string{
+	"Nginx: Failed to fetch stub status page (or no data for 30m)": "Nginx: Service is down",
+	"MySQL: Replication lag is too high (over 30s for 5m)":         "MySQL: Service is down",
+	"MySQL: Refused connections (max_connections limit reached)":   "MySQL: Service is down",
+	"Kubelet: Pod is crash looping (namespace: payments)":          "Kubelet: Node is not ready",
+	"High ICMP ping loss":          "Unavailable by ICMP ping",
+	"High ICMP ping response time": "Unavailable by ICMP ping",
+}
+
+// uplink returns the network device a host is reached through, or "" for
+// the edge router. A host's unavailability trigger depends on the uplink's.
+func uplink(def hostDef) string {
+	switch {
+	case def.name == "edge-rtr-01":
+		return ""
+	case slices.Contains(def.groups, "Network"):
+		return "edge-rtr-01"
+	case slices.Contains(def.groups, "Windows servers"):
+		return "core-sw-02"
+	default:
+		return "core-sw-01"
+	}
+}
+
+// valueMaps are the value maps of demo items.
+var valueMaps = []zabbix.ValueMap{
+	{ValueMapID: "1", Name: "IF-MIB::ifOperStatus", Mappings: []zabbix.ValueMapping{
+		{Type: zabbix.MappingEqual, Value: "1", NewValue: "up"},
+		{Type: zabbix.MappingEqual, Value: "2", NewValue: "down"},
+		{Type: zabbix.MappingEqual, Value: "3", NewValue: "testing"},
+		{Type: zabbix.MappingEqual, Value: "5", NewValue: "dormant"},
+		{Type: zabbix.MappingEqual, Value: "7", NewValue: "lowerLayerDown"},
+	}},
+}
+
+// itemValueMaps are the IDs of the value maps of items, by item key.
+var itemValueMaps = map[string]string{
+	"net.if.status[ifOperStatus.1]": "1",
+}
+
+// itemPreprocessing are the preprocessing steps of items, by item key.
+var itemPreprocessing = map[string][]zabbix.PreprocessingStep{
+	"net.if.in[ifHCInOctets.1]": {
+		{Type: "10", ErrorHandler: zabbix.PreprocessingErrorDefault}, // Change per second
+		{Type: "1", Params: "8", ErrorHandler: zabbix.PreprocessingErrorDefault},
+	},
+	"net.if.out[ifHCOutOctets.1]": {
+		{Type: "10", ErrorHandler: zabbix.PreprocessingErrorDefault},
+		{Type: "1", Params: "8", ErrorHandler: zabbix.PreprocessingErrorDefault},
+	},
+	"net.if.status[ifOperStatus.1]": {
+		{Type: "20", Params: "6h", ErrorHandler: zabbix.PreprocessingErrorDefault}, // Discard unchanged with heartbeat
+	},
+}
+
+// housekeeping are the housekeeping settings, the defaults of Zabbix 7.0.
+var housekeeping = zabbix.Housekeeping{
+	EventsMode: "1", EventsTrigger: "365d",
+	HistoryMode: "1", HistoryGlobal: "0", History: "31d",
+	TrendsMode: "1", TrendsGlobal: "0", Trends: "365d",
+	AuditMode: "1", Audit: "31d",
+	SessionsMode: "1", Sessions: "365d",
+}
+
+// settings are the global settings of settings.get, the defaults of
+// Zabbix 7.0.
+var settings = map[string]string{
+	"severity_name_0":  "Not classified",
+	"severity_name_1":  "Information",
+	"severity_name_2":  "Warning",
+	"severity_name_3":  "Average",
+	"severity_name_4":  "High",
+	"severity_name_5":  "Disaster",
+	"severity_color_0": "97AAB3",
+	"severity_color_1": "7499FF",
+	"severity_color_2": "FFC859",
+	"severity_color_3": "FFA059",
+	"severity_color_4": "E97659",
+	"severity_color_5": "E45959",
+	"work_period":      "1-5,09:00-18:00",
+	"default_timezone": "system",
+	"ok_period":        "5m",
+	"blink_period":     "2m",
+}
+
+// dashboards are the demo dashboards. Item widgets name their item by key,
+// which getDashboards replaces with the ID of the first item with the key.
+var dashboards = []zabbix.Dashboard{
+	{DashboardID: "1", Name: "Global view", Pages: []zabbix.DashboardPage{
+		{DashboardPageID: "1", Name: "Overview", Widgets: []zabbix.Widget{
+			{
+				WidgetID: "1", Type: zabbix.WidgetTypeProblems, Name: "Current problems", X: "0", Y: "0", Width: "36", Height: "6",
+				Fields: []zabbix.WidgetField{{Type: "0", Name: "show_lines", Value: "10"}},
+			},
+			{
+				WidgetID: "2", Type: zabbix.WidgetTypeSVGGraph, Name: "CPU utilization", X: "36", Y: "0", Width: "36", Height: "6",
+				Fields: []zabbix.WidgetField{
+					{Type: "1", Name: "ds.0.hosts.0", Value: "*"},
+					{Type: "1", Name: "ds.0.items.0", Value: "CPU utilization"},
+				},
+			},
+		}},
+		{DashboardPageID: "2", Name: "Network", Widgets: []zabbix.Widget{
+			{
+				WidgetID: "3", Type: zabbix.WidgetTypeSVGGraph, Name: "Uplink traffic", X: "0", Y: "0", Width: "48", Height: "6",
+				Fields: []zabbix.WidgetField{
+					{Type: "1", Name: "ds.0.hosts.0", Value: "*"},
+					{Type: "1", Name: "ds.0.items.0", Value: "Interface Gi0/1(Uplink): Bits*"},
+				},
+			},
+			{
+				WidgetID: "4", Type: zabbix.WidgetTypeItem, Name: "Uplink status", X: "48", Y: "0", Width: "24", Height: "6",
+				Fields: []zabbix.WidgetField{{Type: "4", Name: "itemid.0", Value: "net.if.status[ifOperStatus.1]"}},
+			},
+		}},
+	}},
+	{DashboardID: "2", Name: "High severity problems", Pages: []zabbix.DashboardPage{
+		{DashboardPageID: "3", Widgets: []zabbix.Widget{
+			{
+				WidgetID: "5", Type: zabbix.WidgetTypeProblems, Name: "High and disaster", X: "0", Y: "0", Width: "72", Height: "8",
+				Fields: []zabbix.WidgetField{
+					{Type: "0", Name: "severities.0", Value: "4"},
+					{Type: "0", Name: "severities.1", Value: "5"},
+				},
+			},
+			{WidgetID: "6", Type: "clock", X: "0", Y: "8", Width: "24", Height: "3"},
+		}},
+	}},
+}
+
+// itemDef describes an item template. Values follow a daily wave around
+// base, with per-minute noise, clamped to [lo, hi]. Log items log lines of
+// syslogMessages instead.
+type itemDef struct {
+	name      string
+	key       string
+	valueType string
+	units     string
+	base      float64
+	amplitude float64
+	noise     float64
+	lo, hi    float64
+}
+
+var itemDefs = map[string][]itemDef{
+	"linux": {
+		{"CPU utilization", "system.cpu.util", zabbix.ItemValueTypeFloat, "%", 30, 15, 6, 0, 100},
+		{"Load average (1m avg)", "system.cpu.load[all,avg1]", zabbix.ItemValueTypeFloat, "", 1.2, 0.6, 0.3, 0, 64},
+		{"Memory utilization", "vm.memory.utilization", zabbix.ItemValueTypeFloat, "%", 55, 10, 2, 0, 100},
+		{"/: Space utilization", "vfs.fs.size[/,pused]", zabbix.ItemValueTypeFloat, "%", 62, 1, 0.2, 0, 100},
+		{"Interface eth0: Bits received", `net.if.in["eth0"]`, zabbix.ItemValueTypeUnsigned, "bps", 2e7, 1.5e7, 4e6, 0, 1e9},
+		{"Number of processes", "proc.num", zabbix.ItemValueTypeUnsigned, "", 180, 20, 6, 0, math.MaxInt32},
+		{"Syslog", "log[/var/log/syslog]", zabbix.ItemValueTypeLog, "", 0, 0, 0, 0, 0},
+	},
+	"network": {
+		{"CPU utilization", "system.cpu.util[snmp]", zabbix.ItemValueTypeFloat, "%", 15, 8, 3, 0, 100},
+		{"Interface Gi0/1(Uplink): Bits received", "net.if.in[ifHCInOctets.1]", zabbix.ItemValueTypeUnsigned, "bps", 3e8, 2e8, 4e7, 0, 1e9},
+		{"Interface Gi0/1(Uplink): Bits sent", "net.if.out[ifHCOutOctets.1]", zabbix.ItemValueTypeUnsigned, "bps", 2e8, 1.2e8, 3e7, 0, 1e9},
+		{"Interface Gi0/1(Uplink): Operational status", "net.if.status[ifOperStatus.1]", zabbix.ItemValueTypeUnsigned, "", 1, 0, 0, 1, 1},
+	},
+	"windows": {
+		{"CPU utilization", "system.cpu.util", zabbix.ItemValueTypeFloat, "%", 20, 12, 5, 0, 100},
+		{"Memory utilization", "vm.memory.utilization", zabbix.ItemValueTypeFloat, "%", 65, 8, 2, 0, 100},
+		{"C:: Space utilization", "vfs.fs.size[C:,pused]", zabbix.ItemValueTypeFloat, "%", 71, 1, 0.2, 0, 100},
+		{"Interface Ethernet0: Bits received", `net.if.in["Ethernet0"]`, zabbix.ItemValueTypeUnsigned, "bps", 8e6, 6e6, 2e6, 0, 1e9},
+	},
+}
+
+// syslogMessages are the lines logged by log items, after the time and
+// host name.
+var syslogMessages = []string{
+	"systemd[1]: Started Session 4123 of User deploy.",
+	"sshd[20931]: Accepted publickey for deploy from 10.0.0.15 port 52114 ssh2",
+	"CRON[21877]: (root) CMD (/usr/local/bin/backup.sh --incremental)",
+	"kernel: [UFW BLOCK] IN=eth0 OUT= SRC=203.0.113.7 DST=10.0.1.10 PROTO=TCP DPT=23",
+	"nginx[1420]: upstream timed out (110: Connection timed out) while reading response header from upstream",
+	"systemd[1]: Starting Daily apt upgrade and clean activities...",
+	"kernel: EXT4-fs (sda1): error count since last fsck: 2",
+}
+
+// ackMessages are used for problems acknowledged by the simulated team.
+var ackMessages = []string{
+	"Looking into it",
+	"Known issue, fix scheduled for tonight",
+	"Escalated to the on-call DBA",
+	"Caused by the deploy at 14:00, rolling back",
+	"",
+}
+
+// ackUsers are the simulated team members.
+var ackUsers = []string{"alice", "bob", "carol"}
+
+// expand replaces {HOST} in a template with the host name.
+func expand(template, host string) string {
+	return strings.ReplaceAll(template, "{HOST}", host)
+}
+
+// value returns the simulated value of an item at a Unix time. It depends
+// only on the item and the minute, so repeated queries agree.
+func (it *item) value(clock int64) float64 {
+	day := 2 * math.Pi * float64(clock) / 86400
+	v := it.base + it.amplitude*math.Sin(day+it.phase) + it.noise*noise(it.seed, clock/60)
+	return math.Max(it.lo, math.Min(it.hi, v))
+}
+
+// valueAt returns the value of an item in the minute starting at clock, as
+// returned by the API, or false if a log item logged nothing then. Like
+// values, log lines depend only on the item and the minute.
+func (it *item) valueAt(clock int64) (string, bool) {
+	if it.ValueType != zabbix.ItemValueTypeLog {
+		return it.formatValue(it.value(clock)), true
+	}
+	step := clock / 60
+	if noise(it.seed, step) < 0.2 {
+		return "", false
+	}
+	n := len(syslogMessages)
+	msg := syslogMessages[min(int((noise(it.seed+1, step)+1)/2*float64(n)), n-1)]
+	return time.Unix(clock, 0).Format("Jan _2 15:04:05 ") + it.host.Host + " " + msg, true
+}
+
+// lastValue returns the latest value of an item at or before clock, and
+// when it was collected.
+func (it *item) lastValue(clock int64) (string, int64) {
+	for c := clock; c > clock-86400; c -= 60 {
+		if v, ok := it.valueAt(c); ok {
+			return v, c
+		}
+	}
+	return "", 0
+}
+
+// formatValue formats a value for the item's value type.
+func (it *item) formatValue(v float64) string {
+	if it.ValueType == zabbix.ItemValueTypeUnsigned {
+		return fmt.Sprintf("%d", int64(v))
+	}
+	return fmt.Sprintf("%.4f", v)
+}
+
+// noise returns a deterministic pseudo-random number in [-1, 1] for a seed
+// and step, using the splitmix64 mixing function.
+func noise(seed uint64, step int64) float64 {
+	z := seed + uint64(step)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+	return float64(z>>11)/float64(1<<53)*2 - 1
+}
@@ -0,0 +1,614 @@
+// Package demo simulates a Zabbix server with randomized hosts, problems,
+// events and history, so that chotko can be tried without one.
+//
+// Server implements http.RoundTripper and answers the JSON-RPC calls chotko
+// makes; use it with zabbix.WithTransport. Problems start and resolve over
+// time, and acknowledgements and configuration changes are kept in memory.
+package demo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// URL is the server URL to use in demo mode. Requests never leave the
+// process, so it does not need to resolve.
+const URL = "http://demo.invalid"
+
+// Version is the Zabbix version the demo server reports.
+const Version = "7.0.0"
+
+const (
+	changeInterval     = 20 * time.Second   // How often a problem starts or resolves
+	historyWindow      = 3 * 24 * time.Hour // How far back resolved problems go
+	resolvedCount      = 90                 // Number of resolved problems in the window
+	initialActive      = 10                 // Number of active problems at start
+	minActive          = 6
+	maxActive          = 18
+	firstEventID       = 1000
+	firstHostID        = 10101
+	firstTriggerID     = 20001
+	firstItemID        = 30001
+	firstHostMacro     = 40001
+	firstMaintID       = 50001
+	firstTemplateMacro = 60001
+	maxChangeBursts    = 5 // Changes applied at once after a long pause
+)
+
+// trigger is a simulated trigger on a host.
+type trigger struct {
+	zabbix.Trigger
+	host         *zabbix.Host
+	tags         []zabbix.Tag
+	template     string     // Description before host expansion
+	dependencies []*trigger // Triggers this one depends on
+}
+
+// problem is a simulated problem event and its recovery.
+type problem struct {
+	eventID       int64
+	recoveryID    int64 // 0 while active
+	trigger       *trigger
+	clock         int64
+	recoveryClock int64
+	severity      int
+	acknowledged  bool
+	suppressed    bool
+	suppressUntil int64        // When a user's suppression ends; 0 for indefinitely
+	suppressedBy  string       // The ID of the user who suppressed the problem; empty for a maintenance
+	acks          []zabbix.Ack // Oldest first
+}
+
+func (p *problem) active() bool {
+	return p.recoveryID == 0
+}
+
+// item is a simulated numeric item.
+type item struct {
+	zabbix.Item
+	itemDef
+	host  *zabbix.Host
+	phase float64
+	seed  uint64
+}
+
+// Server is a simulated Zabbix API server. It is safe for concurrent use.
+type Server struct {
+	mu          sync.Mutex
+	rng         *rand.Rand
+	now         func() time.Time
+	hosts       []*zabbix.Host
+	triggers    []*trigger
+	items       []*item
+	problems    []*problem // Ordered by event ID
+	nextEventID int64
+	nextAckID   int64
+	nextMacroID int64
+	lastChange  time.Time
+
+	maintenances []*zabbix.Maintenance
+	nextMaintID  int64
+	// Host groups of maintenances, by maintenance ID; their hosts are
+	// those whose MaintenanceID is theirs
+	maintenanceGroups map[string][]string
+}
+
+// New creates a demo server with data generated from seed.
+func New(seed uint64) *Server {
+	return newServer(seed, time.Now)
+}
+
+func newServer(seed uint64, now func() time.Time) *Server {
+	s := &Server{
+		rng:         rand.New(rand.NewPCG(seed, seed^0x5eed)),
+		now:         now,
+		nextMacroID: firstHostMacro,
+		nextMaintID: firstMaintID,
+	}
+	s.generate()
+	return s
+}
+
+// generate creates the hosts, triggers, items and problem history.
+func (s *Server) generate() {
+	now := s.now()
+
+	for i, def := range hostDefs {
+		s.hosts = append(s.hosts, s.newHost(firstHostID+i, def))
+	}
+	for i, def := range hostDefs {
+		h := s.hosts[i]
+		for _, kind := range def.kinds {
+			for _, td := range triggerDefs[kind] {
+				// Problems of minor triggers can be closed manually
+				manualClose := zabbix.ManualCloseNotAllowed
+				if td.priority <= 2 {
+					manualClose = zabbix.ManualCloseAllowed
+				}
+				t := &trigger{
+					Trigger: zabbix.Trigger{
+						TriggerID:   strconv.Itoa(firstTriggerID + len(s.triggers)),
+						Description: expand(td.description, "{HOST.NAME}"),
+						Expression:  expand(td.expression, h.Host),
+						Priority:    strconv.Itoa(td.priority),
+						Status:      zabbix.TriggerStatusEnabled,
+						ManualClose: manualClose,
+					},
+					host:     h,
+					tags:     td.tags,
+					template: td.description,
+				}
+				s.triggers = append(s.triggers, t)
+			}
+			for _, idef := range itemDefs[kind] {
+				s.items = append(s.items, s.newItem(firstItemID+len(s.items), h, idef))
+			}
+		}
+	}
+
+	s.addDependencies()
+
+	// One host is in maintenance and one is unreachable
+	maintenance := s.hosts[s.rng.IntN(len(s.hosts))]
+	s.startMaintenance("Planned upgrade", now.Add(-30*time.Minute), 4*time.Hour, zabbix.MaintenanceWithData, maintenance)
+	s.addPatchWindow(now)
+	down := s.hosts[s.rng.IntN(len(s.hosts))]
+	for down == maintenance {
+		down = s.hosts[s.rng.IntN(len(s.hosts))]
+	}
+	for i := range down.Interfaces {
+		iface := &down.Interfaces[i]
+		iface.Available = "2"
+		iface.Error = fmt.Sprintf("Get value from agent failed: cannot connect to [[%s]:%s]: [113] No route to host",
+			iface.IP, iface.Port)
+		iface.ErrorsFrom = strconv.FormatInt(now.Unix()-600, 10)
+	}
+	down.ActiveAvailable = "2"
+
+	// One file system has been unmounted
+	var filesystems []*item
+	for _, it := range s.items {
+		if strings.HasPrefix(it.Key, "vfs.fs.size") {
+			filesystems = append(filesystems, it)
+		}
+	}
+	if len(filesystems) > 0 {
+		it := filesystems[len(filesystems)/2]
+		it.State = zabbix.ItemStateNotSupported
+		it.Error = "Cannot obtain filesystem information: [2] No such file or directory"
+	}
+
+	// Event IDs increase with time, like in Zabbix
+	var problems []*problem
+	window := int64(historyWindow.Seconds())
+	for range resolvedCount {
+		t := s.triggers[s.rng.IntN(len(s.triggers))]
+		clock := now.Unix() - window + s.rng.Int64N(window)
+		duration := 120 + int64(math.Pow(s.rng.Float64(), 2)*3*3600)
+		p := &problem{trigger: t, clock: clock, recoveryClock: min(clock+duration, now.Unix()-60)}
+		if p.recoveryClock <= p.clock {
+			continue
+		}
+		problems = append(problems, p)
+	}
+	if t := s.unavailabilityTrigger(down); t != nil {
+		clock := now.Unix() - 600 - s.rng.Int64N(7200)
+		for i := range down.Interfaces {
+			down.Interfaces[i].ErrorsFrom = strconv.FormatInt(clock, 10)
+		}
+		problems = append(problems, &problem{trigger: t, clock: clock})
+		// The outage sets off problems on related hosts shortly after
+		for _, r := range s.stormTriggers(down, t) {
+			problems = append(problems, &problem{trigger: r, clock: clock + 30 + s.rng.Int64N(240)})
+		}
+	}
+	for len(problems) < resolvedCount+initialActive {
+		t := s.freeTrigger(problems)
+		if t == nil {
+			break
+		}
+		clock := now.Unix() - int64(math.Pow(s.rng.Float64(), 2)*2*86400)
+		problems = append(problems, &problem{trigger: t, clock: clock})
+	}
+	slices.SortStableFunc(problems, func(a, b *problem) int {
+		return int(a.clock - b.clock)
+	})
+
+	// Recoveries are numbered along with the problems, in time order
+	type event struct {
+		clock    int64
+		p        *problem
+		recovery bool
+	}
+	var events []event
+	for _, p := range problems {
+		events = append(events, event{p.clock, p, false})
+		if p.recoveryClock > 0 {
+			events = append(events, event{p.recoveryClock, p, true})
+		}
+	}
+	slices.SortStableFunc(events, func(a, b event) int {
+		return int(a.clock - b.clock)
+	})
+	s.nextEventID = firstEventID
+	for _, e := range events {
+		if e.recovery {
+			e.p.recoveryID = s.newEventID()
+		} else {
+			e.p.eventID = s.newEventID()
+		}
+	}
+
+	for _, p := range problems {
+		p.severity, _ = strconv.Atoi(p.trigger.Priority)
+		p.suppressed = p.trigger.host.InMaintenance()
+		if s.rng.Float64() < 0.35 {
+			end := now.Unix()
+			if !p.active() {
+				end = p.recoveryClock
+			}
+			ackClock := p.clock + s.rng.Int64N(max(1, end-p.clock))
+			user := ackUsers[s.rng.IntN(len(ackUsers))]
+			message := ackMessages[s.rng.IntN(len(ackMessages))]
+			s.acknowledge(p, zabbix.AcknowledgeParams{
+				Action:  zabbix.ActionAcknowledge | zabbix.ActionAddMessage,
+				Message: message,
+			}, user, ackClock)
+		}
+	}
+	s.problems = problems
+	s.lastChange = now
+}
+
+// newHost creates a host from its definition.
+func (s *Server) newHost(id int, def hostDef) *zabbix.Host {
+	hostID := strconv.Itoa(id)
+	iface := zabbix.Interface{
+		InterfaceID: strconv.Itoa(id),
+		IP:          def.ip,
+		Port:        "10050",
+		Type:        zabbix.InterfaceTypeAgent,
+		Main:        "1",
+		Available:   "1",
+	}
+	if slices.Contains(def.kinds, "network") {
+		iface.Port = "161"
+		iface.Type = zabbix.InterfaceTypeSNMP
+		iface.Details = &zabbix.InterfaceDetails{Version: zabbix.SNMPv2c, Bulk: "1", Community: "{$SNMP_COMMUNITY}"}
+	}
+
+	h := &zabbix.Host{
+		HostID:            hostID,
+		Host:              def.name,
+		Name:              def.name,
+		Status:            zabbix.HostStatusMonitored,
+		MaintenanceStatus: "0",
+		ActiveAvailable:   "1",
+		Interfaces:        []zabbix.Interface{iface},
+	}
+	for _, g := range def.groups {
+		h.Groups = append(h.Groups, zabbix.HostGroup{GroupID: groupIDs[g], Name: g})
+	}
+
+	if slices.Contains(def.kinds, "network") {
+		s.addMacro(h, "{$SNMP_COMMUNITY}", "", zabbix.MacroTypeSecret)
+		s.addMacro(h, "{$IF.UTIL.MAX}", "90", zabbix.MacroTypeText)
+	} else {
+		s.addMacro(h, "{$AGENT.TIMEOUT}", "3m", zabbix.MacroTypeText)
+		s.addMacro(h, "{$VFS.FS.PUSED.MAX.CRIT}", "90", zabbix.MacroTypeText)
+	}
+	return h
+}
+
+// newItem creates an item from its definition, varying its level per host.
+func (s *Server) newItem(id int, h *zabbix.Host, def itemDef) *item {
+	scale := 0.7 + 0.6*s.rng.Float64()
+	def.base *= scale
+	def.amplitude *= scale
+	itemType := zabbix.ItemTypeAgent
+	interfaceID := h.Interfaces[0].InterfaceID
+	switch {
+	case def.valueType == zabbix.ItemValueTypeLog:
+		// Logs are sent by active agents
+		itemType = zabbix.ItemTypeAgentActive
+		interfaceID = "0"
+	case h.Interfaces[0].Type == "2":
+		itemType = zabbix.ItemTypeSNMP
+	}
+	return &item{
+		Item: zabbix.Item{
+			ItemID:    strconv.Itoa(id),
+			HostID:    h.HostID,
+			Name:      def.name,
+			Key:       def.key,
+			Type:      itemType,
+			ValueType: def.valueType,
+			Units:     def.units,
+			State:     "0",
+			Status:    "0",
+
+			InterfaceID: interfaceID,
+			ValueMapID:  itemValueMaps[def.key],
+
+			Preprocessing: itemPreprocessing[def.key],
+		},
+		itemDef: def,
+		host:    h,
+		phase:   2 * math.Pi * s.rng.Float64(),
+		seed:    s.rng.Uint64(),
+	}
+}
+
+// addMacro adds a user macro to a host.
+func (s *Server) addMacro(h *zabbix.Host, macro, value, macroType string) {
+	h.Macros = append(h.Macros, zabbix.HostMacro{
+		HostMacroID: strconv.FormatInt(s.nextMacroID, 10),
+		HostID:      h.HostID,
+		Macro:       macro,
+		Value:       value,
+		Type:        macroType,
+	})
+	s.nextMacroID++
+}
+
+// addDependencies makes triggers depend on others on the same host, and
+// each host's unavailability trigger on that of its uplink.
+func (s *Server) addDependencies() {
+	for _, t := range s.triggers {
+		dep, ok := triggerDependencies[t.template]
+		if !ok {
+			continue
+		}
+		for _, d := range s.triggers {
+			if d.host == t.host && d.template == dep {
+				t.dependencies = append(t.dependencies, d)
+			}
+		}
+	}
+	for i, def := range hostDefs {
+		up := slices.IndexFunc(s.hosts, func(h *zabbix.Host) bool { return h.Host == uplink(def) })
+		if up < 0 {
+			continue
+		}
+		t, d := s.unavailabilityTrigger(s.hosts[i]), s.unavailabilityTrigger(s.hosts[up])
+		if t != nil && d != nil {
+			t.dependencies = append(t.dependencies, d)
+		}
+	}
+}
+
+// unavailabilityTrigger returns the trigger that fires when a host is
+// unreachable, if it has one.
+func (s *Server) unavailabilityTrigger(h *zabbix.Host) *trigger {
+	for _, t := range s.triggers {
+		if t.host == h && (strings.Contains(t.Expression, "/agent.ping,") ||
+			strings.Contains(t.Expression, "/icmpping,")) {
+			return t
+		}
+	}
+	return nil
+}
+
+// stormTriggers returns a few random triggers that depend on a host's
+// unavailability trigger t, and a few on other hosts sharing a group with it.
+func (s *Server) stormTriggers(h *zabbix.Host, t *trigger) []*trigger {
+	var dependents, neighbors []*trigger
+	for _, other := range s.triggers {
+		switch {
+		case !other.host.IsMonitored():
+		case slices.Contains(other.dependencies, t):
+			dependents = append(dependents, other)
+		case other.host != h && sharesGroup(h, other.host):
+			neighbors = append(neighbors, other)
+		}
+	}
+	return append(s.pick(dependents, 2), s.pick(neighbors, 2)...)
+}
+
+// pick removes up to n random triggers from candidates and returns them.
+func (s *Server) pick(candidates []*trigger, n int) []*trigger {
+	var picked []*trigger
+	for range min(n, len(candidates)) {
+		i := s.rng.IntN(len(candidates))
+		picked = append(picked, candidates[i])
+		candidates = slices.Delete(candidates, i, i+1)
+	}
+	return picked
+}
+
+// sharesGroup reports whether two hosts are in a common host group.
+func sharesGroup(a, b *zabbix.Host) bool {
+	for _, g := range a.Groups {
+		if slices.ContainsFunc(b.Groups, func(o zabbix.HostGroup) bool { return o.GroupID == g.GroupID }) {
+			return true
+		}
+	}
+	return false
+}
+
+// freeTrigger returns a random enabled trigger on a monitored host that has
+// no active problem, or nil if there is none.
+func (s *Server) freeTrigger(problems []*problem) *trigger {
+	busy := make(map[*trigger]bool)
+	for _, p := range problems {
+		if p.active() {
+			busy[p.trigger] = true
+		}
+	}
+	var free []*trigger
+	for _, t := range s.triggers {
+		if !busy[t] && t.IsEnabled() && t.host.IsMonitored() {
+			free = append(free, t)
+		}
+	}
+	if len(free) == 0 {
+		return nil
+	}
+	return free[s.rng.IntN(len(free))]
+}
+
+func (s *Server) newEventID() int64 {
+	id := s.nextEventID
+	s.nextEventID++
+	return id
+}
+
+// evolve ends suppressions that are due, and starts and resolves problems
+// for the time passed since the last change. After a long pause only a few changes are made, not one for every
+// interval missed.
+func (s *Server) evolve() {
+	now := s.now()
+	for _, p := range s.problems {
+		if p.suppressedBy != "" && p.suppressUntil != 0 && now.Unix() >= p.suppressUntil {
+			p.suppressed = false
+			p.suppressedBy = ""
+		}
+	}
+	for n := 0; now.Sub(s.lastChange) >= changeInterval; n++ {
+		if n == maxChangeBursts {
+			s.lastChange = now
+			break
+		}
+		s.lastChange = s.lastChange.Add(changeInterval)
+		s.change(s.lastChange.Unix())
+	}
+}
+
+// change starts a new problem or resolves an active one.
+func (s *Server) change(clock int64) {
+	var active []*problem
+	for _, p := range s.problems {
+		if p.active() {
+			active = append(active, p)
+		}
+	}
+
+	resolve := len(active) >= maxActive || (len(active) > minActive && s.rng.Float64() < 0.45)
+	if resolve {
+		s.resolve(active[s.rng.IntN(len(active))], clock)
+		return
+	}
+	t := s.freeTrigger(s.problems)
+	if t == nil {
+		return
+	}
+	p := &problem{
+		eventID:    s.newEventID(),
+		trigger:    t,
+		clock:      clock,
+		suppressed: t.host.InMaintenance(),
+	}
+	p.severity, _ = strconv.Atoi(t.Priority)
+	s.problems = append(s.problems, p)
+}
+
+// resolve ends a problem with a recovery event.
+func (s *Server) resolve(p *problem, clock int64) {
+	p.recoveryID = s.newEventID()
+	p.recoveryClock = clock
+}
+
+// acknowledge applies the action of event.acknowledge params to a problem
+// and records it in the problem's history.
+func (s *Server) acknowledge(p *problem, params zabbix.AcknowledgeParams, user string, clock int64) {
+	action := params.Action
+	ack := zabbix.Ack{
+		AckID:       strconv.FormatInt(s.nextAckID+1, 10),
+		UserID:      strconv.Itoa(slices.Index(ackUsers, user) + 2),
+		EventID:     strconv.FormatInt(p.eventID, 10),
+		Clock:       strconv.FormatInt(clock, 10),
+		Action:      strconv.Itoa(action),
+		OldSeverity: "0",
+		NewSeverity: "0",
+		Username:    user,
+	}
+	s.nextAckID++
+
+	if action&zabbix.ActionAddMessage != 0 {
+		ack.Message = params.Message
+	}
+	if action&zabbix.ActionAcknowledge != 0 {
+		p.acknowledged = true
+	}
+	if action&zabbix.ActionUnacknowledge != 0 {
+		p.acknowledged = false
+	}
+	if action&zabbix.ActionChangeSeverity != 0 {
+		ack.OldSeverity = strconv.Itoa(p.severity)
+		ack.NewSeverity = strconv.Itoa(params.Severity)
+		p.severity = params.Severity
+	}
+	if action&zabbix.ActionSuppress != 0 {
+		p.suppressed = true
+		p.suppressUntil = params.SuppressUntil
+		p.suppressedBy = ack.UserID
+	}
+	if action&zabbix.ActionUnsuppress != 0 {
+		p.suppressed = false
+		p.suppressedBy = ""
+	}
+	if action&zabbix.ActionClose != 0 && p.active() {
+		s.resolve(p, clock)
+	}
+	p.acks = append(p.acks, ack)
+}
+
+// RoundTrip implements http.RoundTripper by answering a JSON-RPC request.
+func (s *Server) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("demo: failed to read request: %w", err)
+	}
+
+	var rpc struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+		ID     int64           `json:"id"`
+	}
+	resp := zabbix.Response{JSONRPC: "2.0"}
+	if err := json.Unmarshal(body, &rpc); err != nil {
+		resp.Error = &zabbix.APIError{Code: -32700, Message: "Parse error.", Data: err.Error()}
+	} else {
+		resp.ID = rpc.ID
+		s.mu.Lock()
+		s.evolve()
+		result, apiErr := s.handle(rpc.Method, rpc.Params)
+		if apiErr == nil {
+			resp.Result, err = json.Marshal(result)
+		}
+		s.mu.Unlock()
+		if err != nil {
+			return nil, fmt.Errorf("demo: failed to encode result: %w", err)
+		}
+		resp.Error = apiErr
+	}
+
+	out, err := json.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("demo: failed to encode response: %w", err)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(out)),
+		ContentLength: int64(len(out)),
+		Request:       req,
+	}, nil
+}
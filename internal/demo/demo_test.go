@@ -0,0 +1,665 @@
+package demo
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// newTestClient returns a client connected to a demo server with a fixed
+// seed and a clock the test can advance. The clock starts at the real time,
+// since the client computes history ranges from it.
+func newTestClient(t *testing.T) (*zabbix.Client, *Server, *time.Time) {
+	t.Helper()
+	now := time.Now()
+	server := newServer(42, func() time.Time { return now })
+	client := zabbix.NewClient(URL, zabbix.WithTransport(server))
+
+	caps, err := client.Negotiate(context.Background())
+	if err != nil {
+		t.Fatalf("Negotiate() error = %v", err)
+	}
+	if caps.Version != Version {
+		t.Errorf("Version = %q, want %q", caps.Version, Version)
+	}
+	if err := client.Login(context.Background(), "Admin", "zabbix"); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	return client, server, &now
+}
+
+func TestProblems(t *testing.T) {
+	client, _, _ := newTestClient(t)
+
+	problems, err := client.GetProblems(context.Background(), zabbix.DefaultProblemGetParams())
+	if err != nil {
+		t.Fatalf("GetProblems() error = %v", err)
+	}
+	if len(problems) < minActive {
+		t.Fatalf("got %d problems, want at least %d", len(problems), minActive)
+	}
+	for i, p := range problems {
+		if p.IsRecovery() {
+			t.Errorf("problem %s is resolved", p.EventID)
+		}
+		if p.HostName() == "Unknown" || p.Name == "" {
+			t.Errorf("problem %s has no host or name", p.EventID)
+		}
+		if i > 0 && p.EventID >= problems[i-1].EventID {
+			t.Errorf("problems not sorted by event ID: %s after %s", p.EventID, problems[i-1].EventID)
+		}
+	}
+
+	high, err := client.GetProblemsWithMinSeverity(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("GetProblemsWithMinSeverity() error = %v", err)
+	}
+	for _, p := range high {
+		if p.SeverityInt() < 3 {
+			t.Errorf("problem %s has severity %d, want at least 3", p.EventID, p.SeverityInt())
+		}
+	}
+
+	page, err := client.GetProblemPage(context.Background(), zabbix.ProblemGetParams{Limit: 2})
+	if err != nil {
+		t.Fatalf("GetProblemPage() error = %v", err)
+	}
+	if len(page.Problems) != 2 || page.Next == "" {
+		t.Errorf("got %d problems with next %q, want 2 and a next page", len(page.Problems), page.Next)
+	}
+}
+
+func TestHosts(t *testing.T) {
+	client, _, _ := newTestClient(t)
+
+	hosts, err := client.GetAllHosts(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllHosts() error = %v", err)
+	}
+	if len(hosts) != len(hostDefs) {
+		t.Fatalf("got %d hosts, want %d", len(hosts), len(hostDefs))
+	}
+	for _, h := range hosts {
+		if len(h.Groups) == 0 || len(h.Interfaces) == 0 {
+			t.Errorf("host %s has no groups or interfaces", h.Name)
+		}
+	}
+
+	counts, err := client.GetHostCounts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetHostCounts() error = %v", err)
+	}
+	if counts.Maintenance != 1 || counts.Problem != 1 {
+		t.Errorf("counts = %+v, want one host in maintenance and one unavailable", counts)
+	}
+
+	found, err := client.FindHosts(context.Background(), "DB-")
+	if err != nil {
+		t.Fatalf("FindHosts() error = %v", err)
+	}
+	if len(found) != 3 {
+		t.Errorf("FindHosts(\"DB-\") found %d hosts, want 3", len(found))
+	}
+}
+
+func TestEventHistory(t *testing.T) {
+	client, _, now := newTestClient(t)
+
+	params := zabbix.DefaultEventHistoryParams()
+	params.TimeFrom = now.Add(-24 * time.Hour).Unix()
+	events, err := client.GetEventHistory(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetEventHistory() error = %v", err)
+	}
+	if len(events) == 0 {
+		t.Fatal("no events in the last 24 hours")
+	}
+	resolved, recoveries := 0, 0
+	for i, e := range events {
+		if e.StartTime().Unix() < params.TimeFrom {
+			t.Errorf("event %s is older than time_from", e.EventID)
+		}
+		if i > 0 && e.StartTime().After(events[i-1].StartTime()) {
+			t.Errorf("event %s is newer than the event before it", e.EventID)
+		}
+		switch {
+		case e.Value == zabbix.EventValueOK:
+			recoveries++
+		case e.IsRecovery():
+			resolved++
+			if e.ResolvedDuration() <= 0 {
+				t.Errorf("event %s has no resolved duration", e.EventID)
+			}
+		}
+	}
+	if resolved == 0 || recoveries == 0 {
+		t.Errorf("got %d resolved problems and %d recovery events, want both", resolved, recoveries)
+	}
+
+	params.Values = []int{0}
+	events, err = client.GetEventHistory(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetEventHistory() error = %v", err)
+	}
+	if len(events) != recoveries {
+		t.Errorf("got %d recovery events, want %d", len(events), recoveries)
+	}
+
+	params.Values = []int{1}
+	params.Severities = []int{4, 5}
+	events, err = client.GetEventHistory(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetEventHistory() error = %v", err)
+	}
+	for _, e := range events {
+		if e.Value != zabbix.EventValueProblem || e.SeverityInt() < 4 {
+			t.Errorf("event %s has value %s and severity %s, want a high problem", e.EventID, e.Value, e.Severity)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	client, _, _ := newTestClient(t)
+
+	items, err := client.GetAllNumericItems(context.Background(), []string{"system.cpu"})
+	if err != nil {
+		t.Fatalf("GetAllNumericItems() error = %v", err)
+	}
+	hosts, err := client.GetAllHosts(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllHosts() error = %v", err)
+	}
+	// The unreachable host has no values since its outage
+	items = slices.DeleteFunc(items, func(item zabbix.Item) bool {
+		i := slices.IndexFunc(hosts, func(h zabbix.Host) bool { return h.HostID == item.GetHostID() })
+		return i < 0 || hosts[i].IsAvailable() == 2
+	})
+	if len(items) == 0 {
+		t.Fatal("no CPU items")
+	}
+
+	history, err := client.GetItemsHistory(context.Background(), items[:1], 3)
+	if err != nil {
+		t.Fatalf("GetItemsHistory() error = %v", err)
+	}
+	points := history[items[0].ItemID]
+	if len(points) < 3*60-1 || len(points) > 3*60+1 {
+		t.Fatalf("got %d points, want one per minute for 3 hours", len(points))
+	}
+	for _, p := range points {
+		if v := p.ValueFloat(); v < 0 || v > 100 {
+			t.Errorf("CPU utilization %v out of range", v)
+		}
+	}
+
+	again, err := client.GetItemsHistory(context.Background(), items[:1], 3)
+	if err != nil {
+		t.Fatalf("GetItemsHistory() error = %v", err)
+	}
+	if again[items[0].ItemID][10] != points[10] {
+		t.Error("history changed between queries")
+	}
+}
+
+func TestLogHistory(t *testing.T) {
+	client, _, _ := newTestClient(t)
+
+	items, err := client.GetGraphItems(context.Background(), []string{"log"})
+	if err != nil {
+		t.Fatalf("GetGraphItems() error = %v", err)
+	}
+	if len(items) == 0 || items[0].ValueType != zabbix.ItemValueTypeLog || items[0].LastValue == "" {
+		t.Fatalf("items = %+v, want log items with their last line", items)
+	}
+
+	history, err := client.GetItemsHistory(context.Background(), items[:1], 24)
+	if err != nil {
+		t.Fatalf("GetItemsHistory() error = %v", err)
+	}
+	lines := history[items[0].ItemID]
+	if len(lines) != zabbix.TextHistoryLimit {
+		t.Fatalf("got %d lines, want the latest %d of a day", len(lines), zabbix.TextHistoryLimit)
+	}
+	if !lines[0].Time().Before(lines[len(lines)-1].Time()) {
+		t.Error("lines should be oldest first")
+	}
+	if last := lines[len(lines)-1]; last.Value != items[0].LastValue {
+		t.Errorf("last line %q, want the item's last value %q", last.Value, items[0].LastValue)
+	}
+}
+
+func TestValueMaps(t *testing.T) {
+	client, _, _ := newTestClient(t)
+
+	items, err := client.GetGraphItems(context.Background(), []string{"net.if.status"})
+	if err != nil {
+		t.Fatalf("GetGraphItems() error = %v", err)
+	}
+	if len(items) == 0 {
+		t.Fatal("want interface status items")
+	}
+	for _, item := range items {
+		if got := item.MappedValue(item.LastValue); got != "up (1)" {
+			t.Errorf("%s mapped value = %q, want %q", item.Name, got, "up (1)")
+		}
+	}
+}
+
+func TestDashboards(t *testing.T) {
+	client, _, _ := newTestClient(t)
+
+	list, err := client.GetDashboards(context.Background())
+	if err != nil {
+		t.Fatalf("GetDashboards() error = %v", err)
+	}
+	if len(list) != len(dashboards) {
+		t.Fatalf("got %d dashboards, want %d", len(list), len(dashboards))
+	}
+	d, err := client.GetDashboard(context.Background(), list[0].DashboardID)
+	if err != nil {
+		t.Fatalf("GetDashboard() error = %v", err)
+	}
+
+	for _, page := range d.PageList() {
+		data, err := client.GetWidgetData(context.Background(), page.Widgets, 1)
+		if err != nil {
+			t.Fatalf("GetWidgetData() error = %v", err)
+		}
+		for _, w := range page.Widgets {
+			switch w.Type {
+			case zabbix.WidgetTypeProblems:
+				if len(data.Problems[w.WidgetID]) == 0 {
+					t.Errorf("widget %q shows no problems", w.Name)
+				}
+			default:
+				items := data.Items[w.WidgetID]
+				if len(items) == 0 {
+					t.Errorf("widget %q shows no items", w.Name)
+				}
+				for _, item := range items {
+					if w.Type == zabbix.WidgetTypeSVGGraph && len(data.History[item.ItemID]) == 0 {
+						t.Errorf("widget %q has no history of %s", w.Name, item.Name)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestTriggerDependencies(t *testing.T) {
+	client, server, _ := newTestClient(t)
+
+	var loss, agent *trigger
+	for _, tr := range server.triggers {
+		switch {
+		case tr.host.Host == "core-sw-01" && tr.template == "High ICMP ping loss":
+			loss = tr
+		case tr.host.Host == "web-01" && tr.template == "Zabbix agent is not available (for 3m)":
+			agent = tr
+		}
+	}
+	triggers, err := client.GetTriggerRelations(context.Background(), []string{loss.TriggerID, agent.TriggerID})
+	if err != nil {
+		t.Fatalf("GetTriggerRelations() error = %v", err)
+	}
+	deps := make(map[string]string)
+	for _, tr := range triggers {
+		if len(tr.Dependencies) != 1 || len(tr.Groups) == 0 {
+			t.Fatalf("trigger %s: dependencies %v, groups %v", tr.TriggerID, tr.Dependencies, tr.Groups)
+		}
+		deps[tr.TriggerID] = tr.Dependencies[0].Description
+	}
+	if deps[loss.TriggerID] != "Unavailable by ICMP ping" {
+		t.Errorf("ping loss depends on %q, want the same host's ICMP ping", deps[loss.TriggerID])
+	}
+	if deps[agent.TriggerID] != "Unavailable by ICMP ping" {
+		t.Errorf("web-01 agent depends on %q, want its uplink's ICMP ping", deps[agent.TriggerID])
+	}
+}
+
+func TestTriggerNames(t *testing.T) {
+	client, server, _ := newTestClient(t)
+
+	var restart *trigger
+	for _, tr := range server.triggers {
+		if tr.host.Host == "web-01" && tr.template == "{HOST} has been restarted (uptime < 10m)" {
+			restart = tr
+		}
+	}
+	params := zabbix.TriggerGetParams{Output: "extend", TriggerIDs: []string{restart.TriggerID}}
+	for _, expand := range []bool{false, true} {
+		params.ExpandDescription = expand
+		triggers, err := client.GetTriggers(context.Background(), params)
+		if err != nil {
+			t.Fatalf("GetTriggers() error = %v", err)
+		}
+		if len(triggers) != 1 || triggers[0].Description != restart.host.Name+" has been restarted (uptime < 10m)" {
+			t.Errorf("expandDescription %v: triggers = %+v, want the host name resolved", expand, triggers)
+		}
+	}
+}
+
+func TestAcknowledgeAndClose(t *testing.T) {
+	client, _, _ := newTestClient(t)
+	ctx := context.Background()
+
+	problems, err := client.GetActiveProblems(ctx)
+	if err != nil {
+		t.Fatalf("GetActiveProblems() error = %v", err)
+	}
+	var target, locked zabbix.Problem
+	for _, p := range problems {
+		switch {
+		case !p.AllowsManualClose():
+			locked = p
+		case !p.IsAcknowledged() && target.EventID == "":
+			target = p
+		}
+	}
+	if target.EventID == "" || locked.EventID == "" {
+		t.Fatal("need an unacknowledged problem that can be closed and one that can't")
+	}
+
+	var apiErr *zabbix.APIError
+	if err := client.CloseProblem(ctx, locked.EventID, ""); !errors.As(err, &apiErr) {
+		t.Errorf("CloseProblem() error = %v, want an API error for a trigger without manual close", err)
+	}
+
+	if err := client.AcknowledgeProblem(ctx, target.EventID, "On it"); err != nil {
+		t.Fatalf("AcknowledgeProblem() error = %v", err)
+	}
+	if err := client.CloseProblem(ctx, target.EventID, ""); err != nil {
+		t.Fatalf("CloseProblem() error = %v", err)
+	}
+
+	events, err := client.GetEventHistory(ctx, zabbix.EventHistoryParams{Limit: 500})
+	if err != nil {
+		t.Fatalf("GetEventHistory() error = %v", err)
+	}
+	for _, e := range events {
+		if e.EventID != target.EventID {
+			continue
+		}
+		if !e.IsAcknowledged() || !e.IsRecovery() {
+			t.Errorf("event = acknowledged %v, resolved %v; want both", e.IsAcknowledged(), e.IsRecovery())
+		}
+		if len(e.Acknowledges) != 2 || e.Acknowledges[1].Message != "On it" {
+			t.Errorf("acknowledges = %+v, want the message then the close", e.Acknowledges)
+		}
+		return
+	}
+	t.Errorf("event %s not found", target.EventID)
+}
+
+func TestSuppressUntil(t *testing.T) {
+	client, server, now := newTestClient(t)
+	ctx := context.Background()
+
+	problems, err := client.GetActiveProblems(ctx)
+	if err != nil {
+		t.Fatalf("GetActiveProblems() error = %v", err)
+	}
+	i := slices.IndexFunc(problems, func(p zabbix.Problem) bool { return !p.IsSuppressed() })
+	if i < 0 {
+		t.Fatal("need a problem that isn't suppressed")
+	}
+	target := problems[i].EventID
+
+	until := now.Add(time.Hour).Truncate(time.Second)
+	if err := client.SuppressProblems(ctx, []string{target}, until); err != nil {
+		t.Fatalf("SuppressProblems() error = %v", err)
+	}
+	problems, err = client.GetActiveProblems(ctx)
+	if err != nil {
+		t.Fatalf("GetActiveProblems() error = %v", err)
+	}
+	i = slices.IndexFunc(problems, func(p zabbix.Problem) bool { return p.EventID == target })
+	if i < 0 {
+		t.Fatalf("problem %s is gone", target)
+	}
+	if got, ok := problems[i].SuppressedUntil(); !ok || !got.Equal(until) {
+		t.Errorf("SuppressedUntil() = %v, %v; want %v", got, ok, until)
+	}
+
+	// The problem may have been resolved since, but not stay suppressed
+	*now = now.Add(time.Hour)
+	if _, err := client.GetActiveProblems(ctx); err != nil {
+		t.Fatalf("GetActiveProblems() error = %v", err)
+	}
+	for _, p := range server.problems {
+		if strconv.FormatInt(p.eventID, 10) == target && p.suppressed {
+			t.Error("expected the suppression to end")
+		}
+	}
+}
+
+func TestProblemsChangeOverTime(t *testing.T) {
+	client, server, now := newTestClient(t)
+	ctx := context.Background()
+
+	before, err := client.GetActiveProblems(ctx)
+	if err != nil {
+		t.Fatalf("GetActiveProblems() error = %v", err)
+	}
+	events := len(server.problems)
+
+	*now = now.Add(3 * changeInterval)
+	after, err := client.GetActiveProblems(ctx)
+	if err != nil {
+		t.Fatalf("GetActiveProblems() error = %v", err)
+	}
+	if len(server.problems) == events && len(after) == len(before) {
+		t.Error("no problems started or resolved")
+	}
+	if len(after) < minActive || len(after) > maxActive {
+		t.Errorf("got %d active problems, want %d-%d", len(after), minActive, maxActive)
+	}
+}
+
+func TestMacros(t *testing.T) {
+	client, server, _ := newTestClient(t)
+	ctx := context.Background()
+	hostID := server.hosts[0].HostID
+
+	if err := client.CreateHostMacro(ctx, hostID, "{$DEMO}", "1"); err != nil {
+		t.Fatalf("CreateHostMacro() error = %v", err)
+	}
+	macros, err := client.GetHostMacros(ctx, hostID)
+	if err != nil {
+		t.Fatalf("GetHostMacros() error = %v", err)
+	}
+	last := macros[len(macros)-1]
+	if last.Macro != "{$DEMO}" {
+		t.Fatalf("last macro = %q, want {$DEMO}", last.Macro)
+	}
+
+	if err := client.UpdateHostMacro(ctx, last.HostMacroID, "2"); err != nil {
+		t.Fatalf("UpdateHostMacro() error = %v", err)
+	}
+	if err := client.DeleteHostMacro(ctx, last.HostMacroID); err != nil {
+		t.Fatalf("DeleteHostMacro() error = %v", err)
+	}
+	after, err := client.GetHostMacros(ctx, hostID)
+	if err != nil {
+		t.Fatalf("GetHostMacros() error = %v", err)
+	}
+	if len(after) != len(macros)-1 {
+		t.Errorf("got %d macros after delete, want %d", len(after), len(macros)-1)
+	}
+}
+
+func TestEffectiveMacros(t *testing.T) {
+	client, server, _ := newTestClient(t)
+
+	macros, err := client.GetEffectiveMacros(context.Background(), server.hosts[0].HostID)
+	if err != nil {
+		t.Fatalf("GetEffectiveMacros() error = %v", err)
+	}
+	sources := make(map[string][]string)
+	for _, m := range macros {
+		for _, d := range m.Definitions {
+			sources[m.Macro] = append(sources[m.Macro], d.Source()+"="+d.Value)
+		}
+	}
+
+	want := map[string]string{
+		"{$AGENT.TIMEOUT}":   "host=3m,Zabbix agent=5m",
+		"{$MEMORY.UTIL.MAX}": "Linux by Zabbix agent=90,global=95",
+		"{$SNMP_COMMUNITY}":  "global=public",
+	}
+	for macro, w := range want {
+		if got := strings.Join(sources[macro], ","); got != w {
+			t.Errorf("%s resolves as %s, want %s", macro, got, w)
+		}
+	}
+}
+
+func TestMaintenance(t *testing.T) {
+	client, server, now := newTestClient(t)
+	ctx := context.Background()
+	host := server.hosts[0]
+	if host.InMaintenance() {
+		host = server.hosts[1]
+	}
+
+	id, err := client.CreateHostMaintenance(ctx, host.HostID, "Reboot", time.Hour, false)
+	if err != nil {
+		t.Fatalf("CreateHostMaintenance() error = %v", err)
+	}
+	got, err := client.GetHost(ctx, host.HostID)
+	if err != nil {
+		t.Fatalf("GetHost() error = %v", err)
+	}
+	if !got.InMaintenance() || got.MaintenanceID != id || got.MaintenanceType != zabbix.MaintenanceNoData {
+		t.Fatalf("host = %+v, want in maintenance %s without data collection", got, id)
+	}
+	maintenances, err := client.GetMaintenances(ctx, []string{id})
+	if err != nil {
+		t.Fatalf("GetMaintenances() error = %v", err)
+	}
+	mt := maintenances[id]
+	if left := time.Until(mt.End(*now)); left < 59*time.Minute || left > 61*time.Minute {
+		t.Errorf("maintenance %+v ends in %v, want 1h", mt, left)
+	}
+
+	if _, err := client.CreateHostMaintenance(ctx, host.HostID, "Reboot", time.Hour, false); err == nil {
+		t.Error("expected an error for a duplicate maintenance name")
+	}
+
+	// The database servers are patched weekly
+	hostMaintenances, err := client.GetHostMaintenances(ctx, host.HostID, []string{groupIDs["Databases"]})
+	if err != nil {
+		t.Fatalf("GetHostMaintenances() error = %v", err)
+	}
+	var names []string
+	for _, mt := range hostMaintenances {
+		names = append(names, mt.Name)
+	}
+	if !slices.Contains(names, "Database patching") || !slices.Contains(names, "Reboot") {
+		t.Errorf("host maintenances = %v, want the host's and the patch window", names)
+	}
+	if windows := hostMaintenances[0].Windows(*now, now.AddDate(0, 0, 7)); len(windows) != 1 {
+		t.Errorf("patch windows in a week = %v, want one", windows)
+	}
+
+	*now = now.Add(2 * time.Hour)
+	if got, _ := client.GetHost(ctx, host.HostID); got.InMaintenance() {
+		t.Error("host should leave maintenance when it ends")
+	}
+}
+
+func TestHostDiagnostics(t *testing.T) {
+	client, server, now := newTestClient(t)
+	ctx := context.Background()
+	var down *zabbix.Host
+	for _, h := range server.hosts {
+		if h.IsAvailable() == 2 {
+			down = h
+		}
+	}
+
+	d, err := client.GetHostDiagnostics(ctx, down.HostID)
+	if err != nil {
+		t.Fatalf("GetHostDiagnostics() error = %v", err)
+	}
+	iface := d.Host.Interfaces[0]
+	if iface.Error == "" || iface.ErrorsFrom == "" || iface.DisableUntil == "" {
+		t.Errorf("interface = %+v, want why and since when it is unreachable", iface)
+	}
+	if len(d.Items) == 0 || now.Sub(d.InterfaceLastData(iface.InterfaceID)) < 10*time.Minute {
+		t.Errorf("items of an unreachable host should have no recent values")
+	}
+
+	items := d.AvailabilityCheckItems()
+	if len(items) != 1 {
+		t.Fatalf("AvailabilityCheckItems() = %v, want one item", items)
+	}
+	if err := client.CheckItemsNow(ctx, items); err != nil {
+		t.Errorf("CheckItemsNow() error = %v", err)
+	}
+	if err := client.CheckItemsNow(ctx, []string{"1"}); err == nil {
+		t.Error("expected an error checking an unknown item")
+	}
+}
+
+func TestUpdateItem(t *testing.T) {
+	client, server, _ := newTestClient(t)
+	ctx := context.Background()
+	itemID := server.items[0].ItemID
+
+	if err := client.DisableItem(ctx, itemID); err != nil {
+		t.Fatalf("DisableItem() error = %v", err)
+	}
+	items, err := client.GetAllNumericItems(ctx, nil)
+	if err != nil {
+		t.Fatalf("GetAllNumericItems() error = %v", err)
+	}
+	if slices.ContainsFunc(items, func(it zabbix.Item) bool { return it.ItemID == itemID }) {
+		t.Error("a disabled item should not be collected")
+	}
+
+	if err := client.EnableItem(ctx, itemID); err != nil {
+		t.Fatalf("EnableItem() error = %v", err)
+	}
+	if err := client.EnableItem(ctx, "1"); err == nil {
+		t.Error("expected an error enabling an unknown item")
+	}
+}
+
+func TestDisableTriggers(t *testing.T) {
+	client, server, _ := newTestClient(t)
+	ctx := context.Background()
+	ids := []string{server.triggers[0].TriggerID, server.triggers[1].TriggerID}
+
+	if err := client.DisableTriggers(ctx, ids); err != nil {
+		t.Fatalf("DisableTriggers() error = %v", err)
+	}
+	for _, tr := range server.triggers[:2] {
+		if tr.IsEnabled() {
+			t.Errorf("trigger %s should be disabled", tr.TriggerID)
+		}
+	}
+
+	if err := client.EnableTriggers(ctx, append(ids, "1")); err == nil {
+		t.Error("expected an error enabling an unknown trigger")
+	}
+	if server.triggers[0].IsEnabled() {
+		t.Error("no trigger should be enabled when one is unknown")
+	}
+}
+
+func TestUnsupportedMethod(t *testing.T) {
+	client, _, _ := newTestClient(t)
+
+	_, err := client.UserID(context.Background(), "Admin")
+	var apiErr *zabbix.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("UserID() error = %v, want an API error", err)
+	}
+}
@@ -0,0 +1,984 @@
+package demo
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// demoUser is the user that API calls from chotko are made as.
+const demoUser = "Admin"
+
+// demoUserID is the ID of demoUser.
+const demoUserID = "1"
+
+// getParams holds the parameters of the *.get methods that the demo server
+// understands. Output and select parameters are ignored: full objects are
+// always returned.
+type getParams struct {
+	EventIDs       []string          `json:"eventids"`
+	HostIDs        []string          `json:"hostids"`
+	GroupIDs       []string          `json:"groupids"`
+	TriggerIDs     []string          `json:"triggerids"`
+	ItemIDs        []string          `json:"itemids"`
+	Severities     []int             `json:"severities"`
+	Value          []int             `json:"value"`
+	Search         map[string]string `json:"search"`
+	SearchByAny    bool              `json:"searchByAny"`
+	EventIDTill    string            `json:"eventid_till"`
+	TimeFrom       int64             `json:"time_from"`
+	TimeTill       int64             `json:"time_till"`
+	Limit          int               `json:"limit"`
+	CountOutput    bool              `json:"countOutput"`
+	MonitoredHosts bool              `json:"monitored_hosts"`
+	History        int               `json:"history"`
+	SortOrder      string            `json:"sortorder"`
+	Acknowledged   *bool             `json:"acknowledged"`
+	GlobalMacro    bool              `json:"globalmacro"`
+
+	ExpandDescription bool `json:"expandDescription"`
+}
+
+// errInvalidParams returns the error Zabbix reports for bad parameters.
+func errInvalidParams(format string, args ...any) *zabbix.APIError {
+	return &zabbix.APIError{Code: -32602, Message: "Invalid params.", Data: fmt.Sprintf(format, args...)}
+}
+
+// errNoObject is the error Zabbix reports for an unknown object ID.
+var errNoObject = errInvalidParams("No permissions to referred object or it does not exist!")
+
+// errCannotClose is the error Zabbix reports for closing a problem whose
+// trigger does not allow it.
+var errCannotClose = &zabbix.APIError{
+	Code:    -32500,
+	Message: "Application error.",
+	Data:    "Cannot close problem: trigger does not allow manual closing.",
+}
+
+// handle answers one API call. It must be called with s.mu held.
+func (s *Server) handle(method string, raw json.RawMessage) (any, *zabbix.APIError) {
+	var params getParams
+	if strings.HasSuffix(method, ".get") && len(raw) > 0 && raw[0] == '{' {
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, errInvalidParams("%v", err)
+		}
+	}
+
+	switch method {
+	case "apiinfo.version":
+		return Version, nil
+	case "user.login":
+		return "demo", nil
+	case "user.logout":
+		return true, nil
+	case "user.checkAuthentication":
+		return map[string]string{"userid": demoUserID, "username": demoUser}, nil
+	case "problem.get":
+		return s.getProblems(params), nil
+	case "event.get":
+		return s.getEvents(params), nil
+	case "event.acknowledge":
+		return s.acknowledgeEvents(raw)
+	case "host.get":
+		return s.getHosts(params), nil
+	case "host.update":
+		return s.updateHost(raw)
+	case "hostinterface.get":
+		return s.getInterfaces(params), nil
+	case "hostinterface.update":
+		return s.updateInterface(raw)
+	case "trigger.get":
+		if params.CountOutput {
+			return strconv.Itoa(len(s.getTriggers(params))), nil
+		}
+		return s.getTriggers(params), nil
+	case "trigger.update":
+		return s.updateTrigger(raw)
+	case "item.get":
+		if params.CountOutput {
+			return strconv.Itoa(len(s.getItems(params))), nil
+		}
+		return s.getItems(params), nil
+	case "item.update":
+		return s.updateItem(raw)
+	case "history.get":
+		return s.getHistory(params), nil
+	case "valuemap.get":
+		return getValueMaps(raw)
+	case "dashboard.get":
+		return s.getDashboards(raw)
+	case "template.get":
+		return s.getTemplates(params), nil
+	case "housekeeping.get":
+		return housekeeping, nil
+	case "settings.get":
+		return settings, nil
+	case "usermacro.get":
+		return s.getMacros(params), nil
+	case "usermacro.create":
+		return s.createMacro(raw)
+	case "usermacro.update":
+		return s.updateMacro(raw)
+	case "usermacro.delete":
+		return s.deleteMacros(raw)
+	case "maintenance.get":
+		return s.getMaintenances(raw)
+	case "maintenance.create":
+		return s.createMaintenance(raw)
+	case "task.create":
+		return s.createTasks(raw)
+	}
+	return nil, errInvalidParams("Method %q is not supported in demo mode.", method)
+}
+
+// matches reports whether text contains the search string, ignoring case.
+func matches(text, search string) bool {
+	return strings.Contains(strings.ToLower(text), strings.ToLower(strings.ReplaceAll(search, "*", "")))
+}
+
+// inFilter reports whether id is in ids, or ids is empty.
+func inFilter(ids []string, id string) bool {
+	return len(ids) == 0 || slices.Contains(ids, id)
+}
+
+// eventIDTill parses the eventid_till parameter; 0 means no limit.
+func eventIDTill(p getParams) int64 {
+	till, _ := strconv.ParseInt(p.EventIDTill, 10, 64)
+	return till
+}
+
+// event converts a problem to its API representation.
+func (s *Server) event(p *problem) zabbix.Event {
+	t := p.trigger
+	e := zabbix.Event{
+		EventID:      strconv.FormatInt(p.eventID, 10),
+		Source:       "0",
+		Object:       "0",
+		ObjectID:     t.TriggerID,
+		Clock:        strconv.FormatInt(p.clock, 10),
+		NS:           "0",
+		REventID:     strconv.FormatInt(p.recoveryID, 10),
+		RClock:       strconv.FormatInt(p.recoveryClock, 10),
+		Name:         t.name(),
+		Acknowledged: "0",
+		Severity:     strconv.Itoa(p.severity),
+		Value:        zabbix.EventValueProblem,
+		Suppressed:   "0",
+		Tags:         t.tags,
+		Hosts:        []zabbix.Host{{HostID: t.host.HostID, Host: t.host.Host, Name: t.host.Name}},
+		RelatedObject: zabbix.RelatedObject{
+			TriggerID:   t.TriggerID,
+			Status:      t.Status,
+			ManualClose: t.ManualClose,
+		},
+	}
+	if p.acknowledged {
+		e.Acknowledged = "1"
+	}
+	if p.suppressed {
+		e.Suppressed = "1"
+		suppression := zabbix.Suppression{MaintenanceID: t.host.MaintenanceID, SuppressUntil: "0"}
+		if p.suppressedBy != "" {
+			suppression = zabbix.Suppression{
+				MaintenanceID: "0",
+				SuppressUntil: strconv.FormatInt(p.suppressUntil, 10),
+				UserID:        p.suppressedBy,
+			}
+		}
+		e.SuppressionData = []zabbix.Suppression{suppression}
+	}
+	// Newest first, as Zabbix returns them
+	for i := len(p.acks) - 1; i >= 0; i-- {
+		e.Acknowledges = append(e.Acknowledges, p.acks[i])
+	}
+	return e
+}
+
+// recoveryEvent returns the recovery event of a resolved problem. Like in
+// Zabbix, it has no severity.
+func (s *Server) recoveryEvent(p *problem) zabbix.Event {
+	e := s.event(p)
+	e.EventID = strconv.FormatInt(p.recoveryID, 10)
+	e.Clock = e.RClock
+	e.REventID = "0"
+	e.RClock = "0"
+	e.Severity = "0"
+	e.Value = zabbix.EventValueOK
+	e.Acknowledged = "0"
+	e.Acknowledges = nil
+	return e
+}
+
+// getProblems answers problem.get: active problems, newest first.
+func (s *Server) getProblems(params getParams) []zabbix.Event {
+	till := eventIDTill(params)
+	result := []zabbix.Event{}
+	for i := len(s.problems) - 1; i >= 0; i-- {
+		p := s.problems[i]
+		switch {
+		case !p.active(),
+			!inFilter(params.HostIDs, p.trigger.host.HostID),
+			till > 0 && p.eventID > till,
+			len(params.Severities) > 0 && !slices.Contains(params.Severities, p.severity),
+			params.Acknowledged != nil && p.acknowledged != *params.Acknowledged,
+			params.Search["name"] != "" && !matches(p.trigger.name(), params.Search["name"]):
+			continue
+		}
+		result = append(result, s.event(p))
+		if params.Limit > 0 && len(result) == params.Limit {
+			break
+		}
+	}
+	return result
+}
+
+// getEvents answers event.get, either for given event IDs or as a history
+// query, newest first.
+func (s *Server) getEvents(params getParams) []zabbix.Event {
+	type entry struct {
+		id, clock int64
+		p         *problem
+		recovery  bool
+	}
+	var entries []entry
+	for _, p := range s.problems {
+		entries = append(entries, entry{p.eventID, p.clock, p, false})
+		if !p.active() {
+			entries = append(entries, entry{p.recoveryID, p.recoveryClock, p, true})
+		}
+	}
+	// Newest first, by clock then event ID
+	slices.SortFunc(entries, func(a, b entry) int {
+		return cmp.Or(cmp.Compare(b.clock, a.clock), cmp.Compare(b.id, a.id))
+	})
+
+	till := eventIDTill(params)
+	result := []zabbix.Event{}
+	for _, e := range entries {
+		value, severity := 1, e.p.severity
+		if e.recovery {
+			value, severity = 0, 0
+		}
+		switch {
+		case !inFilter(params.EventIDs, strconv.FormatInt(e.id, 10)),
+			!inFilter(params.HostIDs, e.p.trigger.host.HostID),
+			len(params.Value) > 0 && !slices.Contains(params.Value, value),
+			len(params.Severities) > 0 && !slices.Contains(params.Severities, severity),
+			till > 0 && e.id > till,
+			params.TimeFrom > 0 && e.clock < params.TimeFrom,
+			params.TimeTill > 0 && e.clock > params.TimeTill,
+			params.Search["name"] != "" && !matches(e.p.trigger.name(), params.Search["name"]):
+			continue
+		}
+		if e.recovery {
+			result = append(result, s.recoveryEvent(e.p))
+		} else {
+			result = append(result, s.event(e.p))
+		}
+		if params.Limit > 0 && len(result) == params.Limit {
+			break
+		}
+	}
+	return result
+}
+
+// acknowledgeEvents answers event.acknowledge.
+func (s *Server) acknowledgeEvents(raw json.RawMessage) (any, *zabbix.APIError) {
+	var params zabbix.AcknowledgeParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, errInvalidParams("%v", err)
+	}
+
+	var targets []*problem
+	for _, id := range params.EventIDs {
+		i := slices.IndexFunc(s.problems, func(p *problem) bool {
+			return strconv.FormatInt(p.eventID, 10) == id
+		})
+		if i < 0 {
+			return nil, errNoObject
+		}
+		if params.Action&zabbix.ActionClose != 0 && s.problems[i].trigger.ManualClose != zabbix.ManualCloseAllowed {
+			return nil, errCannotClose
+		}
+		targets = append(targets, s.problems[i])
+	}
+
+	clock := s.now().Unix()
+	for _, p := range targets {
+		s.acknowledge(p, params, demoUser, clock)
+	}
+	return map[string][]string{"eventids": params.EventIDs}, nil
+}
+
+// activeTrigger reports whether a trigger has an active problem.
+func (s *Server) activeTrigger(t *trigger) bool {
+	return slices.ContainsFunc(s.problems, func(p *problem) bool {
+		return p.trigger == t && p.active()
+	})
+}
+
+// name returns the description of a trigger with its macros resolved, as
+// in the names of its events.
+func (t *trigger) name() string {
+	return strings.ReplaceAll(t.Description, "{HOST.NAME}", t.host.Name)
+}
+
+// triggerValue returns a trigger with its current value.
+func (s *Server) triggerValue(t *trigger) zabbix.Trigger {
+	v := t.Trigger
+	v.Value = "0"
+	if s.activeTrigger(t) {
+		v.Value = "1"
+	}
+	v.Groups = t.host.Groups
+	v.Hosts = []zabbix.Host{{HostID: t.host.HostID, Host: t.host.Host, Name: t.host.Name}}
+	for _, d := range t.dependencies {
+		v.Dependencies = append(v.Dependencies, zabbix.Trigger{TriggerID: d.TriggerID, Description: d.Description})
+	}
+	return v
+}
+
+// findHost returns the host with the given ID, or nil.
+func (s *Server) findHost(id string) *zabbix.Host {
+	for _, h := range s.hosts {
+		if h.HostID == id {
+			return h
+		}
+	}
+	return nil
+}
+
+// getHosts answers host.get, sorted by name.
+func (s *Server) getHosts(params getParams) any {
+	s.endMaintenances()
+	result := []zabbix.Host{}
+	for _, h := range s.hosts {
+		if !inFilter(params.HostIDs, h.HostID) || (params.MonitoredHosts && !h.IsMonitored()) {
+			continue
+		}
+		if len(params.GroupIDs) > 0 && !slices.ContainsFunc(h.Groups, func(g zabbix.HostGroup) bool {
+			return slices.Contains(params.GroupIDs, g.GroupID)
+		}) {
+			continue
+		}
+		if len(params.Search) > 0 && !matchesHost(h, params.Search, params.SearchByAny) {
+			continue
+		}
+
+		host := *h
+		host.Macros = slices.Clone(h.Macros)
+		host.Interfaces = slices.Clone(h.Interfaces)
+		for i := range host.Interfaces {
+			if host.Interfaces[i].Available == "2" {
+				// Unreachable interfaces are retried every minute
+				next := s.now().Unix()/60*60 + 60
+				host.Interfaces[i].DisableUntil = strconv.FormatInt(next, 10)
+			}
+		}
+		host.Triggers = nil
+		for _, t := range s.triggers {
+			if t.host == h {
+				host.Triggers = append(host.Triggers, s.triggerValue(t))
+			}
+		}
+		result = append(result, host)
+	}
+
+	if params.CountOutput {
+		return strconv.Itoa(len(result))
+	}
+	slices.SortFunc(result, func(a, b zabbix.Host) int { return strings.Compare(a.Name, b.Name) })
+	if params.Limit > 0 && len(result) > params.Limit {
+		result = result[:params.Limit]
+	}
+	return result
+}
+
+// matchesHost applies a host.get search on the name and host fields.
+func matchesHost(h *zabbix.Host, search map[string]string, byAny bool) bool {
+	fields := map[string]string{"name": h.Name, "host": h.Host}
+	for field, value := range search {
+		ok := matches(fields[field], value)
+		if ok && byAny {
+			return true
+		}
+		if !ok && !byAny {
+			return false
+		}
+	}
+	return !byAny
+}
+
+// updateHost answers host.update.
+func (s *Server) updateHost(raw json.RawMessage) (any, *zabbix.APIError) {
+	var params zabbix.HostUpdateParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, errInvalidParams("%v", err)
+	}
+	h := s.findHost(params.HostID)
+	if h == nil {
+		return nil, errNoObject
+	}
+
+	if params.Status != "" {
+		h.Status = params.Status
+	}
+	if params.Name != "" {
+		h.Name = params.Name
+	}
+	if params.Description != "" {
+		h.Description = params.Description
+	}
+	if params.Macros != nil {
+		h.Macros = nil
+		for _, m := range params.Macros {
+			s.addMacro(h, m.Macro, m.Value, m.Type)
+		}
+	}
+	return map[string][]string{"hostids": {h.HostID}}, nil
+}
+
+// getInterfaces answers hostinterface.get.
+func (s *Server) getInterfaces(params getParams) []zabbix.Interface {
+	result := []zabbix.Interface{}
+	for _, h := range s.hosts {
+		if inFilter(params.HostIDs, h.HostID) {
+			result = append(result, h.Interfaces...)
+		}
+	}
+	return result
+}
+
+// updateInterface answers hostinterface.update.
+func (s *Server) updateInterface(raw json.RawMessage) (any, *zabbix.APIError) {
+	var params struct {
+		InterfaceID string                   `json:"interfaceid"`
+		UseIP       string                   `json:"useip"`
+		IP          string                   `json:"ip"`
+		DNS         string                   `json:"dns"`
+		Port        string                   `json:"port"`
+		Details     *zabbix.InterfaceDetails `json:"details"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, errInvalidParams("%v", err)
+	}
+	for _, h := range s.hosts {
+		for i := range h.Interfaces {
+			iface := &h.Interfaces[i]
+			if iface.InterfaceID != params.InterfaceID {
+				continue
+			}
+			if params.UseIP != "" {
+				iface.UseIP = params.UseIP
+			}
+			if params.IP != "" {
+				iface.IP = params.IP
+			}
+			if params.DNS != "" {
+				iface.DNS = params.DNS
+			}
+			if params.Port != "" {
+				iface.Port = params.Port
+			}
+			if params.Details != nil {
+				iface.Details = params.Details
+			}
+			return map[string][]string{"interfaceids": {iface.InterfaceID}}, nil
+		}
+	}
+	return nil, errNoObject
+}
+
+// getTriggers answers trigger.get, sorted by description.
+func (s *Server) getTriggers(params getParams) []zabbix.Trigger {
+	result := []zabbix.Trigger{}
+	for _, t := range s.triggers {
+		if inFilter(params.TriggerIDs, t.TriggerID) && inFilter(params.HostIDs, t.host.HostID) {
+			v := s.triggerValue(t)
+			if params.ExpandDescription {
+				v.Description = t.name()
+			}
+			result = append(result, v)
+		}
+	}
+	slices.SortFunc(result, func(a, b zabbix.Trigger) int { return strings.Compare(a.Description, b.Description) })
+	return result
+}
+
+// updateTrigger answers trigger.update.
+func (s *Server) updateTrigger(raw json.RawMessage) (any, *zabbix.APIError) {
+	var updates []zabbix.TriggerUpdateParams
+	if len(raw) > 0 && raw[0] == '[' {
+		if err := json.Unmarshal(raw, &updates); err != nil {
+			return nil, errInvalidParams("%v", err)
+		}
+	} else {
+		var params zabbix.TriggerUpdateParams
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, errInvalidParams("%v", err)
+		}
+		updates = append(updates, params)
+	}
+
+	// Check all triggers first, as Zabbix updates none if one is unknown
+	triggers := make([]*trigger, len(updates))
+	for n, params := range updates {
+		i := slices.IndexFunc(s.triggers, func(t *trigger) bool { return t.TriggerID == params.TriggerID })
+		if i < 0 {
+			return nil, errNoObject
+		}
+		triggers[n] = s.triggers[i]
+	}
+
+	ids := []string{}
+	for n, params := range updates {
+		t := triggers[n]
+		if params.Status != "" {
+			t.Status = params.Status
+		}
+		if params.Priority != "" {
+			t.Priority = params.Priority
+		}
+		if params.Description != "" {
+			t.Description = params.Description
+		}
+		ids = append(ids, t.TriggerID)
+	}
+	return map[string][]string{"triggerids": ids}, nil
+}
+
+// getItems answers item.get with the enabled items of monitored hosts,
+// including their latest values.
+func (s *Server) getItems(params getParams) []zabbix.Item {
+	now := s.now().Unix()
+	lastClock := now - now%60
+
+	result := []zabbix.Item{}
+	for _, it := range s.items {
+		if !inFilter(params.ItemIDs, it.ItemID) || !inFilter(params.HostIDs, it.host.HostID) || !it.host.IsMonitored() || !it.IsEnabled() {
+			continue
+		}
+		if params.Search["name"] != "" && !matches(it.Name, params.Search["name"]) {
+			continue
+		}
+		v := it.Item
+		value, clock := it.lastValue(it.collectedTill(lastClock))
+		v.LastClock = strconv.FormatInt(clock, 10)
+		if it.ValueType == zabbix.ItemValueTypeLog {
+			v.LastValue = value
+		} else {
+			v.LastValue = it.formatValue(it.value(lastClock))
+		}
+		v.Hosts = []zabbix.Host{{HostID: it.host.HostID, Host: it.host.Host, Name: it.host.Name}}
+		result = append(result, v)
+	}
+	slices.SortStableFunc(result, func(a, b zabbix.Item) int { return strings.Compare(a.Name, b.Name) })
+	return result
+}
+
+// getValueMaps answers valuemap.get.
+func getValueMaps(raw json.RawMessage) (any, *zabbix.APIError) {
+	var params struct {
+		ValueMapIDs []string `json:"valuemapids"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, errInvalidParams("%v", err)
+	}
+	result := []zabbix.ValueMap{}
+	for _, vm := range valueMaps {
+		if inFilter(params.ValueMapIDs, vm.ValueMapID) {
+			result = append(result, vm)
+		}
+	}
+	return result, nil
+}
+
+// getDashboards answers dashboard.get. Pages are always returned, as by
+// the Zabbix version the demo server reports.
+func (s *Server) getDashboards(raw json.RawMessage) (any, *zabbix.APIError) {
+	var params struct {
+		DashboardIDs []string `json:"dashboardids"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, errInvalidParams("%v", err)
+	}
+	result := []zabbix.Dashboard{}
+	for _, d := range dashboards {
+		if !inFilter(params.DashboardIDs, d.DashboardID) {
+			continue
+		}
+		d.Pages = slices.Clone(d.Pages)
+		for i, page := range d.Pages {
+			page.Widgets = slices.Clone(page.Widgets)
+			for j, w := range page.Widgets {
+				if w.Type == zabbix.WidgetTypeItem {
+					w.Fields = []zabbix.WidgetField{{Type: "4", Name: "itemid.0", Value: s.itemIDByKey(w.Field("itemid"))}}
+					page.Widgets[j] = w
+				}
+			}
+			d.Pages[i] = page
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+// itemIDByKey returns the ID of the first item with the key.
+func (s *Server) itemIDByKey(key string) string {
+	for _, it := range s.items {
+		if it.Key == key {
+			return it.ItemID
+		}
+	}
+	return ""
+}
+
+// collectedTill returns the latest time up to clock at which values of an
+// item were collected: none are since its interface became unreachable.
+func (it *item) collectedTill(clock int64) int64 {
+	if since, err := strconv.ParseInt(it.host.Interfaces[0].ErrorsFrom, 10, 64); err == nil {
+		return min(clock, since-since%60)
+	}
+	return clock
+}
+
+// updateItem answers item.update.
+func (s *Server) updateItem(raw json.RawMessage) (any, *zabbix.APIError) {
+	var params zabbix.ItemUpdateParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, errInvalidParams("%v", err)
+	}
+	i := slices.IndexFunc(s.items, func(it *item) bool { return it.ItemID == params.ItemID })
+	if i < 0 {
+		return nil, errNoObject
+	}
+	if params.Status != "" {
+		s.items[i].Status = params.Status
+	}
+	return map[string][]string{"itemids": {params.ItemID}}, nil
+}
+
+// getHistory answers history.get with one value per minute, or the lines
+// logged by log items, by time in the requested order.
+func (s *Server) getHistory(params getParams) []zabbix.History {
+	now := s.now().Unix()
+	till := now
+	if params.TimeTill > 0 {
+		till = min(params.TimeTill, now)
+	}
+	from := params.TimeFrom
+	if from <= 0 {
+		from = till - 3600
+	}
+	from += (60 - from%60) % 60 // Align to the minute
+
+	valueType := strconv.Itoa(params.History)
+	result := []zabbix.History{}
+	for _, it := range s.items {
+		if it.ValueType != valueType || !slices.Contains(params.ItemIDs, it.ItemID) {
+			continue
+		}
+		for clock := from; clock <= it.collectedTill(till); clock += 60 {
+			value, ok := it.valueAt(clock)
+			if !ok {
+				continue
+			}
+			result = append(result, zabbix.History{
+				ItemID: it.ItemID,
+				Clock:  strconv.FormatInt(clock, 10),
+				Value:  value,
+				NS:     "0",
+			})
+		}
+	}
+	if params.SortOrder == "DESC" {
+		slices.SortStableFunc(result, func(a, b zabbix.History) int { return strings.Compare(b.Clock, a.Clock) })
+	}
+	if params.Limit > 0 && len(result) > params.Limit {
+		result = result[:params.Limit]
+	}
+	return result
+}
+
+// getTemplates answers template.get with the templates linked to the given
+// hosts or templates.
+func (s *Server) getTemplates(params getParams) []zabbix.Template {
+	result := []zabbix.Template{}
+	for _, t := range templateDefs {
+		linked := len(params.HostIDs) == 0
+		for _, id := range params.HostIDs {
+			if h := s.findHost(id); h != nil && slices.ContainsFunc(h.Groups, func(g zabbix.HostGroup) bool { return g.Name == t.group }) {
+				linked = true
+			}
+			if slices.ContainsFunc(templateDefs, func(d templateDef) bool { return d.id == id && slices.Contains(d.templates, t.id) }) {
+				linked = true
+			}
+		}
+		if linked {
+			result = append(result, zabbix.Template{TemplateID: t.id, Host: t.name, Name: t.name})
+		}
+	}
+	return result
+}
+
+// getMacros answers usermacro.get with the macros of hosts and templates,
+// or the global macros.
+func (s *Server) getMacros(params getParams) []zabbix.HostMacro {
+	result := []zabbix.HostMacro{}
+	if params.GlobalMacro {
+		for _, name := range slices.Sorted(maps.Keys(globalMacros)) {
+			result = append(result, zabbix.HostMacro{Macro: name, Value: globalMacros[name], Type: zabbix.MacroTypeText})
+		}
+		return result
+	}
+	for _, h := range s.hosts {
+		if inFilter(params.HostIDs, h.HostID) {
+			result = append(result, h.Macros...)
+		}
+	}
+	for i, t := range templateDefs {
+		if len(params.HostIDs) == 0 || !slices.Contains(params.HostIDs, t.id) {
+			continue
+		}
+		for j, name := range slices.Sorted(maps.Keys(t.macros)) {
+			result = append(result, zabbix.HostMacro{
+				HostMacroID: strconv.Itoa(firstTemplateMacro + 100*i + j),
+				HostID:      t.id,
+				Macro:       name,
+				Value:       t.macros[name],
+				Type:        zabbix.MacroTypeText,
+			})
+		}
+	}
+	return result
+}
+
+// findMacro returns the host and index of a user macro, or nil.
+func (s *Server) findMacro(id string) (*zabbix.Host, int) {
+	for _, h := range s.hosts {
+		if i := slices.IndexFunc(h.Macros, func(m zabbix.HostMacro) bool { return m.HostMacroID == id }); i >= 0 {
+			return h, i
+		}
+	}
+	return nil, -1
+}
+
+// createMacro answers usermacro.create.
+func (s *Server) createMacro(raw json.RawMessage) (any, *zabbix.APIError) {
+	var params zabbix.UserMacroCreateParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, errInvalidParams("%v", err)
+	}
+	h := s.findHost(params.HostID)
+	if h == nil {
+		return nil, errNoObject
+	}
+	if slices.ContainsFunc(h.Macros, func(m zabbix.HostMacro) bool { return m.Macro == params.Macro }) {
+		return nil, errInvalidParams("Macro %q already exists on %q.", params.Macro, h.Host)
+	}
+
+	macroType := params.Type
+	if macroType == "" {
+		macroType = zabbix.MacroTypeText
+	}
+	s.addMacro(h, params.Macro, params.Value, macroType)
+	h.Macros[len(h.Macros)-1].Description = params.Description
+	return map[string][]string{"hostmacroids": {h.Macros[len(h.Macros)-1].HostMacroID}}, nil
+}
+
+// updateMacro answers usermacro.update.
+func (s *Server) updateMacro(raw json.RawMessage) (any, *zabbix.APIError) {
+	var params zabbix.UserMacroUpdateParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, errInvalidParams("%v", err)
+	}
+	h, i := s.findMacro(params.HostMacroID)
+	if h == nil {
+		return nil, errNoObject
+	}
+
+	m := &h.Macros[i]
+	if params.Macro != "" {
+		m.Macro = params.Macro
+	}
+	m.Value = params.Value
+	if params.Type != "" {
+		m.Type = params.Type
+	}
+	if params.Description != "" {
+		m.Description = params.Description
+	}
+	return map[string][]string{"hostmacroids": {m.HostMacroID}}, nil
+}
+
+// deleteMacros answers usermacro.delete.
+func (s *Server) deleteMacros(raw json.RawMessage) (any, *zabbix.APIError) {
+	var ids []string
+	if err := json.Unmarshal(raw, &ids); err != nil {
+		return nil, errInvalidParams("%v", err)
+	}
+	for _, id := range ids {
+		h, i := s.findMacro(id)
+		if h == nil {
+			return nil, errNoObject
+		}
+		h.Macros = slices.Delete(h.Macros, i, i+1)
+	}
+	return map[string][]string{"hostmacroids": ids}, nil
+}
+
+// startMaintenance puts hosts in a one-time maintenance.
+func (s *Server) startMaintenance(name string, start time.Time, duration time.Duration, maintenanceType string, hosts ...*zabbix.Host) *zabbix.Maintenance {
+	since := strconv.FormatInt(start.Unix(), 10)
+	mt := &zabbix.Maintenance{
+		MaintenanceID:   strconv.FormatInt(s.nextMaintID, 10),
+		Name:            name,
+		MaintenanceType: maintenanceType,
+		ActiveSince:     since,
+		ActiveTill:      strconv.FormatInt(start.Add(duration).Unix(), 10),
+		TimePeriods: []zabbix.TimePeriod{{
+			TimePeriodType: zabbix.TimePeriodOnce,
+			StartDate:      since,
+			Period:         strconv.FormatInt(int64(duration.Seconds()), 10),
+		}},
+	}
+	s.nextMaintID++
+	s.maintenances = append(s.maintenances, mt)
+
+	for _, h := range hosts {
+		h.MaintenanceStatus = "1"
+		h.MaintenanceType = maintenanceType
+		h.MaintenanceID = mt.MaintenanceID
+		h.MaintenanceFrom = since
+	}
+	return mt
+}
+
+// addPatchWindow adds a weekly maintenance of the database servers for a
+// year from the start of the week of now, on Sunday nights.
+func (s *Server) addPatchWindow(now time.Time) {
+	y, m, d := now.Date()
+	since := time.Date(y, m, d-(int(now.Weekday())+6)%7, 0, 0, 0, 0, now.Location())
+	mt := &zabbix.Maintenance{
+		MaintenanceID:   strconv.FormatInt(s.nextMaintID, 10),
+		Name:            "Database patching",
+		MaintenanceType: zabbix.MaintenanceNoData,
+		Description:     "OS and PostgreSQL updates",
+		ActiveSince:     strconv.FormatInt(since.Unix(), 10),
+		ActiveTill:      strconv.FormatInt(since.AddDate(1, 0, 0).Unix(), 10),
+		TimePeriods: []zabbix.TimePeriod{{
+			TimePeriodType: zabbix.TimePeriodWeekly,
+			Every:          "1",
+			DayOfWeek:      "64", // Sunday
+			StartTime:      strconv.Itoa(2 * 3600),
+			Period:         strconv.Itoa(3 * 3600),
+		}},
+	}
+	s.nextMaintID++
+	s.maintenances = append(s.maintenances, mt)
+	s.maintenanceGroups = map[string][]string{mt.MaintenanceID: {groupIDs["Databases"]}}
+}
+
+// endMaintenances takes hosts out of maintenances that have ended.
+func (s *Server) endMaintenances() {
+	now := s.now()
+	for _, h := range s.hosts {
+		if !h.InMaintenance() {
+			continue
+		}
+		for _, mt := range s.maintenances {
+			if mt.MaintenanceID == h.MaintenanceID && !now.Before(mt.End(now)) {
+				h.MaintenanceStatus = "0"
+				h.MaintenanceType = ""
+				h.MaintenanceID = ""
+				h.MaintenanceFrom = ""
+			}
+		}
+	}
+}
+
+// getMaintenances answers maintenance.get.
+func (s *Server) getMaintenances(raw json.RawMessage) (any, *zabbix.APIError) {
+	var params struct {
+		MaintenanceIDs []string `json:"maintenanceids"`
+		HostIDs        []string `json:"hostids"`
+		GroupIDs       []string `json:"groupids"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, errInvalidParams("%v", err)
+	}
+	result := []zabbix.Maintenance{}
+	for _, mt := range s.maintenances {
+		if !inFilter(params.MaintenanceIDs, mt.MaintenanceID) {
+			continue
+		}
+		if params.HostIDs != nil && !slices.ContainsFunc(s.hosts, func(h *zabbix.Host) bool {
+			return h.MaintenanceID == mt.MaintenanceID && slices.Contains(params.HostIDs, h.HostID)
+		}) {
+			continue
+		}
+		if params.GroupIDs != nil && !slices.ContainsFunc(s.maintenanceGroups[mt.MaintenanceID], func(id string) bool {
+			return slices.Contains(params.GroupIDs, id)
+		}) {
+			continue
+		}
+		result = append(result, *mt)
+	}
+	return result, nil
+}
+
+// createMaintenance answers maintenance.create for one-time maintenances,
+// which start right away.
+func (s *Server) createMaintenance(raw json.RawMessage) (any, *zabbix.APIError) {
+	var params zabbix.MaintenanceCreateParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, errInvalidParams("%v", err)
+	}
+	if len(params.Hosts) == 0 {
+		return nil, errInvalidParams("At least one host group or host must be selected.")
+	}
+	for _, mt := range s.maintenances {
+		if mt.Name == params.Name {
+			return nil, errInvalidParams("Maintenance %q already exists.", params.Name)
+		}
+	}
+	var hosts []*zabbix.Host
+	for _, ref := range params.Hosts {
+		h := s.findHost(ref.HostID)
+		if h == nil {
+			return nil, errNoObject
+		}
+		hosts = append(hosts, h)
+	}
+
+	duration := time.Duration(params.ActiveTill-params.ActiveSince) * time.Second
+	mt := s.startMaintenance(params.Name, time.Unix(params.ActiveSince, 0), duration, params.MaintenanceType, hosts...)
+	return map[string][]string{"maintenanceids": {mt.MaintenanceID}}, nil
+}
+
+// createTasks answers task.create. Checks run at once and change nothing:
+// unreachable hosts stay unreachable.
+func (s *Server) createTasks(raw json.RawMessage) (any, *zabbix.APIError) {
+	var tasks []struct {
+		Type    int               `json:"type"`
+		Request map[string]string `json:"request"`
+	}
+	if err := json.Unmarshal(raw, &tasks); err != nil {
+		return nil, errInvalidParams("%v", err)
+	}
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		if !slices.ContainsFunc(s.items, func(it *item) bool { return it.ItemID == task.Request["itemid"] }) {
+			return nil, errNoObject
+		}
+		ids[i] = strconv.Itoa(i + 1)
+	}
+	return map[string][]string{"taskids": ids}, nil
+}
@@ -3,41 +3,150 @@ package format
 
 import (
 	"fmt"
+	"math"
+	"slices"
 	"strings"
+	"time"
 )
 
-// Value formats a numeric value with appropriate units.
-// Handles percentages, bytes, time units, and generic values.
+// unprefixedUnits are the units Zabbix shows without a multiplier.
+var unprefixedUnits = []string{"ms", "rpm", "RPM"}
+
+// Value formats a numeric value with its Zabbix units, like the Zabbix
+// frontend: "B" and "Bps" use binary multipliers and other units decimal
+// ones ("20.0 Mbps"), "s" is shown as a duration ("1h 2m 3s"), "uptime" as
+// days and a clock, "unixtime" as a date, and units starting with "!" are
+// shown without a multiplier.
 func Value(value float64, units string) string {
-	// Handle percentage
-	if units == "%" {
+	units, raw := strings.CutPrefix(units, "!")
+	switch {
+	case raw || slices.Contains(unprefixedUnits, units):
+		return withUnits(number(value), "", units)
+	case units == "%":
 		return fmt.Sprintf("%.1f%%", value)
+	case units == "s":
+		return Seconds(value)
+	case units == "uptime":
+		return Uptime(value)
+	case units == "unixtime":
+		return unixtime(value, "2006-01-02 15:04:05")
+	case units == "B" || units == "Bps":
+		return prefixed(value, 1024, units)
 	}
+	return prefixed(value, 1000, units)
+}
 
-	// Handle bytes
-	if units == "B" || units == "Bps" {
-		return Bytes(value) + strings.TrimPrefix(units, "B")
+// prefixed formats a value with the largest multiplier of base keeping it
+// at least 1, followed by the units if any: "1.5 KB", or "1.5K" without
+// units.
+func prefixed(value, base float64, units string) string {
+	const prefixes = "KMGTPE"
+	div, exp := 1.0, -1
+	for math.Abs(value)/div >= base && exp < len(prefixes)-1 {
+		div *= base
+		exp++
+	}
+	if exp < 0 {
+		return withUnits(number(value), "", units)
 	}
+	return withUnits(fmt.Sprintf("%.1f", value/div), prefixes[exp:exp+1], units)
+}
 
-	// Handle time units
-	if units == "s" {
-		if value < 1 {
-			return fmt.Sprintf("%.0fms", value*1000)
+// withUnits joins a formatted number, its multiplier and units, with a
+// space before the units.
+func withUnits(num, prefix, units string) string {
+	if units == "" {
+		return num + prefix
+	}
+	return num + " " + prefix + units
+}
+
+// number formats a value without a multiplier: whole numbers without
+// decimals, others with two.
+func number(value float64) string {
+	if value == math.Trunc(value) {
+		return fmt.Sprintf("%.0f", value)
+	}
+	return fmt.Sprintf("%.2f", value)
+}
+
+// durationUnits are the units of Seconds, largest first.
+var durationUnits = []struct {
+	name    string
+	seconds float64
+}{
+	{"y", 365 * 86400},
+	{"M", 30 * 86400},
+	{"d", 86400},
+	{"h", 3600},
+	{"m", 60},
+	{"s", 1},
+	{"ms", 0.001},
+}
+
+// Seconds formats a number of seconds like the Zabbix frontend, with at
+// most three consecutive units from the largest: "1h 1m 1s", "2s 500ms",
+// "3d 4h".
+func Seconds(value float64) string {
+	if value == 0 {
+		return "0"
+	}
+	sign := ""
+	if value < 0 {
+		sign, value = "-", -value
+	}
+
+	var parts []string
+	first := -1
+	rest := value
+	for i, u := range durationUnits {
+		if first >= 0 && i > first+2 {
+			break
 		}
-		return fmt.Sprintf("%.2fs", value)
+		n := math.Floor(rest / u.seconds)
+		if u.name == "ms" {
+			n = math.Round(rest / u.seconds)
+		}
+		if n <= 0 {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		parts = append(parts, fmt.Sprintf("%.0f%s", n, u.name))
+		rest -= n * u.seconds
+	}
+	if len(parts) == 0 {
+		return sign + fmt.Sprintf("%.2fms", value*1000)
 	}
+	return sign + strings.Join(parts, " ")
+}
 
-	// Default formatting
-	if value >= 1000000 {
-		return fmt.Sprintf("%.1fM", value/1000000)
+// Uptime formats a number of seconds like the Zabbix frontend shows
+// uptimes: "3 days, 04:05:06", or "04:05:06" for less than a day.
+func Uptime(value float64) string {
+	sign := ""
+	if value < 0 {
+		sign, value = "-", -value
 	}
-	if value >= 1000 {
-		return fmt.Sprintf("%.1fK", value/1000)
+	secs := int64(value)
+	days := secs / 86400
+	clock := fmt.Sprintf("%02d:%02d:%02d", secs%86400/3600, secs%3600/60, secs%60)
+	switch days {
+	case 0:
+		return sign + clock
+	case 1:
+		return sign + "1 day, " + clock
 	}
-	if value == float64(int64(value)) {
-		return fmt.Sprintf("%.0f", value)
+	return fmt.Sprintf("%s%d days, %s", sign, days, clock)
+}
+
+// unixtime formats a Unix timestamp with layout, or "Never" for zero.
+func unixtime(value float64, layout string) string {
+	if value == 0 {
+		return "Never"
 	}
-	return fmt.Sprintf("%.2f", value)
+	return time.Unix(int64(value), 0).Format(layout)
 }
 
 // Bytes formats bytes to human-readable form using binary prefixes (1024).
@@ -69,7 +178,20 @@ func BytesShort(bytes float64) string {
 }
 
 // YAxisValue formats a value for Y axis labels with appropriate SI suffixes.
+// Durations show their largest unit, timestamps their time of day, and
+// units starting with "!" have no multiplier, like in Value.
 func YAxisValue(value float64, units string) string {
+	units, raw := strings.CutPrefix(units, "!")
+	switch {
+	case raw || slices.Contains(unprefixedUnits, units):
+		return number(value)
+	case units == "s" || units == "uptime":
+		largest, _, _ := strings.Cut(Seconds(value), " ")
+		return largest
+	case units == "unixtime":
+		return unixtime(value, "15:04")
+	}
+
 	// Handle bytes specially - use binary prefixes (Ki, Mi, Gi)
 	if units == "B" || units == "Bps" {
 		return BytesShort(value)
@@ -2,6 +2,7 @@ package format
 
 import (
 	"testing"
+	"time"
 )
 
 func TestValue(t *testing.T) {
@@ -12,16 +13,29 @@ func TestValue(t *testing.T) {
 		want  string
 	}{
 		{"percentage", 75.5, "%", "75.5%"},
-		{"bytes small", 512, "B", "512"},
-		{"bytes KB", 1536, "B", "1.5K"},
-		{"bytes MB", 1572864, "B", "1.5M"},
-		{"bytes per sec", 1536, "Bps", "1.5Kps"},
+		{"bytes small", 512, "B", "512 B"},
+		{"bytes KB", 1536, "B", "1.5 KB"},
+		{"bytes MB", 1572864, "B", "1.5 MB"},
+		{"bytes per sec", 1536, "Bps", "1.5 KBps"},
+		{"bits per sec", 2e7, "bps", "20.0 Mbps"},
 		{"seconds small", 0.5, "s", "500ms"},
-		{"seconds large", 2.5, "s", "2.50s"},
+		{"seconds large", 2.5, "s", "2s 500ms"},
+		{"seconds hours", 3661, "s", "1h 1m 1s"},
+		{"seconds days", 90061, "s", "1d 1h 1m"},
+		{"seconds skipped unit", 3601, "s", "1h 1s"},
+		{"negative seconds", -90, "s", "-1m 30s"},
+		{"uptime", 3*86400 + 4*3600 + 5*60 + 6, "uptime", "3 days, 04:05:06"},
+		{"uptime one day", 86400 + 59, "uptime", "1 day, 00:00:59"},
+		{"uptime hours", 3725, "uptime", "01:02:05"},
+		{"unixtime never", 0, "unixtime", "Never"},
+		{"no multiplier", 15000, "!RPM", "15000 RPM"},
+		{"unprefixed unit", 1500, "ms", "1500 ms"},
 		{"large number", 1500000, "", "1.5M"},
+		{"giga number", 1.5e9, "", "1.5G"},
 		{"medium number", 1500, "", "1.5K"},
 		{"integer", 42, "", "42"},
 		{"decimal", 3.14159, "", "3.14"},
+		{"units", 42, "req", "42 req"},
 	}
 
 	for _, tt := range tests {
@@ -75,6 +89,9 @@ func TestYAxisValue(t *testing.T) {
 		{"decimal", 0.05, "", "0.05"},
 		{"tiny", 0.001, "", "0"},
 		{"negative giga", -1e9, "", "-1.0G"},
+		{"seconds", 7300, "s", "2h"},
+		{"uptime", 3 * 86400, "uptime", "3d"},
+		{"no multiplier", 15000, "!RPM", "15000"},
 	}
 
 	for _, tt := range tests {
@@ -86,3 +103,145 @@ func TestYAxisValue(t *testing.T) {
 		})
 	}
 }
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{45 * time.Second, "45s"},
+		{12 * time.Minute, "12m"},
+		{3*time.Hour + 5*time.Minute, "3h 5m"},
+		{52 * time.Hour, "2d 4h"},
+	}
+
+	for _, tt := range tests {
+		if got := Duration(tt.d); got != tt.want {
+			t.Errorf("Duration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestSpan(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{90 * time.Minute, "90m"},
+		{6 * time.Hour, "6h"},
+		{24 * time.Hour, "1d"},
+		{7 * 24 * time.Hour, "7d"},
+		{45 * time.Second, "45s"},
+	}
+
+	for _, tt := range tests {
+		if got := Span(tt.d); got != tt.want {
+			t.Errorf("Span(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestParseSpan(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30m", 30 * time.Minute, false},
+		{"6h", 6 * time.Hour, false},
+		{"3d", 72 * time.Hour, false},
+		{"1w", 7 * 24 * time.Hour, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"0d", 0, true},
+		{"-1h", 0, true},
+		{"d", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSpan(tt.s)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSpan(%q) = %v, %v; want %v, error %v", tt.s, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestRelative(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{"past", now.Add(-3 * time.Minute), "3m ago"},
+		{"days", now.Add(-52 * time.Hour), "2d 4h ago"},
+		{"now", now, "now"},
+		{"future", now.Add(5 * time.Minute), "in 5m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Relative(tt.t, now); got != tt.want {
+				t.Errorf("Relative() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeFormat_Full(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		f    TimeFormat
+		want string
+	}{
+		{"utc default layout", TimeFormat{Location: time.UTC}, "2025-01-02 15:04:05"},
+		{"timezone", TimeFormat{Location: tokyo}, "2025-01-03 00:04:05"},
+		{"custom layout", TimeFormat{Location: time.UTC, Layout: "Jan 2 15:04"}, "Jan 2 15:04"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.Full(ts); got != tt.want {
+				t.Errorf("Full() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := (TimeFormat{}).Full(time.Time{}); got != "-" {
+		t.Errorf("Full(zero) = %q, want %q", got, "-")
+	}
+}
+
+func TestTimeFormat_Clock(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	f24 := TimeFormat{Location: time.UTC}
+	f12 := TimeFormat{Location: time.UTC, Hour12: true}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"24h clock", f24.Clock(ts), "15:04:05"},
+		{"12h clock", f12.Clock(ts), "3:04:05 PM"},
+		{"24h short", f24.ClockShort(ts), "15:04"},
+		{"12h short", f12.ClockShort(ts), "3:04PM"},
+		{"12h full default layout", f12.Full(ts), "2025-01-02 3:04:05 PM"},
+		{"relative ignored by clock", TimeFormat{Location: time.UTC, Relative: true}.Clock(ts), "15:04:05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
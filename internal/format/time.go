@@ -0,0 +1,152 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default layouts for absolute date-times.
+const (
+	DefaultTimeLayout   = "2006-01-02 15:04:05"   // 24-hour clock
+	DefaultTimeLayout12 = "2006-01-02 3:04:05 PM" // 12-hour clock
+)
+
+// TimeFormat holds the user's timestamp display preferences.
+// The zero value shows absolute times in the local timezone.
+type TimeFormat struct {
+	Relative bool           // Show times relative to now ("3m ago")
+	Hour12   bool           // Use a 12-hour clock for times of day
+	Layout   string         // Layout for absolute date-times (default depends on Hour12)
+	Location *time.Location // Timezone for absolute times (default: local)
+}
+
+// Full formats a timestamp for detail views, e.g. "2025-01-02 15:04:05" or "3m ago".
+func (f TimeFormat) Full(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	if f.Relative {
+		return Relative(t, time.Now())
+	}
+	layout := f.Layout
+	if layout == "" {
+		layout = DefaultTimeLayout
+		if f.Hour12 {
+			layout = DefaultTimeLayout12
+		}
+	}
+	return f.in(t).Format(layout)
+}
+
+// Short formats a timestamp for narrow list columns, e.g. "15:04:05" or "3m".
+func (f TimeFormat) Short(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	if f.Relative {
+		return Duration(time.Since(t))
+	}
+	return f.Clock(t)
+}
+
+// ShortWidth returns the column width needed for Short timestamps.
+func (f TimeFormat) ShortWidth() int {
+	if f.Hour12 {
+		return 11 // "12:04:05 PM"
+	}
+	return 8 // "15:04:05"
+}
+
+// Clock formats the time of day, e.g. "15:04:05" or "3:04:05 PM".
+// It ignores the relative setting, for clocks and "last updated" displays.
+func (f TimeFormat) Clock(t time.Time) string {
+	if f.Hour12 {
+		return f.in(t).Format("3:04:05 PM")
+	}
+	return f.in(t).Format("15:04:05")
+}
+
+// ClockShort formats the time of day without seconds, e.g. "15:04" or "3:04PM".
+func (f TimeFormat) ClockShort(t time.Time) string {
+	if f.Hour12 {
+		return f.in(t).Format("3:04PM")
+	}
+	return f.in(t).Format("15:04")
+}
+
+// in converts t to the configured timezone.
+func (f TimeFormat) in(t time.Time) time.Time {
+	if f.Location == nil {
+		return t.Local()
+	}
+	return t.In(f.Location)
+}
+
+// Relative formats t relative to now, e.g. "3m ago", "2d 4h ago" or "in 5m".
+func Relative(t, now time.Time) string {
+	d := now.Sub(t)
+	if d < 0 {
+		return "in " + Duration(-d)
+	}
+	if d < time.Second {
+		return "now"
+	}
+	return Duration(d) + " ago"
+}
+
+// Duration formats a duration as a compact human-readable string,
+// e.g. "45s", "12m", "3h 5m" or "2d 4h".
+func Duration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	if d < 24*time.Hour {
+		h := int(d.Hours())
+		m := int(d.Minutes()) % 60
+		return fmt.Sprintf("%dh %dm", h, m)
+	}
+
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	return fmt.Sprintf("%dd %dh", days, hours)
+}
+
+// Span formats a duration in its largest whole unit, e.g. "90m", "6h" or
+// "7d", falling back to Duration for other values.
+func Span(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d >= time.Hour && d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d >= time.Minute && d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return Duration(d)
+}
+
+// ParseSpan parses a duration such as "30m", "6h", "7d" or "1w", or any
+// duration accepted by time.ParseDuration.
+func ParseSpan(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	units := map[byte]time.Duration{'d': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	if n := len(s); n > 1 {
+		if unit, ok := units[s[n-1]]; ok {
+			count, err := strconv.Atoi(s[:n-1])
+			if err != nil || count <= 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,200 @@
+// Package hooks plays sounds and runs commands, configured per severity,
+// when a new problem appears or a disaster stays unacknowledged too long.
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/harpchad/chotko/internal/config"
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// Reasons a hook runs, passed to commands as CHOTKO_REASON.
+const (
+	ReasonNew     = "new"     // The problem appeared
+	ReasonUnacked = "unacked" // The disaster is unacknowledged too long
+)
+
+// Event is a problem a hook runs for.
+type Event struct {
+	Reason   string
+	Severity int
+	Host     string
+	Problem  string
+	EventID  string
+}
+
+// Runner decides which problems need a hook and runs their hooks. A nil
+// Runner has no hooks.
+type Runner struct {
+	hooks   [config.MaxSeverity + 1]config.Hook
+	player  []string
+	unacked time.Duration
+	since   time.Time        // Problems that started before are not new
+	seen    map[seenKey]bool // Events already run for
+}
+
+// seenKey identifies an event run for a problem.
+type seenKey struct {
+	reason  string
+	eventID string
+}
+
+// New returns a runner of the configured hooks, or nil if there are none.
+// Only problems starting after since are new, so that the problems open
+// when chotko starts don't all ring at once.
+func New(cfg *config.Config, since time.Time) *Runner {
+	r := &Runner{
+		hooks:   cfg.GetHooks(),
+		player:  strings.Fields(cfg.Hooks.Player),
+		unacked: cfg.GetUnackedDisaster(),
+		since:   since,
+		seen:    make(map[seenKey]bool),
+	}
+	for _, h := range r.hooks {
+		if h != (config.Hook{}) {
+			return r
+		}
+	}
+	return nil
+}
+
+// Check returns the problems whose hooks should run: those that are new,
+// and disasters unacknowledged for longer than the configured limit. Each
+// problem is returned once for each reason.
+func (r *Runner) Check(problems []zabbix.Problem, now time.Time) []Event {
+	if r == nil {
+		return nil
+	}
+	var events []Event
+	for i := range problems {
+		p := &problems[i]
+		severity := p.SeverityInt()
+		if severity < 0 || severity >= len(r.hooks) || r.hooks[severity] == (config.Hook{}) {
+			continue
+		}
+		reason := ""
+		switch {
+		case !p.StartTime().Before(r.since) && !r.seen[seenKey{ReasonNew, p.EventID}]:
+			reason = ReasonNew
+		case severity == config.MaxSeverity && r.unacked > 0 && !p.IsAcknowledged() &&
+			now.Sub(p.StartTime()) >= r.unacked && !r.seen[seenKey{ReasonUnacked, p.EventID}]:
+			reason = ReasonUnacked
+		default:
+			continue
+		}
+		r.seen[seenKey{reason, p.EventID}] = true
+		e := Event{Reason: reason, Severity: severity, Problem: p.Name, EventID: p.EventID}
+		if len(p.Hosts) > 0 {
+			e.Host = p.Hosts[0].Name
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+// Prune forgets the events run for problems that are no longer open, given
+// all the open problems, so that long sessions don't remember every problem
+// they have seen.
+func (r *Runner) Prune(problems []zabbix.Problem) {
+	if r == nil {
+		return
+	}
+	open := make(map[string]bool, len(problems))
+	for i := range problems {
+		open[problems[i].EventID] = true
+	}
+	for key := range r.seen {
+		if !open[key.eventID] {
+			delete(r.seen, key)
+		}
+	}
+}
+
+// Run plays the sound of the worst of the events, once, unless sound is
+// muted, and runs the command of each.
+func (r *Runner) Run(ctx context.Context, events []Event, muted bool) error {
+	if r == nil || len(events) == 0 {
+		return nil
+	}
+	var errs []error
+	worst := events[0]
+	for _, e := range events {
+		if r.hooks[e.Severity].Sound != "" && (e.Severity > worst.Severity || r.hooks[worst.Severity].Sound == "") {
+			worst = e
+		}
+	}
+	if sound := r.hooks[worst.Severity].Sound; sound != "" && !muted {
+		errs = append(errs, r.play(ctx, sound))
+	}
+	for _, e := range events {
+		if command := r.hooks[e.Severity].Command; command != "" {
+			errs = append(errs, runCommand(ctx, command, e))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// play plays a sound file with the configured or default player.
+func (r *Runner) play(ctx context.Context, sound string) error {
+	player := r.player
+	if len(player) == 0 {
+		player = defaultPlayer()
+	}
+	if len(player) == 0 {
+		return fmt.Errorf("no sound player on %s; set hooks.player", runtime.GOOS)
+	}
+	if rest, ok := strings.CutPrefix(sound, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			sound = filepath.Join(home, rest)
+		}
+	}
+	args := append(append([]string{}, player[1:]...), sound)
+	if out, err := exec.CommandContext(ctx, player[0], args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("playing %s: %w: %s", sound, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// defaultPlayer returns the usual command playing sound files on this
+// system.
+func defaultPlayer() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"afplay"}
+	case "windows":
+		return nil
+	}
+	return []string{"paplay"}
+}
+
+// runCommand runs a shell command for an event, given in CHOTKO_REASON,
+// CHOTKO_SEVERITY, CHOTKO_HOST, CHOTKO_PROBLEM and CHOTKO_EVENTID.
+func runCommand(ctx context.Context, command string, e Event) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(),
+		"CHOTKO_REASON="+e.Reason,
+		"CHOTKO_SEVERITY="+theme.SeverityName(e.Severity),
+		"CHOTKO_HOST="+e.Host,
+		"CHOTKO_PROBLEM="+e.Problem,
+		"CHOTKO_EVENTID="+e.EventID,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("hook command %q: %w: %s", command, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harpchad/chotko/internal/config"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+func problem(id string, severity int, started time.Time, acked bool) zabbix.Problem {
+	p := zabbix.Problem{
+		EventID:  id,
+		Name:     "Problem " + id,
+		Severity: strconv.Itoa(severity),
+		Clock:    strconv.FormatInt(started.Unix(), 10),
+		Hosts:    []zabbix.Host{{Name: "web01"}},
+	}
+	if acked {
+		p.Acknowledged = "1"
+	}
+	return p
+}
+
+func TestNew_NoHooks(t *testing.T) {
+	if r := New(config.DefaultConfig(), time.Now()); r != nil {
+		t.Errorf("New() = %+v, want nil without hooks", r)
+	}
+	var r *Runner
+	if events := r.Check([]zabbix.Problem{problem("1", 5, time.Now(), false)}, time.Now()); events != nil {
+		t.Errorf("Check() on nil runner = %v, want nil", events)
+	}
+}
+
+func TestRunner_Check(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cfg := config.DefaultConfig()
+	cfg.Hooks = config.HooksConfig{
+		UnackedDisaster: "15m",
+		Severities: map[string]config.Hook{
+			"disaster": {Sound: "alarm.wav"},
+			"high":     {Command: "true"},
+		},
+	}
+	r := New(cfg, start)
+
+	problems := []zabbix.Problem{
+		problem("1", 5, start.Add(-5*time.Minute), false), // Open before start
+		problem("2", 4, start.Add(time.Minute), false),    // New
+		problem("3", 3, start.Add(time.Minute), false),    // No hook for Average
+		problem("4", 5, start.Add(time.Minute), true),     // New, acknowledged
+	}
+	now := start.Add(2 * time.Minute)
+	events := r.Check(problems, now)
+	if len(events) != 2 || events[0].EventID != "2" || events[1].EventID != "4" || events[0].Reason != ReasonNew {
+		t.Fatalf("Check() = %+v, want problems 2 and 4 as new", events)
+	}
+	if events[0].Host != "web01" || events[0].Problem != "Problem 2" {
+		t.Errorf("event = %+v, want host and problem name", events[0])
+	}
+	if events := r.Check(problems, now); len(events) != 0 {
+		t.Errorf("Check() again = %+v, want each problem once", events)
+	}
+
+	// Disaster 1 is now unacknowledged for over 15 minutes; acknowledged
+	// disaster 4 never escalates
+	events = r.Check(problems, start.Add(20*time.Minute))
+	if len(events) != 1 || events[0].EventID != "1" || events[0].Reason != ReasonUnacked {
+		t.Fatalf("Check() = %+v, want disaster 1 unacknowledged", events)
+	}
+	if events := r.Check(problems, start.Add(time.Hour)); len(events) != 0 {
+		t.Errorf("Check() again = %+v, want the escalation once", events)
+	}
+}
+
+func TestRunner_Prune(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cfg := config.DefaultConfig()
+	cfg.Hooks = config.HooksConfig{Severities: map[string]config.Hook{"high": {Command: "true"}}}
+	r := New(cfg, start)
+
+	problems := []zabbix.Problem{problem("1", 4, start.Add(time.Minute), false), problem("2", 4, start.Add(time.Minute), false)}
+	if events := r.Check(problems, start.Add(2*time.Minute)); len(events) != 2 {
+		t.Fatalf("Check() = %+v, want both problems", events)
+	}
+	r.Prune(problems[1:])
+	if len(r.seen) != 1 || !r.seen[seenKey{ReasonNew, "2"}] {
+		t.Errorf("seen = %v, want only the open problem 2", r.seen)
+	}
+	if events := r.Check(problems[1:], start.Add(3*time.Minute)); len(events) != 0 {
+		t.Errorf("Check() = %+v, want the open problem not to run again", events)
+	}
+}
+
+func TestRunner_Run(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	cfg := config.DefaultConfig()
+	cfg.Hooks = config.HooksConfig{
+		Severities: map[string]config.Hook{
+			"disaster": {Sound: "alarm.wav", Command: `echo "$CHOTKO_REASON $CHOTKO_SEVERITY $CHOTKO_HOST $CHOTKO_PROBLEM" >> ` + out},
+			"high":     {Sound: "beep.wav"},
+		},
+	}
+	r := New(cfg, time.Now())
+	// A configured player is split on spaces, too few for a script
+	r.player = []string{"sh", "-c", "echo played $0 >> " + out}
+
+	err := r.Run(context.Background(), []Event{
+		{Reason: ReasonNew, Severity: 4, Host: "db01", Problem: "Slow"},
+		{Reason: ReasonUnacked, Severity: 5, Host: "web01", Problem: "Down"},
+	}, false)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "played alarm.wav\nunacked Disaster web01 Down\n"
+	if string(data) != want {
+		t.Errorf("hooks wrote %q, want %q (the worst sound once, then the commands)", data, want)
+	}
+
+	// Muting silences the sounds only
+	if err := r.Run(context.Background(), []Event{{Reason: ReasonNew, Severity: 5, Host: "web01", Problem: "Down"}}, true); err != nil {
+		t.Fatalf("Run() muted error = %v", err)
+	}
+	if data, _ = os.ReadFile(out); string(data) != want+"new Disaster web01 Down\n" {
+		t.Errorf("muted hooks wrote %q, want only the command's line added", data)
+	}
+
+	r.hooks[5].Command = "echo oops >&2; exit 3"
+	err = r.Run(context.Background(), []Event{{Severity: 5}}, false)
+	if err == nil || !strings.Contains(err.Error(), "oops") {
+		t.Errorf("Run() error = %v, want the command's output", err)
+	}
+}
@@ -0,0 +1,261 @@
+// Package ical writes the windows of Zabbix maintenances as iCalendar
+// (RFC 5545) files, to import them into team calendars.
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// Layouts of iCalendar date-times, local and UTC.
+const (
+	localLayout = "20060102T150405"
+	utcLayout   = "20060102T150405Z"
+)
+
+// maxLineLength is the length in bytes lines are folded at.
+const maxLineLength = 75
+
+// zoneSpan is how long after the first event the time zone's changes are
+// written for, when the events do not end before.
+const zoneSpan = 20 * 365 * 24 * time.Hour
+
+// event is an event of the calendar: a period of a maintenance, from its
+// first start, recurring by rule if it is not empty.
+type event struct {
+	uid         string
+	maintenance *zabbix.Maintenance
+	start       time.Time
+	duration    time.Duration
+	rule        string
+}
+
+// Write writes an event for each period of the maintenances, recurring
+// periods with a recurrence rule until the maintenance ends, and returns
+// how many maintenances have events. Times are written in loc, the time
+// zone the periods are expanded in, which should be the server's, with its
+// changes over the events. domain makes the events' IDs unique across
+// servers, and now stamps them.
+func Write(w io.Writer, maintenances []zabbix.Maintenance, loc *time.Location, domain string, now time.Time) (int, error) {
+	var events []event
+	count := 0
+	for i := range maintenances {
+		mt := &maintenances[i]
+		n := len(events)
+		for j, p := range mt.TimePeriods {
+			if e, ok := periodEvent(mt, p, loc); ok {
+				e.uid = fmt.Sprintf("maintenance-%s-%d@%s", mt.MaintenanceID, j, domain)
+				events = append(events, e)
+			}
+		}
+		if len(events) > n {
+			count++
+		}
+	}
+
+	b := &builder{}
+	b.line("BEGIN:VCALENDAR")
+	b.line("VERSION:2.0")
+	b.line("PRODID:-//chotko//Zabbix maintenances//EN")
+	b.line("CALSCALE:GREGORIAN")
+	if len(events) > 0 && loc != time.UTC {
+		b.timeZone(loc, events)
+	}
+	for _, e := range events {
+		b.event(e, loc, now)
+	}
+	b.line("END:VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	return count, err
+}
+
+// periodEvent returns the event of a period of a maintenance, starting at
+// its first window. ok is false if it has none while the maintenance is
+// active.
+func periodEvent(mt *zabbix.Maintenance, p zabbix.TimePeriod, loc *time.Location) (event, bool) {
+	duration := p.Duration()
+	since, until := mt.Active()
+	if duration <= 0 || since.IsZero() {
+		return event{}, false
+	}
+	end := until
+	if end.IsZero() {
+		end = since.Add(zoneSpan)
+	}
+
+	// The first window open once the maintenance is active
+	starts := mt.Starts(p, since.Add(-duration).In(loc), end)
+	for len(starts) > 0 && !starts[0].Add(duration).After(since) {
+		starts = starts[1:]
+	}
+	if len(starts) == 0 {
+		return event{}, false
+	}
+	e := event{maintenance: mt, start: starts[0], duration: duration}
+	if p.TimePeriodType != zabbix.TimePeriodOnce {
+		e.rule = rule(p, until)
+	}
+	return e, true
+}
+
+// rule returns the recurrence rule of a recurring period of a maintenance
+// active until until.
+func rule(p zabbix.TimePeriod, until time.Time) string {
+	every, err := strconv.Atoi(p.Every)
+	if err != nil || every < 1 {
+		every = 1
+	}
+
+	var parts []string
+	switch p.TimePeriodType {
+	case zabbix.TimePeriodDaily:
+		parts = append(parts, "FREQ=DAILY", fmt.Sprintf("INTERVAL=%d", every))
+	case zabbix.TimePeriodWeekly:
+		parts = append(parts, "FREQ=WEEKLY", fmt.Sprintf("INTERVAL=%d", every), "BYDAY="+byDay(p.Weekdays(), ""), "WKST=MO")
+	case zabbix.TimePeriodMonthly:
+		parts = append(parts, "FREQ=MONTHLY")
+		if months := p.Months(); len(months) < 12 {
+			var ms []string
+			for _, m := range months {
+				ms = append(ms, fmt.Sprint(int(m)))
+			}
+			parts = append(parts, "BYMONTH="+strings.Join(ms, ","))
+		}
+		if p.Day != "" && p.Day != "0" {
+			parts = append(parts, "BYMONTHDAY="+p.Day)
+		} else {
+			// The week of the month, 5 for the last
+			week := fmt.Sprint(every)
+			if every >= 5 {
+				week = "-1"
+			}
+			parts = append(parts, "BYDAY="+byDay(p.Weekdays(), week))
+		}
+	}
+	if !until.IsZero() {
+		// Periods starting when the maintenance ends are not in effect
+		parts = append(parts, "UNTIL="+until.Add(-time.Second).UTC().Format(utcLayout))
+	}
+	return strings.Join(parts, ";")
+}
+
+// byDay returns the days of the week of a rule, each prefixed with the
+// week of the month if not empty.
+func byDay(days []time.Weekday, week string) string {
+	names := make([]string, 0, len(days))
+	for _, d := range days {
+		names = append(names, week+strings.ToUpper(d.String()[:2]))
+	}
+	return strings.Join(names, ",")
+}
+
+// builder builds the lines of a calendar, folded and ending with CRLF.
+type builder struct {
+	strings.Builder
+}
+
+// line adds a content line, folded at maxLineLength bytes without
+// splitting characters.
+func (b *builder) line(s string) {
+	limit := maxLineLength
+	for len(s) > limit {
+		n := limit
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		b.WriteString(s[:n])
+		b.WriteString("\r\n ")
+		s = s[n:]
+		limit = maxLineLength - 1 // Continuation lines start with a space
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
+
+// event adds a maintenance period as an event.
+func (b *builder) event(e event, loc *time.Location, now time.Time) {
+	mt := e.maintenance
+	b.line("BEGIN:VEVENT")
+	b.line("UID:" + e.uid)
+	b.line("DTSTAMP:" + now.UTC().Format(utcLayout))
+	b.line("DTSTART" + dateTime(e.start, loc))
+	b.line("DURATION:" + duration(e.duration))
+	if e.rule != "" {
+		b.line("RRULE:" + e.rule)
+	}
+	b.line("SUMMARY:" + escape(mt.Name))
+	b.line("DESCRIPTION:" + escape(description(mt)))
+	b.line("CATEGORIES:Maintenance")
+	b.line("TRANSP:TRANSPARENT")
+	b.line("END:VEVENT")
+}
+
+// description describes a maintenance: its description, hosts and host
+// groups and whether data is collected.
+func description(mt *zabbix.Maintenance) string {
+	var lines []string
+	if mt.Description != "" {
+		lines = append(lines, mt.Description, "")
+	}
+	if len(mt.Hosts) > 0 {
+		names := make([]string, 0, len(mt.Hosts))
+		for _, h := range mt.Hosts {
+			names = append(names, h.DisplayName())
+		}
+		lines = append(lines, "Hosts: "+strings.Join(names, ", "))
+	}
+	if len(mt.Groups) > 0 {
+		names := make([]string, 0, len(mt.Groups))
+		for _, g := range mt.Groups {
+			names = append(names, g.Name)
+		}
+		lines = append(lines, "Host groups: "+strings.Join(names, ", "))
+	}
+	if mt.CollectsData() {
+		lines = append(lines, "Data collection: on")
+	} else {
+		lines = append(lines, "Data collection: off")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// dateTime returns the parameters and value of a date-time property in
+// loc, e.g. ";TZID=Europe/Berlin:20250302T020000", in UTC if loc is.
+func dateTime(t time.Time, loc *time.Location) string {
+	if loc == time.UTC {
+		return ":" + t.UTC().Format(utcLayout)
+	}
+	return ";TZID=" + loc.String() + ":" + t.In(loc).Format(localLayout)
+}
+
+// duration formats a duration exactly, in hours rather than days, which
+// last 23 or 25 hours when the clocks change, e.g. "PT2H30M".
+func duration(d time.Duration) string {
+	secs := int64(d / time.Second)
+	if secs <= 0 {
+		return "PT0S"
+	}
+	s := "PT"
+	if h := secs / 3600; h > 0 {
+		s += fmt.Sprintf("%dH", h)
+	}
+	if m := secs % 3600 / 60; m > 0 {
+		s += fmt.Sprintf("%dM", m)
+	}
+	if sec := secs % 60; sec > 0 {
+		s += fmt.Sprintf("%dS", sec)
+	}
+	return s
+}
+
+// escape escapes a text value.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,123 @@
+package ical
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+func TestWrite(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2025, month, day, hour, 0, 0, 0, berlin)
+	}
+	unix := func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) }
+
+	maintenances := []zabbix.Maintenance{
+		{
+			MaintenanceID: "1", Name: "Database patching", Description: "OS updates; reboots",
+			MaintenanceType: zabbix.MaintenanceNoData,
+			ActiveSince:     unix(at(time.March, 1, 0)), ActiveTill: unix(at(time.December, 1, 0)),
+			Groups: []zabbix.HostGroup{{GroupID: "21", Name: "Databases"}},
+			TimePeriods: []zabbix.TimePeriod{
+				{TimePeriodType: zabbix.TimePeriodWeekly, Every: "2", DayOfWeek: "65", StartTime: "7200", Period: "10800"},
+				{TimePeriodType: zabbix.TimePeriodMonthly, Month: "65", Every: "5", DayOfWeek: "64", StartTime: "3600", Period: "93600"},
+				{TimePeriodType: zabbix.TimePeriodMonthly, Month: "4095", Day: "15", Period: "1800"},
+			},
+		},
+		{
+			MaintenanceID: "2", Name: "Rack move",
+			ActiveSince: unix(at(time.April, 1, 0)), ActiveTill: unix(at(time.April, 2, 0)),
+			Hosts:       []zabbix.Host{{HostID: "10084", Name: "web01"}},
+			TimePeriods: []zabbix.TimePeriod{{TimePeriodType: zabbix.TimePeriodOnce, StartDate: unix(at(time.April, 1, 20)), Period: "7200"}},
+		},
+		{
+			// Its only period is before it becomes active
+			MaintenanceID: "3", Name: "Expired",
+			ActiveSince: unix(at(time.May, 1, 0)), ActiveTill: unix(at(time.May, 2, 0)),
+			TimePeriods: []zabbix.TimePeriod{{TimePeriodType: zabbix.TimePeriodOnce, StartDate: unix(at(time.April, 1, 0)), Period: "60"}},
+		},
+	}
+
+	var b strings.Builder
+	n, err := Write(&b, maintenances, berlin, "zabbix.example.com", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Write() = %d maintenances, want 2 with events", n)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"TZID:Europe/Berlin\r\n",
+		// The clocks go forward on the last Sunday of March
+		"BEGIN:DAYLIGHT\r\nDTSTART:20250330T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\nTZNAME:CEST\r\n",
+		"UID:maintenance-1-0@zabbix.example.com\r\n",
+		"DTSTAMP:20250201T000000Z\r\n",
+		// Every other week from the week of March 1st, a Saturday: Sunday
+		// the 2nd is the first
+		"DTSTART;TZID=Europe/Berlin:20250302T020000\r\nDURATION:PT3H\r\n" +
+			"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,SU;WKST=MO;UNTIL=20251130T225959Z\r\n",
+		"DTSTART;TZID=Europe/Berlin:20250727T010000\r\nDURATION:PT26H\r\n" +
+			"RRULE:FREQ=MONTHLY;BYMONTH=1,7;BYDAY=-1SU;UNTIL=20251130T225959Z\r\n",
+		"RRULE:FREQ=MONTHLY;BYMONTHDAY=15;UNTIL=20251130T225959Z\r\n",
+		`SUMMARY:Database patching`,
+		`DESCRIPTION:OS updates\; reboots\n\nHost groups: Databases\n`,
+		"DTSTART;TZID=Europe/Berlin:20250401T200000\r\nDURATION:PT2H\r\nSUMMARY:Rack move\r\n",
+		`DESCRIPTION:Hosts: web01\nData collection: on`,
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("calendar missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Expired") {
+		t.Error("maintenances without windows while active should be left out")
+	}
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > maxLineLength {
+			t.Errorf("line %q is longer than %d bytes", line, maxLineLength)
+		}
+	}
+}
+
+func TestLineFolding(t *testing.T) {
+	var b builder
+	b.line("DESCRIPTION:" + strings.Repeat("ä", 100))
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) != 3 {
+		t.Fatalf("folded into %d lines, want 3:\n%s", len(lines), b.String())
+	}
+	unfolded := lines[0]
+	for _, l := range lines[1:] {
+		if !strings.HasPrefix(l, " ") {
+			t.Errorf("continuation line %q should start with a space", l)
+		}
+		unfolded += l[1:]
+	}
+	if unfolded != "DESCRIPTION:"+strings.Repeat("ä", 100) {
+		t.Error("folding should not split characters")
+	}
+}
+
+func TestDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                              "PT0S",
+		90 * time.Minute:               "PT1H30M",
+		26 * time.Hour:                 "PT26H",
+		time.Hour + 5*time.Second:      "PT1H5S",
+		2*time.Minute + 30*time.Second: "PT2M30S",
+	}
+	for d, want := range tests {
+		if got := duration(d); got != want {
+			t.Errorf("duration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package ical
+
+import (
+	"fmt"
+	"time"
+)
+
+// timeZone adds the time zone of the events, with its offset when the
+// first one starts and its changes until the last one ends, so that
+// calendars in other time zones show them at the right times even if loc
+// is only known here, such as "Local".
+func (b *builder) timeZone(loc *time.Location, events []event) {
+	from, till := events[0].start, events[0].start
+	for _, e := range events {
+		if e.start.Before(from) {
+			from = e.start
+		}
+		end := e.start.Add(e.duration)
+		if _, until := e.maintenance.Active(); e.rule != "" && !until.IsZero() {
+			end = until
+		}
+		if end.After(till) {
+			till = end
+		}
+	}
+	if limit := from.Add(zoneSpan); till.After(limit) {
+		till = limit
+	}
+
+	b.line("BEGIN:VTIMEZONE")
+	b.line("TZID:" + loc.String())
+	start := from.In(loc)
+	_, offset := start.Zone()
+	b.observance(start, offset)
+	for _, t := range transitions(loc, from, till) {
+		b.observance(t, offset)
+		_, offset = t.Zone()
+	}
+	b.line("END:VTIMEZONE")
+}
+
+// observance adds the observance of the time zone from t, when its offset
+// changes from offset.
+func (b *builder) observance(t time.Time, offset int) {
+	kind := "STANDARD"
+	if t.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	name, to := t.Zone()
+	b.line("BEGIN:" + kind)
+	// Observances start at the local time before the change
+	b.line("DTSTART:" + t.In(time.FixedZone("", offset)).Format(localLayout))
+	b.line("TZOFFSETFROM:" + utcOffset(offset))
+	b.line("TZOFFSETTO:" + utcOffset(to))
+	b.line("TZNAME:" + escape(name))
+	b.line("END:" + kind)
+}
+
+// transitions returns when the offset of loc changes between from and till.
+func transitions(loc *time.Location, from, till time.Time) []time.Time {
+	var changes []time.Time
+	t := from.In(loc)
+	_, offset := t.Zone()
+	for t.Before(till) {
+		next := t.Add(24 * time.Hour)
+		if _, o := next.Zone(); o != offset {
+			// Narrow the day down to the second of the change
+			lo, hi := t, next
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2)
+				if _, o := mid.Zone(); o == offset {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			changes = append(changes, hi.Truncate(time.Second))
+			offset = o
+		}
+		t = next
+	}
+	return changes
+}
+
+// utcOffset formats an offset from UTC in seconds, e.g. "+0100".
+func utcOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	s := fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+	if offset%60 != 0 {
+		s += fmt.Sprintf("%02d", offset%60)
+	}
+	return s
+}
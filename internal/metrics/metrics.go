@@ -0,0 +1,102 @@
+// Package metrics collects API call latencies for the :stats view.
+package metrics
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SampleSize is the number of recent calls kept per method for percentiles.
+const SampleSize = 100
+
+// MethodStats summarizes the recent calls of a single API method.
+type MethodStats struct {
+	Method string
+	Calls  int // Total calls since startup
+	Errors int // Total failed calls since startup
+	P50    time.Duration
+	P95    time.Duration
+	Last   time.Duration
+}
+
+// methodSamples holds a ring of recent durations for one method.
+type methodSamples struct {
+	durations []time.Duration
+	next      int
+	calls     int
+	errors    int
+	last      time.Duration
+}
+
+// Latencies records API call durations per method. It is safe for concurrent use.
+type Latencies struct {
+	mu      sync.Mutex
+	methods map[string]*methodSamples
+}
+
+// NewLatencies creates an empty latency recorder.
+func NewLatencies() *Latencies {
+	return &Latencies{methods: make(map[string]*methodSamples)}
+}
+
+// Record adds a completed call.
+func (l *Latencies) Record(method string, d time.Duration, failed bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s, ok := l.methods[method]
+	if !ok {
+		s = &methodSamples{}
+		l.methods[method] = s
+	}
+
+	if len(s.durations) < SampleSize {
+		s.durations = append(s.durations, d)
+	} else {
+		s.durations[s.next] = d
+		s.next = (s.next + 1) % SampleSize
+	}
+	s.calls++
+	if failed {
+		s.errors++
+	}
+	s.last = d
+}
+
+// Summary returns per-method statistics sorted by method name.
+func (l *Latencies) Summary() []MethodStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	out := make([]MethodStats, 0, len(l.methods))
+	for method, s := range l.methods {
+		sorted := make([]time.Duration, len(s.durations))
+		copy(sorted, s.durations)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		out = append(out, MethodStats{
+			Method: method,
+			Calls:  s.calls,
+			Errors: s.errors,
+			P50:    Percentile(sorted, 50),
+			P95:    Percentile(sorted, 95),
+			Last:   s.last,
+		})
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Method < out[j].Method })
+	return out
+}
+
+// Percentile returns the p-th percentile (nearest rank) of sorted durations.
+// Returns 0 for an empty slice.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	rank = min(max(rank, 1), len(sorted))
+	return sorted[rank-1]
+}
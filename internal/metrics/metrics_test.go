@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	var sorted []time.Duration
+	for i := 1; i <= 100; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Millisecond)
+	}
+
+	tests := []struct {
+		name   string
+		sorted []time.Duration
+		p      float64
+		want   time.Duration
+	}{
+		{"empty", nil, 50, 0},
+		{"single", []time.Duration{7 * time.Millisecond}, 95, 7 * time.Millisecond},
+		{"p50", sorted, 50, 50 * time.Millisecond},
+		{"p95", sorted, 95, 95 * time.Millisecond},
+		{"p100", sorted, 100, 100 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Percentile(tt.sorted, tt.p); got != tt.want {
+				t.Errorf("Percentile(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLatencies_Summary(t *testing.T) {
+	l := NewLatencies()
+	for i := 1; i <= SampleSize+10; i++ {
+		l.Record("problem.get", time.Duration(i)*time.Millisecond, i%10 == 0)
+	}
+	l.Record("host.get", 5*time.Millisecond, false)
+
+	summary := l.Summary()
+	if len(summary) != 2 {
+		t.Fatalf("Summary() len = %d, want 2", len(summary))
+	}
+	if summary[0].Method != "host.get" {
+		t.Errorf("Summary()[0].Method = %q, want sorted by name", summary[0].Method)
+	}
+
+	p := summary[1]
+	if p.Calls != SampleSize+10 || p.Errors != 11 {
+		t.Errorf("Calls/Errors = %d/%d, want %d/11", p.Calls, p.Errors, SampleSize+10)
+	}
+	// Only the last SampleSize calls (11ms..110ms) count toward percentiles
+	if p.P50 != 60*time.Millisecond {
+		t.Errorf("P50 = %v, want 60ms", p.P50)
+	}
+	if p.P95 != 105*time.Millisecond {
+		t.Errorf("P95 = %v, want 105ms", p.P95)
+	}
+	if p.Last != 110*time.Millisecond {
+		t.Errorf("Last = %v, want 110ms", p.Last)
+	}
+}
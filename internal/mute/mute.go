@@ -0,0 +1,81 @@
+// Package mute hides the problems of known-noisy triggers, matched by the
+// mute rules of the config. Muted problems still exist in Zabbix.
+package mute
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/harpchad/chotko/internal/config"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// rule is a compiled mute rule.
+type rule struct {
+	trigger  *regexp.Regexp // nil to match any problem name
+	tag      string         // Empty to match any tags
+	value    string
+	anyValue bool // The rule names a tag without a value
+}
+
+// List matches problems against mute rules. A nil List mutes nothing.
+type List struct {
+	rules []rule
+}
+
+// New compiles mute rules.
+func New(rules []config.MuteRule) (*List, error) {
+	l := &List{}
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+		var compiled rule
+		if r.Trigger != "" {
+			compiled.trigger = regexp.MustCompile(r.Trigger)
+		}
+		compiled.tag, compiled.value, compiled.anyValue = r.Tag, "", true
+		if name, value, ok := strings.Cut(r.Tag, "="); ok {
+			compiled.tag, compiled.value, compiled.anyValue = name, value, false
+		}
+		l.rules = append(l.rules, compiled)
+	}
+	return l, nil
+}
+
+// Muted reports whether a problem matches any of the rules.
+func (l *List) Muted(p *zabbix.Problem) bool {
+	if l == nil {
+		return false
+	}
+	for _, r := range l.rules {
+		if r.matches(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of rules.
+func (l *List) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.rules)
+}
+
+// matches reports whether a problem matches the rule.
+func (r rule) matches(p *zabbix.Problem) bool {
+	if r.trigger != nil && !r.trigger.MatchString(p.Name) {
+		return false
+	}
+	if r.tag == "" {
+		return true
+	}
+	for _, t := range p.Tags {
+		if t.Tag == r.tag && (r.anyValue || t.Value == r.value) {
+			return true
+		}
+	}
+	return false
+}
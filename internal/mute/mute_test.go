@@ -0,0 +1,55 @@
+package mute
+
+import (
+	"testing"
+
+	"github.com/harpchad/chotko/internal/config"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+func TestMuted(t *testing.T) {
+	l, err := New([]config.MuteRule{
+		{Trigger: "^Zabbix agent", Comment: "agents restart nightly"},
+		{Tag: "env=dev"},
+		{Trigger: "(?i)disk", Tag: "scope"},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if l.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", l.Len())
+	}
+
+	tests := []struct {
+		name    string
+		problem zabbix.Problem
+		want    bool
+	}{
+		{"trigger name", zabbix.Problem{Name: "Zabbix agent is not available"}, true},
+		{"name elsewhere", zabbix.Problem{Name: "web-01: Zabbix agent is not available"}, false},
+		{"tag value", zabbix.Problem{Name: "High CPU", Tags: []zabbix.Tag{{Tag: "env", Value: "dev"}}}, true},
+		{"other tag value", zabbix.Problem{Name: "High CPU", Tags: []zabbix.Tag{{Tag: "env", Value: "prod"}}}, false},
+		{"name and tag", zabbix.Problem{Name: "/: Disk space is low", Tags: []zabbix.Tag{{Tag: "scope", Value: "capacity"}}}, true},
+		{"name without tag", zabbix.Problem{Name: "/: Disk space is low"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := l.Muted(&tt.problem); got != tt.want {
+				t.Errorf("Muted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	if _, err := New([]config.MuteRule{{Trigger: "disk (low"}}); err == nil {
+		t.Error("New() should fail for an invalid pattern")
+	}
+}
+
+func TestMuted_NilList(t *testing.T) {
+	var l *List
+	if l.Muted(&zabbix.Problem{Name: "anything"}) || l.Len() != 0 {
+		t.Error("a nil List should mute nothing")
+	}
+}
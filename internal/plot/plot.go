@@ -0,0 +1,203 @@
+// Package plot renders time series charts as PNG images with the standard
+// library alone, for exporting the charts of the detail pane.
+package plot
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Default image size.
+const (
+	DefaultWidth  = 960
+	DefaultHeight = 480
+)
+
+// Chart colors.
+var (
+	background = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	gridColor  = color.RGBA{0xE3, 0xE6, 0xE8, 0xFF}
+	axisColor  = color.RGBA{0x76, 0x83, 0x8B, 0xFF}
+	textColor  = color.RGBA{0x1F, 0x2C, 0x33, 0xFF}
+	lineColor  = color.RGBA{0x1F, 0x77, 0xB4, 0xFF}
+)
+
+// ticks is the number of grid lines, and labels, along each axis.
+const ticks = 5
+
+// Point is a value at a time.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Chart is a line chart of a time series.
+type Chart struct {
+	Title  string
+	Points []Point // In time order
+	// YLabel formats the value axis labels (default: shortest decimal)
+	YLabel func(float64) string
+	// XLabel formats the time axis labels (default: 15:04)
+	XLabel func(time.Time) string
+	// Width and Height are the image size in pixels (default: 960x480)
+	Width, Height int
+}
+
+// PNG renders the chart as a PNG image.
+func (c Chart) PNG(w io.Writer) error {
+	if len(c.Points) == 0 {
+		return errors.New("no data points to plot")
+	}
+	return png.Encode(w, c.Image())
+}
+
+// Image renders the chart.
+func (c Chart) Image() *image.RGBA {
+	width, height := c.Width, c.Height
+	if width <= 0 || height <= 0 {
+		width, height = DefaultWidth, DefaultHeight
+	}
+	yLabel := c.YLabel
+	if yLabel == nil {
+		yLabel = func(v float64) string { return strconv.FormatFloat(v, 'g', 4, 64) }
+	}
+	xLabel := c.XLabel
+	if xLabel == nil {
+		xLabel = func(t time.Time) string { return t.Format("15:04") }
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{background}, image.Point{}, draw.Src)
+	if len(c.Points) == 0 {
+		return img
+	}
+
+	start, end := c.Points[0].Time, c.Points[len(c.Points)-1].Time
+	low, high := c.Points[0].Value, c.Points[0].Value
+	for _, p := range c.Points {
+		low, high = min(low, p.Value), max(high, p.Value)
+	}
+	if low == high {
+		// A flat line is drawn in the middle
+		low, high = low-1, high+1
+	} else {
+		pad := (high - low) * 0.05
+		low, high = low-pad, high+pad
+	}
+
+	// The plot area leaves room for the title and the axis labels
+	yLabels := make([]string, ticks+1)
+	labelWidth := 0
+	for i := range yLabels {
+		yLabels[i] = yLabel(low + (high-low)*float64(i)/ticks)
+		labelWidth = max(labelWidth, textWidth(yLabels[i]))
+	}
+	left := labelWidth + 3*charWidth/2
+	top := 2 * charHeight
+	right := width - 2*charWidth
+	bottom := height - 2*charHeight
+	if right <= left || bottom <= top {
+		return img
+	}
+
+	if c.Title != "" {
+		drawText(img, left, charHeight/2, c.Title, textColor)
+	}
+	for i, label := range yLabels {
+		y := bottom - (bottom-top)*i/ticks
+		hLine(img, left, right, y, gridColor)
+		drawText(img, left-charWidth/2-textWidth(label), y-charHeight/2, label, textColor)
+	}
+	span := end.Sub(start)
+	for i := range ticks + 1 {
+		x := left + (right-left)*i/ticks
+		vLine(img, x, top, bottom, gridColor)
+		label := xLabel(start.Add(span * time.Duration(i) / ticks))
+		lx := min(max(x-textWidth(label)/2, 0), width-textWidth(label))
+		drawText(img, lx, bottom+charHeight/2, label, textColor)
+	}
+	hLine(img, left, right, bottom, axisColor)
+	vLine(img, left, top, bottom, axisColor)
+
+	at := func(p Point) (int, int) {
+		x := left
+		if span > 0 {
+			x += int(float64(right-left) * float64(p.Time.Sub(start)) / float64(span))
+		}
+		y := bottom - int(float64(bottom-top)*(p.Value-low)/(high-low))
+		return x, y
+	}
+	x0, y0 := at(c.Points[0])
+	if len(c.Points) == 1 {
+		drawLine(img, x0-2, y0, x0+2, y0, lineColor)
+	}
+	for _, p := range c.Points[1:] {
+		x1, y1 := at(p)
+		drawLine(img, x0, y0, x1, y1, lineColor)
+		x0, y0 = x1, y1
+	}
+	return img
+}
+
+// hLine draws a horizontal line.
+func hLine(img *image.RGBA, x0, x1, y int, c color.Color) {
+	for x := x0; x <= x1; x++ {
+		img.Set(x, y, c)
+	}
+}
+
+// vLine draws a vertical line.
+func vLine(img *image.RGBA, x, y0, y1 int, c color.Color) {
+	for y := y0; y <= y1; y++ {
+		img.Set(x, y, c)
+	}
+}
+
+// drawLine draws a line two pixels thick between two points.
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	err := dx + dy
+	for {
+		img.Set(x0, y0, c)
+		img.Set(x0+1, y0, c)
+		img.Set(x0, y0+1, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// sign returns -1, 0 or 1 by the sign of n.
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,58 @@
+package plot
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestChart_PNG(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	chart := Chart{
+		Title:  "CPU load",
+		Points: []Point{{start, 1}, {start.Add(time.Minute), 3}, {start.Add(2 * time.Minute), 2}},
+		Width:  400,
+		Height: 200,
+	}
+	var buf bytes.Buffer
+	if err := chart.PNG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("PNG() wrote an invalid image: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 400 || b.Dy() != 200 {
+		t.Errorf("image is %dx%d, want 400x200", b.Dx(), b.Dy())
+	}
+
+	// The line runs from the first point, low on the left, to the last
+	plotted := 0
+	rgba := chart.Image()
+	for y := range 200 {
+		for x := range 400 {
+			if rgba.RGBAAt(x, y) == lineColor {
+				plotted++
+			}
+		}
+	}
+	if plotted < 100 {
+		t.Errorf("%d line pixels drawn, want a line across the chart", plotted)
+	}
+
+	if err := (Chart{}).PNG(&buf); err == nil {
+		t.Error("PNG() of no points should fail")
+	}
+}
+
+func TestDrawText(t *testing.T) {
+	if got := textWidth("12:00"); got != 5*charWidth {
+		t.Errorf("textWidth() = %d, want %d", got, 5*charWidth)
+	}
+	for r := range glyphs {
+		if r >= 'a' && r <= 'z' {
+			t.Errorf("glyph %q should be upper case", r)
+		}
+	}
+}
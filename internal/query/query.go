@@ -0,0 +1,417 @@
+// Package query parses the text filter of the lists. Besides plain words,
+// a filter can hold regular expressions (/disk.*full/) and field terms such
+// as host:web*, sev>=4, ack:no and tag:env=prod. Terms separated by spaces
+// or AND must all match; OR separates alternatives and binds more loosely.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// Record holds the fields of a list row that queries match against.
+type Record struct {
+	Text         []string     // Fields searched by plain words and regular expressions
+	Hosts        []string     // Host names, matched by host:
+	Severity     int          // -1 for rows without a severity
+	Acknowledged bool         // Only meaningful when HasAck is set
+	HasAck       bool         // The row can be acknowledged
+	Tags         []zabbix.Tag // Tags, matched by tag:
+}
+
+// Problem returns the record of a problem or event: its name and host are
+// searched as text.
+func Problem(p *zabbix.Problem) *Record {
+	r := &Record{
+		Text:         []string{p.Name, p.HostName()},
+		Severity:     p.SeverityInt(),
+		Acknowledged: p.IsAcknowledged(),
+		HasAck:       true,
+		Tags:         p.Tags,
+	}
+	for _, h := range p.Hosts {
+		r.Hosts = append(r.Hosts, h.DisplayName())
+		if h.Host != h.DisplayName() {
+			r.Hosts = append(r.Hosts, h.Host)
+		}
+	}
+	return r
+}
+
+// term is a condition on a record.
+type term interface {
+	match(r *Record) bool
+}
+
+// Query is a parsed filter: a record matches if all of the terms of any
+// alternative match. An empty query matches every record.
+type Query struct {
+	alternatives [][]term
+}
+
+// Parse parses a filter.
+func Parse(s string) (*Query, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{}
+	var current []term
+	for i, tok := range tokens {
+		switch {
+		case tok.op == "OR":
+			if len(current) == 0 {
+				return nil, errors.New("OR needs a term on each side")
+			}
+			q.alternatives = append(q.alternatives, current)
+			current = nil
+		case tok.op == "AND":
+			if len(current) == 0 || i == len(tokens)-1 || tokens[i+1].op != "" {
+				return nil, errors.New("AND needs a term on each side")
+			}
+		default:
+			t, err := parseTerm(tok)
+			if err != nil {
+				return nil, err
+			}
+			current = append(current, t)
+		}
+	}
+	if len(current) == 0 && len(q.alternatives) > 0 {
+		return nil, errors.New("OR needs a term on each side")
+	}
+	if len(current) > 0 {
+		q.alternatives = append(q.alternatives, current)
+	}
+	return q, nil
+}
+
+// Lenient parses a filter, matching it as plain text if it isn't a valid
+// query, e.g. a filter saved before it became one.
+func Lenient(s string) *Query {
+	q, err := Parse(s)
+	if err != nil {
+		return &Query{alternatives: [][]term{{textTerm(strings.ToLower(s))}}}
+	}
+	return q
+}
+
+// Match reports whether a record matches the query.
+func (q *Query) Match(r *Record) bool {
+	if q == nil || len(q.alternatives) == 0 {
+		return true
+	}
+	for _, terms := range q.alternatives {
+		matched := true
+		for _, t := range terms {
+			if !t.match(r) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Search returns the words and phrases of a query made only of them, each
+// of which a record must contain, which the API can search for, or nil for
+// any other query.
+func (q *Query) Search() []string {
+	if q == nil || len(q.alternatives) != 1 {
+		return nil
+	}
+	words := make([]string, 0, len(q.alternatives[0]))
+	for _, t := range q.alternatives[0] {
+		word, ok := t.(textTerm)
+		if !ok {
+			return nil
+		}
+		words = append(words, string(word))
+	}
+	return words
+}
+
+// token is a word, quoted phrase or regular expression of a filter, or
+// one of the operators AND and OR.
+type token struct {
+	text   string
+	op     string // "AND" or "OR"
+	quoted bool   // A phrase in double quotes
+	regex  bool   // A regular expression between slashes
+}
+
+// tokenize splits a filter into tokens.
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '"':
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				return nil, errors.New("missing closing \"")
+			}
+			tokens = append(tokens, token{text: s[i+1 : i+1+end], quoted: true})
+			i += end + 2
+		case c == '/' && regexEnd(s, i) > 0:
+			end := regexEnd(s, i)
+			pattern := strings.ReplaceAll(s[i+1:end], `\/`, "/")
+			tokens = append(tokens, token{text: pattern, regex: true})
+			i = end + 1
+		default:
+			end := strings.IndexAny(s[i:], " \t")
+			if end < 0 {
+				end = len(s) - i
+			}
+			word := s[i : i+end]
+			if word == "AND" || word == "OR" {
+				tokens = append(tokens, token{op: word})
+			} else {
+				tokens = append(tokens, token{text: word})
+			}
+			i += end
+		}
+	}
+	return tokens, nil
+}
+
+// regexEnd returns the index of the slash closing the regular expression
+// starting at start, or -1 if there is none. The closing slash must end a
+// word, so that paths such as /var/log are matched as plain words.
+func regexEnd(s string, start int) int {
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '/':
+			if i+1 == len(s) || s[i+1] == ' ' || s[i+1] == '\t' {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseTerm parses a token into a term.
+func parseTerm(tok token) (term, error) {
+	if tok.regex {
+		re, err := regexp.Compile("(?i)" + tok.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression /%s/: %w", tok.text, err)
+		}
+		return regexTerm{re}, nil
+	}
+	if tok.quoted {
+		return textTerm(strings.ToLower(tok.text)), nil
+	}
+
+	word := tok.text
+	lower := strings.ToLower(word)
+	for _, field := range []string{"severity", "sev"} {
+		if rest, ok := strings.CutPrefix(lower, field); ok && rest != "" && strings.ContainsRune(":=<>", rune(rest[0])) {
+			return parseSeverity(rest)
+		}
+	}
+	if field, value, ok := strings.Cut(word, ":"); ok {
+		switch strings.ToLower(field) {
+		case "host":
+			if value == "" {
+				return nil, errors.New("host: needs a host name, e.g. host:web*")
+			}
+			return hostTerm{pattern(value)}, nil
+		case "tag":
+			name, tagValue, hasValue := strings.Cut(value, "=")
+			if name == "" {
+				return nil, errors.New("tag: needs a tag name, e.g. tag:env=prod")
+			}
+			t := tagTerm{name: strings.ToLower(name)}
+			if hasValue {
+				value := pattern(tagValue)
+				t.value = &value
+			}
+			return t, nil
+		case "ack":
+			switch strings.ToLower(value) {
+			case "yes", "y", "true", "1":
+				return ackTerm(true), nil
+			case "no", "n", "false", "0":
+				return ackTerm(false), nil
+			}
+			return nil, fmt.Errorf("ack: needs yes or no, not %q", value)
+		}
+	}
+	return textTerm(lower), nil
+}
+
+// severities are the names accepted for severities, indexed by severity.
+var severities = [][]string{
+	{"nc", "not_classified", "unclassified"},
+	{"info", "information"},
+	{"warn", "warning"},
+	{"avg", "average"},
+	{"high"},
+	{"disaster"},
+}
+
+// parseSeverity parses the comparison of a severity term, e.g. ">=4".
+func parseSeverity(s string) (term, error) {
+	op := ""
+	for _, candidate := range []string{">=", "<=", ">", "<", "=", ":"} {
+		if rest, ok := strings.CutPrefix(s, candidate); ok {
+			op, s = candidate, rest
+			break
+		}
+	}
+
+	level := -1
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 5 {
+		level = n
+	}
+	for i, names := range severities {
+		for _, name := range names {
+			if s == name {
+				level = i
+			}
+		}
+	}
+	if level < 0 {
+		return nil, fmt.Errorf("unknown severity %q; use 0-5 or a name such as high", s)
+	}
+	return severityTerm{op: op, level: level}, nil
+}
+
+// pattern compiles a host or tag value: with * or ? a case-insensitive
+// wildcard pattern matching the whole value, otherwise plain text.
+func pattern(s string) matcher {
+	if !strings.ContainsAny(s, "*?") {
+		return matcher{text: strings.ToLower(s)}
+	}
+	expr := regexp.QuoteMeta(s)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	return matcher{text: strings.ToLower(s), re: regexp.MustCompile("(?i)^" + expr + "$")}
+}
+
+// matcher matches a value by wildcard pattern, or by its text without one.
+type matcher struct {
+	text string
+	re   *regexp.Regexp
+}
+
+// match reports whether the value matches, by substring without a
+// wildcard.
+func (m matcher) match(value string) bool {
+	if m.re != nil {
+		return m.re.MatchString(value)
+	}
+	return strings.Contains(strings.ToLower(value), m.text)
+}
+
+// textTerm matches records with a text field containing a word or phrase.
+type textTerm string
+
+func (t textTerm) match(r *Record) bool {
+	for _, s := range r.Text {
+		if strings.Contains(strings.ToLower(s), string(t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// regexTerm matches records with a text field matching a regular
+// expression.
+type regexTerm struct {
+	re *regexp.Regexp
+}
+
+func (t regexTerm) match(r *Record) bool {
+	for _, s := range r.Text {
+		if t.re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostTerm matches records on a matching host.
+type hostTerm struct {
+	host matcher
+}
+
+func (t hostTerm) match(r *Record) bool {
+	for _, h := range r.Hosts {
+		if t.host.match(h) {
+			return true
+		}
+	}
+	return false
+}
+
+// severityTerm matches records by comparing their severity to a level.
+type severityTerm struct {
+	op    string
+	level int
+}
+
+func (t severityTerm) match(r *Record) bool {
+	if r.Severity < 0 {
+		return false
+	}
+	switch t.op {
+	case ">=":
+		return r.Severity >= t.level
+	case "<=":
+		return r.Severity <= t.level
+	case ">":
+		return r.Severity > t.level
+	case "<":
+		return r.Severity < t.level
+	}
+	return r.Severity == t.level
+}
+
+// ackTerm matches acknowledged or unacknowledged records.
+type ackTerm bool
+
+func (t ackTerm) match(r *Record) bool {
+	return r.HasAck && r.Acknowledged == bool(t)
+}
+
+// tagTerm matches records with a tag, and a matching value if one is
+// given.
+type tagTerm struct {
+	name  string
+	value *matcher // nil to match any value
+}
+
+func (t tagTerm) match(r *Record) bool {
+	for _, tag := range r.Tags {
+		if strings.ToLower(tag.Tag) != t.name {
+			continue
+		}
+		if t.value == nil || t.value.matchExact(tag.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchExact is like match, but without a wildcard the whole value must
+// match.
+func (m matcher) matchExact(value string) bool {
+	if m.re != nil {
+		return m.re.MatchString(value)
+	}
+	return strings.EqualFold(value, m.text)
+}
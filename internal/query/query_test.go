@@ -0,0 +1,130 @@
+package query
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+func TestMatch(t *testing.T) {
+	disk := &Record{
+		Text:         []string{"/: Disk space is low", "web-01"},
+		Hosts:        []string{"web-01"},
+		Severity:     2,
+		HasAck:       true,
+		Acknowledged: true,
+		Tags:         []zabbix.Tag{{Tag: "env", Value: "prod"}, {Tag: "scope", Value: "capacity"}},
+	}
+	agent := &Record{
+		Text:     []string{"Zabbix agent is not available", "db-01"},
+		Hosts:    []string{"db-01"},
+		Severity: 4,
+		HasAck:   true,
+		Tags:     []zabbix.Tag{{Tag: "env", Value: "staging"}},
+	}
+	host := &Record{Text: []string{"web-02", "10.0.0.2"}, Hosts: []string{"web-02"}, Severity: -1}
+
+	tests := []struct {
+		filter string
+		want   []*Record
+	}{
+		{"", []*Record{disk, agent, host}},
+		{"DISK", []*Record{disk}},
+		{"web", []*Record{disk, host}},
+		{"disk web-01", []*Record{disk}},
+		{`"disk space"`, []*Record{disk}},
+		{"/: Disk", []*Record{disk}},
+		{"/disk.*low|agent/", []*Record{disk, agent}},
+		{"/^web-0[12]$/", []*Record{disk, host}},
+		{"host:web*", []*Record{disk, host}},
+		{"host:web", []*Record{disk, host}},
+		{"host:WEB-01", []*Record{disk}},
+		{"sev>=4", []*Record{agent}},
+		{"sev<3", []*Record{disk}},
+		{"severity:warning", []*Record{disk}},
+		{"sev=high", []*Record{agent}},
+		{"ack:no", []*Record{agent}},
+		{"ack:yes", []*Record{disk}},
+		{"tag:env=prod", []*Record{disk}},
+		{"tag:env=stag*", []*Record{agent}},
+		{"tag:scope", []*Record{disk}},
+		{"tag:env=pro", nil},
+		{"sev>=4 OR tag:env=prod", []*Record{disk, agent}},
+		{"ack:no AND host:db*", []*Record{agent}},
+		{"ack:no AND host:web* OR 10.0.0", []*Record{host}},
+		{"10.0.0.2:10050", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			q, err := Parse(tt.filter)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			var got []*Record
+			for _, r := range []*Record{disk, agent, host} {
+				if q.Match(r) {
+					got = append(got, r)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("matched %d records, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("matched %v, want %v", got[i].Text, tt.want[i].Text)
+				}
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, filter := range []string{
+		"/disk(/",
+		`"disk space`,
+		"sev>=9",
+		"sev:urgent",
+		"ack:maybe",
+		"host:",
+		"tag:=prod",
+		"OR disk",
+		"disk OR",
+		"disk AND",
+		"disk AND OR web",
+	} {
+		if _, err := Parse(filter); err == nil {
+			t.Errorf("Parse(%q) should fail", filter)
+		}
+	}
+}
+
+func TestLenient(t *testing.T) {
+	q := Lenient("sev>=9")
+	if !q.Match(&Record{Text: []string{"SEV>=9 reached"}}) {
+		t.Error("Lenient() should match an invalid query as plain text")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"Disk", []string{"disk"}},
+		{`"disk space"`, []string{"disk space"}},
+		{"disk space", []string{"disk", "space"}},
+		{"disk OR space", nil},
+		{"host:web", nil},
+		{"/disk/", nil},
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.filter)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.filter, err)
+		}
+		if got := q.Search(); !slices.Equal(got, tt.want) {
+			t.Errorf("Search(%q) = %q, want %q", tt.filter, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package recording
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// URL is the server URL to use when replaying. Requests never leave the
+// process, so it does not need to resolve.
+const URL = "http://replay.invalid"
+
+// maxLineSize is the largest exchange a recording may contain.
+const maxLineSize = 64 << 20
+
+// Player serves recorded responses. It is safe for concurrent use.
+//
+// A call is answered with the next recorded response to the same method and
+// parameters. Calls whose parameters differ from the recording, such as
+// time ranges computed from the current time, get the next response to the
+// same method instead. Once the responses are used up, the last one is
+// repeated, so periodic refreshes keep working.
+type Player struct {
+	mu        sync.Mutex
+	header    Header
+	exchanges []Exchange
+	byCall    map[string][]int // Exchange indexes by method and params
+	byMethod  map[string][]int // Exchange indexes by method
+	served    map[string]int   // Responses served per byCall or byMethod key
+}
+
+// Load reads a recording file.
+func Load(path string) (*Player, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	p, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Read reads a recording.
+func Read(r io.Reader) (*Player, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+
+	p := &Player{
+		byCall:   make(map[string][]int),
+		byMethod: make(map[string][]int),
+		served:   make(map[string]int),
+	}
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		if p.header.Format == "" {
+			if err := json.Unmarshal(scanner.Bytes(), &p.header); err != nil || p.header.Format != Format {
+				return nil, fmt.Errorf("not a chotko recording")
+			}
+			if p.header.Version > FormatVersion {
+				return nil, fmt.Errorf("recording format version %d is newer than supported (%d)", p.header.Version, FormatVersion)
+			}
+			continue
+		}
+
+		var ex Exchange
+		if err := json.Unmarshal(scanner.Bytes(), &ex); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		i := len(p.exchanges)
+		p.exchanges = append(p.exchanges, ex)
+		p.byCall[callKey(ex.Method, ex.Params)] = append(p.byCall[callKey(ex.Method, ex.Params)], i)
+		p.byMethod[ex.Method] = append(p.byMethod[ex.Method], i)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if p.header.Format == "" {
+		return nil, fmt.Errorf("recording is empty")
+	}
+	return p, nil
+}
+
+// callKey identifies a call by method and parameters, ignoring formatting.
+func callKey(method string, params json.RawMessage) string {
+	var buf bytes.Buffer
+	if json.Compact(&buf, params) != nil {
+		buf.Reset()
+		buf.Write(params)
+	}
+	return method + "\x00" + buf.String()
+}
+
+// next returns the next exchange from a queue, or the last one once the
+// queue is used up.
+func (p *Player) next(key string, queue []int) Exchange {
+	n := p.served[key]
+	p.served[key] = n + 1
+	return p.exchanges[queue[min(n, len(queue)-1)]]
+}
+
+// find returns the response to a call.
+func (p *Player) find(method string, params json.RawMessage) (Exchange, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := callKey(method, params)
+	if queue := p.byCall[key]; len(queue) > 0 {
+		return p.next(key, queue), true
+	}
+	if queue := p.byMethod[method]; len(queue) > 0 {
+		return p.next(method, queue), true
+	}
+	return Exchange{}, false
+}
+
+// RoundTrip implements http.RoundTripper by answering a JSON-RPC request
+// from the recording.
+func (p *Player) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request: %w", err)
+	}
+
+	var call rpcRequest
+	resp := zabbix.Response{JSONRPC: "2.0"}
+	status := http.StatusOK
+	if err := json.Unmarshal(body, &call); err != nil {
+		resp.Error = &zabbix.APIError{Code: -32700, Message: "Parse error.", Data: err.Error()}
+	} else if ex, ok := p.find(call.Method, call.Params); !ok {
+		resp.ID = call.ID
+		resp.Error = &zabbix.APIError{
+			Code:    -32602,
+			Message: "Invalid params.",
+			Data:    fmt.Sprintf("Method %q is not in the recording.", call.Method),
+		}
+	} else {
+		resp.ID = call.ID
+		resp.Result = ex.Result
+		resp.Error = ex.Error
+		if ex.Status != 0 {
+			status = ex.Status
+		}
+	}
+
+	out, err := json.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode response: %w", err)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(out)),
+		ContentLength: int64(len(out)),
+		Request:       req,
+	}, nil
+}
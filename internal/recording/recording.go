@@ -0,0 +1,235 @@
+// Package recording captures Zabbix API traffic to a file and serves it back,
+// so that a session can be attached to a bug report and replayed without
+// access to the server.
+//
+// A recording is a JSON Lines file: a header line followed by one Exchange
+// per API call. Passwords, API tokens, session tokens and SNMP secrets are
+// redacted.
+package recording
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// Format identifies recording files in their header.
+const Format = "chotko-recording"
+
+// FormatVersion is the version of the recording file format.
+const FormatVersion = 1
+
+// redacted replaces secrets in recordings.
+const redacted = "REDACTED"
+
+// Header is the first line of a recording.
+type Header struct {
+	Format   string    `json:"format"`
+	Version  int       `json:"version"`
+	Recorded time.Time `json:"recorded"`
+}
+
+// Exchange is one recorded API call and its response.
+type Exchange struct {
+	Time   time.Time        `json:"time"`
+	Method string           `json:"method"`
+	Params json.RawMessage  `json:"params,omitempty"`
+	Status int              `json:"status,omitempty"` // HTTP status, when not 200
+	Result json.RawMessage  `json:"result,omitempty"`
+	Error  *zabbix.APIError `json:"error,omitempty"`
+}
+
+// rpcRequest is the part of a JSON-RPC request that is recorded. The auth
+// field is deliberately left out.
+type rpcRequest struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	ID     int64           `json:"id"`
+}
+
+// Recorder writes the API calls made through its transports to a file.
+type Recorder struct {
+	mu      sync.Mutex
+	enc     *json.Encoder
+	err     error
+	started bool
+}
+
+// NewRecorder creates a recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// Err returns the first error that occurred writing the recording.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Wrap returns a transport that records the calls passed to next.
+// It can be used with zabbix.WrapTransport.
+func (r *Recorder) Wrap(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		// The request must not be modified, so a copy is sent with the body
+		// that was read
+		var body []byte
+		if req.Body != nil {
+			var err error
+			body, err = io.ReadAll(req.Body)
+			_ = req.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read request: %w", err)
+			}
+		}
+		sent := req.Clone(req.Context())
+		sent.Body = io.NopCloser(bytes.NewReader(body))
+		resp, err := next.RoundTrip(sent)
+		if err != nil {
+			return nil, err
+		}
+		respBody, err := readBody(&resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		r.record(decodeRequest(body, req.Header.Get("Content-Encoding")), resp.StatusCode, respBody)
+		return resp, nil
+	})
+}
+
+// decodeRequest returns a request body sent with the given content encoding
+// as JSON, or the body as it is if it can't be decoded.
+func decodeRequest(body []byte, encoding string) []byte {
+	if encoding != "gzip" {
+		return body
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return body
+	}
+	decoded, err := io.ReadAll(zr)
+	if err != nil {
+		return body
+	}
+	return decoded
+}
+
+// record writes one exchange. Requests that are not JSON-RPC are skipped.
+func (r *Recorder) record(body []byte, status int, respBody []byte) {
+	var req rpcRequest
+	if json.Unmarshal(body, &req) != nil {
+		return
+	}
+
+	ex := Exchange{Time: time.Now(), Method: req.Method, Params: req.Params}
+	if status != http.StatusOK {
+		ex.Status = status
+	} else {
+		var resp zabbix.Response
+		if err := json.Unmarshal(respBody, &resp); err != nil {
+			ex.Error = &zabbix.APIError{Code: -32700, Message: "Invalid response.", Data: err.Error()}
+		} else {
+			ex.Result = resp.Result
+			ex.Error = resp.Error
+		}
+	}
+	redact(&ex)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	if !r.started {
+		r.started = true
+		r.err = r.enc.Encode(Header{Format: Format, Version: FormatVersion, Recorded: ex.Time})
+	}
+	if r.err == nil {
+		r.err = r.enc.Encode(ex)
+	}
+}
+
+// secretFields are the parameters and result fields holding passwords, API
+// tokens, session IDs and the SNMP community and passphrases of interface
+// details.
+var secretFields = []string{"password", "token", "sessionid", "community", "authpassphrase", "privpassphrase"}
+
+// redact removes the secrets of an exchange: those passed as parameters or
+// returned in results, and the session token of a login.
+func redact(ex *Exchange) {
+	ex.Params = redactFields(ex.Params)
+	if len(ex.Result) == 0 {
+		return
+	}
+	if ex.Method == "user.login" {
+		ex.Result, _ = json.Marshal(redacted)
+		return
+	}
+	ex.Result = redactFields(ex.Result)
+}
+
+// redactFields replaces the secret fields of the objects in a JSON value, at
+// any depth. Values without secrets are returned as they are, so that calls
+// without secrets are replayed by their exact parameters.
+func redactFields(raw json.RawMessage) json.RawMessage {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var value any
+	if dec.Decode(&value) != nil || !redactValue(value) {
+		return raw
+	}
+	redactedRaw, err := json.Marshal(value)
+	if err != nil {
+		return raw
+	}
+	return redactedRaw
+}
+
+// redactValue replaces the secret fields of the objects in a decoded JSON
+// value and of those nested in them, and reports whether it found any.
+func redactValue(value any) bool {
+	found := false
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if slices.Contains(secretFields, key) {
+				value[key] = redacted
+				found = true
+				continue
+			}
+			found = redactValue(field) || found
+		}
+	case []any:
+		for _, elem := range value {
+			found = redactValue(elem) || found
+		}
+	}
+	return found
+}
+
+// readBody reads a response body and replaces it with a copy, so that it
+// can be read again.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
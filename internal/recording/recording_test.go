@@ -0,0 +1,289 @@
+package recording
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harpchad/chotko/internal/demo"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// record runs fn against a demo server and returns the recording.
+func record(t *testing.T, fn func(*zabbix.Client)) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+	client := zabbix.NewClient(demo.URL,
+		zabbix.WithTransport(demo.New(1)),
+		zabbix.WrapTransport(rec.Wrap))
+	fn(client)
+	if err := rec.Err(); err != nil {
+		t.Fatalf("recording error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+// replay returns a client serving the recording.
+func replay(t *testing.T, data []byte) *zabbix.Client {
+	t.Helper()
+	player, err := Read(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	return zabbix.NewClient(URL, zabbix.WithTransport(player))
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	var problems []zabbix.Problem
+	var hosts []zabbix.Host
+	data := record(t, func(c *zabbix.Client) {
+		var err error
+		if _, err = c.Negotiate(ctx); err != nil {
+			t.Fatalf("Negotiate() error = %v", err)
+		}
+		if problems, err = c.GetActiveProblems(ctx); err != nil {
+			t.Fatalf("GetActiveProblems() error = %v", err)
+		}
+		if hosts, err = c.GetAllHosts(ctx); err != nil {
+			t.Fatalf("GetAllHosts() error = %v", err)
+		}
+	})
+
+	client := replay(t, data)
+	caps, err := client.Negotiate(ctx)
+	if err != nil {
+		t.Fatalf("Negotiate() error = %v", err)
+	}
+	if caps.Version != demo.Version {
+		t.Errorf("Version = %q, want %q", caps.Version, demo.Version)
+	}
+
+	// Replaying twice gives the same data; the last response repeats
+	for range 2 {
+		got, err := client.GetActiveProblems(ctx)
+		if err != nil {
+			t.Fatalf("GetActiveProblems() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, problems) {
+			t.Error("replayed problems differ from the recording")
+		}
+	}
+	gotHosts, err := client.GetAllHosts(ctx)
+	if err != nil {
+		t.Fatalf("GetAllHosts() error = %v", err)
+	}
+	// SNMP communities are redacted in the recording
+	for _, host := range hosts {
+		for _, iface := range host.Interfaces {
+			if iface.Details != nil && iface.Details.Community != "" {
+				iface.Details.Community = redacted
+			}
+		}
+	}
+	if !reflect.DeepEqual(gotHosts, hosts) {
+		t.Error("replayed hosts differ from the recording")
+	}
+
+	_, err = client.GetHostMacros(ctx, "10101")
+	var apiErr *zabbix.APIError
+	if !errors.As(err, &apiErr) || !strings.Contains(apiErr.Data, "not in the recording") {
+		t.Errorf("GetHostMacros() error = %v, want not in the recording", err)
+	}
+}
+
+func TestReplayMatchesParams(t *testing.T) {
+	ctx := context.Background()
+	data := record(t, func(c *zabbix.Client) {
+		for _, id := range []string{"10101", "10102"} {
+			if _, err := c.GetHostMacros(ctx, id); err != nil {
+				t.Fatalf("GetHostMacros() error = %v", err)
+			}
+		}
+	})
+
+	client := replay(t, data)
+	for _, id := range []string{"10102", "10101"} {
+		macros, err := client.GetHostMacros(ctx, id)
+		if err != nil {
+			t.Fatalf("GetHostMacros() error = %v", err)
+		}
+		if len(macros) == 0 || macros[0].HostID != id {
+			t.Errorf("GetHostMacros(%s) = %+v, want the macros of that host", id, macros)
+		}
+	}
+}
+
+func TestRecordingRedactsLogin(t *testing.T) {
+	data := record(t, func(c *zabbix.Client) {
+		if err := c.Login(context.Background(), "Admin", "hunter2"); err != nil {
+			t.Fatalf("Login() error = %v", err)
+		}
+		if _, err := c.GetAllHosts(context.Background()); err != nil {
+			t.Fatalf("GetAllHosts() error = %v", err)
+		}
+	})
+
+	if bytes.Contains(data, []byte("hunter2")) {
+		t.Error("recording contains the password")
+	}
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+	var login Exchange
+	if err := json.Unmarshal(lines[1], &login); err != nil {
+		t.Fatalf("failed to decode exchange: %v", err)
+	}
+	if login.Method != "user.login" || string(login.Result) != `"REDACTED"` {
+		t.Errorf("login exchange = %s %s, want a redacted session token", login.Method, login.Result)
+	}
+}
+
+// fakeServer returns a transport answering calls with canned results by
+// method.
+func fakeServer(results map[string]string) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		var call struct {
+			Method string `json:"method"`
+			ID     int64  `json:"id"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&call); err != nil {
+			return nil, err
+		}
+		body := fmt.Sprintf(`{"jsonrpc":"2.0","result":%s,"id":%d}`, results[call.Method], call.ID)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+}
+
+func TestRecordingRedactsTokens(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+	client := zabbix.NewClient("http://zabbix.example.com/api_jsonrpc.php",
+		zabbix.WithTransport(fakeServer(map[string]string{
+			"token.create":             `{"tokenids":["7"]}`,
+			"token.generate":           `[{"tokenid":"7","token":"new-secret-token"}]`,
+			"token.get":                `[{"tokenid":"6","name":"chotko"}]`,
+			"user.checkAuthentication": `{"userid":"1"}`,
+		})),
+		zabbix.WrapTransport(rec.Wrap))
+	client.SetToken("current-secret-token")
+
+	token, err := client.CreateTokenExpiring(ctx, "1", "chotko", time.Time{})
+	if err != nil || token != "new-secret-token" {
+		t.Fatalf("CreateTokenExpiring() = %q, %v", token, err)
+	}
+	if _, err := client.TokenUserID(ctx); err != nil {
+		t.Fatalf("TokenUserID() error = %v", err)
+	}
+	if _, err := client.CurrentToken(ctx); err != nil {
+		t.Fatalf("CurrentToken() error = %v", err)
+	}
+	if err := rec.Err(); err != nil {
+		t.Fatalf("recording error = %v", err)
+	}
+
+	data := buf.String()
+	for _, secret := range []string{"new-secret-token", "current-secret-token"} {
+		if strings.Contains(data, secret) {
+			t.Errorf("recording contains %q:\n%s", secret, data)
+		}
+	}
+	if n := strings.Count(data, redacted); n != 3 {
+		t.Errorf("recording has %d redacted values, want 3:\n%s", n, data)
+	}
+}
+
+func TestRecordingRedactsSessions(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+	client := zabbix.NewClient("http://zabbix.example.com/api_jsonrpc.php",
+		zabbix.WithTransport(fakeServer(map[string]string{
+			"user.checkAuthentication": `{"userid":"1","username":"Admin","sessionid":"secret-session"}`,
+		})),
+		zabbix.WrapTransport(rec.Wrap))
+	client.SetToken("secret-session")
+
+	if _, username, err := client.SessionUser(context.Background()); err != nil || username != "Admin" {
+		t.Fatalf("SessionUser() = %q, %v", username, err)
+	}
+	if err := rec.Err(); err != nil {
+		t.Fatalf("recording error = %v", err)
+	}
+	if data := buf.String(); strings.Contains(data, "secret-session") || strings.Count(data, redacted) != 2 {
+		t.Errorf("recording should have the session ID redacted in the params and result:\n%s", data)
+	}
+}
+
+func TestRecordingRedactsInterfaces(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+	client := zabbix.NewClient("http://zabbix.example.com/api_jsonrpc.php",
+		zabbix.WithTransport(fakeServer(map[string]string{
+			"hostinterface.get": `[
+				{"interfaceid":"1","type":"2","ip":"10.0.0.1","port":"161","details":{"version":"2","bulk":"1","community":"secret-community"}},
+				{"interfaceid":"2","type":"2","ip":"10.0.0.2","port":"161","details":{"version":"3","bulk":"1","securityname":"monitor","authpassphrase":"secret-auth","privpassphrase":"secret-priv"}}
+			]`,
+			"hostinterface.update": `{"interfaceids":["1"]}`,
+		})),
+		zabbix.WrapTransport(rec.Wrap))
+	client.SetToken("secret-token")
+
+	ifaces, err := client.GetHostInterfaces(ctx, "10084")
+	if err != nil || len(ifaces) != 2 {
+		t.Fatalf("GetHostInterfaces() = %v, %v", ifaces, err)
+	}
+	if err := client.UpdateInterface(ctx, ifaces[0]); err != nil {
+		t.Fatalf("UpdateInterface() error = %v", err)
+	}
+	if err := rec.Err(); err != nil {
+		t.Fatalf("recording error = %v", err)
+	}
+
+	data := buf.String()
+	for _, secret := range []string{"secret-community", "secret-auth", "secret-priv"} {
+		if strings.Contains(data, secret) {
+			t.Errorf("recording contains %q:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(data, "monitor") || !strings.Contains(data, "10.0.0.2") {
+		t.Errorf("recording should keep the other interface details:\n%s", data)
+	}
+}
+
+func TestDecodeRequest(t *testing.T) {
+	body := []byte(`{"method":"host.get"}`)
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write(body)
+	_ = zw.Close()
+
+	if got := decodeRequest(buf.Bytes(), "gzip"); !bytes.Equal(got, body) {
+		t.Errorf("decodeRequest(gzip) = %q, want %q", got, body)
+	}
+	if got := decodeRequest(body, ""); !bytes.Equal(got, body) {
+		t.Errorf("decodeRequest() = %q, want the body unchanged", got)
+	}
+}
+
+func TestReadRejectsOtherFiles(t *testing.T) {
+	for _, input := range []string{"", "{}\n", "not json\n", `{"format":"chotko-recording","version":99}` + "\n"} {
+		if _, err := Read(strings.NewReader(input)); err == nil {
+			t.Errorf("Read(%q) succeeded, want an error", input)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// Flap is a trigger that went into problem repeatedly.
+type Flap struct {
+	TriggerID string
+	Host      string
+	Name      string // Of its latest problem
+	Severity  int    // Of its latest problem
+	Count     int    // Problems started
+	Last      time.Time
+	// MeanDuration is how long its problems lasted on average, of those
+	// whose recovery is among the events; 0 if none
+	MeanDuration time.Duration
+}
+
+// Flapping returns the triggers with more than threshold problems among
+// events, in any order, the most problems first. Triggers with many short
+// problems usually have a threshold that needs hysteresis or tuning.
+func Flapping(events []zabbix.Event, threshold int) []Flap {
+	recoveries := make(map[string]time.Time)
+	for i := range events {
+		if events[i].Value == zabbix.EventValueOK {
+			recoveries[events[i].EventID] = events[i].StartTime()
+		}
+	}
+
+	type stats struct {
+		flap     Flap
+		total    time.Duration
+		resolved int
+	}
+	byTrigger := make(map[string]*stats)
+	for i := range events {
+		e := &events[i]
+		if e.Value == zabbix.EventValueOK || e.ObjectID == "" {
+			continue
+		}
+		s, ok := byTrigger[e.ObjectID]
+		if !ok {
+			s = &stats{flap: Flap{TriggerID: e.ObjectID}}
+			byTrigger[e.ObjectID] = s
+		}
+		s.flap.Count++
+		if start := e.StartTime(); !start.Before(s.flap.Last) {
+			s.flap.Last = start
+			s.flap.Host = e.HostName()
+			s.flap.Name = e.Name
+			s.flap.Severity = e.SeverityInt()
+		}
+		if recovery, ok := recoveries[e.REventID]; ok {
+			s.total += recovery.Sub(e.StartTime())
+			s.resolved++
+		}
+	}
+
+	var flaps []Flap
+	for _, s := range byTrigger {
+		if s.flap.Count <= threshold {
+			continue
+		}
+		if s.resolved > 0 {
+			s.flap.MeanDuration = s.total / time.Duration(s.resolved)
+		}
+		flaps = append(flaps, s.flap)
+	}
+	slices.SortFunc(flaps, func(a, b Flap) int {
+		if a.Count != b.Count {
+			return cmp.Compare(b.Count, a.Count)
+		}
+		if c := b.Last.Compare(a.Last); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.TriggerID, b.TriggerID)
+	})
+	return flaps
+}
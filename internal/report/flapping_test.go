@@ -0,0 +1,45 @@
+package report
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// flapEvents returns the events of a trigger going into problem n times for
+// a minute each, every ten minutes from minute first.
+func flapEvents(triggerID, name string, n, first int) []zabbix.Event {
+	hosts := []zabbix.Host{{Name: "web01"}}
+	var events []zabbix.Event
+	for i := range n {
+		id := triggerID + "-" + strconv.Itoa(i)
+		events = append(events,
+			zabbix.Event{EventID: id, ObjectID: triggerID, Value: "1", Name: name, Severity: "2", Clock: clock(first + 10*i), REventID: id + "r", Hosts: hosts},
+			zabbix.Event{EventID: id + "r", ObjectID: triggerID, Value: "0", Name: name, Clock: clock(first + 10*i + 1), Hosts: hosts},
+		)
+	}
+	return events
+}
+
+func TestFlapping(t *testing.T) {
+	var events []zabbix.Event
+	events = append(events, flapEvents("1", "Ping loss", 4, 0)...)
+	events = append(events, flapEvents("2", "CPU load", 6, 5)...)
+	events = append(events, flapEvents("3", "Disk full", 2, 0)...)
+	// A problem still open counts without a duration
+	events = append(events, zabbix.Event{EventID: "9", ObjectID: "1", Value: "1", Name: "Ping loss", Severity: "3", Clock: clock(100)})
+
+	flaps := Flapping(events, 3)
+	if len(flaps) != 2 {
+		t.Fatalf("Flapping() = %+v, want the two triggers above 3 problems", flaps)
+	}
+	cpu, ping := flaps[0], flaps[1]
+	if cpu.TriggerID != "2" || cpu.Count != 6 || cpu.MeanDuration != time.Minute {
+		t.Errorf("first = %+v, want CPU load with 6 problems of 1m", cpu)
+	}
+	if ping.Count != 5 || ping.Severity != 3 || !ping.Last.Equal(start.Add(100*time.Minute)) {
+		t.Errorf("second = %+v, want Ping loss with 5 problems, the latest Average", ping)
+	}
+}
@@ -0,0 +1,141 @@
+package report
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// OpenProblem is a problem still open at the handover.
+type OpenProblem struct {
+	Start       time.Time
+	Severity    int
+	Host        string
+	Name        string
+	AckedBy     []string // Authors of its acknowledgements, first first
+	Assignee    string   // Username; empty for none
+	LastMessage string   // Its latest message, with the author
+}
+
+// Handover is the summary of a shift for the next one: the problems open
+// at or above a severity and who acknowledged them, and what happened to
+// such problems in the last hours.
+type Handover struct {
+	From, Till  time.Time // The window of the events
+	MinSeverity int
+	Truncated   bool // Only the latest events of the window are included
+	Open        []OpenProblem
+	Entries     []Entry
+}
+
+// NewHandover builds the handover of the open problems and the events of the
+// window between from and till, keeping those of at least minSeverity.
+// Recoveries of problems that started before the window have no known
+// severity and are left out unless minSeverity is 0.
+func NewHandover(problems []zabbix.Problem, events []zabbix.Event, minSeverity int, from, till time.Time) *Handover {
+	h := &Handover{From: from, Till: till, MinSeverity: minSeverity}
+	for i := range problems {
+		p := &problems[i]
+		if p.SeverityInt() < minSeverity {
+			continue
+		}
+		open := OpenProblem{
+			Start:    p.StartTime(),
+			Severity: p.SeverityInt(),
+			Host:     p.HostName(),
+			Name:     p.Name,
+			Assignee: p.Assignee(),
+		}
+		var lastClock int64
+		for _, a := range p.Acknowledges {
+			action, _ := strconv.Atoi(a.Action)
+			if action&zabbix.ActionAcknowledge != 0 {
+				open.AckedBy = append(open.AckedBy, authorOf(a))
+			}
+			clock, _ := strconv.ParseInt(a.Clock, 10, 64)
+			if a.Message != "" && clock >= lastClock {
+				lastClock = clock
+				open.LastMessage = authorOf(a) + ": " + a.Message
+			}
+		}
+		slices.Sort(open.AckedBy)
+		open.AckedBy = slices.Compact(open.AckedBy)
+		h.Open = append(h.Open, open)
+	}
+	// Worst first, then oldest first
+	slices.SortStableFunc(h.Open, func(a, b OpenProblem) int {
+		if a.Severity != b.Severity {
+			return cmp.Compare(b.Severity, a.Severity)
+		}
+		return a.Start.Compare(b.Start)
+	})
+
+	for _, e := range New(events, from, till).Entries {
+		if e.Severity >= minSeverity {
+			h.Entries = append(h.Entries, e)
+		}
+	}
+	return h
+}
+
+// Unacknowledged returns the number of open problems nobody acknowledged.
+func (h *Handover) Unacknowledged() int {
+	n := 0
+	for _, p := range h.Open {
+		if len(p.AckedBy) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Markdown returns the handover as a Markdown document, with times
+// formatted by tf.
+func (h *Handover) Markdown(tf format.TimeFormat) string {
+	// Notes outlive "3m ago"
+	tf.Relative = false
+	severities := theme.SeverityName(h.MinSeverity) + " and above"
+	if h.MinSeverity == 0 {
+		severities = "all severities"
+	}
+
+	var b strings.Builder
+	b.WriteString("# Shift handover\n\n")
+	fmt.Fprintf(&b, "- Time: %s\n", tf.Full(h.Till))
+	fmt.Fprintf(&b, "- Severities: %s\n", severities)
+	fmt.Fprintf(&b, "- Open problems: %d (%d unacknowledged)\n", len(h.Open), h.Unacknowledged())
+	if h.Truncated {
+		b.WriteString("- Only the latest events of the window are included\n")
+	}
+
+	b.WriteString("\n## Open problems\n\n")
+	if len(h.Open) == 0 {
+		b.WriteString("No open problems.\n")
+	} else {
+		b.WriteString("| Since | Severity | Host | Problem | Acknowledged by | Assignee | Last message |\n")
+		b.WriteString("|---|---|---|---|---|---|---|\n")
+		for _, p := range h.Open {
+			markdownRow(&b, tf.Full(p.Start), theme.SeverityName(p.Severity), p.Host, p.Name,
+				strings.Join(p.AckedBy, ", "), p.Assignee, p.LastMessage)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## Last %s\n\n", format.Span(h.Till.Sub(h.From)))
+	if len(h.Entries) == 0 {
+		b.WriteString("Nothing happened.\n")
+	} else {
+		b.WriteString("| Time | Event | Host | Problem | Message |\n")
+		b.WriteString("|---|---|---|---|---|\n")
+		for _, e := range h.Entries {
+			markdownRow(&b, tf.Full(e.Time), e.summary(), e.Host, e.Problem, e.Message)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+func testProblems() []zabbix.Problem {
+	hosts := []zabbix.Host{{Name: "db01"}}
+	return []zabbix.Problem{
+		{EventID: "10", Name: "Replication lag", Severity: "2", Clock: clock(50), Hosts: hosts},
+		{EventID: "11", Name: "Agent restarted", Severity: "1", Clock: clock(55), Hosts: hosts},
+		{
+			EventID: "12", Name: "Disk full", Severity: "4", Clock: clock(20), Hosts: hosts,
+			Acknowledges: []zabbix.Ack{
+				{Clock: clock(25), Action: "6", Message: "Cleaning up logs", Username: "jdoe", Name: "Jane", Surname: "Doe"},
+				{Clock: clock(35), Action: "4", Message: "Still growing", Username: "admin"},
+			},
+		},
+	}
+}
+
+func TestNewHandover(t *testing.T) {
+	h := NewHandover(testProblems(), testEvents(), 2, start, start.Add(time.Hour))
+
+	if len(h.Open) != 2 || h.Unacknowledged() != 1 {
+		t.Fatalf("open = %+v, want 2 with 1 unacknowledged", h.Open)
+	}
+	disk := h.Open[0]
+	if disk.Name != "Disk full" || strings.Join(disk.AckedBy, ",") != "Jane Doe (jdoe)" || disk.Assignee != "jdoe" {
+		t.Errorf("first open problem = %+v, want Disk full acknowledged by jdoe", disk)
+	}
+	if disk.LastMessage != "admin: Still growing" {
+		t.Errorf("LastMessage = %q, want the latest message", disk.LastMessage)
+	}
+
+	// The recovery of a problem from before the window has no severity
+	if len(h.Entries) != 5 || h.Entries[0].Kind != KindProblem {
+		t.Errorf("entries = %+v, want the 5 of a known severity", h.Entries)
+	}
+}
+
+func TestHandoverMarkdown(t *testing.T) {
+	h := NewHandover(testProblems(), testEvents(), 3, start, start.Add(8*time.Hour))
+	md := h.Markdown(format.TimeFormat{Relative: true, Location: time.UTC})
+
+	for _, want := range []string{
+		"- Time: 2026-03-01 17:00:00\n",
+		"- Severities: Average and above\n",
+		"- Open problems: 1 (0 unacknowledged)\n",
+		"| 2026-03-01 09:20:00 | High | db01 | Disk full | Jane Doe (jdoe) | jdoe | admin: Still growing |\n",
+		"## Last 8h\n",
+		"| 2026-03-01 09:40:00 | Resolved after 30m | web01 | Disk full |  |\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("handover missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "Replication lag") {
+		t.Errorf("handover lists a problem below the severity:\n%s", md)
+	}
+}
@@ -0,0 +1,257 @@
+// Package report writes post-incident timelines of Zabbix events as
+// Markdown or HTML documents, shift handover summaries, finds the triggers
+// that flap and when the problems of hosts were open.
+package report
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/theme"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// Format is the file format of a report.
+type Format int
+
+// Report formats.
+const (
+	FormatMarkdown Format = iota
+	FormatHTML
+)
+
+// FormatOf returns the format of a report file by its extension.
+func FormatOf(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return FormatMarkdown, nil
+	case ".html", ".htm":
+		return FormatHTML, nil
+	}
+	return 0, errors.New("report files must end in .md or .html")
+}
+
+// IsReportFile reports whether path names a report file.
+func IsReportFile(path string) bool {
+	_, err := FormatOf(path)
+	return err == nil
+}
+
+// Kinds of timeline entries.
+const (
+	KindProblem  = "problem"
+	KindUpdate   = "update"
+	KindRecovery = "recovery"
+)
+
+// Entry is one line of the timeline: a problem starting, an update to it
+// such as an acknowledgement, or its recovery.
+type Entry struct {
+	Time     time.Time
+	Kind     string
+	Severity int
+	Host     string
+	Problem  string
+	Author   string        // Updates only
+	Action   string        // Updates only, e.g. "acknowledged, commented"
+	Message  string        // Updates only
+	Duration time.Duration // Recoveries only: how long the problem lasted, 0 if unknown
+}
+
+// Problem summarizes a problem that started in the window.
+type Problem struct {
+	Start    time.Time
+	Duration time.Duration // Until its recovery, or the end of the window
+	Ongoing  bool
+	Resolved bool // Recovered, even if its recovery is not among the events
+	Severity int
+	Host     string
+	Name     string
+	AckedBy  []string // Authors of its acknowledgements, first first
+}
+
+// Report is the timeline of the events of a time window.
+type Report struct {
+	From, Till time.Time
+	Group      string // Host group the events are limited to; empty for all
+	Truncated  bool   // Only the latest events of the window are included
+	Problems   []Problem
+	Entries    []Entry
+}
+
+// New builds the report of events, in any order, between from and till.
+func New(events []zabbix.Event, from, till time.Time) *Report {
+	r := &Report{From: from, Till: till}
+	recoveries := make(map[string]*zabbix.Event)
+	for i := range events {
+		if events[i].Value == zabbix.EventValueOK {
+			recoveries[events[i].EventID] = &events[i]
+		}
+	}
+	problemOf := make(map[string]*zabbix.Event) // By recovery event ID
+	for i := range events {
+		e := &events[i]
+		if e.Value == zabbix.EventValueOK {
+			continue
+		}
+		if e.REventID != "" && e.REventID != "0" {
+			problemOf[e.REventID] = e
+		}
+
+		p := Problem{
+			Start:    e.StartTime(),
+			Severity: e.SeverityInt(),
+			Host:     e.HostName(),
+			Name:     e.Name,
+			Resolved: e.REventID != "" && e.REventID != "0",
+		}
+		switch recovery, ok := recoveries[e.REventID]; {
+		case ok:
+			p.Duration = recovery.StartTime().Sub(p.Start)
+		case !p.Resolved:
+			p.Ongoing = true
+			p.Duration = till.Sub(p.Start)
+		}
+		r.Entries = append(r.Entries, Entry{Time: p.Start, Kind: KindProblem, Severity: p.Severity, Host: p.Host, Problem: p.Name})
+
+		for _, a := range e.Acknowledges {
+			action, _ := strconv.Atoi(a.Action)
+			author := authorOf(a)
+			if action&zabbix.ActionAcknowledge != 0 {
+				p.AckedBy = append(p.AckedBy, author)
+			}
+			clock, _ := strconv.ParseInt(a.Clock, 10, 64)
+			r.Entries = append(r.Entries, Entry{
+				Time:     time.Unix(clock, 0),
+				Kind:     KindUpdate,
+				Severity: p.Severity,
+				Host:     p.Host,
+				Problem:  p.Name,
+				Author:   author,
+				Action:   actionText(action, a),
+				Message:  a.Message,
+			})
+		}
+		r.Problems = append(r.Problems, p)
+	}
+
+	for _, e := range recoveries {
+		entry := Entry{Time: e.StartTime(), Kind: KindRecovery, Host: e.HostName(), Problem: e.Name}
+		if p, ok := problemOf[e.EventID]; ok {
+			entry.Severity = p.SeverityInt()
+			entry.Duration = e.StartTime().Sub(p.StartTime())
+		}
+		r.Entries = append(r.Entries, entry)
+	}
+
+	slices.SortStableFunc(r.Problems, func(a, b Problem) int { return a.Start.Compare(b.Start) })
+	order := map[string]int{KindProblem: 0, KindUpdate: 1, KindRecovery: 2}
+	slices.SortStableFunc(r.Entries, func(a, b Entry) int {
+		if c := a.Time.Compare(b.Time); c != 0 {
+			return c
+		}
+		return cmp.Compare(order[a.Kind], order[b.Kind])
+	})
+	for i := range r.Problems {
+		slices.Sort(r.Problems[i].AckedBy)
+		r.Problems[i].AckedBy = slices.Compact(r.Problems[i].AckedBy)
+	}
+	return r
+}
+
+// Ongoing returns the number of problems not recovered by the end of the
+// window.
+func (r *Report) Ongoing() int {
+	n := 0
+	for _, p := range r.Problems {
+		if p.Ongoing {
+			n++
+		}
+	}
+	return n
+}
+
+// authorOf returns the full name and username of the author of an update,
+// e.g. "Jane Doe (jdoe)".
+func authorOf(a zabbix.Ack) string {
+	name := strings.TrimSpace(a.Name + " " + a.Surname)
+	switch {
+	case a.Username == "":
+		return "system"
+	case name == "":
+		return a.Username
+	}
+	return name + " (" + a.Username + ")"
+}
+
+// actionText describes the actions of an update, e.g. "acknowledged,
+// commented".
+func actionText(action int, a zabbix.Ack) string {
+	var parts []string
+	if action&zabbix.ActionAcknowledge != 0 {
+		parts = append(parts, "acknowledged")
+	}
+	if action&zabbix.ActionUnacknowledge != 0 {
+		parts = append(parts, "unacknowledged")
+	}
+	if action&zabbix.ActionChangeSeverity != 0 {
+		old, _ := strconv.Atoi(a.OldSeverity)
+		updated, _ := strconv.Atoi(a.NewSeverity)
+		parts = append(parts, fmt.Sprintf("changed severity from %s to %s", theme.SeverityName(old), theme.SeverityName(updated)))
+	}
+	if action&zabbix.ActionSuppress != 0 {
+		parts = append(parts, "suppressed")
+	}
+	if action&zabbix.ActionUnsuppress != 0 {
+		parts = append(parts, "unsuppressed")
+	}
+	if action&zabbix.ActionClose != 0 {
+		parts = append(parts, "closed")
+	}
+	if action&zabbix.ActionAddMessage != 0 {
+		parts = append(parts, "commented")
+	}
+	if len(parts) == 0 {
+		return "updated"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// summary describes an entry in a sentence, e.g. "High problem started" or
+// "Acknowledged by Jane Doe (jdoe)".
+func (e Entry) summary() string {
+	switch e.Kind {
+	case KindProblem:
+		return theme.SeverityName(e.Severity) + " problem started"
+	case KindRecovery:
+		if e.Duration > 0 {
+			return "Resolved after " + format.Duration(e.Duration)
+		}
+		return "Resolved"
+	}
+	action := e.Action
+	if action != "" {
+		action = strings.ToUpper(action[:1]) + action[1:]
+	}
+	return action + " by " + e.Author
+}
+
+// durationText describes how long a problem lasted.
+func (p Problem) durationText() string {
+	switch {
+	case p.Ongoing:
+		return format.Duration(p.Duration) + " (ongoing)"
+	case p.Duration > 0:
+		return format.Duration(p.Duration)
+	case p.Resolved:
+		return "resolved"
+	}
+	return "-"
+}
@@ -0,0 +1,116 @@
+package report
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+var start = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func clock(minutes int) string {
+	return strconv.FormatInt(start.Add(time.Duration(minutes)*time.Minute).Unix(), 10)
+}
+
+func testEvents() []zabbix.Event {
+	hosts := []zabbix.Host{{Name: "web01"}}
+	return []zabbix.Event{
+		// Newest first, as event.get returns them
+		{EventID: "4", Value: "0", Name: "Disk full", Clock: clock(40), Hosts: hosts},
+		{EventID: "3", Value: "1", Name: "High load | CPU", Severity: "3", Clock: clock(30), Hosts: hosts},
+		{EventID: "2", Value: "0", Name: "Ping lost", Clock: clock(5), Hosts: hosts},
+		{
+			EventID: "1", Value: "1", Name: "Disk full", Severity: "4", Clock: clock(10), REventID: "4", Hosts: hosts,
+			Acknowledges: []zabbix.Ack{
+				{Clock: clock(15), Action: "6", Message: "Looking", Username: "jdoe", Name: "Jane", Surname: "Doe"},
+				{Clock: clock(20), Action: "8", OldSeverity: "4", NewSeverity: "5", Username: "admin"},
+			},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	r := New(testEvents(), start, start.Add(time.Hour))
+
+	if len(r.Problems) != 2 || r.Ongoing() != 1 {
+		t.Fatalf("problems = %+v, want 2 with 1 ongoing", r.Problems)
+	}
+	disk := r.Problems[0]
+	if disk.Name != "Disk full" || disk.Duration != 30*time.Minute || disk.Ongoing {
+		t.Errorf("first problem = %+v, want Disk full resolved after 30m", disk)
+	}
+	if len(disk.AckedBy) != 1 || disk.AckedBy[0] != "Jane Doe (jdoe)" {
+		t.Errorf("acked by %v, want Jane Doe (jdoe)", disk.AckedBy)
+	}
+	if load := r.Problems[1]; !load.Ongoing || load.Duration != 30*time.Minute {
+		t.Errorf("second problem = %+v, want ongoing for 30m at the end of the window", load)
+	}
+
+	var got []string
+	for _, e := range r.Entries {
+		got = append(got, e.summary())
+	}
+	want := []string{
+		"Resolved", // A problem that started before the window
+		"High problem started",
+		"Acknowledged, commented by Jane Doe (jdoe)",
+		"Changed severity from High to Disaster by admin",
+		"Average problem started",
+		"Resolved after 30m",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("timeline =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestWrite(t *testing.T) {
+	r := New(testEvents(), start, start.Add(time.Hour))
+	r.Group = "Linux servers"
+	tf := format.TimeFormat{Relative: true, Location: time.UTC}
+
+	var md strings.Builder
+	if err := r.Write(&md, FormatMarkdown, tf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"- Window: 2026-03-01 09:00:00 to 2026-03-01 10:00:00\n",
+		"- Scope: host group Linux servers\n",
+		"- Problems: 2 (1 ongoing)\n",
+		"| 2026-03-01 09:10:00 | 30m | High | web01 | Disk full | Jane Doe (jdoe) |\n",
+		`| 2026-03-01 09:30:00 | Average problem started | web01 | High load \| CPU |  |`,
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("Markdown report missing %q:\n%s", want, md.String())
+		}
+	}
+
+	var html strings.Builder
+	if err := r.Write(&html, FormatHTML, tf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<li>Scope: host group Linux servers</li>",
+		`<td class="sev sev-4">High</td>`,
+		"<td>Jane Doe (jdoe)</td>",
+		`<tr class="recovery"><td>2026-03-01 09:40:00</td><td>Resolved after 30m</td>`,
+	} {
+		if !strings.Contains(html.String(), want) {
+			t.Errorf("HTML report missing %q:\n%s", want, html.String())
+		}
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	for path, want := range map[string]Format{"out.md": FormatMarkdown, "out.MARKDOWN": FormatMarkdown, "/tmp/out.html": FormatHTML, "a.htm": FormatHTML} {
+		if got, err := FormatOf(path); err != nil || got != want {
+			t.Errorf("FormatOf(%q) = %v, %v; want %v", path, got, err, want)
+		}
+	}
+	if _, err := FormatOf("out.txt"); err == nil {
+		t.Error("FormatOf(out.txt) should fail")
+	}
+}
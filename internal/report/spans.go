@@ -0,0 +1,74 @@
+package report
+
+import (
+	"time"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+// Span is when a problem of a host was open.
+type Span struct {
+	Host     string
+	Severity int
+	Start    time.Time
+	End      time.Time
+}
+
+// ProblemSpans returns when problems were open between from and till,
+// clipped to that window: the problems among events end at their recovery,
+// or at till if it is not among them, and the open problems, which may have
+// started before from, at till.
+func ProblemSpans(events []zabbix.Event, problems []zabbix.Problem, from, till time.Time) []Span {
+	recoveries := make(map[string]time.Time)
+	for i := range events {
+		if events[i].Value == zabbix.EventValueOK {
+			recoveries[events[i].EventID] = events[i].StartTime()
+		}
+	}
+
+	seen := make(map[string]bool)
+	var spans []Span
+	add := func(p *zabbix.Problem, end time.Time) {
+		start := p.StartTime()
+		if seen[p.EventID] || start.IsZero() || start.After(till) || end.Before(from) {
+			return
+		}
+		seen[p.EventID] = true
+		spans = append(spans, Span{
+			Host:     p.HostName(),
+			Severity: p.SeverityInt(),
+			Start:    maxTime(start, from),
+			End:      minTime(end, till),
+		})
+	}
+	for i := range events {
+		if events[i].Value == zabbix.EventValueOK {
+			continue
+		}
+		end, ok := recoveries[events[i].REventID]
+		if !ok {
+			end = till
+		}
+		add(&events[i], end)
+	}
+	for i := range problems {
+		add(&problems[i], till)
+	}
+	return spans
+}
+
+// maxTime returns the later of two times.
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// minTime returns the earlier of two times.
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
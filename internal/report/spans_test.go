@@ -0,0 +1,41 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harpchad/chotko/internal/zabbix"
+)
+
+func TestProblemSpans(t *testing.T) {
+	web := []zabbix.Host{{Name: "web01"}}
+	db := []zabbix.Host{{Name: "db01"}}
+	events := []zabbix.Event{
+		{EventID: "1", Value: "1", Severity: "4", Clock: clock(10), REventID: "2", Hosts: web},
+		{EventID: "2", Value: "0", Clock: clock(20), Hosts: web},
+		// Its recovery is not among the events, so it is open till the end
+		{EventID: "3", Value: "1", Severity: "2", Clock: clock(50), REventID: "9", Hosts: db},
+	}
+	problems := []zabbix.Problem{
+		{EventID: "3", Severity: "2", Clock: clock(50), Hosts: db},
+		// Started before the window
+		{EventID: "4", Severity: "5", Clock: clock(-30), Hosts: db},
+	}
+
+	from, till := start, start.Add(time.Hour)
+	spans := ProblemSpans(events, problems, from, till)
+	want := []Span{
+		{Host: "web01", Severity: 4, Start: start.Add(10 * time.Minute), End: start.Add(20 * time.Minute)},
+		{Host: "db01", Severity: 2, Start: start.Add(50 * time.Minute), End: till},
+		{Host: "db01", Severity: 5, Start: from, End: till},
+	}
+	if len(spans) != len(want) {
+		t.Fatalf("ProblemSpans() = %+v, want %+v", spans, want)
+	}
+	for i := range want {
+		got := spans[i]
+		if got.Host != want[i].Host || got.Severity != want[i].Severity || !got.Start.Equal(want[i].Start) || !got.End.Equal(want[i].End) {
+			t.Errorf("span %d = %+v, want %+v", i, spans[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/harpchad/chotko/internal/format"
+	"github.com/harpchad/chotko/internal/theme"
+)
+
+// Write writes the report in a format, with times formatted by tf.
+func (r *Report) Write(w io.Writer, f Format, tf format.TimeFormat) error {
+	// A document outlives "3m ago"
+	tf.Relative = false
+	if f == FormatHTML {
+		return r.writeHTML(w, tf)
+	}
+	return r.writeMarkdown(w, tf)
+}
+
+// scope describes the hosts of the report.
+func (r *Report) scope() string {
+	if r.Group == "" {
+		return "all hosts"
+	}
+	return "host group " + r.Group
+}
+
+// problemCount describes the number of problems, e.g. "3 (1 ongoing)".
+func (r *Report) problemCount() string {
+	if n := r.Ongoing(); n > 0 {
+		return fmt.Sprintf("%d (%d ongoing)", len(r.Problems), n)
+	}
+	return fmt.Sprint(len(r.Problems))
+}
+
+// writeMarkdown writes the report as Markdown tables.
+func (r *Report) writeMarkdown(w io.Writer, tf format.TimeFormat) error {
+	var b strings.Builder
+	b.WriteString("# Incident timeline\n\n")
+	fmt.Fprintf(&b, "- Window: %s to %s\n", tf.Full(r.From), tf.Full(r.Till))
+	fmt.Fprintf(&b, "- Scope: %s\n", r.scope())
+	fmt.Fprintf(&b, "- Problems: %s\n", r.problemCount())
+	if r.Truncated {
+		b.WriteString("- Only the latest events of the window are included\n")
+	}
+
+	b.WriteString("\n## Problems\n\n")
+	if len(r.Problems) == 0 {
+		b.WriteString("No problems started in the window.\n")
+	} else {
+		b.WriteString("| Started | Duration | Severity | Host | Problem | Acknowledged by |\n")
+		b.WriteString("|---|---|---|---|---|---|\n")
+		for _, p := range r.Problems {
+			markdownRow(&b, tf.Full(p.Start), p.durationText(), theme.SeverityName(p.Severity),
+				p.Host, p.Name, strings.Join(p.AckedBy, ", "))
+		}
+	}
+
+	b.WriteString("\n## Timeline\n\n")
+	if len(r.Entries) == 0 {
+		b.WriteString("No events in the window.\n")
+	} else {
+		b.WriteString("| Time | Event | Host | Problem | Message |\n")
+		b.WriteString("|---|---|---|---|---|\n")
+		for _, e := range r.Entries {
+			markdownRow(&b, tf.Full(e.Time), e.summary(), e.Host, e.Problem, e.Message)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownRow writes a table row, escaping the cells.
+func markdownRow(b *strings.Builder, cells ...string) {
+	b.WriteString("|")
+	for _, c := range cells {
+		c = strings.ReplaceAll(c, "|", `\|`)
+		c = strings.Join(strings.Fields(c), " ")
+		b.WriteString(" " + c + " |")
+	}
+	b.WriteString("\n")
+}
+
+// htmlReport is the HTML document of a report; severities are colored as
+// in the Zabbix frontend.
+var htmlReport = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Incident timeline</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #1f2c33; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { border: 1px solid #dbe1e5; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f2f4f5; }
+.sev { white-space: nowrap; }
+.sev-0 { background: #97aab3; } .sev-1 { background: #7499ff; } .sev-2 { background: #ffc859; }
+.sev-3 { background: #ffa059; } .sev-4 { background: #e97659; } .sev-5 { background: #e45959; }
+.recovery { background: #e6f5e6; }
+</style>
+</head>
+<body>
+<h1>Incident timeline</h1>
+<ul>
+<li>Window: {{.From}} to {{.Till}}</li>
+<li>Scope: {{.Scope}}</li>
+<li>Problems: {{.Count}}</li>
+{{- if .Truncated}}
+<li>Only the latest events of the window are included</li>
+{{- end}}
+</ul>
+<h2>Problems</h2>
+{{- if .Problems}}
+<table>
+<tr><th>Started</th><th>Duration</th><th>Severity</th><th>Host</th><th>Problem</th><th>Acknowledged by</th></tr>
+{{- range .Problems}}
+<tr><td>{{.Start}}</td><td>{{.Duration}}</td><td class="sev sev-{{.Severity}}">{{.SeverityName}}</td><td>{{.Host}}</td><td>{{.Name}}</td><td>{{.AckedBy}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No problems started in the window.</p>
+{{- end}}
+<h2>Timeline</h2>
+{{- if .Entries}}
+<table>
+<tr><th>Time</th><th>Event</th><th>Host</th><th>Problem</th><th>Message</th></tr>
+{{- range .Entries}}
+<tr class="{{.Kind}}"><td>{{.Time}}</td><td{{if eq .Kind "problem"}} class="sev sev-{{.Severity}}"{{end}}>{{.Summary}}</td><td>{{.Host}}</td><td>{{.Problem}}</td><td>{{.Message}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No events in the window.</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// writeHTML writes the report as an HTML document.
+func (r *Report) writeHTML(w io.Writer, tf format.TimeFormat) error {
+	type problem struct {
+		Start, Duration, SeverityName, Host, Name, AckedBy string
+		Severity                                           int
+	}
+	type entry struct {
+		Time, Kind, Summary, Host, Problem, Message string
+		Severity                                    int
+	}
+	data := struct {
+		From, Till, Scope, Count string
+		Truncated                bool
+		Problems                 []problem
+		Entries                  []entry
+	}{
+		From:      tf.Full(r.From),
+		Till:      tf.Full(r.Till),
+		Scope:     r.scope(),
+		Count:     r.problemCount(),
+		Truncated: r.Truncated,
+	}
+	for _, p := range r.Problems {
+		data.Problems = append(data.Problems, problem{
+			Start:        tf.Full(p.Start),
+			Duration:     p.durationText(),
+			SeverityName: theme.SeverityName(p.Severity),
+			Host:         p.Host,
+			Name:         p.Name,
+			AckedBy:      strings.Join(p.AckedBy, ", "),
+			Severity:     p.Severity,
+		})
+	}
+	for _, e := range r.Entries {
+		data.Entries = append(data.Entries, entry{
+			Time:     tf.Full(e.Time),
+			Kind:     e.Kind,
+			Summary:  e.summary(),
+			Host:     e.Host,
+			Problem:  e.Problem,
+			Message:  e.Message,
+			Severity: e.Severity,
+		})
+	}
+	return htmlReport.Execute(w, data)
+}
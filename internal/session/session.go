@@ -0,0 +1,83 @@
+// Package session saves the UI state on exit and restores it on launch, so
+// that restarting chotko doesn't lose the place in each tab.
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// State is the UI state saved between runs. Tabs are keyed by their name in
+// lower case, e.g. "alerts".
+type State struct {
+	Tab           string            `yaml:"tab,omitempty"`                // Active tab
+	MinSeverity   *int              `yaml:"min_severity,omitempty"`       // Unset to use the configured one
+	RelativeTime  *bool             `yaml:"relative_time,omitempty"`      // Unset to use the configured one
+	Filters       map[string]string `yaml:"filters,omitempty"`            // Text filter per tab
+	Selected      map[string]string `yaml:"selected,omitempty"`           // Selected row ID per tab
+	Expanded      []string          `yaml:"expanded,omitempty"`           // Expanded graph tree node IDs
+	EventWindow   string            `yaml:"event_window,omitempty"`       // Events tab lookback, e.g. "6h"
+	EventType     string            `yaml:"event_type,omitempty"`         // Events tab type: problems or recoveries
+	EventSeverity int               `yaml:"event_min_severity,omitempty"` // Events tab minimum severity
+	AlertGroupBy  string            `yaml:"alert_group_by,omitempty"`     // Alerts grouping, e.g. "tag:service"
+	HostState     string            `yaml:"host_state,omitempty"`         // Hosts tab state filter, e.g. "problem"
+	HostSort      string            `yaml:"host_sort,omitempty"`          // Hosts tab order, e.g. "availability"
+	HostTree      bool              `yaml:"host_tree,omitempty"`          // Hosts tab shows hosts under their host groups
+	RecentActions []string          `yaml:"recent_actions,omitempty"`     // Command palette actions, most recent first
+	Saved         time.Time         `yaml:"saved"`
+	path          string
+}
+
+// Load loads the state from the config directory.
+// Returns an empty state if the file doesn't exist.
+func Load(configDir string) (*State, error) {
+	path := filepath.Join(configDir, "state.yaml")
+	s := &State{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return s, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, s); err != nil {
+		// Start afresh rather than block startup
+		return &State{path: path}, fmt.Errorf("failed to parse state file: %w", err)
+	}
+
+	return s, nil
+}
+
+// Save persists the state to disk.
+func (s *State) Save() error {
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	s.Saved = time.Now()
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	header := "# Chotko Session State\n# Written on exit and restored on launch. Delete this file to start afresh.\n\n"
+	content := header + string(data)
+
+	if err := os.WriteFile(s.path, []byte(content), 0o600); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	return nil
+}
+
+// Path returns the file path of the state.
+func (s *State) Path() string {
+	return s.path
+}
@@ -0,0 +1,96 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad_NewFile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	s, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if s.Tab != "" || s.MinSeverity != nil || len(s.Filters) != 0 {
+		t.Errorf("Load() = %+v, want an empty state", s)
+	}
+
+	expectedPath := filepath.Join(tmpDir, "state.yaml")
+	if s.Path() != expectedPath {
+		t.Errorf("Path() = %q, want %q", s.Path(), expectedPath)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	s, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	severity := 3
+	relative := false
+	s.Tab = "graphs"
+	s.MinSeverity = &severity
+	s.RelativeTime = &relative
+	s.Filters = map[string]string{"alerts": "disk"}
+	s.Selected = map[string]string{"alerts": "1234", "graphs": "host:10084"}
+	s.Expanded = []string{"host:10084", "cat:10084:CPU"}
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, err := os.ReadFile(s.Path())
+	if err != nil {
+		t.Fatalf("failed to read state file: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "# Chotko Session State") {
+		t.Error("state file should start with a header comment")
+	}
+
+	loaded, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Tab != "graphs" {
+		t.Errorf("Tab = %q, want %q", loaded.Tab, "graphs")
+	}
+	if loaded.MinSeverity == nil || *loaded.MinSeverity != 3 {
+		t.Errorf("MinSeverity = %v, want 3", loaded.MinSeverity)
+	}
+	if loaded.RelativeTime == nil || *loaded.RelativeTime {
+		t.Errorf("RelativeTime = %v, want false", loaded.RelativeTime)
+	}
+	if loaded.Filters["alerts"] != "disk" || loaded.Selected["graphs"] != "host:10084" {
+		t.Errorf("Filters = %v, Selected = %v", loaded.Filters, loaded.Selected)
+	}
+	if len(loaded.Expanded) != 2 || loaded.Expanded[1] != "cat:10084:CPU" {
+		t.Errorf("Expanded = %v", loaded.Expanded)
+	}
+	if loaded.Saved.IsZero() {
+		t.Error("Saved time should be set")
+	}
+}
+
+func TestLoad_InvalidFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "state.yaml")
+	if err := os.WriteFile(path, []byte("tab: [unclosed"), 0o600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	s, err := Load(tmpDir)
+	if err == nil {
+		t.Error("Load() should fail for invalid YAML")
+	}
+	if s == nil || s.Path() != path {
+		t.Fatal("Load() should return an empty state that can still be saved")
+	}
+	if s.Tab != "" {
+		t.Errorf("Tab = %q, want empty", s.Tab)
+	}
+}
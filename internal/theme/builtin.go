@@ -2,6 +2,7 @@
 package theme
 
 import (
+	"strings"
 	"sync"
 
 	"github.com/charmbracelet/lipgloss"
@@ -16,8 +17,24 @@ const (
 	ThemeCatppuccin = "catppuccin"
 	ThemeTokyoNight = "tokyonight"
 	ThemeSolarized  = "solarized"
+
+	ThemeDefaultLight    = "default-light"
+	ThemeNordLight       = "nord-light"
+	ThemeDraculaLight    = "dracula-light"
+	ThemeGruvboxLight    = "gruvbox-light"
+	ThemeCatppuccinLight = "catppuccin-light"
+	ThemeTokyoNightLight = "tokyonight-light"
+	ThemeSolarizedLight  = "solarized-light"
 )
 
+// ThemeAuto selects the default theme or its light variant based on the
+// terminal background. A "-auto" suffix does the same for any built-in
+// theme, e.g. "nord-auto".
+const ThemeAuto = "auto"
+
+// lightSuffix is appended to a dark theme name to form its light variant.
+const lightSuffix = "-light"
+
 var (
 	builtinThemes     map[string]*Theme
 	builtinThemesOnce sync.Once
@@ -35,6 +52,14 @@ func BuiltinThemes() map[string]*Theme {
 			ThemeCatppuccin: CatppuccinTheme(),
 			ThemeTokyoNight: TokyoNightTheme(),
 			ThemeSolarized:  SolarizedTheme(),
+
+			ThemeDefaultLight:    DefaultLightTheme(),
+			ThemeNordLight:       NordLightTheme(),
+			ThemeDraculaLight:    DraculaLightTheme(),
+			ThemeGruvboxLight:    GruvboxLightTheme(),
+			ThemeCatppuccinLight: CatppuccinLightTheme(),
+			ThemeTokyoNightLight: TokyoNightLightTheme(),
+			ThemeSolarizedLight:  SolarizedLightTheme(),
 		}
 	})
 	return builtinThemes
@@ -50,7 +75,40 @@ func BuiltinThemeNames() []string {
 		ThemeCatppuccin,
 		ThemeTokyoNight,
 		ThemeSolarized,
+		ThemeDefaultLight,
+		ThemeNordLight,
+		ThemeDraculaLight,
+		ThemeGruvboxLight,
+		ThemeCatppuccinLight,
+		ThemeTokyoNightLight,
+		ThemeSolarizedLight,
+	}
+}
+
+// IsAuto reports whether name selects a theme by terminal background.
+func IsAuto(name string) bool {
+	return name == ThemeAuto || strings.HasSuffix(name, "-"+ThemeAuto)
+}
+
+// Resolve maps an auto theme name to a concrete built-in theme name:
+// the dark theme when dark is true, otherwise its light variant.
+// Names that are not auto are returned unchanged.
+func Resolve(name string, dark bool) string {
+	if !IsAuto(name) {
+		return name
+	}
+
+	base := strings.TrimSuffix(name, "-"+ThemeAuto)
+	if base == ThemeAuto {
+		base = ThemeDefault
 	}
+	if dark {
+		return base
+	}
+	if light := base + lightSuffix; BuiltinThemes()[light] != nil {
+		return light
+	}
+	return base
 }
 
 // DefaultTheme returns the default Zabbix-inspired theme.
@@ -289,3 +347,240 @@ func SolarizedTheme() *Theme {
 		},
 	}
 }
+
+// DefaultLightTheme returns the light variant of the default theme.
+func DefaultLightTheme() *Theme {
+	return &Theme{
+		Name:        ThemeDefaultLight,
+		Description: "Classic Zabbix-inspired colors on a light background",
+		Colors: ColorPalette{
+			// Severity colors (darkened for contrast on white)
+			Disaster:      lipgloss.Color("#D00000"), // Red
+			High:          lipgloss.Color("#D45500"), // Orange
+			Average:       lipgloss.Color("#B87800"), // Yellow-Orange
+			Warning:       lipgloss.Color("#9A7B00"), // Dark yellow
+			Information:   lipgloss.Color("#3366CC"), // Blue
+			NotClassified: lipgloss.Color("#777777"), // Gray
+
+			// Status colors
+			OK:          lipgloss.Color("#008800"), // Green
+			Unknown:     lipgloss.Color("#888888"), // Gray
+			Maintenance: lipgloss.Color("#7A3DCC"), // Purple
+
+			// UI colors
+			Primary:       lipgloss.Color("#3366CC"),
+			Secondary:     lipgloss.Color("#008800"),
+			Background:    lipgloss.Color("#FFFFFF"),
+			Foreground:    lipgloss.Color("#1A1A1A"),
+			Muted:         lipgloss.Color("#888888"),
+			Border:        lipgloss.Color("#CCCCCC"),
+			FocusedBorder: lipgloss.Color("#3366CC"),
+			Highlight:     lipgloss.Color("#D6E0F5"),
+			Surface:       lipgloss.Color("#F2F2F2"),
+		},
+	}
+}
+
+// NordLightTheme returns the Nord theme on its Snow Storm background.
+// https://www.nordtheme.com/
+func NordLightTheme() *Theme {
+	return &Theme{
+		Name:        ThemeNordLight,
+		Description: "Nord palette on a light Snow Storm background",
+		Colors: ColorPalette{
+			// Severity colors (Aurora)
+			Disaster:      lipgloss.Color("#BF616A"), // nord11 - red
+			High:          lipgloss.Color("#D08770"), // nord12 - orange
+			Average:       lipgloss.Color("#B48A3C"), // nord13, darkened - yellow
+			Warning:       lipgloss.Color("#5E81AC"), // nord10 - blue
+			Information:   lipgloss.Color("#81A1C1"), // nord9 - blue
+			NotClassified: lipgloss.Color("#7B88A1"), // nord3, lightened - gray
+
+			// Status colors
+			OK:          lipgloss.Color("#7F9F65"), // nord14, darkened - green
+			Unknown:     lipgloss.Color("#7B88A1"), // nord3, lightened
+			Maintenance: lipgloss.Color("#B48EAD"), // nord15 - purple
+
+			// UI colors (Snow Storm + Frost)
+			Primary:       lipgloss.Color("#5E81AC"), // nord10 - blue
+			Secondary:     lipgloss.Color("#7F9F65"), // nord14, darkened - green
+			Background:    lipgloss.Color("#ECEFF4"), // nord6
+			Foreground:    lipgloss.Color("#2E3440"), // nord0
+			Muted:         lipgloss.Color("#7B88A1"), // nord3, lightened
+			Border:        lipgloss.Color("#D8DEE9"), // nord4
+			FocusedBorder: lipgloss.Color("#5E81AC"), // nord10
+			Highlight:     lipgloss.Color("#D8DEE9"), // nord4
+			Surface:       lipgloss.Color("#E5E9F0"), // nord5
+		},
+	}
+}
+
+// DraculaLightTheme returns the Alucard color theme, Dracula's light variant.
+// https://draculatheme.com/
+func DraculaLightTheme() *Theme {
+	return &Theme{
+		Name:        ThemeDraculaLight,
+		Description: "Light Alucard variant of the Dracula palette",
+		Colors: ColorPalette{
+			// Severity colors
+			Disaster:      lipgloss.Color("#CB3A2A"), // Red
+			High:          lipgloss.Color("#A34D14"), // Orange
+			Average:       lipgloss.Color("#846E15"), // Yellow
+			Warning:       lipgloss.Color("#036A96"), // Cyan
+			Information:   lipgloss.Color("#644AC9"), // Purple
+			NotClassified: lipgloss.Color("#635D97"), // Comment
+
+			// Status colors
+			OK:          lipgloss.Color("#14710A"), // Green
+			Unknown:     lipgloss.Color("#635D97"), // Comment
+			Maintenance: lipgloss.Color("#A3144D"), // Pink
+
+			// UI colors
+			Primary:       lipgloss.Color("#644AC9"), // Purple
+			Secondary:     lipgloss.Color("#14710A"), // Green
+			Background:    lipgloss.Color("#FFFBEB"), // Background
+			Foreground:    lipgloss.Color("#1F1F1F"), // Foreground
+			Muted:         lipgloss.Color("#635D97"), // Comment
+			Border:        lipgloss.Color("#CFCFDE"), // Current Line
+			FocusedBorder: lipgloss.Color("#A3144D"), // Pink
+			Highlight:     lipgloss.Color("#CFCFDE"), // Selection
+			Surface:       lipgloss.Color("#DEDCCF"), // Floating
+		},
+	}
+}
+
+// GruvboxLightTheme returns the Gruvbox light color theme.
+// https://github.com/morhetz/gruvbox
+func GruvboxLightTheme() *Theme {
+	return &Theme{
+		Name:        ThemeGruvboxLight,
+		Description: "Retro warm-toned Gruvbox light palette",
+		Colors: ColorPalette{
+			// Severity colors (faded variants)
+			Disaster:      lipgloss.Color("#9D0006"), // red_faded
+			High:          lipgloss.Color("#AF3A03"), // orange_faded
+			Average:       lipgloss.Color("#B57614"), // yellow_faded
+			Warning:       lipgloss.Color("#076678"), // blue_faded
+			Information:   lipgloss.Color("#8F3F71"), // purple_faded
+			NotClassified: lipgloss.Color("#7C6F64"), // gray
+
+			// Status colors
+			OK:          lipgloss.Color("#79740E"), // green_faded
+			Unknown:     lipgloss.Color("#7C6F64"), // gray
+			Maintenance: lipgloss.Color("#8F3F71"), // purple_faded
+
+			// UI colors
+			Primary:       lipgloss.Color("#427B58"), // aqua_faded
+			Secondary:     lipgloss.Color("#79740E"), // green_faded
+			Background:    lipgloss.Color("#FBF1C7"), // bg0
+			Foreground:    lipgloss.Color("#3C3836"), // fg1
+			Muted:         lipgloss.Color("#928374"), // gray_244
+			Border:        lipgloss.Color("#EBDBB2"), // bg1
+			FocusedBorder: lipgloss.Color("#427B58"), // aqua_faded
+			Highlight:     lipgloss.Color("#EBDBB2"), // bg1
+			Surface:       lipgloss.Color("#D5C4A1"), // bg2
+		},
+	}
+}
+
+// CatppuccinLightTheme returns the Catppuccin Latte color theme.
+// https://github.com/catppuccin/catppuccin
+func CatppuccinLightTheme() *Theme {
+	return &Theme{
+		Name:        ThemeCatppuccinLight,
+		Description: "Soothing pastel Catppuccin Latte palette",
+		Colors: ColorPalette{
+			// Severity colors
+			Disaster:      lipgloss.Color("#D20F39"), // Red
+			High:          lipgloss.Color("#FE640B"), // Peach
+			Average:       lipgloss.Color("#DF8E1D"), // Yellow
+			Warning:       lipgloss.Color("#04A5E5"), // Sky
+			Information:   lipgloss.Color("#1E66F5"), // Blue
+			NotClassified: lipgloss.Color("#9CA0B0"), // Overlay0
+
+			// Status colors
+			OK:          lipgloss.Color("#40A02B"), // Green
+			Unknown:     lipgloss.Color("#9CA0B0"), // Overlay0
+			Maintenance: lipgloss.Color("#8839EF"), // Mauve
+
+			// UI colors
+			Primary:       lipgloss.Color("#7287FD"), // Lavender
+			Secondary:     lipgloss.Color("#40A02B"), // Green
+			Background:    lipgloss.Color("#EFF1F5"), // Base
+			Foreground:    lipgloss.Color("#4C4F69"), // Text
+			Muted:         lipgloss.Color("#9CA0B0"), // Overlay0
+			Border:        lipgloss.Color("#CCD0DA"), // Surface0
+			FocusedBorder: lipgloss.Color("#7287FD"), // Lavender
+			Highlight:     lipgloss.Color("#BCC0CC"), // Surface1
+			Surface:       lipgloss.Color("#CCD0DA"), // Surface0
+		},
+	}
+}
+
+// TokyoNightLightTheme returns the Tokyo Night Day color theme.
+// https://github.com/folke/tokyonight.nvim
+func TokyoNightLightTheme() *Theme {
+	return &Theme{
+		Name:        ThemeTokyoNightLight,
+		Description: "Cool blues and purples Tokyo Night Day palette",
+		Colors: ColorPalette{
+			// Severity colors
+			Disaster:      lipgloss.Color("#F52A65"), // red
+			High:          lipgloss.Color("#B15C00"), // orange
+			Average:       lipgloss.Color("#8C6C3E"), // yellow
+			Warning:       lipgloss.Color("#007197"), // cyan
+			Information:   lipgloss.Color("#2E7DE9"), // blue
+			NotClassified: lipgloss.Color("#848CB5"), // comment
+
+			// Status colors
+			OK:          lipgloss.Color("#587539"), // green
+			Unknown:     lipgloss.Color("#848CB5"), // comment
+			Maintenance: lipgloss.Color("#9854F1"), // magenta
+
+			// UI colors
+			Primary:       lipgloss.Color("#2E7DE9"), // blue
+			Secondary:     lipgloss.Color("#587539"), // green
+			Background:    lipgloss.Color("#E1E2E7"), // bg
+			Foreground:    lipgloss.Color("#3760BF"), // fg
+			Muted:         lipgloss.Color("#848CB5"), // comment
+			Border:        lipgloss.Color("#C4C8DA"), // bg_highlight
+			FocusedBorder: lipgloss.Color("#9854F1"), // magenta
+			Highlight:     lipgloss.Color("#C4C8DA"), // bg_highlight
+			Surface:       lipgloss.Color("#D0D5E3"), // bg_dark
+		},
+	}
+}
+
+// SolarizedLightTheme returns the Solarized light color theme.
+// https://ethanschoonover.com/solarized/
+func SolarizedLightTheme() *Theme {
+	return &Theme{
+		Name:        ThemeSolarizedLight,
+		Description: "Precision-balanced Solarized light palette",
+		Colors: ColorPalette{
+			// Severity colors
+			Disaster:      lipgloss.Color("#DC322F"), // red
+			High:          lipgloss.Color("#CB4B16"), // orange
+			Average:       lipgloss.Color("#B58900"), // yellow
+			Warning:       lipgloss.Color("#268BD2"), // blue
+			Information:   lipgloss.Color("#6C71C4"), // violet
+			NotClassified: lipgloss.Color("#93A1A1"), // base1
+
+			// Status colors
+			OK:          lipgloss.Color("#859900"), // green
+			Unknown:     lipgloss.Color("#93A1A1"), // base1
+			Maintenance: lipgloss.Color("#D33682"), // magenta
+
+			// UI colors
+			Primary:       lipgloss.Color("#268BD2"), // blue
+			Secondary:     lipgloss.Color("#859900"), // green
+			Background:    lipgloss.Color("#FDF6E3"), // base3
+			Foreground:    lipgloss.Color("#657B83"), // base00
+			Muted:         lipgloss.Color("#93A1A1"), // base1
+			Border:        lipgloss.Color("#EEE8D5"), // base2
+			FocusedBorder: lipgloss.Color("#2AA198"), // cyan
+			Highlight:     lipgloss.Color("#EEE8D5"), // base2
+			Surface:       lipgloss.Color("#EEE8D5"), // base2
+		},
+	}
+}
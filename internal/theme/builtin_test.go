@@ -17,6 +17,13 @@ func TestBuiltinThemes(t *testing.T) {
 		ThemeCatppuccin,
 		ThemeTokyoNight,
 		ThemeSolarized,
+		ThemeDefaultLight,
+		ThemeNordLight,
+		ThemeDraculaLight,
+		ThemeGruvboxLight,
+		ThemeCatppuccinLight,
+		ThemeTokyoNightLight,
+		ThemeSolarizedLight,
 	}
 
 	// Verify all expected themes are present
@@ -45,6 +52,13 @@ func TestBuiltinThemeNames(t *testing.T) {
 		ThemeCatppuccin,
 		ThemeTokyoNight,
 		ThemeSolarized,
+		ThemeDefaultLight,
+		ThemeNordLight,
+		ThemeDraculaLight,
+		ThemeGruvboxLight,
+		ThemeCatppuccinLight,
+		ThemeTokyoNightLight,
+		ThemeSolarizedLight,
 	}
 
 	if len(names) != len(expectedNames) {
@@ -74,6 +88,8 @@ func TestThemeConstants(t *testing.T) {
 		{"ThemeCatppuccin", ThemeCatppuccin, "catppuccin"},
 		{"ThemeTokyoNight", ThemeTokyoNight, "tokyonight"},
 		{"ThemeSolarized", ThemeSolarized, "solarized"},
+		{"ThemeNordLight", ThemeNordLight, "nord-light"},
+		{"ThemeSolarizedLight", ThemeSolarizedLight, "solarized-light"},
 	}
 
 	for _, tt := range tests {
@@ -323,3 +339,47 @@ func TestBuiltinThemeNamesMatchThemeNames(t *testing.T) {
 		}
 	}
 }
+
+func TestLightVariants(t *testing.T) {
+	t.Parallel()
+
+	themes := BuiltinThemes()
+	for _, name := range []string{ThemeDefault, ThemeNord, ThemeDracula, ThemeGruvbox, ThemeCatppuccin, ThemeTokyoNight, ThemeSolarized} {
+		light, ok := themes[name+lightSuffix]
+		if !ok {
+			t.Errorf("theme %q has no light variant", name)
+			continue
+		}
+		if light.Colors.Background == themes[name].Colors.Background {
+			t.Errorf("light variant of %q has the same background as the dark theme", name)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		dark bool
+		want string
+	}{
+		{"auto", true, ThemeDefault},
+		{"auto", false, ThemeDefaultLight},
+		{"nord-auto", true, ThemeNord},
+		{"nord-auto", false, ThemeNordLight},
+		{"mytheme-auto", false, "mytheme"}, // no light variant
+		{"dracula", false, ThemeDracula},   // not auto
+		{"solarized-light", true, ThemeSolarizedLight},
+	}
+
+	for _, tt := range tests {
+		if got := Resolve(tt.name, tt.dark); got != tt.want {
+			t.Errorf("Resolve(%q, %v) = %q, want %q", tt.name, tt.dark, got, tt.want)
+		}
+	}
+
+	if !IsAuto("auto") || !IsAuto("nord-auto") || IsAuto("nord") {
+		t.Error("IsAuto() misclassified a theme name")
+	}
+}
@@ -1,6 +1,11 @@
 package theme
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // ColorPalette defines all colors used throughout the application.
 // Colors are organized by semantic meaning to ensure consistent theming.
@@ -48,8 +53,71 @@ func (c ColorPalette) SeverityColor(severity int) lipgloss.TerminalColor {
 	}
 }
 
-// SeverityName returns the human-readable name for a severity level.
+// severityColorField returns the field holding the color of a severity
+// level (0-5).
+func (c *ColorPalette) severityColorField(severity int) *lipgloss.TerminalColor {
+	switch severity {
+	case 5:
+		return &c.Disaster
+	case 4:
+		return &c.High
+	case 3:
+		return &c.Average
+	case 2:
+		return &c.Warning
+	case 1:
+		return &c.Information
+	default:
+		return &c.NotClassified
+	}
+}
+
+// WithSeverityColors returns a copy of the theme using the severity colors
+// the server is configured with: hex RGB without the leading #, as in
+// "E45959", indexed by severity. Empty or invalid colors keep the theme's.
+func (t *Theme) WithSeverityColors(colors [6]string) *Theme {
+	c := *t
+	for severity, color := range colors {
+		if color == "" || !hexColorPattern.MatchString("#"+color) {
+			continue
+		}
+		*c.Colors.severityColorField(severity) = lipgloss.Color("#" + color)
+	}
+	return &c
+}
+
+// severityNames holds the names set by SetSeverityNames. It is global, like
+// the names on the server, as severities are named throughout the UI.
+var severityNames atomic.Pointer[[6]string]
+
+// SetSeverityNames replaces the names SeverityName returns with those the
+// server is configured with, indexed by severity. Empty names keep the
+// default.
+func SetSeverityNames(names [6]string) {
+	severityNames.Store(&names)
+}
+
+// SeverityName returns the human-readable name for a severity level: the
+// name set with SetSeverityNames, or Zabbix's default.
 func SeverityName(severity int) string {
+	if name := customSeverityName(severity); name != "" {
+		return name
+	}
+	return defaultSeverityName(severity)
+}
+
+// customSeverityName returns the name set with SetSeverityNames for a
+// severity level, or "" if it is not set or is Zabbix's default.
+func customSeverityName(severity int) string {
+	names := severityNames.Load()
+	if names == nil || severity < 0 || severity >= len(names) || names[severity] == defaultSeverityName(severity) {
+		return ""
+	}
+	return names[severity]
+}
+
+// defaultSeverityName returns Zabbix's default name for a severity level.
+func defaultSeverityName(severity int) string {
 	switch severity {
 	case 5:
 		return "Disaster"
@@ -65,3 +133,29 @@ func SeverityName(severity int) string {
 		return "Not classified"
 	}
 }
+
+// SeverityLabel returns a short bracketed label for a severity level, used
+// instead of color in no-color mode. Names set with SetSeverityNames are
+// shown in capitals, cut to four letters if longer than six, e.g. "[CRIT]".
+func SeverityLabel(severity int) string {
+	if name := []rune(strings.ToUpper(customSeverityName(severity))); len(name) > 0 {
+		if len(name) > 6 {
+			name = name[:4]
+		}
+		return "[" + string(name) + "]"
+	}
+	switch severity {
+	case 5:
+		return "[DIS]"
+	case 4:
+		return "[HIGH]"
+	case 3:
+		return "[AVG]"
+	case 2:
+		return "[WARN]"
+	case 1:
+		return "[INFO]"
+	default:
+		return "[N/C]"
+	}
+}
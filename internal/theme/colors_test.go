@@ -141,3 +141,52 @@ func TestColorPalette_AllFieldsUsed(t *testing.T) {
 		t.Error("Surface color should not be nil")
 	}
 }
+
+func TestSeverityLabel(t *testing.T) {
+	t.Parallel()
+
+	want := map[int]string{0: "[N/C]", 1: "[INFO]", 2: "[WARN]", 3: "[AVG]", 4: "[HIGH]", 5: "[DIS]", 9: "[N/C]"}
+	for sev, label := range want {
+		if got := SeverityLabel(sev); got != label {
+			t.Errorf("SeverityLabel(%d) = %q, want %q", sev, got, label)
+		}
+	}
+}
+
+// TestSetSeverityNames is not parallel, as it changes the names the other
+// tests expect; parallel tests only start once it has put them back.
+func TestSetSeverityNames(t *testing.T) {
+	SetSeverityNames([6]string{2: "Warning", 3: "Major", 5: "Critical"})
+	defer SetSeverityNames([6]string{})
+
+	for severity, want := range map[int]string{3: "Major", 5: "Critical", 4: "High", 9: "Not classified"} {
+		if got := SeverityName(severity); got != want {
+			t.Errorf("SeverityName(%d) = %q, want %q", severity, got, want)
+		}
+	}
+	for severity, want := range map[int]string{2: "[WARN]", 3: "[MAJOR]", 4: "[HIGH]", 5: "[CRIT]"} {
+		if got := SeverityLabel(severity); got != want {
+			t.Errorf("SeverityLabel(%d) = %q, want %q", severity, got, want)
+		}
+	}
+}
+
+func TestTheme_WithSeverityColors(t *testing.T) {
+	t.Parallel()
+
+	base := DefaultTheme()
+	got := base.WithSeverityColors([6]string{3: "FFA059", 4: "not-a-color", 5: "e45959"})
+
+	if got.Colors.Average != lipgloss.Color("#FFA059") {
+		t.Errorf("Average = %v, want #FFA059", got.Colors.Average)
+	}
+	if got.Colors.Disaster != lipgloss.Color("#e45959") {
+		t.Errorf("Disaster = %v, want #e45959", got.Colors.Disaster)
+	}
+	if got.Colors.High != base.Colors.High || got.Colors.Warning != base.Colors.Warning {
+		t.Error("invalid and empty colors should keep the theme's")
+	}
+	if base.Colors.Average == got.Colors.Average {
+		t.Error("the theme itself should not change")
+	}
+}
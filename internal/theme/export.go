@@ -0,0 +1,129 @@
+package theme
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// paletteColor is a palette color with its key in custom theme files.
+type paletteColor struct {
+	Key   string
+	Color lipgloss.TerminalColor
+}
+
+// paletteColors returns the colors of a palette in the order of custom
+// theme files.
+func paletteColors(c ColorPalette) []paletteColor {
+	return []paletteColor{
+		{"disaster", c.Disaster},
+		{"high", c.High},
+		{"average", c.Average},
+		{"warning", c.Warning},
+		{"information", c.Information},
+		{"not_classified", c.NotClassified},
+		{"ok", c.OK},
+		{"unknown", c.Unknown},
+		{"maintenance", c.Maintenance},
+		{"primary", c.Primary},
+		{"secondary", c.Secondary},
+		{"background", c.Background},
+		{"foreground", c.Foreground},
+		{"muted", c.Muted},
+		{"border", c.Border},
+		{"focused_border", c.FocusedBorder},
+		{"highlight", c.Highlight},
+		{"surface", c.Surface},
+	}
+}
+
+// colorValue returns the hex or ANSI value of a color as written in custom
+// theme files, or "" for colors that cannot be written there.
+func colorValue(c lipgloss.TerminalColor) string {
+	if color, ok := c.(lipgloss.Color); ok {
+		return string(color)
+	}
+	return ""
+}
+
+// CustomThemeNames returns the names of the custom themes in the themes
+// directory under configDir, sorted. A missing directory has no themes.
+func CustomThemeNames(configDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(configDir, "themes"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read themes directory: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".yaml"); ok && !e.IsDir() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Export returns a theme as a custom theme file, a starting point for
+// authors deriving their own theme from it.
+func Export(t *Theme) ([]byte, error) {
+	c := t.Colors
+	cfg := CustomThemeConfig{
+		Name:        t.Name,
+		Description: t.Description,
+		Colors: CustomColorConfig{
+			Disaster:      colorValue(c.Disaster),
+			High:          colorValue(c.High),
+			Average:       colorValue(c.Average),
+			Warning:       colorValue(c.Warning),
+			Information:   colorValue(c.Information),
+			NotClassified: colorValue(c.NotClassified),
+			OK:            colorValue(c.OK),
+			Unknown:       colorValue(c.Unknown),
+			Maintenance:   colorValue(c.Maintenance),
+			Primary:       colorValue(c.Primary),
+			Secondary:     colorValue(c.Secondary),
+			Background:    colorValue(c.Background),
+			Foreground:    colorValue(c.Foreground),
+			Muted:         colorValue(c.Muted),
+			Border:        colorValue(c.Border),
+			FocusedBorder: colorValue(c.FocusedBorder),
+			Highlight:     colorValue(c.Highlight),
+			Surface:       colorValue(c.Surface),
+		},
+		Styles: t.Overrides,
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Chotko theme exported from %q.\n", t.Name)
+	buf.WriteString("# Change the name, save it as ~/.config/chotko/themes/<name>.yaml and edit\n")
+	buf.WriteString("# the colors (#RRGGBB or an ANSI number 0-255). Style overrides for\n")
+	buf.WriteString("# components can be added under \"styles\":\n")
+	line := "#  "
+	for _, name := range OverrideNames() {
+		if len(line)+len(name) > 76 {
+			buf.WriteString(line + "\n")
+			line = "#  "
+		}
+		line += " " + name + ","
+	}
+	buf.WriteString(strings.TrimSuffix(line, ",") + "\n\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to encode theme: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode theme: %w", err)
+	}
+	return buf.Bytes(), nil
+}
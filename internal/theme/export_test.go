@@ -0,0 +1,70 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestExport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	bold := true
+	orig := NordTheme()
+	orig.Overrides = map[string]StyleOverride{"selected_row": {Background: "#444488", Bold: &bold}}
+
+	data, err := Export(orig)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "mynord.yaml")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile() error = %v\n%s", err, data)
+	}
+
+	if loaded.Name != orig.Name || loaded.Description != orig.Description {
+		t.Errorf("loaded %q (%q), want %q (%q)", loaded.Name, loaded.Description, orig.Name, orig.Description)
+	}
+	want, got := paletteColors(orig.Colors), paletteColors(loaded.Colors)
+	for i := range want {
+		if got[i].Color != want[i].Color {
+			t.Errorf("%s = %v, want %v", want[i].Key, got[i].Color, want[i].Color)
+		}
+	}
+	o := loaded.Overrides["selected_row"]
+	if o.Background != "#444488" || o.Bold == nil || !*o.Bold || o.Foreground != "" || o.Italic != nil {
+		t.Errorf("selected_row override = %+v", o)
+	}
+}
+
+func TestCustomThemeNames(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	names, err := CustomThemeNames(dir)
+	if err != nil || len(names) != 0 {
+		t.Fatalf("CustomThemeNames() without themes = %v, %v", names, err)
+	}
+
+	themesDir := filepath.Join(dir, "themes")
+	if err := os.MkdirAll(filepath.Join(themesDir, "dir.yaml"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{"zen.yaml", "amber.yaml", "custom.yaml.example"} {
+		if err := os.WriteFile(filepath.Join(themesDir, file), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	names, err = CustomThemeNames(dir)
+	if err != nil {
+		t.Fatalf("CustomThemeNames() error = %v", err)
+	}
+	if want := []string{"amber", "zen"}; !slices.Equal(names, want) {
+		t.Errorf("CustomThemeNames() = %v, want %v", names, want)
+	}
+}
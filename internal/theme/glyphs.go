@@ -0,0 +1,92 @@
+package theme
+
+// Glyph set names for the display.glyphs setting.
+const (
+	GlyphsUnicode  = "unicode"
+	GlyphsNerdFont = "nerdfont"
+	GlyphsASCII    = "ascii"
+)
+
+// StatusGlyphs are the status indicators shown in lists.
+type StatusGlyphs struct {
+	// Host states
+	Available   string
+	Unavailable string
+	Unknown     string
+	Maintenance string
+	Disabled    string // Also disabled triggers
+
+	Enabled  string // Enabled triggers
+	Problem  string // Problem events
+	Resolved string // Recovery events
+	Acked    string // Acknowledged problems
+}
+
+// GlyphSet is a consistent set of severity and status indicators.
+type GlyphSet struct {
+	Severity [6]string // Indexed by severity (0-5)
+	Status   StatusGlyphs
+}
+
+// glyphSets are the built-in glyph sets by name.
+var glyphSets = map[string]GlyphSet{
+	// Geometric shapes most terminal fonts have
+	GlyphsUnicode: {
+		Severity: [6]string{"○", "○", "○", "◐", "●", "●"},
+		Status: StatusGlyphs{
+			Available:   "▲",
+			Unavailable: "▼",
+			Unknown:     "?",
+			Maintenance: "⚙",
+			Disabled:    "○",
+			Enabled:     "●",
+			Problem:     "!!",
+			Resolved:    "OK",
+			Acked:       "✓",
+		},
+	},
+	// Font Awesome icons from a patched Nerd Font (https://www.nerdfonts.com)
+	GlyphsNerdFont: {
+		Severity: [6]string{"", "", "", "", "", ""},
+		Status: StatusGlyphs{
+			Available:   "",
+			Unavailable: "",
+			Unknown:     "",
+			Maintenance: "",
+			Disabled:    "",
+			Enabled:     "",
+			Problem:     "",
+			Resolved:    "",
+			Acked:       "",
+		},
+	},
+	// Plain ASCII for terminals and fonts without either
+	GlyphsASCII: {
+		Severity: [6]string{".", "i", "w", "a", "H", "D"},
+		Status: StatusGlyphs{
+			Available:   "+",
+			Unavailable: "!",
+			Unknown:     "?",
+			Maintenance: "M",
+			Disabled:    "-",
+			Enabled:     "+",
+			Problem:     "!!",
+			Resolved:    "OK",
+			Acked:       "A",
+		},
+	},
+}
+
+// GlyphSetNames returns the names of the built-in glyph sets.
+func GlyphSetNames() []string {
+	return []string{GlyphsUnicode, GlyphsNerdFont, GlyphsASCII}
+}
+
+// Glyphs returns the built-in glyph set with the given name, or the unicode
+// set for an empty or unknown name.
+func Glyphs(name string) GlyphSet {
+	if set, ok := glyphSets[name]; ok {
+		return set
+	}
+	return glyphSets[GlyphsUnicode]
+}
@@ -0,0 +1,57 @@
+package theme
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestGlyphSets(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range GlyphSetNames() {
+		set := Glyphs(name)
+		for sev, icon := range set.Severity {
+			if icon == "" {
+				t.Errorf("%s: severity %d has no glyph", name, sev)
+			}
+		}
+		status := reflect.ValueOf(set.Status)
+		for i := range status.NumField() {
+			if glyph := status.Field(i).String(); glyph == "" || lipgloss.Width(glyph) > 2 {
+				t.Errorf("%s: %s glyph %q, want one or two columns", name, status.Type().Field(i).Name, glyph)
+			}
+		}
+	}
+
+	if Glyphs("") != Glyphs(GlyphsUnicode) || Glyphs("emoji") != Glyphs(GlyphsUnicode) {
+		t.Error("Glyphs() of an empty or unknown name should be the unicode set")
+	}
+	ascii := Glyphs(GlyphsASCII)
+	glyphs := ascii.Severity[:]
+	status := reflect.ValueOf(ascii.Status)
+	for i := range status.NumField() {
+		glyphs = append(glyphs, status.Field(i).String())
+	}
+	for _, glyph := range glyphs {
+		for _, r := range glyph {
+			if r > 127 {
+				t.Errorf("ascii set contains %q", r)
+			}
+		}
+	}
+}
+
+func TestSetGlyphs(t *testing.T) {
+	t.Parallel()
+
+	s := NewStyles(DefaultTheme())
+	if s.StatusIcon.Acked != "✓" || s.SeverityIcon[5] != "●" {
+		t.Errorf("default glyphs = %v %+v, want the unicode set", s.SeverityIcon, s.StatusIcon)
+	}
+	s.SetGlyphs(Glyphs(GlyphsNerdFont))
+	if s.SeverityIcon != Glyphs(GlyphsNerdFont).Severity || s.StatusIcon != Glyphs(GlyphsNerdFont).Status {
+		t.Errorf("SetGlyphs() = %v %+v, want the nerd font set", s.SeverityIcon, s.StatusIcon)
+	}
+}
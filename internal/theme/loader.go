@@ -14,6 +14,9 @@ type CustomThemeConfig struct {
 	Name        string            `yaml:"name"`
 	Description string            `yaml:"description"`
 	Colors      CustomColorConfig `yaml:"colors"`
+
+	// Styles overrides component styles, e.g. "selected_row" or "chart_line"
+	Styles map[string]StyleOverride `yaml:"styles,omitempty"`
 }
 
 // CustomColorConfig holds color hex values from a custom theme file.
@@ -68,6 +71,10 @@ func LoadFromFile(path string) (*Theme, error) {
 		return nil, fmt.Errorf("failed to parse theme file: %w", err)
 	}
 
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid theme file %s: %w", path, err)
+	}
+
 	return buildThemeFromConfig(&cfg), nil
 }
 
@@ -99,6 +106,7 @@ func buildThemeFromConfig(cfg *CustomThemeConfig) *Theme {
 			Highlight:     colorOrDefault(cfg.Colors.Highlight, def.Colors.Highlight),
 			Surface:       colorOrDefault(cfg.Colors.Surface, def.Colors.Surface),
 		},
+		Overrides: cfg.Styles,
 	}
 }
 
@@ -149,6 +157,22 @@ colors:
   focused_border: "#6699FF" # Focused pane borders
   highlight: "#333366"      # Selected/highlighted items
   surface: "#2a2a2a"        # Elevated surfaces (modals, etc.)
+
+# Optional per-component overrides. Each accepts foreground, background,
+# border (colors) and bold, italic, underline (true/false).
+# Components: selected_row, row, host, duration, pane_border,
+# pane_border_focused, pane_title, status_bar, status_banner, tab_active,
+# tab_inactive, tab_bar, detail_label, detail_value, modal, modal_title,
+# modal_button, chart_axis, chart_label, chart_line, help_key
+#
+# styles:
+#   selected_row:
+#     background: "#444488"
+#     bold: false
+#   pane_border_focused:
+#     border: "#FFAA00"
+#   chart_line:
+#     foreground: "#00CC00"
 `
 
 	templatePath := filepath.Join(themesDir, "custom.yaml.example")
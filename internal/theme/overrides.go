@@ -0,0 +1,157 @@
+package theme
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// StyleOverride customizes one component style of a custom theme.
+// Empty colors and nil attributes keep the style derived from the palette.
+type StyleOverride struct {
+	Foreground string `yaml:"foreground,omitempty"`
+	Background string `yaml:"background,omitempty"`
+	Border     string `yaml:"border,omitempty"`
+	Bold       *bool  `yaml:"bold,omitempty"`
+	Italic     *bool  `yaml:"italic,omitempty"`
+	Underline  *bool  `yaml:"underline,omitempty"`
+}
+
+// overrideTargets maps the component names accepted under "styles:" in a
+// custom theme file to the styles they change.
+var overrideTargets = map[string]func(s *Styles) *lipgloss.Style{
+	"selected_row":        func(s *Styles) *lipgloss.Style { return &s.AlertSelected },
+	"row":                 func(s *Styles) *lipgloss.Style { return &s.AlertNormal },
+	"host":                func(s *Styles) *lipgloss.Style { return &s.AlertHost },
+	"duration":            func(s *Styles) *lipgloss.Style { return &s.AlertDuration },
+	"pane_border":         func(s *Styles) *lipgloss.Style { return &s.PaneBlurred },
+	"pane_border_focused": func(s *Styles) *lipgloss.Style { return &s.PaneFocused },
+	"pane_title":          func(s *Styles) *lipgloss.Style { return &s.PaneTitle },
+	"status_bar":          func(s *Styles) *lipgloss.Style { return &s.StatusBar },
+	"status_banner":       func(s *Styles) *lipgloss.Style { return &s.StatusBanner },
+	"tab_active":          func(s *Styles) *lipgloss.Style { return &s.TabActive },
+	"tab_inactive":        func(s *Styles) *lipgloss.Style { return &s.TabInactive },
+	"tab_bar":             func(s *Styles) *lipgloss.Style { return &s.TabBar },
+	"detail_label":        func(s *Styles) *lipgloss.Style { return &s.DetailLabel },
+	"detail_value":        func(s *Styles) *lipgloss.Style { return &s.DetailValue },
+	"modal":               func(s *Styles) *lipgloss.Style { return &s.ModalBox },
+	"modal_title":         func(s *Styles) *lipgloss.Style { return &s.ModalTitle },
+	"modal_button":        func(s *Styles) *lipgloss.Style { return &s.ModalButton },
+	"chart_axis":          func(s *Styles) *lipgloss.Style { return &s.ChartAxis },
+	"chart_label":         func(s *Styles) *lipgloss.Style { return &s.ChartLabel },
+	"chart_line":          func(s *Styles) *lipgloss.Style { return &s.ChartLine },
+	"help_key":            func(s *Styles) *lipgloss.Style { return &s.HelpKey },
+}
+
+// OverrideNames returns the component names that can be overridden, sorted.
+func OverrideNames() []string {
+	names := make([]string, 0, len(overrideTargets))
+	for name := range overrideTargets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyOverrides applies a theme's style overrides on top of the derived styles.
+// Unknown component names are ignored; LoadFromFile rejects them.
+func applyOverrides(s *Styles, overrides map[string]StyleOverride) {
+	for name, o := range overrides {
+		target, ok := overrideTargets[name]
+		if !ok {
+			continue
+		}
+		style := target(s)
+		if o.Foreground != "" {
+			*style = style.Foreground(lipgloss.Color(o.Foreground))
+		}
+		if o.Background != "" {
+			*style = style.Background(lipgloss.Color(o.Background))
+		}
+		if o.Border != "" {
+			*style = style.BorderForeground(lipgloss.Color(o.Border))
+		}
+		if o.Bold != nil {
+			*style = style.Bold(*o.Bold)
+		}
+		if o.Italic != nil {
+			*style = style.Italic(*o.Italic)
+		}
+		if o.Underline != nil {
+			*style = style.Underline(*o.Underline)
+		}
+	}
+}
+
+// hexColorPattern matches "#RGB" and "#RRGGBB" colors.
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// validateColor checks that a color is a hex value or an ANSI color number (0-255).
+// Empty values are valid and mean "not set".
+func validateColor(field, value string) error {
+	if value == "" || hexColorPattern.MatchString(value) {
+		return nil
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 255 {
+		return nil
+	}
+	return fmt.Errorf("%s: invalid color %q (use #RRGGBB or an ANSI number 0-255)", field, value)
+}
+
+// Validate checks the colors and style overrides of a custom theme.
+func (cfg *CustomThemeConfig) Validate() error {
+	c := cfg.Colors
+	colors := []struct{ field, value string }{
+		{"disaster", c.Disaster},
+		{"high", c.High},
+		{"average", c.Average},
+		{"warning", c.Warning},
+		{"information", c.Information},
+		{"not_classified", c.NotClassified},
+		{"ok", c.OK},
+		{"unknown", c.Unknown},
+		{"maintenance", c.Maintenance},
+		{"primary", c.Primary},
+		{"secondary", c.Secondary},
+		{"background", c.Background},
+		{"foreground", c.Foreground},
+		{"muted", c.Muted},
+		{"border", c.Border},
+		{"focused_border", c.FocusedBorder},
+		{"highlight", c.Highlight},
+		{"surface", c.Surface},
+	}
+	for _, col := range colors {
+		if err := validateColor("colors."+col.field, col.value); err != nil {
+			return err
+		}
+	}
+
+	names := make([]string, 0, len(cfg.Styles))
+	for name := range cfg.Styles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := overrideTargets[name]; !ok {
+			return fmt.Errorf("styles.%s: unknown component (valid: %s)", name, strings.Join(OverrideNames(), ", "))
+		}
+		o := cfg.Styles[name]
+		for _, col := range []struct{ field, value string }{
+			{"foreground", o.Foreground},
+			{"background", o.Background},
+			{"border", o.Border},
+		} {
+			if err := validateColor("styles."+name+"."+col.field, col.value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
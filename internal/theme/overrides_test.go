@@ -0,0 +1,118 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLoadFromFile_StyleOverrides(t *testing.T) {
+	t.Parallel()
+
+	themePath := filepath.Join(t.TempDir(), "overrides.yaml")
+	content := `name: "overrides"
+colors:
+  primary: "#0000FF"
+styles:
+  selected_row:
+    background: "#112233"
+    bold: false
+  pane_border_focused:
+    border: "#FFAA00"
+  chart_line:
+    foreground: "42"
+`
+	if err := os.WriteFile(themePath, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write theme: %v", err)
+	}
+
+	theme, err := LoadFromFile(themePath)
+	if err != nil {
+		t.Fatalf("LoadFromFile() error = %v", err)
+	}
+	styles := NewStyles(theme)
+
+	if got := styles.AlertSelected.GetBackground(); got != lipgloss.Color("#112233") {
+		t.Errorf("selected_row background = %v, want #112233", got)
+	}
+	if styles.AlertSelected.GetBold() {
+		t.Error("selected_row should not be bold")
+	}
+	if got := styles.PaneFocused.GetBorderTopForeground(); got != lipgloss.Color("#FFAA00") {
+		t.Errorf("pane_border_focused border = %v, want #FFAA00", got)
+	}
+	if got := styles.ChartLine.GetForeground(); got != lipgloss.Color("42") {
+		t.Errorf("chart_line foreground = %v, want 42", got)
+	}
+	// Styles without overrides still follow the palette
+	if got := styles.Title.GetForeground(); got != lipgloss.Color("#0000FF") {
+		t.Errorf("title foreground = %v, want palette primary #0000FF", got)
+	}
+}
+
+func TestLoadFromFile_InvalidTheme(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "invalid palette color",
+			content: "colors:\n  disaster: \"red\"\n",
+			wantErr: `colors.disaster: invalid color "red"`,
+		},
+		{
+			name:    "unknown component",
+			content: "styles:\n  sidebar:\n    foreground: \"#FFFFFF\"\n",
+			wantErr: "styles.sidebar: unknown component",
+		},
+		{
+			name:    "invalid override color",
+			content: "styles:\n  modal:\n    border: \"#12345\"\n",
+			wantErr: `styles.modal.border: invalid color "#12345"`,
+		},
+		{
+			name:    "ANSI number out of range",
+			content: "styles:\n  row:\n    foreground: \"300\"\n",
+			wantErr: `styles.row.foreground: invalid color "300"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			themePath := filepath.Join(t.TempDir(), "bad.yaml")
+			if err := os.WriteFile(themePath, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("Failed to write theme: %v", err)
+			}
+
+			_, err := LoadFromFile(themePath)
+			if err == nil {
+				t.Fatal("LoadFromFile() should return an error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadFromFile() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOverrideNames(t *testing.T) {
+	t.Parallel()
+
+	names := OverrideNames()
+	if len(names) != len(overrideTargets) {
+		t.Fatalf("OverrideNames() returned %d names, want %d", len(names), len(overrideTargets))
+	}
+	for i := 1; i < len(names); i++ {
+		if names[i-1] >= names[i] {
+			t.Errorf("OverrideNames() not sorted: %q before %q", names[i-1], names[i])
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package theme
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// previewAlert is a made-up problem shown in theme previews.
+type previewAlert struct {
+	severity int
+	host     string
+	name     string
+	duration string
+	acked    bool
+}
+
+// previewAlerts cover every severity, a selected and an acknowledged row.
+var previewAlerts = []previewAlert{
+	{5, "db-01", "MySQL: Service is down", "3m 12s", false},
+	{4, "web-02", "High CPU utilization (over 90% for 5m)", "17m", false},
+	{3, "core-sw-01", "Interface Gi0/1: Link down", "1h 4m", true},
+	{2, "web-01", "/: Disk space is low (used > 80%)", "2d 3h", false},
+	{1, "mail-01", "mail-01 has been restarted (uptime < 10m)", "6m", false},
+	{0, "backup-01", "Backup job took longer than usual", "5h", true},
+}
+
+// previewSelected is the index of the selected row in previewAlerts.
+const previewSelected = 1
+
+// Preview renders a theme for theme authors: a swatch of every palette
+// color with its value, and alert rows styled as in the problem list.
+func Preview(t *Theme) string {
+	s := NewStyles(t)
+
+	var b strings.Builder
+	title := t.Name
+	if t.Description != "" {
+		title += " - " + t.Description
+	}
+	b.WriteString(s.Title.Render(title) + "\n\n")
+
+	for _, pc := range paletteColors(t.Colors) {
+		value := colorValue(pc.Color)
+		if value == "" {
+			value = "(terminal default)"
+		}
+		swatch := lipgloss.NewStyle().Foreground(pc.Color).Render("██████")
+		fmt.Fprintf(&b, "  %-16s %s  %s\n", pc.Key, swatch, s.Subtle.Render(value))
+	}
+
+	rows := make([]string, 0, len(previewAlerts))
+	for i, a := range previewAlerts {
+		rows = append(rows, previewRow(s, a, i == previewSelected))
+	}
+	pane := s.PaneTitle.Render("Alerts") + "\n" + strings.Join(rows, "\n")
+	b.WriteString("\n" + s.PaneFocused.Render(pane) + "\n")
+	b.WriteString(s.StatusBar.Render(
+		s.StatusProblem.Render("6 problems") + "  " +
+			s.StatusOK.Render("42 OK") + "  " +
+			s.StatusMaint.Render("2 in maintenance") + "  " +
+			s.StatusUnknown.Render("1 unknown")))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// previewRow renders one alert row like the problem list does.
+func previewRow(s *Styles, a previewAlert, selected bool) string {
+	icon := s.SeverityIcon[a.severity]
+	if pad := s.SeverityIconWidth() - lipgloss.Width(icon); pad > 0 {
+		icon += strings.Repeat(" ", pad)
+	}
+	host := fmt.Sprintf("%-15s", a.host)
+	name := fmt.Sprintf("%-42s", a.name)
+	duration := fmt.Sprintf("%10s", a.duration)
+	ack := s.StatusIcon.Acked
+	if !a.acked {
+		ack = strings.Repeat(" ", lipgloss.Width(ack))
+	}
+	if selected {
+		return s.AlertSelected.Render(fmt.Sprintf("%s %s %s %s %s", icon, host, name, duration, ack))
+	}
+	return s.AlertSeverity[a.severity].Render(icon) + " " +
+		s.AlertHost.Render(host) + " " +
+		s.AlertName.Render(name) + " " +
+		s.AlertDuration.Render(duration) + " " +
+		s.AlertAcked.Render(ack)
+}
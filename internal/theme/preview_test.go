@@ -0,0 +1,22 @@
+package theme
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPreview(t *testing.T) {
+	t.Parallel()
+
+	out := Preview(GruvboxTheme())
+	for _, want := range []string{"gruvbox", "not_classified", "focused_border", "#", "db-01", "MySQL: Service is down", "✓"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Preview() missing %q:\n%s", want, out)
+		}
+	}
+	for _, pc := range paletteColors(GruvboxTheme().Colors) {
+		if !strings.Contains(out, colorValue(pc.Color)) {
+			t.Errorf("Preview() missing %s value %s", pc.Key, colorValue(pc.Color))
+		}
+	}
+}
@@ -7,6 +7,10 @@ type Theme struct {
 	Name        string
 	Description string
 	Colors      ColorPalette
+
+	// Overrides customizes individual component styles, keyed by component
+	// name (see OverrideNames). Only custom themes set overrides.
+	Overrides map[string]StyleOverride
 }
 
 // Styles contains pre-built lipgloss styles derived from a theme.
@@ -30,6 +34,7 @@ type Styles struct {
 	StatusUnknown lipgloss.Style
 	StatusMaint   lipgloss.Style
 	StatusFilter  lipgloss.Style
+	StatusBanner  lipgloss.Style // Full-width connection warning banner
 
 	// Alert list styles
 	AlertSelected lipgloss.Style
@@ -40,6 +45,12 @@ type Styles struct {
 	AlertDuration lipgloss.Style
 	AlertAcked    lipgloss.Style
 
+	// SeverityIcon is the indicator shown in lists, indexed by severity (0-5)
+	SeverityIcon [6]string
+
+	// StatusIcon holds the host, trigger and event status indicators
+	StatusIcon StatusGlyphs
+
 	// Detail pane styles
 	DetailLabel lipgloss.Style
 	DetailValue lipgloss.Style
@@ -63,16 +74,26 @@ type Styles struct {
 	ModalButton     lipgloss.Style
 	ModalButtonAlt  lipgloss.Style
 
+	// Chart styles
+	ChartAxis  lipgloss.Style
+	ChartLabel lipgloss.Style
+	ChartLine  lipgloss.Style
+	// ChartBaselines draw past history over a chart, the nearest first
+	ChartBaselines [2]lipgloss.Style
+
 	// Help styles
 	HelpKey  lipgloss.Style
 	HelpDesc lipgloss.Style
+
+	// NoColor is set when severity and status must be readable without color
+	NoColor bool
 }
 
 // NewStyles creates a Styles struct from a Theme.
 func NewStyles(t *Theme) *Styles {
 	c := t.Colors
 
-	return &Styles{
+	s := &Styles{
 		// Base styles
 		App:    lipgloss.NewStyle().Background(c.Background),
 		Title:  lipgloss.NewStyle().Foreground(c.Primary).Bold(true),
@@ -109,6 +130,11 @@ func NewStyles(t *Theme) *Styles {
 		StatusFilter: lipgloss.NewStyle().
 			Foreground(c.Warning).
 			Bold(true),
+		StatusBanner: lipgloss.NewStyle().
+			Background(c.Disaster).
+			Foreground(c.Background).
+			Bold(true).
+			Padding(0, 1),
 
 		// Alert list styles
 		AlertSelected: lipgloss.NewStyle().
@@ -133,6 +159,8 @@ func NewStyles(t *Theme) *Styles {
 			Foreground(c.Muted),
 		AlertAcked: lipgloss.NewStyle().
 			Foreground(c.OK),
+		SeverityIcon: Glyphs(GlyphsUnicode).Severity,
+		StatusIcon:   Glyphs(GlyphsUnicode).Status,
 
 		// Detail pane styles
 		DetailLabel: lipgloss.NewStyle().
@@ -190,6 +218,18 @@ func NewStyles(t *Theme) *Styles {
 			Background(c.Surface).
 			Padding(0, 2),
 
+		// Chart styles
+		ChartAxis: lipgloss.NewStyle().
+			Foreground(c.Border),
+		ChartLabel: lipgloss.NewStyle().
+			Foreground(c.Muted),
+		ChartLine: lipgloss.NewStyle().
+			Foreground(c.Primary),
+		ChartBaselines: [2]lipgloss.Style{
+			lipgloss.NewStyle().Foreground(c.Secondary),
+			lipgloss.NewStyle().Foreground(c.Muted),
+		},
+
 		// Help styles
 		HelpKey: lipgloss.NewStyle().
 			Foreground(c.Primary).
@@ -197,4 +237,54 @@ func NewStyles(t *Theme) *Styles {
 		HelpDesc: lipgloss.NewStyle().
 			Foreground(c.Muted),
 	}
+
+	applyOverrides(s, t.Overrides)
+	return s
+}
+
+// Monochrome adjusts the styles for terminals without color and colorblind
+// users: severities are shown as text labels such as "[DIS]" and emphasized
+// with bold and underline, and selection and focus use reverse video and
+// heavier borders instead of background and border colors.
+func (s *Styles) Monochrome() {
+	s.NoColor = true
+	for sev := range s.SeverityIcon {
+		s.SeverityIcon[sev] = SeverityLabel(sev)
+	}
+
+	s.AlertSeverity[5] = s.AlertSeverity[5].Bold(true).Underline(true)
+	s.AlertSeverity[4] = s.AlertSeverity[4].Bold(true)
+	s.AlertSeverity[3] = s.AlertSeverity[3].Underline(true)
+
+	s.AlertSelected = s.AlertSelected.Reverse(true)
+	s.PaneFocused = s.PaneFocused.Border(lipgloss.ThickBorder())
+	s.TabActive = s.TabActive.Underline(true)
+	s.StatusProblem = s.StatusProblem.Underline(true)
+	s.StatusBanner = s.StatusBanner.Reverse(true)
+	s.ModalButton = s.ModalButton.Reverse(true)
+}
+
+// SetGlyphs replaces the severity and status indicators with a glyph set.
+func (s *Styles) SetGlyphs(set GlyphSet) {
+	s.SeverityIcon = set.Severity
+	s.StatusIcon = set.Status
+}
+
+// SetSeverityIcons replaces the severity indicators, indexed by severity.
+// Empty entries keep the current indicator.
+func (s *Styles) SetSeverityIcons(icons []string) {
+	for sev, icon := range icons {
+		if sev < len(s.SeverityIcon) && icon != "" {
+			s.SeverityIcon[sev] = icon
+		}
+	}
+}
+
+// SeverityIconWidth returns the display width of the widest severity indicator.
+func (s *Styles) SeverityIconWidth() int {
+	width := 0
+	for _, icon := range s.SeverityIcon {
+		width = max(width, lipgloss.Width(icon))
+	}
+	return width
 }
@@ -217,3 +217,43 @@ func TestNewStyles_DifferentThemesProduceDifferentStyles(t *testing.T) {
 		t.Error("draculaStyles should not be nil")
 	}
 }
+
+func TestStyles_Monochrome(t *testing.T) {
+	t.Parallel()
+
+	styles := NewStyles(DefaultTheme())
+	if styles.NoColor {
+		t.Fatal("NewStyles() should not be in no-color mode")
+	}
+	styles.Monochrome()
+
+	if !styles.NoColor {
+		t.Error("Monochrome() should set NoColor")
+	}
+	for sev := range styles.SeverityIcon {
+		if got, want := styles.SeverityIcon[sev], SeverityLabel(sev); got != want {
+			t.Errorf("SeverityIcon[%d] = %q, want %q", sev, got, want)
+		}
+	}
+	if !styles.AlertSeverity[5].GetUnderline() || !styles.AlertSeverity[5].GetBold() {
+		t.Error("disaster severity should be bold and underlined")
+	}
+	if !styles.AlertSelected.GetReverse() {
+		t.Error("selected row should use reverse video")
+	}
+	if got := styles.SeverityIconWidth(); got != len("[HIGH]") {
+		t.Errorf("SeverityIconWidth() = %d, want %d", got, len("[HIGH]"))
+	}
+}
+
+func TestStyles_SetSeverityIcons(t *testing.T) {
+	t.Parallel()
+
+	styles := NewStyles(DefaultTheme())
+	styles.SetSeverityIcons([]string{"", "i", "w", "a", "h", "D", "ignored"})
+
+	want := [6]string{"○", "i", "w", "a", "h", "D"}
+	if styles.SeverityIcon != want {
+		t.Errorf("SeverityIcon = %v, want %v", styles.SeverityIcon, want)
+	}
+}
@@ -0,0 +1,134 @@
+package zabbix
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Problems are assigned to a user by problem update messages starting with
+// AssignPrefix and the user's username, e.g. "assign: jdoe", and unassigned
+// by ones starting with UnassignPrefix. Any text after them is a note. Zabbix
+// has no assignments of its own, so these are plain messages to it and to
+// its frontend.
+const (
+	AssignPrefix   = "assign:"
+	UnassignPrefix = "unassign:"
+)
+
+// AssignMessage returns the update message assigning a problem to username,
+// followed by note if any.
+func AssignMessage(username, note string) string {
+	return strings.TrimSpace(AssignPrefix + " " + username + " " + note)
+}
+
+// UnassignMessage returns the update message unassigning a problem,
+// followed by note if any.
+func UnassignMessage(note string) string {
+	return strings.TrimSpace(UnassignPrefix + " " + note)
+}
+
+// parseAssignment returns the username an update message assigns its problem
+// to, empty for one unassigning it. ok is false for other messages.
+func parseAssignment(message string) (username string, ok bool) {
+	message = strings.TrimSpace(message)
+	if strings.HasPrefix(message, UnassignPrefix) {
+		return "", true
+	}
+	rest, found := strings.CutPrefix(message, AssignPrefix)
+	if !found {
+		return "", false
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return "", false
+	}
+	return fields[0], true
+}
+
+// sortedAcks returns the problem's updates, oldest first.
+func (p *Problem) sortedAcks() []Ack {
+	acks := slices.Clone(p.Acknowledges)
+	slices.SortStableFunc(acks, func(a, b Ack) int {
+		x, _ := strconv.ParseInt(a.Clock, 10, 64)
+		y, _ := strconv.ParseInt(b.Clock, 10, 64)
+		return cmp.Compare(x, y)
+	})
+	return acks
+}
+
+// Assignee returns the username of the user the problem is assigned to by
+// its latest assign or unassign message. A problem never assigned belongs to
+// the user who first acknowledged it. Returns empty for none.
+func (p *Problem) Assignee() string {
+	acks := p.sortedAcks()
+	for i := len(acks) - 1; i >= 0; i-- {
+		if username, ok := parseAssignment(acks[i].Message); ok {
+			return username
+		}
+	}
+	for _, a := range acks {
+		action, _ := strconv.Atoi(a.Action)
+		if action&ActionAcknowledge != 0 {
+			return a.Username
+		}
+	}
+	return ""
+}
+
+// AssignedTo reports whether the problem is assigned to username.
+func (p *Problem) AssignedTo(username string) bool {
+	return username != "" && p.Assignee() == username
+}
+
+// AssigneeInitials returns the initials of the problem's assignee, from the
+// name and surname of their updates to it when known and otherwise the
+// first two letters of their username, upper case. Returns empty for none.
+func (p *Problem) AssigneeInitials() string {
+	username := p.Assignee()
+	if username == "" {
+		return ""
+	}
+	for _, a := range p.Acknowledges {
+		if a.Username == username && a.Name != "" && a.Surname != "" {
+			return initials(a.Name, a.Surname)
+		}
+	}
+	first, size := utf8.DecodeRuneInString(username)
+	return initials(string(first), username[size:])
+}
+
+// initials returns the upper case first letters of the words given.
+func initials(words ...string) string {
+	var b strings.Builder
+	for _, w := range words {
+		if r, _ := utf8.DecodeRuneInString(w); r != utf8.RuneError {
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	return b.String()
+}
+
+// AddProblemsMessage adds a message to problem events without acknowledging
+// them, e.g. AssignMessage.
+func (c *Client) AddProblemsMessage(ctx context.Context, eventIDs []string, message string) error {
+	params := AcknowledgeParams{
+		EventIDs: eventIDs,
+		Action:   ActionAddMessage,
+		Message:  message,
+	}
+
+	// Result contains eventids but the type varies by Zabbix version (string or number)
+	// We don't need the result, just check if the call succeeded
+	var result interface{}
+	if err := c.call(ctx, "event.acknowledge", params, &result); err != nil {
+		return fmt.Errorf("failed to add message: %w", err)
+	}
+
+	return nil
+}
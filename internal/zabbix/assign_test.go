@@ -0,0 +1,93 @@
+package zabbix
+
+import (
+	"context"
+	"testing"
+)
+
+func TestProblem_Assignee(t *testing.T) {
+	tests := []struct {
+		name         string
+		acks         []Ack
+		wantAssignee string
+		wantInitials string
+	}{
+		{name: "no updates"},
+		{
+			name:         "first acknowledger",
+			acks:         []Ack{{Username: "jdoe", Name: "John", Surname: "Doe", Action: "2", Clock: "100"}, {Username: "asmith", Action: "6", Clock: "200"}},
+			wantAssignee: "jdoe",
+			wantInitials: "JD",
+		},
+		{
+			name: "assigned, newest first",
+			acks: []Ack{
+				{Username: "jdoe", Action: "4", Message: "assign: asmith taking over", Clock: "300"},
+				{Username: "jdoe", Action: "4", Message: "assign: bwayne", Clock: "200"},
+				{Username: "jdoe", Action: "2", Clock: "100"},
+			},
+			wantAssignee: "asmith",
+			wantInitials: "AS",
+		},
+		{
+			name: "unassigned",
+			acks: []Ack{
+				{Username: "jdoe", Action: "2", Clock: "100"},
+				{Username: "jdoe", Action: "4", Message: "unassign: off shift", Clock: "200"},
+			},
+		},
+		{
+			name:         "not a message of ours",
+			acks:         []Ack{{Username: "jdoe", Action: "6", Message: "assign:", Clock: "100"}},
+			wantAssignee: "jdoe",
+			wantInitials: "JD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Problem{Acknowledges: tt.acks}
+			if got := p.Assignee(); got != tt.wantAssignee {
+				t.Errorf("Assignee() = %q, want %q", got, tt.wantAssignee)
+			}
+			if got := p.AssigneeInitials(); got != tt.wantInitials {
+				t.Errorf("AssigneeInitials() = %q, want %q", got, tt.wantInitials)
+			}
+		})
+	}
+
+	if (&Problem{}).AssignedTo("") {
+		t.Error("AssignedTo(\"\") should be false")
+	}
+}
+
+func TestAssignMessage(t *testing.T) {
+	if got := AssignMessage("jdoe", ""); got != "assign: jdoe" {
+		t.Errorf("AssignMessage() = %q", got)
+	}
+	if got := UnassignMessage("off shift"); got != "unassign: off shift" {
+		t.Errorf("UnassignMessage() = %q", got)
+	}
+	for _, message := range []string{AssignMessage("jdoe", "note"), UnassignMessage("")} {
+		if _, ok := parseAssignment(message); !ok {
+			t.Errorf("parseAssignment(%q) not recognized", message)
+		}
+	}
+}
+
+func TestClient_AddProblemsMessage(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"event.acknowledge": {Result: map[string]any{"eventids": []string{"1", "2"}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.AddProblemsMessage(context.Background(), []string{"1", "2"}, "assign: jdoe"); err != nil {
+		t.Fatalf("AddProblemsMessage() error = %v", err)
+	}
+	got := params["event.acknowledge"]
+	if got["action"] != float64(ActionAddMessage) || got["message"] != "assign: jdoe" {
+		t.Errorf("event.acknowledge params = %v, want only a message", got)
+	}
+}
@@ -0,0 +1,178 @@
+package zabbix
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Capabilities describes the API differences between Zabbix versions that
+// the client adapts to. The zero value describes Zabbix 5.0.
+type Capabilities struct {
+	Version string
+
+	// APITokens: API tokens exist (5.4+)
+	APITokens bool
+	// LoginUsername: user.login takes "username" instead of "user" (5.4+)
+	LoginUsername bool
+	// InterfaceAvailability: availability is reported per interface
+	// rather than per host (5.2+)
+	InterfaceAvailability bool
+	// HostGroupsSelect: host.get, trigger.get and maintenance.get take
+	// selectHostGroups and return "hostgroups" instead of selectGroups and
+	// "groups" (6.2+)
+	HostGroupsSelect bool
+	// BearerAuth: the token is sent in the Authorization header instead
+	// of the "auth" request field (6.4+)
+	BearerAuth bool
+	// AuthHeaderOnly: the "auth" request field is gone, so the token can
+	// only be sent in the Authorization header (7.2+)
+	AuthHeaderOnly bool
+	// ActiveAvailability: hosts report active agent availability (6.4+)
+	ActiveAvailability bool
+	// MaintenanceHosts: maintenance.create takes "hosts" objects instead
+	// of "hostids" (6.0+)
+	MaintenanceHosts bool
+	// TaskRequests: task.create takes tasks with a "request" object
+	// instead of "itemids" (5.2+)
+	TaskRequests bool
+	// DashboardPages: dashboards have pages of widgets instead of widgets
+	// (5.4+)
+	DashboardPages bool
+	// UserRoles: users have a role giving their type and allowed actions,
+	// instead of a type (5.2+)
+	UserRoles bool
+	// ExecuteNowAction: roles may allow users without write access to
+	// check items now (6.4+)
+	ExecuteNowAction bool
+	// ManualSuppression: users may suppress problems, until a time or
+	// indefinitely, through event.acknowledge (6.2+)
+	ManualSuppression bool
+	// MonitoredBy: hosts are assigned to a proxy with "monitored_by" and
+	// "proxyid" instead of "proxy_hostid" (7.0+)
+	MonitoredBy bool
+	// ConfigurationYAML: configuration.export and configuration.import
+	// take YAML (5.2+)
+	ConfigurationYAML bool
+	// Settings: the global settings, including severity names, are read
+	// with settings.get (5.2+)
+	Settings bool
+}
+
+// latestCapabilities is assumed until the server version is known.
+var latestCapabilities = CapabilitiesFor("7.0")
+
+// CapabilitiesFor returns the capabilities of a Zabbix version such as "6.0.21".
+func CapabilitiesFor(version string) Capabilities {
+	return Capabilities{
+		Version:               version,
+		APITokens:             VersionAtLeast(version, 5, 4),
+		LoginUsername:         VersionAtLeast(version, 5, 4),
+		InterfaceAvailability: VersionAtLeast(version, 5, 2),
+		HostGroupsSelect:      VersionAtLeast(version, 6, 2),
+		BearerAuth:            VersionAtLeast(version, 6, 4),
+		AuthHeaderOnly:        VersionAtLeast(version, 7, 2),
+		ActiveAvailability:    VersionAtLeast(version, 6, 4),
+		MaintenanceHosts:      VersionAtLeast(version, 6, 0),
+		TaskRequests:          VersionAtLeast(version, 5, 2),
+		DashboardPages:        VersionAtLeast(version, 5, 4),
+		UserRoles:             VersionAtLeast(version, 5, 2),
+		ExecuteNowAction:      VersionAtLeast(version, 6, 4),
+		ManualSuppression:     VersionAtLeast(version, 6, 2),
+		MonitoredBy:           VersionAtLeast(version, 7, 0),
+		ConfigurationYAML:     VersionAtLeast(version, 5, 2),
+		Settings:              VersionAtLeast(version, 5, 2),
+	}
+}
+
+// VersionAtLeast reports whether a Zabbix version string such as "6.4.12"
+// is at least major.minor. Unparsable versions are treated as too old.
+func VersionAtLeast(version string, major, minor int) bool {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	gotMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	gotMinor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return gotMajor > major || (gotMajor == major && gotMinor >= minor)
+}
+
+// Negotiate queries the server version and adapts subsequent calls to it.
+// It should be called before logging in. Until then the client assumes the
+// latest supported version.
+func (c *Client) Negotiate(ctx context.Context) (Capabilities, error) {
+	version, err := c.Version(ctx)
+	if err != nil {
+		return Capabilities{}, err
+	}
+	caps := CapabilitiesFor(version)
+	c.caps.Store(&caps)
+	return caps, nil
+}
+
+// Capabilities returns the capabilities the client uses for API calls.
+func (c *Client) Capabilities() Capabilities {
+	if caps := c.caps.Load(); caps != nil {
+		return *caps
+	}
+	return latestCapabilities
+}
+
+// adaptHostParams rewrites host.get parameters for older servers.
+func (c *Client) adaptHostParams(params HostGetParams) HostGetParams {
+	caps := c.Capabilities()
+	if !caps.HostGroupsSelect && params.SelectHostGroups != nil {
+		params.SelectGroups = params.SelectHostGroups
+		params.SelectHostGroups = nil
+	}
+	if output, ok := params.Output.([]string); ok {
+		if !caps.ActiveAvailability {
+			output = without(output, "active_available")
+		}
+		if !caps.InterfaceAvailability && params.SelectInterfaces != nil {
+			// Availability is a host property before 5.2
+			output = append(output, "available")
+			if fields, ok := params.SelectInterfaces.([]string); ok {
+				for _, f := range interfaceErrorFields {
+					if slices.Contains(fields, f) {
+						output = append(output, f)
+					}
+				}
+			}
+		}
+		params.Output = output
+	}
+	if fields, ok := params.SelectInterfaces.([]string); ok && !caps.InterfaceAvailability {
+		fields = without(fields, "available")
+		for _, f := range interfaceErrorFields {
+			fields = without(fields, f)
+		}
+		params.SelectInterfaces = fields
+	}
+	return params
+}
+
+// interfaceErrorFields are the interface fields describing why it is
+// unavailable, host properties of the agent interface before 5.2.
+var interfaceErrorFields = []string{"error", "errors_from", "disable_until"}
+
+// adaptTriggerParams rewrites trigger.get parameters for older servers.
+func (c *Client) adaptTriggerParams(params TriggerGetParams) TriggerGetParams {
+	if !c.Capabilities().HostGroupsSelect && params.SelectHostGroups != nil {
+		params.SelectGroups = params.SelectHostGroups
+		params.SelectHostGroups = nil
+	}
+	return params
+}
+
+// without returns a copy of fields with name removed.
+func without(fields []string, name string) []string {
+	return slices.DeleteFunc(slices.Clone(fields), func(f string) bool { return f == name })
+}
@@ -0,0 +1,228 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestCapabilitiesFor(t *testing.T) {
+	tests := []struct {
+		version string
+		want    Capabilities
+	}{
+		{"5.0.40", Capabilities{Version: "5.0.40"}},
+		{"5.2.7", Capabilities{Version: "5.2.7", InterfaceAvailability: true, TaskRequests: true, UserRoles: true, ConfigurationYAML: true, Settings: true}},
+		{"6.0.21", Capabilities{
+			Version: "6.0.21", APITokens: true, LoginUsername: true, InterfaceAvailability: true,
+			MaintenanceHosts: true, TaskRequests: true, DashboardPages: true, UserRoles: true,
+			ConfigurationYAML: true, Settings: true,
+		}},
+		{"6.2.0", Capabilities{
+			Version: "6.2.0", APITokens: true, LoginUsername: true, InterfaceAvailability: true,
+			HostGroupsSelect: true, MaintenanceHosts: true, TaskRequests: true, DashboardPages: true,
+			UserRoles: true, ManualSuppression: true, ConfigurationYAML: true, Settings: true,
+		}},
+		{"7.0.3", Capabilities{
+			Version: "7.0.3", APITokens: true, LoginUsername: true, InterfaceAvailability: true,
+			HostGroupsSelect: true, BearerAuth: true, ActiveAvailability: true, MaintenanceHosts: true,
+			TaskRequests: true, DashboardPages: true, UserRoles: true, ExecuteNowAction: true,
+			ManualSuppression: true, MonitoredBy: true, ConfigurationYAML: true, Settings: true,
+		}},
+	}
+
+	for _, tt := range tests {
+		if got := CapabilitiesFor(tt.version); got != tt.want {
+			t.Errorf("CapabilitiesFor(%q) = %+v, want %+v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version      string
+		major, minor int
+		want         bool
+	}{
+		{"5.4.0", 5, 4, true},
+		{"6.0.21", 5, 4, true},
+		{"5.2.7", 5, 4, false},
+		{"4.0.0", 5, 4, false},
+		{"7.0", 7, 0, true},
+		{"", 5, 4, false},
+		{"x.y", 5, 4, false},
+	}
+
+	for _, tt := range tests {
+		if got := VersionAtLeast(tt.version, tt.major, tt.minor); got != tt.want {
+			t.Errorf("VersionAtLeast(%q, %d, %d) = %v, want %v", tt.version, tt.major, tt.minor, got, tt.want)
+		}
+	}
+}
+
+// rawRequest is a request as seen by the server.
+type rawRequest struct {
+	Method string
+	Body   map[string]any
+	Header http.Header
+}
+
+// newVersionServer returns a server reporting the given version and
+// recording every request.
+func newVersionServer(t *testing.T, version string, requests *[]rawRequest) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var body map[string]any
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+		method, _ := body["method"].(string)
+		*requests = append(*requests, rawRequest{Method: method, Body: body, Header: r.Header.Clone()})
+
+		var result any = []any{}
+		switch method {
+		case "apiinfo.version":
+			result = version
+		case "user.login":
+			result = "session"
+		case "task.create":
+			result = map[string][]string{"taskids": {"1"}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": body["id"], "result": result})
+	}))
+}
+
+func TestNegotiate_LegacyServer(t *testing.T) {
+	var requests []rawRequest
+	server := newVersionServer(t, "5.0.40", &requests)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if client.Capabilities() != latestCapabilities {
+		t.Error("expected the latest capabilities before negotiation")
+	}
+
+	caps, err := client.Negotiate(context.Background())
+	if err != nil {
+		t.Fatalf("Negotiate() error = %v", err)
+	}
+	if caps.Version != "5.0.40" || client.Capabilities() != caps {
+		t.Fatalf("capabilities = %+v, want those of 5.0.40", client.Capabilities())
+	}
+
+	if err := client.Login(context.Background(), "Admin", "zabbix"); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if _, err := client.GetAllHosts(context.Background()); err != nil {
+		t.Fatalf("GetAllHosts() error = %v", err)
+	}
+
+	login := requests[1].Body["params"].(map[string]any)
+	if login["user"] != "Admin" || login["username"] != nil {
+		t.Errorf("user.login params = %v, want the 5.0 \"user\" field", login)
+	}
+
+	hostGet := requests[2]
+	if hostGet.Body["auth"] != "session" || hostGet.Header.Get("Authorization") != "" {
+		t.Error("expected the token in the request body, not the Authorization header")
+	}
+	params := hostGet.Body["params"].(map[string]any)
+	if params["selectGroups"] == nil || params["selectHostGroups"] != nil {
+		t.Errorf("host.get params = %v, want selectGroups", params)
+	}
+	output := toStrings(params["output"])
+	if slices.Contains(output, "active_available") || !slices.Contains(output, "available") {
+		t.Errorf("host.get output = %v, want host availability without active_available", output)
+	}
+	if slices.Contains(toStrings(params["selectInterfaces"]), "available") {
+		t.Error("interfaces have no availability before 5.2")
+	}
+}
+
+func TestNegotiate_CurrentServer(t *testing.T) {
+	var requests []rawRequest
+	server := newVersionServer(t, "7.0.3", &requests)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if _, err := client.Negotiate(context.Background()); err != nil {
+		t.Fatalf("Negotiate() error = %v", err)
+	}
+	client.SetToken("api-token")
+	if _, err := client.GetAllHosts(context.Background()); err != nil {
+		t.Fatalf("GetAllHosts() error = %v", err)
+	}
+
+	hostGet := requests[1]
+	if hostGet.Header.Get("Authorization") != "Bearer api-token" || hostGet.Body["auth"] != nil {
+		t.Error("expected the token in the Authorization header only")
+	}
+	params := hostGet.Body["params"].(map[string]any)
+	if params["selectHostGroups"] == nil || params["selectGroups"] != nil {
+		t.Errorf("host.get params = %v, want selectHostGroups", params)
+	}
+	if !slices.Contains(toStrings(params["output"]), "active_available") {
+		t.Error("expected active_available in the host output")
+	}
+	if requests[0].Header.Get("Authorization") != "" || requests[0].Body["auth"] != nil {
+		t.Error("apiinfo.version must be sent without authentication")
+	}
+}
+
+func TestHost_UnmarshalLegacyFields(t *testing.T) {
+	data := `{
+		"hostid": "1",
+		"proxy_hostid": "9",
+		"available": "2",
+		"error": "Connection refused",
+		"groups": [{"groupid": "4", "name": "Linux servers"}],
+		"interfaces": [{"type": "1", "ip": "10.0.0.1"}, {"type": "2", "ip": "10.0.0.1"}]
+	}`
+	var h Host
+	if err := json.Unmarshal([]byte(data), &h); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(h.Groups) != 1 || h.Groups[0].Name != "Linux servers" {
+		t.Errorf("Groups = %v, want Linux servers", h.Groups)
+	}
+	if h.ProxyID != "9" {
+		t.Errorf("ProxyID = %q, want 9", h.ProxyID)
+	}
+	if h.Interfaces[0].Available != "2" || h.Interfaces[1].Available != "" {
+		t.Errorf("interfaces = %+v, want host availability on the agent interface only", h.Interfaces)
+	}
+	if h.Interfaces[0].Error != "Connection refused" {
+		t.Errorf("agent interface error = %q, want the host error", h.Interfaces[0].Error)
+	}
+}
+
+func TestHost_UnmarshalHostGroups(t *testing.T) {
+	data := `{"hostid": "1", "proxyid": "3", "hostgroups": [{"groupid": "4", "name": "Databases"}]}`
+	var h Host
+	if err := json.Unmarshal([]byte(data), &h); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(h.Groups) != 1 || h.Groups[0].Name != "Databases" {
+		t.Errorf("Groups = %v, want the 6.2+ hostgroups", h.Groups)
+	}
+	if h.ProxyID != "3" {
+		t.Errorf("ProxyID = %q, want 3", h.ProxyID)
+	}
+}
+
+// toStrings converts a decoded JSON array of strings.
+func toStrings(v any) []string {
+	items, _ := v.([]any)
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		s, _ := item.(string)
+		out = append(out, s)
+	}
+	return out
+}
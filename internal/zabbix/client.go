@@ -2,13 +2,17 @@
 package zabbix
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -21,6 +25,37 @@ type Client struct {
 	tokenMu    sync.RWMutex
 	token      string // API token or session token
 	requestID  int64
+	observer   func(CallInfo)
+	caps       atomic.Pointer[Capabilities] // Set by Negotiate
+	// Gzip request bodies of at least minCompressSize bytes
+	compressRequests bool
+	// Per-call deadlines by method, and for other methods when set; see
+	// WithMethodTimeouts
+	methodTimeouts map[string]time.Duration
+	timeout        time.Duration
+	// HTTP basic auth credentials for a proxy in front of the frontend; see
+	// WithBasicAuth
+	basicUsername, basicPassword string
+}
+
+// minCompressSize is the smallest request body compressed by
+// WithRequestCompression; smaller bodies gain too little to be worth it.
+const minCompressSize = 1024
+
+// responseReaders holds the buffered readers responses are decoded through,
+// so that their buffers are reused from call to call instead of allocated
+// for each response.
+var responseReaders = sync.Pool{
+	New: func() any { return bufio.NewReaderSize(nil, 32<<10) },
+}
+
+// CallInfo describes a completed API call, for debug logging and metrics.
+type CallInfo struct {
+	Method        string
+	Duration      time.Duration
+	RequestBytes  int
+	ResponseBytes int
+	Err           error
 }
 
 // Request represents a JSON-RPC request to the Zabbix API.
@@ -28,6 +63,7 @@ type Request struct {
 	JSONRPC string      `json:"jsonrpc"`
 	Method  string      `json:"method"`
 	Params  interface{} `json:"params"`
+	Auth    string      `json:"auth,omitempty"` // Token for servers without Authorization header support
 	ID      int64       `json:"id"`
 }
 
@@ -63,6 +99,15 @@ func WithTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
+// WithMethodTimeouts sets how long calls of the given methods may take, e.g.
+// longer for history.get than for event.acknowledge. Other methods keep the
+// client's timeout.
+func WithMethodTimeouts(timeouts map[string]time.Duration) ClientOption {
+	return func(c *Client) {
+		c.methodTimeouts = timeouts
+	}
+}
+
 // WithInsecureSkipVerify disables TLS certificate verification.
 func WithInsecureSkipVerify() ClientOption {
 	return func(c *Client) {
@@ -75,6 +120,53 @@ func WithInsecureSkipVerify() ClientOption {
 	}
 }
 
+// WithRequestCompression gzips large request bodies. The web server in
+// front of the Zabbix frontend must decompress them, which e.g. Apache does
+// with "SetInputFilter DEFLATE", so it is off by default. Responses are
+// compressed regardless when the server supports it, as the default
+// transport asks for gzip and transparently decodes it.
+func WithRequestCompression() ClientOption {
+	return func(c *Client) {
+		c.compressRequests = true
+	}
+}
+
+// WithBasicAuth sends HTTP basic auth credentials with every request, for
+// frontends behind a proxy that requires them. They are in addition to the
+// API token or session, which then goes in the request body as the
+// Authorization header is taken.
+func WithBasicAuth(username, password string) ClientOption {
+	return func(c *Client) {
+		c.basicUsername = username
+		c.basicPassword = password
+	}
+}
+
+// WithCallObserver registers a function that is called after every API call.
+// The function may be called concurrently from multiple goroutines.
+func WithCallObserver(fn func(CallInfo)) ClientOption {
+	return func(c *Client) {
+		c.observer = fn
+	}
+}
+
+// WithTransport replaces the HTTP transport, e.g. to serve requests from
+// something other than a real server. WithInsecureSkipVerify has no effect
+// on a custom transport.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Transport = rt
+	}
+}
+
+// WrapTransport wraps the HTTP transport, e.g. to record the traffic. It
+// wraps the transport set by earlier options.
+func WrapTransport(wrap func(http.RoundTripper) http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Transport = wrap(c.httpClient.Transport)
+	}
+}
+
 // NewClient creates a new Zabbix API client.
 func NewClient(baseURL string, opts ...ClientOption) *Client {
 	c := &Client{
@@ -91,9 +183,25 @@ func NewClient(baseURL string, opts ...ClientOption) *Client {
 		opt(c)
 	}
 
+	if len(c.methodTimeouts) > 0 {
+		// Deadlines are set per call, so methods may take longer than the
+		// client's timeout
+		c.timeout = c.httpClient.Timeout
+		c.httpClient.Timeout = 0
+	}
+
 	return c
 }
 
+// callTimeout returns the deadline of a call of method, or 0 when the HTTP
+// client's timeout applies.
+func (c *Client) callTimeout(method string) time.Duration {
+	if timeout, ok := c.methodTimeouts[method]; ok {
+		return timeout
+	}
+	return c.timeout
+}
+
 // SetToken sets the API token for authentication.
 func (c *Client) SetToken(token string) {
 	c.tokenMu.Lock()
@@ -124,7 +232,21 @@ func (c *Client) callNoAuth(ctx context.Context, method string, params, result i
 }
 
 // callWithAuth makes a JSON-RPC call to the Zabbix API with optional authentication.
-func (c *Client) callWithAuth(ctx context.Context, method string, params, result interface{}, useAuth bool) error {
+func (c *Client) callWithAuth(ctx context.Context, method string, params, result interface{}, useAuth bool) (err error) {
+	var reqSize, respSize int
+	if c.observer != nil {
+		start := time.Now()
+		defer func() {
+			c.observer(CallInfo{
+				Method:        method,
+				Duration:      time.Since(start),
+				RequestBytes:  reqSize,
+				ResponseBytes: respSize,
+				Err:           err,
+			})
+		}()
+	}
+
 	req := Request{
 		JSONRPC: "2.0",
 		Method:  method,
@@ -132,10 +254,41 @@ func (c *Client) callWithAuth(ctx context.Context, method string, params, result
 		ID:      c.nextID(),
 	}
 
+	token := ""
+	if useAuth {
+		token = c.getToken()
+	}
+	// Basic auth takes the Authorization header, leaving only the "auth"
+	// field for the token, which Zabbix 7.2 removed
+	caps := c.Capabilities()
+	bearer := caps.BearerAuth && c.basicUsername == ""
+	if token != "" && !bearer {
+		if caps.AuthHeaderOnly {
+			return ErrBasicAuthToken
+		}
+		req.Auth = token
+	}
+
 	body, err := json.Marshal(req)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
+	compressed := c.compressRequests && len(body) >= minCompressSize
+	if compressed {
+		if body, err = gzipBytes(body); err != nil {
+			return fmt.Errorf("failed to compress request: %w", err)
+		}
+	}
+	reqSize = len(body)
+
+	// The call gets a deadline of its own when timeouts are set by method
+	parent := ctx
+	timeout := c.callTimeout(method)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL, bytes.NewReader(body))
 	if err != nil {
@@ -143,49 +296,166 @@ func (c *Client) callWithAuth(ctx context.Context, method string, params, result
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json-rpc")
-	if useAuth {
-		if token := c.getToken(); token != "" {
-			httpReq.Header.Set("Authorization", "Bearer "+token)
-		}
+	if compressed {
+		httpReq.Header.Set("Content-Encoding", "gzip")
+	}
+	if token != "" && bearer {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+	if c.basicUsername != "" {
+		httpReq.SetBasicAuth(c.basicUsername, c.basicPassword)
 	}
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
+		if timeout > 0 && errors.Is(err, context.DeadlineExceeded) && parent.Err() == nil {
+			return fmt.Errorf("%s timed out after %s: %w", method, timeout, err)
+		}
 		return fmt.Errorf("failed to execute request: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
-	}
+	respBody := &countingReader{r: resp.Body}
+	defer func() {
+		// Drain what the decoder left so the connection can be reused
+		_, _ = io.Copy(io.Discard, respBody)
+		_ = resp.Body.Close()
+		respSize = int(respBody.n)
+	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return &StatusError{Code: resp.StatusCode}
 	}
 
-	var apiResp Response
-	if err := json.Unmarshal(respBody, &apiResp); err != nil {
-		return fmt.Errorf("failed to unmarshal response: %w", err)
+	br := responseReaders.Get().(*bufio.Reader)
+	br.Reset(respBody)
+	defer func() {
+		br.Reset(nil)
+		responseReaders.Put(br)
+	}()
+	return decodeResponse(br, result)
+}
+
+// gzipBytes returns data compressed with gzip.
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
 	}
+	return buf.Bytes(), nil
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
 
-	if apiResp.Error != nil {
-		return apiResp.Error
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// decodeResponse decodes a JSON-RPC response from r, decoding its result
+// straight into result rather than buffering the whole response first. Array
+// results are decoded one element at a time when result points to a slice,
+// so a large event.get or host.get response never sits in memory as both
+// JSON and Go values.
+func decodeResponse(r io.Reader, result interface{}) error {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("failed to unmarshal response: %w", tokenError(tok, err))
 	}
 
-	if result != nil {
-		if err := json.Unmarshal(apiResp.Result, result); err != nil {
-			return fmt.Errorf("failed to unmarshal result: %w", err)
+	var apiErr *APIError
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		switch tok {
+		case "error":
+			err = dec.Decode(&apiErr)
+		case "result":
+			if result == nil {
+				err = dec.Decode(&json.RawMessage{})
+			} else if err = decodeResult(dec, result); err != nil {
+				return fmt.Errorf("failed to unmarshal result: %w", err)
+			}
+		default:
+			err = dec.Decode(&json.RawMessage{})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
 		}
 	}
 
+	if apiErr != nil {
+		return apiErr
+	}
 	return nil
 }
 
+// decodeResult decodes the next JSON value of dec into result, streaming
+// the elements of an array into a slice.
+func decodeResult(dec *json.Decoder, result interface{}) error {
+	v := reflect.ValueOf(result)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return dec.Decode(result)
+	}
+	if _, ok := result.(json.Unmarshaler); ok {
+		return dec.Decode(result)
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	slice := v.Elem()
+	if tok == nil {
+		slice.SetZero()
+		return nil
+	}
+	if tok != json.Delim('[') {
+		return tokenError(tok, nil)
+	}
+
+	// Elements are decoded in place, into the backing array of result when
+	// it has room, rather than into a new value copied into the slice
+	elems := slice.Slice(0, 0)
+	zero := reflect.Zero(slice.Type().Elem())
+	for dec.More() {
+		elems = reflect.Append(elems, zero)
+		if err := dec.Decode(elems.Index(elems.Len() - 1).Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	slice.Set(elems)
+	return nil
+}
+
+// tokenError describes an unexpected JSON token, or the error reading it.
+func tokenError(tok json.Token, err error) error {
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("unexpected %v", tok)
+}
+
 // Login authenticates with username and password and stores the session token.
 func (c *Client) Login(ctx context.Context, username, password string) error {
+	userField := "username"
+	if !c.Capabilities().LoginUsername {
+		userField = "user"
+	}
 	params := map[string]string{
-		"username": username,
+		userField:  username,
 		"password": password,
 	}
 
@@ -223,6 +493,16 @@ func (c *Client) Version(ctx context.Context) (string, error) {
 	return version, nil
 }
 
+// Ping measures the round-trip time of apiinfo.version, the lightest call
+// of the API, as a health check of the server.
+func (c *Client) Ping(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	if _, err := c.Version(ctx); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
 // IsConnected checks if the client can communicate with Zabbix.
 func (c *Client) IsConnected(ctx context.Context) bool {
 	_, err := c.Version(ctx)
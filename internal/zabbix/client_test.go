@@ -1,9 +1,17 @@
 package zabbix
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -77,6 +85,21 @@ func TestClient_Version(t *testing.T) {
 	}
 }
 
+func TestClient_Ping(t *testing.T) {
+	server := newMockServer(t, map[string]mockResponse{
+		"apiinfo.version": {Result: "7.0.0"},
+	})
+	defer server.Close()
+
+	latency, err := newTestClient(t, server.URL).Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	if latency <= 0 {
+		t.Errorf("Ping() = %v, want a round-trip time", latency)
+	}
+}
+
 func TestClient_Version_Error(t *testing.T) {
 	server := newMockServer(t, map[string]mockResponse{
 		"apiinfo.version": {
@@ -97,6 +120,38 @@ func TestClient_Version_Error(t *testing.T) {
 	}
 }
 
+func TestClient_CallObserver(t *testing.T) {
+	server := newMockServer(t, map[string]mockResponse{
+		"apiinfo.version": {Result: "7.0.0"},
+		"host.get":        {Error: &APIError{Code: -32602, Message: "Invalid params"}},
+	})
+	defer server.Close()
+
+	var calls []CallInfo
+	client := newTestClient(t, server.URL)
+	WithCallObserver(func(info CallInfo) { calls = append(calls, info) })(client)
+
+	if _, err := client.Version(context.Background()); err != nil {
+		t.Fatalf("Version() error = %v", err)
+	}
+	if _, err := client.GetHosts(context.Background(), HostGetParams{}); err == nil {
+		t.Fatal("GetHosts() expected error")
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("observer called %d times, want 2", len(calls))
+	}
+	if calls[0].Method != "apiinfo.version" || calls[0].Err != nil {
+		t.Errorf("calls[0] = %+v, want successful apiinfo.version", calls[0])
+	}
+	if calls[0].RequestBytes == 0 || calls[0].ResponseBytes == 0 {
+		t.Errorf("calls[0] sizes = %d/%d, want non-zero", calls[0].RequestBytes, calls[0].ResponseBytes)
+	}
+	if calls[1].Method != "host.get" || calls[1].Err == nil {
+		t.Errorf("calls[1] = %+v, want failed host.get", calls[1])
+	}
+}
+
 func TestClient_IsConnected(t *testing.T) {
 	server := newMockServer(t, map[string]mockResponse{
 		"apiinfo.version": {Result: "7.0.0"},
@@ -191,6 +246,223 @@ func TestClient_Logout_NoToken(t *testing.T) {
 	}
 }
 
+func TestClient_Compression(t *testing.T) {
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := io.Reader(r.Body)
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("failed to decompress request: %v", err)
+				return
+			}
+			body = zr
+		}
+		var req Request
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			t.Error("request does not accept gzip responses")
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		_ = json.NewEncoder(zw).Encode(map[string]any{"jsonrpc": "2.0", "result": []Host{{HostID: "1"}}, "id": req.ID})
+		_ = zw.Close()
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	WithRequestCompression()(client)
+
+	// Small requests are sent as they are
+	if _, err := client.GetHosts(context.Background(), HostGetParams{}); err != nil {
+		t.Fatalf("GetHosts() error = %v", err)
+	}
+	params := HostGetParams{HostIDs: make([]string, 500)}
+	for i := range params.HostIDs {
+		params.HostIDs[i] = strconv.Itoa(10000 + i)
+	}
+	hosts, err := client.GetHosts(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetHosts() error = %v", err)
+	}
+	if len(hosts) != 1 || hosts[0].HostID != "1" {
+		t.Errorf("hosts = %+v, want host 1 from the gzipped response", hosts)
+	}
+	if len(encodings) != 2 || encodings[0] != "" || encodings[1] != "gzip" {
+		t.Errorf("request encodings = %q, want only the large request gzipped", encodings)
+	}
+}
+
+func TestClient_BasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+		if user, password, ok := r.BasicAuth(); !ok || user != "proxy" || password != "pass" {
+			t.Errorf("basic auth = %q, %q, %v; want proxy, pass", user, password, ok)
+		}
+		// The token can't share the Authorization header
+		if req.Auth != "secret" {
+			t.Errorf("request auth = %q, want the token in the body", req.Auth)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "result": "3", "id": req.ID})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	WithBasicAuth("proxy", "pass")(client)
+	client.caps.Store(&Capabilities{BearerAuth: true})
+	client.SetToken("secret")
+	if err := client.CheckAuth(context.Background()); err != nil {
+		t.Fatalf("CheckAuth() error = %v", err)
+	}
+
+	// Zabbix 7.2 has no auth field left for the token
+	caps := CapabilitiesFor("7.2.0")
+	client.caps.Store(&caps)
+	if err := client.CheckAuth(context.Background()); !errors.Is(err, ErrBasicAuthToken) {
+		t.Errorf("CheckAuth() on 7.2 error = %v, want ErrBasicAuthToken", err)
+	}
+}
+
+func TestClient_MethodTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "result": []any{}, "id": req.ID})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL,
+		WithTimeout(50*time.Millisecond),
+		WithMethodTimeouts(map[string]time.Duration{"history.get": 5 * time.Second}))
+
+	var history []History
+	if err := client.call(context.Background(), "history.get", map[string]any{}, &history); err != nil {
+		t.Errorf("history.get error = %v, want its longer timeout to apply", err)
+	}
+	var items []Item
+	err := client.call(context.Background(), "item.get", map[string]any{}, &items)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "item.get timed out after 50ms") {
+		t.Errorf("item.get error = %v, want it to time out after 50ms", err)
+	}
+}
+
+func TestDecodeResponse(t *testing.T) {
+	t.Run("slice", func(t *testing.T) {
+		hosts := []Host{{HostID: "stale", Name: "stale"}, {HostID: "stale"}, {HostID: "stale"}}
+		first := &hosts[0]
+		body := `{"jsonrpc":"2.0","result":[{"hostid":"1"},{"hostid":"2"}],"id":1}`
+		if err := decodeResponse(strings.NewReader(body), &hosts); err != nil {
+			t.Fatalf("decodeResponse() error = %v", err)
+		}
+		if len(hosts) != 2 || hosts[0].HostID != "1" || hosts[1].HostID != "2" || hosts[0].Name != "" {
+			t.Errorf("hosts = %+v, want hosts 1 and 2 only", hosts)
+		}
+		if &hosts[0] != first {
+			t.Error("hosts were decoded into a new array, want the one of the slice reused")
+		}
+	})
+
+	t.Run("null slice", func(t *testing.T) {
+		hosts := []Host{{HostID: "stale"}}
+		if err := decodeResponse(strings.NewReader(`{"result":null}`), &hosts); err != nil {
+			t.Fatalf("decodeResponse() error = %v", err)
+		}
+		if hosts != nil {
+			t.Errorf("hosts = %+v, want nil", hosts)
+		}
+	})
+
+	t.Run("scalar", func(t *testing.T) {
+		var version string
+		if err := decodeResponse(strings.NewReader(`{"id":1,"result":"7.0.0"}`), &version); err != nil {
+			t.Fatalf("decodeResponse() error = %v", err)
+		}
+		if version != "7.0.0" {
+			t.Errorf("version = %q, want 7.0.0", version)
+		}
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		body := `{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params","data":"No permissions"},"id":1}`
+		err := decodeResponse(strings.NewReader(body), &[]Host{})
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != -32602 {
+			t.Errorf("decodeResponse() error = %v, want API error -32602", err)
+		}
+	})
+
+	t.Run("object into slice", func(t *testing.T) {
+		if err := decodeResponse(strings.NewReader(`{"result":{"1":{}}}`), &[]Host{}); err == nil {
+			t.Error("decodeResponse() expected error for an object result")
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		if err := decodeResponse(strings.NewReader(`{"result":[{"hostid":"1"},`), &[]Host{}); err == nil {
+			t.Error("decodeResponse() expected error for truncated response")
+		}
+	})
+}
+
+func TestErrorKinds(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		kinds []error
+	}{
+		{"expired session", &APIError{Code: -32602, Data: "Session terminated, re-login, please."}, []error{ErrAuth}},
+		{"bad token", &APIError{Code: -32602, Data: "Not authorized."}, []error{ErrAuth}},
+		{"hidden object", &APIError{Code: -32500, Data: "No permissions to referred object or it does not exist!"}, []error{ErrPermission, ErrNotFound}},
+		{"denied method", &APIError{Code: -32500, Data: `No permissions to call "user.update".`}, []error{ErrPermission}},
+		{"other API error", &APIError{Code: -32602, Data: "Invalid parameter \"/1\": unexpected parameter \"x\"."}, nil},
+		{"unauthorized status", &StatusError{Code: 401}, []error{ErrAuth}},
+		{"too many requests", &StatusError{Code: 429}, []error{ErrRateLimited}},
+		{"server error", &StatusError{Code: 500}, nil},
+		{"unavailable", &StatusError{Code: 503}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("failed to get hosts: %w", tt.err)
+			for _, kind := range []error{ErrAuth, ErrPermission, ErrNotFound, ErrRateLimited} {
+				want := slices.Contains(tt.kinds, kind)
+				if got := errors.Is(err, kind); got != want {
+					t.Errorf("errors.Is(%v, %v) = %v, want %v", err, kind, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_GetHost_NotFoundKind(t *testing.T) {
+	server := newMockServer(t, map[string]mockResponse{
+		"host.get": {Result: []Host{}},
+	})
+	defer server.Close()
+
+	_, err := newTestClient(t, server.URL).GetHost(context.Background(), "10101")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetHost() error = %v, want ErrNotFound", err)
+	}
+}
+
 func TestAPIError_Error(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,162 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+)
+
+// GroupRef refers to a host group by ID.
+type GroupRef struct {
+	GroupID string `json:"groupid"`
+}
+
+// TemplateRef refers to a template by ID.
+type TemplateRef struct {
+	TemplateID string `json:"templateid"`
+}
+
+// HostCreateParams defines parameters for host.create API call.
+type HostCreateParams struct {
+	Host        string           `json:"host"`
+	Status      string           `json:"status,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Groups      []GroupRef       `json:"groups"`
+	Templates   []TemplateRef    `json:"templates,omitempty"`
+	Macros      []HostMacro      `json:"macros,omitempty"`
+	Interfaces  []map[string]any `json:"interfaces,omitempty"`
+	Tags        []Tag            `json:"tags,omitempty"`
+	ProxyHostID string           `json:"proxy_hostid,omitempty"` // Before Zabbix 7.0
+	ProxyID     string           `json:"proxyid,omitempty"`      // Zabbix 7.0+, with MonitoredBy
+	MonitoredBy string           `json:"monitored_by,omitempty"` // Zabbix 7.0+: 1 = proxy
+}
+
+// hostCreateResult represents the result of a host.create API call.
+type hostCreateResult struct {
+	HostIDs []string `json:"hostids"`
+}
+
+// monitoredByProxy is the monitored_by of hosts monitored by a proxy.
+const monitoredByProxy = "1"
+
+// cloneInterfaceFields are the interface properties copied to a clone;
+// the others are set by the server.
+var cloneInterfaceFields = []string{"type", "main", "useip", "ip", "dns", "port", "details"}
+
+// HostClone is a host created as a copy of another.
+type HostClone struct {
+	HostID string
+	// SkippedMacros are the secret macros of the source host, not copied
+	// because the API doesn't return their values
+	SkippedMacros []string
+}
+
+// CloneHost creates a host named name as a copy of another, as the
+// frontend's Clone button does: with the same host groups, templates,
+// macros, tags, interfaces, proxy, status and description. A non-empty
+// address, an IP address or a DNS name, replaces the address of each
+// interface.
+func (c *Client) CloneHost(ctx context.Context, hostID, name, address string) (*HostClone, error) {
+	params := c.adaptHostParams(HostGetParams{
+		Output:                []string{"hostid", "host", "status", "description", "proxy_hostid", "proxyid"},
+		SelectInterfaces:      "extend",
+		SelectHostGroups:      []string{"groupid"},
+		SelectMacros:          "extend",
+		SelectParentTemplates: []string{"templateid"},
+		SelectTags:            []string{"tag", "value"},
+		HostIDs:               []string{hostID},
+	})
+	if c.Capabilities().MonitoredBy {
+		params.Output = without(params.Output.([]string), "proxy_hostid")
+	} else {
+		params.Output = without(params.Output.([]string), "proxyid")
+	}
+
+	var raw []json.RawMessage
+	if err := c.call(ctx, "host.get", params, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get host: %w", err)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("host %s: %w", hostID, ErrNotFound)
+	}
+	// Host drops the interface properties it doesn't know, such as the
+	// SNMP details, so interfaces are copied as returned
+	var source Host
+	var extra struct {
+		Interfaces      []map[string]any `json:"interfaces"`
+		ParentTemplates []Template       `json:"parentTemplates"`
+		Tags            []Tag            `json:"tags"`
+	}
+	if err := json.Unmarshal(raw[0], &source); err != nil {
+		return nil, fmt.Errorf("failed to decode host: %w", err)
+	}
+	if err := json.Unmarshal(raw[0], &extra); err != nil {
+		return nil, fmt.Errorf("failed to decode host: %w", err)
+	}
+
+	create := HostCreateParams{
+		Host:        name,
+		Status:      source.Status,
+		Description: source.Description,
+		Tags:        extra.Tags,
+	}
+	for _, g := range source.Groups {
+		create.Groups = append(create.Groups, GroupRef{GroupID: g.GroupID})
+	}
+	for _, t := range extra.ParentTemplates {
+		create.Templates = append(create.Templates, TemplateRef{TemplateID: t.TemplateID})
+	}
+	result := &HostClone{}
+	for _, m := range source.Macros {
+		if m.Type == MacroTypeSecret {
+			result.SkippedMacros = append(result.SkippedMacros, m.Macro)
+			continue
+		}
+		create.Macros = append(create.Macros, HostMacro{Macro: m.Macro, Value: m.Value, Type: m.Type, Description: m.Description})
+	}
+	for _, iface := range extra.Interfaces {
+		create.Interfaces = append(create.Interfaces, cloneInterface(iface, address))
+	}
+	if source.ProxyID != "" && source.ProxyID != "0" {
+		if c.Capabilities().MonitoredBy {
+			create.ProxyID, create.MonitoredBy = source.ProxyID, monitoredByProxy
+		} else {
+			create.ProxyHostID = source.ProxyID
+		}
+	}
+
+	var created hostCreateResult
+	if err := c.call(ctx, "host.create", create, &created); err != nil {
+		return nil, fmt.Errorf("failed to create host: %w", err)
+	}
+	if len(created.HostIDs) == 0 {
+		return nil, fmt.Errorf("failed to create host: no ID returned")
+	}
+	result.HostID = created.HostIDs[0]
+	return result, nil
+}
+
+// cloneInterface returns the properties of an interface to create on a
+// clone, with its address replaced by address if not empty.
+func cloneInterface(iface map[string]any, address string) map[string]any {
+	clone := make(map[string]any, len(cloneInterfaceFields))
+	for _, f := range cloneInterfaceFields {
+		if v, ok := iface[f]; ok {
+			clone[f] = v
+		}
+	}
+	// SNMP details are returned as an empty list by interfaces of other
+	// types, which host.create rejects
+	if details, ok := clone["details"].([]any); ok && len(details) == 0 {
+		delete(clone, "details")
+	}
+	switch {
+	case address == "":
+	case net.ParseIP(address) != nil:
+		clone["ip"], clone["useip"] = address, "1"
+	default:
+		clone["dns"], clone["useip"] = address, "0"
+	}
+	return clone
+}
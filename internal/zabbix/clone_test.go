@@ -0,0 +1,87 @@
+package zabbix
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestClient_CloneHost(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"host.get": {Result: []map[string]any{{
+			"hostid":      "10",
+			"host":        "web01",
+			"status":      "0",
+			"description": "Frontend",
+			"proxyid":     "5",
+			"hostgroups":  []map[string]string{{"groupid": "2"}},
+			"macros": []map[string]string{
+				{"hostmacroid": "1", "hostid": "10", "macro": "{$PORT}", "value": "8080", "type": "0"},
+				{"hostmacroid": "2", "hostid": "10", "macro": "{$PASSWORD}", "type": "1"},
+			},
+			"parentTemplates": []map[string]string{{"templateid": "100"}},
+			"tags":            []map[string]string{{"tag": "role", "value": "web"}},
+			"interfaces": []map[string]any{
+				{"interfaceid": "7", "hostid": "10", "type": "1", "main": "1", "useip": "1", "ip": "10.0.0.1", "dns": "", "port": "10050", "available": "1", "details": []any{}},
+				{"interfaceid": "8", "hostid": "10", "type": "2", "main": "1", "useip": "1", "ip": "10.0.0.1", "dns": "", "port": "161", "details": map[string]any{"version": "2", "community": "{$SNMP_COMMUNITY}"}},
+			},
+		}}},
+		"host.create": {Result: map[string]any{"hostids": []string{"11"}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	clone, err := client.CloneHost(context.Background(), "10", "web02", "10.0.0.2")
+	if err != nil {
+		t.Fatalf("CloneHost() error = %v", err)
+	}
+	if clone.HostID != "11" || !slices.Equal(clone.SkippedMacros, []string{"{$PASSWORD}"}) {
+		t.Errorf("clone = %+v, want host 11 without the secret macro", clone)
+	}
+
+	create := params["host.create"]
+	if create["host"] != "web02" || create["description"] != "Frontend" || create["proxyid"] != "5" || create["monitored_by"] != "1" {
+		t.Errorf("host.create params = %v, want web02 monitored by the proxy", create)
+	}
+	if groups, _ := create["groups"].([]any); len(groups) != 1 {
+		t.Errorf("groups = %v, want the source host's group", create["groups"])
+	}
+	if templates, _ := create["templates"].([]any); len(templates) != 1 {
+		t.Errorf("templates = %v, want the source host's template", create["templates"])
+	}
+	macros, _ := create["macros"].([]any)
+	if len(macros) != 1 {
+		t.Fatalf("macros = %v, want only {$PORT}", macros)
+	}
+	if macro, _ := macros[0].(map[string]any); macro["macro"] != "{$PORT}" || macro["hostmacroid"] != nil {
+		t.Errorf("macro = %v, want {$PORT} without its ID", macro)
+	}
+
+	interfaces, _ := create["interfaces"].([]any)
+	if len(interfaces) != 2 {
+		t.Fatalf("interfaces = %v, want both", interfaces)
+	}
+	agent, _ := interfaces[0].(map[string]any)
+	if agent["ip"] != "10.0.0.2" || agent["interfaceid"] != nil || agent["available"] != nil || agent["details"] != nil {
+		t.Errorf("agent interface = %v, want the new IP without server-set fields", agent)
+	}
+	snmp, _ := interfaces[1].(map[string]any)
+	if details, _ := snmp["details"].(map[string]any); details["community"] != "{$SNMP_COMMUNITY}" {
+		t.Errorf("SNMP interface = %v, want its details copied", snmp)
+	}
+}
+
+func TestCloneInterface(t *testing.T) {
+	iface := map[string]any{"type": "1", "useip": "1", "ip": "10.0.0.1", "dns": "", "port": "10050"}
+
+	if got := cloneInterface(iface, ""); got["ip"] != "10.0.0.1" || got["useip"] != "1" {
+		t.Errorf("cloneInterface() without address = %v, want the source address", got)
+	}
+	if got := cloneInterface(iface, "web02.example.com"); got["dns"] != "web02.example.com" || got["useip"] != "0" {
+		t.Errorf("cloneInterface() with a DNS name = %v, want it used", got)
+	}
+	if got := cloneInterface(iface, "2001:db8::2"); got["ip"] != "2001:db8::2" || got["useip"] != "1" {
+		t.Errorf("cloneInterface() with an IPv6 address = %v, want it used", got)
+	}
+}
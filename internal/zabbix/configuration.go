@@ -0,0 +1,365 @@
+package zabbix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Configuration formats of configuration.export and configuration.import.
+const (
+	ConfigFormatYAML = "yaml"
+	ConfigFormatJSON = "json"
+	ConfigFormatXML  = "xml"
+)
+
+// ConfigFormatOf returns the configuration format of a file from its
+// extension.
+func ConfigFormatOf(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return ConfigFormatYAML, nil
+	case ".json":
+		return ConfigFormatJSON, nil
+	case ".xml":
+		return ConfigFormatXML, nil
+	}
+	return "", fmt.Errorf("%s: not a .yaml, .json or .xml file", path)
+}
+
+// configurationObjects are the objects of configuration.export.
+type configurationObjects struct {
+	Hosts     []string `json:"hosts,omitempty"`
+	Templates []string `json:"templates,omitempty"`
+}
+
+// configurationExportParams defines parameters for configuration.export
+// API call.
+type configurationExportParams struct {
+	Format  string               `json:"format"`
+	Options configurationObjects `json:"options"`
+}
+
+// configurationImportParams defines parameters for configuration.import
+// API call.
+type configurationImportParams struct {
+	Format string                     `json:"format"`
+	Source string                     `json:"source"`
+	Rules  map[string]map[string]bool `json:"rules"`
+}
+
+// ExportConfiguration exports hosts and templates, with their items,
+// triggers and so on, in a configuration format.
+func (c *Client) ExportConfiguration(ctx context.Context, format string, hostIDs, templateIDs []string) (string, error) {
+	if format == ConfigFormatYAML && !c.Capabilities().ConfigurationYAML {
+		return "", fmt.Errorf("exporting YAML requires Zabbix 5.2 or later; export JSON or XML")
+	}
+	params := configurationExportParams{
+		Format:  format,
+		Options: configurationObjects{Hosts: hostIDs, Templates: templateIDs},
+	}
+	var source string
+	if err := c.call(ctx, "configuration.export", params, &source); err != nil {
+		return "", fmt.Errorf("failed to export configuration: %w", err)
+	}
+	return source, nil
+}
+
+// FindTemplate retrieves a template by its technical or visible name.
+func (c *Client) FindTemplate(ctx context.Context, name string) (*Template, error) {
+	for _, field := range []string{"host", "name"} {
+		var templates []Template
+		params := templateGetParams{
+			Output: []string{"templateid", "host", "name"},
+			Filter: map[string]interface{}{field: name},
+		}
+		if err := c.call(ctx, "template.get", params, &templates); err != nil {
+			return nil, fmt.Errorf("failed to get template: %w", err)
+		}
+		if len(templates) > 0 {
+			return &templates[0], nil
+		}
+	}
+	return nil, fmt.Errorf("template %s: %w", name, ErrNotFound)
+}
+
+// configurationContents are the hosts and templates of a configuration
+// file, by technical name.
+type configurationContents struct {
+	Templates []struct {
+		Template string `json:"template" yaml:"template" xml:"template"`
+	} `json:"templates" yaml:"templates" xml:"templates>template"`
+	Hosts []struct {
+		Host string `json:"host" yaml:"host" xml:"host"`
+	} `json:"hosts" yaml:"hosts" xml:"hosts>host"`
+}
+
+// parseConfigurationContents returns the hosts and templates of a
+// configuration file.
+func parseConfigurationContents(format, source string) (configurationContents, error) {
+	var file struct {
+		Export configurationContents `json:"zabbix_export" yaml:"zabbix_export"`
+	}
+	var err error
+	switch format {
+	case ConfigFormatYAML:
+		err = yaml.Unmarshal([]byte(source), &file)
+	case ConfigFormatJSON:
+		err = json.Unmarshal([]byte(source), &file)
+	case ConfigFormatXML:
+		// The root element is zabbix_export
+		err = xml.Unmarshal([]byte(source), &file.Export)
+	default:
+		err = fmt.Errorf("unknown format %q", format)
+	}
+	if err != nil {
+		return configurationContents{}, fmt.Errorf("cannot read the configuration: %w", err)
+	}
+	return file.Export, nil
+}
+
+// ImportPreview is what importing a configuration file would change: the
+// file compared with an export of the hosts and templates it defines that
+// already exist on the server.
+type ImportPreview struct {
+	Format    string
+	Source    string
+	Hosts     []string // Technical names of the hosts of the file
+	Templates []string // Technical names of the templates of the file
+	Existing  []string // Those of the hosts and templates that exist
+	Diff      []string // Lines prefixed "+", "-" or " ", empty if unchanged
+}
+
+// importDiffContext is how many unchanged lines are shown around changes.
+const importDiffContext = 2
+
+// PreviewImport compares a configuration file with the configuration of
+// the hosts and templates it defines on the server, for confirming its
+// import.
+func (c *Client) PreviewImport(ctx context.Context, format, source string) (*ImportPreview, error) {
+	contents, err := parseConfigurationContents(format, source)
+	if err != nil {
+		return nil, err
+	}
+	preview := &ImportPreview{Format: format, Source: source}
+	for _, t := range contents.Templates {
+		preview.Templates = append(preview.Templates, t.Template)
+	}
+	for _, h := range contents.Hosts {
+		preview.Hosts = append(preview.Hosts, h.Host)
+	}
+	if len(preview.Templates) == 0 && len(preview.Hosts) == 0 {
+		return nil, fmt.Errorf("the configuration has no hosts or templates")
+	}
+
+	var objects configurationObjects
+	if len(preview.Templates) > 0 {
+		var templates []Template
+		params := templateGetParams{
+			Output: []string{"templateid", "host"},
+			Filter: map[string]interface{}{"host": preview.Templates},
+		}
+		if err := c.call(ctx, "template.get", params, &templates); err != nil {
+			return nil, fmt.Errorf("failed to get templates: %w", err)
+		}
+		for _, t := range templates {
+			objects.Templates = append(objects.Templates, t.TemplateID)
+			preview.Existing = append(preview.Existing, t.Host)
+		}
+	}
+	if len(preview.Hosts) > 0 {
+		hosts, err := c.GetHosts(ctx, HostGetParams{
+			Output: []string{"hostid", "host"},
+			Filter: map[string]interface{}{"host": preview.Hosts},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hosts {
+			objects.Hosts = append(objects.Hosts, h.HostID)
+			preview.Existing = append(preview.Existing, h.Host)
+		}
+	}
+
+	current := ""
+	if len(objects.Templates) > 0 || len(objects.Hosts) > 0 {
+		if current, err = c.ExportConfiguration(ctx, format, objects.Hosts, objects.Templates); err != nil {
+			return nil, err
+		}
+	}
+	preview.Diff = diffLines(configurationLines(format, current), configurationLines(format, source), importDiffContext)
+	return preview, nil
+}
+
+// configurationLines splits a configuration into lines, indenting JSON,
+// which may be exported on one line.
+func configurationLines(format, source string) []string {
+	if format == ConfigFormatJSON {
+		var b bytes.Buffer
+		if json.Indent(&b, []byte(source), "", "  ") == nil {
+			source = b.String()
+		}
+	}
+	source = strings.TrimRight(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	if source == "" {
+		return nil
+	}
+	return strings.Split(source, "\n")
+}
+
+// importRules returns the rules of configuration.import: everything in
+// the file is created or updated, nothing missing from it is deleted.
+func (c *Client) importRules() map[string]map[string]bool {
+	update := map[string]bool{"createMissing": true, "updateExisting": true}
+	rules := map[string]map[string]bool{"templateLinkage": {"createMissing": true}}
+	objects := []string{"hosts", "templates", "items", "triggers", "graphs", "discoveryRules", "valueMaps", "httptests"}
+	if c.Capabilities().HostGroupsSelect {
+		objects = append(objects, "host_groups", "template_groups")
+	} else {
+		objects = append(objects, "groups")
+	}
+	for _, o := range objects {
+		rules[o] = update
+	}
+	return rules
+}
+
+// ImportConfiguration imports a configuration file, creating the objects
+// it defines that are missing and updating those that exist. Objects
+// missing from the file are kept.
+func (c *Client) ImportConfiguration(ctx context.Context, format, source string) error {
+	if format == ConfigFormatYAML && !c.Capabilities().ConfigurationYAML {
+		return fmt.Errorf("importing YAML requires Zabbix 5.2 or later; import JSON or XML")
+	}
+	params := configurationImportParams{Format: format, Source: source, Rules: c.importRules()}
+	var ok bool
+	if err := c.call(ctx, "configuration.import", params, &ok); err != nil {
+		return fmt.Errorf("failed to import configuration: %w", err)
+	}
+	return nil
+}
+
+// diffLines returns the lines changed from a to b, prefixed "-" when
+// removed and "+" when added, with up to context unchanged lines, prefixed
+// " ", around them. Runs of unchanged lines left out are shown as "...".
+func diffLines(a, b []string, context int) []string {
+	ops := diffOps(a, b)
+	show := make([]bool, len(ops))
+	for i, op := range ops {
+		if op[0] == ' ' {
+			continue
+		}
+		for j := max(0, i-context); j <= min(len(ops)-1, i+context); j++ {
+			show[j] = true
+		}
+	}
+
+	var out []string
+	skipped := false
+	for i, op := range ops {
+		if !show[i] {
+			skipped = true
+			continue
+		}
+		if skipped && len(out) > 0 {
+			out = append(out, "...")
+		}
+		skipped = false
+		out = append(out, op)
+	}
+	return out
+}
+
+// maxDiffCells bounds the table of the longest common subsequence of two
+// configurations; larger changes are shown as all lines removed, then all
+// lines added.
+const maxDiffCells = 4 << 20
+
+// diffOps returns the lines of a and b in order, prefixed " " when in both,
+// "-" when only in a and "+" when only in b.
+func diffOps(a, b []string) []string {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var ops []string
+	for _, line := range a[:prefix] {
+		ops = append(ops, " "+line)
+	}
+	am, bm := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(am)*len(bm) > maxDiffCells {
+		for _, line := range am {
+			ops = append(ops, "-"+line)
+		}
+		for _, line := range bm {
+			ops = append(ops, "+"+line)
+		}
+	} else {
+		ops = append(ops, lcsOps(am, bm)...)
+	}
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, " "+line)
+	}
+	return ops
+}
+
+// lcsOps diffs a and b by their longest common subsequence.
+func lcsOps(a, b []string) []string {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:]
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]string, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, " "+a[i])
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, "-"+a[i])
+			i++
+		default:
+			ops = append(ops, "+"+b[j])
+			j++
+		}
+	}
+	return ops
+}
+
+// NewObjects returns the hosts and templates of the file that don't exist
+// yet.
+func (p *ImportPreview) NewObjects() []string {
+	var names []string
+	for _, name := range slices.Concat(p.Templates, p.Hosts) {
+		if !slices.Contains(p.Existing, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,121 @@
+package zabbix
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestConfigFormatOf(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"web01.yaml", ConfigFormatYAML, false},
+		{"templates/Linux.YML", ConfigFormatYAML, false},
+		{"export.json", ConfigFormatJSON, false},
+		{"export.xml", ConfigFormatXML, false},
+		{"export.txt", "", true},
+		{"export", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ConfigFormatOf(tt.path)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ConfigFormatOf(%q) = %q, %v, want %q, error %v", tt.path, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseConfigurationContents(t *testing.T) {
+	sources := map[string]string{
+		ConfigFormatYAML: "zabbix_export:\n  version: '7.0'\n  templates:\n    - template: 'Linux by Zabbix agent'\n  hosts:\n    - host: web01\n",
+		ConfigFormatJSON: `{"zabbix_export":{"version":"7.0","templates":[{"template":"Linux by Zabbix agent"}],"hosts":[{"host":"web01"}]}}`,
+		ConfigFormatXML:  `<?xml version="1.0" encoding="UTF-8"?><zabbix_export><version>5.0</version><templates><template><template>Linux by Zabbix agent</template></template></templates><hosts><host><host>web01</host></host></hosts></zabbix_export>`,
+	}
+
+	for format, source := range sources {
+		contents, err := parseConfigurationContents(format, source)
+		if err != nil {
+			t.Errorf("parseConfigurationContents(%s) error = %v", format, err)
+			continue
+		}
+		if len(contents.Templates) != 1 || contents.Templates[0].Template != "Linux by Zabbix agent" ||
+			len(contents.Hosts) != 1 || contents.Hosts[0].Host != "web01" {
+			t.Errorf("parseConfigurationContents(%s) = %+v, want the template and host", format, contents)
+		}
+	}
+
+	if _, err := parseConfigurationContents(ConfigFormatJSON, "{"); err == nil {
+		t.Error("parseConfigurationContents() of invalid JSON should fail")
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	a := []string{"hosts:", "  - host: web01", "    name: Web", "    status: ENABLED", "    items:", "      - key: a", "      - key: b", "      - key: c", "      - key: d"}
+	b := []string{"hosts:", "  - host: web01", "    name: Web server", "    status: ENABLED", "    items:", "      - key: a", "      - key: b", "      - key: c", "      - key: d", "      - key: e"}
+
+	got := diffLines(a, b, 1)
+	want := []string{"   - host: web01", "-    name: Web", "+    name: Web server", "     status: ENABLED", "...", "       - key: d", "+      - key: e"}
+	if !slices.Equal(got, want) {
+		t.Errorf("diffLines() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if got := diffLines(a, a, 1); len(got) != 0 {
+		t.Errorf("diffLines() of the same lines = %q, want none", got)
+	}
+	if got := diffLines(nil, []string{"x", "y"}, 1); !slices.Equal(got, []string{"+x", "+y"}) {
+		t.Errorf("diffLines() from nothing = %q, want all added", got)
+	}
+}
+
+func TestClient_PreviewImport(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"template.get":         {Result: []any{}},
+		"host.get":             {Result: []map[string]string{{"hostid": "10", "host": "web01"}}},
+		"configuration.export": {Result: `{"zabbix_export":{"hosts":[{"host":"web01","name":"Web"}]}}`},
+	}, params)
+	defer server.Close()
+
+	source := `{"zabbix_export":{"hosts":[{"host":"web01","name":"Web server"},{"host":"web02"}]}}`
+	client := newTestClient(t, server.URL)
+	preview, err := client.PreviewImport(context.Background(), ConfigFormatJSON, source)
+	if err != nil {
+		t.Fatalf("PreviewImport() error = %v", err)
+	}
+	if !slices.Equal(preview.Existing, []string{"web01"}) || !slices.Equal(preview.NewObjects(), []string{"web02"}) {
+		t.Errorf("preview = %+v, want web01 existing and web02 new", preview)
+	}
+	diff := strings.Join(preview.Diff, "\n")
+	if !strings.Contains(diff, `-        "name": "Web"`) || !strings.Contains(diff, `+        "name": "Web server"`) || !strings.Contains(diff, `+        "host": "web02"`) {
+		t.Errorf("diff =\n%s\nwant the renamed host and the new one", diff)
+	}
+
+	options, _ := params["configuration.export"]["options"].(map[string]any)
+	if hosts, _ := options["hosts"].([]any); len(hosts) != 1 || hosts[0] != "10" || options["templates"] != nil {
+		t.Errorf("configuration.export options = %v, want only the existing host", options)
+	}
+}
+
+func TestClient_ImportConfiguration(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"configuration.import": {Result: true},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.ImportConfiguration(context.Background(), ConfigFormatYAML, "zabbix_export: {}"); err != nil {
+		t.Fatalf("ImportConfiguration() error = %v", err)
+	}
+	rules, _ := params["configuration.import"]["rules"].(map[string]any)
+	if hosts, _ := rules["hosts"].(map[string]any); hosts["createMissing"] != true || hosts["updateExisting"] != true || hosts["deleteMissing"] != nil {
+		t.Errorf("hosts rule = %v, want hosts created and updated, never deleted", rules["hosts"])
+	}
+	if rules["host_groups"] == nil || rules["groups"] != nil {
+		t.Errorf("rules = %v, want the group rules of Zabbix 6.2 and later", rules)
+	}
+}
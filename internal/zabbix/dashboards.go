@@ -0,0 +1,495 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Widget types shown in the terminal.
+const (
+	WidgetTypeGraph    = "graph"    // Graph (classic), of a graph or a single item
+	WidgetTypeSVGGraph = "svggraph" // Graph, of data sets of host and item patterns
+	WidgetTypeProblems = "problems" // Problems
+	WidgetTypeItem     = "item"     // Item value
+	// Problems by severity, counted per host group
+	WidgetTypeProblemsBySeverity = "problemsbysv"
+)
+
+// graphSourceItem is the source_type of a classic graph widget charting a
+// single item ("simple graph") instead of a graph.
+const graphSourceItem = "1"
+
+// MaxWidgetItems is the most items a graph widget charts.
+const MaxWidgetItems = 8
+
+// defaultProblemLines is how many problems a problems widget shows when its
+// show_lines field is not set, as in Zabbix.
+const defaultProblemLines = 25
+
+// Dashboard represents a Zabbix dashboard. Since Zabbix 5.4 its widgets
+// are on pages; before, they are in Widgets.
+type Dashboard struct {
+	DashboardID string          `json:"dashboardid"`
+	Name        string          `json:"name"`
+	Pages       []DashboardPage `json:"pages,omitempty"`
+	Widgets     []Widget        `json:"widgets,omitempty"`
+
+	// Columns is the width of the dashboard grid, set by GetDashboard
+	Columns int `json:"-"`
+}
+
+// DashboardPage is a page of a dashboard.
+type DashboardPage struct {
+	DashboardPageID string   `json:"dashboard_pageid"`
+	Name            string   `json:"name"`
+	Widgets         []Widget `json:"widgets"`
+}
+
+// Widget is a widget on a dashboard page, placed on the dashboard grid.
+type Widget struct {
+	WidgetID string        `json:"widgetid"`
+	Type     string        `json:"type"`
+	Name     string        `json:"name"`
+	X        string        `json:"x"`
+	Y        string        `json:"y"`
+	Width    string        `json:"width"`
+	Height   string        `json:"height"`
+	Fields   []WidgetField `json:"fields"`
+}
+
+// WidgetField is a setting of a widget. Fields with several values are
+// repeated, named "name" before Zabbix 7.0 and "name.0", "name.1"... since.
+type WidgetField struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// PageList returns the pages of the dashboard, with the widgets of a
+// dashboard without pages on a single page.
+func (d *Dashboard) PageList() []DashboardPage {
+	if len(d.Pages) > 0 {
+		return d.Pages
+	}
+	return []DashboardPage{{Widgets: d.Widgets}}
+}
+
+// Rect returns the position and size of the widget on the dashboard grid.
+func (w *Widget) Rect() (x, y, width, height int) {
+	x, _ = strconv.Atoi(w.X)
+	y, _ = strconv.Atoi(w.Y)
+	width, _ = strconv.Atoi(w.Width)
+	height, _ = strconv.Atoi(w.Height)
+	return x, y, max(1, width), max(1, height)
+}
+
+// FieldValues returns the values of a field, named name or name.N.
+func (w *Widget) FieldValues(name string) []string {
+	var values []string
+	for _, f := range w.Fields {
+		if f.Name == name || isIndexed(f.Name, name) {
+			values = append(values, f.Value)
+		}
+	}
+	return values
+}
+
+// Field returns the first value of a field, or empty if it is not set.
+func (w *Widget) Field(name string) string {
+	if values := w.FieldValues(name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// isIndexed returns true if field is name followed by an index, like
+// "itemid.0".
+func isIndexed(field, name string) bool {
+	index, ok := strings.CutPrefix(field, name+".")
+	if !ok {
+		return false
+	}
+	_, err := strconv.Atoi(index)
+	return err == nil
+}
+
+// dataSetField matches the fields of the data sets of a graph widget:
+// "ds.0.hosts.0" since Zabbix 5.4 and "ds.hosts.0.0" before.
+var dataSetField = regexp.MustCompile(`^ds\.(?:(\d+)\.(hosts|items|itemids)|(hosts|items)\.(\d+))\.\d+$`)
+
+// widgetDataSet is a data set of a graph widget: the items named like one
+// of items on the hosts named like one of hosts, or the items of itemIDs.
+type widgetDataSet struct {
+	hosts, items, itemIDs []string
+}
+
+// dataSets returns the data sets of a graph widget, in order.
+func (w *Widget) dataSets() []widgetDataSet {
+	var sets []widgetDataSet
+	for _, f := range w.Fields {
+		m := dataSetField.FindStringSubmatch(f.Name)
+		if m == nil {
+			continue
+		}
+		index, kind := m[1], m[2]
+		if index == "" {
+			index, kind = m[4], m[3]
+		}
+		i, _ := strconv.Atoi(index)
+		for len(sets) <= i {
+			sets = append(sets, widgetDataSet{})
+		}
+		switch kind {
+		case "hosts":
+			sets[i].hosts = append(sets[i].hosts, f.Value)
+		case "items":
+			sets[i].items = append(sets[i].items, f.Value)
+		case "itemids":
+			sets[i].itemIDs = append(sets[i].itemIDs, f.Value)
+		}
+	}
+	return sets
+}
+
+// dashboardGetParams defines parameters for dashboard.get API call.
+type dashboardGetParams struct {
+	Output        interface{} `json:"output,omitempty"`
+	SelectPages   interface{} `json:"selectPages,omitempty"`
+	SelectWidgets interface{} `json:"selectWidgets,omitempty"`
+	DashboardIDs  []string    `json:"dashboardids,omitempty"`
+	SortField     []string    `json:"sortfield,omitempty"`
+	SortOrder     string      `json:"sortorder,omitempty"`
+}
+
+// GetDashboards retrieves the names of the dashboards, sorted by name.
+func (c *Client) GetDashboards(ctx context.Context) ([]Dashboard, error) {
+	params := dashboardGetParams{
+		Output:    []string{"dashboardid", "name"},
+		SortField: []string{"name"},
+		SortOrder: "ASC",
+	}
+
+	var dashboards []Dashboard
+	if err := c.call(ctx, "dashboard.get", params, &dashboards); err != nil {
+		return nil, fmt.Errorf("failed to get dashboards: %w", err)
+	}
+	return dashboards, nil
+}
+
+// GetDashboard retrieves a dashboard with its pages and widgets.
+func (c *Client) GetDashboard(ctx context.Context, dashboardID string) (*Dashboard, error) {
+	caps := c.Capabilities()
+	params := dashboardGetParams{
+		Output:       []string{"dashboardid", "name"},
+		DashboardIDs: []string{dashboardID},
+	}
+	if caps.DashboardPages {
+		params.SelectPages = "extend"
+	} else {
+		params.SelectWidgets = "extend"
+	}
+
+	var dashboards []Dashboard
+	if err := c.call(ctx, "dashboard.get", params, &dashboards); err != nil {
+		return nil, fmt.Errorf("failed to get dashboard: %w", err)
+	}
+	if len(dashboards) == 0 {
+		return nil, fmt.Errorf("dashboard %s: %w", dashboardID, ErrNotFound)
+	}
+	d := &dashboards[0]
+	switch {
+	case VersionAtLeast(caps.Version, 7, 0):
+		d.Columns = 72
+	case caps.DashboardPages:
+		d.Columns = 24
+	default:
+		d.Columns = 12
+	}
+	return d, nil
+}
+
+// WidgetData holds what the widgets of a dashboard page show.
+type WidgetData struct {
+	Items    map[string][]Item    // Items of graph and item widgets, by widget ID
+	History  map[string][]History // History of these items, by item ID
+	Problems map[string][]Problem // Problems of problems widgets, by widget ID
+	// Problem counts of problems by severity widgets, by widget ID
+	Groups map[string][]GroupProblems
+}
+
+// GroupProblems counts the open problems of a host group by severity.
+type GroupProblems struct {
+	Group    HostGroup
+	Problems ProblemCounts
+}
+
+// GetWidgetData retrieves the data shown by widgets: the items of graph and
+// item widgets with their history over the last hours, the problems of
+// problems widgets and the problem counts of problems by severity widgets.
+// Other widgets have no data.
+func (c *Client) GetWidgetData(ctx context.Context, widgets []Widget, hours int) (*WidgetData, error) {
+	data := &WidgetData{
+		Items:    map[string][]Item{},
+		Problems: map[string][]Problem{},
+		Groups:   map[string][]GroupProblems{},
+	}
+
+	// The item IDs of each widget, resolved from graphs and patterns
+	widgetItemIDs := map[string][]string{}
+	var graphIDs []string
+	for _, w := range widgets {
+		switch w.Type {
+		case WidgetTypeGraph:
+			if w.Field("source_type") == graphSourceItem {
+				widgetItemIDs[w.WidgetID] = w.FieldValues("itemid")
+			} else {
+				graphIDs = append(graphIDs, w.FieldValues("graphid")...)
+			}
+		case WidgetTypeItem:
+			widgetItemIDs[w.WidgetID] = w.FieldValues("itemid")
+		case WidgetTypeSVGGraph:
+			ids, err := c.dataSetItemIDs(ctx, w.dataSets())
+			if err != nil {
+				return nil, err
+			}
+			widgetItemIDs[w.WidgetID] = ids
+		case WidgetTypeProblems:
+			problems, err := c.widgetProblems(ctx, w)
+			if err != nil {
+				return nil, err
+			}
+			data.Problems[w.WidgetID] = problems
+		case WidgetTypeProblemsBySeverity:
+			groups, err := c.widgetGroupProblems(ctx, w)
+			if err != nil {
+				return nil, err
+			}
+			data.Groups[w.WidgetID] = groups
+		}
+	}
+	if len(graphIDs) > 0 {
+		graphItems, err := c.getGraphItemIDs(ctx, graphIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range widgets {
+			if w.Type == WidgetTypeGraph && w.Field("source_type") != graphSourceItem {
+				widgetItemIDs[w.WidgetID] = graphItems[w.Field("graphid")]
+			}
+		}
+	}
+
+	var itemIDs []string
+	for _, ids := range widgetItemIDs {
+		for _, id := range ids {
+			if !slices.Contains(itemIDs, id) {
+				itemIDs = append(itemIDs, id)
+			}
+		}
+	}
+	if len(itemIDs) == 0 {
+		return data, nil
+	}
+
+	params := DefaultItemGetParams()
+	params.ItemIDs = itemIDs
+	params.Monitored = false
+	items, err := c.GetItems(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.resolveValueMaps(ctx, items); err != nil {
+		return nil, err
+	}
+	for widgetID, ids := range widgetItemIDs {
+		for _, id := range ids {
+			i := slices.IndexFunc(items, func(item Item) bool { return item.ItemID == id })
+			if i >= 0 && len(data.Items[widgetID]) < MaxWidgetItems {
+				data.Items[widgetID] = append(data.Items[widgetID], items[i])
+			}
+		}
+	}
+
+	data.History, err = c.GetItemsHistory(ctx, items, hours)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// dataSetItemIDs returns the IDs of the items of graph data sets, up to
+// MaxWidgetItems.
+func (c *Client) dataSetItemIDs(ctx context.Context, sets []widgetDataSet) ([]string, error) {
+	var ids []string
+	for _, set := range sets {
+		ids = append(ids, set.itemIDs...)
+
+		var hostIDs []string
+		for _, pattern := range set.hosts {
+			hosts, err := c.SearchHosts(ctx, pattern)
+			if err != nil {
+				return nil, err
+			}
+			for _, h := range hosts {
+				hostIDs = append(hostIDs, h.HostID)
+			}
+		}
+		if len(hostIDs) == 0 {
+			continue
+		}
+		for _, pattern := range set.items {
+			items, err := c.GetItems(ctx, ItemGetParams{
+				Output:                 []string{"itemid"},
+				HostIDs:                hostIDs,
+				Search:                 map[string]string{"name": pattern},
+				SearchWildcardsEnabled: true,
+				Monitored:              true,
+				Limit:                  MaxWidgetItems,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				ids = append(ids, item.ItemID)
+			}
+		}
+	}
+	return ids[:min(len(ids), MaxWidgetItems)], nil
+}
+
+// widgetProblems retrieves the problems shown by a problems widget: those
+// of its severities, host groups and hosts, up to its number of lines.
+func (c *Client) widgetProblems(ctx context.Context, w Widget) ([]Problem, error) {
+	params := DefaultProblemGetParams()
+	params.Limit = defaultProblemLines
+	if lines, err := strconv.Atoi(w.Field("show_lines")); err == nil && lines > 0 {
+		params.Limit = lines
+	}
+	for _, s := range w.FieldValues("severities") {
+		if severity, err := strconv.Atoi(s); err == nil {
+			params.Severities = append(params.Severities, severity)
+		}
+	}
+	params.GroupIDs = w.FieldValues("groupids")
+	params.HostIDs = w.FieldValues("hostids")
+	return c.GetProblems(ctx, params)
+}
+
+// widgetGroupProblems counts the problems shown by a problems by severity
+// widget in each host group, sorted by name: in its host groups, or in all
+// with problems, leaving out its excluded groups, and of its hosts and
+// severities. Its host groups without problems are counted too unless it
+// hides them. Problems of disabled triggers are left out, as in the alerts
+// list.
+func (c *Client) widgetGroupProblems(ctx context.Context, w Widget) ([]GroupProblems, error) {
+	groupIDs := w.FieldValues("groupids")
+	excluded := w.FieldValues("exclude_groupids")
+	params := internalProblemGetParams{
+		Output:   []string{"eventid", "objectid", "severity"},
+		GroupIDs: groupIDs,
+		HostIDs:  w.FieldValues("hostids"),
+	}
+	for _, s := range w.FieldValues("severities") {
+		if severity, err := strconv.Atoi(s); err == nil {
+			params.Severities = append(params.Severities, severity)
+		}
+	}
+	var problems []Problem
+	if err := c.call(ctx, "problem.get", params, &problems); err != nil {
+		return nil, fmt.Errorf("failed to get active problems: %w", err)
+	}
+
+	byGroup := make(map[string]*GroupProblems)
+	if len(groupIDs) > 0 && w.Field("hide_empty_groups") != "1" {
+		var groups []HostGroup
+		err := c.call(ctx, "hostgroup.get", hostGroupGetParams{Output: []string{"groupid", "name"}, GroupIDs: groupIDs}, &groups)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get host groups: %w", err)
+		}
+		for _, g := range groups {
+			byGroup[g.GroupID] = &GroupProblems{Group: g}
+		}
+	}
+
+	if len(problems) > 0 {
+		var triggerIDs []string
+		for _, p := range problems {
+			if !slices.Contains(triggerIDs, p.ObjectID) {
+				triggerIDs = append(triggerIDs, p.ObjectID)
+			}
+		}
+		triggers, err := c.GetTriggers(ctx, TriggerGetParams{
+			Output:           []string{"triggerid", "status"},
+			SelectHostGroups: []string{"groupid", "name"},
+			TriggerIDs:       triggerIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		groupsOf := make(map[string][]HostGroup, len(triggers))
+		for _, t := range triggers {
+			if t.Status != TriggerStatusDisabled {
+				groupsOf[t.TriggerID] = t.Groups
+			}
+		}
+		for _, p := range problems {
+			for _, g := range groupsOf[p.ObjectID] {
+				if slices.Contains(excluded, g.GroupID) || (len(groupIDs) > 0 && !slices.Contains(groupIDs, g.GroupID)) {
+					continue
+				}
+				gp, ok := byGroup[g.GroupID]
+				if !ok {
+					gp = &GroupProblems{Group: g}
+					byGroup[g.GroupID] = gp
+				}
+				gp.Problems.Severities[p.SeverityInt()]++
+			}
+		}
+	}
+
+	groups := make([]GroupProblems, 0, len(byGroup))
+	for _, gp := range byGroup {
+		if !slices.Contains(excluded, gp.Group.GroupID) {
+			groups = append(groups, *gp)
+		}
+	}
+	slices.SortFunc(groups, func(a, b GroupProblems) int {
+		return strings.Compare(a.Group.Name, b.Group.Name)
+	})
+	return groups, nil
+}
+
+// graphGetParams defines parameters for graph.get API call.
+type graphGetParams struct {
+	Output      interface{} `json:"output,omitempty"`
+	SelectItems interface{} `json:"selectItems,omitempty"`
+	GraphIDs    []string    `json:"graphids,omitempty"`
+}
+
+// getGraphItemIDs retrieves the IDs of the items of graphs, by graph ID.
+func (c *Client) getGraphItemIDs(ctx context.Context, graphIDs []string) (map[string][]string, error) {
+	params := graphGetParams{
+		Output:      []string{"graphid"},
+		SelectItems: []string{"itemid"},
+		GraphIDs:    graphIDs,
+	}
+
+	var graphs []struct {
+		GraphID string `json:"graphid"`
+		Items   []Item `json:"items"`
+	}
+	if err := c.call(ctx, "graph.get", params, &graphs); err != nil {
+		return nil, fmt.Errorf("failed to get graphs: %w", err)
+	}
+	byID := make(map[string][]string, len(graphs))
+	for _, g := range graphs {
+		for _, item := range g.Items {
+			byID[g.GraphID] = append(byID[g.GraphID], item.ItemID)
+		}
+	}
+	return byID, nil
+}
@@ -0,0 +1,174 @@
+package zabbix
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClient_GetDashboard(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"dashboard.get": {Result: []Dashboard{{
+			DashboardID: "1",
+			Name:        "Global view",
+			Pages: []DashboardPage{{Widgets: []Widget{
+				{WidgetID: "10", Type: WidgetTypeProblems, X: "0", Y: "0", Width: "36", Height: "5"},
+			}}},
+		}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	d, err := client.GetDashboard(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("GetDashboard() error = %v", err)
+	}
+	if p := params["dashboard.get"]; p["selectPages"] != "extend" || p["selectWidgets"] != nil {
+		t.Errorf("dashboard.get params = %v, want pages", p)
+	}
+	if d.Columns != 72 || len(d.PageList()) != 1 {
+		t.Errorf("dashboard = %+v, want one page on a 72 column grid", d)
+	}
+	if x, y, w, h := d.PageList()[0].Widgets[0].Rect(); x != 0 || y != 0 || w != 36 || h != 5 {
+		t.Errorf("Rect() = %d, %d, %d, %d, want 0, 0, 36, 5", x, y, w, h)
+	}
+
+	// Before Zabbix 5.4 widgets are on the dashboard
+	caps := CapabilitiesFor("5.0.40")
+	client.caps.Store(&caps)
+	d, err = client.GetDashboard(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("GetDashboard() error = %v", err)
+	}
+	if p := params["dashboard.get"]; p["selectWidgets"] != "extend" || p["selectPages"] != nil {
+		t.Errorf("dashboard.get params = %v, want widgets on 5.0", p)
+	}
+	if d.Columns != 12 {
+		t.Errorf("Columns = %d, want 12 on 5.0", d.Columns)
+	}
+}
+
+func TestWidget_Fields(t *testing.T) {
+	w := Widget{Fields: []WidgetField{
+		{Name: "severities", Value: "4"},
+		{Name: "severities", Value: "5"},
+		{Name: "hostids.0", Value: "10084"},
+		{Name: "hostids.1", Value: "10085"},
+		{Name: "hostids_extra", Value: "x"},
+		{Name: "ds.0.hosts.0", Value: "web*"},
+		{Name: "ds.0.items.0", Value: "CPU*"},
+		{Name: "ds.hosts.1.0", Value: "db*"},
+		{Name: "ds.1.itemids.0", Value: "23"},
+	}}
+
+	if got := w.FieldValues("severities"); len(got) != 2 || got[1] != "5" {
+		t.Errorf("FieldValues(severities) = %v, want [4 5]", got)
+	}
+	if got := w.FieldValues("hostids"); len(got) != 2 || got[0] != "10084" {
+		t.Errorf("FieldValues(hostids) = %v, want the indexed values only", got)
+	}
+	if got := w.Field("show_lines"); got != "" {
+		t.Errorf("Field(show_lines) = %q, want empty", got)
+	}
+
+	sets := w.dataSets()
+	if len(sets) != 2 {
+		t.Fatalf("dataSets() = %+v, want 2 data sets", sets)
+	}
+	if sets[0].hosts[0] != "web*" || sets[0].items[0] != "CPU*" {
+		t.Errorf("data set 0 = %+v, want web* and CPU*", sets[0])
+	}
+	if sets[1].hosts[0] != "db*" || sets[1].itemIDs[0] != "23" {
+		t.Errorf("data set 1 = %+v, want db* and item 23", sets[1])
+	}
+}
+
+func TestClient_GetWidgetData(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"host.get": {Result: []Host{{HostID: "10084", Name: "web01"}}},
+		"item.get": {Result: []Item{
+			{ItemID: "23", Name: "CPU utilization", ValueType: ItemValueTypeFloat, Units: "%"},
+		}},
+		"history.get": {Result: []History{{ItemID: "23", Clock: "1700000000", Value: "12.5"}}},
+		"problem.get": {Result: []map[string]string{{"eventid": "100"}}},
+		"event.get":   {Result: []Problem{{EventID: "100", Name: "High CPU", Severity: "4"}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	data, err := client.GetWidgetData(context.Background(), []Widget{
+		{WidgetID: "1", Type: WidgetTypeSVGGraph, Fields: []WidgetField{
+			{Name: "ds.0.hosts.0", Value: "web*"},
+			{Name: "ds.0.items.0", Value: "CPU*"},
+		}},
+		{WidgetID: "2", Type: WidgetTypeProblems, Fields: []WidgetField{
+			{Name: "severities", Value: "4"},
+			{Name: "show_lines", Value: "10"},
+		}},
+		{WidgetID: "3", Type: "clock"},
+	}, 1)
+	if err != nil {
+		t.Fatalf("GetWidgetData() error = %v", err)
+	}
+
+	if items := data.Items["1"]; len(items) != 1 || items[0].ItemID != "23" {
+		t.Errorf("graph items = %+v, want item 23", items)
+	}
+	if len(data.History["23"]) != 1 {
+		t.Errorf("history = %v, want the history of item 23", data.History)
+	}
+	if problems := data.Problems["2"]; len(problems) != 1 || problems[0].Name != "High CPU" {
+		t.Errorf("problems = %+v, want the widget's problem", problems)
+	}
+	p := params["problem.get"]
+	if severities, _ := p["severities"].([]any); len(severities) != 1 || p["limit"] != float64(10) {
+		t.Errorf("problem.get params = %v, want the widget's severities and lines", p)
+	}
+}
+
+func TestClient_GetWidgetData_ProblemsBySeverity(t *testing.T) {
+	params := map[string]map[string]any{}
+	web := HostGroup{GroupID: "2", Name: "Web servers"}
+	db := HostGroup{GroupID: "3", Name: "Databases"}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"problem.get": {Result: []Problem{
+			{EventID: "100", ObjectID: "13", Severity: "4"},
+			{EventID: "101", ObjectID: "14", Severity: "2"},
+			{EventID: "102", ObjectID: "15", Severity: "5"},
+		}},
+		"trigger.get": {Result: []map[string]any{
+			{"triggerid": "13", "status": "0", "hostgroups": []HostGroup{web, {GroupID: "9", Name: "Linux"}}},
+			{"triggerid": "14", "status": "0", "hostgroups": []HostGroup{web}},
+			{"triggerid": "15", "status": "1", "hostgroups": []HostGroup{web}},
+		}},
+		"hostgroup.get": {Result: []HostGroup{web, db}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	data, err := client.GetWidgetData(context.Background(), []Widget{
+		{WidgetID: "1", Type: WidgetTypeProblemsBySeverity, Fields: []WidgetField{
+			{Name: "groupids", Value: "2"},
+			{Name: "groupids", Value: "3"},
+		}},
+	}, 1)
+	if err != nil {
+		t.Fatalf("GetWidgetData() error = %v", err)
+	}
+
+	groups := data.Groups["1"]
+	if len(groups) != 2 || groups[0].Group.Name != "Databases" || groups[1].Group.Name != "Web servers" {
+		t.Fatalf("groups = %+v, want Databases and Web servers", groups)
+	}
+	if groups[0].Problems.Total() != 0 {
+		t.Errorf("Databases problems = %+v, want none", groups[0].Problems)
+	}
+	// The problem of the disabled trigger is left out
+	if got := groups[1].Problems; got.Total() != 2 || got.Severities[4] != 1 || got.Severities[2] != 1 {
+		t.Errorf("Web servers problems = %+v, want one high and one warning", got)
+	}
+	if groupIDs, _ := params["problem.get"]["groupids"].([]any); len(groupIDs) != 2 {
+		t.Errorf("problem.get params = %v, want the widget's host groups", params["problem.get"])
+	}
+}
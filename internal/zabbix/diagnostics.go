@@ -0,0 +1,177 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Item types collecting values from a host's agents.
+const (
+	ItemTypeAgent       = "0"  // Zabbix agent, polled on an agent interface
+	ItemTypeIPMI        = "12" // IPMI agent
+	ItemTypeAgentActive = "7"  // Zabbix agent (active), sent by the agent
+	ItemTypeJMX         = "16" // JMX agent
+	ItemTypeSNMP        = "20" // SNMP agent
+)
+
+// agentItemTypes are the item types whose values come from the agents of
+// the host's interfaces, or from its active agent.
+var agentItemTypes = []string{ItemTypeAgent, ItemTypeAgentActive, ItemTypeIPMI, ItemTypeJMX, ItemTypeSNMP}
+
+// Task types of task.create.
+const taskTypeCheckNow = 6
+
+// HostDiagnostics holds what is needed to troubleshoot the availability of
+// a host.
+type HostDiagnostics struct {
+	Host  Host   // With the errors of its interfaces
+	Items []Item // Enabled items collected by the host's agents
+}
+
+// GetHostDiagnostics retrieves a host with the errors of its interfaces and
+// its items collected by agents, with their last collection time.
+func (c *Client) GetHostDiagnostics(ctx context.Context, hostID string) (*HostDiagnostics, error) {
+	hostParams := DefaultHostGetParams()
+	hostParams.HostIDs = []string{hostID}
+	hostParams.MonitoredHosts = false
+	hostParams.SelectInterfaces = []string{
+		"interfaceid", "ip", "dns", "useip", "port", "type", "main", "available",
+		"error", "errors_from", "disable_until",
+	}
+	hosts, err := c.GetHosts(ctx, hostParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("host %s: %w", hostID, ErrNotFound)
+	}
+
+	items, err := c.GetItems(ctx, ItemGetParams{
+		Output:  []string{"itemid", "name", "key_", "type", "interfaceid", "lastclock", "state", "status", "error"},
+		HostIDs: []string{hostID},
+		Filter: map[string]interface{}{
+			"type":   agentItemTypes,
+			"status": "0", // enabled items only
+		},
+		SortField: []string{"name"},
+		SortOrder: "ASC",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &HostDiagnostics{Host: hosts[0], Items: items}, nil
+}
+
+// LastData returns when the latest value was collected from items of the
+// given types, or zero if none has been.
+func (d *HostDiagnostics) LastData(types ...string) time.Time {
+	var last time.Time
+	for _, item := range d.Items {
+		if !slices.Contains(types, item.Type) {
+			continue
+		}
+		if t := item.LastTime(); t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
+
+// InterfaceLastData returns when the latest value was polled on an
+// interface, or zero if none has been.
+func (d *HostDiagnostics) InterfaceLastData(interfaceID string) time.Time {
+	var last time.Time
+	for _, item := range d.Items {
+		if item.InterfaceID != interfaceID {
+			continue
+		}
+		if t := item.LastTime(); t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
+
+// NotSupported returns the items that could not be collected.
+func (d *HostDiagnostics) NotSupported() []Item {
+	var items []Item
+	for _, item := range d.Items {
+		if item.IsUnsupported() {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// AvailabilityCheckItems returns one item to check now on each unavailable
+// interface, or on each interface if none is unavailable: polling an item
+// is what updates the availability of its interface. Agent pings are
+// preferred, as they only fail when the agent cannot be reached.
+func (d *HostDiagnostics) AvailabilityCheckItems() []string {
+	var unavailable []string
+	for _, iface := range d.Host.Interfaces {
+		if iface.Available == "2" {
+			unavailable = append(unavailable, iface.InterfaceID)
+		}
+	}
+
+	var ids []string
+	for _, iface := range d.Host.Interfaces {
+		if len(unavailable) > 0 && !slices.Contains(unavailable, iface.InterfaceID) {
+			continue
+		}
+		var chosen *Item
+		for i := range d.Items {
+			item := &d.Items[i]
+			if item.InterfaceID != iface.InterfaceID || item.Type == ItemTypeAgentActive {
+				continue
+			}
+			if chosen == nil || item.Key == "agent.ping" {
+				chosen = item
+			}
+		}
+		if chosen != nil {
+			ids = append(ids, chosen.ItemID)
+		}
+	}
+	return ids
+}
+
+// checkNowTask is a "check now" task of task.create, since Zabbix 5.2.
+type checkNowTask struct {
+	Type    int               `json:"type"`
+	Request map[string]string `json:"request"`
+}
+
+// legacyCheckNowParams are the task.create parameters before Zabbix 5.2.
+type legacyCheckNowParams struct {
+	Type    string   `json:"type"`
+	ItemIDs []string `json:"itemids"`
+}
+
+// CheckItemsNow asks the server to collect the values of items right away,
+// instead of at their next scheduled check. Only items polled by the server
+// or a proxy can be checked.
+func (c *Client) CheckItemsNow(ctx context.Context, itemIDs []string) error {
+	var params any
+	if c.Capabilities().TaskRequests {
+		tasks := make([]checkNowTask, len(itemIDs))
+		for i, id := range itemIDs {
+			tasks[i] = checkNowTask{Type: taskTypeCheckNow, Request: map[string]string{"itemid": id}}
+		}
+		params = tasks
+	} else {
+		params = legacyCheckNowParams{Type: strconv.Itoa(taskTypeCheckNow), ItemIDs: itemIDs}
+	}
+
+	var result struct {
+		TaskIDs []string `json:"taskids"`
+	}
+	if err := c.call(ctx, "task.create", params, &result); err != nil {
+		return fmt.Errorf("failed to create check now tasks: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package zabbix
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestClient_GetHostDiagnostics(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"host.get": {Result: []map[string]any{{
+			"hostid": "1", "host": "web01",
+			"interfaces": []map[string]string{
+				{"interfaceid": "11", "type": "1", "available": "2", "error": "Connection refused", "errors_from": "1700000000"},
+				{"interfaceid": "12", "type": "2", "available": "1"},
+			},
+		}}},
+		"item.get": {Result: []map[string]string{
+			{"itemid": "1", "key_": "system.uptime", "type": "0", "interfaceid": "11", "lastclock": "1700000000"},
+			{"itemid": "2", "key_": "agent.ping", "type": "0", "interfaceid": "11", "lastclock": "1699999990"},
+			{"itemid": "3", "key_": "ifInOctets", "type": "20", "interfaceid": "12", "lastclock": "1700000500"},
+			{"itemid": "4", "key_": "log[/var/log/app]", "type": "7", "interfaceid": "0", "lastclock": "1700000100", "state": "1"},
+		}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	d, err := client.GetHostDiagnostics(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("GetHostDiagnostics() error = %v", err)
+	}
+
+	if d.Host.Interfaces[0].Error != "Connection refused" {
+		t.Errorf("interface error = %q, want Connection refused", d.Host.Interfaces[0].Error)
+	}
+	if params["host.get"]["monitored_hosts"] != nil {
+		t.Error("diagnostics should include disabled hosts")
+	}
+	if got := d.LastData(ItemTypeAgent).Unix(); got != 1700000000 {
+		t.Errorf("LastData(agent) = %d, want 1700000000", got)
+	}
+	if got := d.InterfaceLastData("12").Unix(); got != 1700000500 {
+		t.Errorf("InterfaceLastData(12) = %d, want 1700000500", got)
+	}
+	if got := d.NotSupported(); len(got) != 1 || got[0].ItemID != "4" {
+		t.Errorf("NotSupported() = %v, want item 4", got)
+	}
+
+	// Only the unavailable interface is checked, with its agent ping
+	if got := d.AvailabilityCheckItems(); !reflect.DeepEqual(got, []string{"2"}) {
+		t.Errorf("AvailabilityCheckItems() = %v, want [2]", got)
+	}
+	d.Host.Interfaces[0].Available = "1"
+	if got := d.AvailabilityCheckItems(); !reflect.DeepEqual(got, []string{"2", "3"}) {
+		t.Errorf("AvailabilityCheckItems() = %v, want [2 3] with no interface unavailable", got)
+	}
+}
+
+func TestClient_CheckItemsNow(t *testing.T) {
+	var requests []rawRequest
+	server := newVersionServer(t, "7.0.3", &requests)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.CheckItemsNow(context.Background(), []string{"2", "3"}); err != nil {
+		t.Fatalf("CheckItemsNow() error = %v", err)
+	}
+	tasks, _ := requests[0].Body["params"].([]any)
+	if len(tasks) != 2 {
+		t.Fatalf("task.create params = %v, want two tasks", requests[0].Body["params"])
+	}
+	task := tasks[1].(map[string]any)
+	if task["type"] != float64(6) || task["request"].(map[string]any)["itemid"] != "3" {
+		t.Errorf("task = %v, want check now of item 3", task)
+	}
+
+	// Before Zabbix 5.2 one task takes all items
+	caps := CapabilitiesFor("5.0.40")
+	client.caps.Store(&caps)
+	if err := client.CheckItemsNow(context.Background(), []string{"2", "3"}); err != nil {
+		t.Fatalf("CheckItemsNow() error = %v", err)
+	}
+	legacy, _ := requests[1].Body["params"].(map[string]any)
+	if legacy["type"] != "6" || len(legacy["itemids"].([]any)) != 2 {
+		t.Errorf("task.create params = %v, want type 6 with itemids", legacy)
+	}
+}
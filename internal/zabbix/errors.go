@@ -0,0 +1,81 @@
+package zabbix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Kinds of errors returned by the client, to be tested with errors.Is. API
+// errors carry no machine-readable kind, so APIError.Is tells them apart by
+// their message.
+var (
+	// ErrAuth is an expired session or rejected token: logging in again
+	// may help.
+	ErrAuth = errors.New("not authorized")
+	// ErrPermission is a call or object the user may not access.
+	ErrPermission = errors.New("no permissions")
+	// ErrNotFound is a requested object that does not exist. Zabbix reports
+	// objects the user can't see the same way, so such errors are also
+	// ErrPermission.
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited is a request refused for being one too many: it may be
+	// retried later.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrBasicAuthToken is a call with a token or session over HTTP basic
+	// auth to Zabbix 7.2 or later, which only takes them in the
+	// Authorization header basic auth uses.
+	ErrBasicAuthToken = errors.New("HTTP basic auth can't be combined with a token or session on Zabbix 7.2 or later")
+)
+
+// apiErrorKinds maps the start of an API error's data to its kinds.
+var apiErrorKinds = []struct {
+	prefix string
+	kinds  []error
+}{
+	{"Not authorized", []error{ErrAuth}},
+	{"Not authorised", []error{ErrAuth}},
+	{"Session terminated", []error{ErrAuth}},
+	{"Incorrect user name or password", []error{ErrAuth}},
+	{"API token expired", []error{ErrAuth}},
+	{"No permissions to referred object or it does not exist", []error{ErrPermission, ErrNotFound}},
+	{"No permissions", []error{ErrPermission}},
+	{"You do not have permission", []error{ErrPermission}},
+}
+
+// Is reports whether the error is of kind target, one of ErrAuth,
+// ErrPermission or ErrNotFound.
+func (e *APIError) Is(target error) bool {
+	for _, k := range apiErrorKinds {
+		if strings.HasPrefix(e.Data, k.prefix) {
+			return slices.Contains(k.kinds, target)
+		}
+	}
+	return false
+}
+
+// StatusError is an HTTP response other than 200 OK from the server.
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.Code)
+}
+
+// Is reports whether the error is of kind target: ErrAuth for 401,
+// ErrPermission for 403 and ErrRateLimited for 429. A 503 is left unclassified
+// like other server errors, so that an unavailable server counts as failing.
+func (e *StatusError) Is(target error) bool {
+	switch e.Code {
+	case http.StatusUnauthorized:
+		return target == ErrAuth
+	case http.StatusForbidden:
+		return target == ErrPermission
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	}
+	return false
+}
@@ -0,0 +1,268 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TriggerEvaluation is a trigger expression evaluated with the current
+// values of its items, telling why the trigger is firing or not.
+type TriggerEvaluation struct {
+	Trigger    Trigger
+	Expression string // With the functions written out
+	State      Truth
+	Conditions []Condition
+	Notes      []string // Why values are unknown
+}
+
+// userMacro matches a user macro, with an optional context, as in
+// "{$CPU.UTIL.CRIT}" or `{$VFS.FS.PUSED.MAX.CRIT:"/var"}`.
+var userMacro = regexp.MustCompile(`\{\$[A-Z0-9_.]+(?::[^}]*)?\}`)
+
+// EvaluateTrigger evaluates the expression of a trigger client-side with
+// the last values of its items, or their recent history for functions of a
+// period such as avg(/web01/system.cpu.util,5m). Only last, min, max, avg,
+// sum and nodata are evaluated; other functions, time shifts and values that
+// can't be fetched are unknown, with notes saying why.
+func (c *Client) EvaluateTrigger(ctx context.Context, triggerID string) (*TriggerEvaluation, error) {
+	triggers, err := c.GetTriggers(ctx, TriggerGetParams{
+		Output:          []string{"triggerid", "description", "expression", "priority", "value"},
+		SelectHosts:     []string{"hostid", "host", "name"},
+		SelectFunctions: "extend",
+		SelectItems:     []string{"itemid", "hostid", "key_", "value_type", "lastvalue", "lastclock", "state", "error"},
+		TriggerIDs:      []string{triggerID},
+
+		ExpandDescription: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(triggers) == 0 {
+		return nil, fmt.Errorf("trigger %s: %w", triggerID, ErrNotFound)
+	}
+	trigger := triggers[0]
+	result := &TriggerEvaluation{Trigger: trigger}
+
+	resolve := func(s string) string { return s }
+	if len(trigger.Hosts) > 0 && userMacro.MatchString(trigger.Expression+functionParameters(trigger)) {
+		macros, err := c.GetEffectiveMacros(ctx, trigger.Hosts[0].HostID)
+		if err != nil {
+			return nil, err
+		}
+		resolve = func(s string) string {
+			return userMacro.ReplaceAllStringFunc(s, func(macro string) string {
+				if value, ok := macroValue(macros, macro); ok {
+					return value
+				}
+				if note := macro + " has no value"; !slices.Contains(result.Notes, note) {
+					result.Notes = append(result.Notes, note)
+				}
+				return macro
+			})
+		}
+	}
+
+	hostNames := make(map[string]string)
+	for _, h := range trigger.Hosts {
+		hostNames[h.HostID] = h.Host
+	}
+	items := make(map[string]Item)
+	for _, item := range trigger.Items {
+		items[item.ItemID] = item
+	}
+
+	now := time.Now()
+	functions := make(map[string]exprFunction)
+	for _, f := range trigger.Functions {
+		item := items[f.ItemID]
+		params := functionArgs(resolve(f.Parameter))
+		text := f.Function + "(" + strings.Join(append([]string{"/" + hostNames[item.HostID] + "/" + item.Key}, params...), ",") + ")"
+		value, note := c.functionValue(ctx, f.Function, params, item, now)
+		if note != "" {
+			result.Notes = append(result.Notes, text+": "+note)
+		}
+		functions[f.FunctionID] = exprFunction{Text: text, Value: value}
+	}
+
+	result.State, result.Conditions, result.Expression, err = evaluateExpression(resolve(trigger.Expression), functions)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// functionParameters returns the parameters of the functions of a trigger,
+// to tell whether they use macros.
+func functionParameters(t Trigger) string {
+	var b strings.Builder
+	for _, f := range t.Functions {
+		b.WriteString(f.Parameter)
+	}
+	return b.String()
+}
+
+// macroValue returns the value of a user macro, falling back from a macro
+// with context to the macro without it, as Zabbix does. Secret macros have
+// no value.
+func macroValue(macros []EffectiveMacro, macro string) (string, bool) {
+	names := []string{macro}
+	if base, macroContext, found := strings.Cut(strings.TrimSuffix(macro, "}"), ":"); found {
+		names = append(names, base+":"+strings.Trim(macroContext, `"`)+"}", base+"}")
+	}
+	for _, name := range names {
+		for _, m := range macros {
+			if m.Macro != name {
+				continue
+			}
+			if def := m.Effective(); def.Type != MacroTypeSecret && def.Type != MacroTypeVault {
+				return def.Value, true
+			}
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// functionArgs splits the parameter of a trigger function into its
+// arguments, without the "$" standing for the item since Zabbix 5.4.
+func functionArgs(parameter string) []string {
+	_, args, err := scanArgs("("+parameter+")", 0)
+	if err != nil {
+		return []string{parameter}
+	}
+	if len(args) > 0 && args[0] == "$" {
+		args = args[1:]
+	}
+	return args
+}
+
+// functionValue returns the value of a trigger function of an item, or an
+// unknown value and why.
+func (c *Client) functionValue(ctx context.Context, name string, args []string, item Item, now time.Time) (exprValue, string) {
+	switch {
+	case item.ItemID == "":
+		return exprValue{}, "item not found"
+	case item.IsUnsupported():
+		return exprValue{}, "item not supported: " + item.Error
+	}
+	arg := ""
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	if strings.Contains(arg, ":") {
+		return exprValue{}, "time shifts are not evaluated"
+	}
+
+	switch name {
+	case "last":
+		// last(#n) is the nth latest value; the period of last(5m) before
+		// Zabbix 5.4 was ignored
+		n := 1
+		if count, ok := strings.CutPrefix(arg, "#"); ok {
+			var err error
+			if n, err = strconv.Atoi(count); err != nil || n < 1 {
+				return exprValue{}, "invalid count " + arg
+			}
+		}
+		if n == 1 {
+			if item.LastClock == "" || item.LastClock == "0" {
+				return exprValue{}, "no data"
+			}
+			return itemValue(item, item.LastValue), ""
+		}
+		history, err := c.functionHistory(ctx, item, arg, now)
+		if err != nil {
+			return exprValue{}, err.Error()
+		}
+		if len(history) < n {
+			return exprValue{}, fmt.Sprintf("only %d values", len(history))
+		}
+		return itemValue(item, history[n-1].Value), ""
+
+	case "nodata":
+		period, ok := parseTimeParam(arg)
+		if !ok {
+			return exprValue{}, "invalid period " + arg
+		}
+		last := item.LastTime()
+		return boolValue(last.IsZero() || now.Sub(last) >= period), ""
+
+	case "min", "max", "avg", "sum":
+		if !item.IsNumeric() {
+			return exprValue{}, name + "() of a non-numeric item"
+		}
+		history, err := c.functionHistory(ctx, item, arg, now)
+		if err != nil {
+			return exprValue{}, err.Error()
+		}
+		if len(history) == 0 {
+			return exprValue{}, "no values in " + arg
+		}
+		return numValue(aggregate(name, history)), ""
+	}
+	return exprValue{}, name + "() is not evaluated"
+}
+
+// itemValue returns a value of an item, a string for non-numeric items.
+func itemValue(item Item, value string) exprValue {
+	if !item.IsNumeric() {
+		return strValue(value)
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return exprValue{}
+	}
+	return numValue(f)
+}
+
+// functionHistory returns the values of an item a function of a period
+// applies to, latest first: the last n for "#n", else those of the period.
+func (c *Client) functionHistory(ctx context.Context, item Item, period string, now time.Time) ([]History, error) {
+	params := HistoryGetParams{
+		History:   historyType(item.ValueType),
+		ItemIDs:   []string{item.ItemID},
+		Output:    "extend",
+		SortField: []string{"clock"},
+		SortOrder: "DESC",
+	}
+	if count, ok := strings.CutPrefix(period, "#"); ok {
+		n, err := strconv.Atoi(count)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid count %s", period)
+		}
+		params.Limit = n
+	} else {
+		d, ok := parseTimeParam(period)
+		if !ok {
+			return nil, fmt.Errorf("invalid period %q", period)
+		}
+		params.TimeFrom = now.Add(-d).Unix()
+	}
+	return c.GetHistory(ctx, params)
+}
+
+// aggregate returns the min, max, avg or sum of values.
+func aggregate(name string, history []History) float64 {
+	result := history[0].ValueFloat()
+	sum := 0.0
+	for _, h := range history {
+		v := h.ValueFloat()
+		sum += v
+		switch {
+		case name == "min" && v < result, name == "max" && v > result:
+			result = v
+		}
+	}
+	switch name {
+	case "avg":
+		return sum / float64(len(history))
+	case "sum":
+		return sum
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package zabbix
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestClient_EvaluateTrigger(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"trigger.get": {Result: []map[string]any{{
+			"triggerid":   "100",
+			"description": "High CPU on web01",
+			"expression":  "({1}>{$CPU.CRIT} and {2}>40) or {3}=0",
+			"value":       "1",
+			"hosts":       []map[string]string{{"hostid": "10", "host": "web01", "name": "Web 01"}},
+			"functions": []map[string]string{
+				{"functionid": "1", "itemid": "1000", "function": "last", "parameter": "$"},
+				{"functionid": "2", "itemid": "1000", "function": "avg", "parameter": "$,5m"},
+				{"functionid": "3", "itemid": "1001", "function": "last", "parameter": "$"},
+			},
+			"items": []map[string]string{
+				{"itemid": "1000", "hostid": "10", "key_": "system.cpu.util", "value_type": "0", "lastvalue": "93.5", "lastclock": "1700000000", "state": "0"},
+				{"itemid": "1001", "hostid": "10", "key_": "agent.ping", "value_type": "3", "state": "1", "error": "Timeout"},
+			},
+		}}},
+		"template.get":  {Result: []any{}},
+		"usermacro.get": {Result: []map[string]string{{"hostid": "10", "macro": "{$CPU.CRIT}", "value": "90"}}},
+		"history.get": {Result: []map[string]string{
+			{"itemid": "1000", "clock": "1700000000", "value": "70"},
+			{"itemid": "1000", "clock": "1699999940", "value": "30"},
+		}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	eval, err := client.EvaluateTrigger(context.Background(), "100")
+	if err != nil {
+		t.Fatalf("EvaluateTrigger() error = %v", err)
+	}
+	if eval.State != TruthTrue {
+		t.Errorf("State = %v, want true", eval.State)
+	}
+	if want := "(last(/web01/system.cpu.util) > 90 and avg(/web01/system.cpu.util,5m) > 40) or last(/web01/agent.ping) = 0"; eval.Expression != want {
+		t.Errorf("Expression = %q, want %q", eval.Expression, want)
+	}
+
+	var values []string
+	for _, c := range eval.Conditions {
+		values = append(values, c.Values+" "+c.State.String())
+	}
+	if got, want := strings.Join(values, "; "), "93.5 > 90 true; 50 > 40 true; ? = 0 unknown"; got != want {
+		t.Errorf("conditions = %q, want %q", got, want)
+	}
+	if len(eval.Notes) != 1 || !strings.Contains(eval.Notes[0], "Timeout") {
+		t.Errorf("Notes = %q, want the unsupported item's error", eval.Notes)
+	}
+
+	if history := params["history.get"]; history["time_from"] == nil || history["sortorder"] != "DESC" {
+		t.Errorf("history.get params = %v, want the latest values of the period", history)
+	}
+}
+
+func TestMacroValue(t *testing.T) {
+	macros := []EffectiveMacro{
+		{Macro: "{$FS.PUSED.MAX}", Definitions: []MacroDefinition{{HostMacro: HostMacro{Value: "90"}}}},
+		{Macro: "{$FS.PUSED.MAX:/var}", Definitions: []MacroDefinition{{HostMacro: HostMacro{Value: "95"}}}},
+		{Macro: "{$SECRET}", Definitions: []MacroDefinition{{HostMacro: HostMacro{Value: "", Type: MacroTypeSecret}}}},
+	}
+
+	tests := []struct {
+		macro string
+		want  string
+		ok    bool
+	}{
+		{"{$FS.PUSED.MAX}", "90", true},
+		{`{$FS.PUSED.MAX:"/var"}`, "95", true},
+		{"{$FS.PUSED.MAX:/home}", "90", true},
+		{"{$SECRET}", "", false},
+		{"{$MISSING}", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := macroValue(macros, tt.macro)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("macroValue(%q) = %q, %v, want %q, %v", tt.macro, got, ok, tt.want, tt.ok)
+		}
+	}
+}
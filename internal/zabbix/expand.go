@@ -0,0 +1,68 @@
+package zabbix
+
+import (
+	"cmp"
+	"regexp"
+	"strings"
+)
+
+// hostMacro matches the host macros resolved client-side in names:
+// {HOST.NAME}, {HOST.HOST} and its old name {HOSTNAME}, with an optional
+// position of the host in the trigger expression.
+var hostMacro = regexp.MustCompile(`\{(HOST\.NAME|HOST\.HOST|HOSTNAME)([1-9]?)\}`)
+
+// positionalMacro matches the positional macros $1 to $9 of trigger names,
+// standing for the constants of the trigger expression.
+var positionalMacro = regexp.MustCompile(`\$([1-9])`)
+
+// expressionOperand matches what is not a constant in a trigger expression:
+// function references like {12345} and function calls with their parameters.
+var expressionOperand = regexp.MustCompile(`\{[^}]*\}|\w+\((?:[^()]|\([^()]*\))*\)`)
+
+// expressionConstant matches a number with an optional unit suffix.
+var expressionConstant = regexp.MustCompile(`-?\d+(?:\.\d+)?[KMGTsmhdw]?`)
+
+// ExpandHostMacros resolves the host macros in a trigger or problem name
+// with the hosts of the trigger, which Zabbix leaves in names it could not
+// resolve. Hosts are taken in the order returned, exact for the usual
+// single host triggers. Other macros are left as they are.
+func ExpandHostMacros(text string, hosts []Host) string {
+	if len(hosts) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+	return hostMacro.ReplaceAllStringFunc(text, func(macro string) string {
+		m := hostMacro.FindStringSubmatch(macro)
+		n := 1
+		if m[2] != "" {
+			n = int(m[2][0] - '0')
+		}
+		if n > len(hosts) {
+			return macro
+		}
+		if m[1] == "HOST.NAME" {
+			return cmp.Or(hosts[n-1].Name, hosts[n-1].Host)
+		}
+		return hosts[n-1].Host
+	})
+}
+
+// expandPositionalMacros resolves $1 to $9 in a trigger name with the
+// constants of its expression, in the order they appear.
+func expandPositionalMacros(text, expression string) string {
+	if expression == "" || !strings.Contains(text, "$") {
+		return text
+	}
+	constants := expressionConstant.FindAllString(expressionOperand.ReplaceAllString(expression, " "), -1)
+	return positionalMacro.ReplaceAllStringFunc(text, func(macro string) string {
+		if n := int(macro[1] - '0'); n <= len(constants) {
+			return constants[n-1]
+		}
+		return macro
+	})
+}
+
+// expandTriggerName resolves the macros of a trigger name that the server
+// did not expand.
+func expandTriggerName(t Trigger) string {
+	return ExpandHostMacros(expandPositionalMacros(t.Description, t.Expression), t.Hosts)
+}
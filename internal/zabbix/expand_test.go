@@ -0,0 +1,48 @@
+package zabbix
+
+import "testing"
+
+func TestExpandHostMacros(t *testing.T) {
+	hosts := []Host{{Host: "db01", Name: "Database 01"}, {Host: "web01"}}
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"{HOST.NAME} has been restarted", "Database 01 has been restarted"},
+		{"{HOST.HOST}: disk is full", "db01: disk is full"},
+		{"Ping from {HOSTNAME1} to {HOST.NAME2}", "Ping from db01 to web01"},
+		{"{HOST.NAME3} is unknown", "{HOST.NAME3} is unknown"},
+		{"Interface {#IFNAME} is down on {HOST.NAME}", "Interface {#IFNAME} is down on Database 01"},
+		{"No macros", "No macros"},
+	}
+	for _, tt := range tests {
+		if got := ExpandHostMacros(tt.text, hosts); got != tt.want {
+			t.Errorf("ExpandHostMacros(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestExpandTriggerName(t *testing.T) {
+	tests := []struct {
+		trigger Trigger
+		want    string
+	}{
+		{
+			Trigger{Description: "Load over $1 on {HOST.NAME}", Expression: "{13001}>5", Hosts: []Host{{Host: "app01"}}},
+			"Load over 5 on app01",
+		},
+		{
+			Trigger{Description: "Free space below $2 or $1", Expression: "last(/h/vfs.fs.size[/,pfree])<10 or min(/h/vfs.fs.size[/,free],5m)<1G"},
+			"Free space below 1G or 10",
+		},
+		{
+			Trigger{Description: "Costs $5", Expression: "{1}>0"},
+			"Costs $5",
+		},
+	}
+	for _, tt := range tests {
+		if got := expandTriggerName(tt.trigger); got != tt.want {
+			t.Errorf("expandTriggerName(%q) = %q, want %q", tt.trigger.Description, got, tt.want)
+		}
+	}
+}
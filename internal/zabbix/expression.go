@@ -0,0 +1,574 @@
+package zabbix
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Truth is the state of a trigger expression or of one of its conditions,
+// unknown when it depends on values that can't be evaluated.
+type Truth int
+
+// Truth constants.
+const (
+	TruthUnknown Truth = iota
+	TruthFalse
+	TruthTrue
+)
+
+// String returns "true", "false" or "unknown".
+func (t Truth) String() string {
+	switch t {
+	case TruthTrue:
+		return "true"
+	case TruthFalse:
+		return "false"
+	}
+	return "unknown"
+}
+
+// Condition is a comparison of a trigger expression, or another operand of
+// its and, or and not operators, with its current state.
+type Condition struct {
+	Text   string // As written, e.g. "avg(/web01/system.cpu.util,5m) > 90"
+	Values string // With the values of its functions, e.g. "93.5 > 90"
+	State  Truth
+}
+
+// exprValue is the value of a trigger expression or of part of it. Values
+// chotko can't compute, such as those of functions it does not implement,
+// are unknown.
+type exprValue struct {
+	num   float64
+	str   string
+	isStr bool
+	known bool
+}
+
+// numValue returns a known number.
+func numValue(f float64) exprValue {
+	return exprValue{num: f, known: true}
+}
+
+// strValue returns a known string.
+func strValue(s string) exprValue {
+	return exprValue{str: s, isStr: true, known: true}
+}
+
+// boolValue returns 1 for true and 0 for false, as Zabbix does.
+func boolValue(b bool) exprValue {
+	if b {
+		return numValue(1)
+	}
+	return numValue(0)
+}
+
+// truth returns whether the value is a non-zero number.
+func (v exprValue) truth() Truth {
+	switch {
+	case !v.known || v.isStr:
+		return TruthUnknown
+	case v.num != 0:
+		return TruthTrue
+	}
+	return TruthFalse
+}
+
+// String formats the value as in an expression, "?" when unknown.
+func (v exprValue) String() string {
+	switch {
+	case !v.known:
+		return "?"
+	case v.isStr:
+		return strconv.Quote(v.str)
+	}
+	return strconv.FormatFloat(math.Round(v.num*1e4)/1e4, 'f', -1, 64)
+}
+
+// exprFunction is a function of a trigger expression, referenced as {ID}.
+type exprFunction struct {
+	Text  string // How it is shown, e.g. "last(/web01/system.cpu.util)"
+	Value exprValue
+}
+
+// exprNode is a node of a parsed trigger expression: an operator with its
+// operands, or an operand.
+type exprNode struct {
+	op    string      // Operator, "()" for parentheses, "call" or empty for operands
+	left  *exprNode   // Operand of unary operators and parentheses
+	right *exprNode   // Second operand of binary operators
+	args  []*exprNode // Arguments of calls
+	kind  tokenKind   // Kind of operand
+	text  string      // Operand or name of the called function, as written
+	ref   string      // Function ID of function operands
+}
+
+// tokenKind is the kind of a token of a trigger expression.
+type tokenKind int
+
+const (
+	tokenNumber   tokenKind = iota + 1
+	tokenString             // Quoted
+	tokenFunction           // Function reference, e.g. {12345}
+	tokenOpaque             // Operand that can't be evaluated, e.g. an unknown macro
+	tokenCall               // Name of a function called with expressions
+	tokenOperator
+)
+
+// exprToken is a token of a trigger expression.
+type exprToken struct {
+	kind tokenKind
+	text string
+	ref  string   // Function ID of function references
+	args []string // Arguments of calls, as written
+}
+
+// functionRef matches a function reference, e.g. "{12345}": the raw
+// expression references its item functions by ID.
+var functionRef = regexp.MustCompile(`^\{(\d+)\}$`)
+
+// exprNumber matches a number with an optional unit suffix.
+var exprNumber = regexp.MustCompile(`^(?:\d+(?:\.\d*)?|\.\d+)[KMGTsmhdw]?`)
+
+// exprWord matches operator keywords and function names.
+var exprWord = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.]*`)
+
+// exprSuffixes are the multipliers of number suffixes.
+var exprSuffixes = map[byte]float64{
+	'K': 1024, 'M': 1024 * 1024, 'G': 1024 * 1024 * 1024, 'T': 1024 * 1024 * 1024 * 1024,
+	's': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 7 * 86400,
+}
+
+// exprLevels are the binary operators by precedence, lowest first.
+var exprLevels = [][]string{{"or"}, {"and"}, {"=", "<>"}, {"<", "<=", ">", ">="}, {"+", "-"}, {"*", "/"}}
+
+// tokenizeExpression splits a trigger expression into tokens.
+func tokenizeExpression(s string) ([]exprToken, error) {
+	var tokens []exprToken
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '"':
+			end, text, err := scanString(s, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, exprToken{kind: tokenString, text: text})
+			i = end
+		case c == '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed { at %d", i)
+			}
+			text := s[i : i+end+1]
+			tok := exprToken{kind: tokenOpaque, text: text}
+			if m := functionRef.FindStringSubmatch(text); m != nil {
+				tok = exprToken{kind: tokenFunction, text: text, ref: m[1]}
+			}
+			tokens = append(tokens, tok)
+			i += end + 1
+		case exprNumber.MatchString(s[i:]):
+			n := exprNumber.FindString(s[i:])
+			tokens = append(tokens, exprToken{kind: tokenNumber, text: n})
+			i += len(n)
+		case exprWord.MatchString(s[i:]):
+			word := exprWord.FindString(s[i:])
+			i += len(word)
+			if word == "and" || word == "or" || word == "not" {
+				tokens = append(tokens, exprToken{kind: tokenOperator, text: word})
+				continue
+			}
+			rest := strings.TrimLeft(s[i:], " ")
+			if !strings.HasPrefix(rest, "(") {
+				return nil, fmt.Errorf("unexpected %q", word)
+			}
+			i = len(s) - len(rest)
+			end, args, err := scanArgs(s, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, callToken(word, s[i:end], args))
+			i = end
+		default:
+			op, width := string(c), 1
+			if two := s[i:min(i+2, len(s))]; two == "<=" || two == ">=" || two == "<>" {
+				op, width = two, 2
+			}
+			if op == "#" { // Not equal before Zabbix 5.4
+				op = "<>"
+			}
+			if !strings.Contains("( ) + - * / < > = <= >= <>", op) {
+				return nil, fmt.Errorf("unexpected %q", op)
+			}
+			tokens = append(tokens, exprToken{kind: tokenOperator, text: op})
+			i += width
+		}
+	}
+	return tokens, nil
+}
+
+// scanString returns the end of the quoted string starting at s[start] and
+// its text, unescaped.
+func scanString(s string, start int) (end int, text string, err error) {
+	var b strings.Builder
+	for i := start + 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s):
+			i++
+			b.WriteByte(s[i])
+		case s[i] == '"':
+			return i + 1, b.String(), nil
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return 0, "", fmt.Errorf("unclosed string at %d", start)
+}
+
+// scanArgs returns the end of the argument list starting with the
+// parenthesis at s[start] and its arguments, split at the commas outside
+// strings, parentheses and item key brackets.
+func scanArgs(s string, start int) (end int, args []string, err error) {
+	depth := 0
+	argStart := start + 1
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			if i, _, err = scanString(s, i); err != nil {
+				return 0, nil, err
+			}
+			i-- // Back to the closing quote
+		case '(', '[':
+			depth++
+		case ']':
+			depth--
+		case ')':
+			depth--
+			if depth == 0 {
+				if arg := strings.TrimSpace(s[argStart:i]); arg != "" || len(args) > 0 {
+					args = append(args, arg)
+				}
+				return i + 1, args, nil
+			}
+		case ',':
+			if depth == 1 {
+				args = append(args, strings.TrimSpace(s[argStart:i]))
+				argStart = i + 1
+			}
+		}
+	}
+	return 0, nil, fmt.Errorf("unclosed ( at %d", start)
+}
+
+// callToken returns the token of a function called with args, raw being
+// its argument list as written: opaque when its first argument is an item,
+// as in "last(/web01/system.cpu.util)", whose function has no ID, and
+// otherwise a call of a function of expressions, such as abs({12345}).
+func callToken(name, raw string, args []string) exprToken {
+	if len(args) > 0 && strings.HasPrefix(args[0], "/") {
+		return exprToken{kind: tokenOpaque, text: name + raw}
+	}
+	return exprToken{kind: tokenCall, text: name, args: args}
+}
+
+// exprParser parses the tokens of a trigger expression.
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+// parseExpression parses a trigger expression.
+func parseExpression(s string) (*exprNode, error) {
+	tokens, err := tokenizeExpression(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	node, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	return node, nil
+}
+
+// peekOperator returns the next token if it is an operator, else empty.
+func (p *exprParser) peekOperator() string {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenOperator {
+		return p.tokens[p.pos].text
+	}
+	return ""
+}
+
+// parseBinary parses the operators of a precedence level and above.
+func (p *exprParser) parseBinary(level int) (*exprNode, error) {
+	if level == len(exprLevels) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peekOperator()
+		if op == "" || !slices.Contains(exprLevels[level], op) {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &exprNode{op: op, left: left, right: right}
+	}
+}
+
+// parseUnary parses unary minus and not, which bind tightest.
+func (p *exprParser) parseUnary() (*exprNode, error) {
+	if op := p.peekOperator(); op == "-" || op == "not" {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprNode{op: op, left: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses an operand or an expression in parentheses.
+func (p *exprParser) parsePrimary() (*exprNode, error) {
+	if p.pos == len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+	switch tok.kind {
+	case tokenNumber, tokenString, tokenFunction, tokenOpaque:
+		return &exprNode{kind: tok.kind, text: tok.text, ref: tok.ref}, nil
+	case tokenCall:
+		node := &exprNode{op: "call", text: tok.text}
+		for _, arg := range tok.args {
+			parsed, err := parseExpression(arg)
+			if err != nil {
+				return nil, err
+			}
+			node.args = append(node.args, parsed)
+		}
+		return node, nil
+	case tokenOperator:
+		if tok.text != "(" {
+			break
+		}
+		inner, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		if p.peekOperator() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return &exprNode{op: "()", left: inner}, nil
+	}
+	return nil, fmt.Errorf("unexpected %q", tok.text)
+}
+
+// eval returns the value of the expression, with the values of its
+// functions by ID.
+func (n *exprNode) eval(functions map[string]exprFunction) exprValue {
+	switch n.op {
+	case "":
+		return n.operand(functions)
+	case "()":
+		return n.left.eval(functions)
+	case "call":
+		return exprValue{}
+	case "-":
+		if n.right == nil {
+			v := n.left.eval(functions)
+			if !v.known || v.isStr {
+				return exprValue{}
+			}
+			return numValue(-v.num)
+		}
+	case "not":
+		switch n.left.eval(functions).truth() {
+		case TruthTrue:
+			return numValue(0)
+		case TruthFalse:
+			return numValue(1)
+		}
+		return exprValue{}
+	case "and", "or":
+		// A false operand of and, or a true one of or, decides the result
+		// even when the other is unknown, as in Zabbix
+		decisive := TruthFalse
+		if n.op == "or" {
+			decisive = TruthTrue
+		}
+		l, r := n.left.eval(functions).truth(), n.right.eval(functions).truth()
+		switch {
+		case l == decisive || r == decisive:
+			return boolValue(decisive == TruthTrue)
+		case l == TruthUnknown || r == TruthUnknown:
+			return exprValue{}
+		}
+		return boolValue(decisive != TruthTrue)
+	}
+
+	l, r := n.left.eval(functions), n.right.eval(functions)
+	if !l.known || !r.known {
+		return exprValue{}
+	}
+	if l.isStr || r.isStr {
+		switch n.op {
+		case "=":
+			return boolValue(l.text() == r.text())
+		case "<>":
+			return boolValue(l.text() != r.text())
+		}
+		return exprValue{}
+	}
+	switch n.op {
+	case "=":
+		return boolValue(math.Abs(l.num-r.num) <= exprEpsilon)
+	case "<>":
+		return boolValue(math.Abs(l.num-r.num) > exprEpsilon)
+	case "<":
+		return boolValue(l.num < r.num)
+	case "<=":
+		return boolValue(l.num <= r.num)
+	case ">":
+		return boolValue(l.num > r.num)
+	case ">=":
+		return boolValue(l.num >= r.num)
+	case "+":
+		return numValue(l.num + r.num)
+	case "-":
+		return numValue(l.num - r.num)
+	case "*":
+		return numValue(l.num * r.num)
+	case "/":
+		if r.num == 0 {
+			return exprValue{}
+		}
+		return numValue(l.num / r.num)
+	}
+	return exprValue{}
+}
+
+// exprEpsilon is how close numbers compared with = and <> are equal.
+const exprEpsilon = 0.000001
+
+// text returns a value as the string it is compared as.
+func (v exprValue) text() string {
+	if v.isStr {
+		return v.str
+	}
+	return strconv.FormatFloat(v.num, 'f', -1, 64)
+}
+
+// operand returns the value of an operand.
+func (n *exprNode) operand(functions map[string]exprFunction) exprValue {
+	switch n.kind {
+	case tokenNumber:
+		text := n.text
+		multiplier := 1.0
+		if m, ok := exprSuffixes[text[len(text)-1]]; ok {
+			text, multiplier = text[:len(text)-1], m
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return exprValue{}
+		}
+		return numValue(f * multiplier)
+	case tokenString:
+		return strValue(n.text)
+	case tokenFunction:
+		return functions[n.ref].Value
+	}
+	return exprValue{}
+}
+
+// render returns the expression as text, with each operand as given by
+// operand.
+func (n *exprNode) render(operand func(*exprNode) string) string {
+	switch n.op {
+	case "":
+		return operand(n)
+	case "()":
+		return "(" + n.left.render(operand) + ")"
+	case "call":
+		args := make([]string, len(n.args))
+		for i, arg := range n.args {
+			args[i] = arg.render(operand)
+		}
+		return n.text + "(" + strings.Join(args, ",") + ")"
+	case "not":
+		return "not " + n.left.render(operand)
+	case "-":
+		if n.right == nil {
+			return "-" + n.left.render(operand)
+		}
+	}
+	return n.left.render(operand) + " " + n.op + " " + n.right.render(operand)
+}
+
+// conditions returns the operands of the and, or and not operators of the
+// expression, or the expression itself if it has none.
+func (n *exprNode) conditions() []*exprNode {
+	switch n.op {
+	case "and", "or":
+		return append(n.left.conditions(), n.right.conditions()...)
+	case "not", "()":
+		return n.left.conditions()
+	}
+	return []*exprNode{n}
+}
+
+// evaluateExpression evaluates a trigger expression whose functions are
+// referenced by ID, such as "{12345}>90", with the values of the functions.
+// It returns the state of the expression, its conditions and the expression
+// with the functions as they are shown.
+func evaluateExpression(expression string, functions map[string]exprFunction) (Truth, []Condition, string, error) {
+	root, err := parseExpression(expression)
+	if err != nil {
+		return TruthUnknown, nil, "", fmt.Errorf("cannot parse expression: %w", err)
+	}
+
+	asWritten := func(n *exprNode) string {
+		switch n.kind {
+		case tokenFunction:
+			if f, ok := functions[n.ref]; ok && f.Text != "" {
+				return f.Text
+			}
+		case tokenString:
+			return strconv.Quote(n.text)
+		}
+		return n.text
+	}
+	withValues := func(n *exprNode) string {
+		if n.kind == tokenFunction {
+			return n.operand(functions).String()
+		}
+		return asWritten(n)
+	}
+
+	var conditions []Condition
+	for _, c := range root.conditions() {
+		conditions = append(conditions, Condition{
+			Text:   c.render(asWritten),
+			Values: c.render(withValues),
+			State:  c.eval(functions).truth(),
+		})
+	}
+	return root.eval(functions).truth(), conditions, root.render(asWritten), nil
+}
@@ -0,0 +1,123 @@
+package zabbix
+
+import (
+	"testing"
+)
+
+func TestEvaluateExpression(t *testing.T) {
+	functions := map[string]exprFunction{
+		"1": {Text: "last(/web01/system.cpu.util)", Value: numValue(93.5)},
+		"2": {Text: "avg(/web01/system.cpu.util,5m)", Value: numValue(40)},
+		"3": {Text: "last(/web01/agent.version)", Value: strValue("7.0.1")},
+		"4": {Text: "count(/web01/log,1h)", Value: exprValue{}},
+		"5": {Text: "last(/web01/vfs.fs.size[/,free])", Value: numValue(2 * 1024 * 1024 * 1024)},
+	}
+
+	tests := []struct {
+		expression string
+		want       Truth
+	}{
+		{"{1}>90", TruthTrue},
+		{"{1}>90 and {2}>50", TruthFalse},
+		{"{1}>90 or {2}>50", TruthTrue},
+		{"{1}-{2}>50", TruthTrue},
+		{"{1}>{2}*2", TruthTrue},
+		{"not {1}>90", TruthFalse},
+		{"({1}>90 or {2}>90) and {5}<1G", TruthFalse},
+		{"{5}<3G", TruthTrue},
+		{"{2}=40", TruthTrue},
+		{"{2}#40", TruthFalse},
+		{"{2}<>40", TruthFalse},
+		{"-{2}<0", TruthTrue},
+		{`{3}="7.0.1"`, TruthTrue},
+		{`{3}<>"7.0.1"`, TruthFalse},
+		{"{3}>1", TruthUnknown},
+		// Unknown values decide nothing unless the other operand does
+		{"{4}>0", TruthUnknown},
+		{"{4}>0 or {1}>90", TruthTrue},
+		{"{4}>0 and {1}>90", TruthUnknown},
+		{"{4}>0 and {1}>95", TruthFalse},
+		{"{1}/0>1", TruthUnknown},
+		{"abs({2})>1", TruthUnknown},
+		{"last(/web01/system.cpu.util)>90", TruthUnknown},
+		{"{9}>1", TruthUnknown},
+	}
+
+	for _, tt := range tests {
+		got, _, _, err := evaluateExpression(tt.expression, functions)
+		if err != nil {
+			t.Errorf("evaluateExpression(%q) error = %v", tt.expression, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("evaluateExpression(%q) = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateExpression_Conditions(t *testing.T) {
+	functions := map[string]exprFunction{
+		"1": {Text: "last(/web01/system.cpu.util)", Value: numValue(93.51234)},
+		"2": {Text: "avg(/web01/system.cpu.util,5m)", Value: numValue(40)},
+		"3": {Text: "nodata(/web01/agent.ping,5m)", Value: exprValue{}},
+	}
+
+	state, conditions, expression, err := evaluateExpression("({1}>90 and {2}>50) or {3}=1", functions)
+	if err != nil {
+		t.Fatalf("evaluateExpression() error = %v", err)
+	}
+	if state != TruthUnknown {
+		t.Errorf("state = %v, want unknown", state)
+	}
+	if want := "(last(/web01/system.cpu.util) > 90 and avg(/web01/system.cpu.util,5m) > 50) or nodata(/web01/agent.ping,5m) = 1"; expression != want {
+		t.Errorf("expression = %q, want %q", expression, want)
+	}
+
+	want := []Condition{
+		{Text: "last(/web01/system.cpu.util) > 90", Values: "93.5123 > 90", State: TruthTrue},
+		{Text: "avg(/web01/system.cpu.util,5m) > 50", Values: "40 > 50", State: TruthFalse},
+		{Text: "nodata(/web01/agent.ping,5m) = 1", Values: "? = 1", State: TruthUnknown},
+	}
+	if len(conditions) != len(want) {
+		t.Fatalf("conditions = %+v, want %+v", conditions, want)
+	}
+	for i := range want {
+		if conditions[i] != want[i] {
+			t.Errorf("condition %d = %+v, want %+v", i, conditions[i], want[i])
+		}
+	}
+}
+
+func TestEvaluateExpression_Errors(t *testing.T) {
+	for _, expression := range []string{"", "{1}>", "({1}>1", "{1}>1)", `{1}="open`, "{1}>1 ~ 2", "last({1}"} {
+		if _, _, _, err := evaluateExpression(expression, nil); err == nil {
+			t.Errorf("evaluateExpression(%q) error = nil, want an error", expression)
+		}
+	}
+}
+
+func TestFunctionArgs(t *testing.T) {
+	tests := []struct {
+		parameter string
+		want      []string
+	}{
+		{"$", nil},
+		{"$,5m", []string{"5m"}},
+		{"#3", []string{"#3"}},
+		{`$,"a,b",#2`, []string{`"a,b"`, "#2"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		got := functionArgs(tt.parameter)
+		if len(got) != len(tt.want) {
+			t.Errorf("functionArgs(%q) = %q, want %q", tt.parameter, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("functionArgs(%q) = %q, want %q", tt.parameter, got, tt.want)
+			}
+		}
+	}
+}
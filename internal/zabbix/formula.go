@@ -0,0 +1,217 @@
+package zabbix
+
+import (
+	"slices"
+	"strings"
+)
+
+// Item types computing values from other items.
+const (
+	ItemTypeAggregate  = "8"  // Zabbix aggregate, before Zabbix 5.4
+	ItemTypeCalculated = "15" // Calculated
+)
+
+// ItemReference is an item a calculated or aggregate item's formula uses.
+type ItemReference struct {
+	Host string // Technical name; empty for the item's own host
+	Key  string // May contain * wildcards in aggregate functions
+}
+
+// String returns the reference in the formula syntax of Zabbix 5.4 and
+// later.
+func (r ItemReference) String() string {
+	return "/" + r.Host + "/" + r.Key
+}
+
+// Matches reports whether the item with the given key, on the host with the
+// given technical name, is referenced. ownHost is the technical name of the
+// referencing item's host.
+func (r ItemReference) Matches(ownHost, host, key string) bool {
+	refHost := r.Host
+	if refHost == "" || refHost == "{HOST.HOST}" {
+		refHost = ownHost
+	}
+	return wildcardMatch(refHost, host) && wildcardMatch(r.Key, key)
+}
+
+// Formula returns the formula of a calculated item, or the key of an
+// aggregate item, which holds its formula. Returns "" for other items.
+func (i *Item) Formula() string {
+	switch i.Type {
+	case ItemTypeCalculated:
+		return i.Params
+	case ItemTypeAggregate:
+		return i.Key
+	}
+	return ""
+}
+
+// References returns the items the formula of a calculated or aggregate
+// item uses, in order of first use. Returns nil for other items.
+func (i *Item) References() []ItemReference {
+	switch i.Type {
+	case ItemTypeCalculated:
+		return formulaReferences(i.Params)
+	case ItemTypeAggregate:
+		// grpfunc["group","key",func,param]: the key on the group's hosts
+		if params := keyParams(i.Key); len(params) > 1 {
+			return []ItemReference{{Host: "*", Key: params[1]}}
+		}
+	}
+	return nil
+}
+
+// formulaReferences returns the item queries of a formula, the first
+// parameter of its functions, such as /host/key in last(/host/key). Filters
+// of the queries are dropped.
+func formulaReferences(formula string) []ItemReference {
+	var refs []ItemReference
+	for i := 0; i < len(formula); i++ {
+		switch formula[i] {
+		case '"':
+			i = skipQuoted(formula, i)
+		case '(':
+			j := i + 1
+			for j < len(formula) && formula[j] == ' ' {
+				j++
+			}
+			ref, end, ok := parseItemQuery(formula, j)
+			if !ok {
+				continue
+			}
+			if !slices.Contains(refs, ref) {
+				refs = append(refs, ref)
+			}
+			i = end - 1
+		}
+	}
+	return refs
+}
+
+// parseItemQuery parses an item query /host/key[params]?[filter] at
+// formula[start:], returning it and the index after it.
+func parseItemQuery(formula string, start int) (ItemReference, int, bool) {
+	if start >= len(formula) || formula[start] != '/' {
+		return ItemReference{}, 0, false
+	}
+	hostEnd := strings.IndexByte(formula[start+1:], '/')
+	if hostEnd < 0 {
+		return ItemReference{}, 0, false
+	}
+	host := formula[start+1 : start+1+hostEnd]
+	if strings.ContainsAny(host, `()",`) {
+		return ItemReference{}, 0, false
+	}
+
+	keyStart := start + 1 + hostEnd + 1
+	end := keyStart
+	for end < len(formula) && isKeyChar(formula[end]) {
+		end++
+	}
+	if end == keyStart {
+		return ItemReference{}, 0, false
+	}
+	if end < len(formula) && formula[end] == '[' {
+		end = skipBrackets(formula, end)
+	}
+	key := formula[keyStart:end]
+	if strings.HasPrefix(formula[end:], "?[") {
+		end = skipBrackets(formula, end+1)
+	}
+	return ItemReference{Host: host, Key: key}, end, true
+}
+
+// isKeyChar reports whether c may be in the name of an item key, before
+// its parameters. * is a wildcard in aggregate functions.
+func isKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '-' || c == '.' || c == '*'
+}
+
+// skipQuoted returns the index of the quote closing the string starting at
+// s[start], or the last index if it is not closed.
+func skipQuoted(s string, start int) int {
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return len(s) - 1
+}
+
+// skipBrackets returns the index after the bracket matching the one at
+// s[start], skipping brackets in quoted strings, or len(s) if it is not
+// closed.
+func skipBrackets(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			i = skipQuoted(s, i)
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(s)
+}
+
+// keyParams returns the top-level parameters of an item key, unquoted.
+func keyParams(key string) []string {
+	open := strings.IndexByte(key, '[')
+	if open < 0 || !strings.HasSuffix(key, "]") {
+		return nil
+	}
+	var params []string
+	var param strings.Builder
+	depth := 0
+	for i := open + 1; i < len(key)-1; i++ {
+		c := key[i]
+		switch {
+		case c == '"' && depth == 0:
+			end := skipQuoted(key, i)
+			param.WriteString(strings.ReplaceAll(key[i+1:end], `\"`, `"`))
+			i = end
+		case c == '[':
+			depth++
+			param.WriteByte(c)
+		case c == ']':
+			depth--
+			param.WriteByte(c)
+		case c == ',' && depth == 0:
+			params = append(params, strings.TrimSpace(param.String()))
+			param.Reset()
+		default:
+			param.WriteByte(c)
+		}
+	}
+	return append(params, strings.TrimSpace(param.String()))
+}
+
+// wildcardMatch reports whether s matches pattern, where * matches any
+// run of characters.
+func wildcardMatch(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
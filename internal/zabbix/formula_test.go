@@ -0,0 +1,71 @@
+package zabbix
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestItem_References(t *testing.T) {
+	tests := []struct {
+		name string
+		item Item
+		want []ItemReference
+	}{
+		{
+			name: "calculated",
+			item: Item{Type: ItemTypeCalculated, Params: `100*last(//vfs.fs.size[/,free])/last(//vfs.fs.size[/,total])`},
+			want: []ItemReference{{Key: "vfs.fs.size[/,free]"}, {Key: "vfs.fs.size[/,total]"}},
+		},
+		{
+			name: "other hosts, repeated",
+			item: Item{Type: ItemTypeCalculated, Params: `last(/web-01/net.if.in["eth0"]) + last( /web-02/net.if.in["eth0"]) - avg(/web-01/net.if.in["eth0"],1h)`},
+			want: []ItemReference{{Host: "web-01", Key: `net.if.in["eth0"]`}, {Host: "web-02", Key: `net.if.in["eth0"]`}},
+		},
+		{
+			name: "aggregate function with a filter",
+			item: Item{Type: ItemTypeCalculated, Params: `sum(last_foreach(/*/system.cpu.util?[group="Linux servers"]))/count(/*/system.cpu.util)`},
+			want: []ItemReference{{Host: "*", Key: "system.cpu.util"}},
+		},
+		{
+			name: "strings are skipped",
+			item: Item{Type: ItemTypeCalculated, Params: `find(//log,,"like","(/x/y)")`},
+			want: []ItemReference{{Key: "log"}},
+		},
+		{
+			name: "aggregate item",
+			item: Item{Type: ItemTypeAggregate, Key: `grpavg["Linux servers","system.cpu.load[,avg1]",last]`},
+			want: []ItemReference{{Host: "*", Key: "system.cpu.load[,avg1]"}},
+		},
+		{
+			name: "other type",
+			item: Item{Type: ItemTypeAgent, Params: "last(//a)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.References(); !slices.Equal(got, tt.want) {
+				t.Errorf("References() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestItemReference_Matches(t *testing.T) {
+	tests := []struct {
+		ref       ItemReference
+		host, key string
+		want      bool
+	}{
+		{ItemReference{Key: "system.cpu.util"}, "web-01", "system.cpu.util", true},
+		{ItemReference{Key: "system.cpu.util"}, "web-02", "system.cpu.util", false},
+		{ItemReference{Host: "{HOST.HOST}", Key: "a"}, "web-01", "a", true},
+		{ItemReference{Host: "db-01", Key: "a"}, "db-01", "a", true},
+		{ItemReference{Host: "*", Key: "vfs.fs.size[*,pused]"}, "db-01", "vfs.fs.size[/var,pused]", true},
+		{ItemReference{Host: "*", Key: "vfs.fs.size[*,pused]"}, "db-01", "vfs.fs.size[/var,free]", false},
+	}
+	for _, tt := range tests {
+		if got := tt.ref.Matches("web-01", tt.host, tt.key); got != tt.want {
+			t.Errorf("%v.Matches(%q, %q) = %v, want %v", tt.ref, tt.host, tt.key, got, tt.want)
+		}
+	}
+}
@@ -13,16 +13,24 @@ type HostGetParams struct {
 	SelectInterfaces interface{} `json:"selectInterfaces,omitempty"`
 	// Select host groups
 	SelectHostGroups interface{} `json:"selectHostGroups,omitempty"`
+	// Select host groups (before Zabbix 6.2)
+	SelectGroups interface{} `json:"selectGroups,omitempty"`
 	// Select macros
 	SelectMacros interface{} `json:"selectMacros,omitempty"`
 	// Select triggers
 	SelectTriggers interface{} `json:"selectTriggers,omitempty"`
+	// Select the templates linked to the hosts
+	SelectParentTemplates interface{} `json:"selectParentTemplates,omitempty"`
+	// Select tags
+	SelectTags interface{} `json:"selectTags,omitempty"`
 	// Filter by host IDs
 	HostIDs []string `json:"hostids,omitempty"`
 	// Filter by group IDs
 	GroupIDs []string `json:"groupids,omitempty"`
 	// Filter by monitored hosts only
 	MonitoredHosts bool `json:"monitored_hosts,omitempty"`
+	// Filter by exact field values
+	Filter map[string]interface{} `json:"filter,omitempty"`
 	// Filter to hosts with problems
 	WithProblemsSuppressed *bool `json:"withProblemsSuppressed,omitempty"`
 	// Sort field
@@ -35,13 +43,18 @@ type HostGetParams struct {
 	Search map[string]string `json:"search,omitempty"`
 	// Enable wildcard search
 	SearchWildcardsEnabled bool `json:"searchWildcardsEnabled,omitempty"`
+	// Match any search field instead of all of them
+	SearchByAny bool `json:"searchByAny,omitempty"`
 }
 
 // DefaultHostGetParams returns default parameters for fetching hosts.
 func DefaultHostGetParams() HostGetParams {
 	return HostGetParams{
-		Output:           []string{"hostid", "host", "name", "status", "maintenance_status", "active_available"},
-		SelectInterfaces: []string{"interfaceid", "ip", "dns", "port", "type", "main", "available"},
+		Output: []string{
+			"hostid", "host", "name", "status", "active_available",
+			"maintenance_status", "maintenance_type", "maintenanceid", "maintenance_from",
+		},
+		SelectInterfaces: []string{"interfaceid", "ip", "dns", "useip", "port", "type", "main", "available", "details"},
 		SelectHostGroups: []string{"groupid", "name"},
 		MonitoredHosts:   true,
 		SortField:        []string{"name"},
@@ -52,30 +65,77 @@ func DefaultHostGetParams() HostGetParams {
 // GetHosts retrieves hosts from Zabbix.
 func (c *Client) GetHosts(ctx context.Context, params HostGetParams) ([]Host, error) {
 	var hosts []Host
-	if err := c.call(ctx, "host.get", params, &hosts); err != nil {
+	if err := c.call(ctx, "host.get", c.adaptHostParams(params), &hosts); err != nil {
 		return nil, fmt.Errorf("failed to get hosts: %w", err)
 	}
 	return hosts, nil
 }
 
-// GetAllHosts retrieves all monitored hosts.
+// GetAllHosts retrieves all hosts, including those that are not monitored.
 func (c *Client) GetAllHosts(ctx context.Context) ([]Host, error) {
 	params := DefaultHostGetParams()
+	params.MonitoredHosts = false
 	return c.GetHosts(ctx, params)
 }
 
-// GetHostCounts retrieves aggregated host status counts.
-func (c *Client) GetHostCounts(ctx context.Context) (*HostCounts, error) {
-	hosts, err := c.GetAllHosts(ctx)
+// hostGroupGetParams defines parameters for hostgroup.get API call.
+type hostGroupGetParams struct {
+	Output   []string               `json:"output"`
+	GroupIDs []string               `json:"groupids,omitempty"`
+	Filter   map[string]interface{} `json:"filter,omitempty"`
+}
+
+// GetHostGroupsByName retrieves the host groups with these exact names, in
+// no particular order. Names that match no group are left out.
+func (c *Client) GetHostGroupsByName(ctx context.Context, names []string) ([]HostGroup, error) {
+	params := hostGroupGetParams{
+		Output: []string{"groupid", "name"},
+		Filter: map[string]interface{}{"name": names},
+	}
+	var groups []HostGroup
+	if err := c.call(ctx, "hostgroup.get", params, &groups); err != nil {
+		return nil, fmt.Errorf("failed to get host groups: %w", err)
+	}
+	return groups, nil
+}
+
+// hostCountParams returns parameters fetching only what CountHosts needs of
+// the monitored hosts in groupIDs, or of all of them if groupIDs is empty: no
+// names, groups or interface addresses, and no server-side sorting.
+func hostCountParams(groupIDs []string) HostGetParams {
+	return HostGetParams{
+		Output:           []string{"hostid", "status", "active_available", "maintenance_status"},
+		SelectInterfaces: []string{"available"},
+		GroupIDs:         groupIDs,
+		MonitoredHosts:   true,
+	}
+}
+
+// GetHostCounts retrieves aggregated status counts of the monitored hosts in
+// groupIDs, or of all of them if groupIDs is empty.
+// Availability depends on each host's interfaces, which host.get can't filter
+// on, so rather than count per state it fetches the hosts with the minimum of
+// fields needed to classify them.
+func (c *Client) GetHostCounts(ctx context.Context, groupIDs []string) (*HostCounts, error) {
+	hosts, err := c.GetHosts(ctx, hostCountParams(groupIDs))
 	if err != nil {
 		return nil, err
 	}
+	return CountHosts(hosts), nil
+}
 
-	counts := &HostCounts{
-		Total: len(hosts),
-	}
+// CountHosts aggregates the status counts of the monitored hosts among
+// hosts, so a host list already fetched can stand in for GetHostCounts.
+func CountHosts(hosts []Host) *HostCounts {
+	counts := &HostCounts{}
+
+	for i := range hosts {
+		h := &hosts[i]
+		if !h.IsMonitored() {
+			continue
+		}
+		counts.Total++
 
-	for _, h := range hosts {
 		if h.InMaintenance() {
 			counts.Maintenance++
 			continue
@@ -97,7 +157,7 @@ func (c *Client) GetHostCounts(ctx context.Context) (*HostCounts, error) {
 		}
 	}
 
-	return counts, nil
+	return counts
 }
 
 // GetHost retrieves a single host by ID.
@@ -111,7 +171,7 @@ func (c *Client) GetHost(ctx context.Context, hostID string) (*Host, error) {
 	}
 
 	if len(hosts) == 0 {
-		return nil, fmt.Errorf("host not found: %s", hostID)
+		return nil, fmt.Errorf("host %s: %w", hostID, ErrNotFound)
 	}
 
 	return &hosts[0], nil
@@ -126,11 +186,22 @@ func (c *Client) SearchHosts(ctx context.Context, pattern string) ([]Host, error
 	return c.GetHosts(ctx, params)
 }
 
+// FindHosts retrieves hosts whose visible or technical name contains text
+// (case-insensitive), including those that are not monitored.
+func (c *Client) FindHosts(ctx context.Context, text string) ([]Host, error) {
+	params := DefaultHostGetParams()
+	params.MonitoredHosts = false
+	params.Search = map[string]string{"name": text, "host": text}
+	params.SearchByAny = true
+
+	return c.GetHosts(ctx, params)
+}
+
 // GetHostWithDetails retrieves a host with extended details including macros and triggers.
 func (c *Client) GetHostWithDetails(ctx context.Context, hostID string) (*Host, error) {
 	params := HostGetParams{
 		Output:           "extend",
-		SelectInterfaces: []string{"interfaceid", "ip", "dns", "port", "type", "main", "available"},
+		SelectInterfaces: []string{"interfaceid", "ip", "dns", "useip", "port", "type", "main", "available", "details"},
 		SelectHostGroups: []string{"groupid", "name"},
 		SelectMacros:     "extend",
 		SelectTriggers:   []string{"triggerid", "description", "priority", "status", "value"},
@@ -143,7 +214,7 @@ func (c *Client) GetHostWithDetails(ctx context.Context, hostID string) (*Host,
 	}
 
 	if len(hosts) == 0 {
-		return nil, fmt.Errorf("host not found: %s", hostID)
+		return nil, fmt.Errorf("host %s: %w", hostID, ErrNotFound)
 	}
 
 	return &hosts[0], nil
@@ -213,8 +284,9 @@ func (c *Client) SetHostMacros(ctx context.Context, hostID string, macros []Host
 
 // UserMacroGetParams defines parameters for usermacro.get API call.
 type UserMacroGetParams struct {
-	Output  interface{} `json:"output,omitempty"`
-	HostIDs []string    `json:"hostids,omitempty"`
+	Output      interface{} `json:"output,omitempty"`
+	HostIDs     []string    `json:"hostids,omitempty"`
+	GlobalMacro bool        `json:"globalmacro,omitempty"`
 }
 
 // GetHostMacros retrieves all macros for a specific host.
@@ -2,6 +2,8 @@ package zabbix
 
 import (
 	"context"
+	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -114,6 +116,27 @@ func TestClient_GetAllHosts(t *testing.T) {
 	}
 }
 
+func TestClient_GetHostGroupsByName(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"hostgroup.get": {Result: []HostGroup{{GroupID: "2", Name: "Linux servers"}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	groups, err := client.GetHostGroupsByName(context.Background(), []string{"Linux servers", "Missing"})
+	if err != nil {
+		t.Fatalf("GetHostGroupsByName() error = %v", err)
+	}
+	if len(groups) != 1 || groups[0].GroupID != "2" {
+		t.Errorf("GetHostGroupsByName() = %v, want Linux servers", groups)
+	}
+	filter, _ := params["hostgroup.get"]["filter"].(map[string]any)
+	if names, _ := filter["name"].([]any); len(names) != 2 {
+		t.Errorf("hostgroup.get filter = %v, want both names", params["hostgroup.get"]["filter"])
+	}
+}
+
 func TestClient_GetHostCounts(t *testing.T) {
 	// Test the critical availability mapping:
 	// 0 = Unknown
@@ -139,7 +162,7 @@ func TestClient_GetHostCounts(t *testing.T) {
 	client := newTestClient(t, server.URL)
 	client.token = "test-token"
 
-	counts, err := client.GetHostCounts(context.Background())
+	counts, err := client.GetHostCounts(context.Background(), nil)
 	if err != nil {
 		t.Fatalf("GetHostCounts() error = %v", err)
 	}
@@ -171,6 +194,55 @@ func TestClient_GetHostCounts(t *testing.T) {
 	}
 }
 
+func TestClient_GetHostCounts_MinimalOutput(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"host.get": {Result: []Host{{HostID: "1", Status: "0", Interfaces: []Interface{{Available: "1"}}}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	counts, err := client.GetHostCounts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetHostCounts() error = %v", err)
+	}
+	if counts.OK != 1 || counts.Total != 1 {
+		t.Errorf("counts = %+v, want 1 OK host", counts)
+	}
+
+	got := params["host.get"]
+	if got["monitored_hosts"] != true {
+		t.Error("host.get monitored_hosts not set")
+	}
+	for _, field := range []string{"selectHostGroups", "selectGroups", "sortfield"} {
+		if _, ok := got[field]; ok {
+			t.Errorf("host.get requested %s, not needed for counting", field)
+		}
+	}
+	if output, _ := got["output"].([]any); slices.Contains(output, any("name")) {
+		t.Errorf("host.get output = %v, want no host names", output)
+	}
+	if ifaces, _ := got["selectInterfaces"].([]any); len(ifaces) != 1 || ifaces[0] != "available" {
+		t.Errorf("host.get selectInterfaces = %v, want only available", got["selectInterfaces"])
+	}
+}
+
+func TestClient_GetHostCounts_Groups(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"host.get": {Result: []Host{}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if _, err := client.GetHostCounts(context.Background(), []string{"2", "4"}); err != nil {
+		t.Fatalf("GetHostCounts() error = %v", err)
+	}
+	if got := params["host.get"]["groupids"]; !reflect.DeepEqual(got, []any{"2", "4"}) {
+		t.Errorf("host.get groupids = %v, want [2 4]", got)
+	}
+}
+
 func TestClient_GetHostCounts_Empty(t *testing.T) {
 	server := newMockServer(t, map[string]mockResponse{
 		"host.get": {Result: []Host{}},
@@ -180,7 +252,7 @@ func TestClient_GetHostCounts_Empty(t *testing.T) {
 	client := newTestClient(t, server.URL)
 	client.token = "test-token"
 
-	counts, err := client.GetHostCounts(context.Background())
+	counts, err := client.GetHostCounts(context.Background(), nil)
 	if err != nil {
 		t.Fatalf("GetHostCounts() error = %v", err)
 	}
@@ -190,6 +262,22 @@ func TestClient_GetHostCounts_Empty(t *testing.T) {
 	}
 }
 
+func TestCountHosts(t *testing.T) {
+	// Disabled hosts are left out, as GetHostCounts only sees monitored hosts
+	counts := CountHosts([]Host{
+		{HostID: "1", Status: "0", MaintenanceStatus: "0", ActiveAvailable: "1"},
+		{HostID: "2", Status: "0", MaintenanceStatus: "0", ActiveAvailable: "2"},
+		{HostID: "3", Status: "0", MaintenanceStatus: "1", ActiveAvailable: "2"},
+		{HostID: "4", Status: "1", MaintenanceStatus: "0", ActiveAvailable: "1"},
+		{HostID: "5", Status: "1", MaintenanceStatus: "0", ActiveAvailable: "0"},
+	})
+
+	want := HostCounts{OK: 1, Problem: 1, Maintenance: 1, Total: 3}
+	if *counts != want {
+		t.Errorf("CountHosts() = %+v, want %+v", *counts, want)
+	}
+}
+
 func TestClient_GetHost(t *testing.T) {
 	server := newMockServer(t, map[string]mockResponse{
 		"host.get": {
@@ -255,3 +343,28 @@ func TestClient_SearchHosts(t *testing.T) {
 		t.Errorf("len(hosts) = %d, want 2", len(hosts))
 	}
 }
+
+func TestClient_FindHosts(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"host.get": {Result: []Host{{HostID: "1", Host: "web-server-1", Name: "Web Server 1"}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	hosts, err := client.FindHosts(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("FindHosts() error = %v", err)
+	}
+	if len(hosts) != 1 {
+		t.Errorf("len(hosts) = %d, want 1", len(hosts))
+	}
+
+	search, _ := params["host.get"]["search"].(map[string]any)
+	if search["name"] != "web" || search["host"] != "web" {
+		t.Errorf("host.get search = %v, want name and host", params["host.get"]["search"])
+	}
+	if params["host.get"]["searchByAny"] != true {
+		t.Error("host.get searchByAny not set")
+	}
+}
@@ -0,0 +1,165 @@
+package zabbix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// InterfaceTypeSNMP is the interface type of an SNMP interface.
+const InterfaceTypeSNMP = "2"
+
+// SNMP versions of InterfaceDetails.Version.
+const (
+	SNMPv1  = "1"
+	SNMPv2c = "2"
+	SNMPv3  = "3"
+)
+
+// InterfaceDetails are the SNMP settings of an SNMP interface.
+type InterfaceDetails struct {
+	Version   string `json:"version"`             // SNMPv1, SNMPv2c or SNMPv3
+	Bulk      string `json:"bulk"`                // 1 = bulk requests
+	Community string `json:"community,omitempty"` // v1 and v2c
+	// MaxRepetitions is the max-repetitions of bulk requests (Zabbix 6.4+)
+	MaxRepetitions string `json:"max_repetitions,omitempty"`
+	SecurityName   string `json:"securityname,omitempty"`  // v3
+	SecurityLevel  string `json:"securitylevel,omitempty"` // v3: 0 = noAuthNoPriv, 1 = authNoPriv, 2 = authPriv
+	AuthProtocol   string `json:"authprotocol,omitempty"`  // v3
+	PrivProtocol   string `json:"privprotocol,omitempty"`  // v3
+	ContextName    string `json:"contextname,omitempty"`   // v3
+}
+
+// UnmarshalJSON decodes the details, which interfaces other than SNMP ones
+// return as an empty list.
+func (d *InterfaceDetails) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		*d = InterfaceDetails{}
+		return nil
+	}
+	type details InterfaceDetails
+	return json.Unmarshal(data, (*details)(d))
+}
+
+// VersionName returns the SNMP version, e.g. "SNMPv2c".
+func (d *InterfaceDetails) VersionName() string {
+	switch d.Version {
+	case SNMPv1:
+		return "SNMPv1"
+	case SNMPv2c:
+		return "SNMPv2c"
+	case SNMPv3:
+		return "SNMPv3"
+	}
+	return "SNMP"
+}
+
+// UsesBulk reports whether bulk requests are used.
+func (d *InterfaceDetails) UsesBulk() bool {
+	return d.Bulk == "1"
+}
+
+// SecurityLevelName returns the SNMPv3 security level, e.g. "authPriv".
+func (d *InterfaceDetails) SecurityLevelName() string {
+	switch d.SecurityLevel {
+	case "1":
+		return "authNoPriv"
+	case "2":
+		return "authPriv"
+	}
+	return "noAuthNoPriv"
+}
+
+// authProtocols and privProtocols name the SNMPv3 protocols by their API
+// values.
+var (
+	authProtocols = []string{"MD5", "SHA1", "SHA224", "SHA256", "SHA384", "SHA512"}
+	privProtocols = []string{"DES", "AES128", "AES192", "AES256", "AES192C", "AES256C"}
+)
+
+// Protocols returns the SNMPv3 authentication and privacy protocols in
+// effect at the security level, e.g. "SHA1/AES128", or empty without
+// authentication.
+func (d *InterfaceDetails) Protocols() string {
+	name := func(names []string, value string) string {
+		for i, n := range names {
+			if value == strconv.Itoa(i) {
+				return n
+			}
+		}
+		return value
+	}
+	switch d.SecurityLevel {
+	case "1":
+		return name(authProtocols, d.AuthProtocol)
+	case "2":
+		return name(authProtocols, d.AuthProtocol) + "/" + name(privProtocols, d.PrivProtocol)
+	}
+	return ""
+}
+
+// IsSNMP reports whether the interface is an SNMP interface.
+func (i *Interface) IsSNMP() bool {
+	return i.Type == InterfaceTypeSNMP
+}
+
+// GetHostInterfaces returns the interfaces of a host, with the SNMP
+// details of SNMP interfaces.
+func (c *Client) GetHostInterfaces(ctx context.Context, hostID string) ([]Interface, error) {
+	params := map[string]interface{}{
+		"output":  []string{"interfaceid", "ip", "dns", "useip", "port", "type", "main", "details"},
+		"hostids": []string{hostID},
+	}
+
+	var ifaces []Interface
+	if err := c.call(ctx, "hostinterface.get", params, &ifaces); err != nil {
+		return nil, fmt.Errorf("failed to get interfaces: %w", err)
+	}
+	return ifaces, nil
+}
+
+// UpdateInterface updates the address and port of an interface, and the
+// SNMP details of SNMPv1 and SNMPv2c interfaces. SNMPv3 details are left
+// alone, as their passphrases are not returned to be sent back.
+func (c *Client) UpdateInterface(ctx context.Context, iface Interface) error {
+	params := map[string]interface{}{
+		"interfaceid": iface.InterfaceID,
+		"port":        iface.Port,
+	}
+	if iface.UsesDNS() {
+		params["useip"], params["dns"] = "0", iface.DNS
+	} else {
+		params["useip"], params["ip"] = "1", iface.IP
+	}
+	if iface.IsSNMP() && iface.Details != nil && iface.Details.Version != SNMPv3 {
+		details := map[string]string{
+			"version":   iface.Details.Version,
+			"bulk":      iface.Details.Bulk,
+			"community": iface.Details.Community,
+		}
+		if iface.Details.MaxRepetitions != "" {
+			details["max_repetitions"] = iface.Details.MaxRepetitions
+		}
+		params["details"] = details
+	}
+
+	var result struct {
+		InterfaceIDs []string `json:"interfaceids"`
+	}
+	if err := c.call(ctx, "hostinterface.update", params, &result); err != nil {
+		return fmt.Errorf("failed to update interface: %w", err)
+	}
+	return nil
+}
+
+// MaskCommunity returns an SNMP community to show: macros as they are, as
+// they name rather than hold the secret, and other values masked.
+func MaskCommunity(community string) string {
+	if community == "" || strings.HasPrefix(community, "{$") {
+		return community
+	}
+	return "******"
+}
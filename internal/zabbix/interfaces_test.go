@@ -0,0 +1,74 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestInterface_Details(t *testing.T) {
+	var ifaces []Interface
+	data := `[
+		{"interfaceid": "1", "type": "1", "details": []},
+		{"interfaceid": "2", "type": "2", "details": {"version": "3", "bulk": "1", "securityname": "monitor",
+			"securitylevel": "2", "authprotocol": "1", "privprotocol": "1", "max_repetitions": "10"}}
+	]`
+	if err := json.Unmarshal([]byte(data), &ifaces); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if d := ifaces[0].Details; d == nil || *d != (InterfaceDetails{}) {
+		t.Errorf("agent interface details = %+v, want empty", d)
+	}
+	d := ifaces[1].Details
+	if d == nil || d.VersionName() != "SNMPv3" || !d.UsesBulk() || d.SecurityLevelName() != "authPriv" || d.Protocols() != "SHA1/AES128" {
+		t.Errorf("SNMP details = %+v, want SNMPv3 authPriv SHA1/AES128 with bulk", d)
+	}
+}
+
+func TestMaskCommunity(t *testing.T) {
+	for community, want := range map[string]string{"public": "******", "{$SNMP_COMMUNITY}": "{$SNMP_COMMUNITY}", "": ""} {
+		if got := MaskCommunity(community); got != want {
+			t.Errorf("MaskCommunity(%q) = %q, want %q", community, got, want)
+		}
+	}
+}
+
+func TestClient_UpdateInterface(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"hostinterface.update": {Result: map[string]any{"interfaceids": []string{"2"}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.UpdateInterface(context.Background(), Interface{
+		InterfaceID: "2", Type: InterfaceTypeSNMP, DNS: "sw01.example.com", UseIP: "0", Port: "1161",
+		Details: &InterfaceDetails{Version: SNMPv2c, Bulk: "0", Community: "{$SNMP_COMMUNITY}"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateInterface() error = %v", err)
+	}
+	got := params["hostinterface.update"]
+	if got["useip"] != "0" || got["dns"] != "sw01.example.com" || got["port"] != "1161" {
+		t.Errorf("params = %v, want the DNS name and port", got)
+	}
+	details, _ := got["details"].(map[string]any)
+	if details["version"] != "2" || details["bulk"] != "0" || details["community"] != "{$SNMP_COMMUNITY}" {
+		t.Errorf("details = %v, want SNMPv2c without bulk", got["details"])
+	}
+	if _, ok := details["max_repetitions"]; ok {
+		t.Error("max_repetitions should only be sent when the server has it")
+	}
+
+	// SNMPv3 details are not sent back without their passphrases
+	err = client.UpdateInterface(context.Background(), Interface{
+		InterfaceID: "2", Type: InterfaceTypeSNMP, IP: "192.0.2.2", UseIP: "1", Port: "161",
+		Details: &InterfaceDetails{Version: SNMPv3, SecurityName: "monitor"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateInterface() error = %v", err)
+	}
+	if _, ok := params["hostinterface.update"]["details"]; ok {
+		t.Errorf("params = %v, want no SNMPv3 details", params["hostinterface.update"])
+	}
+}
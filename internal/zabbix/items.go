@@ -3,6 +3,8 @@ package zabbix
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 	"time"
 )
 
@@ -12,6 +14,8 @@ type ItemGetParams struct {
 	Output interface{} `json:"output,omitempty"`
 	// Select hosts
 	SelectHosts interface{} `json:"selectHosts,omitempty"`
+	// Select preprocessing steps
+	SelectPreprocessing interface{} `json:"selectPreprocessing,omitempty"`
 	// Filter by host IDs
 	HostIDs []string `json:"hostids,omitempty"`
 	// Filter by item IDs
@@ -22,6 +26,8 @@ type ItemGetParams struct {
 	Search map[string]string `json:"search,omitempty"`
 	// Enable wildcard search
 	SearchWildcardsEnabled bool `json:"searchWildcardsEnabled,omitempty"`
+	// Match any search field instead of all of them
+	SearchByAny bool `json:"searchByAny,omitempty"`
 	// Filter to monitored items only
 	Monitored bool `json:"monitored,omitempty"`
 	// Filter by value types (0=float, 3=unsigned for numeric)
@@ -39,11 +45,13 @@ type ItemGetParams struct {
 // DefaultItemGetParams returns default parameters for fetching items.
 func DefaultItemGetParams() ItemGetParams {
 	return ItemGetParams{
-		Output:      []string{"itemid", "hostid", "name", "key_", "value_type", "units", "lastvalue", "lastclock", "state", "status"},
+		Output:      []string{"itemid", "hostid", "name", "key_", "type", "params", "value_type", "units", "lastvalue", "lastclock", "state", "status", "error", "valuemapid"},
 		SelectHosts: []string{"hostid", "host", "name"},
-		Monitored:   true,
-		SortField:   []string{"name"},
-		SortOrder:   "ASC",
+		// Preprocessing errors are a common cause of unsupported items
+		SelectPreprocessing: []string{"type", "params", "error_handler", "error_handler_params"},
+		Monitored:           true,
+		SortField:           []string{"name"},
+		SortOrder:           "ASC",
 	}
 }
 
@@ -56,14 +64,66 @@ func (c *Client) GetItems(ctx context.Context, params ItemGetParams) ([]Item, er
 	return items, nil
 }
 
+// ItemUpdateParams defines parameters for item.update API call.
+type ItemUpdateParams struct {
+	ItemID string `json:"itemid"`
+	Status string `json:"status,omitempty"`
+}
+
+// ItemUpdateResult represents the result of an item.update API call.
+type ItemUpdateResult struct {
+	ItemIDs []string `json:"itemids"`
+}
+
+// UpdateItem updates an item with the given parameters.
+func (c *Client) UpdateItem(ctx context.Context, params ItemUpdateParams) error {
+	var result ItemUpdateResult
+	if err := c.call(ctx, "item.update", params, &result); err != nil {
+		return fmt.Errorf("failed to update item: %w", err)
+	}
+	return nil
+}
+
+// EnableItem enables collecting an item.
+func (c *Client) EnableItem(ctx context.Context, itemID string) error {
+	return c.UpdateItem(ctx, ItemUpdateParams{ItemID: itemID, Status: ItemStatusEnabled})
+}
+
+// DisableItem disables collecting an item.
+func (c *Client) DisableItem(ctx context.Context, itemID string) error {
+	return c.UpdateItem(ctx, ItemUpdateParams{ItemID: itemID, Status: ItemStatusDisabled})
+}
+
 // GetNumericItems retrieves numeric items (float and unsigned int) for the given hosts.
 // This is used for the graphs tab to fetch items that can be charted.
 func (c *Client) GetNumericItems(ctx context.Context, hostIDs, keyPrefixes []string) ([]Item, error) {
+	return c.getItemsOfTypes(ctx, hostIDs, keyPrefixes, []string{ItemValueTypeFloat, ItemValueTypeUnsigned})
+}
+
+// GetGraphItems retrieves the items of all hosts shown on the graphs tab:
+// numeric items, charted, and character, log and text items, shown as
+// their latest values. Items with a value map have it set.
+func (c *Client) GetGraphItems(ctx context.Context, keyPrefixes []string) ([]Item, error) {
+	items, err := c.getItemsOfTypes(ctx, nil, keyPrefixes, []string{
+		ItemValueTypeFloat, ItemValueTypeChar, ItemValueTypeLog, ItemValueTypeUnsigned, ItemValueTypeText,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.resolveValueMaps(ctx, items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// getItemsOfTypes retrieves the enabled items of the given value types for
+// the given hosts, or all hosts, keeping those whose key starts with one of
+// keyPrefixes if any are given.
+func (c *Client) getItemsOfTypes(ctx context.Context, hostIDs, keyPrefixes, valueTypes []string) ([]Item, error) {
 	params := DefaultItemGetParams()
 	params.HostIDs = hostIDs
-	// Filter to numeric value types only (0=float, 3=unsigned int)
 	params.Filter = map[string]interface{}{
-		"value_type": []string{ItemValueTypeFloat, ItemValueTypeUnsigned},
+		"value_type": valueTypes,
 		"status":     "0", // enabled items only
 	}
 
@@ -106,7 +166,7 @@ func matchKeyPrefix(key, prefix string) bool {
 
 // HistoryGetParams defines parameters for history.get API call.
 type HistoryGetParams struct {
-	// History type (0=float, 3=unsigned int, matches item value_type)
+	// History type, the value type of the items (see ItemValueTypeFloat)
 	History int `json:"history"`
 	// Item IDs to get history for
 	ItemIDs []string `json:"itemids"`
@@ -137,43 +197,93 @@ func (c *Client) GetHistory(ctx context.Context, params HistoryGetParams) ([]His
 	return history, nil
 }
 
+// TextHistoryLimit is how many of the latest values of a character, log or
+// text item are fetched, as such items can log many lines an hour.
+const TextHistoryLimit = 200
+
+// historyType returns the history type of an item's values for history.get.
+func historyType(valueType string) int {
+	t, err := strconv.Atoi(valueType)
+	if err != nil {
+		return 0 // float by default
+	}
+	return t
+}
+
 // GetItemHistory retrieves history for a single item over a time range.
+// Only the latest TextHistoryLimit values of non-numeric items are returned.
 func (c *Client) GetItemHistory(ctx context.Context, itemID, valueType string, hours int) ([]History, error) {
-	// Determine history type based on value_type
-	historyType := 0 // float by default
-	if valueType == ItemValueTypeUnsigned {
-		historyType = 3
+	history, err := c.GetItemsHistory(ctx, []Item{{ItemID: itemID, ValueType: valueType}}, hours)
+	if err != nil {
+		return nil, err
 	}
+	return history[itemID], nil
+}
 
-	now := time.Now()
+// GetHistoryBetween retrieves the values of a numeric item collected
+// between from and till, oldest first.
+func (c *Client) GetHistoryBetween(ctx context.Context, item Item, from, till time.Time) ([]History, error) {
+	return c.GetHistory(ctx, HistoryGetParams{
+		History:   historyType(item.ValueType),
+		ItemIDs:   []string{item.ItemID},
+		TimeFrom:  from.Unix(),
+		TimeTill:  till.Unix(),
+		Output:    "extend",
+		SortField: []string{"clock"},
+		SortOrder: "ASC",
+	})
+}
+
+// GetHistoryAfter retrieves the values of an item collected after last,
+// oldest first, at most TextHistoryLimit at a time, to follow a log.
+func (c *Client) GetHistoryAfter(ctx context.Context, item Item, last History) ([]History, error) {
 	params := HistoryGetParams{
-		History:   historyType,
-		ItemIDs:   []string{itemID},
-		TimeFrom:  now.Add(-time.Duration(hours) * time.Hour).Unix(),
-		TimeTill:  now.Unix(),
+		History:   historyType(item.ValueType),
+		ItemIDs:   []string{item.ItemID},
+		TimeFrom:  last.Time().Unix(), // Values of the same second may follow
 		Output:    "extend",
 		SortField: []string{"clock"},
 		SortOrder: "ASC",
+		Limit:     TextHistoryLimit,
 	}
 
-	return c.GetHistory(ctx, params)
+	history, err := c.GetHistory(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	newer := make([]History, 0, len(history))
+	for _, h := range history {
+		if h.After(last) {
+			newer = append(newer, h)
+		}
+	}
+	slices.SortStableFunc(newer, func(a, b History) int {
+		switch {
+		case a.After(b):
+			return 1
+		case b.After(a):
+			return -1
+		}
+		return 0
+	})
+	return newer, nil
 }
 
-// GetItemsHistory retrieves history for multiple items over a time range.
-// Returns a map of itemID -> []History
+// GetItemsHistory retrieves history for multiple items over a time range,
+// oldest first. Returns a map of itemID -> []History. Numeric items are
+// fetched together by value type; non-numeric items one by one, with only
+// their latest TextHistoryLimit values.
 func (c *Client) GetItemsHistory(ctx context.Context, items []Item, hours int) (map[string][]History, error) {
 	result := make(map[string][]History)
 
-	// Group items by value type to make efficient API calls
-	floatItems := make([]string, 0)
-	unsignedItems := make([]string, 0)
-
+	// Group numeric items by value type to make efficient API calls
+	numericItems := make(map[string][]string)
+	var textItems []Item
 	for _, item := range items {
-		switch item.ValueType {
-		case ItemValueTypeFloat:
-			floatItems = append(floatItems, item.ItemID)
-		case ItemValueTypeUnsigned:
-			unsignedItems = append(unsignedItems, item.ItemID)
+		if item.IsNumeric() {
+			numericItems[item.ValueType] = append(numericItems[item.ValueType], item.ItemID)
+		} else {
+			textItems = append(textItems, item)
 		}
 	}
 
@@ -181,11 +291,13 @@ func (c *Client) GetItemsHistory(ctx context.Context, items []Item, hours int) (
 	timeFrom := now.Add(-time.Duration(hours) * time.Hour).Unix()
 	timeTill := now.Unix()
 
-	// Fetch float history
-	if len(floatItems) > 0 {
+	for _, valueType := range []string{ItemValueTypeFloat, ItemValueTypeUnsigned} {
+		if len(numericItems[valueType]) == 0 {
+			continue
+		}
 		params := HistoryGetParams{
-			History:   0, // float
-			ItemIDs:   floatItems,
+			History:   historyType(valueType),
+			ItemIDs:   numericItems[valueType],
 			TimeFrom:  timeFrom,
 			TimeTill:  timeTill,
 			Output:    "extend",
@@ -195,7 +307,7 @@ func (c *Client) GetItemsHistory(ctx context.Context, items []Item, hours int) (
 
 		history, err := c.GetHistory(ctx, params)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get float history: %w", err)
+			return nil, fmt.Errorf("failed to get numeric history: %w", err)
 		}
 
 		for _, h := range history {
@@ -203,25 +315,27 @@ func (c *Client) GetItemsHistory(ctx context.Context, items []Item, hours int) (
 		}
 	}
 
-	// Fetch unsigned history
-	if len(unsignedItems) > 0 {
+	// The limit applies to all items of a call, so the latest values of
+	// each non-numeric item are fetched on their own
+	for _, item := range textItems {
 		params := HistoryGetParams{
-			History:   3, // unsigned
-			ItemIDs:   unsignedItems,
+			History:   historyType(item.ValueType),
+			ItemIDs:   []string{item.ItemID},
 			TimeFrom:  timeFrom,
 			TimeTill:  timeTill,
 			Output:    "extend",
 			SortField: []string{"clock"},
-			SortOrder: "ASC",
+			SortOrder: "DESC",
+			Limit:     TextHistoryLimit,
 		}
 
 		history, err := c.GetHistory(ctx, params)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get unsigned history: %w", err)
+			return nil, fmt.Errorf("failed to get text history: %w", err)
 		}
-
-		for _, h := range history {
-			result[h.ItemID] = append(result[h.ItemID], h)
+		slices.Reverse(history)
+		if len(history) > 0 {
+			result[item.ItemID] = history
 		}
 	}
 
@@ -0,0 +1,107 @@
+package zabbix
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClient_EnableDisableItem(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"item.update": {Result: map[string][]string{"itemids": {"23"}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.DisableItem(context.Background(), "23"); err != nil {
+		t.Fatalf("DisableItem() error = %v", err)
+	}
+	if p := params["item.update"]; p["itemid"] != "23" || p["status"] != ItemStatusDisabled {
+		t.Errorf("item.update params = %v, want item 23 disabled", p)
+	}
+
+	if err := client.EnableItem(context.Background(), "23"); err != nil {
+		t.Fatalf("EnableItem() error = %v", err)
+	}
+	if p := params["item.update"]; p["status"] != ItemStatusEnabled {
+		t.Errorf("item.update params = %v, want item 23 enabled", p)
+	}
+}
+
+func TestClient_GetItemsHistory_Text(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"history.get": {Result: []History{
+			{ItemID: "7", Clock: "1700000120", Value: "error: disk full"},
+			{ItemID: "7", Clock: "1700000060", Value: "started"},
+		}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	history, err := client.GetItemsHistory(context.Background(), []Item{
+		{ItemID: "7", ValueType: ItemValueTypeLog},
+	}, 1)
+	if err != nil {
+		t.Fatalf("GetItemsHistory() error = %v", err)
+	}
+
+	p := params["history.get"]
+	if p["history"] != float64(2) || p["sortorder"] != "DESC" || p["limit"] != float64(TextHistoryLimit) {
+		t.Errorf("history.get params = %v, want the latest log values", p)
+	}
+	lines := history["7"]
+	if len(lines) != 2 || lines[0].Value != "started" {
+		t.Errorf("history = %v, want the values oldest first", lines)
+	}
+}
+
+func TestClient_GetHistoryBetween(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"history.get": {Result: []History{{ItemID: "7", Clock: "1699913700", Value: "0.5"}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	till := time.Unix(1700000000, 0).Add(-24 * time.Hour)
+	history, err := client.GetHistoryBetween(context.Background(), Item{ItemID: "7", ValueType: ItemValueTypeUnsigned}, till.Add(-time.Hour), till)
+	if err != nil {
+		t.Fatalf("GetHistoryBetween() error = %v", err)
+	}
+
+	p := params["history.get"]
+	if p["history"] != float64(3) || p["time_from"] != float64(till.Add(-time.Hour).Unix()) || p["time_till"] != float64(till.Unix()) {
+		t.Errorf("history.get params = %v, want the unsigned values of the hour before till", p)
+	}
+	if len(history) != 1 || history[0].Value != "0.5" {
+		t.Errorf("history = %v", history)
+	}
+}
+
+func TestClient_GetHistoryAfter(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"history.get": {Result: []History{
+			{ItemID: "7", Clock: "1700000060", NS: "100", Value: "seen"},
+			{ItemID: "7", Clock: "1700000120", NS: "0", Value: "third"},
+			{ItemID: "7", Clock: "1700000060", NS: "500", Value: "second"},
+		}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	last := History{ItemID: "7", Clock: "1700000060", NS: "100"}
+	history, err := client.GetHistoryAfter(context.Background(), Item{ItemID: "7", ValueType: ItemValueTypeLog}, last)
+	if err != nil {
+		t.Fatalf("GetHistoryAfter() error = %v", err)
+	}
+
+	if p := params["history.get"]; p["time_from"] != float64(1700000060) || p["sortorder"] != "ASC" {
+		t.Errorf("history.get params = %v, want values from the last one's second", p)
+	}
+	if len(history) != 2 || history[0].Value != "second" || history[1].Value != "third" {
+		t.Errorf("history = %v, want the values after the last one, oldest first", history)
+	}
+}
@@ -0,0 +1,177 @@
+package zabbix
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// maxTemplateDepth is how many levels of nested templates are followed
+// when resolving macros.
+const maxTemplateDepth = 10
+
+// MacroLevel is where a user macro is defined.
+type MacroLevel int
+
+// MacroLevel constants, in the order Zabbix resolves macros.
+const (
+	MacroLevelHost MacroLevel = iota
+	MacroLevelTemplate
+	MacroLevelGlobal
+)
+
+// String returns the name of the level.
+func (l MacroLevel) String() string {
+	switch l {
+	case MacroLevelHost:
+		return "host"
+	case MacroLevelTemplate:
+		return "template"
+	default:
+		return "global"
+	}
+}
+
+// Template represents a Zabbix template.
+type Template struct {
+	TemplateID string `json:"templateid"`
+	Host       string `json:"host"`
+	Name       string `json:"name"`
+	Hosts      []Host `json:"hosts,omitempty"` // Hosts linked to the template, when selected
+}
+
+// MacroDefinition is a definition of a user macro on the host, on one of
+// its templates or globally.
+type MacroDefinition struct {
+	HostMacro
+	Level    MacroLevel
+	Template string // Name of the template, for template macros
+}
+
+// Source returns where the macro is defined: "host", "global" or the name
+// of the template.
+func (d MacroDefinition) Source() string {
+	if d.Level == MacroLevelTemplate {
+		return d.Template
+	}
+	return d.Level.String()
+}
+
+// EffectiveMacro is a user macro as it applies to a host: its definitions
+// in the order Zabbix resolves them, the first one providing the value.
+type EffectiveMacro struct {
+	Macro       string
+	Definitions []MacroDefinition
+}
+
+// Effective returns the definition providing the value of the macro.
+func (e EffectiveMacro) Effective() MacroDefinition {
+	return e.Definitions[0]
+}
+
+// templateGetParams defines parameters for template.get API call.
+type templateGetParams struct {
+	Output  interface{}            `json:"output,omitempty"`
+	HostIDs []string               `json:"hostids,omitempty"`
+	Filter  map[string]interface{} `json:"filter,omitempty"`
+}
+
+// GetEffectiveMacros retrieves the user macros of a host with those it
+// inherits from its templates and the global macros, resolved as Zabbix
+// does: host macros first, then macros of the templates linked to the host,
+// of the templates linked to those, and so on, then global macros. Templates
+// of the same level are taken by template ID.
+func (c *Client) GetEffectiveMacros(ctx context.Context, hostID string) ([]EffectiveMacro, error) {
+	levels, err := c.templateLevels(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{hostID}
+	for _, level := range levels {
+		for _, t := range level {
+			ids = append(ids, t.TemplateID)
+		}
+	}
+	var macros []HostMacro
+	if err := c.call(ctx, "usermacro.get", UserMacroGetParams{Output: "extend", HostIDs: ids}, &macros); err != nil {
+		return nil, fmt.Errorf("failed to get host macros: %w", err)
+	}
+	byHost := make(map[string][]HostMacro)
+	for _, m := range macros {
+		byHost[m.HostID] = append(byHost[m.HostID], m)
+	}
+
+	// Global macros may not be readable by every user, the host and
+	// template macros are shown without them
+	var globals []HostMacro
+	if err := c.call(ctx, "usermacro.get", UserMacroGetParams{Output: "extend", GlobalMacro: true}, &globals); err != nil {
+		globals = nil
+	}
+
+	definitions := make(map[string][]MacroDefinition)
+	add := func(m HostMacro, level MacroLevel, template string) {
+		definitions[m.Macro] = append(definitions[m.Macro], MacroDefinition{HostMacro: m, Level: level, Template: template})
+	}
+	for _, m := range byHost[hostID] {
+		add(m, MacroLevelHost, "")
+	}
+	for _, level := range levels {
+		for _, t := range level {
+			for _, m := range byHost[t.TemplateID] {
+				add(m, MacroLevelTemplate, t.Name)
+			}
+		}
+	}
+	for _, m := range globals {
+		add(m, MacroLevelGlobal, "")
+	}
+
+	result := make([]EffectiveMacro, 0, len(definitions))
+	for macro, defs := range definitions {
+		result = append(result, EffectiveMacro{Macro: macro, Definitions: defs})
+	}
+	slices.SortFunc(result, func(a, b EffectiveMacro) int { return cmp.Compare(a.Macro, b.Macro) })
+	return result, nil
+}
+
+// templateLevels returns the templates linked to a host, the templates
+// linked to those on the next level and so on, each level ordered by
+// template ID.
+func (c *Client) templateLevels(ctx context.Context, hostID string) ([][]Template, error) {
+	var levels [][]Template
+	seen := make(map[string]bool)
+	ids := []string{hostID}
+	for range maxTemplateDepth {
+		var templates []Template
+		params := templateGetParams{Output: []string{"templateid", "host", "name"}, HostIDs: ids}
+		if err := c.call(ctx, "template.get", params, &templates); err != nil {
+			return nil, fmt.Errorf("failed to get templates: %w", err)
+		}
+
+		var level []Template
+		for _, t := range templates {
+			if !seen[t.TemplateID] {
+				seen[t.TemplateID] = true
+				level = append(level, t)
+			}
+		}
+		if len(level) == 0 {
+			break
+		}
+		slices.SortFunc(level, func(a, b Template) int {
+			x, _ := strconv.Atoi(a.TemplateID)
+			y, _ := strconv.Atoi(b.TemplateID)
+			return cmp.Compare(x, y)
+		})
+		levels = append(levels, level)
+
+		ids = nil
+		for _, t := range level {
+			ids = append(ids, t.TemplateID)
+		}
+	}
+	return levels, nil
+}
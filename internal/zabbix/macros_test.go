@@ -0,0 +1,46 @@
+package zabbix
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClient_TemplateLevels(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"template.get": {Result: []Template{{TemplateID: "200", Name: "B"}, {TemplateID: "30", Name: "A"}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	levels, err := client.templateLevels(context.Background(), "10084")
+	if err != nil {
+		t.Fatalf("templateLevels() error = %v", err)
+	}
+	// The second call returns the same templates, which are not repeated
+	if len(levels) != 1 || len(levels[0]) != 2 {
+		t.Fatalf("levels = %+v, want one level of 2 templates", levels)
+	}
+	if levels[0][0].Name != "A" {
+		t.Errorf("levels = %+v, want templates ordered by ID", levels)
+	}
+	if ids, _ := params["template.get"]["hostids"].([]any); len(ids) != 2 || ids[0] != "30" {
+		t.Errorf("template.get params = %v, want the templates of the first level", params["template.get"])
+	}
+}
+
+func TestMacroDefinition_Source(t *testing.T) {
+	tests := []struct {
+		def  MacroDefinition
+		want string
+	}{
+		{MacroDefinition{Level: MacroLevelHost}, "host"},
+		{MacroDefinition{Level: MacroLevelTemplate, Template: "Linux by Zabbix agent"}, "Linux by Zabbix agent"},
+		{MacroDefinition{Level: MacroLevelGlobal}, "global"},
+	}
+	for _, tt := range tests {
+		if got := tt.def.Source(); got != tt.want {
+			t.Errorf("Source() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,472 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Maintenance types.
+const (
+	MaintenanceWithData = "0" // Data is collected during the maintenance
+	MaintenanceNoData   = "1" // Data collection is paused
+)
+
+// Types of maintenance periods.
+const (
+	TimePeriodOnce    = "0" // Happens once, at StartDate
+	TimePeriodDaily   = "2" // Every Every days
+	TimePeriodWeekly  = "3" // On DayOfWeek, every Every weeks
+	TimePeriodMonthly = "4" // In Month, on Day or on DayOfWeek of week Every
+)
+
+// Maintenance represents a Zabbix maintenance window.
+type Maintenance struct {
+	MaintenanceID   string       `json:"maintenanceid"`
+	Name            string       `json:"name"`
+	MaintenanceType string       `json:"maintenance_type"`
+	Description     string       `json:"description,omitempty"`
+	ActiveSince     string       `json:"active_since"`
+	ActiveTill      string       `json:"active_till"`
+	TimePeriods     []TimePeriod `json:"timeperiods,omitempty"`
+	Hosts           []Host       `json:"hosts,omitempty"`
+	Groups          []HostGroup  `json:"groups,omitempty"`
+}
+
+// UnmarshalJSON decodes a maintenance, accepting "hostgroups", the name of
+// "groups" since Zabbix 6.2.
+func (mt *Maintenance) UnmarshalJSON(data []byte) error {
+	type maintenance Maintenance // Without this method, to avoid recursion
+	aux := struct {
+		*maintenance
+		HostGroups []HostGroup `json:"hostgroups"`
+	}{maintenance: (*maintenance)(mt)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if len(aux.HostGroups) > 0 {
+		mt.Groups = aux.HostGroups
+	}
+	return nil
+}
+
+// TimePeriod is a period of a maintenance during which hosts are in
+// maintenance.
+type TimePeriod struct {
+	TimePeriodType string `json:"timeperiod_type"`
+	StartDate      string `json:"start_date,omitempty"` // Start of a one-time period
+	StartTime      string `json:"start_time,omitempty"` // Seconds after midnight a recurring period starts at
+	Period         string `json:"period"`               // Length in seconds
+	// Days or weeks between daily or weekly periods, or the week of the
+	// month of monthly periods on a day of the week (5 is the last week)
+	Every     string `json:"every,omitempty"`
+	DayOfWeek string `json:"dayofweek,omitempty"` // Days of the week as a bitmask, Monday first
+	Day       string `json:"day,omitempty"`       // Day of the month of monthly periods
+	Month     string `json:"month,omitempty"`     // Months as a bitmask, January first
+}
+
+// Window is a span of time during which a maintenance is in effect.
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+// CollectsData returns true if data is collected during the maintenance.
+func (mt *Maintenance) CollectsData() bool {
+	return mt.MaintenanceType != MaintenanceNoData
+}
+
+// End returns when the maintenance active at now ends: the end of the
+// one-time period containing now, or else the end of the maintenance.
+// Recurring periods are not expanded.
+func (mt *Maintenance) End(now time.Time) time.Time {
+	end := parseUnix(mt.ActiveTill)
+	for _, p := range mt.TimePeriods {
+		if p.TimePeriodType != TimePeriodOnce {
+			continue
+		}
+		start := parseUnix(p.StartDate)
+		period, err := strconv.ParseInt(p.Period, 10, 64)
+		if err != nil || start.IsZero() {
+			continue
+		}
+		periodEnd := start.Add(time.Duration(period) * time.Second)
+		if !now.Before(start) && now.Before(periodEnd) && (end.IsZero() || periodEnd.Before(end)) {
+			return periodEnd
+		}
+	}
+	return end
+}
+
+// Active returns when the maintenance is active: since active_since until
+// active_till, zero if not set.
+func (mt *Maintenance) Active() (since, until time.Time) {
+	return parseUnix(mt.ActiveSince), parseUnix(mt.ActiveTill)
+}
+
+// Windows returns the periods of the maintenance overlapping from until
+// till, in order, with its recurring periods expanded: daily and weekly
+// periods count their days and weeks from the day it becomes active, and
+// periods are cut to when it is active. Days are those of from's time zone,
+// which should be the server's.
+func (mt *Maintenance) Windows(from, till time.Time) []Window {
+	since, until := mt.Active()
+	if since.IsZero() {
+		since = from
+	}
+	var windows []Window
+	for _, p := range mt.TimePeriods {
+		period := p.Duration()
+		if period <= 0 {
+			continue
+		}
+		for _, start := range mt.Starts(p, from.Add(-period), till) {
+			w := Window{Start: start, End: start.Add(period)}
+			if w.Start.Before(since) {
+				w.Start = since
+			}
+			if !until.IsZero() && w.End.After(until) {
+				w.End = until
+			}
+			if w.Start.Before(w.End) && w.End.After(from) && w.Start.Before(till) {
+				windows = append(windows, w)
+			}
+		}
+	}
+	slices.SortFunc(windows, func(a, b Window) int {
+		return a.Start.Compare(b.Start)
+	})
+	return windows
+}
+
+// Starts returns when a period of the maintenance starts from until till,
+// while the maintenance is active, in from's time zone. Unlike Windows, the
+// starts are not cut to when the maintenance becomes active.
+func (mt *Maintenance) Starts(p TimePeriod, from, till time.Time) []time.Time {
+	since, until := mt.Active()
+	if since.IsZero() {
+		since = from
+	}
+	return p.starts(since.In(from.Location()), until, from, till)
+}
+
+// Duration returns how long the period lasts.
+func (p TimePeriod) Duration() time.Duration {
+	return time.Duration(parseInt(p.Period)) * time.Second
+}
+
+// Weekdays returns the days of the week of weekly periods and of monthly
+// periods on a day of the week.
+func (p TimePeriod) Weekdays() []time.Weekday {
+	var days []time.Weekday
+	for i := range 7 {
+		if parseInt(p.DayOfWeek)&(1<<i) != 0 {
+			days = append(days, time.Weekday((i+1)%7))
+		}
+	}
+	return days
+}
+
+// Months returns the months of monthly periods.
+func (p TimePeriod) Months() []time.Month {
+	var months []time.Month
+	for m := time.January; m <= time.December; m++ {
+		if parseInt(p.Month)&(1<<(int(m)-1)) != 0 {
+			months = append(months, m)
+		}
+	}
+	return months
+}
+
+// starts returns when the period starts between from and till, for a
+// maintenance active since until until (zero for no end).
+func (p TimePeriod) starts(since, until, from, till time.Time) []time.Time {
+	if p.TimePeriodType == TimePeriodOnce {
+		if start := parseUnix(p.StartDate); !start.IsZero() && !start.Before(from) && start.Before(till) {
+			return []time.Time{start}
+		}
+		return nil
+	}
+
+	first := midnight(since)
+	startTime := parseInt(p.StartTime)
+	var starts []time.Time
+	day := midnight(from.In(since.Location()))
+	if day.Before(first) {
+		day = first
+	}
+	for ; day.Before(till) && (until.IsZero() || day.Before(until)); day = day.AddDate(0, 0, 1) {
+		if p.recursOn(day, first) {
+			// On the clock, also on days the clocks change
+			y, m, d := day.Date()
+			starts = append(starts, time.Date(y, m, d, 0, 0, startTime, 0, day.Location()))
+		}
+	}
+	return starts
+}
+
+// recursOn returns true if a recurring period starts on day, counting days
+// and weeks from first.
+func (p TimePeriod) recursOn(day, first time.Time) bool {
+	every := max(1, parseInt(p.Every))
+	onWeekday := parseInt(p.DayOfWeek)&(1<<((int(day.Weekday())+6)%7)) != 0
+	switch p.TimePeriodType {
+	case TimePeriodDaily:
+		return daysBetween(first, day)%every == 0
+	case TimePeriodWeekly:
+		weeks := daysBetween(weekStart(first), weekStart(day)) / 7
+		return onWeekday && weeks%every == 0
+	case TimePeriodMonthly:
+		if parseInt(p.Month)&(1<<(int(day.Month())-1)) == 0 {
+			return false
+		}
+		if d := parseInt(p.Day); d > 0 {
+			return day.Day() == d
+		}
+		if every >= 5 {
+			// The last week of the month
+			return onWeekday && day.AddDate(0, 0, 7).Month() != day.Month()
+		}
+		return onWeekday && (day.Day()-1)/7+1 == every
+	}
+	return false
+}
+
+// Schedule describes when a period recurs, e.g. "every 2 days at 02:00" or
+// "on the last Sunday of Jan, Jul at 03:30".
+func (p TimePeriod) Schedule() string {
+	startTime := parseInt(p.StartTime)
+	at := fmt.Sprintf(" at %02d:%02d", startTime/3600, startTime%3600/60)
+	every := max(1, parseInt(p.Every))
+	switch p.TimePeriodType {
+	case TimePeriodOnce:
+		return "once"
+	case TimePeriodDaily:
+		if every == 1 {
+			return "daily" + at
+		}
+		return fmt.Sprintf("every %d days%s", every, at)
+	case TimePeriodWeekly:
+		days := strings.Join(p.weekdays(), ", ")
+		if every == 1 {
+			return "weekly on " + days + at
+		}
+		return fmt.Sprintf("every %d weeks on %s%s", every, days, at)
+	case TimePeriodMonthly:
+		var months []string
+		for _, m := range p.Months() {
+			months = append(months, m.String()[:3])
+		}
+		in := " of " + strings.Join(months, ", ")
+		if len(months) == 12 {
+			in = " of every month"
+		}
+		if d := parseInt(p.Day); d > 0 {
+			return fmt.Sprintf("on day %d%s%s", d, in, at)
+		}
+		week := []string{"first", "second", "third", "fourth", "last"}[min(every, 5)-1]
+		return fmt.Sprintf("on the %s %s%s%s", week, strings.Join(p.weekdays(), ", "), in, at)
+	}
+	return "unknown schedule"
+}
+
+// weekdays returns the short names of the days of the week of the period.
+func (p TimePeriod) weekdays() []string {
+	var days []string
+	for _, d := range p.Weekdays() {
+		days = append(days, d.String()[:3])
+	}
+	return days
+}
+
+// midnight returns the start of t's day.
+func midnight(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// weekStart returns the Monday of t's week, at midnight.
+func weekStart(t time.Time) time.Time {
+	return midnight(t).AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+}
+
+// daysBetween returns the number of calendar days from a to b.
+func daysBetween(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return int(time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC).Sub(time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+}
+
+// parseInt parses an integer field; zero if empty or invalid.
+func parseInt(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// parseUnix parses a Unix timestamp field; zero if empty or invalid.
+func parseUnix(s string) time.Time {
+	ts, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || ts <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(ts, 0)
+}
+
+// maintenanceGetParams defines parameters for maintenance.get API call.
+type maintenanceGetParams struct {
+	Output            interface{} `json:"output,omitempty"`
+	SelectTimePeriods interface{} `json:"selectTimeperiods,omitempty"`
+	MaintenanceIDs    []string    `json:"maintenanceids,omitempty"`
+	HostIDs           []string    `json:"hostids,omitempty"`
+	GroupIDs          []string    `json:"groupids,omitempty"`
+	SelectHosts       interface{} `json:"selectHosts,omitempty"`
+	// Select host groups (Zabbix 6.2+; adapted to SelectGroups for older servers)
+	SelectHostGroups interface{} `json:"selectHostGroups,omitempty"`
+	SelectGroups     interface{} `json:"selectGroups,omitempty"`
+}
+
+// maintenanceOutput and timePeriodOutput are the fields of maintenances and
+// of their periods fetched to tell when they are in effect.
+var (
+	maintenanceOutput = []string{"maintenanceid", "name", "maintenance_type", "description", "active_since", "active_till"}
+	timePeriodOutput  = []string{"timeperiod_type", "start_date", "start_time", "period", "every", "dayofweek", "day", "month"}
+)
+
+// GetMaintenances retrieves maintenances by ID, keyed by ID.
+func (c *Client) GetMaintenances(ctx context.Context, maintenanceIDs []string) (map[string]Maintenance, error) {
+	params := maintenanceGetParams{
+		Output:            maintenanceOutput,
+		SelectTimePeriods: timePeriodOutput,
+		MaintenanceIDs:    maintenanceIDs,
+	}
+
+	var maintenances []Maintenance
+	if err := c.call(ctx, "maintenance.get", params, &maintenances); err != nil {
+		return nil, fmt.Errorf("failed to get maintenances: %w", err)
+	}
+	byID := make(map[string]Maintenance, len(maintenances))
+	for _, mt := range maintenances {
+		byID[mt.MaintenanceID] = mt
+	}
+	return byID, nil
+}
+
+// GetHostMaintenances retrieves the maintenances of a host, given directly
+// or through its host groups groupIDs, sorted by name.
+func (c *Client) GetHostMaintenances(ctx context.Context, hostID string, groupIDs []string) ([]Maintenance, error) {
+	// Maintenances are asked for by host and by host group separately, as
+	// maintenance.get returns only those matching both
+	queries := []maintenanceGetParams{{HostIDs: []string{hostID}}}
+	if len(groupIDs) > 0 {
+		queries = append(queries, maintenanceGetParams{GroupIDs: groupIDs})
+	}
+	var maintenances []Maintenance
+	for _, params := range queries {
+		params.Output = maintenanceOutput
+		params.SelectTimePeriods = timePeriodOutput
+		var result []Maintenance
+		if err := c.call(ctx, "maintenance.get", params, &result); err != nil {
+			return nil, fmt.Errorf("failed to get maintenances: %w", err)
+		}
+		for _, mt := range result {
+			if !slices.ContainsFunc(maintenances, func(other Maintenance) bool { return other.MaintenanceID == mt.MaintenanceID }) {
+				maintenances = append(maintenances, mt)
+			}
+		}
+	}
+	slices.SortFunc(maintenances, func(a, b Maintenance) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return maintenances, nil
+}
+
+// GetAllMaintenances retrieves all maintenances with their hosts and host
+// groups, sorted by name.
+func (c *Client) GetAllMaintenances(ctx context.Context) ([]Maintenance, error) {
+	params := maintenanceGetParams{
+		Output:            maintenanceOutput,
+		SelectTimePeriods: timePeriodOutput,
+		SelectHosts:       []string{"hostid", "host", "name"},
+		SelectHostGroups:  []string{"groupid", "name"},
+	}
+	if !c.Capabilities().HostGroupsSelect {
+		params.SelectGroups = params.SelectHostGroups
+		params.SelectHostGroups = nil
+	}
+
+	var maintenances []Maintenance
+	if err := c.call(ctx, "maintenance.get", params, &maintenances); err != nil {
+		return nil, fmt.Errorf("failed to get maintenances: %w", err)
+	}
+	slices.SortFunc(maintenances, func(a, b Maintenance) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return maintenances, nil
+}
+
+// MaintenanceCreateParams defines parameters for maintenance.create API
+// call. Hosts are given as HostIDs before Zabbix 6.0 and as Hosts since.
+type MaintenanceCreateParams struct {
+	Name            string       `json:"name"`
+	MaintenanceType string       `json:"maintenance_type"`
+	Description     string       `json:"description,omitempty"`
+	ActiveSince     int64        `json:"active_since"`
+	ActiveTill      int64        `json:"active_till"`
+	Hosts           []HostRef    `json:"hosts,omitempty"`
+	HostIDs         []string     `json:"hostids,omitempty"`
+	TimePeriods     []TimePeriod `json:"timeperiods"`
+}
+
+// HostRef refers to a host by ID.
+type HostRef struct {
+	HostID string `json:"hostid"`
+}
+
+// maintenanceCreateResult represents the result of maintenance.create.
+type maintenanceCreateResult struct {
+	MaintenanceIDs []string `json:"maintenanceids"`
+}
+
+// CreateHostMaintenance puts a host in maintenance from now for duration,
+// with a one-time maintenance named name, and returns its ID. The window
+// starts at the current minute and ends on a whole minute, as Zabbix
+// checks maintenances once a minute.
+func (c *Client) CreateHostMaintenance(ctx context.Context, hostID, name string, duration time.Duration, collectData bool) (string, error) {
+	now := time.Now()
+	start := now.Truncate(time.Minute)
+	end := now.Add(duration + time.Minute - 1).Truncate(time.Minute)
+
+	params := MaintenanceCreateParams{
+		Name:            name,
+		MaintenanceType: MaintenanceWithData,
+		ActiveSince:     start.Unix(),
+		ActiveTill:      end.Unix(),
+		TimePeriods: []TimePeriod{{
+			TimePeriodType: TimePeriodOnce,
+			StartDate:      strconv.FormatInt(start.Unix(), 10),
+			Period:         strconv.FormatInt(int64(end.Sub(start).Seconds()), 10),
+		}},
+	}
+	if !collectData {
+		params.MaintenanceType = MaintenanceNoData
+	}
+	if c.Capabilities().MaintenanceHosts {
+		params.Hosts = []HostRef{{HostID: hostID}}
+	} else {
+		params.HostIDs = []string{hostID}
+	}
+
+	var result maintenanceCreateResult
+	if err := c.call(ctx, "maintenance.create", params, &result); err != nil {
+		return "", fmt.Errorf("failed to create maintenance: %w", err)
+	}
+	if len(result.MaintenanceIDs) == 0 {
+		return "", fmt.Errorf("failed to create maintenance: no ID returned")
+	}
+	return result.MaintenanceIDs[0], nil
+}
@@ -0,0 +1,236 @@
+package zabbix
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestClient_CreateHostMaintenance(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"maintenance.create": {Result: map[string][]string{"maintenanceids": {"12"}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	id, err := client.CreateHostMaintenance(context.Background(), "10084", "web01 for 1h", time.Hour, false)
+	if err != nil {
+		t.Fatalf("CreateHostMaintenance() error = %v", err)
+	}
+	if id != "12" {
+		t.Errorf("maintenance ID = %q, want 12", id)
+	}
+
+	p := params["maintenance.create"]
+	if p["name"] != "web01 for 1h" || p["maintenance_type"] != MaintenanceNoData {
+		t.Errorf("maintenance.create params = %v, want the name and no data collection", p)
+	}
+	hosts, _ := p["hosts"].([]any)
+	if len(hosts) != 1 || hosts[0].(map[string]any)["hostid"] != "10084" || p["hostids"] != nil {
+		t.Errorf("maintenance.create hosts = %v, want [{hostid: 10084}]", p["hosts"])
+	}
+	since, _ := p["active_since"].(float64)
+	till, _ := p["active_till"].(float64)
+	if int64(since)%60 != 0 || int64(till)%60 != 0 {
+		t.Errorf("active %v-%v, want whole minutes", since, till)
+	}
+	if d := time.Duration(till-since) * time.Second; d < time.Hour || d > time.Hour+2*time.Minute {
+		t.Errorf("maintenance lasts %v, want about 1h", d)
+	}
+	periods, _ := p["timeperiods"].([]any)
+	if len(periods) != 1 || periods[0].(map[string]any)["timeperiod_type"] != TimePeriodOnce {
+		t.Errorf("timeperiods = %v, want one one-time period", p["timeperiods"])
+	}
+
+	// Before Zabbix 6.0 hosts are given by ID
+	caps := CapabilitiesFor("5.0.40")
+	client.caps.Store(&caps)
+	if _, err := client.CreateHostMaintenance(context.Background(), "10084", "web01 for 1h", time.Hour, true); err != nil {
+		t.Fatalf("CreateHostMaintenance() error = %v", err)
+	}
+	p = params["maintenance.create"]
+	if ids, _ := p["hostids"].([]any); len(ids) != 1 || p["hosts"] != nil {
+		t.Errorf("maintenance.create params = %v, want hostids on 5.0", p)
+	}
+	if p["maintenance_type"] != MaintenanceWithData {
+		t.Errorf("maintenance_type = %v, want data collection", p["maintenance_type"])
+	}
+}
+
+func TestMaintenance_End(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	mt := Maintenance{
+		ActiveSince: "1699990000",
+		ActiveTill:  "1800000000",
+		TimePeriods: []TimePeriod{
+			{TimePeriodType: "2", Period: "3600"}, // Daily, not expanded
+			{TimePeriodType: TimePeriodOnce, StartDate: "1699999000", Period: "7200"},
+		},
+	}
+	if got := mt.End(now); got.Unix() != 1_700_006_200 {
+		t.Errorf("End() = %d, want the end of the current one-time period", got.Unix())
+	}
+	if got := mt.End(now.Add(3 * time.Hour)); got.Unix() != 1_800_000_000 {
+		t.Errorf("End() = %d, want active_till outside the one-time period", got.Unix())
+	}
+}
+
+func TestMaintenance_Windows(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, time.March, day, hour, minute, 0, 0, time.Local)
+	}
+	unix := func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) }
+	from, till := at(3, 12, 0), at(31, 0, 0) // Monday noon until the end of the month
+	var oddDays []time.Time
+	for day := 3; day < 31; day += 2 {
+		oddDays = append(oddDays, at(day, 11, 0))
+	}
+
+	tests := []struct {
+		name   string
+		period TimePeriod
+		want   []time.Time // Window starts
+	}{
+		{
+			name:   "once",
+			period: TimePeriod{TimePeriodType: TimePeriodOnce, StartDate: unix(at(10, 22, 0)), Period: "3600"},
+			want:   []time.Time{at(10, 22, 0)},
+		},
+		{
+			// Counted from March 1st, the window of the 3rd is still open at noon
+			name:   "every 2 days",
+			period: TimePeriod{TimePeriodType: TimePeriodDaily, Every: "2", StartTime: "39600", Period: "7200"},
+			want:   oddDays,
+		},
+		{
+			// Mondays and Wednesdays of every other week from the week of
+			// March 1st, a Saturday
+			name:   "every 2 weeks",
+			period: TimePeriod{TimePeriodType: TimePeriodWeekly, Every: "2", DayOfWeek: "5", StartTime: "7200", Period: "3600"},
+			want:   []time.Time{at(10, 2, 0), at(12, 2, 0), at(24, 2, 0), at(26, 2, 0)},
+		},
+		{
+			name:   "day of month",
+			period: TimePeriod{TimePeriodType: TimePeriodMonthly, Month: "4", Day: "15", Period: "3600"},
+			want:   []time.Time{at(15, 0, 0)},
+		},
+		{
+			name:   "last Sunday",
+			period: TimePeriod{TimePeriodType: TimePeriodMonthly, Month: "4095", Every: "5", DayOfWeek: "64", StartTime: "3600", Period: "3600"},
+			want:   []time.Time{at(30, 1, 0)},
+		},
+		{
+			name:   "other months",
+			period: TimePeriod{TimePeriodType: TimePeriodMonthly, Month: "1", Day: "15", Period: "3600"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := Maintenance{
+				ActiveSince: unix(at(1, 0, 0)),
+				ActiveTill:  unix(at(27, 0, 0).AddDate(0, 1, 0)),
+				TimePeriods: []TimePeriod{tt.period},
+			}
+			windows := mt.Windows(from, till)
+			var got []time.Time
+			for _, w := range windows {
+				got = append(got, w.Start)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Windows() starts = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Windows()[%d].Start = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	// Windows are cut to when the maintenance is active
+	mt := Maintenance{
+		ActiveSince: unix(at(5, 0, 0)),
+		ActiveTill:  unix(at(6, 12, 0)),
+		TimePeriods: []TimePeriod{{TimePeriodType: TimePeriodDaily, StartTime: "36000", Period: "14400"}},
+	}
+	windows := mt.Windows(from, till)
+	if len(windows) != 2 || !windows[1].End.Equal(at(6, 12, 0)) {
+		t.Errorf("Windows() = %v, want two windows, the last ending with the maintenance", windows)
+	}
+}
+
+func TestTimePeriod_Schedule(t *testing.T) {
+	tests := []struct {
+		period TimePeriod
+		want   string
+	}{
+		{TimePeriod{TimePeriodType: TimePeriodDaily, StartTime: "7200"}, "daily at 02:00"},
+		{TimePeriod{TimePeriodType: TimePeriodWeekly, Every: "2", DayOfWeek: "65", StartTime: "84600"}, "every 2 weeks on Mon, Sun at 23:30"},
+		{TimePeriod{TimePeriodType: TimePeriodMonthly, Month: "4095", Day: "1"}, "on day 1 of every month at 00:00"},
+		{TimePeriod{TimePeriodType: TimePeriodMonthly, Month: "65", Every: "5", DayOfWeek: "64", StartTime: "12600"}, "on the last Sun of Jan, Jul at 03:30"},
+	}
+	for _, tt := range tests {
+		if got := tt.period.Schedule(); got != tt.want {
+			t.Errorf("Schedule() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestClient_GetHostMaintenances(t *testing.T) {
+	server := newMockServer(t, map[string]mockResponse{
+		"maintenance.get": {Result: func(params map[string]any) any {
+			if params["groupids"] != nil {
+				return []Maintenance{{MaintenanceID: "2", Name: "Patch window"}, {MaintenanceID: "1", Name: "Upgrade"}}
+			}
+			return []Maintenance{{MaintenanceID: "1", Name: "Upgrade"}}
+		}},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	maintenances, err := client.GetHostMaintenances(context.Background(), "10084", []string{"2"})
+	if err != nil {
+		t.Fatalf("GetHostMaintenances() error = %v", err)
+	}
+	if len(maintenances) != 2 || maintenances[0].Name != "Patch window" || maintenances[1].Name != "Upgrade" {
+		t.Errorf("maintenances = %+v, want those of the host and its groups once, by name", maintenances)
+	}
+}
+
+func TestClient_GetAllMaintenances(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"maintenance.get": {Result: []map[string]any{
+			{"maintenanceid": "2", "name": "Upgrade", "hostgroups": []HostGroup{{GroupID: "21", Name: "Databases"}}},
+			{"maintenanceid": "1", "name": "Patch window", "hosts": []Host{{HostID: "10084", Name: "web01"}}},
+		}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	maintenances, err := client.GetAllMaintenances(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllMaintenances() error = %v", err)
+	}
+	if len(maintenances) != 2 || maintenances[0].Name != "Patch window" || len(maintenances[0].Hosts) != 1 {
+		t.Fatalf("maintenances = %+v, want both by name with their hosts", maintenances)
+	}
+	if groups := maintenances[1].Groups; len(groups) != 1 || groups[0].Name != "Databases" {
+		t.Errorf("groups = %+v, want the host groups of Zabbix 6.2+", groups)
+	}
+	if params["maintenance.get"]["selectHostGroups"] == nil {
+		t.Errorf("maintenance.get params = %v, want selectHostGroups", params["maintenance.get"])
+	}
+
+	// Before Zabbix 6.2 host groups are selected as groups
+	caps := CapabilitiesFor("6.0.30")
+	client.caps.Store(&caps)
+	if _, err := client.GetAllMaintenances(context.Background()); err != nil {
+		t.Fatalf("GetAllMaintenances() error = %v", err)
+	}
+	if p := params["maintenance.get"]; p["selectGroups"] == nil || p["selectHostGroups"] != nil {
+		t.Errorf("maintenance.get params = %v, want selectGroups on 6.0", p)
+	}
+}
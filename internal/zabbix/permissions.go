@@ -0,0 +1,160 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// User types, the base of a user's permissions.
+const (
+	UserTypeUser       = 1 // Monitoring only
+	UserTypeAdmin      = 2 // May configure hosts it has write access to
+	UserTypeSuperAdmin = 3 // May do anything
+)
+
+// Permissions are the changes a user may make through the API.
+type Permissions struct {
+	UserType    int
+	Acknowledge bool // Acknowledge problems
+	Close       bool // Close problems manually
+	Suppress    bool // Suppress problems
+	Configure   bool // Edit hosts, items, triggers and macros
+	Maintenance bool // Create maintenance periods
+	CheckNow    bool // Ask for items to be checked now
+}
+
+// AllPermissions returns the permissions of a super admin, assumed when a
+// user's own can't be looked up.
+func AllPermissions() *Permissions {
+	return &Permissions{
+		UserType:    UserTypeSuperAdmin,
+		Acknowledge: true,
+		Close:       true,
+		Suppress:    true,
+		Configure:   true,
+		Maintenance: true,
+		CheckNow:    true,
+	}
+}
+
+// roleRules are the rules of a user role that restrict actions.
+type roleRules struct {
+	Actions []struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+	} `json:"actions"`
+	ActionsDefaultAccess string `json:"actions.default_access"`
+}
+
+// allows reports whether the rules allow the frontend action, e.g.
+// "acknowledge_problems". Actions not listed get the default access.
+func (r *roleRules) allows(action string) bool {
+	for _, a := range r.Actions {
+		if a.Name == action {
+			return a.Status == "1"
+		}
+	}
+	return r.ActionsDefaultAccess != "0"
+}
+
+// GetPermissions returns what the user may change, from the user's type
+// and, on servers with user roles (5.2+), the actions the role allows.
+// Roles whose rules can't be read allow every action of their type.
+func (c *Client) GetPermissions(ctx context.Context, userID string) (*Permissions, error) {
+	params := map[string]interface{}{
+		"userids": []string{userID},
+		"output":  []string{"userid", "type"},
+	}
+	caps := c.Capabilities()
+	roles := caps.UserRoles
+	if roles {
+		params["output"] = []string{"userid", "roleid"}
+		params["selectRole"] = []string{"roleid", "type"}
+	}
+
+	var users []struct {
+		Type   string `json:"type"`
+		RoleID string `json:"roleid"`
+		Role   struct {
+			Type string `json:"type"`
+		} `json:"role"`
+	}
+	if err := c.call(ctx, "user.get", params, &users); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("user %s: %w", userID, ErrNotFound)
+	}
+
+	userType := users[0].Type
+	if roles {
+		userType = users[0].Role.Type
+	}
+	p := &Permissions{}
+	p.UserType, _ = strconv.Atoi(userType)
+
+	rules := &roleRules{}
+	if roles && users[0].RoleID != "" {
+		var found []struct {
+			Rules roleRules `json:"rules"`
+		}
+		params := map[string]interface{}{
+			"roleids":     []string{users[0].RoleID},
+			"output":      []string{"roleid"},
+			"selectRules": []string{"actions", "actions.default_access"},
+		}
+		if err := c.call(ctx, "role.get", params, &found); err == nil && len(found) > 0 {
+			rules = &found[0].Rules
+		}
+	}
+
+	admin := p.UserType >= UserTypeAdmin
+	p.Acknowledge = rules.allows("acknowledge_problems")
+	p.Close = rules.allows("close_problems")
+	p.Suppress = rules.allows("suppress_problems")
+	p.Configure = admin
+	p.Maintenance = admin && rules.allows("edit_maintenance")
+	// Before 6.4 checking now needs write access to the items
+	p.CheckNow = admin || (caps.ExecuteNowAction && rules.allows("invoke_execute_now"))
+	return p, nil
+}
+
+// UserProfile is who a user is to Zabbix: their role and user groups.
+type UserProfile struct {
+	Role   string   // Name of the user role; empty before Zabbix 5.2
+	Groups []string // Names of the user groups
+}
+
+// GetUserProfile retrieves the role and user groups of a user.
+func (c *Client) GetUserProfile(ctx context.Context, userID string) (*UserProfile, error) {
+	params := map[string]interface{}{
+		"userids":       []string{userID},
+		"output":        []string{"userid"},
+		"selectUsrgrps": []string{"name"},
+	}
+	if c.Capabilities().UserRoles {
+		params["selectRole"] = []string{"name"}
+	}
+
+	var users []struct {
+		Role struct {
+			Name string `json:"name"`
+		} `json:"role"`
+		Groups []struct {
+			Name string `json:"name"`
+		} `json:"usrgrps"`
+	}
+	if err := c.call(ctx, "user.get", params, &users); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("user %s: %w", userID, ErrNotFound)
+	}
+
+	profile := &UserProfile{Role: users[0].Role.Name}
+	for _, g := range users[0].Groups {
+		profile.Groups = append(profile.Groups, g.Name)
+	}
+	return profile, nil
+}
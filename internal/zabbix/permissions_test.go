@@ -0,0 +1,108 @@
+package zabbix
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestClient_GetPermissions(t *testing.T) {
+	tests := []struct {
+		name  string
+		users []map[string]any
+		roles any
+		want  Permissions
+	}{
+		{
+			name:  "user role without closing",
+			users: []map[string]any{{"userid": "5", "roleid": "1", "role": map[string]any{"type": "1"}}},
+			roles: []map[string]any{{"rules": map[string]any{
+				"actions":                []map[string]any{{"name": "close_problems", "status": "0"}},
+				"actions.default_access": "1",
+			}}},
+			want: Permissions{UserType: UserTypeUser, Acknowledge: true, Suppress: true, CheckNow: true},
+		},
+		{
+			name:  "admin role denying everything by default",
+			users: []map[string]any{{"userid": "5", "roleid": "2", "role": map[string]any{"type": "2"}}},
+			roles: []map[string]any{{"rules": map[string]any{
+				"actions":                []map[string]any{{"name": "acknowledge_problems", "status": "1"}},
+				"actions.default_access": "0",
+			}}},
+			want: Permissions{UserType: UserTypeAdmin, Acknowledge: true, Configure: true, CheckNow: true},
+		},
+		{
+			name:  "unreadable rules",
+			users: []map[string]any{{"userid": "5", "roleid": "1", "role": map[string]any{"type": "1"}}},
+			roles: []map[string]any{},
+			want:  Permissions{UserType: UserTypeUser, Acknowledge: true, Close: true, Suppress: true, CheckNow: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := map[string]map[string]any{}
+			server := newRecordingMockServer(t, map[string]mockResponse{
+				"user.get": {Result: tt.users},
+				"role.get": {Result: tt.roles},
+			}, params)
+			defer server.Close()
+
+			got, err := newTestClient(t, server.URL).GetPermissions(context.Background(), "5")
+			if err != nil {
+				t.Fatalf("GetPermissions() error = %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("GetPermissions() = %+v, want %+v", *got, tt.want)
+			}
+			if params["user.get"]["selectRole"] == nil {
+				t.Error("user.get did not select the role")
+			}
+		})
+	}
+}
+
+func TestClient_GetPermissions_BeforeRoles(t *testing.T) {
+	server := newMockServer(t, map[string]mockResponse{
+		"user.get": {Result: []map[string]any{{"userid": "5", "type": "2"}}},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	caps := CapabilitiesFor("5.0.40")
+	client.caps.Store(&caps)
+	got, err := client.GetPermissions(context.Background(), "5")
+	if err != nil {
+		t.Fatalf("GetPermissions() error = %v", err)
+	}
+	want := Permissions{
+		UserType: UserTypeAdmin, Acknowledge: true, Close: true, Suppress: true,
+		Configure: true, Maintenance: true, CheckNow: true,
+	}
+	if *got != want {
+		t.Errorf("GetPermissions() = %+v, want %+v", *got, want)
+	}
+}
+
+func TestClient_GetUserProfile(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"user.get": {Result: []map[string]any{{
+			"userid":  "5",
+			"role":    map[string]any{"name": "DBA"},
+			"usrgrps": []map[string]any{{"name": "Database team"}, {"name": "On call"}},
+		}}},
+	}, params)
+	defer server.Close()
+
+	got, err := newTestClient(t, server.URL).GetUserProfile(context.Background(), "5")
+	if err != nil {
+		t.Fatalf("GetUserProfile() error = %v", err)
+	}
+	if got.Role != "DBA" || !slices.Equal(got.Groups, []string{"Database team", "On call"}) {
+		t.Errorf("GetUserProfile() = %+v", *got)
+	}
+	if params["user.get"]["selectRole"] == nil || params["user.get"]["selectUsrgrps"] == nil {
+		t.Errorf("user.get params = %v, want the role and user groups selected", params["user.get"])
+	}
+}
@@ -0,0 +1,88 @@
+package zabbix
+
+import "strings"
+
+// PreprocessingStep is a step of an item's preprocessing, applied to each
+// collected value in order before it is stored.
+type PreprocessingStep struct {
+	Type   string `json:"type"`
+	Params string `json:"params"` // Parameters, one per line
+	// ErrorHandler is what happens when the step fails, see
+	// PreprocessingErrorDefault
+	ErrorHandler       string `json:"error_handler"`
+	ErrorHandlerParams string `json:"error_handler_params"`
+}
+
+// Preprocessing error handlers.
+const (
+	PreprocessingErrorDefault = "0" // The item becomes unsupported
+	PreprocessingErrorDiscard = "1" // The value is discarded
+	PreprocessingErrorValue   = "2" // A custom value is stored instead
+	PreprocessingErrorMessage = "3" // The item becomes unsupported with a custom error
+)
+
+// preprocessingTypes are the names of the preprocessing step types, as the
+// frontend shows them.
+var preprocessingTypes = map[string]string{
+	"1":  "Custom multiplier",
+	"2":  "Right trim",
+	"3":  "Left trim",
+	"4":  "Trim",
+	"5":  "Regular expression",
+	"6":  "Boolean to decimal",
+	"7":  "Octal to decimal",
+	"8":  "Hexadecimal to decimal",
+	"9":  "Simple change",
+	"10": "Change per second",
+	"11": "XML XPath",
+	"12": "JSONPath",
+	"13": "In range",
+	"14": "Matches regular expression",
+	"15": "Does not match regular expression",
+	"16": "Check for error in JSON",
+	"17": "Check for error in XML",
+	"18": "Check for error using regular expression",
+	"19": "Discard unchanged",
+	"20": "Discard unchanged with heartbeat",
+	"21": "JavaScript",
+	"22": "Prometheus pattern",
+	"23": "Prometheus to JSON",
+	"24": "CSV to JSON",
+	"25": "Replace",
+	"26": "Check for not supported value",
+	"27": "XML to JSON",
+	"28": "SNMP walk value",
+	"29": "SNMP walk to JSON",
+	"30": "SNMP get value",
+}
+
+// TypeName returns the name of the step's type.
+func (s PreprocessingStep) TypeName() string {
+	if name, ok := preprocessingTypes[s.Type]; ok {
+		return name
+	}
+	return "Type " + s.Type
+}
+
+// Parameters returns the step's parameters, without trailing empty ones.
+func (s PreprocessingStep) Parameters() []string {
+	params := strings.TrimRight(s.Params, "\n")
+	if params == "" {
+		return nil
+	}
+	return strings.Split(params, "\n")
+}
+
+// OnFail describes what happens when the step fails; empty for the default
+// of making the item unsupported.
+func (s PreprocessingStep) OnFail() string {
+	switch s.ErrorHandler {
+	case PreprocessingErrorDiscard:
+		return "Discard value"
+	case PreprocessingErrorValue:
+		return "Set value to " + s.ErrorHandlerParams
+	case PreprocessingErrorMessage:
+		return "Set error to " + s.ErrorHandlerParams
+	}
+	return ""
+}
@@ -0,0 +1,45 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestPreprocessingStep(t *testing.T) {
+	var item Item
+	err := json.Unmarshal([]byte(`{"itemid":"5","preprocessing":[
+		{"type":"5","params":"(\\d+) ms\n\\1","error_handler":"3","error_handler_params":"no latency"},
+		{"type":"19","params":"","error_handler":"0","error_handler_params":""},
+		{"type":"99","params":"x\n","error_handler":"1","error_handler_params":""}
+	]}`), &item)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	steps := item.Preprocessing
+	if len(steps) != 3 {
+		t.Fatalf("steps = %v, want 3", steps)
+	}
+
+	tests := []struct {
+		step   PreprocessingStep
+		name   string
+		params []string
+		onFail string
+	}{
+		{steps[0], "Regular expression", []string{`(\d+) ms`, `\1`}, "Set error to no latency"},
+		{steps[1], "Discard unchanged", nil, ""},
+		{steps[2], "Type 99", []string{"x"}, "Discard value"},
+	}
+	for _, tt := range tests {
+		if got := tt.step.TypeName(); got != tt.name {
+			t.Errorf("TypeName() = %q, want %q", got, tt.name)
+		}
+		if got := tt.step.Parameters(); !slices.Equal(got, tt.params) {
+			t.Errorf("%s: Parameters() = %q, want %q", tt.name, got, tt.params)
+		}
+		if got := tt.step.OnFail(); got != tt.onFail {
+			t.Errorf("%s: OnFail() = %q, want %q", tt.name, got, tt.onFail)
+		}
+	}
+}
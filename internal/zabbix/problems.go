@@ -3,6 +3,7 @@ package zabbix
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -10,6 +11,27 @@ import (
 type ProblemGetParams struct {
 	Severities []int
 	Limit      int
+	// Search returns only problems whose name contains each word
+	// (case-insensitive), except words in a host's name, which the caller
+	// must match (see NameSearch)
+	Search []string
+	// EventIDTill returns only problems with an event ID up to this one,
+	// used to fetch the page after a previous one (see ProblemPage.Next)
+	EventIDTill string
+	// Acknowledged returns only acknowledged (true) or unacknowledged
+	// (false) problems; nil for both
+	Acknowledged *bool
+	// HostIDs and GroupIDs return only problems of these hosts and host
+	// groups
+	HostIDs  []string
+	GroupIDs []string
+}
+
+// ProblemPage is one page of problems, newest first.
+type ProblemPage struct {
+	Problems []Problem
+	// Next is the EventIDTill value for the following page; empty when there are no more.
+	Next string
 }
 
 // DefaultProblemGetParams returns default parameters for fetching active problems.
@@ -27,6 +49,11 @@ type internalProblemGetParams struct {
 	SortField          []string    `json:"sortfield,omitempty"`
 	SortOrder          string      `json:"sortorder,omitempty"`
 	Limit              int         `json:"limit,omitempty"`
+	Search             interface{} `json:"search,omitempty"`
+	EventIDTill        string      `json:"eventid_till,omitempty"`
+	Acknowledged       *bool       `json:"acknowledged,omitempty"`
+	HostIDs            []string    `json:"hostids,omitempty"`
+	GroupIDs           []string    `json:"groupids,omitempty"`
 }
 
 // EventGetParams defines parameters for event.get API call.
@@ -38,26 +65,39 @@ type EventGetParams struct {
 	SelectTags          interface{} `json:"selectTags,omitempty"`
 	SelectAcknowledges  interface{} `json:"selectAcknowledges,omitempty"`
 	SelectRelatedObject interface{} `json:"selectRelatedObject,omitempty"`
-	EventIDs            []string    `json:"eventids,omitempty"`
-	HostIDs             []string    `json:"hostids,omitempty"`
-	GroupIDs            []string    `json:"groupids,omitempty"`
-	ObjectIDs           []string    `json:"objectids,omitempty"`
-	Severities          []int       `json:"severities,omitempty"`
-	Value               []int       `json:"value,omitempty"`  // 0 = OK, 1 = problem (can be array)
-	Source              *int        `json:"source,omitempty"` // 0 = trigger (single int, use pointer to omit when nil)
-	Object              *int        `json:"object,omitempty"` // 0 = trigger (single int, use pointer to omit when nil)
-	SortField           []string    `json:"sortfield,omitempty"`
-	SortOrder           string      `json:"sortorder,omitempty"`
-	Limit               int         `json:"limit,omitempty"`
-	TimeFrom            int64       `json:"time_from,omitempty"`
-	TimeTill            int64       `json:"time_till,omitempty"`
+	// SelectSuppressionData returns why suppressed events are suppressed
+	SelectSuppressionData interface{} `json:"selectSuppressionData,omitempty"`
+	EventIDs              []string    `json:"eventids,omitempty"`
+	HostIDs               []string    `json:"hostids,omitempty"`
+	GroupIDs              []string    `json:"groupids,omitempty"`
+	ObjectIDs             []string    `json:"objectids,omitempty"`
+	Severities            []int       `json:"severities,omitempty"`
+	Value                 []int       `json:"value,omitempty"`  // 0 = OK, 1 = problem (can be array)
+	Source                *int        `json:"source,omitempty"` // 0 = trigger (single int, use pointer to omit when nil)
+	Object                *int        `json:"object,omitempty"` // 0 = trigger (single int, use pointer to omit when nil)
+	SortField             []string    `json:"sortfield,omitempty"`
+	SortOrder             string      `json:"sortorder,omitempty"`
+	Limit                 int         `json:"limit,omitempty"`
+	TimeFrom              int64       `json:"time_from,omitempty"`
+	TimeTill              int64       `json:"time_till,omitempty"`
+	Search                interface{} `json:"search,omitempty"`
+	EventIDTill           string      `json:"eventid_till,omitempty"`
 }
 
 // GetProblems retrieves current active problems from Zabbix.
+func (c *Client) GetProblems(ctx context.Context, params ProblemGetParams) ([]Problem, error) {
+	page, err := c.GetProblemPage(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return page.Problems, nil
+}
+
+// GetProblemPage retrieves up to params.Limit active problems, newest first.
 // Uses a two-step approach:
 // 1. problem.get to get current active problem eventids (problem.get only returns unresolved problems)
 // 2. event.get with those eventids to get host information (selectHosts not supported in problem.get)
-func (c *Client) GetProblems(ctx context.Context, params ProblemGetParams) ([]Problem, error) {
+func (c *Client) GetProblemPage(ctx context.Context, params ProblemGetParams) (*ProblemPage, error) {
 	// Step 1: Get current active problems from problem.get
 	problemParams := internalProblemGetParams{
 		Output:             []string{"eventid"}, // Only need eventids for step 2
@@ -66,12 +106,26 @@ func (c *Client) GetProblems(ctx context.Context, params ProblemGetParams) ([]Pr
 		Severities:         params.Severities,
 		SortField:          []string{"eventid"},
 		SortOrder:          "DESC",
+		EventIDTill:        params.EventIDTill,
+		Acknowledged:       params.Acknowledged,
+		HostIDs:            params.HostIDs,
+		GroupIDs:           params.GroupIDs,
 	}
 
 	if params.Limit > 0 {
 		problemParams.Limit = params.Limit
 	}
 
+	if len(params.Search) > 0 {
+		search, err := c.NameSearch(ctx, params.Search)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get active problems: %w", err)
+		}
+		if search != nil {
+			problemParams.Search = search
+		}
+	}
+
 	var problemResults []struct {
 		EventID string `json:"eventid"`
 	}
@@ -81,7 +135,13 @@ func (c *Client) GetProblems(ctx context.Context, params ProblemGetParams) ([]Pr
 
 	// If no problems, return empty slice
 	if len(problemResults) == 0 {
-		return []Problem{}, nil
+		return &ProblemPage{Problems: []Problem{}}, nil
+	}
+
+	// A full page means there may be more; results are sorted by eventid DESC
+	page := &ProblemPage{}
+	if params.Limit > 0 && len(problemResults) == params.Limit {
+		page.Next = previousEventID(problemResults[len(problemResults)-1].EventID)
 	}
 
 	// Extract eventids
@@ -92,14 +152,15 @@ func (c *Client) GetProblems(ctx context.Context, params ProblemGetParams) ([]Pr
 
 	// Step 2: Get full event details with hosts and trigger status
 	eventParams := EventGetParams{
-		Output:              "extend",
-		SelectHosts:         []string{"hostid", "host", "name"},
-		SelectTags:          "extend",
-		SelectAcknowledges:  "extend",
-		SelectRelatedObject: []string{"triggerid", "status"},
-		EventIDs:            eventIDs,
-		SortField:           []string{"eventid"},
-		SortOrder:           "DESC",
+		Output:                "extend",
+		SelectHosts:           []string{"hostid", "host", "name"},
+		SelectTags:            "extend",
+		SelectAcknowledges:    "extend",
+		SelectRelatedObject:   []string{"triggerid", "status", "manual_close"},
+		SelectSuppressionData: "extend",
+		EventIDs:              eventIDs,
+		SortField:             []string{"eventid"},
+		SortOrder:             "DESC",
 	}
 
 	var problems []Problem
@@ -109,14 +170,163 @@ func (c *Client) GetProblems(ctx context.Context, params ProblemGetParams) ([]Pr
 
 	// Filter out problems where the trigger is disabled (status=1)
 	// This matches Zabbix web UI behavior which hides disabled trigger problems
-	filtered := make([]Problem, 0, len(problems))
+	page.Problems = make([]Problem, 0, len(problems))
 	for _, p := range problems {
 		if p.RelatedObject.Status != "1" {
-			filtered = append(filtered, p)
+			p.Name = ExpandHostMacros(p.Name, p.Hosts)
+			page.Problems = append(page.Problems, p)
 		}
 	}
 
-	return filtered, nil
+	return page, nil
+}
+
+// HostProblems summarizes the active problems of a host.
+type HostProblems struct {
+	Count int
+	Worst int // Highest severity (0-5)
+}
+
+// GetHostProblems counts the active problems of each host, by host ID, with
+// their highest severity. All problems are fetched with one problem.get, or
+// only those of hostIDs if given, and one trigger.get maps their triggers to
+// hosts. Problems of disabled triggers are left out, as in the alerts list.
+func (c *Client) GetHostProblems(ctx context.Context, hostIDs []string) (map[string]HostProblems, error) {
+	problemParams := internalProblemGetParams{
+		Output:  []string{"eventid", "objectid", "severity"},
+		HostIDs: hostIDs,
+	}
+	var problems []Problem
+	if err := c.call(ctx, "problem.get", problemParams, &problems); err != nil {
+		return nil, fmt.Errorf("failed to get active problems: %w", err)
+	}
+
+	summary := make(map[string]HostProblems)
+	if len(problems) == 0 {
+		return summary, nil
+	}
+
+	seen := make(map[string]bool)
+	var triggerIDs []string
+	for _, p := range problems {
+		if !seen[p.ObjectID] {
+			seen[p.ObjectID] = true
+			triggerIDs = append(triggerIDs, p.ObjectID)
+		}
+	}
+	triggers, err := c.GetTriggers(ctx, TriggerGetParams{
+		Output:      []string{"triggerid", "status"},
+		SelectHosts: []string{"hostid"},
+		TriggerIDs:  triggerIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	hostsOf := make(map[string][]Host, len(triggers))
+	for _, t := range triggers {
+		if t.Status != TriggerStatusDisabled {
+			hostsOf[t.TriggerID] = t.Hosts
+		}
+	}
+
+	for _, p := range problems {
+		for _, h := range hostsOf[p.ObjectID] {
+			s := summary[h.HostID]
+			s.Count++
+			s.Worst = max(s.Worst, p.SeverityInt())
+			summary[h.HostID] = s
+		}
+	}
+	return summary, nil
+}
+
+// ProblemCounts counts the active problems by severity.
+type ProblemCounts struct {
+	Severities     [6]int // Problems of each severity (0-5)
+	Unacknowledged int
+}
+
+// Total returns the number of problems.
+func (p ProblemCounts) Total() int {
+	total := 0
+	for _, n := range p.Severities {
+		total += n
+	}
+	return total
+}
+
+// Worst returns the highest severity of the problems, or -1 if there are
+// none.
+func (p ProblemCounts) Worst() int {
+	for s := len(p.Severities) - 1; s >= 0; s-- {
+		if p.Severities[s] > 0 {
+			return s
+		}
+	}
+	return -1
+}
+
+// CountProblems counts the active problems of at least minSeverity with one
+// problem.get and one trigger.get, much cheaper than fetching them. Problems
+// of disabled triggers are left out, as in the alerts list.
+func (c *Client) CountProblems(ctx context.Context, minSeverity int) (ProblemCounts, error) {
+	problemParams := internalProblemGetParams{
+		Output:     []string{"eventid", "objectid", "severity", "acknowledged"},
+		Severities: SeveritiesFrom(minSeverity),
+	}
+	var problems []Problem
+	if err := c.call(ctx, "problem.get", problemParams, &problems); err != nil {
+		return ProblemCounts{}, fmt.Errorf("failed to get active problems: %w", err)
+	}
+
+	var counts ProblemCounts
+	if len(problems) == 0 {
+		return counts, nil
+	}
+
+	seen := make(map[string]bool)
+	var triggerIDs []string
+	for _, p := range problems {
+		if !seen[p.ObjectID] {
+			seen[p.ObjectID] = true
+			triggerIDs = append(triggerIDs, p.ObjectID)
+		}
+	}
+	triggers, err := c.GetTriggers(ctx, TriggerGetParams{
+		Output:     []string{"triggerid", "status"},
+		TriggerIDs: triggerIDs,
+	})
+	if err != nil {
+		return ProblemCounts{}, err
+	}
+	disabled := make(map[string]bool)
+	for _, t := range triggers {
+		if t.Status == TriggerStatusDisabled {
+			disabled[t.TriggerID] = true
+		}
+	}
+
+	for _, p := range problems {
+		s := p.SeverityInt()
+		if disabled[p.ObjectID] || s < 0 || s >= len(counts.Severities) {
+			continue
+		}
+		counts.Severities[s]++
+		if !p.IsAcknowledged() {
+			counts.Unacknowledged++
+		}
+	}
+	return counts, nil
+}
+
+// previousEventID returns the event ID just below id, for eventid_till paging.
+// Returns empty if id is not a positive number.
+func previousEventID(id string) string {
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || n == 0 {
+		return ""
+	}
+	return strconv.FormatUint(n-1, 10)
 }
 
 // GetActiveProblems retrieves all active (unresolved) problems.
@@ -128,15 +338,17 @@ func (c *Client) GetActiveProblems(ctx context.Context) ([]Problem, error) {
 // GetProblemsWithMinSeverity retrieves problems with at least the given severity.
 func (c *Client) GetProblemsWithMinSeverity(ctx context.Context, minSeverity int) ([]Problem, error) {
 	params := DefaultProblemGetParams()
+	params.Severities = SeveritiesFrom(minSeverity)
+	return c.GetProblems(ctx, params)
+}
 
-	// Build severity list from minSeverity to 5
+// SeveritiesFrom returns the severity list from minSeverity up to Disaster (5).
+func SeveritiesFrom(minSeverity int) []int {
 	severities := make([]int, 0)
 	for s := minSeverity; s <= 5; s++ {
 		severities = append(severities, s)
 	}
-	params.Severities = severities
-
-	return c.GetProblems(ctx, params)
+	return severities
 }
 
 // AcknowledgeParams defines parameters for event.acknowledge API call.
@@ -145,6 +357,9 @@ type AcknowledgeParams struct {
 	Action   int      `json:"action"`
 	Message  string   `json:"message,omitempty"`
 	Severity int      `json:"severity,omitempty"`
+	// SuppressUntil is when a suppress action ends, as Unix time; 0 for
+	// indefinitely
+	SuppressUntil int64 `json:"suppress_until,omitempty"`
 }
 
 // AcknowledgeAction constants for the action bitmask.
@@ -227,12 +442,41 @@ func (c *Client) CloseProblem(ctx context.Context, eventID, message string) erro
 	return nil
 }
 
-// SuppressProblem suppresses a problem event.
-func (c *Client) SuppressProblem(ctx context.Context, eventID string) error {
+// AcknowledgeAndCloseProblem acknowledges and closes a problem in one call.
+// The server rejects the whole call, leaving the problem unacknowledged, if
+// the trigger does not allow manual closing.
+func (c *Client) AcknowledgeAndCloseProblem(ctx context.Context, eventID, message string) error {
+	action := ActionAcknowledge | ActionClose
+	if message != "" {
+		action |= ActionAddMessage
+	}
+
 	params := AcknowledgeParams{
 		EventIDs: []string{eventID},
+		Action:   action,
+		Message:  message,
+	}
+
+	// Result contains eventids but the type varies by Zabbix version (string or number)
+	// We don't need the result, just check if the call succeeded
+	var result interface{}
+	if err := c.call(ctx, "event.acknowledge", params, &result); err != nil {
+		return fmt.Errorf("failed to acknowledge and close problem: %w", err)
+	}
+
+	return nil
+}
+
+// SuppressProblems suppresses problem events until a time, or
+// indefinitely when until is zero. Requires Zabbix 6.2 or later.
+func (c *Client) SuppressProblems(ctx context.Context, eventIDs []string, until time.Time) error {
+	params := AcknowledgeParams{
+		EventIDs: eventIDs,
 		Action:   ActionSuppress,
 	}
+	if !until.IsZero() {
+		params.SuppressUntil = until.Unix()
+	}
 
 	// Result contains eventids but the type varies by Zabbix version (string or number)
 	// We don't need the result, just check if the call succeeded
@@ -267,6 +511,23 @@ type EventHistoryParams struct {
 	TimeFrom int64 // Unix timestamp - events from this time
 	TimeTill int64 // Unix timestamp - events until this time
 	HostIDs  []string
+	GroupIDs []string // Only events of hosts in these host groups
+	Search   []string // Words the event name contains, as ProblemGetParams.Search
+	// Values limits the events to problems (1) or recoveries (0); empty for both
+	Values []int
+	// Severities limits the events to these severities. Recovery events
+	// have severity 0 (not classified)
+	Severities []int
+	// EventIDTill returns only events with an event ID up to this one,
+	// used to fetch the page after a previous one (see EventPage.Next)
+	EventIDTill string
+}
+
+// EventPage is one page of events, newest first.
+type EventPage struct {
+	Events []Event
+	// Next is the EventIDTill value for the following page; empty when there are no more.
+	Next string
 }
 
 // DefaultEventHistoryParams returns default parameters for event history.
@@ -279,6 +540,15 @@ func DefaultEventHistoryParams() EventHistoryParams {
 // GetEventHistory retrieves recent events (both problem and recovery).
 // This shows historical events, not just active problems.
 func (c *Client) GetEventHistory(ctx context.Context, params EventHistoryParams) ([]Event, error) {
+	page, err := c.GetEventPage(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return page.Events, nil
+}
+
+// GetEventPage retrieves up to params.Limit events (default 100), newest first.
+func (c *Client) GetEventPage(ctx context.Context, params EventHistoryParams) (*EventPage, error) {
 	source := 0 // 0 = trigger events
 	object := 0 // 0 = trigger
 
@@ -292,6 +562,7 @@ func (c *Client) GetEventHistory(ctx context.Context, params EventHistoryParams)
 		SortField:          []string{"clock", "eventid"},
 		SortOrder:          "DESC",
 		Limit:              params.Limit,
+		EventIDTill:        params.EventIDTill,
 	}
 
 	if params.Limit == 0 {
@@ -309,13 +580,34 @@ func (c *Client) GetEventHistory(ctx context.Context, params EventHistoryParams)
 	if len(params.HostIDs) > 0 {
 		eventParams.HostIDs = params.HostIDs
 	}
+	eventParams.GroupIDs = params.GroupIDs
+
+	if len(params.Search) > 0 {
+		search, err := c.NameSearch(ctx, params.Search)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get event history: %w", err)
+		}
+		if search != nil {
+			eventParams.Search = search
+		}
+	}
+
+	eventParams.Value = params.Values
+	eventParams.Severities = params.Severities
 
 	var events []Event
 	if err := c.call(ctx, "event.get", eventParams, &events); err != nil {
 		return nil, fmt.Errorf("failed to get event history: %w", err)
 	}
+	for i := range events {
+		events[i].Name = ExpandHostMacros(events[i].Name, events[i].Hosts)
+	}
 
-	return events, nil
+	page := &EventPage{Events: events}
+	if len(events) > 0 && len(events) == eventParams.Limit {
+		page.Next = previousEventID(events[len(events)-1].EventID)
+	}
+	return page, nil
 }
 
 // GetRecentEvents retrieves events from the last N hours.
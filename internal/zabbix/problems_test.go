@@ -2,7 +2,11 @@ package zabbix
 
 import (
 	"context"
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestClient_GetProblems(t *testing.T) {
@@ -217,6 +221,160 @@ func TestClient_GetProblemsWithMinSeverity(t *testing.T) {
 	}
 }
 
+func TestClient_GetProblems_Search(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"problem.get": {Result: []map[string]string{{"eventid": "1"}}},
+		"event.get":   {Result: []Problem{{EventID: "1", Name: "Disk full"}}},
+		// "web" is in a host's name
+		"host.get": {Result: func(p map[string]any) any {
+			if search, _ := p["search"].(map[string]any); search["name"] == "web" {
+				return []Host{{HostID: "1"}}
+			}
+			return []Host{}
+		}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	_, err := client.GetProblems(context.Background(), ProblemGetParams{
+		Severities:   SeveritiesFrom(4),
+		Search:       []string{"disk", "web", "full"},
+		Acknowledged: new(bool),
+	})
+	if err != nil {
+		t.Fatalf("GetProblems() error = %v", err)
+	}
+
+	// Each word is searched for, but the host's is left to the caller
+	search, _ := params["problem.get"]["search"].(map[string]any)
+	if names, _ := search["name"].([]any); len(names) != 2 || names[0] != "disk" || names[1] != "full" {
+		t.Errorf("problem.get search = %v, want name=[disk full]", params["problem.get"]["search"])
+	}
+	if _, ok := params["problem.get"]["searchByAny"]; ok {
+		t.Error("problem.get should match every word")
+	}
+	if sev, _ := params["problem.get"]["severities"].([]any); len(sev) != 2 {
+		t.Errorf("problem.get severities = %v, want [4 5]", params["problem.get"]["severities"])
+	}
+	if ack, ok := params["problem.get"]["acknowledged"].(bool); !ok || ack {
+		t.Errorf("problem.get acknowledged = %v, want false", params["problem.get"]["acknowledged"])
+	}
+}
+
+func TestClient_GetProblemPage(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"problem.get": {Result: []map[string]string{{"eventid": "9"}, {"eventid": "7"}}},
+		"event.get":   {Result: []Problem{{EventID: "9"}, {EventID: "7"}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	page, err := client.GetProblemPage(context.Background(), ProblemGetParams{Limit: 2, EventIDTill: "10"})
+	if err != nil {
+		t.Fatalf("GetProblemPage() error = %v", err)
+	}
+	if len(page.Problems) != 2 {
+		t.Errorf("len(Problems) = %d, want 2", len(page.Problems))
+	}
+	if page.Next != "6" {
+		t.Errorf("Next = %q, want %q", page.Next, "6")
+	}
+	if params["problem.get"]["eventid_till"] != "10" {
+		t.Errorf("problem.get eventid_till = %v, want 10", params["problem.get"]["eventid_till"])
+	}
+
+	// A short page is the last one
+	page, err = client.GetProblemPage(context.Background(), ProblemGetParams{Limit: 5})
+	if err != nil {
+		t.Fatalf("GetProblemPage() error = %v", err)
+	}
+	if page.Next != "" {
+		t.Errorf("Next = %q, want empty for the last page", page.Next)
+	}
+}
+
+func TestClient_GetHostProblems(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"problem.get": {Result: []Problem{
+			{EventID: "1", ObjectID: "10", Severity: "2"},
+			{EventID: "2", ObjectID: "11", Severity: "4"},
+			{EventID: "3", ObjectID: "10", Severity: "2"},
+			{EventID: "4", ObjectID: "12", Severity: "5"},
+		}},
+		"trigger.get": {Result: []Trigger{
+			{TriggerID: "10", Status: "0", Hosts: []Host{{HostID: "1"}}},
+			{TriggerID: "11", Status: "0", Hosts: []Host{{HostID: "1"}, {HostID: "2"}}},
+			{TriggerID: "12", Status: "1", Hosts: []Host{{HostID: "3"}}},
+		}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	summary, err := client.GetHostProblems(context.Background(), []string{"1", "2", "3"})
+	if err != nil {
+		t.Fatalf("GetHostProblems() error = %v", err)
+	}
+	want := map[string]HostProblems{"1": {Count: 3, Worst: 4}, "2": {Count: 1, Worst: 4}}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("GetHostProblems() = %v, want %v (disabled trigger left out)", summary, want)
+	}
+	if ids, _ := params["trigger.get"]["triggerids"].([]any); len(ids) != 3 {
+		t.Errorf("trigger.get triggerids = %v, want each trigger once", params["trigger.get"]["triggerids"])
+	}
+	if ids, _ := params["problem.get"]["hostids"].([]any); len(ids) != 3 {
+		t.Errorf("problem.get hostids = %v, want the three hosts", params["problem.get"]["hostids"])
+	}
+}
+
+func TestClient_CountProblems(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"problem.get": {Result: []Problem{
+			{EventID: "1", ObjectID: "10", Severity: "2", Acknowledged: "1"},
+			{EventID: "2", ObjectID: "11", Severity: "4"},
+			{EventID: "3", ObjectID: "10", Severity: "2"},
+			{EventID: "4", ObjectID: "12", Severity: "5"},
+		}},
+		"trigger.get": {Result: []Trigger{
+			{TriggerID: "10", Status: "0"},
+			{TriggerID: "11", Status: "0"},
+			{TriggerID: "12", Status: "1"},
+		}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	counts, err := client.CountProblems(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("CountProblems() error = %v", err)
+	}
+	want := ProblemCounts{Severities: [6]int{2: 2, 4: 1}, Unacknowledged: 2}
+	if counts != want {
+		t.Errorf("CountProblems() = %+v, want %+v (disabled trigger left out)", counts, want)
+	}
+	if counts.Total() != 3 || counts.Worst() != 4 {
+		t.Errorf("Total() = %d, Worst() = %d, want 3 and 4", counts.Total(), counts.Worst())
+	}
+	if severities, _ := params["problem.get"]["severities"].([]any); len(severities) != 4 {
+		t.Errorf("problem.get severities = %v, want 2 to 5", params["problem.get"]["severities"])
+	}
+	if (ProblemCounts{}).Worst() != -1 {
+		t.Error("Worst() of no problems should be -1")
+	}
+}
+
+func TestPreviousEventID(t *testing.T) {
+	tests := map[string]string{"100": "99", "1": "0", "0": "", "abc": "", "": ""}
+	for id, want := range tests {
+		if got := previousEventID(id); got != want {
+			t.Errorf("previousEventID(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
 func TestClient_AcknowledgeProblem(t *testing.T) {
 	server := newMockServer(t, map[string]mockResponse{
 		"event.acknowledge": {
@@ -286,20 +444,76 @@ func TestClient_CloseProblem(t *testing.T) {
 	}
 }
 
-func TestClient_SuppressProblem(t *testing.T) {
-	server := newMockServer(t, map[string]mockResponse{
+func TestClient_AcknowledgeAndCloseProblem(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
 		"event.acknowledge": {
 			Result: map[string]any{"eventids": []string{"123"}},
 		},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.token = "test-token"
+
+	if err := client.AcknowledgeAndCloseProblem(context.Background(), "123", "Fixed"); err != nil {
+		t.Fatalf("AcknowledgeAndCloseProblem() error = %v", err)
+	}
+	want := float64(ActionAcknowledge | ActionClose | ActionAddMessage)
+	if got := params["event.acknowledge"]["action"]; got != want {
+		t.Errorf("action = %v, want %v", got, want)
+	}
+}
+
+func TestClient_AcknowledgeAndCloseProblem_NotAllowed(t *testing.T) {
+	server := newMockServer(t, map[string]mockResponse{
+		"event.acknowledge": {
+			Error: &APIError{
+				Code:    -32500,
+				Message: "Application error.",
+				Data:    "Cannot close problem: trigger does not allow manual closing.",
+			},
+		},
 	})
 	defer server.Close()
 
 	client := newTestClient(t, server.URL)
 	client.token = "test-token"
 
-	err := client.SuppressProblem(context.Background(), "123")
-	if err != nil {
-		t.Fatalf("SuppressProblem() error = %v", err)
+	err := client.AcknowledgeAndCloseProblem(context.Background(), "123", "")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !strings.Contains(apiErr.Data, "manual closing") {
+		t.Errorf("AcknowledgeAndCloseProblem() error = %v, want the server's API error", err)
+	}
+}
+
+func TestClient_SuppressProblems(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"event.acknowledge": {
+			Result: map[string]any{"eventids": []string{"123"}},
+		},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.token = "test-token"
+
+	until := time.Unix(1700003600, 0)
+	if err := client.SuppressProblems(context.Background(), []string{"123"}, until); err != nil {
+		t.Fatalf("SuppressProblems() error = %v", err)
+	}
+	got := params["event.acknowledge"]
+	if got["action"] != float64(ActionSuppress) || got["suppress_until"] != float64(1700003600) {
+		t.Errorf("event.acknowledge params = %v, want a suppress action until 1700003600", got)
+	}
+
+	// Indefinitely
+	if err := client.SuppressProblems(context.Background(), []string{"123"}, time.Time{}); err != nil {
+		t.Fatalf("SuppressProblems() error = %v", err)
+	}
+	if _, ok := params["event.acknowledge"]["suppress_until"]; ok {
+		t.Errorf("event.acknowledge params = %v, want no end", params["event.acknowledge"])
 	}
 }
 
@@ -0,0 +1,143 @@
+package zabbix
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harpchad/chotko/internal/format"
+)
+
+// ItemTypeInternal is the type of the items a Zabbix server or proxy
+// collects about itself, e.g. zabbix[queue].
+const ItemTypeInternal = "5"
+
+// DefaultQueueDelay is how late an item must be to count in zabbix[queue]
+// when the item key gives no lower bound.
+const DefaultQueueDelay = 6 * time.Second
+
+// Queue is how many items a Zabbix server or proxy is late collecting, from
+// the zabbix[queue,<from>,<to>] internal items of the host monitoring it.
+// The API has no queue of its own: the frontend's queue report asks the
+// server directly.
+type Queue struct {
+	Host    Host
+	Buckets []QueueBucket // By From, then To
+}
+
+// QueueBucket is the number of items late by at least From and less than
+// To, or any longer when To is zero.
+type QueueBucket struct {
+	From, To time.Duration
+	Count    int
+	Clock    time.Time // When the count was collected
+}
+
+// Label returns the delays a bucket counts, e.g. "1m-5m" or "10m+".
+func (b QueueBucket) Label() string {
+	if b.To == 0 {
+		return format.Span(b.From) + "+"
+	}
+	return format.Span(b.From) + "-" + format.Span(b.To)
+}
+
+// parseQueueKey returns the delays counted by a zabbix[queue,<from>,<to>]
+// item key. ok is false for other keys.
+func parseQueueKey(key string) (from, to time.Duration, ok bool) {
+	rest, found := strings.CutPrefix(key, "zabbix[queue")
+	if !found || !strings.HasSuffix(rest, "]") {
+		return 0, 0, false
+	}
+	rest = strings.TrimSuffix(rest, "]")
+	if rest != "" && !strings.HasPrefix(rest, ",") {
+		return 0, 0, false
+	}
+
+	params := strings.Split(strings.TrimPrefix(rest, ","), ",")
+	from = DefaultQueueDelay
+	if p := strings.Trim(strings.TrimSpace(params[0]), `"`); p != "" {
+		if from, ok = parseTimeParam(p); !ok {
+			return 0, 0, false
+		}
+	}
+	if len(params) > 1 {
+		if p := strings.Trim(strings.TrimSpace(params[1]), `"`); p != "" {
+			if to, ok = parseTimeParam(p); !ok {
+				return 0, 0, false
+			}
+		}
+	}
+	return from, to, true
+}
+
+// parseTimeParam parses a time parameter of an item key or a trigger
+// function: seconds, or a number with a time suffix such as "10m".
+func parseTimeParam(s string) (time.Duration, bool) {
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return time.Duration(n) * time.Second, true
+	}
+	d, err := format.ParseSpan(s)
+	return d, err == nil
+}
+
+// GetQueues retrieves the queues of the Zabbix servers and proxies that
+// monitor themselves, sorted by host name. Hosts without queue items are
+// not listed.
+func (c *Client) GetQueues(ctx context.Context) ([]Queue, error) {
+	items, err := c.GetItems(ctx, ItemGetParams{
+		Output:      []string{"itemid", "hostid", "key_", "lastvalue", "lastclock"},
+		SelectHosts: []string{"hostid", "host", "name"},
+		Search:      map[string]string{"key_": "zabbix[queue"},
+		Monitored:   true,
+		Filter: map[string]interface{}{
+			"type":   ItemTypeInternal,
+			"status": ItemStatusEnabled,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byHost := make(map[string]*Queue)
+	var queues []*Queue
+	for _, item := range items {
+		from, to, ok := parseQueueKey(item.Key)
+		if !ok {
+			continue
+		}
+		hostID := item.GetHostID()
+		q := byHost[hostID]
+		if q == nil {
+			q = &Queue{Host: Host{HostID: hostID}}
+			if len(item.Hosts) > 0 {
+				q.Host = item.Hosts[0]
+			}
+			byHost[hostID] = q
+			queues = append(queues, q)
+		}
+		count, _ := strconv.Atoi(item.LastValue)
+		q.Buckets = append(q.Buckets, QueueBucket{From: from, To: to, Count: count, Clock: item.LastTime()})
+	}
+
+	result := make([]Queue, 0, len(queues))
+	for _, q := range queues {
+		slices.SortFunc(q.Buckets, func(a, b QueueBucket) int {
+			// Open-ended buckets after the bounded ones from the same delay
+			if c := cmp.Compare(a.From, b.From); c != 0 {
+				return c
+			}
+			if a.To == 0 || b.To == 0 {
+				return cmp.Compare(b.To, a.To)
+			}
+			return cmp.Compare(a.To, b.To)
+		})
+		result = append(result, *q)
+	}
+	slices.SortFunc(result, func(a, b Queue) int {
+		return strings.Compare(a.Host.DisplayName(), b.Host.DisplayName())
+	})
+	return result, nil
+}
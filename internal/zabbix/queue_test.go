@@ -0,0 +1,73 @@
+package zabbix
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseQueueKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		from, to time.Duration
+		ok       bool
+	}{
+		{"zabbix[queue]", DefaultQueueDelay, 0, true},
+		{"zabbix[queue,10m]", 10 * time.Minute, 0, true},
+		{"zabbix[queue,,30]", DefaultQueueDelay, 30 * time.Second, true},
+		{`zabbix[queue,"1m","5m"]`, time.Minute, 5 * time.Minute, true},
+		{"zabbix[queue,soon]", 0, 0, false},
+		{"zabbix[queue_size]", 0, 0, false},
+		{"zabbix[process,poller,avg,busy]", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		from, to, ok := parseQueueKey(tt.key)
+		if ok != tt.ok || from != tt.from || to != tt.to {
+			t.Errorf("parseQueueKey(%q) = %v, %v, %v, want %v, %v, %v", tt.key, from, to, ok, tt.from, tt.to, tt.ok)
+		}
+	}
+}
+
+func TestClient_GetQueues(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"item.get": {Result: []map[string]any{
+			{"itemid": "1", "hostid": "10", "key_": "zabbix[queue]", "lastvalue": "42", "lastclock": "1700000000", "hosts": []map[string]string{{"hostid": "10", "host": "zabbix-server", "name": "Zabbix server"}}},
+			{"itemid": "2", "hostid": "10", "key_": "zabbix[queue,10m]", "lastvalue": "3", "lastclock": "1700000000", "hosts": []map[string]string{{"hostid": "10", "host": "zabbix-server", "name": "Zabbix server"}}},
+			{"itemid": "3", "hostid": "10", "key_": "zabbix[queue,1m,10m]", "lastvalue": "7", "lastclock": "1700000000", "hosts": []map[string]string{{"hostid": "10", "host": "zabbix-server", "name": "Zabbix server"}}},
+			{"itemid": "4", "hostid": "20", "key_": "zabbix[queue]", "lastvalue": "0", "lastclock": "1700000000", "hosts": []map[string]string{{"hostid": "20", "host": "proxy-a", "name": "A proxy"}}},
+			{"itemid": "5", "hostid": "20", "key_": "zabbix[queue_size]", "lastvalue": "100", "lastclock": "1700000000", "hosts": []map[string]string{{"hostid": "20", "host": "proxy-a", "name": "A proxy"}}},
+		}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	queues, err := client.GetQueues(context.Background())
+	if err != nil {
+		t.Fatalf("GetQueues() error = %v", err)
+	}
+	if len(queues) != 2 || queues[0].Host.Name != "A proxy" || queues[1].Host.Name != "Zabbix server" {
+		t.Fatalf("queues = %+v, want the proxy then the server", queues)
+	}
+	if got := queues[0].Buckets; len(got) != 1 || got[0].Count != 0 {
+		t.Errorf("proxy buckets = %+v, want only zabbix[queue]", got)
+	}
+
+	var labels []string
+	for _, b := range queues[1].Buckets {
+		labels = append(labels, b.Label())
+	}
+	if want := []string{"6s+", "1m-10m", "10m+"}; !slices.Equal(labels, want) {
+		t.Errorf("server buckets = %v, want %v", labels, want)
+	}
+	if b := queues[1].Buckets[0]; b.Count != 42 || b.Clock.Unix() != 1700000000 {
+		t.Errorf("zabbix[queue] bucket = %+v, want 42 late items at its last clock", b)
+	}
+
+	filter, _ := params["item.get"]["filter"].(map[string]any)
+	if filter["type"] != ItemTypeInternal {
+		t.Errorf("item.get filter = %v, want internal items", filter)
+	}
+}
@@ -0,0 +1,121 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// SearchResults are the objects found by Search.
+type SearchResults struct {
+	Hosts     []Host
+	Triggers  []Trigger
+	Items     []Item
+	Templates []Template // With the hosts linked to them
+}
+
+// Count returns the number of objects found.
+func (r *SearchResults) Count() int {
+	return len(r.Hosts) + len(r.Triggers) + len(r.Items) + len(r.Templates)
+}
+
+// Search looks up, in parallel, the monitored hosts, the triggers and items
+// of monitored hosts, and the templates whose names contain text, as the
+// search parameter of the API does: in any case, anywhere in the name. Hosts
+// and templates also match by technical name and items by key. At most limit
+// objects of each kind are returned.
+func (c *Client) Search(ctx context.Context, text string, limit int) (*SearchResults, error) {
+	var (
+		results SearchResults
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		first   error
+	)
+	run := func(search func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := search(); err != nil {
+				mu.Lock()
+				if first == nil {
+					first = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	run(func() (err error) {
+		params := DefaultHostGetParams()
+		params.Search = map[string]string{"host": text, "name": text}
+		params.SearchByAny = true
+		params.Limit = limit
+		results.Hosts, err = c.GetHosts(ctx, params)
+		return err
+	})
+	run(func() (err error) {
+		params := DefaultTriggerGetParams()
+		params.Search = map[string]string{"description": text}
+		params.Active = true
+		params.Limit = limit
+		results.Triggers, err = c.GetTriggers(ctx, params)
+		return err
+	})
+	run(func() (err error) {
+		params := DefaultItemGetParams()
+		params.Search = map[string]string{"name": text, "key_": text}
+		params.SearchByAny = true
+		params.Limit = limit
+		results.Items, err = c.GetItems(ctx, params)
+		return err
+	})
+	run(func() error {
+		params := map[string]interface{}{
+			"output":      []string{"templateid", "host", "name"},
+			"selectHosts": []string{"hostid", "host", "name"},
+			"search":      map[string]string{"host": text, "name": text},
+			"searchByAny": true,
+			"sortfield":   "name",
+			"limit":       limit,
+		}
+		if err := c.call(ctx, "template.get", params, &results.Templates); err != nil {
+			return fmt.Errorf("failed to get templates: %w", err)
+		}
+		return nil
+	})
+
+	wg.Wait()
+	if first != nil {
+		return nil, first
+	}
+	return &results, nil
+}
+
+// NameSearch returns the search parameter of problem.get and event.get for
+// names containing each of words, or nil to search for none. Filters match
+// problems and events by their host's name too, which the API can't search
+// along with theirs, so the words in the name of a host are left out, for
+// the caller to match in the results.
+func (c *Client) NameSearch(ctx context.Context, words []string) (map[string]any, error) {
+	var names []string
+	for _, word := range words {
+		params := HostGetParams{
+			Output:      []string{"hostid"},
+			Search:      map[string]string{"name": word, "host": word},
+			SearchByAny: true,
+			Limit:       1,
+		}
+		hosts, err := c.GetHosts(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if len(hosts) == 0 {
+			names = append(names, word)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	// With searchByAny off, a name must contain every word
+	return map[string]any{"name": names}, nil
+}
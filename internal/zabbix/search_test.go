@@ -0,0 +1,60 @@
+package zabbix
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClient_Search(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"host.get":    {Result: []Host{{HostID: "1", Host: "db01", Name: "db01"}}},
+		"trigger.get": {Result: []Trigger{{TriggerID: "10", Description: "db01 is down"}}},
+		"item.get":    {Result: []Item{{ItemID: "100", Name: "DB connections", Key: "db.connections"}}},
+		"template.get": {Result: []map[string]any{{
+			"templateid": "200", "host": "Template DB", "name": "Template DB",
+			"hosts": []map[string]any{{"hostid": "1", "host": "db01", "name": "db01"}},
+		}}},
+	}, params)
+	defer server.Close()
+
+	got, err := newTestClient(t, server.URL).Search(context.Background(), "db", 50)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if got.Count() != 4 || got.Items[0].Key != "db.connections" || len(got.Templates[0].Hosts) != 1 {
+		t.Errorf("Search() = %+v", *got)
+	}
+
+	for _, method := range []string{"host.get", "trigger.get", "item.get", "template.get"} {
+		search, _ := params[method]["search"].(map[string]any)
+		if len(search) == 0 {
+			t.Errorf("%s was not searched: %v", method, params[method])
+		}
+		for field, value := range search {
+			if value != "db" {
+				t.Errorf("%s searched %s for %v, want db", method, field, value)
+			}
+		}
+		if params[method]["limit"] != float64(50) {
+			t.Errorf("%s limit = %v, want 50", method, params[method]["limit"])
+		}
+	}
+	if params["item.get"]["searchByAny"] != true {
+		t.Error("items should match by name or key")
+	}
+}
+
+func TestClient_Search_Error(t *testing.T) {
+	server := newMockServer(t, map[string]mockResponse{
+		"host.get":     {Result: []Host{}},
+		"trigger.get":  {Error: &APIError{Code: -32602, Message: "Invalid params."}},
+		"item.get":     {Result: []Item{}},
+		"template.get": {Result: []Template{}},
+	})
+	defer server.Close()
+
+	if _, err := newTestClient(t, server.URL).Search(context.Background(), "db", 50); err == nil {
+		t.Error("Search() should fail when one of the searches fails")
+	}
+}
@@ -0,0 +1,205 @@
+package zabbix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServerInfo is what the API tells about a Zabbix installation, like the
+// frontend's System information.
+type ServerInfo struct {
+	// Version of the API, that is of the frontend; the server runs the
+	// same major version
+	Version string
+	// Hosts, items and triggers the user can see
+	Hosts, Items, Triggers int
+	// Servers and proxies that monitor themselves, sorted by host name
+	Servers []ServerStats
+	// Housekeeping settings; nil unless the user is a super admin on
+	// Zabbix 5.2 or later
+	Housekeeping *Housekeeping
+}
+
+// RequiredDBVersion returns the database version the server of a Zabbix
+// release requires at least, e.g. 6040000 for 6.4.x; 0 if the version can't
+// be parsed.
+func RequiredDBVersion(version string) int {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return 0
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0
+	}
+	return major*1000000 + minor*10000
+}
+
+// ServerStats are the internal items a Zabbix server or proxy collects
+// about itself. Items the host does not have are left zero.
+type ServerStats struct {
+	Host    Host
+	Version string        // zabbix[version]
+	Uptime  time.Duration // zabbix[uptime]
+	// NVPS is the required performance, in new values per second
+	// (zabbix[requiredperformance])
+	NVPS float64
+	// Hosts, items and triggers monitored by the server, whoever can see
+	// them (zabbix[hosts], zabbix[items], zabbix[items_unsupported],
+	// zabbix[triggers])
+	Hosts, Items, UnsupportedItems, Triggers int
+	// HousekeeperBusy is how busy the housekeeper process is, in percent
+	// (zabbix[process,housekeeper,avg,busy])
+	HousekeeperBusy float64
+	Clock           time.Time // When the values were last collected
+}
+
+// serverStatsKeys are the keys of the internal items of ServerStats.
+var serverStatsKeys = []string{
+	"zabbix[version]",
+	"zabbix[uptime]",
+	"zabbix[requiredperformance]",
+	"zabbix[hosts]",
+	"zabbix[items]",
+	"zabbix[items_unsupported]",
+	"zabbix[triggers]",
+	"zabbix[process,housekeeper,avg,busy]",
+}
+
+// Housekeeping holds the global housekeeping settings: how long the
+// housekeeper keeps data. Periods are as entered, e.g. "365d".
+type Housekeeping struct {
+	EventsMode    string `json:"hk_events_mode"` // "1" when events are housekept
+	EventsTrigger string `json:"hk_events_trigger"`
+	HistoryMode   string `json:"hk_history_mode"`
+	HistoryGlobal string `json:"hk_history_global"` // "1" when History overrides the items'
+	History       string `json:"hk_history"`
+	TrendsMode    string `json:"hk_trends_mode"`
+	TrendsGlobal  string `json:"hk_trends_global"` // "1" when Trends overrides the items'
+	Trends        string `json:"hk_trends"`
+	AuditMode     string `json:"hk_audit_mode"`
+	Audit         string `json:"hk_audit"`
+	SessionsMode  string `json:"hk_sessions_mode"`
+	Sessions      string `json:"hk_sessions"`
+	DBExtension   string `json:"db_extension"`       // "timescaledb" if in use
+	Compression   string `json:"compression_status"` // "1" when compression is on
+	CompressOlder string `json:"compress_older"`
+}
+
+// GetServerInfo retrieves the API version, the number of hosts, items and
+// triggers the user can see, the internal statistics of the servers and
+// proxies, and the housekeeping settings if the user may read them.
+func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	version, err := c.Version(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version: %w", err)
+	}
+	info := &ServerInfo{Version: version}
+
+	// Items and triggers of templates are not counted, as on the frontend
+	for _, count := range []struct {
+		method string
+		params map[string]interface{}
+		n      *int
+	}{
+		{"host.get", map[string]interface{}{"countOutput": true}, &info.Hosts},
+		{"item.get", map[string]interface{}{"countOutput": true, "templated": false}, &info.Items},
+		{"trigger.get", map[string]interface{}{"countOutput": true, "templated": false}, &info.Triggers},
+	} {
+		var n string
+		if err := c.call(ctx, count.method, count.params, &n); err != nil {
+			return nil, fmt.Errorf("failed to count %s: %w", strings.TrimSuffix(count.method, ".get")+"s", err)
+		}
+		*count.n, _ = strconv.Atoi(n)
+	}
+
+	if info.Servers, err = c.getServerStats(ctx); err != nil {
+		return nil, err
+	}
+
+	if VersionAtLeast(version, 5, 2) {
+		var hk Housekeeping
+		err := c.call(ctx, "housekeeping.get", map[string]interface{}{"output": "extend"}, &hk)
+		switch {
+		case err == nil:
+			info.Housekeeping = &hk
+		case !errors.Is(err, ErrPermission):
+			return nil, fmt.Errorf("failed to get housekeeping settings: %w", err)
+		}
+	}
+	return info, nil
+}
+
+// getServerStats retrieves the internal items of the servers and proxies
+// that monitor themselves, by host.
+func (c *Client) getServerStats(ctx context.Context) ([]ServerStats, error) {
+	items, err := c.GetItems(ctx, ItemGetParams{
+		Output:      []string{"itemid", "hostid", "key_", "lastvalue", "lastclock"},
+		SelectHosts: []string{"hostid", "host", "name"},
+		Monitored:   true,
+		Filter: map[string]interface{}{
+			"type":   ItemTypeInternal,
+			"key_":   serverStatsKeys,
+			"status": ItemStatusEnabled,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byHost := make(map[string]*ServerStats)
+	var servers []*ServerStats
+	for _, item := range items {
+		hostID := item.GetHostID()
+		s := byHost[hostID]
+		if s == nil {
+			s = &ServerStats{Host: Host{HostID: hostID}}
+			if len(item.Hosts) > 0 {
+				s.Host = item.Hosts[0]
+			}
+			byHost[hostID] = s
+			servers = append(servers, s)
+		}
+		if clock := item.LastTime(); clock.After(s.Clock) {
+			s.Clock = clock
+		}
+		value := item.LastValue
+		switch item.Key {
+		case "zabbix[version]":
+			s.Version = value
+		case "zabbix[uptime]":
+			seconds, _ := strconv.ParseInt(value, 10, 64)
+			s.Uptime = time.Duration(seconds) * time.Second
+		case "zabbix[requiredperformance]":
+			s.NVPS, _ = strconv.ParseFloat(value, 64)
+		case "zabbix[hosts]":
+			s.Hosts, _ = strconv.Atoi(value)
+		case "zabbix[items]":
+			s.Items, _ = strconv.Atoi(value)
+		case "zabbix[items_unsupported]":
+			s.UnsupportedItems, _ = strconv.Atoi(value)
+		case "zabbix[triggers]":
+			s.Triggers, _ = strconv.Atoi(value)
+		case "zabbix[process,housekeeper,avg,busy]":
+			s.HousekeeperBusy, _ = strconv.ParseFloat(value, 64)
+		}
+	}
+
+	result := make([]ServerStats, 0, len(servers))
+	for _, s := range servers {
+		result = append(result, *s)
+	}
+	slices.SortFunc(result, func(a, b ServerStats) int {
+		return strings.Compare(a.Host.DisplayName(), b.Host.DisplayName())
+	})
+	return result, nil
+}
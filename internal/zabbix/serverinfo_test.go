@@ -0,0 +1,59 @@
+package zabbix
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClient_GetServerInfo(t *testing.T) {
+	server := newMockServer(t, map[string]mockResponse{
+		"apiinfo.version": {Result: "7.0.5"},
+		"host.get":        {Result: "42"},
+		"trigger.get":     {Result: "310"},
+		"item.get": {Result: func(p map[string]any) any {
+			if p["countOutput"] == true {
+				return "1200"
+			}
+			host := []Host{{HostID: "10084", Host: "Zabbix server", Name: "Zabbix server"}}
+			return []Item{
+				{HostID: "10084", Key: "zabbix[version]", LastValue: "7.0.5", LastClock: "1700000000", Hosts: host},
+				{HostID: "10084", Key: "zabbix[requiredperformance]", LastValue: "135.2", LastClock: "1700000060", Hosts: host},
+				{HostID: "10084", Key: "zabbix[uptime]", LastValue: "90000", Hosts: host},
+				{HostID: "10084", Key: "zabbix[items_unsupported]", LastValue: "17", Hosts: host},
+			}
+		}},
+		"housekeeping.get": {Error: &APIError{Code: -32500, Message: "Application error.", Data: "No permissions to call \"housekeeping.get\"."}},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	info, err := client.GetServerInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetServerInfo() error = %v", err)
+	}
+	if info.Version != "7.0.5" || info.Hosts != 42 || info.Items != 1200 || info.Triggers != 310 {
+		t.Errorf("info = %+v, want version 7.0.5 with 42 hosts, 1200 items and 310 triggers", info)
+	}
+	if info.Housekeeping != nil {
+		t.Error("housekeeping should be left out when it may not be read")
+	}
+	if len(info.Servers) != 1 {
+		t.Fatalf("servers = %+v, want the Zabbix server", info.Servers)
+	}
+	s := info.Servers[0]
+	if s.Version != "7.0.5" || s.NVPS != 135.2 || s.Uptime != 25*time.Hour || s.UnsupportedItems != 17 {
+		t.Errorf("server = %+v, want its internal items", s)
+	}
+	if s.Clock.Unix() != 1700000060 {
+		t.Errorf("clock = %v, want the latest value's", s.Clock)
+	}
+}
+
+func TestRequiredDBVersion(t *testing.T) {
+	for version, want := range map[string]int{"6.4.12": 6040000, "7.0.0": 7000000, "5.0": 5000000, "bad": 0} {
+		if got := RequiredDBVersion(version); got != want {
+			t.Errorf("RequiredDBVersion(%q) = %d, want %d", version, got, want)
+		}
+	}
+}
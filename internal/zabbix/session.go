@@ -0,0 +1,46 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SessionID returns the session ID in a value of the frontend's zbx_session
+// cookie, which since Zabbix 5.0 is base64-encoded JSON holding it, or value
+// itself when it is already a session ID.
+func SessionID(value string) string {
+	value = strings.TrimSpace(value)
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return value
+	}
+	var cookie struct {
+		SessionID string `json:"sessionid"`
+	}
+	if json.Unmarshal(data, &cookie) != nil || cookie.SessionID == "" {
+		return value
+	}
+	return cookie.SessionID
+}
+
+// SessionUser returns the ID and username of the user of the client's
+// session, failing when the session has expired. It extends the session as
+// any other call does.
+func (c *Client) SessionUser(ctx context.Context) (userID, username string, err error) {
+	var user struct {
+		UserID   string `json:"userid"`
+		Username string `json:"username"`
+		Alias    string `json:"alias"` // Before Zabbix 5.4
+	}
+	params := map[string]string{"sessionid": c.getToken()}
+	if err := c.callNoAuth(ctx, "user.checkAuthentication", params, &user); err != nil {
+		return "", "", fmt.Errorf("failed to check session: %w", err)
+	}
+	if user.Username == "" {
+		user.Username = user.Alias
+	}
+	return user.UserID, user.Username, nil
+}
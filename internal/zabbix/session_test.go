@@ -0,0 +1,41 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+)
+
+func TestSessionID(t *testing.T) {
+	cookie := base64.StdEncoding.EncodeToString([]byte(`{"sessionid":"0424bd59b807674191e7d77572075f33","serverCheckResult":true,"sign":"x"}`))
+	for value, want := range map[string]string{
+		cookie:                                "0424bd59b807674191e7d77572075f33",
+		"0424bd59b807674191e7d77572075f33":    "0424bd59b807674191e7d77572075f33",
+		" 0424bd59b807674191e7d77572075f33\n": "0424bd59b807674191e7d77572075f33",
+	} {
+		if got := SessionID(value); got != want {
+			t.Errorf("SessionID(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestClient_SessionUser(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"user.checkAuthentication": {Result: map[string]string{"userid": "7", "alias": "jdoe"}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.SetToken("0424bd59")
+	id, username, err := client.SessionUser(context.Background())
+	if err != nil {
+		t.Fatalf("SessionUser() error = %v", err)
+	}
+	if id != "7" || username != "jdoe" {
+		t.Errorf("SessionUser() = %q, %q; want 7, jdoe", id, username)
+	}
+	if params["user.checkAuthentication"]["sessionid"] != "0424bd59" {
+		t.Errorf("user.checkAuthentication params = %v, want the session ID", params["user.checkAuthentication"])
+	}
+}
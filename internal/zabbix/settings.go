@@ -0,0 +1,115 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Settings holds the global settings of Administration → General that
+// matter to operators.
+type Settings struct {
+	// SeverityNames are the names of the severities, indexed by severity;
+	// empty for names the server did not return
+	SeverityNames [6]string
+	// SeverityColors are the colors of the severities as hex RGB without
+	// the leading #, e.g. "E45959"
+	SeverityColors [6]string
+
+	WorkPeriod      string // Working time, e.g. "1-5,09:00-18:00"
+	DefaultTimezone string // "system" for the server's time zone
+	// RefreshUnsupported is how often unsupported items are checked
+	// again; empty from Zabbix 5.4, where they follow their interval
+	RefreshUnsupported string
+	OKPeriod           string // How long resolved problems stay shown
+	BlinkPeriod        string // How long changed problems blink
+}
+
+// settingsJSON is the settings object of settings.get, for the fields of
+// Settings.
+type settingsJSON struct {
+	SeverityName0      string `json:"severity_name_0"`
+	SeverityName1      string `json:"severity_name_1"`
+	SeverityName2      string `json:"severity_name_2"`
+	SeverityName3      string `json:"severity_name_3"`
+	SeverityName4      string `json:"severity_name_4"`
+	SeverityName5      string `json:"severity_name_5"`
+	SeverityColor0     string `json:"severity_color_0"`
+	SeverityColor1     string `json:"severity_color_1"`
+	SeverityColor2     string `json:"severity_color_2"`
+	SeverityColor3     string `json:"severity_color_3"`
+	SeverityColor4     string `json:"severity_color_4"`
+	SeverityColor5     string `json:"severity_color_5"`
+	WorkPeriod         string `json:"work_period"`
+	DefaultTimezone    string `json:"default_timezone"`
+	RefreshUnsupported string `json:"refresh_unsupported"`
+	OKPeriod           string `json:"ok_period"`
+	BlinkPeriod        string `json:"blink_period"`
+}
+
+// UnmarshalJSON decodes a settings.get result.
+func (s *Settings) UnmarshalJSON(data []byte) error {
+	var raw settingsJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = Settings{
+		SeverityNames: [6]string{
+			raw.SeverityName0, raw.SeverityName1, raw.SeverityName2,
+			raw.SeverityName3, raw.SeverityName4, raw.SeverityName5,
+		},
+		SeverityColors: [6]string{
+			raw.SeverityColor0, raw.SeverityColor1, raw.SeverityColor2,
+			raw.SeverityColor3, raw.SeverityColor4, raw.SeverityColor5,
+		},
+		WorkPeriod:         raw.WorkPeriod,
+		DefaultTimezone:    raw.DefaultTimezone,
+		RefreshUnsupported: raw.RefreshUnsupported,
+		OKPeriod:           raw.OKPeriod,
+		BlinkPeriod:        raw.BlinkPeriod,
+	}
+	return nil
+}
+
+// GetSettings retrieves the global settings. It needs Zabbix 5.2 or later,
+// see Capabilities.Settings.
+func (c *Client) GetSettings(ctx context.Context) (*Settings, error) {
+	var settings Settings
+	if err := c.call(ctx, "settings.get", map[string]interface{}{"output": "extend"}, &settings); err != nil {
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// WorkingTime returns a working time period such as "1-5,09:00-18:00;6,10:00-14:00"
+// in words, one period per entry, e.g. "Mon-Fri 09:00-18:00".
+func WorkingTime(period string) []string {
+	days := [...]string{"", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+	day := func(s string) string {
+		if len(s) == 1 && s[0] >= '1' && s[0] <= '7' {
+			return days[s[0]-'0']
+		}
+		return s
+	}
+
+	var result []string
+	for _, p := range strings.Split(period, ";") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		weekdays, hours, ok := strings.Cut(p, ",")
+		if !ok {
+			result = append(result, p)
+			continue
+		}
+		from, to, isRange := strings.Cut(weekdays, "-")
+		weekdays = day(from)
+		if isRange {
+			weekdays += "-" + day(to)
+		}
+		result = append(result, weekdays+" "+hours)
+	}
+	return result
+}
@@ -0,0 +1,41 @@
+package zabbix
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestClient_GetSettings(t *testing.T) {
+	server := newMockServer(t, map[string]mockResponse{
+		"settings.get": {Result: map[string]string{
+			"severity_name_0":  "Not classified",
+			"severity_name_3":  "Major",
+			"severity_color_3": "FFA059",
+			"work_period":      "1-5,09:00-18:00",
+			"ok_period":        "5m",
+			"search_limit":     "1000",
+		}},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	settings, err := client.GetSettings(context.Background())
+	if err != nil {
+		t.Fatalf("GetSettings() error = %v", err)
+	}
+	if settings.SeverityNames[3] != "Major" || settings.SeverityNames[5] != "" || settings.SeverityColors[3] != "FFA059" {
+		t.Errorf("severities = %q %q, want Major in FFA059", settings.SeverityNames, settings.SeverityColors)
+	}
+	if settings.WorkPeriod != "1-5,09:00-18:00" || settings.OKPeriod != "5m" {
+		t.Errorf("settings = %+v, want the working time and OK period", settings)
+	}
+}
+
+func TestWorkingTime(t *testing.T) {
+	got := WorkingTime("1-5,09:00-18:00;6,10:00-14:00; ;{$WORK}")
+	want := []string{"Mon-Fri 09:00-18:00", "Sat 10:00-14:00", "{$WORK}"}
+	if !slices.Equal(got, want) {
+		t.Errorf("WorkingTime() = %q, want %q", got, want)
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 )
 
-// mockResponse represents a mock JSON-RPC response.
+// mockResponse represents a mock JSON-RPC response. A Result of type
+// func(map[string]any) any is called with the params of each request, for
+// methods whose result depends on them.
 type mockResponse struct {
 	Result any
 	Error  *APIError
@@ -17,7 +20,15 @@ type mockResponse struct {
 // The handler map keys are method names, values are the responses to return.
 func newMockServer(t *testing.T, handlers map[string]mockResponse) *httptest.Server {
 	t.Helper()
+	return newRecordingMockServer(t, handlers, nil)
+}
+
+// newRecordingMockServer is like newMockServer, but also stores the params of
+// each request in params, keyed by method name (the last call wins).
+func newRecordingMockServer(t *testing.T, handlers map[string]mockResponse, params map[string]map[string]any) *httptest.Server {
+	t.Helper()
 
+	var mu sync.Mutex
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			t.Errorf("expected POST, got %s", r.Method)
@@ -32,6 +43,13 @@ func newMockServer(t *testing.T, handlers map[string]mockResponse) *httptest.Ser
 			return
 		}
 
+		p, _ := req.Params.(map[string]any)
+		if params != nil {
+			mu.Lock()
+			params[req.Method] = p
+			mu.Unlock()
+		}
+
 		handler, ok := handlers[req.Method]
 		if !ok {
 			t.Errorf("unexpected method: %s", req.Method)
@@ -45,8 +63,12 @@ func newMockServer(t *testing.T, handlers map[string]mockResponse) *httptest.Ser
 			Error:   handler.Error,
 		}
 
-		if handler.Result != nil {
-			resultBytes, err := json.Marshal(handler.Result)
+		result := handler.Result
+		if f, ok := result.(func(map[string]any) any); ok {
+			result = f(p)
+		}
+		if result != nil {
+			resultBytes, err := json.Marshal(result)
 			if err != nil {
 				t.Errorf("failed to marshal result: %v", err)
 				http.Error(w, "internal error", http.StatusInternalServerError)
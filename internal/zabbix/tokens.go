@@ -0,0 +1,231 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Token is an API token; its secret is only known when it is generated.
+type Token struct {
+	TokenID     string `json:"tokenid"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	UserID      string `json:"userid"`
+	LastAccess  string `json:"lastaccess"` // Unix time; "0" for never
+	Status      string `json:"status"`     // 0 = enabled, 1 = disabled
+	ExpiresAt   string `json:"expires_at"` // Unix time; "0" for never
+	CreatedAt   string `json:"created_at"`
+}
+
+// unixTime parses a Unix time of the API, returning zero for "0".
+func unixTime(s string) time.Time {
+	n, _ := strconv.ParseInt(s, 10, 64)
+	if n <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(n, 0)
+}
+
+// IsEnabled reports whether the token is enabled.
+func (t *Token) IsEnabled() bool {
+	return t.Status == "0"
+}
+
+// Expires returns when the token expires, or zero if it never does.
+func (t *Token) Expires() time.Time {
+	return unixTime(t.ExpiresAt)
+}
+
+// IsExpired reports whether the token has expired at now.
+func (t *Token) IsExpired(now time.Time) bool {
+	expires := t.Expires()
+	return !expires.IsZero() && !now.Before(expires)
+}
+
+// LastUsed returns when the token was last used, or zero if never.
+func (t *Token) LastUsed() time.Time {
+	return unixTime(t.LastAccess)
+}
+
+// Created returns when the token was created.
+func (t *Token) Created() time.Time {
+	return unixTime(t.CreatedAt)
+}
+
+// CheckAuth verifies that the server accepts the client's credentials.
+// apiinfo.version does not require authentication, so a host count is
+// requested instead.
+func (c *Client) CheckAuth(ctx context.Context) error {
+	var count string
+	if err := c.call(ctx, "host.get", map[string]interface{}{"countOutput": true}, &count); err != nil {
+		return fmt.Errorf("authentication check failed: %w", err)
+	}
+	return nil
+}
+
+// UserID returns the ID of the user with the given username.
+func (c *Client) UserID(ctx context.Context, username string) (string, error) {
+	params := map[string]interface{}{
+		"output": []string{"userid"},
+		"filter": map[string]string{"username": username},
+	}
+
+	var users []struct {
+		UserID string `json:"userid"`
+	}
+	if err := c.call(ctx, "user.get", params, &users); err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if len(users) == 0 {
+		return "", fmt.Errorf("user %q: %w", username, ErrNotFound)
+	}
+	return users[0].UserID, nil
+}
+
+// Username returns the username of the user with the given ID.
+func (c *Client) Username(ctx context.Context, userID string) (string, error) {
+	params := map[string]interface{}{
+		"output":  []string{"username"},
+		"userids": []string{userID},
+	}
+
+	var users []struct {
+		Username string `json:"username"`
+	}
+	if err := c.call(ctx, "user.get", params, &users); err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if len(users) == 0 {
+		return "", fmt.Errorf("user %s: %w", userID, ErrNotFound)
+	}
+	return users[0].Username, nil
+}
+
+// TokenUserID returns the ID of the user owning the client's API token.
+// Requires Zabbix 6.4 or later.
+func (c *Client) TokenUserID(ctx context.Context) (string, error) {
+	var user struct {
+		UserID string `json:"userid"`
+	}
+	params := map[string]string{"token": c.getToken()}
+	if err := c.callNoAuth(ctx, "user.checkAuthentication", params, &user); err != nil {
+		return "", fmt.Errorf("failed to check token: %w", err)
+	}
+	return user.UserID, nil
+}
+
+// CreateToken creates a non-expiring API token for a user and returns the
+// token secret. Requires Zabbix 5.4 or later.
+func (c *Client) CreateToken(ctx context.Context, userID, name string) (string, error) {
+	return c.CreateTokenExpiring(ctx, userID, name, time.Time{})
+}
+
+// CreateTokenExpiring creates an API token for a user expiring at expires,
+// or never if it is zero, and returns the token secret. Requires Zabbix 5.4
+// or later.
+func (c *Client) CreateTokenExpiring(ctx context.Context, userID, name string, expires time.Time) (string, error) {
+	params := map[string]interface{}{
+		"name":   name,
+		"userid": userID,
+	}
+	if !expires.IsZero() {
+		params["expires_at"] = strconv.FormatInt(expires.Unix(), 10)
+	}
+
+	var created struct {
+		TokenIDs []string `json:"tokenids"`
+	}
+	if err := c.call(ctx, "token.create", params, &created); err != nil {
+		return "", fmt.Errorf("failed to create token: %w", err)
+	}
+	if len(created.TokenIDs) == 0 {
+		return "", fmt.Errorf("failed to create token: no token ID returned")
+	}
+
+	var generated []struct {
+		TokenID string `json:"tokenid"`
+		Token   string `json:"token"`
+	}
+	if err := c.call(ctx, "token.generate", created.TokenIDs, &generated); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	if len(generated) == 0 || generated[0].Token == "" {
+		return "", fmt.Errorf("failed to generate token: no token returned")
+	}
+	return generated[0].Token, nil
+}
+
+// GetTokens returns the API tokens of a user, by name. Requires Zabbix 5.4
+// or later.
+func (c *Client) GetTokens(ctx context.Context, userID string) ([]Token, error) {
+	params := map[string]interface{}{
+		"output":    "extend",
+		"userids":   []string{userID},
+		"sortfield": "name",
+	}
+
+	var tokens []Token
+	if err := c.call(ctx, "token.get", params, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to get tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// CurrentToken returns the API token the client authenticates with.
+// Requires Zabbix 5.4 or later.
+func (c *Client) CurrentToken(ctx context.Context) (*Token, error) {
+	params := map[string]interface{}{
+		"output": "extend",
+		"token":  c.getToken(),
+	}
+
+	var tokens []Token
+	if err := c.call(ctx, "token.get", params, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("current token: %w", ErrNotFound)
+	}
+	return &tokens[0], nil
+}
+
+// DeleteTokens deletes API tokens by ID.
+func (c *Client) DeleteTokens(ctx context.Context, tokenIDs ...string) error {
+	var deleted struct {
+		TokenIDs []string `json:"tokenids"`
+	}
+	if err := c.call(ctx, "token.delete", tokenIDs, &deleted); err != nil {
+		return fmt.Errorf("failed to delete tokens: %w", err)
+	}
+	return nil
+}
+
+// RotateToken replaces the API token the client authenticates with: it
+// creates a token named name for the same user, lasting as long as the
+// current one did if it expires, switches the client to it once the server
+// accepts it, and returns the current token, to delete once the new secret
+// is saved, and the new secret.
+func (c *Client) RotateToken(ctx context.Context, name string, now time.Time) (*Token, string, error) {
+	current, err := c.CurrentToken(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	var expires time.Time
+	if end := current.Expires(); !end.IsZero() {
+		expires = now.Add(end.Sub(current.Created()))
+	}
+	secret, err := c.CreateTokenExpiring(ctx, current.UserID, name, expires)
+	if err != nil {
+		return nil, "", err
+	}
+
+	previous := c.getToken()
+	c.SetToken(secret)
+	if err := c.CheckAuth(ctx); err != nil {
+		c.SetToken(previous)
+		return nil, "", err
+	}
+	return current, secret, nil
+}
@@ -0,0 +1,185 @@
+package zabbix
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClient_CheckAuth(t *testing.T) {
+	server := newMockServer(t, map[string]mockResponse{
+		"host.get": {Result: "12"},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.CheckAuth(context.Background()); err != nil {
+		t.Fatalf("CheckAuth() error = %v", err)
+	}
+}
+
+func TestClient_CheckAuth_Rejected(t *testing.T) {
+	server := newMockServer(t, map[string]mockResponse{
+		"host.get": {Error: &APIError{Code: -32602, Message: "Invalid params", Data: "Not authorized."}},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.CheckAuth(context.Background()); err == nil {
+		t.Fatal("CheckAuth() expected error for rejected token")
+	}
+}
+
+func TestClient_TokenUserID(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"user.checkAuthentication": {Result: map[string]string{"userid": "7", "username": "Admin"}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.SetToken("secret")
+	id, err := client.TokenUserID(context.Background())
+	if err != nil {
+		t.Fatalf("TokenUserID() error = %v", err)
+	}
+	if id != "7" {
+		t.Errorf("TokenUserID() = %q, want %q", id, "7")
+	}
+	if params["user.checkAuthentication"]["token"] != "secret" {
+		t.Errorf("user.checkAuthentication params = %v, want the token", params["user.checkAuthentication"])
+	}
+}
+
+func TestClient_UserID(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"user.get": {Result: []map[string]string{{"userid": "7"}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	id, err := client.UserID(context.Background(), "Admin")
+	if err != nil {
+		t.Fatalf("UserID() error = %v", err)
+	}
+	if id != "7" {
+		t.Errorf("UserID() = %q, want %q", id, "7")
+	}
+	filter, _ := params["user.get"]["filter"].(map[string]any)
+	if filter["username"] != "Admin" {
+		t.Errorf("filter = %v, want username Admin", filter)
+	}
+}
+
+func TestClient_UserID_NotFound(t *testing.T) {
+	server := newMockServer(t, map[string]mockResponse{
+		"user.get": {Result: []map[string]string{}},
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if _, err := client.UserID(context.Background(), "nobody"); err == nil {
+		t.Fatal("UserID() expected error for unknown user")
+	}
+}
+
+func TestClient_CreateToken(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"token.create":   {Result: map[string][]string{"tokenids": {"3"}}},
+		"token.generate": {Result: []map[string]string{{"tokenid": "3", "token": "secret"}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	token, err := client.CreateToken(context.Background(), "7", "chotko")
+	if err != nil {
+		t.Fatalf("CreateToken() error = %v", err)
+	}
+	if token != "secret" {
+		t.Errorf("CreateToken() = %q, want %q", token, "secret")
+	}
+	if params["token.create"]["userid"] != "7" || params["token.create"]["name"] != "chotko" {
+		t.Errorf("token.create params = %v", params["token.create"])
+	}
+}
+
+func TestClient_Username(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"user.get": {Result: []map[string]string{{"userid": "7", "username": "jdoe"}}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	username, err := client.Username(context.Background(), "7")
+	if err != nil {
+		t.Fatalf("Username() error = %v", err)
+	}
+	if username != "jdoe" {
+		t.Errorf("Username() = %q, want %q", username, "jdoe")
+	}
+	if ids, _ := params["user.get"]["userids"].([]any); len(ids) != 1 || ids[0] != "7" {
+		t.Errorf("userids = %v, want [7]", params["user.get"]["userids"])
+	}
+}
+
+func TestClient_GetTokens(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"token.get": {Result: []map[string]string{
+			{"tokenid": "3", "name": "chotko", "userid": "7", "status": "0", "expires_at": "0", "lastaccess": "1772355600", "created_at": "1772000000"},
+			{"tokenid": "4", "name": "old", "userid": "7", "status": "1", "expires_at": "1772000100", "lastaccess": "0", "created_at": "1772000000"},
+		}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	tokens, err := client.GetTokens(context.Background(), "7")
+	if err != nil {
+		t.Fatalf("GetTokens() error = %v", err)
+	}
+	if len(tokens) != 2 {
+		t.Fatalf("GetTokens() returned %d tokens, want 2", len(tokens))
+	}
+	if ids, _ := params["token.get"]["userids"].([]any); len(ids) != 1 || ids[0] != "7" {
+		t.Errorf("token.get params = %v, want the user's tokens", params["token.get"])
+	}
+
+	now := time.Unix(1772400000, 0)
+	active, old := tokens[0], tokens[1]
+	if !active.IsEnabled() || active.IsExpired(now) || !active.Expires().IsZero() || active.LastUsed().Unix() != 1772355600 {
+		t.Errorf("token %+v should be enabled, never expire and have been used", active)
+	}
+	if old.IsEnabled() || !old.IsExpired(now) || !old.LastUsed().IsZero() {
+		t.Errorf("token %+v should be disabled, expired and never used", old)
+	}
+}
+
+func TestClient_RotateToken(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		// Created a day before it expires
+		"token.get":      {Result: []map[string]string{{"tokenid": "3", "name": "chotko", "userid": "7", "expires_at": "1772086400", "created_at": "1772000000"}}},
+		"token.create":   {Result: map[string][]string{"tokenids": {"5"}}},
+		"token.generate": {Result: []map[string]string{{"tokenid": "5", "token": "new-secret"}}},
+		"host.get":       {Result: "12"},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.SetToken("old-secret")
+	now := time.Unix(1772050000, 0)
+	current, secret, err := client.RotateToken(context.Background(), "chotko 2026-03-01", now)
+	if err != nil {
+		t.Fatalf("RotateToken() error = %v", err)
+	}
+	if current.TokenID != "3" || secret != "new-secret" || client.getToken() != "new-secret" {
+		t.Errorf("RotateToken() = %+v, %q; want token 3 replaced and the client switched", current, secret)
+	}
+	created := params["token.create"]
+	if created["userid"] != "7" || created["name"] != "chotko 2026-03-01" || created["expires_at"] != "1772136400" {
+		t.Errorf("token.create params = %v, want a token of user 7 lasting a day", created)
+	}
+}
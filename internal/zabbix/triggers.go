@@ -11,6 +11,12 @@ const (
 	TriggerStatusDisabled = "1" // Trigger is disabled
 )
 
+// Trigger manual_close values.
+const (
+	ManualCloseNotAllowed = "0"
+	ManualCloseAllowed    = "1"
+)
+
 // TriggerGetParams defines parameters for trigger.get API call.
 type TriggerGetParams struct {
 	// Output fields to return
@@ -19,6 +25,16 @@ type TriggerGetParams struct {
 	SelectHosts interface{} `json:"selectHosts,omitempty"`
 	// Select tags
 	SelectTags interface{} `json:"selectTags,omitempty"`
+	// Select the triggers this one depends on
+	SelectDependencies interface{} `json:"selectDependencies,omitempty"`
+	// Select the functions of the expression
+	SelectFunctions interface{} `json:"selectFunctions,omitempty"`
+	// Select the items of the functions
+	SelectItems interface{} `json:"selectItems,omitempty"`
+	// Select host groups (Zabbix 6.2+; adapted to SelectGroups for older servers)
+	SelectHostGroups interface{} `json:"selectHostGroups,omitempty"`
+	// Select host groups (before Zabbix 6.2)
+	SelectGroups interface{} `json:"selectGroups,omitempty"`
 	// Filter by trigger IDs
 	TriggerIDs []string `json:"triggerids,omitempty"`
 	// Filter by host IDs
@@ -39,6 +55,8 @@ type TriggerGetParams struct {
 	SearchWildcardsEnabled bool `json:"searchWildcardsEnabled,omitempty"`
 	// Filter parameters
 	Filter map[string]interface{} `json:"filter,omitempty"`
+	// Resolve macros in trigger names, like {HOST.NAME} and {ITEM.LASTVALUE}
+	ExpandDescription bool `json:"expandDescription,omitempty"`
 }
 
 // DefaultTriggerGetParams returns default parameters for fetching triggers.
@@ -49,15 +67,20 @@ func DefaultTriggerGetParams() TriggerGetParams {
 		SelectTags:  "extend",
 		SortField:   []string{"description"},
 		SortOrder:   "ASC",
+
+		ExpandDescription: true,
 	}
 }
 
 // GetTriggers retrieves triggers from Zabbix.
 func (c *Client) GetTriggers(ctx context.Context, params TriggerGetParams) ([]Trigger, error) {
 	var triggers []Trigger
-	if err := c.call(ctx, "trigger.get", params, &triggers); err != nil {
+	if err := c.call(ctx, "trigger.get", c.adaptTriggerParams(params), &triggers); err != nil {
 		return nil, fmt.Errorf("failed to get triggers: %w", err)
 	}
+	for i := range triggers {
+		triggers[i].Description = expandTriggerName(triggers[i])
+	}
 	return triggers, nil
 }
 
@@ -72,12 +95,24 @@ func (c *Client) GetTrigger(ctx context.Context, triggerID string) (*Trigger, er
 	}
 
 	if len(triggers) == 0 {
-		return nil, fmt.Errorf("trigger not found: %s", triggerID)
+		return nil, fmt.Errorf("trigger %s: %w", triggerID, ErrNotFound)
 	}
 
 	return &triggers[0], nil
 }
 
+// GetTriggerRelations retrieves the dependencies and host groups of
+// triggers, used to tell which problems are likely consequences of others.
+// The triggers have only their ID, dependency IDs and groups set.
+func (c *Client) GetTriggerRelations(ctx context.Context, triggerIDs []string) ([]Trigger, error) {
+	return c.GetTriggers(ctx, TriggerGetParams{
+		Output:             []string{"triggerid"},
+		SelectDependencies: []string{"triggerid"},
+		SelectHostGroups:   []string{"groupid", "name"},
+		TriggerIDs:         triggerIDs,
+	})
+}
+
 // GetHostTriggers retrieves all triggers for a specific host.
 func (c *Client) GetHostTriggers(ctx context.Context, hostID string) ([]Trigger, error) {
 	params := DefaultTriggerGetParams()
@@ -128,22 +163,30 @@ func (c *Client) DisableTrigger(ctx context.Context, triggerID string) error {
 	return c.UpdateTrigger(ctx, params)
 }
 
-// EnableTriggers enables multiple triggers.
+// EnableTriggers enables multiple triggers in one call.
 func (c *Client) EnableTriggers(ctx context.Context, triggerIDs []string) error {
-	for _, id := range triggerIDs {
-		if err := c.EnableTrigger(ctx, id); err != nil {
-			return err
-		}
-	}
-	return nil
+	return c.setTriggersStatus(ctx, triggerIDs, TriggerStatusEnabled)
 }
 
-// DisableTriggers disables multiple triggers.
+// DisableTriggers disables multiple triggers in one call.
 func (c *Client) DisableTriggers(ctx context.Context, triggerIDs []string) error {
-	for _, id := range triggerIDs {
-		if err := c.DisableTrigger(ctx, id); err != nil {
-			return err
-		}
+	return c.setTriggersStatus(ctx, triggerIDs, TriggerStatusDisabled)
+}
+
+// setTriggersStatus sets the status of triggers with a single trigger.update
+// call, which takes an array of triggers.
+func (c *Client) setTriggersStatus(ctx context.Context, triggerIDs []string, status string) error {
+	if len(triggerIDs) == 0 {
+		return nil
+	}
+	params := make([]TriggerUpdateParams, len(triggerIDs))
+	for i, id := range triggerIDs {
+		params[i] = TriggerUpdateParams{TriggerID: id, Status: status}
+	}
+
+	var result TriggerUpdateResult
+	if err := c.call(ctx, "trigger.update", params, &result); err != nil {
+		return fmt.Errorf("failed to update triggers: %w", err)
 	}
 	return nil
 }
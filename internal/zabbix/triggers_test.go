@@ -92,6 +92,37 @@ func TestClient_GetTriggers_Error(t *testing.T) {
 	}
 }
 
+func TestClient_GetTriggerRelations(t *testing.T) {
+	params := make(map[string]map[string]any)
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"trigger.get": {
+			Result: []map[string]any{{
+				"triggerid":    "2",
+				"dependencies": []map[string]any{{"triggerid": "1"}},
+				"hostgroups":   []map[string]any{{"groupid": "4", "name": "Linux servers"}},
+			}},
+		},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.token = "test-token"
+
+	triggers, err := client.GetTriggerRelations(context.Background(), []string{"2"})
+	if err != nil {
+		t.Fatalf("GetTriggerRelations() error = %v", err)
+	}
+	if len(triggers) != 1 || len(triggers[0].Dependencies) != 1 || triggers[0].Dependencies[0].TriggerID != "1" {
+		t.Fatalf("triggers = %+v, want trigger 2 depending on 1", triggers)
+	}
+	if len(triggers[0].Groups) != 1 || triggers[0].Groups[0].GroupID != "4" {
+		t.Errorf("Groups = %v, want the 6.2+ hostgroups", triggers[0].Groups)
+	}
+	if params["trigger.get"]["selectDependencies"] == nil || params["trigger.get"]["selectHostGroups"] == nil {
+		t.Errorf("trigger.get params = %v", params["trigger.get"])
+	}
+}
+
 func TestClient_GetHostTriggers(t *testing.T) {
 	server := newMockServer(t, map[string]mockResponse{
 		"trigger.get": {
@@ -1,38 +1,45 @@
 package zabbix
 
 import (
-	"fmt"
+	"encoding/json"
+	"net"
 	"strconv"
 	"time"
+
+	"github.com/harpchad/chotko/internal/format"
 )
 
 // Problem represents a Zabbix problem/alert.
 type Problem struct {
-	EventID       string        `json:"eventid"`
-	Source        string        `json:"source"`
-	Object        string        `json:"object"`
-	ObjectID      string        `json:"objectid"`
-	Clock         string        `json:"clock"`
-	NS            string        `json:"ns"`
-	REventID      string        `json:"r_eventid"`
-	RClock        string        `json:"r_clock"`
-	Name          string        `json:"name"`
-	Acknowledged  string        `json:"acknowledged"`
-	Severity      string        `json:"severity"`
-	Suppressed    string        `json:"suppressed"`
-	OpData        string        `json:"opdata"`
-	URLs          []URL         `json:"urls,omitempty"`
-	Tags          []Tag         `json:"tags,omitempty"`
-	Acknowledges  []Ack         `json:"acknowledges,omitempty"`
-	Hosts         []Host        `json:"hosts,omitempty"`
-	Triggers      []Trigger     `json:"triggers,omitempty"`
-	RelatedObject RelatedObject `json:"relatedObject,omitempty"`
+	EventID      string `json:"eventid"`
+	Source       string `json:"source"`
+	Object       string `json:"object"`
+	ObjectID     string `json:"objectid"`
+	Clock        string `json:"clock"`
+	NS           string `json:"ns"`
+	REventID     string `json:"r_eventid"`
+	RClock       string `json:"r_clock"`
+	Name         string `json:"name"`
+	Acknowledged string `json:"acknowledged"`
+	Severity     string `json:"severity"`
+	Value        string `json:"value,omitempty"` // Events only: 0 = recovery, 1 = problem
+	Suppressed   string `json:"suppressed"`
+	OpData       string `json:"opdata"`
+	URLs         []URL  `json:"urls,omitempty"`
+	Tags         []Tag  `json:"tags,omitempty"`
+	Acknowledges []Ack  `json:"acknowledges,omitempty"`
+	// Why the problem is suppressed, when it is
+	SuppressionData []Suppression `json:"suppression_data,omitempty"`
+	Hosts           []Host        `json:"hosts,omitempty"`
+	Triggers        []Trigger     `json:"triggers,omitempty"`
+	RelatedObject   RelatedObject `json:"relatedObject,omitempty"`
 }
 
 // RelatedObject represents the trigger/item that caused the event.
 type RelatedObject struct {
-	TriggerID string `json:"triggerid,omitempty"`
-	Status    string `json:"status,omitempty"` // 0=enabled, 1=disabled
+	TriggerID   string `json:"triggerid,omitempty"`
+	Status      string `json:"status,omitempty"`       // 0=enabled, 1=disabled
+	ManualClose string `json:"manual_close,omitempty"` // 0=not allowed, 1=allowed
 }
 
 // URL represents a Zabbix URL associated with a problem.
@@ -62,6 +69,14 @@ type Ack struct {
 	Surname     string `json:"surname,omitempty"`
 }
 
+// Suppression is a reason a problem is suppressed: a maintenance, or a user
+// suppressing it (6.2+).
+type Suppression struct {
+	MaintenanceID string `json:"maintenanceid"`    // "0" when suppressed by a user
+	SuppressUntil string `json:"suppress_until"`   // Unix time; "0" for indefinitely
+	UserID        string `json:"userid,omitempty"` // The user who suppressed it, if any
+}
+
 // Host represents a Zabbix host.
 type Host struct {
 	HostID            string      `json:"hostid"`
@@ -71,6 +86,8 @@ type Host struct {
 	ProxyID           string      `json:"proxyid,omitempty"`
 	MaintenanceStatus string      `json:"maintenance_status,omitempty"`
 	MaintenanceType   string      `json:"maintenance_type,omitempty"`
+	MaintenanceID     string      `json:"maintenanceid,omitempty"`
+	MaintenanceFrom   string      `json:"maintenance_from,omitempty"`
 	ActiveAvailable   string      `json:"active_available,omitempty"`
 	Description       string      `json:"description,omitempty"`
 	Interfaces        []Interface `json:"interfaces,omitempty"`
@@ -79,6 +96,46 @@ type Host struct {
 	Triggers          []Trigger   `json:"triggers,omitempty"`
 }
 
+// UnmarshalJSON decodes a host, accepting the field names of older Zabbix
+// versions: "groups" (renamed "hostgroups" in 6.2), "proxy_hostid" (renamed
+// "proxyid" in 7.0) and the host-level "available", "error", "errors_from"
+// and "disable_until" of agent interfaces (moved to interfaces in 5.2).
+func (h *Host) UnmarshalJSON(data []byte) error {
+	type host Host // Without this method, to avoid recursion
+	aux := struct {
+		*host
+		HostGroups  []HostGroup `json:"hostgroups"`
+		ProxyHostID string      `json:"proxy_hostid"`
+		Available   string      `json:"available"`
+
+		Error        string `json:"error"`
+		ErrorsFrom   string `json:"errors_from"`
+		DisableUntil string `json:"disable_until"`
+	}{host: (*host)(h)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if len(aux.HostGroups) > 0 {
+		h.Groups = aux.HostGroups
+	}
+	if h.ProxyID == "" {
+		h.ProxyID = aux.ProxyHostID
+	}
+	if aux.Available != "" {
+		for i := range h.Interfaces {
+			iface := &h.Interfaces[i]
+			if iface.Type == InterfaceTypeAgent && iface.Available == "" {
+				iface.Available = aux.Available
+				iface.Error = aux.Error
+				iface.ErrorsFrom = aux.ErrorsFrom
+				iface.DisableUntil = aux.DisableUntil
+			}
+		}
+	}
+	return nil
+}
+
 // HostStatus constants.
 const (
 	HostStatusMonitored   = "0" // Host is monitored
@@ -102,15 +159,60 @@ const (
 	MacroTypeVault  = "2" // Vault macro
 )
 
+// InterfaceTypeAgent is the interface type of a Zabbix agent interface.
+const InterfaceTypeAgent = "1"
+
 // Interface represents a host interface.
 type Interface struct {
-	InterfaceID string `json:"interfaceid"`
-	IP          string `json:"ip"`
-	DNS         string `json:"dns"`
-	Port        string `json:"port"`
-	Type        string `json:"type"`
-	Main        string `json:"main"`
-	Available   string `json:"available"`
+	InterfaceID  string `json:"interfaceid"`
+	IP           string `json:"ip"`
+	DNS          string `json:"dns"`
+	UseIP        string `json:"useip"` // 1 = connect to the IP, 0 = to the DNS name
+	Port         string `json:"port"`
+	Type         string `json:"type"`
+	Main         string `json:"main"`
+	Available    string `json:"available"`
+	Error        string `json:"error,omitempty"`         // Why the interface is unavailable
+	ErrorsFrom   string `json:"errors_from,omitempty"`   // When checks on it started failing
+	DisableUntil string `json:"disable_until,omitempty"` // When it is checked again
+	// SNMP settings of SNMP interfaces
+	Details *InterfaceDetails `json:"details,omitempty"`
+}
+
+// UsesDNS reports whether Zabbix connects to the interface by its DNS name.
+func (i *Interface) UsesDNS() bool {
+	return i.UseIP == "0" && i.DNS != ""
+}
+
+// Address returns the address Zabbix connects to: the DNS name or the IP,
+// by UseIP, or whichever is set when it is unknown.
+func (i *Interface) Address() string {
+	if i.UsesDNS() || i.IP == "" {
+		return i.DNS
+	}
+	return i.IP
+}
+
+// Endpoint returns the address and port Zabbix connects to, with IPv6
+// addresses bracketed, e.g. "[2001:db8::1]:10050".
+func (i *Interface) Endpoint() string {
+	addr := i.Address()
+	if i.Port == "" || i.Port == "0" || addr == "" {
+		return addr
+	}
+	return net.JoinHostPort(addr, i.Port)
+}
+
+// ErrorsSince returns when checks on the interface started failing.
+// Returns zero time if they are not failing.
+func (i *Interface) ErrorsSince() time.Time {
+	return parseUnix(i.ErrorsFrom)
+}
+
+// NextCheck returns when an unavailable interface is checked again.
+// Returns zero time if it is not known.
+func (i *Interface) NextCheck() time.Time {
+	return parseUnix(i.DisableUntil)
 }
 
 // HostGroup represents a Zabbix host group.
@@ -129,6 +231,42 @@ type Trigger struct {
 	Value       string `json:"value"`
 	URL         string `json:"url,omitempty"`
 	Comments    string `json:"comments,omitempty"`
+	ManualClose string `json:"manual_close,omitempty"` // 0=not allowed, 1=allowed
+	// Dependencies are the triggers this trigger depends on: it doesn't
+	// fire while any of them is in a problem state
+	Dependencies []Trigger   `json:"dependencies,omitempty"`
+	Groups       []HostGroup `json:"groups,omitempty"` // Host groups of the trigger's hosts
+	Hosts        []Host      `json:"hosts,omitempty"`
+	// Functions are the functions the expression references as {ID}
+	Functions []TriggerFunction `json:"functions,omitempty"`
+	Items     []Item            `json:"items,omitempty"` // Items of the functions
+}
+
+// TriggerFunction is a function of a trigger expression, such as
+// avg(/web01/system.cpu.util,5m), referenced in the raw expression by its ID.
+type TriggerFunction struct {
+	FunctionID string `json:"functionid"`
+	ItemID     string `json:"itemid"`
+	Function   string `json:"function"`  // e.g. "avg"
+	Parameter  string `json:"parameter"` // e.g. "$,5m" since Zabbix 5.4, "5m" before
+}
+
+// UnmarshalJSON decodes a trigger, accepting "hostgroups", the name of
+// "groups" since Zabbix 6.2.
+func (t *Trigger) UnmarshalJSON(data []byte) error {
+	type trigger Trigger // Without this method, to avoid recursion
+	aux := struct {
+		*trigger
+		HostGroups []HostGroup `json:"hostgroups"`
+	}{trigger: (*trigger)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if len(aux.HostGroups) > 0 {
+		t.Groups = aux.HostGroups
+	}
+	return nil
 }
 
 // HostCounts represents aggregated host status counts.
@@ -174,6 +312,33 @@ func (p *Problem) IsSuppressed() bool {
 	return p.Suppressed == "1"
 }
 
+// SuppressedUntil returns when the problem stops being suppressed, the
+// end of the last of its suppressions, and false if it is not suppressed.
+// The time is zero when a suppression has no end.
+func (p *Problem) SuppressedUntil() (time.Time, bool) {
+	if !p.IsSuppressed() {
+		return time.Time{}, false
+	}
+	var until int64
+	for _, s := range p.SuppressionData {
+		ts, _ := strconv.ParseInt(s.SuppressUntil, 10, 64)
+		if ts == 0 {
+			return time.Time{}, true
+		}
+		until = max(until, ts)
+	}
+	if until == 0 {
+		return time.Time{}, true
+	}
+	return time.Unix(until, 0), true
+}
+
+// AllowsManualClose returns true if the problem's trigger allows closing
+// it manually.
+func (p *Problem) AllowsManualClose() bool {
+	return p.RelatedObject.ManualClose == ManualCloseAllowed
+}
+
 // StartTime returns the problem start time.
 // Returns zero time if the clock string is empty or invalid.
 func (p *Problem) StartTime() time.Time {
@@ -205,26 +370,7 @@ func (p *Problem) DurationString() string {
 	if d <= 0 {
 		return "-"
 	}
-	return formatDuration(d)
-}
-
-// formatDuration formats a duration as a human-readable string.
-func formatDuration(d time.Duration) string {
-	if d < time.Minute {
-		return fmt.Sprintf("%ds", int(d.Seconds()))
-	}
-	if d < time.Hour {
-		return fmt.Sprintf("%dm", int(d.Minutes()))
-	}
-	if d < 24*time.Hour {
-		h := int(d.Hours())
-		m := int(d.Minutes()) % 60
-		return fmt.Sprintf("%dh %dm", h, m)
-	}
-
-	days := int(d.Hours()) / 24
-	hours := int(d.Hours()) % 24
-	return fmt.Sprintf("%dd %dh", days, hours)
+	return format.Duration(d)
 }
 
 // HostName returns the first host name associated with this problem.
@@ -238,10 +384,11 @@ func (p *Problem) HostName() string {
 	return "Unknown"
 }
 
-// HostIP returns the first host IP associated with this problem.
+// HostIP returns the address of the first host interface associated with
+// this problem.
 func (p *Problem) HostIP() string {
 	if len(p.Hosts) > 0 && len(p.Hosts[0].Interfaces) > 0 {
-		return p.Hosts[0].Interfaces[0].IP
+		return p.Hosts[0].Interfaces[0].Address()
 	}
 	return ""
 }
@@ -299,6 +446,20 @@ func (h *Host) IsAvailable() int {
 	return a
 }
 
+// MainInterface returns the first default interface of the host, or its
+// first interface if none is the default. Returns nil without interfaces.
+func (h *Host) MainInterface() *Interface {
+	for i := range h.Interfaces {
+		if h.Interfaces[i].Main == "1" {
+			return &h.Interfaces[i]
+		}
+	}
+	if len(h.Interfaces) > 0 {
+		return &h.Interfaces[0]
+	}
+	return nil
+}
+
 // DisplayName returns the visible name or falls back to technical name.
 func (h *Host) DisplayName() string {
 	if h.Name != "" {
@@ -307,9 +468,10 @@ func (h *Host) DisplayName() string {
 	return h.Host
 }
 
-// IsRecovery returns true if this is a recovery (OK) event.
+// IsRecovery returns true if this is a recovery (OK) event, or a problem
+// event that has been resolved.
 func (p *Problem) IsRecovery() bool {
-	return p.REventID != "" && p.REventID != "0"
+	return p.Value == EventValueOK || (p.REventID != "" && p.REventID != "0")
 }
 
 // RecoveryTime returns the recovery time if the problem was resolved.
@@ -350,7 +512,22 @@ func (p *Problem) ResolvedDurationString() string {
 	if d <= 0 {
 		return "-"
 	}
-	return formatDuration(d)
+	return format.Duration(d)
+}
+
+// AcknowledgedBy returns true if the user with the given ID has
+// acknowledged the problem.
+func (p *Problem) AcknowledgedBy(userID string) bool {
+	if userID == "" {
+		return false
+	}
+	for _, a := range p.Acknowledges {
+		action, _ := strconv.Atoi(a.Action)
+		if a.UserID == userID && action&ActionAcknowledge != 0 {
+			return true
+		}
+	}
+	return false
 }
 
 // Item represents a Zabbix item (metric).
@@ -359,19 +536,39 @@ type Item struct {
 	HostID    string `json:"hostid"`
 	Name      string `json:"name"`
 	Key       string `json:"key_"`
-	ValueType string `json:"value_type"` // 0=float, 3=unsigned int (numeric)
+	Type      string `json:"type,omitempty"` // How the value is collected, see ItemTypeAgent
+	ValueType string `json:"value_type"`     // See ItemValueTypeFloat
 	Units     string `json:"units"`
 	LastValue string `json:"lastvalue"`
 	LastClock string `json:"lastclock"`
-	State     string `json:"state"`  // 0=normal, 1=not supported
-	Status    string `json:"status"` // 0=enabled, 1=disabled
+	State     string `json:"state"`           // See ItemStateNormal
+	Status    string `json:"status"`          // 0=enabled, 1=disabled
+	Error     string `json:"error,omitempty"` // Why the item is not supported
 	Hosts     []Host `json:"hosts,omitempty"`
+
+	// InterfaceID is the interface a passive item is polled on
+	InterfaceID string `json:"interfaceid,omitempty"`
+
+	// ValueMapID is the value map of the item's values, "0" if none
+	ValueMapID string `json:"valuemapid,omitempty"`
+	// ValueMap is set by GetGraphItems for items with a value map
+	ValueMap *ValueMap `json:"-"`
+
+	// Params holds the formula of calculated items, see Formula
+	Params string `json:"params,omitempty"`
+
+	// Preprocessing is the item's preprocessing steps, in order
+	Preprocessing []PreprocessingStep `json:"preprocessing,omitempty"`
 }
 
-// ItemValueType constants for numeric items.
+// ItemValueType constants. The value type of an item is also the history
+// type of its values in history.get.
 const (
 	ItemValueTypeFloat    = "0" // Numeric (float)
+	ItemValueTypeChar     = "1" // Character, up to 255 bytes
+	ItemValueTypeLog      = "2" // Log lines
 	ItemValueTypeUnsigned = "3" // Numeric (unsigned)
+	ItemValueTypeText     = "4" // Text
 )
 
 // History represents a single history data point.
@@ -389,14 +586,37 @@ func (i *Item) IsNumeric() bool {
 	return i.ValueType == ItemValueTypeFloat || i.ValueType == ItemValueTypeUnsigned
 }
 
+// ItemStatus constants.
+const (
+	ItemStatusEnabled  = "0" // Item is collected
+	ItemStatusDisabled = "1" // Item is not collected
+)
+
 // IsEnabled returns true if the item is enabled.
 func (i *Item) IsEnabled() bool {
-	return i.Status == "0"
+	return i.Status == ItemStatusEnabled
 }
 
+// IsDisabled returns true if the item is disabled.
+func (i *Item) IsDisabled() bool {
+	return i.Status == ItemStatusDisabled
+}
+
+// ItemState constants.
+const (
+	ItemStateNormal       = "0" // Values are collected
+	ItemStateNotSupported = "1" // Values can't be collected, see Item.Error
+)
+
 // IsSupported returns true if the item is in normal state (not unsupported).
 func (i *Item) IsSupported() bool {
-	return i.State == "0"
+	return i.State == ItemStateNormal
+}
+
+// IsUnsupported returns true if the item's values can't be collected.
+// Items whose state was not fetched are not.
+func (i *Item) IsUnsupported() bool {
+	return i.State == ItemStateNotSupported
 }
 
 // LastValueFloat returns the last value as a float64.
@@ -416,6 +636,25 @@ func (i *Item) LastTime() time.Time {
 	return time.Unix(ts, 0)
 }
 
+// HasValueMap returns true if the item's values are mapped to text.
+func (i *Item) HasValueMap() bool {
+	return i.ValueMapID != "" && i.ValueMapID != "0"
+}
+
+// MappedValue returns a value with the text its value map maps it to, like
+// the Zabbix frontend shows it: "Up (1)". It returns empty if the value is
+// not mapped.
+func (i *Item) MappedValue(value string) string {
+	if i.ValueMap == nil {
+		return ""
+	}
+	text, ok := i.ValueMap.Map(value)
+	if !ok {
+		return ""
+	}
+	return text + " (" + value + ")"
+}
+
 // HostName returns the first host name associated with this item.
 func (i *Item) HostName() string {
 	if len(i.Hosts) > 0 {
@@ -447,6 +686,19 @@ func (h *History) Time() time.Time {
 	return time.Unix(ts, 0)
 }
 
+// After returns true if the value was collected after other, to the
+// nanosecond.
+func (h *History) After(other History) bool {
+	clock, _ := strconv.ParseInt(h.Clock, 10, 64)
+	otherClock, _ := strconv.ParseInt(other.Clock, 10, 64)
+	if clock != otherClock {
+		return clock > otherClock
+	}
+	ns, _ := strconv.ParseInt(h.NS, 10, 64)
+	otherNS, _ := strconv.ParseInt(other.NS, 10, 64)
+	return ns > otherNS
+}
+
 // ValueFloat returns the history value as a float64.
 // Returns 0 if the value string is empty or invalid.
 func (h *History) ValueFloat() float64 {
@@ -54,6 +54,31 @@ func TestProblem_IsAcknowledged(t *testing.T) {
 	}
 }
 
+func TestProblem_AcknowledgedBy(t *testing.T) {
+	p := Problem{Acknowledges: []Ack{
+		{UserID: "2", Action: "4"},  // Message only
+		{UserID: "3", Action: "6"},  // Acknowledged with a message
+		{UserID: "4", Action: "16"}, // Unacknowledged
+	}}
+
+	tests := []struct {
+		userID string
+		want   bool
+	}{
+		{"2", false},
+		{"3", true},
+		{"4", false},
+		{"5", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := p.AcknowledgedBy(tt.userID); got != tt.want {
+			t.Errorf("AcknowledgedBy(%q) = %v, want %v", tt.userID, got, tt.want)
+		}
+	}
+}
+
 func TestProblem_IsSuppressed(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -75,6 +100,46 @@ func TestProblem_IsSuppressed(t *testing.T) {
 	}
 }
 
+func TestProblem_SuppressedUntil(t *testing.T) {
+	tests := []struct {
+		name   string
+		p      Problem
+		want   int64 // Unix time; 0 for no end
+		wantOK bool
+	}{
+		{name: "not suppressed", p: Problem{Suppressed: "0"}},
+		{
+			name: "until the last end",
+			p: Problem{Suppressed: "1", SuppressionData: []Suppression{
+				{MaintenanceID: "4", SuppressUntil: "1700003600"},
+				{MaintenanceID: "0", SuppressUntil: "1700007200", UserID: "1"},
+			}},
+			want:   1700007200,
+			wantOK: true,
+		},
+		{
+			name: "indefinitely",
+			p: Problem{Suppressed: "1", SuppressionData: []Suppression{
+				{MaintenanceID: "0", SuppressUntil: "0", UserID: "1"},
+				{MaintenanceID: "4", SuppressUntil: "1700003600"},
+			}},
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until, ok := tt.p.SuppressedUntil()
+			if ok != tt.wantOK {
+				t.Fatalf("SuppressedUntil() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if want := time.Unix(tt.want, 0); tt.want == 0 && !until.IsZero() || tt.want != 0 && !until.Equal(want) {
+				t.Errorf("SuppressedUntil() = %v, want %v", until, want)
+			}
+		})
+	}
+}
+
 func TestProblem_StartTime(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -397,6 +462,72 @@ func TestHost_DisplayName(t *testing.T) {
 	}
 }
 
+func TestInterface_Endpoint(t *testing.T) {
+	tests := []struct {
+		name  string
+		iface Interface
+		want  string
+	}{
+		{
+			name:  "IPv4",
+			iface: Interface{IP: "192.168.1.1", UseIP: "1", Port: "10050"},
+			want:  "192.168.1.1:10050",
+		},
+		{
+			name:  "IPv6 bracketed with port",
+			iface: Interface{IP: "2001:db8::1", UseIP: "1", Port: "10050"},
+			want:  "[2001:db8::1]:10050",
+		},
+		{
+			name:  "IPv6 without port",
+			iface: Interface{IP: "2001:db8::1", UseIP: "1"},
+			want:  "2001:db8::1",
+		},
+		{
+			name:  "DNS name when not using the IP",
+			iface: Interface{IP: "192.168.1.1", DNS: "web01.example.com", UseIP: "0", Port: "161"},
+			want:  "web01.example.com:161",
+		},
+		{
+			name:  "IP when using it despite a DNS name",
+			iface: Interface{IP: "192.168.1.1", DNS: "web01.example.com", UseIP: "1", Port: "161"},
+			want:  "192.168.1.1:161",
+		},
+		{
+			name:  "DNS name when there is no IP",
+			iface: Interface{DNS: "web01.example.com", Port: "0"},
+			want:  "web01.example.com",
+		},
+		{
+			name:  "port macro",
+			iface: Interface{IP: "::1", UseIP: "1", Port: "{$AGENT.PORT}"},
+			want:  "[::1]:{$AGENT.PORT}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.iface.Endpoint(); got != tt.want {
+				t.Errorf("Endpoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHost_MainInterface(t *testing.T) {
+	h := Host{Interfaces: []Interface{{InterfaceID: "1"}, {InterfaceID: "2", Main: "1"}}}
+	if iface := h.MainInterface(); iface == nil || iface.InterfaceID != "2" {
+		t.Errorf("MainInterface() = %+v, want interface 2", iface)
+	}
+	h.Interfaces[1].Main = "0"
+	if iface := h.MainInterface(); iface == nil || iface.InterfaceID != "1" {
+		t.Errorf("MainInterface() = %+v, want the first interface", iface)
+	}
+	if iface := (&Host{}).MainInterface(); iface != nil {
+		t.Errorf("MainInterface() = %+v, want nil without interfaces", iface)
+	}
+}
+
 // Helper functions
 
 func itoa(n int64) string {
@@ -0,0 +1,150 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Value map mapping types, since Zabbix 6.0. Earlier mappings have no type
+// and all match values exactly.
+const (
+	MappingEqual          = "0"
+	MappingGreaterOrEqual = "1"
+	MappingLessOrEqual    = "2"
+	MappingRange          = "3"
+	MappingRegexp         = "4"
+	MappingDefault        = "5"
+)
+
+// ValueMap represents a Zabbix value map, turning item values into text
+// like "Up" and "Down".
+type ValueMap struct {
+	ValueMapID string         `json:"valuemapid"`
+	Name       string         `json:"name"`
+	Mappings   []ValueMapping `json:"mappings"`
+}
+
+// ValueMapping maps the values it matches to NewValue.
+type ValueMapping struct {
+	Type     string `json:"type,omitempty"` // See MappingEqual
+	Value    string `json:"value"`
+	NewValue string `json:"newvalue"`
+}
+
+// Map returns the text a value is mapped to by the first mapping matching
+// it, or by the default mapping if none does, and false if the value is
+// not mapped.
+func (vm *ValueMap) Map(value string) (string, bool) {
+	num, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	numeric := err == nil
+	def, hasDefault := "", false
+	for _, mp := range vm.Mappings {
+		if mp.Type == MappingDefault {
+			def, hasDefault = mp.NewValue, true
+			continue
+		}
+		if mp.matches(value, num, numeric) {
+			return mp.NewValue, true
+		}
+	}
+	return def, hasDefault
+}
+
+// matches returns true if the mapping matches a value, given as text and,
+// if numeric, as a number.
+func (mp ValueMapping) matches(value string, num float64, numeric bool) bool {
+	switch mp.Type {
+	case MappingGreaterOrEqual, MappingLessOrEqual:
+		bound, err := strconv.ParseFloat(mp.Value, 64)
+		if !numeric || err != nil {
+			return false
+		}
+		if mp.Type == MappingGreaterOrEqual {
+			return num >= bound
+		}
+		return num <= bound
+	case MappingRange:
+		return numeric && inRanges(mp.Value, num)
+	case MappingRegexp:
+		re, err := regexp.Compile(mp.Value)
+		return err == nil && re.MatchString(value)
+	}
+	if v, err := strconv.ParseFloat(mp.Value, 64); numeric && err == nil {
+		return v == num
+	}
+	return mp.Value == value
+}
+
+// inRanges returns true if num is in one of comma-separated ranges like
+// "1-10,20,-5--1".
+func inRanges(ranges string, num float64) bool {
+	for _, r := range strings.Split(ranges, ",") {
+		r = strings.TrimSpace(r)
+		lo, hi := r, r
+		// Skip a leading minus sign when looking for the separator
+		if i := strings.Index(r[min(1, len(r)):], "-"); i >= 0 {
+			lo, hi = r[:i+1], r[i+2:]
+		}
+		from, err1 := strconv.ParseFloat(strings.TrimSpace(lo), 64)
+		to, err2 := strconv.ParseFloat(strings.TrimSpace(hi), 64)
+		if err1 == nil && err2 == nil && num >= from && num <= to {
+			return true
+		}
+	}
+	return false
+}
+
+// valueMapGetParams defines parameters for valuemap.get API call.
+type valueMapGetParams struct {
+	Output         interface{} `json:"output,omitempty"`
+	SelectMappings interface{} `json:"selectMappings,omitempty"`
+	ValueMapIDs    []string    `json:"valuemapids,omitempty"`
+}
+
+// GetValueMaps retrieves value maps by ID, keyed by ID.
+func (c *Client) GetValueMaps(ctx context.Context, valueMapIDs []string) (map[string]*ValueMap, error) {
+	params := valueMapGetParams{
+		Output:         []string{"valuemapid", "name"},
+		SelectMappings: "extend",
+		ValueMapIDs:    valueMapIDs,
+	}
+
+	var valueMaps []ValueMap
+	if err := c.call(ctx, "valuemap.get", params, &valueMaps); err != nil {
+		return nil, fmt.Errorf("failed to get value maps: %w", err)
+	}
+	byID := make(map[string]*ValueMap, len(valueMaps))
+	for i := range valueMaps {
+		byID[valueMaps[i].ValueMapID] = &valueMaps[i]
+	}
+	return byID, nil
+}
+
+// resolveValueMaps sets the value maps of items using one.
+func (c *Client) resolveValueMaps(ctx context.Context, items []Item) error {
+	var ids []string
+	seen := map[string]bool{}
+	for _, item := range items {
+		if item.HasValueMap() && !seen[item.ValueMapID] {
+			seen[item.ValueMapID] = true
+			ids = append(ids, item.ValueMapID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	valueMaps, err := c.GetValueMaps(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		if items[i].HasValueMap() {
+			items[i].ValueMap = valueMaps[items[i].ValueMapID]
+		}
+	}
+	return nil
+}
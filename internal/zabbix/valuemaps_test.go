@@ -0,0 +1,73 @@
+package zabbix
+
+import (
+	"context"
+	"testing"
+)
+
+func TestValueMap_Map(t *testing.T) {
+	vm := ValueMap{Mappings: []ValueMapping{
+		{Value: "1", NewValue: "Up"},
+		{Type: MappingEqual, Value: "2", NewValue: "Down"},
+		{Type: MappingRange, Value: "3-5,-10--8", NewValue: "Degraded"},
+		{Type: MappingGreaterOrEqual, Value: "100", NewValue: "High"},
+		{Type: MappingLessOrEqual, Value: "-100", NewValue: "Low"},
+		{Type: MappingRegexp, Value: "^err", NewValue: "Error"},
+		{Type: MappingDefault, NewValue: "Other"},
+	}}
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"1", "Up"},
+		{"1.0", "Up"},
+		{"2", "Down"},
+		{"4", "Degraded"},
+		{"-9", "Degraded"},
+		{"150", "High"},
+		{"-200", "Low"},
+		{"error: timeout", "Error"},
+		{"7", "Other"},
+	}
+	for _, tt := range tests {
+		if got, ok := vm.Map(tt.value); !ok || got != tt.want {
+			t.Errorf("Map(%q) = %q, %v, want %q", tt.value, got, ok, tt.want)
+		}
+	}
+
+	vm.Mappings = vm.Mappings[:1]
+	if got, ok := vm.Map("0"); ok {
+		t.Errorf("Map(0) = %q, want no mapping without a default", got)
+	}
+}
+
+func TestClient_GetGraphItems_ValueMaps(t *testing.T) {
+	params := map[string]map[string]any{}
+	server := newRecordingMockServer(t, map[string]mockResponse{
+		"item.get": {Result: []Item{
+			{ItemID: "1", Key: "net.if.status[1]", ValueType: ItemValueTypeUnsigned, LastValue: "2", ValueMapID: "5"},
+			{ItemID: "2", Key: "net.if.in[1]", ValueType: ItemValueTypeUnsigned, LastValue: "2", ValueMapID: "0"},
+		}},
+		"valuemap.get": {Result: []ValueMap{
+			{ValueMapID: "5", Name: "ifOperStatus", Mappings: []ValueMapping{{Value: "1", NewValue: "up"}, {Value: "2", NewValue: "down"}}},
+		}},
+	}, params)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	items, err := client.GetGraphItems(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetGraphItems() error = %v", err)
+	}
+
+	if ids, _ := params["valuemap.get"]["valuemapids"].([]any); len(ids) != 1 || ids[0] != "5" {
+		t.Errorf("valuemap.get params = %v, want value map 5 only", params["valuemap.get"])
+	}
+	if got := items[0].MappedValue(items[0].LastValue); got != "down (2)" {
+		t.Errorf("MappedValue() = %q, want %q", got, "down (2)")
+	}
+	if got := items[1].MappedValue(items[1].LastValue); got != "" {
+		t.Errorf("MappedValue() = %q, want empty without a value map", got)
+	}
+}